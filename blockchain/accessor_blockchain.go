@@ -6,11 +6,14 @@ import (
 
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/privacy"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 )
 
 //get beacon block hash by height, with current view
@@ -64,6 +67,11 @@ func (blockchain *BlockChain) GetBeaconBlockHashByView(view multiview.View, heig
 	return rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), height)
 }
 
+// GetFinalBeaconHeight returns the height of the final view of the beacon chain
+func (blockchain *BlockChain) GetFinalBeaconHeight() uint64 {
+	return blockchain.BeaconChain.GetFinalViewHeight()
+}
+
 func (blockchain *BlockChain) GetBeaconBlockByHeightV1(height uint64) (*BeaconBlock, error) {
 	beaconBlocks, err := blockchain.GetBeaconBlockByHeight(height)
 	if err != nil {
@@ -212,6 +220,44 @@ func (blockchain *BlockChain) GetShardBlockByView(view multiview.View, height ui
 	return shardBlock, err
 }
 
+// GetFinalShardHeight returns the height of the final view of a shard chain
+func (blockchain *BlockChain) GetFinalShardHeight(shardID byte) uint64 {
+	return blockchain.ShardChain[shardID].GetFinalViewHeight()
+}
+
+// GetShardCommitteeRewardReceiversByEpoch returns the committee of every shard at the last beacon height of epoch,
+// which the shard rewards of the epoch are split among, and the reward receivers of the committees mapped by committee public key
+func (blockchain *BlockChain) GetShardCommitteeRewardReceiversByEpoch(epoch uint64) (map[int][]incognitokey.CommitteePublicKey, map[string]privacy.PaymentAddress, error) {
+	height := epoch * blockchain.config.ChainParams.Epoch
+	beaconConsensusRootHash, err := blockchain.GetBeaconConsensusRootHash(blockchain.GetBeaconBestState(), height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Beacon Consensus Root Hash of Height %+v not found ,error %+v", height, err)
+	}
+	beaconConsensusStateDB, err := statedb.NewWithPrefixTrie(beaconConsensusRootHash, statedb.NewDatabaseAccessWarper(blockchain.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, nil, err
+	}
+	committees := statedb.GetAllShardCommittee(beaconConsensusStateDB, blockchain.GetShardIDs())
+	rewardReceivers := make(map[string]privacy.PaymentAddress)
+	for _, committee := range committees {
+		for _, committeePublicKey := range committee {
+			keyStr, err := committeePublicKey.ToBase58()
+			if err != nil {
+				return nil, nil, err
+			}
+			stakerInfo, has, err := statedb.GetStakerInfo(beaconConsensusStateDB, keyStr)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !has || stakerInfo == nil {
+				return nil, nil, fmt.Errorf("Staker info of committee %+v not found", keyStr)
+			}
+			rewardReceivers[keyStr] = stakerInfo.RewardReceiver()
+		}
+	}
+	return committees, rewardReceivers, nil
+}
+
 func (blockchain *BlockChain) GetShardBlockByHeightV1(height uint64, shardID byte) (*ShardBlock, error) {
 	res, err := blockchain.GetShardBlockByHeight(height, shardID)
 	if err != nil {
@@ -350,10 +396,70 @@ func (blockchain *BlockChain) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return blockchain.GetConfig().BTCChain
 }
 
+func (blockchain *BlockChain) GetETHHeaderChain() *ethrelaying.HeaderChain {
+	return blockchain.GetConfig().ETHChain
+}
+
+// IsETHRelayingEnabled returns true if ETH issuance reads headers from the relayed ETH header chain at beaconHeight,
+// the beacon height of the block being produced or validated. It depends on beaconHeight only so that all nodes agree
+func (blockchain *BlockChain) IsETHRelayingEnabled(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.GetConfig().ChainParams.BCHeightBreakPointETHRelaying
+}
+
+// IsLTCRelayingEnabled returns true if a pLTC token is supported at beaconHeight, LTC headers are relayed from then on
+func (blockchain *BlockChain) IsLTCRelayingEnabled(beaconHeight uint64) bool {
+	params := blockchain.GetConfig().ChainParams
+	for _, tokenID := range params.GetPortalTokenIDs(beaconHeight) {
+		if _, ok := params.PortalTokens[tokenID].(*PortalLTCTokenProcessor); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidPortalRemoteAddress checks remoteAddress by the processor of the portal token
+func (blockchain *BlockChain) IsValidPortalRemoteAddress(tokenIDStr string, remoteAddress string) (bool, error) {
+	portalTokenProcessor, ok := blockchain.GetConfig().ChainParams.PortalTokens[tokenIDStr]
+	if !ok || portalTokenProcessor == nil {
+		return false, fmt.Errorf("portal token %v is not supported", tokenIDStr)
+	}
+	return portalTokenProcessor.IsValidRemoteAddress(remoteAddress, blockchain)
+}
+
+// IsPortalToken returns true if the portal token is supported at beaconHeight
+func (blockchain *BlockChain) IsPortalToken(beaconHeight uint64, tokenIDStr string) bool {
+	_, ok := blockchain.GetConfig().ChainParams.GetPortalTokenProcessor(tokenIDStr, beaconHeight)
+	return ok
+}
+
+// GetMinAmountPortalToken returns the min amount of ptoken in porting and redeem requests of the portal token
+func (blockchain *BlockChain) GetMinAmountPortalToken(tokenIDStr string, beaconHeight uint64) (uint64, error) {
+	portalTokenProcessor, ok := blockchain.GetConfig().ChainParams.GetPortalTokenProcessor(tokenIDStr, beaconHeight)
+	if !ok {
+		return 0, fmt.Errorf("portal token %v is not supported", tokenIDStr)
+	}
+	return portalTokenProcessor.GetMinAmount(), nil
+}
+
 func (blockchain *BlockChain) GetPortalFeederAddress() string {
 	return blockchain.GetConfig().ChainParams.PortalFeederAddress
 }
 
+// GetPortalFeederAddresses returns the feeders authorized to submit exchange rates at beaconHeight,
+// there is only the single feeder before BCHeightBreakPointPortalFeeders
+func (blockchain *BlockChain) GetPortalFeederAddresses(beaconHeight uint64) []string {
+	chainParams := blockchain.GetConfig().ChainParams
+	if !blockchain.IsPortalFeedersEnabled(beaconHeight) || len(chainParams.PortalFeederAddresses) == 0 {
+		return []string{chainParams.PortalFeederAddress}
+	}
+	return chainParams.PortalFeederAddresses
+}
+
+// IsPortalFeedersEnabled returns true if final exchange rates are aggregated from the feeders' history at beaconHeight
+func (blockchain *BlockChain) IsPortalFeedersEnabled(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.GetConfig().ChainParams.BCHeightBreakPointPortalFeeders
+}
+
 func (blockchain *BlockChain) GetBeaconRootsHashFromBlockHeight(height uint64) (*BeaconRootHash, error) {
 	h, e := blockchain.GetBeaconBlockHashByHeight(blockchain.BeaconChain.GetFinalView(), blockchain.BeaconChain.GetBestView(), height)
 	if e != nil {
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"sync"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/tracing"
 )
 
 type BeaconChain struct {
@@ -161,8 +163,11 @@ func (chain *BeaconChain) GetLastProposerIndex() int {
 	return chain.multiView.GetBestView().(*BeaconBestState).BeaconProposerIndex
 }
 
-func (chain *BeaconChain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+func (chain *BeaconChain) CreateNewBlock(ctx context.Context, version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+	_, span := tracing.StartSpan(ctx, "beacon.NewBlockBeacon", "height", chain.CurrentHeight()+1, "round", round)
 	newBlock, err := chain.Blockchain.NewBlockBeacon(chain.GetBestView().(*BeaconBestState), version, proposer, round, startTime)
+	span.SetError(err)
+	span.End()
 	if err != nil {
 		return nil, err
 	}
@@ -198,9 +203,13 @@ func (chain *BeaconChain) CheckExistedBlk(block common.BlockInterface) bool {
 	return err == nil
 }
 
-func (chain *BeaconChain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+func (chain *BeaconChain) InsertAndBroadcastBlock(ctx context.Context, block common.BlockInterface) error {
 	go chain.Blockchain.config.Server.PushBlockToAll(block, true)
-	if err := chain.Blockchain.InsertBeaconBlock(block.(*BeaconBlock), false); err != nil {
+	_, span := tracing.StartSpan(ctx, "beacon.InsertBlock", "height", block.GetHeight(), "hash", block.Hash().String())
+	err := chain.Blockchain.InsertBeaconBlock(block.(*BeaconBlock), false)
+	span.SetError(err)
+	span.End()
+	if err != nil {
 		Logger.log.Info(err)
 		return err
 	}
@@ -216,8 +225,11 @@ func (chain *BeaconChain) GetChainName() string {
 	return chain.ChainName
 }
 
-func (chain *BeaconChain) ValidatePreSignBlock(block common.BlockInterface) error {
+func (chain *BeaconChain) ValidatePreSignBlock(ctx context.Context, block common.BlockInterface) error {
+	_, span := tracing.StartSpan(ctx, "beacon.VerifyPreSignBlock", "height", block.GetHeight())
 	err := chain.Blockchain.VerifyPreSignBeaconBlock(block.(*BeaconBlock), true)
+	span.SetError(err)
+	span.End()
 	if err != nil {
 		Logger.log.Error("ValidatePreSignBlock Beacon", err)
 	}
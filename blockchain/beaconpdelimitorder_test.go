@@ -0,0 +1,278 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLimitOrderTestBlockChain() *BlockChain {
+	return &BlockChain{
+		config: Config{
+			ChainParams: &Params{
+				BCHeightBreakPointPDELimitOrders: 50,
+				MaxPDELimitOrdersPerPair:         3,
+				MaxPDELimitOrdersPerTrader:       2,
+				MaxPDELimitOrderLifetime:         100,
+			},
+		},
+	}
+}
+
+func newLimitOrderTestPDEState(beaconHeight uint64) *CurrentPDEState {
+	currentPDEState := newQuoteTestPDEState(beaconHeight)
+	currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	currentPDEState.DeletedPDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	return currentPDEState
+}
+
+func buildPDELimitOrderTestAction(
+	txReqID common.Hash,
+	traderAddressStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	expiryBeaconHeight uint64,
+) []string {
+	tokenIDToBuyStr := common.PRVIDStr
+	if tokenIDToSellStr == common.PRVIDStr {
+		tokenIDToBuyStr = quoteTestTokenAIDStr
+	}
+	action := metadata.PDELimitOrderRequestAction{
+		Meta: metadata.PDELimitOrderRequest{
+			TokenIDToBuyStr:     tokenIDToBuyStr,
+			TokenIDToSellStr:    tokenIDToSellStr,
+			SellAmount:          sellAmount,
+			MinAcceptableAmount: minAcceptableAmount,
+			TradingFee:          tradingFee,
+			TraderAddressStr:    traderAddressStr,
+			ExpiryBeaconHeight:  expiryBeaconHeight,
+			MetadataBase:        metadata.MetadataBase{Type: metadata.PDELimitOrderRequestMeta},
+		},
+		TxReqID: txReqID,
+		ShardID: 1,
+	}
+	actionBytes, _ := json.Marshal(action)
+	return []string{strconv.Itoa(metadata.PDELimitOrderRequestMeta), base64.StdEncoding.EncodeToString(actionBytes)}
+}
+
+func buildPDECancelLimitOrderTestAction(orderID common.Hash, traderAddressStr string) []string {
+	action := metadata.PDECancelLimitOrderRequestAction{
+		Meta: metadata.PDECancelLimitOrderRequest{
+			OrderID:          orderID.String(),
+			TraderAddressStr: traderAddressStr,
+			MetadataBase:     metadata.MetadataBase{Type: metadata.PDECancelLimitOrderRequestMeta},
+		},
+		TxReqID: common.HashH([]byte("cancel-" + orderID.String())),
+		ShardID: 1,
+	}
+	actionBytes, _ := json.Marshal(action)
+	return []string{strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta), base64.StdEncoding.EncodeToString(actionBytes)}
+}
+
+func limitOrderTestOrderID(i int) common.Hash {
+	return common.HashH([]byte("limit-order-" + strconv.Itoa(i)))
+}
+
+func TestCalcLimitOrderFillAmount(t *testing.T) {
+	// PRV/A pool with 1M PRV and 2M A, selling A for PRV
+	poolPair := rawdbv2.NewPDEPoolForPair(common.PRVIDStr, 1000000, quoteTestTokenAIDStr, 2000000)
+	tests := []struct {
+		name                string
+		minAcceptableAmount uint64
+		remainingSellAmount uint64
+		wantSellAmount      uint64
+		wantReceiveAmount   uint64
+	}{
+		{"fully filled", 4900, 10000, 10000, 4975},
+		{"partially filled", 4990, 10000, 3170, 1582},
+		{"limit price at the spot price", 5000, 10000, 0, 0},
+		{"limit price above the spot price", 6000, 10000, 0, 0},
+		{"nothing remaining", 4900, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := rawdbv2.NewPDELimitOrder(limitOrderTestOrderID(0), "trader", common.PRVIDStr, quoteTestTokenAIDStr,
+				10000, tt.minAcceptableAmount, tt.remainingSellAmount, 0, 0, 200, 1)
+			sellAmount, receiveAmount := calcLimitOrderFillAmount(poolPair, order)
+			assert.Equal(t, tt.wantSellAmount, sellAmount)
+			assert.Equal(t, tt.wantReceiveAmount, receiveAmount)
+		})
+	}
+}
+
+func TestBuildInstsForNewPDELimitOrders(t *testing.T) {
+	bc := newLimitOrderTestBlockChain()
+	beaconHeight := uint64(100)
+	tests := []struct {
+		name         string
+		beaconHeight uint64
+		action       []string
+		wantStatus   string
+	}{
+		{"accepted", beaconHeight, buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader", quoteTestTokenAIDStr, 10000, 6000, 10, beaconHeight+10), common.PDELimitOrderAcceptedChainStatus},
+		{"before the break point", 48, buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader", quoteTestTokenAIDStr, 10000, 6000, 10, 58), common.PDELimitOrderFeeRefundChainStatus},
+		{"expired", beaconHeight, buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader", quoteTestTokenAIDStr, 10000, 6000, 10, beaconHeight), common.PDELimitOrderFeeRefundChainStatus},
+		{"expiry too far", beaconHeight, buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader", quoteTestTokenAIDStr, 10000, 6000, 10, beaconHeight+102), common.PDELimitOrderFeeRefundChainStatus},
+		{"missing pool", beaconHeight, buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader", quoteTestTokenCIDStr, 10000, 6000, 10, beaconHeight+10), common.PDELimitOrderFeeRefundChainStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentPDEState := newLimitOrderTestPDEState(tt.beaconHeight)
+			insts := bc.buildInstsForNewPDELimitOrders(currentPDEState, tt.beaconHeight, map[byte][][]string{1: {tt.action}})
+			assert.NotEmpty(t, insts)
+			assert.Equal(t, tt.wantStatus, insts[0][2])
+			if tt.wantStatus == common.PDELimitOrderAcceptedChainStatus {
+				assert.Equal(t, 1, len(currentPDEState.PDELimitOrders))
+			} else {
+				// the trading fee and the selling token are refunded
+				assert.Equal(t, 2, len(insts))
+				assert.Equal(t, common.PDELimitOrderSellingTokenRefundChainStatus, insts[1][2])
+				assert.Empty(t, currentPDEState.PDELimitOrders)
+			}
+		})
+	}
+}
+
+func TestBuildInstsForNewPDELimitOrders_Caps(t *testing.T) {
+	bc := newLimitOrderTestBlockChain()
+	beaconHeight := uint64(100)
+	currentPDEState := newLimitOrderTestPDEState(beaconHeight)
+	actions := [][]string{
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader-1", quoteTestTokenAIDStr, 10000, 6000, 0, beaconHeight+10),
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(2), "trader-1", quoteTestTokenAIDStr, 10000, 6000, 0, beaconHeight+10),
+		// over the cap of the trader
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(3), "trader-1", quoteTestTokenAIDStr, 10000, 6000, 0, beaconHeight+10),
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(4), "trader-2", common.PRVIDStr, 10000, 30000, 0, beaconHeight+10),
+		// over the cap of the pool pair
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(5), "trader-3", quoteTestTokenAIDStr, 10000, 6000, 0, beaconHeight+10),
+		// another pool pair
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(6), "trader-3", quoteTestTokenBIDStr, 10000, 60000, 0, beaconHeight+10),
+	}
+	insts := bc.buildInstsForNewPDELimitOrders(currentPDEState, beaconHeight, map[byte][][]string{1: actions})
+	statuses := []string{}
+	for _, inst := range insts {
+		statuses = append(statuses, inst[2])
+	}
+	assert.Equal(t, []string{
+		common.PDELimitOrderAcceptedChainStatus,
+		common.PDELimitOrderAcceptedChainStatus,
+		common.PDELimitOrderSellingTokenRefundChainStatus,
+		common.PDELimitOrderAcceptedChainStatus,
+		common.PDELimitOrderSellingTokenRefundChainStatus,
+		common.PDELimitOrderAcceptedChainStatus,
+	}, statuses)
+	assert.Equal(t, 4, len(currentPDEState.PDELimitOrders))
+}
+
+func TestHandlePDELimitOrderInsts(t *testing.T) {
+	bc := newLimitOrderTestBlockChain()
+	beaconHeight := uint64(100)
+	currentPDEState := newLimitOrderTestPDEState(beaconHeight)
+	restingOrders := []*rawdbv2.PDELimitOrder{
+		// filled at once
+		rawdbv2.NewPDELimitOrder(limitOrderTestOrderID(1), "trader-1", common.PRVIDStr, quoteTestTokenAIDStr, 10000, 4900, 10000, 0, 7, 200, 1),
+		// not filled, expiring at the new beacon block
+		rawdbv2.NewPDELimitOrder(limitOrderTestOrderID(2), "trader-2", common.PRVIDStr, quoteTestTokenAIDStr, 10000, 6000, 10000, 0, 3, beaconHeight+1, 1),
+		// cancelled
+		rawdbv2.NewPDELimitOrder(limitOrderTestOrderID(3), "trader-3", common.PRVIDStr, quoteTestTokenAIDStr, 10000, 6000, 10000, 0, 0, 200, 1),
+		// resting
+		rawdbv2.NewPDELimitOrder(limitOrderTestOrderID(4), "trader-4", common.PRVIDStr, quoteTestTokenAIDStr, 10000, 6000, 10000, 0, 0, 200, 1),
+	}
+	for _, order := range restingOrders {
+		currentPDEState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, order.OrderID.String()))] = order
+	}
+	cancelActions := map[byte][][]string{1: {
+		buildPDECancelLimitOrderTestAction(limitOrderTestOrderID(3), "trader-3"),
+		// only the owner can cancel an order
+		buildPDECancelLimitOrderTestAction(limitOrderTestOrderID(4), "trader-3"),
+	}}
+	tradingFeeByPair := map[string]uint64{}
+	insts := bc.handlePDELimitOrderInsts(currentPDEState, beaconHeight, map[byte][][]string{}, cancelActions, tradingFeeByPair)
+
+	statuses := []string{}
+	for _, inst := range insts {
+		statuses = append(statuses, inst[2])
+	}
+	assert.Equal(t, []string{
+		common.PDECancelLimitOrderAcceptedChainStatus,
+		common.PDELimitOrderSellingTokenRefundChainStatus,
+		common.PDECancelLimitOrderRejectedChainStatus,
+		common.PDELimitOrderFilledChainStatus,
+		common.PDELimitOrderFeeRefundChainStatus,
+		common.PDELimitOrderSellingTokenRefundChainStatus,
+	}, statuses)
+
+	var filledContent metadata.PDELimitOrderFilledContent
+	assert.Nil(t, json.Unmarshal([]byte(insts[3][3]), &filledContent))
+	assert.Equal(t, limitOrderTestOrderID(1), filledContent.OrderID)
+	assert.Equal(t, uint64(10000), filledContent.SellAmount)
+	assert.Equal(t, uint64(4975), filledContent.ReceiveAmount)
+	assert.Equal(t, uint64(7), filledContent.AddingFee)
+
+	pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, common.PRVIDStr, quoteTestTokenAIDStr))
+	assert.Equal(t, uint64(1000000-4975), currentPDEState.PDEPoolPairs[pairKey].Token1PoolValue)
+	assert.Equal(t, uint64(2010000), currentPDEState.PDEPoolPairs[pairKey].Token2PoolValue)
+	sharesKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, common.PRVIDStr, quoteTestTokenAIDStr, ""))
+	assert.Equal(t, map[string]uint64{sharesKey: 7}, tradingFeeByPair)
+
+	// only the order of trader-4 is resting
+	assert.Equal(t, 1, len(currentPDEState.PDELimitOrders))
+	_, found := currentPDEState.PDELimitOrders[string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, limitOrderTestOrderID(4).String()))]
+	assert.True(t, found)
+}
+
+func TestProcessPDELimitOrder(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, _ := incdb.Open("leveldb", dbPath)
+	stateDB, _ := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+
+	bc := newLimitOrderTestBlockChain()
+	beaconHeight := uint64(100)
+	producerState := newLimitOrderTestPDEState(beaconHeight)
+	processState := newLimitOrderTestPDEState(beaconHeight)
+	actions := [][]string{
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(1), "trader-1", quoteTestTokenAIDStr, 10000, 4990, 5, beaconHeight+10),
+		buildPDELimitOrderTestAction(limitOrderTestOrderID(2), "trader-2", quoteTestTokenAIDStr, 10000, 6000, 0, beaconHeight+200),
+	}
+	insts := bc.handlePDELimitOrderInsts(producerState, beaconHeight, map[byte][][]string{1: actions}, map[byte][][]string{}, map[string]uint64{})
+	for _, inst := range insts {
+		assert.Nil(t, bc.processPDELimitOrder(stateDB, beaconHeight, inst, processState))
+	}
+	// the producer and the processor end with the same pde state
+	assert.Equal(t, producerState.PDEPoolPairs, processState.PDEPoolPairs)
+	assert.Equal(t, producerState.PDELimitOrders, processState.PDELimitOrders)
+
+	orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, limitOrderTestOrderID(1).String()))
+	order := processState.PDELimitOrders[orderKey]
+	assert.NotNil(t, order)
+	assert.Equal(t, uint64(10000-3170), order.RemainingSellAmount)
+	assert.Equal(t, uint64(1582), order.ReceivedAmount)
+	assert.Equal(t, uint64(0), order.TradingFee)
+
+	orderIDs := []common.Hash{limitOrderTestOrderID(1), limitOrderTestOrderID(2)}
+	status, err := statedb.GetPDEStatus(stateDB, rawdbv2.PDELimitOrderStatusPrefix, orderIDs[1-1][:])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(common.PDELimitOrderPartiallyFilledStatus), status)
+	status, err = statedb.GetPDEStatus(stateDB, rawdbv2.PDELimitOrderStatusPrefix, orderIDs[2-1][:])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(common.PDELimitOrderRejectedStatus), status)
+	assert.Empty(t, processState.DeletedPDELimitOrders)
+}
@@ -0,0 +1,151 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processPDELimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if currentPDEState.PDELimitOrders == nil {
+		currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	if currentPDEState.DeletedPDELimitOrders == nil {
+		currentPDEState.DeletedPDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	switch instruction[2] {
+	case common.PDELimitOrderAcceptedChainStatus:
+		var acceptedContent metadata.PDELimitOrderAcceptedContent
+		err := json.Unmarshal([]byte(instruction[3]), &acceptedContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order accepted instruction: %+v", err)
+			return nil
+		}
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, acceptedContent.OrderID.String()))
+		currentPDEState.PDELimitOrders[orderKey] = rawdbv2.NewPDELimitOrder(
+			acceptedContent.OrderID,
+			acceptedContent.TraderAddressStr,
+			acceptedContent.TokenIDToBuyStr,
+			acceptedContent.TokenIDToSellStr,
+			acceptedContent.SellAmount,
+			acceptedContent.MinAcceptableAmount,
+			acceptedContent.SellAmount,
+			0,
+			acceptedContent.TradingFee,
+			acceptedContent.ExpiryBeaconHeight,
+			acceptedContent.ShardID,
+		)
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			acceptedContent.OrderID[:],
+			byte(common.PDELimitOrderOpenStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde open limit order status: %+v", err)
+		}
+
+	case common.PDELimitOrderFilledChainStatus:
+		var filledContent metadata.PDELimitOrderFilledContent
+		err := json.Unmarshal([]byte(instruction[3]), &filledContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order filled instruction: %+v", err)
+			return nil
+		}
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, filledContent.OrderID.String()))
+		order, found := currentPDEState.PDELimitOrders[orderKey]
+		if !found || order == nil {
+			Logger.log.Errorf("WARNING: could not find out pde limit order %s", filledContent.OrderID.String())
+			return nil
+		}
+		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, filledContent.Token1IDStr, filledContent.Token2IDStr))
+		pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
+		if !found || pdePoolForPair == nil {
+			Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", filledContent.Token1IDStr, filledContent.Token2IDStr)
+			return nil
+		}
+		if filledContent.Token1PoolValueOperation.Operator == "+" {
+			pdePoolForPair.Token1PoolValue += filledContent.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue -= filledContent.Token2PoolValueOperation.Value
+		} else {
+			pdePoolForPair.Token1PoolValue -= filledContent.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue += filledContent.Token2PoolValueOperation.Value
+		}
+		order.RemainingSellAmount -= filledContent.SellAmount
+		order.ReceivedAmount += filledContent.ReceiveAmount
+		order.TradingFee = 0
+
+		orderStatus := common.PDELimitOrderPartiallyFilledStatus
+		if order.RemainingSellAmount == 0 {
+			orderStatus = common.PDELimitOrderFilledStatus
+			delete(currentPDEState.PDELimitOrders, orderKey)
+			currentPDEState.DeletedPDELimitOrders[orderKey] = order
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			filledContent.OrderID[:],
+			byte(orderStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde filled limit order status: %+v", err)
+		}
+
+	case common.PDELimitOrderFeeRefundChainStatus, common.PDELimitOrderSellingTokenRefundChainStatus:
+		var refundContent metadata.PDERefundLimitOrder
+		err := json.Unmarshal([]byte(instruction[3]), &refundContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde refund limit order instruction: %+v", err)
+			return nil
+		}
+		// an order is refunded when it is cancelled, expired or rejected, it is closed from then
+		orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, refundContent.OrderID.String()))
+		if order, found := currentPDEState.PDELimitOrders[orderKey]; found {
+			delete(currentPDEState.PDELimitOrders, orderKey)
+			currentPDEState.DeletedPDELimitOrders[orderKey] = order
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDELimitOrderStatusPrefix,
+			refundContent.OrderID[:],
+			refundContent.OrderStatus,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde refunded limit order status: %+v", err)
+		}
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDECancelLimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	var cancelContent metadata.PDECancelLimitOrderContent
+	err := json.Unmarshal([]byte(instruction[3]), &cancelContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order instruction: %+v", err)
+		return nil
+	}
+	cancelStatus := common.PDECancelLimitOrderRejectedStatus
+	if instruction[2] == common.PDECancelLimitOrderAcceptedChainStatus {
+		// the order itself is closed by the following refund instructions
+		cancelStatus = common.PDECancelLimitOrderAcceptedStatus
+	}
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDECancelOrderStatusPrefix,
+		cancelContent.TxReqID[:],
+		byte(cancelStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde cancel limit order status: %+v", err)
+	}
+	return nil
+}
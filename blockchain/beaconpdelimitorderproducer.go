@@ -0,0 +1,397 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func buildPDELimitOrderRefundInsts(
+	order *rawdbv2.PDELimitOrder,
+	orderStatus byte,
+) [][]string {
+	insts := [][]string{}
+	refunds := []struct {
+		tokenIDStr string
+		amount     uint64
+		status     string
+	}{
+		{common.PRVCoinID.String(), order.TradingFee, common.PDELimitOrderFeeRefundChainStatus},
+		{order.TokenIDToSellStr, order.RemainingSellAmount, common.PDELimitOrderSellingTokenRefundChainStatus},
+	}
+	for _, refund := range refunds {
+		if refund.amount == 0 {
+			continue
+		}
+		refundLimitOrder := metadata.PDERefundLimitOrder{
+			OrderID:          order.OrderID,
+			TraderAddressStr: order.TraderAddressStr,
+			TokenIDStr:       refund.tokenIDStr,
+			Amount:           refund.amount,
+			OrderStatus:      orderStatus,
+			ShardID:          order.ShardID,
+		}
+		refundLimitOrderBytes, _ := json.Marshal(refundLimitOrder)
+		insts = append(insts, []string{
+			strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+			strconv.Itoa(int(order.ShardID)),
+			refund.status,
+			string(refundLimitOrderBytes),
+		})
+	}
+	return insts
+}
+
+func buildPDECancelLimitOrderInst(
+	cancelAction metadata.PDECancelLimitOrderRequestAction,
+	orderID common.Hash,
+	status string,
+) []string {
+	cancelContent := metadata.PDECancelLimitOrderContent{
+		OrderID:          orderID,
+		TraderAddressStr: cancelAction.Meta.TraderAddressStr,
+		ShardID:          cancelAction.ShardID,
+		TxReqID:          cancelAction.TxReqID,
+	}
+	cancelContentBytes, _ := json.Marshal(cancelContent)
+	return []string{
+		strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta),
+		strconv.Itoa(int(cancelAction.ShardID)),
+		status,
+		string(cancelContentBytes),
+	}
+}
+
+// calcLimitOrderFillAmount returns the largest part of the remaining selling amount of a limit order
+// which the pool pair buys at a price not worse than the limit price, and the amount received for it.
+// The price of selling to a pool pair goes down as the selling amount goes up so the amount is bisected,
+// the received amount is rounded down so near the limit price the bisection may stop a bit below the largest part.
+// Zero is returned if no part of the order can be filled at the limit price
+func calcLimitOrderFillAmount(
+	poolPair *rawdbv2.PDEPoolForPair,
+	order *rawdbv2.PDELimitOrder,
+) (uint64, uint64) {
+	if order.RemainingSellAmount == 0 || order.SellAmount == 0 {
+		return 0, 0
+	}
+	isAtLimitPrice := func(sellAmount uint64) (uint64, bool) {
+		receiveAmount, _, _ := calcTradeValue(poolPair, order.TokenIDToSellStr, sellAmount)
+		if receiveAmount == 0 {
+			return 0, false
+		}
+		// comparing receiveAmount/sellAmount to MinAcceptableAmount/SellAmount
+		receiveValue := new(big.Int).Mul(new(big.Int).SetUint64(receiveAmount), new(big.Int).SetUint64(order.SellAmount))
+		limitValue := new(big.Int).Mul(new(big.Int).SetUint64(sellAmount), new(big.Int).SetUint64(order.MinAcceptableAmount))
+		return receiveAmount, receiveValue.Cmp(limitValue) >= 0
+	}
+	if receiveAmount, ok := isAtLimitPrice(order.RemainingSellAmount); ok {
+		return order.RemainingSellAmount, receiveAmount
+	}
+	low, high := uint64(0), order.RemainingSellAmount-1
+	for low < high {
+		mid := high - (high-low)/2
+		if _, ok := isAtLimitPrice(mid); ok {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	if low == 0 {
+		return 0, 0
+	}
+	receiveAmount, ok := isAtLimitPrice(low)
+	if !ok {
+		return 0, 0
+	}
+	return low, receiveAmount
+}
+
+// sortPDELimitOrderKeys returns the keys of limit orders grouped by pool pair,
+// orders of a pool pair are sorted by their limit prices, the lowest first
+func sortPDELimitOrderKeys(
+	beaconHeight uint64,
+	pdeLimitOrders map[string]*rawdbv2.PDELimitOrder,
+) []string {
+	keys := []string{}
+	poolPairKeys := map[string]string{}
+	for key, order := range pdeLimitOrders {
+		keys = append(keys, key)
+		poolPairKeys[key] = string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		first := pdeLimitOrders[keys[i]]
+		second := pdeLimitOrders[keys[j]]
+		if poolPairKeys[keys[i]] != poolPairKeys[keys[j]] {
+			return poolPairKeys[keys[i]] < poolPairKeys[keys[j]]
+		}
+		if first.TokenIDToSellStr != second.TokenIDToSellStr {
+			return first.TokenIDToSellStr < second.TokenIDToSellStr
+		}
+		// comparing a/b to c/d is equivalent with comparing a*d to c*b
+		firstItemProportion := new(big.Int).Mul(
+			new(big.Int).SetUint64(first.MinAcceptableAmount),
+			new(big.Int).SetUint64(second.SellAmount),
+		)
+		secondItemProportion := new(big.Int).Mul(
+			new(big.Int).SetUint64(second.MinAcceptableAmount),
+			new(big.Int).SetUint64(first.SellAmount),
+		)
+		if cmp := firstItemProportion.Cmp(secondItemProportion); cmp != 0 {
+			return cmp < 0
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func (blockchain *BlockChain) buildInstsForPDECancelLimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
+) [][]string {
+	insts := [][]string{}
+	var keys []int
+	for k := range pdeCancelLimitOrderActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		actions := pdeCancelLimitOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cancel limit order action: %+v", err)
+				continue
+			}
+			var cancelAction metadata.PDECancelLimitOrderRequestAction
+			err = json.Unmarshal(contentBytes, &cancelAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order action: %+v", err)
+				continue
+			}
+			orderID, err := common.Hash{}.NewHashFromStr(cancelAction.Meta.OrderID)
+			if err != nil {
+				insts = append(insts, buildPDECancelLimitOrderInst(cancelAction, common.Hash{}, common.PDECancelLimitOrderRejectedChainStatus))
+				continue
+			}
+			orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, orderID.String()))
+			order, found := currentPDEState.PDELimitOrders[orderKey]
+			if !found || order == nil || order.TraderAddressStr != cancelAction.Meta.TraderAddressStr {
+				insts = append(insts, buildPDECancelLimitOrderInst(cancelAction, *orderID, common.PDECancelLimitOrderRejectedChainStatus))
+				continue
+			}
+			insts = append(insts, buildPDECancelLimitOrderInst(cancelAction, *orderID, common.PDECancelLimitOrderAcceptedChainStatus))
+			insts = append(insts, buildPDELimitOrderRefundInsts(order, common.PDELimitOrderCancelledStatus)...)
+			delete(currentPDEState.PDELimitOrders, orderKey)
+		}
+	}
+	return insts
+}
+
+func (blockchain *BlockChain) buildInstsForNewPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+) [][]string {
+	insts := [][]string{}
+	params := blockchain.GetConfig().ChainParams
+	// the order book is bounded so matching it at every beacon block stays cheap
+	ordersByPair := map[string]int{}
+	ordersByTrader := map[string]int{}
+	for _, order := range currentPDEState.PDELimitOrders {
+		ordersByPair[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr))]++
+		ordersByTrader[order.TraderAddressStr]++
+	}
+	var keys []int
+	for k := range pdeLimitOrderActionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	for _, value := range keys {
+		shardID := byte(value)
+		actions := pdeLimitOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order action: %+v", err)
+				continue
+			}
+			var limitOrderAction metadata.PDELimitOrderRequestAction
+			err = json.Unmarshal(contentBytes, &limitOrderAction)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order action: %+v", err)
+				continue
+			}
+			meta := limitOrderAction.Meta
+			order := rawdbv2.NewPDELimitOrder(
+				limitOrderAction.TxReqID,
+				meta.TraderAddressStr,
+				meta.TokenIDToBuyStr,
+				meta.TokenIDToSellStr,
+				meta.SellAmount,
+				meta.MinAcceptableAmount,
+				meta.SellAmount,
+				0,
+				meta.TradingFee,
+				meta.ExpiryBeaconHeight,
+				limitOrderAction.ShardID,
+			)
+			orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, order.OrderID.String()))
+			pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr))
+			_, found := currentPDEState.PDELimitOrders[orderKey]
+			// the new beacon block is at beaconHeight + 1
+			if found || beaconHeight+1 < params.BCHeightBreakPointPDELimitOrders ||
+				!isTradingFairContainsPRV(meta.TokenIDToSellStr, meta.TokenIDToBuyStr) ||
+				!isPoolPairExisting(beaconHeight, currentPDEState, meta.TokenIDToSellStr, meta.TokenIDToBuyStr) ||
+				meta.SellAmount == 0 || meta.MinAcceptableAmount == 0 || meta.ExpiryBeaconHeight <= beaconHeight ||
+				meta.ExpiryBeaconHeight > beaconHeight+1+params.MaxPDELimitOrderLifetime ||
+				ordersByPair[pairKey] >= params.MaxPDELimitOrdersPerPair ||
+				ordersByTrader[order.TraderAddressStr] >= params.MaxPDELimitOrdersPerTrader {
+				insts = append(insts, buildPDELimitOrderRefundInsts(order, common.PDELimitOrderRejectedStatus)...)
+				continue
+			}
+			acceptedContent := metadata.PDELimitOrderAcceptedContent{
+				OrderID:             order.OrderID,
+				TraderAddressStr:    order.TraderAddressStr,
+				TokenIDToBuyStr:     order.TokenIDToBuyStr,
+				TokenIDToSellStr:    order.TokenIDToSellStr,
+				SellAmount:          order.SellAmount,
+				MinAcceptableAmount: order.MinAcceptableAmount,
+				TradingFee:          order.TradingFee,
+				ExpiryBeaconHeight:  order.ExpiryBeaconHeight,
+				ShardID:             order.ShardID,
+			}
+			acceptedContentBytes, err := json.Marshal(acceptedContent)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while marshaling pde limit order accepted content: %+v", err)
+				continue
+			}
+			insts = append(insts, []string{
+				strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+				strconv.Itoa(int(order.ShardID)),
+				common.PDELimitOrderAcceptedChainStatus,
+				string(acceptedContentBytes),
+			})
+			currentPDEState.PDELimitOrders[orderKey] = order
+			ordersByPair[pairKey]++
+			ordersByTrader[order.TraderAddressStr]++
+		}
+	}
+	return insts
+}
+
+// buildInstsForMatchingPDELimitOrders fills resting limit orders against the pool pairs,
+// the trading fee of an order is added to its pool pair on its first fill
+func (blockchain *BlockChain) buildInstsForMatchingPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tradingFeeByPair map[string]uint64,
+) [][]string {
+	insts := [][]string{}
+	for _, orderKey := range sortPDELimitOrderKeys(beaconHeight, currentPDEState.PDELimitOrders) {
+		order := currentPDEState.PDELimitOrders[orderKey]
+		if !isPoolPairExisting(beaconHeight, currentPDEState, order.TokenIDToSellStr, order.TokenIDToBuyStr) {
+			continue
+		}
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr))
+		pdePoolPair := currentPDEState.PDEPoolPairs[pairKey]
+		sellAmount, receiveAmount := calcLimitOrderFillAmount(pdePoolPair, order)
+		if sellAmount == 0 {
+			continue
+		}
+
+		filledContent := metadata.PDELimitOrderFilledContent{
+			OrderID:          order.OrderID,
+			TraderAddressStr: order.TraderAddressStr,
+			TokenIDToBuyStr:  order.TokenIDToBuyStr,
+			SellAmount:       sellAmount,
+			ReceiveAmount:    receiveAmount,
+			Token1IDStr:      pdePoolPair.Token1IDStr,
+			Token2IDStr:      pdePoolPair.Token2IDStr,
+			AddingFee:        order.TradingFee,
+			ShardID:          order.ShardID,
+		}
+		// update current pde state on mem
+		if pdePoolPair.Token1IDStr == order.TokenIDToSellStr {
+			pdePoolPair.Token1PoolValue += sellAmount
+			pdePoolPair.Token2PoolValue -= receiveAmount
+			filledContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: sellAmount}
+			filledContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmount}
+		} else {
+			pdePoolPair.Token1PoolValue -= receiveAmount
+			pdePoolPair.Token2PoolValue += sellAmount
+			filledContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmount}
+			filledContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: sellAmount}
+		}
+		if order.TradingFee > 0 {
+			sKey := string(rawdbv2.BuildPDESharesKeyV2(beaconHeight, order.TokenIDToBuyStr, order.TokenIDToSellStr, ""))
+			tradingFeeByPair[sKey] += order.TradingFee
+		}
+		order.RemainingSellAmount -= sellAmount
+		order.ReceivedAmount += receiveAmount
+		order.TradingFee = 0
+		if order.RemainingSellAmount == 0 {
+			delete(currentPDEState.PDELimitOrders, orderKey)
+		}
+
+		filledContentBytes, err := json.Marshal(filledContent)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while marshaling pde limit order filled content: %+v", err)
+			continue
+		}
+		insts = append(insts, []string{
+			strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+			strconv.Itoa(int(order.ShardID)),
+			common.PDELimitOrderFilledChainStatus,
+			string(filledContentBytes),
+		})
+	}
+	return insts
+}
+
+// buildInstsForExpiredPDELimitOrders refunds the limit orders expiring at the new beacon block (beaconHeight + 1)
+func (blockchain *BlockChain) buildInstsForExpiredPDELimitOrders(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) [][]string {
+	insts := [][]string{}
+	var keys []string
+	for k := range currentPDEState.PDELimitOrders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, orderKey := range keys {
+		order := currentPDEState.PDELimitOrders[orderKey]
+		if order.ExpiryBeaconHeight > beaconHeight+1 {
+			continue
+		}
+		insts = append(insts, buildPDELimitOrderRefundInsts(order, common.PDELimitOrderExpiredStatus)...)
+		delete(currentPDEState.PDELimitOrders, orderKey)
+	}
+	return insts
+}
+
+func (blockchain *BlockChain) handlePDELimitOrderInsts(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
+	tradingFeeByPair map[string]uint64,
+) [][]string {
+	if currentPDEState.PDELimitOrders == nil {
+		currentPDEState.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	instructions := [][]string{}
+	instructions = append(instructions, blockchain.buildInstsForPDECancelLimitOrders(currentPDEState, beaconHeight, pdeCancelLimitOrderActionsByShardID)...)
+	instructions = append(instructions, blockchain.buildInstsForNewPDELimitOrders(currentPDEState, beaconHeight, pdeLimitOrderActionsByShardID)...)
+	instructions = append(instructions, blockchain.buildInstsForMatchingPDELimitOrders(currentPDEState, beaconHeight, tradingFeeByPair)...)
+	instructions = append(instructions, blockchain.buildInstsForExpiredPDELimitOrders(currentPDEState, beaconHeight)...)
+	return instructions
+}
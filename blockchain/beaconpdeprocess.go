@@ -51,6 +51,10 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 			err = blockchain.processPDEFeeWithdrawal(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDETradingFeesDistributionMeta):
 			err = blockchain.processPDETradingFeesDistribution(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			err = blockchain.processPDELimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			err = blockchain.processPDECancelLimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -96,6 +100,12 @@ func hasPDEInstruction(instructions [][]string) bool {
 		case strconv.Itoa(metadata.PDETradingFeesDistributionMeta):
 			hasPDEXInstruction = true
 			break
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
 		}
 	}
 	return hasPDEXInstruction
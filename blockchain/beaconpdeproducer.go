@@ -421,34 +421,11 @@ func (blockchain *BlockChain) buildInstsForSortedTradableActions(
 	beaconHeight uint64,
 	sortedTradableActions []metadata.PDECrossPoolTradeRequestAction,
 ) ([][]string, map[string]uint64) {
-	prvIDStr := common.PRVCoinID.String()
 	tradableInsts := [][]string{}
 	tradingFeeByPair := make(map[string]uint64)
 	for _, tradeAction := range sortedTradableActions {
 		tradeMeta := tradeAction.Meta
-		var sequentialTrades []*tradeInfo
-		if isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) { // direct trade
-			sequentialTrades = []*tradeInfo{
-				&tradeInfo{
-					tokenIDToBuyStr:  tradeMeta.TokenIDToBuyStr,
-					tokenIDToSellStr: tradeMeta.TokenIDToSellStr,
-					sellAmount:       tradeMeta.SellAmount,
-				},
-			}
-		} else { // cross pool trade
-			sequentialTrades = []*tradeInfo{
-				&tradeInfo{
-					tokenIDToBuyStr:  prvIDStr,
-					tokenIDToSellStr: tradeMeta.TokenIDToSellStr,
-					sellAmount:       tradeMeta.SellAmount,
-				},
-				&tradeInfo{
-					tokenIDToBuyStr:  tradeMeta.TokenIDToBuyStr,
-					tokenIDToSellStr: prvIDStr,
-					sellAmount:       uint64(0),
-				},
-			}
-		}
+		sequentialTrades := buildSequentialTrades(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr, tradeMeta.SellAmount)
 		newInsts, err := blockchain.buildInstructionsForPDECrossPoolTrade(
 			sequentialTrades,
 			tradeMeta.MinAcceptableAmount,
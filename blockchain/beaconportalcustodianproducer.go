@@ -384,7 +384,7 @@ func (p *portalCustodianDepositProcessorV3) buildNewInsts(
 	}
 
 	// verify proof and parse receipt
-	ethReceipt, err := metadata.VerifyProofAndParseReceipt(meta.BlockHash, meta.TxIndex, meta.ProofStrs)
+	ethReceipt, err := metadata.VerifyProofAndParseReceipt(bc, beaconHeight, meta.BlockHash, meta.TxIndex, meta.ProofStrs)
 	if err != nil {
 		Logger.log.Errorf("Custodian deposit v3: Verify eth proof error: %+v", err)
 		return [][]string{rejectedInst}, nil
@@ -1480,7 +1480,7 @@ func (p *portalCustodianTopupProcessorV3) buildNewInsts(
 		}
 
 		// verify proof and parse receipt
-		ethReceipt, err := metadata.VerifyProofAndParseReceipt(meta.BlockHash, meta.TxIndex, meta.ProofStrs)
+		ethReceipt, err := metadata.VerifyProofAndParseReceipt(bc, beaconHeight, meta.BlockHash, meta.TxIndex, meta.ProofStrs)
 		if err != nil {
 			Logger.log.Errorf("Topup v3: Verify eth proof error: %+v", err)
 			return [][]string{rejectInst2}, nil
@@ -1753,7 +1753,7 @@ func (p *portalTopupWaitingPortingReqProcessorV3) buildNewInsts(
 		}
 
 		// verify proof and parse receipt
-		ethReceipt, err := metadata.VerifyProofAndParseReceipt(meta.BlockHash, meta.TxIndex, meta.ProofStrs)
+		ethReceipt, err := metadata.VerifyProofAndParseReceipt(bc, beaconHeight, meta.BlockHash, meta.TxIndex, meta.ProofStrs)
 		if err != nil {
 			Logger.log.Errorf("Topup waiting porting v3: Verify eth proof error: %+v", err)
 			return [][]string{rejectInst2}, nil
@@ -349,8 +349,8 @@ func (p *portalRequestPTokenProcessor) buildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	portalTokenProcessor := bc.config.ChainParams.PortalTokens[meta.TokenID]
-	if portalTokenProcessor == nil {
+	portalTokenProcessor, ok := bc.config.ChainParams.GetPortalTokenProcessor(meta.TokenID, beaconHeight)
+	if !ok {
 		Logger.log.Errorf("TokenID is not supported currently on Portal")
 		return [][]string{rejectInst}, nil
 	}
@@ -134,7 +134,7 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 	}
 
 	//save final exchangeRates
-	blockchain.pickExchangesRatesFinal(currentPortalState)
+	blockchain.pickExchangesRatesFinal(currentPortalState, beaconHeight, portalParams)
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
@@ -214,6 +214,13 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 		}
 
 		currentPortalState.ExchangeRatesRequests[portingExchangeRatesContent.TxReqID.String()] = newExchangeRates
+		if blockchain.IsPortalFeedersEnabled(beaconHeight) {
+			err = addPortalFeederSubmission(portalStateDB, currentPortalState, beaconHeight+1, portingExchangeRatesContent)
+			if err != nil {
+				Logger.log.Errorf("ERROR: Save portal feeder submission error: %+v", err)
+				return nil
+			}
+		}
 
 		Logger.log.Infof("Portal exchange rates, exchange rates request: total exchange rate request %v", len(currentPortalState.ExchangeRatesRequests))
 
@@ -235,18 +242,95 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 			Logger.log.Errorf("ERROR: Save exchange rates error: %+v", err)
 			return nil
 		}
+		// duplicated requests are not submissions of the feeder
+		if blockchain.IsPortalFeedersEnabled(beaconHeight) && len(portingExchangeRatesContent.RejectedRates) > 0 {
+			err = addPortalFeederSubmission(portalStateDB, currentPortalState, beaconHeight+1, portingExchangeRatesContent)
+			if err != nil {
+				Logger.log.Errorf("ERROR: Save portal feeder submission error: %+v", err)
+				return nil
+			}
+		}
 	}
 
 	return nil
 }
 
-func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
+// addPortalFeederSubmission records the accepted and rejected rates of an exchange rates request to the feeder's history,
+// the history is a ring of maxPortalFeederSubmissions slots so a submission only writes its own slot
+func addPortalFeederSubmission(
+	portalStateDB *statedb.StateDB,
+	currentPortalState *CurrentPortalState,
+	blockHeight uint64,
+	content metadata.PortalExchangeRatesContent,
+) error {
+	if currentPortalState.FeederStates == nil {
+		currentPortalState.FeederStates = make(map[string]*statedb.PortalFeederState)
+	}
+	feederState, ok := currentPortalState.FeederStates[content.SenderAddress]
+	if !ok || feederState == nil {
+		feederState = statedb.NewPortalFeederState()
+		feederState.SetFeederAddress(content.SenderAddress)
+		currentPortalState.FeederStates[content.SenderAddress] = feederState
+	}
+	submission := statedb.PortalFeederSubmission{
+		TxReqID:       content.TxReqID,
+		BeaconHeight:  blockHeight,
+		AcceptedRates: make(map[string]uint64),
+		RejectedRates: make(map[string]uint64),
+	}
+	for _, rate := range content.Rates {
+		submission.AcceptedRates[rate.PTokenID] = rate.Rate
+	}
+	for _, rate := range content.RejectedRates {
+		submission.RejectedRates[rate.PTokenID] = rate.Rate
+	}
+	feederState.AddSubmission(submission)
+	slot := (feederState.TotalSubmissions() - 1) % maxPortalFeederSubmissions
+	return statedb.StorePortalFeederSubmission(portalStateDB, content.SenderAddress, slot, submission)
+}
+
+// GetPortalFeederSubmissions returns the recent submissions of a feeder, the most recent first
+func GetPortalFeederSubmissions(stateDB *statedb.StateDB, feederState *statedb.PortalFeederState) ([]statedb.PortalFeederSubmission, error) {
+	total := feederState.TotalSubmissions()
+	count := total
+	if count > maxPortalFeederSubmissions {
+		count = maxPortalFeederSubmissions
+	}
+	submissions := make([]statedb.PortalFeederSubmission, 0, count)
+	for i := uint64(1); i <= count; i++ {
+		submission, err := statedb.GetPortalFeederSubmission(stateDB, feederState.FeederAddress(), (total-i)%maxPortalFeederSubmissions)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, *submission)
+	}
+	return submissions, nil
+}
+
+func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState, beaconHeight uint64, portalParams PortalParams) {
 	// sort exchange rate requests by rate
 	sumRates := map[string][]uint64{}
 
-	for _, req := range currentPortalState.ExchangeRatesRequests {
-		for _, rate := range req.Rates {
-			sumRates[rate.PTokenID] = append(sumRates[rate.PTokenID], rate.Rate)
+	if blockchain.IsPortalFeedersEnabled(beaconHeight) {
+		// the latest rates of authorized feeders inside the window
+		blockHeight := beaconHeight + 1
+		for _, feederAddress := range blockchain.GetPortalFeederAddresses(beaconHeight) {
+			feederState, ok := currentPortalState.FeederStates[feederAddress]
+			if !ok || feederState == nil {
+				continue
+			}
+			for tokenID, latestRate := range feederState.LatestRates() {
+				if latestRate.BeaconHeight+portalParams.ExchangeRatesWindow < blockHeight {
+					continue
+				}
+				sumRates[tokenID] = append(sumRates[tokenID], latestRate.Rate)
+			}
+		}
+	} else {
+		for _, req := range currentPortalState.ExchangeRatesRequests {
+			for _, rate := range req.Rates {
+				sumRates[rate.PTokenID] = append(sumRates[rate.PTokenID], rate.Rate)
+			}
 		}
 	}
 
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"encoding/base64"
 	"encoding/json"
+	"math/big"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 
 	//"github.com/binance-chain/go-sdk/types/msg"
@@ -84,12 +85,41 @@ func (p *portalExchangeRateProcessor) buildNewInsts(
 		}
 	}
 
+	// reject the rates deviating too much from the final rates
+	acceptedRates := actionData.Meta.Rates
+	var rejectedRates []*metadata.ExchangeRateInfo
+	if bc.IsPortalFeedersEnabled(beaconHeight) {
+		acceptedRates, rejectedRates = filterPortalExchangeRates(actionData.Meta.Rates, currentPortalState, beaconHeight+1, portalParams)
+		if len(acceptedRates) == 0 {
+			Logger.log.Errorf("ERROR: all exchange rates of feeder %v deviate too much from the final rates", actionData.Meta.SenderAddress)
+
+			portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
+				SenderAddress: actionData.Meta.SenderAddress,
+				TxReqID:       actionData.TxReqID,
+				LockTime:      actionData.LockTime,
+				RejectedRates: rejectedRates,
+			}
+
+			portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
+
+			inst := []string{
+				strconv.Itoa(metaType),
+				strconv.Itoa(int(shardID)),
+				common.PortalExchangeRatesRejectedChainStatus,
+				string(portalExchangeRatesContentBytes),
+			}
+
+			return [][]string{inst}, nil
+		}
+	}
+
 	//success
 	portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
 		SenderAddress: actionData.Meta.SenderAddress,
-		Rates:         actionData.Meta.Rates,
+		Rates:         acceptedRates,
 		TxReqID:       actionData.TxReqID,
 		LockTime:      actionData.LockTime,
+		RejectedRates: rejectedRates,
 	}
 
 	portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
@@ -106,7 +136,7 @@ func (p *portalExchangeRateProcessor) buildNewInsts(
 		currentPortalState.ExchangeRatesRequests[actionData.TxReqID.String()] = metadata.NewExchangeRatesRequestStatus(
 			common.PortalExchangeRatesAcceptedStatus,
 			actionData.Meta.SenderAddress,
-			actionData.Meta.Rates,
+			acceptedRates,
 		)
 	} else {
 		//new object
@@ -114,11 +144,56 @@ func (p *portalExchangeRateProcessor) buildNewInsts(
 		newExchangeRatesRequest[actionData.TxReqID.String()] = metadata.NewExchangeRatesRequestStatus(
 			common.PortalExchangeRatesAcceptedStatus,
 			actionData.Meta.SenderAddress,
-			actionData.Meta.Rates,
+			acceptedRates,
 		)
 
 		currentPortalState.ExchangeRatesRequests = newExchangeRatesRequest
 	}
 
 	return [][]string{inst}, nil
-}
\ No newline at end of file
+}
+
+// filterPortalExchangeRates splits the rates of a feeder into the accepted and the rejected ones,
+// a rate is rejected if it deviates from the final rate more than MaxPercentExchangeRateDeviation percent.
+// The band only applies while some feeder has an accepted rate of the token inside the window,
+// otherwise the final rate is stale and it is replaced by any new rate
+func filterPortalExchangeRates(
+	rates []*metadata.ExchangeRateInfo,
+	currentPortalState *CurrentPortalState,
+	blockHeight uint64,
+	portalParams PortalParams,
+) ([]*metadata.ExchangeRateInfo, []*metadata.ExchangeRateInfo) {
+	if portalParams.MaxPercentExchangeRateDeviation == 0 || currentPortalState.FinalExchangeRatesState == nil {
+		return rates, nil
+	}
+	finalRates := currentPortalState.FinalExchangeRatesState.Rates()
+	acceptedRates := []*metadata.ExchangeRateInfo{}
+	var rejectedRates []*metadata.ExchangeRateInfo
+	for _, rate := range rates {
+		finalRate := finalRates[rate.PTokenID].Amount
+		if finalRate == 0 || !hasRecentFeederRate(currentPortalState.FeederStates, rate.PTokenID, blockHeight, portalParams.ExchangeRatesWindow) {
+			acceptedRates = append(acceptedRates, rate)
+			continue
+		}
+		deviation := new(big.Int).Sub(new(big.Int).SetUint64(rate.Rate), new(big.Int).SetUint64(finalRate))
+		deviation = deviation.Abs(deviation).Mul(deviation, big.NewInt(100))
+		maxDeviation := new(big.Int).Mul(new(big.Int).SetUint64(finalRate), new(big.Int).SetUint64(portalParams.MaxPercentExchangeRateDeviation))
+		if deviation.Cmp(maxDeviation) > 0 {
+			rejectedRates = append(rejectedRates, rate)
+			continue
+		}
+		acceptedRates = append(acceptedRates, rate)
+	}
+	return acceptedRates, rejectedRates
+}
+
+// hasRecentFeederRate returns true if some feeder has an accepted rate of the token inside the window
+func hasRecentFeederRate(feederStates map[string]*statedb.PortalFeederState, tokenID string, blockHeight uint64, window uint64) bool {
+	for _, feederState := range feederStates {
+		latestRate, ok := feederState.LatestRates()[tokenID]
+		if ok && latestRate.BeaconHeight+window >= blockHeight {
+			return true
+		}
+	}
+	return false
+}
@@ -613,8 +613,8 @@ func (p *portalRequestUnlockCollateralProcessor) buildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	portalTokenProcessor := bc.config.ChainParams.PortalTokens[meta.TokenID]
-	if portalTokenProcessor == nil {
+	portalTokenProcessor, ok := bc.config.ChainParams.GetPortalTokenProcessor(meta.TokenID, beaconHeight)
+	if !ok {
 		Logger.log.Errorf("TokenID %v is not supported currently on Portal", meta.TokenID)
 		return [][]string{rejectInst}, nil
 	}
@@ -35,7 +35,7 @@ func (blockchain *BlockChain) processPortalReward(
 
 		// update locked collateral for rewards base on holding public tokens
 		if metaType == metadata.PortalRewardMetaV3 {
-			UpdateLockedCollateralForRewardsV3(currentPortalState, portalParams)
+			UpdateLockedCollateralForRewardsV3(currentPortalState, portalParams, blockchain.config.ChainParams.GetPortalTokenIDs(beaconHeight))
 		} else if metaType == metadata.PortalRewardMeta {
 			UpdateLockedCollateralForRewards(currentPortalState, portalParams)
 		}
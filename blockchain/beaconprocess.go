@@ -231,6 +231,7 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, should
 	// 	)
 	// }
 	beaconInsertBlockTimer.UpdateSince(startTimeStoreBeaconBlock)
+	beaconInsertBlockLatencyTimer.UpdateSince(startTimeStoreBeaconBlock)
 	return nil
 }
 
@@ -8,8 +8,8 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
-	"github.com/tendermint/tendermint/types"
 	"strconv"
 )
 
@@ -20,6 +20,9 @@ func (blockchain *BlockChain) processRelayingInstructions(block *BeaconBlock) er
 		return nil
 	}
 
+	// relayed BNB headers are only processed from the break point, the BNB header chain is not touched before it
+	isBNBRelaying := block.Header.Height >= blockchain.config.ChainParams.BCHeightBreakPointBNBRelaying
+
 	// because relaying instructions in received beacon block were sorted already as desired so dont need to do sorting again over here
 	for _, inst := range block.Body.Instructions {
 		if len(inst) < 4 {
@@ -27,10 +30,16 @@ func (blockchain *BlockChain) processRelayingInstructions(block *BeaconBlock) er
 		}
 		var err error
 		switch inst[0] {
-		//case strconv.Itoa(metadata.RelayingBNBHeaderMeta):
-		//	err = blockchain.processRelayingBNBHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingBNBHeaderMeta):
+			if isBNBRelaying {
+				err = blockchain.processRelayingBNBHeaderInst(inst, relayingState)
+			}
 		case strconv.Itoa(metadata.RelayingBTCHeaderMeta):
 			err = blockchain.processRelayingBTCHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingETHHeaderMeta):
+			err = blockchain.processRelayingETHHeaderInst(inst, relayingState)
+		case strconv.Itoa(metadata.RelayingLTCHeaderMeta):
+			err = blockchain.processRelayingLTCHeaderInst(inst, relayingState)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -38,10 +47,12 @@ func (blockchain *BlockChain) processRelayingInstructions(block *BeaconBlock) er
 	}
 
 	// store updated relayingState to leveldb with new beacon height
-	//err = relayingState.BNBHeaderChain.StoreBNBChainState()
-	//if err != nil {
-	//	Logger.log.Error(err)
-	//}
+	if isBNBRelaying && relayingState.BNBHeaderChain != nil {
+		err = relayingState.BNBHeaderChain.StoreBNBChainState()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
 	return nil
 }
 
@@ -84,11 +95,77 @@ func (blockchain *BlockChain) processRelayingBTCHeaderInst(
 	return nil
 }
 
+func (blockchain *BlockChain) processRelayingETHHeaderInst(
+	instruction []string,
+	relayingState *RelayingHeaderChainState,
+) error {
+	ethHeaderChain := relayingState.ETHHeaderChain
+	if ethHeaderChain == nil {
+		return errors.New("[processRelayingETHHeaderInst] ETH Header chain instance should not be nil")
+	}
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] != common.RelayingHeaderConsideringChainStatus {
+		return nil
+	}
+
+	var relayingHeaderContent metadata.RelayingHeaderContent
+	err := json.Unmarshal([]byte(instruction[3]), &relayingHeaderContent)
+	if err != nil {
+		return err
+	}
+	header, err := parseETHHeader(relayingHeaderContent.Header)
+	if err != nil {
+		return err
+	}
+	isMainChain, err := ethHeaderChain.ProcessHeader(header)
+	if err != nil {
+		Logger.log.Errorf("[ETH Relaying] ProcessHeader fail with error: %v", err)
+		return err
+	}
+	Logger.log.Infof("[ETH Relaying] ProcessHeader (%s) success with result: isMainChain: %v", header.Hash().String(), isMainChain)
+	return nil
+}
+
+func (blockchain *BlockChain) processRelayingLTCHeaderInst(
+	instruction []string,
+	relayingState *RelayingHeaderChainState,
+) error {
+	ltcHeaderChain := relayingState.LTCHeaderChain
+	if ltcHeaderChain == nil {
+		return errors.New("[processRelayingLTCHeaderInst] LTC Header chain instance should not be nil")
+	}
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] != common.RelayingHeaderConsideringChainStatus {
+		return nil
+	}
+
+	var relayingHeaderContent metadata.RelayingHeaderContent
+	err := json.Unmarshal([]byte(instruction[3]), &relayingHeaderContent)
+	if err != nil {
+		return err
+	}
+	header, err := parseLTCHeader(relayingHeaderContent.Header)
+	if err != nil {
+		return err
+	}
+	isMainChain, err := ltcHeaderChain.ProcessHeader(header)
+	if err != nil {
+		Logger.log.Errorf("[LTC Relaying] ProcessHeader fail with error: %v", err)
+		return err
+	}
+	Logger.log.Infof("[LTC Relaying] ProcessHeader (%s) success with result: isMainChain: %v", header.BlockHash().String(), isMainChain)
+	return nil
+}
+
 func (blockchain *BlockChain) processRelayingBNBHeaderInst(
 	instructions []string,
 	relayingState *RelayingHeaderChainState,
 ) error {
-	if relayingState == nil {
+	if relayingState == nil || relayingState.BNBHeaderChain == nil {
 		Logger.log.Errorf("relaying block state is nil")
 		return errors.New("relaying block state is nil")
 	}
@@ -104,13 +181,12 @@ func (blockchain *BlockChain) processRelayingBNBHeaderInst(
 		return err
 	}
 
-	var block types.Block
 	blockBytes, err := base64.StdEncoding.DecodeString(actionData.Header)
 	if err != nil {
 		Logger.log.Errorf("Can not decode bnb block %v - %v\n", actionData.Header, err)
 		return err
 	}
-	err = json.Unmarshal(blockBytes, &block)
+	block, nextValidators, err := bnbrelaying.ParseRelayingBlock(blockBytes)
 	if err != nil {
 		Logger.log.Errorf("Can not unmarshal bnb block %v - %v\n", string(blockBytes), err)
 		return err
@@ -118,7 +194,7 @@ func (blockchain *BlockChain) processRelayingBNBHeaderInst(
 
 	reqStatus := instructions[2]
 	if reqStatus == common.RelayingHeaderConsideringChainStatus {
-		err := relayingState.BNBHeaderChain.ProcessNewBlock(&block, blockchain.config.ChainParams.BNBRelayingHeaderChainID)
+		err := relayingState.BNBHeaderChain.ProcessNewBlock(block, nextValidators, blockchain.config.ChainParams.BNBRelayingHeaderChainID)
 		if err != nil {
 			Logger.log.Errorf("Error when process new block %v\n", err)
 			return err
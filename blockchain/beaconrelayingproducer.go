@@ -13,6 +13,7 @@ func buildRelayingInstsFromActions(
 	rc relayingProcessor,
 	relayingState *RelayingHeaderChainState,
 	blockchain *BlockChain,
+	beaconHeight uint64,
 ) [][]string {
 	actions := rc.getActions()
 	Logger.log.Infof("[Blocks Relaying] - Processing buildRelayingInstsFromActions for %d actions", len(actions))
@@ -59,7 +60,7 @@ func buildRelayingInstsFromActions(
 		blockHeight := uint64(value)
 		actions := actionsGroupByBlockHeight[blockHeight]
 		for _, action := range actions {
-			inst := rc.buildRelayingInst(blockchain, action, relayingState)
+			inst := rc.buildRelayingInst(blockchain, action, relayingState, beaconHeight)
 			relayingInsts = append(relayingInsts, inst...)
 		}
 	}
@@ -69,6 +70,7 @@ func buildRelayingInstsFromActions(
 func (blockchain *BlockChain) handleRelayingInsts(
 	relayingState *RelayingHeaderChainState,
 	pm *portalManager,
+	beaconHeight uint64,
 ) [][]string {
 	Logger.log.Info("[Blocks Relaying] - Processing handleRelayingInsts...")
 	newInsts := [][]string{}
@@ -80,7 +82,7 @@ func (blockchain *BlockChain) handleRelayingInsts(
 	sort.Ints(metaTypes)
 	for _, metaType := range metaTypes {
 		rc := pm.relayingChains[metaType]
-		insts := buildRelayingInstsFromActions(rc, relayingState, blockchain)
+		insts := buildRelayingInstsFromActions(rc, relayingState, blockchain, beaconHeight)
 		newInsts = append(newInsts, insts...)
 	}
 	return newInsts
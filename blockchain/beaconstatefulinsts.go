@@ -41,6 +41,8 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PDEFeeWithdrawalRequestMeta,
 			metadata.PDEPRVRequiredContributionRequestMeta,
 			metadata.PDECrossPoolTradeRequestMeta,
+			metadata.PDELimitOrderRequestMeta,
+			metadata.PDECancelLimitOrderRequestMeta,
 			metadata.PortalCustodianDepositMeta,
 			metadata.PortalRequestPortingMeta,
 			metadata.PortalUserRequestPTokenMeta,
@@ -48,6 +50,8 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PortalUnlockOverRateCollateralsMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
+			metadata.RelayingETHHeaderMeta,
+			metadata.RelayingLTCHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
@@ -127,6 +131,8 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	pdeCrossPoolTradeActionsByShardID := map[byte][][]string{}
 	pdeWithdrawalActionsByShardID := map[byte][][]string{}
 	pdeFeeWithdrawalActionsByShardID := map[byte][][]string{}
+	pdeLimitOrderActionsByShardID := map[byte][][]string{}
+	pdeCancelLimitOrderActionsByShardID := map[byte][][]string{}
 
 	var keys []int
 	for k := range statefulActionsByShardID {
@@ -186,6 +192,18 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.PDELimitOrderRequestMeta:
+				pdeLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDECancelLimitOrderRequestMeta:
+				pdeCancelLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeCancelLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PortalCustodianDepositMeta:
 				pm.portalInstructions[metadata.PortalCustodianDepositMeta].putAction(action, shardID)
 			case metadata.PortalRequestPortingMeta, metadata.PortalRequestPortingMetaV3:
@@ -227,6 +245,10 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 				pm.relayingChains[metadata.RelayingBNBHeaderMeta].putAction(action)
 			case metadata.RelayingBTCHeaderMeta:
 				pm.relayingChains[metadata.RelayingBTCHeaderMeta].putAction(action)
+			case metadata.RelayingETHHeaderMeta:
+				pm.relayingChains[metadata.RelayingETHHeaderMeta].putAction(action)
+			case metadata.RelayingLTCHeaderMeta:
+				pm.relayingChains[metadata.RelayingLTCHeaderMeta].putAction(action)
 			default:
 				continue
 			}
@@ -248,6 +270,8 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		pdeCrossPoolTradeActionsByShardID,
 		pdeWithdrawalActionsByShardID,
 		pdeFeeWithdrawalActionsByShardID,
+		pdeLimitOrderActionsByShardID,
+		pdeCancelLimitOrderActionsByShardID,
 	)
 
 	if err != nil {
@@ -277,7 +301,7 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	}
 
 	// handle relaying instructions
-	relayingInsts := blockchain.handleRelayingInsts(relayingHeaderState, pm)
+	relayingInsts := blockchain.handleRelayingInsts(relayingHeaderState, pm, beaconHeight-1)
 	if len(relayingInsts) > 0 {
 		instructions = append(instructions, relayingInsts...)
 	}
@@ -506,6 +530,8 @@ func (blockchain *BlockChain) handlePDEInsts(
 	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
 	pdeWithdrawalActionsByShardID map[byte][][]string,
 	pdeFeeWithdrawalActionsByShardID map[byte][][]string,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
 ) ([][]string, error) {
 	instructions := [][]string{}
 
@@ -561,6 +587,16 @@ func (blockchain *BlockChain) handlePDEInsts(
 	instructions = append(instructions, tradableInsts...)
 	instructions = append(instructions, untradableInsts...)
 
+	// handle limit orders, they are filled against the pool prices left by the trades
+	limitOrderInsts := blockchain.handlePDELimitOrderInsts(
+		currentPDEState,
+		beaconHeight,
+		pdeLimitOrderActionsByShardID,
+		pdeCancelLimitOrderActionsByShardID,
+		tradingFeeByPair,
+	)
+	instructions = append(instructions, limitOrderInsts...)
+
 	// calculate and build instruction for trading fees distribution
 	tradingFeesDistInst := blockchain.buildInstForTradingFeesDist(currentPDEState, beaconHeight, tradingFeeByPair)
 	if len(tradingFeesDistInst) > 0 {
@@ -23,6 +23,8 @@ import (
 	"github.com/incognitochain/incognito-chain/privacy"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/pkg/errors"
 )
@@ -42,6 +44,8 @@ type BlockChain struct {
 type Config struct {
 	BTCChain      *btcrelaying.BlockChain
 	BNBChainState *bnbrelaying.BNBChainState
+	ETHChain      *ethrelaying.HeaderChain
+	LTCChain      *ltcrelaying.HeaderChain
 	DataBase      map[int]incdb.Database
 	MemCache      *memcache.MemoryCache
 	Interrupt     <-chan struct{}
@@ -61,6 +65,8 @@ type Config struct {
 	Server            Server
 	ConsensusEngine   ConsensusEngine
 	Highway           Highway
+	// number of goroutines running the stateless checks of the transactions of a shard block, number of CPUs if it is not positive
+	TxValidationWorkers int
 
 	relayShardLck sync.Mutex
 }
@@ -72,9 +78,6 @@ func NewBlockChain(config *Config, isTest bool) *BlockChain {
 	bc.IsTest = isTest
 	bc.beaconViewCache, _ = lru.New(100)
 	bc.cQuitSync = make(chan struct{})
-	bc.GetBeaconBestState().Params = make(map[string]string)
-	bc.GetBeaconBestState().ShardCommittee = make(map[byte][]incognitokey.CommitteePublicKey)
-	bc.GetBeaconBestState().ShardPendingValidator = make(map[byte][]incognitokey.CommitteePublicKey)
 	return bc
 }
 
@@ -99,6 +102,11 @@ func (blockchain *BlockChain) Init(config *Config) error {
 	if err := blockchain.InitChainState(); err != nil {
 		return err
 	}
+	// past the break point ETH headers are read from the relayed ETH header chain only
+	beaconHeight := blockchain.GetBeaconBestState().BeaconHeight
+	if config.ETHChain == nil && blockchain.IsETHRelayingEnabled(beaconHeight) {
+		return NewBlockChainError(UnExpectedError, fmt.Errorf("ETH relaying is enabled at beacon height %v but the relayed ETH header chain is not config", beaconHeight))
+	}
 	blockchain.cQuitSync = make(chan struct{})
 	return nil
 }
@@ -741,6 +749,17 @@ func (blockchain *BlockChain) GetFixedRandomForShardIDCommitment(beaconHeight ui
 	return nil
 }
 
+// GetSupportedCommitmentRingSizes returns the ring sizes transactions version 2 can use at beaconHeight,
+// the beacon height of the block the transaction is validated in, before BCHeightBreakPointRingSize,
+// only the ring size of transactions version 1 is supported
+func (blockchain *BlockChain) GetSupportedCommitmentRingSizes(beaconHeight uint64) []int {
+	if beaconHeight >= blockchain.GetConfig().ChainParams.BCHeightBreakPointRingSize {
+		return blockchain.GetConfig().ChainParams.CommitmentRingSizes
+	}
+
+	return []int{privacy.CommitmentRingSize}
+}
+
 func (blockchain *BlockChain) IsAfterNewZKPCheckPoint(beaconHeight uint64) bool {
 	if beaconHeight == 0 {
 		beaconHeight = blockchain.GetBeaconBestState().GetHeight()
@@ -0,0 +1,401 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ChainConfigBaseMainnet  = "mainnet"
+	ChainConfigBaseTestnet  = "testnet"
+	ChainConfigBaseTestnet2 = "testnet2"
+
+	GenesisBlockTimeLayout = "2006-01-02T15:04:05.000Z"
+
+	// the number of shards is bounded by common.MaxShardNumber which is set from the config
+	maxActiveShards = 8
+)
+
+// GenesisValidator is a validator staked in the genesis beacon block
+type GenesisValidator struct {
+	CommitteePublicKey string // base58 encoded committee public key, as in keylist.json of utility/genkeylistjson
+	PaymentAddress     string // receiver of the rewards of the validator
+}
+
+// GenesisConfig is the genesis of a network, it replaces the genesis params of the base network
+type GenesisConfig struct {
+	BlockTime        string // timestamp of the genesis blocks in GenesisBlockTimeLayout
+	BeaconCommittee  []GenesisValidator
+	ShardCommittees  [][]GenesisValidator // committee of each shard by shard ID
+	InitialIncognito []string             // json of the transactions of the genesis shard block
+	FeePerTxKb       uint64
+}
+
+// ChainConfig defines a network by overriding the params of a built-in network,
+// it is read from a json or yaml file so a private network can be run without patching the code
+type ChainConfig struct {
+	Base         string          // built-in network the params are based on: mainnet, testnet or testnet2, default is testnet
+	Params       json.RawMessage // fields of Params overriding the base params, durations are in nanoseconds
+	Genesis      *GenesisConfig  // nil keeps the genesis of the base network
+	PortalTokens []PortalTokenConfig
+}
+
+// LoadChainConfigFromFile reads a chain config from a json file, or from a yaml file if path ends with .yaml or .yml
+func LoadChainConfigFromFile(path string) (*ChainConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		data, err = yamlToJSON(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain config file %v", path)
+		}
+	}
+	config := &ChainConfig{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid chain config file %v", path)
+	}
+	return config, nil
+}
+
+// yamlToJSON converts a yaml document to json so the chain config is decoded by the json tags of Params
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value interface{}
+	err := yaml.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	value, err = convertYAMLValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func convertYAMLValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for key, item := range v {
+			converted, err := convertYAMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[fmt.Sprint(key)] = converted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			converted, err := convertYAMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// BaseParams returns the params of the built-in network of the config, SetupParam must be called before
+func (config *ChainConfig) BaseParams() (*Params, error) {
+	switch config.Base {
+	case ChainConfigBaseMainnet:
+		return &ChainMainParam, nil
+	case ChainConfigBaseTestnet, "":
+		return &ChainTestParam, nil
+	case ChainConfigBaseTestnet2:
+		return &ChainTest2Param, nil
+	}
+	return nil, fmt.Errorf("unknown base network %v", config.Base)
+}
+
+// ToParams builds the params of the config from a copy of the base params and validates them
+func (config *ChainConfig) ToParams() (*Params, error) {
+	base, err := config.BaseParams()
+	if err != nil {
+		return nil, err
+	}
+	params, err := base.copy()
+	if err != nil {
+		return nil, err
+	}
+	if config.Genesis != nil {
+		// key swaps read the new keys from the genesis params of the base network
+		params.EpochBreakPointSwapNewKey = nil
+	}
+	if len(config.Params) > 0 {
+		fields := map[string]json.RawMessage{}
+		err = json.Unmarshal(config.Params, &fields)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid params")
+		}
+		for _, field := range []string{"GenesisParams", "GenesisBeaconBlock", "GenesisShardBlock", "PortalTokens"} {
+			if _, ok := fields[field]; ok {
+				return nil, fmt.Errorf("%v must not be set in params, it is built from the genesis and portal tokens of the chain config", field)
+			}
+		}
+		decoder := json.NewDecoder(bytes.NewReader(config.Params))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(params)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid params")
+		}
+	}
+	if config.Genesis != nil {
+		params.GenesisParams = config.Genesis.toGenesisParams(params.GenesisParams)
+	}
+	err = params.LoadPortalTokens(config.PortalTokens)
+	if err != nil {
+		return nil, err
+	}
+	err = params.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (genesis *GenesisConfig) toGenesisParams(base *GenesisParams) *GenesisParams {
+	genesisParams := &GenesisParams{
+		InitialIncognito:                            genesis.InitialIncognito,
+		FeePerTxKb:                                  genesis.FeePerTxKb,
+		PreSelectBeaconNodeSerializedPubkey:         []string{},
+		PreSelectBeaconNodeSerializedPaymentAddress: []string{},
+		PreSelectShardNodeSerializedPubkey:          []string{},
+		PreSelectShardNodeSerializedPaymentAddress:  []string{},
+		SelectBeaconNodeSerializedPubkeyV2:          map[uint64][]string{},
+		SelectBeaconNodeSerializedPaymentAddressV2:  map[uint64][]string{},
+		SelectShardNodeSerializedPubkeyV2:           map[uint64][]string{},
+		SelectShardNodeSerializedPaymentAddressV2:   map[uint64][]string{},
+		ConsensusAlgorithm:                          common.BlsConsensus,
+		GenesisBlockTime:                            genesis.BlockTime,
+	}
+	if base != nil && base.ConsensusAlgorithm != "" {
+		genesisParams.ConsensusAlgorithm = base.ConsensusAlgorithm
+	}
+	for _, validator := range genesis.BeaconCommittee {
+		genesisParams.PreSelectBeaconNodeSerializedPubkey = append(genesisParams.PreSelectBeaconNodeSerializedPubkey, validator.CommitteePublicKey)
+		genesisParams.PreSelectBeaconNodeSerializedPaymentAddress = append(genesisParams.PreSelectBeaconNodeSerializedPaymentAddress, validator.PaymentAddress)
+	}
+	// the genesis shard committees are assigned in the order of shards
+	for _, committee := range genesis.ShardCommittees {
+		for _, validator := range committee {
+			genesisParams.PreSelectShardNodeSerializedPubkey = append(genesisParams.PreSelectShardNodeSerializedPubkey, validator.CommitteePublicKey)
+			genesisParams.PreSelectShardNodeSerializedPaymentAddress = append(genesisParams.PreSelectShardNodeSerializedPaymentAddress, validator.PaymentAddress)
+		}
+	}
+	return genesisParams
+}
+
+// copy returns a deep copy of the params without the genesis blocks, which are created from the genesis params
+func (p *Params) copy() (*Params, error) {
+	data, err := p.marshalParamSet()
+	if err != nil {
+		return nil, err
+	}
+	params := &Params{}
+	err = json.Unmarshal(data, params)
+	if err != nil {
+		return nil, err
+	}
+	params.PortalTokens = map[string]PortalTokenProcessor{}
+	for tokenID, processor := range p.PortalTokens {
+		params.PortalTokens[tokenID] = processor
+	}
+	return params, nil
+}
+
+// marshalParamSet encodes the params without the genesis blocks and the portal token processors,
+// which can not be decoded back as they are interfaces
+func (p *Params) marshalParamSet() ([]byte, error) {
+	params := *p
+	params.GenesisBeaconBlock = nil
+	params.GenesisShardBlock = nil
+	params.PortalTokens = nil
+	return json.Marshal(params)
+}
+
+// Hash returns the hash of the param set, nodes of a network must have the same hash,
+// the genesis blocks are left out as they are created from the genesis params and node options are ignored
+func (p *Params) Hash() (common.Hash, error) {
+	params := *p
+	params.IsBackup = false
+	params.PreloadAddress = ""
+	data, err := params.marshalParamSet()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	portalTokens, err := json.Marshal(p.PortalTokens)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HashH(append(data, portalTokens...)), nil
+}
+
+// Validate checks the params are consistent so a network can be started with them
+func (p *Params) Validate() error {
+	if p.Name == "" {
+		return errors.New("name of the network must not be empty")
+	}
+	if p.Net == 0 {
+		return errors.New("net of the network must not be 0")
+	}
+	if p.ActiveShards < 1 || p.ActiveShards > maxActiveShards {
+		return fmt.Errorf("active shards %v must be from 1 to %v", p.ActiveShards, maxActiveShards)
+	}
+	if p.MinShardCommitteeSize < 1 || p.MinShardCommitteeSize > p.MaxShardCommitteeSize {
+		return fmt.Errorf("min shard committee size %v must be from 1 to max shard committee size %v", p.MinShardCommitteeSize, p.MaxShardCommitteeSize)
+	}
+	if p.MinBeaconCommitteeSize < 1 || p.MinBeaconCommitteeSize > p.MaxBeaconCommitteeSize {
+		return fmt.Errorf("min beacon committee size %v must be from 1 to max beacon committee size %v", p.MinBeaconCommitteeSize, p.MaxBeaconCommitteeSize)
+	}
+	if p.NumberOfFixedBlockValidators < 0 || p.NumberOfFixedBlockValidators > p.MinShardCommitteeSize {
+		return fmt.Errorf("number of fixed block validators %v must be from 0 to min shard committee size %v", p.NumberOfFixedBlockValidators, p.MinShardCommitteeSize)
+	}
+	if p.Epoch == 0 {
+		return errors.New("epoch must be greater than 0")
+	}
+	if p.RandomTime >= p.Epoch {
+		return fmt.Errorf("random time %v must be less than epoch %v", p.RandomTime, p.Epoch)
+	}
+	if p.Timeslot == 0 {
+		return errors.New("timeslot must be greater than 0")
+	}
+	if p.MinShardBlockInterval <= 0 || p.MaxShardBlockCreation <= 0 {
+		return errors.New("min shard block interval and max shard block creation must be greater than 0")
+	}
+	if p.MinBeaconBlockInterval <= 0 || p.MaxBeaconBlockCreation <= 0 {
+		return errors.New("min beacon block interval and max beacon block creation must be greater than 0")
+	}
+	for i := 1; i < len(p.SlashLevels); i++ {
+		if p.SlashLevels[i].MinRange <= p.SlashLevels[i-1].MinRange {
+			return errors.New("min ranges of slash levels must be ascending")
+		}
+	}
+	if _, ok := p.PortalParams[0]; !ok {
+		return errors.New("portal params must have params from beacon height 0")
+	}
+	for height, portalParams := range p.PortalParams {
+		for _, collateral := range portalParams.SupportedCollateralTokens {
+			if !isValidExternalTokenID(collateral.ExternalTokenID) {
+				return fmt.Errorf("invalid collateral token ID %v of portal params at beacon height %v, it must be 40 hex characters in lower case without 0x", collateral.ExternalTokenID, height)
+			}
+		}
+	}
+	if p.BCHeightBreakPointPortalFeeders != BreakPointNotScheduled {
+		feeders := map[string]bool{}
+		for _, feederAddress := range p.PortalFeederAddresses {
+			if _, err := wallet.Base58CheckDeserialize(feederAddress); err != nil {
+				return errors.Wrapf(err, "invalid portal feeder address %v", feederAddress)
+			}
+			feeders[feederAddress] = true
+		}
+		if len(feeders) < minPortalFeeders {
+			return fmt.Errorf("portal feeders are scheduled at beacon height %v with %v distinct feeders, at least %v are needed", p.BCHeightBreakPointPortalFeeders, len(feeders), minPortalFeeders)
+		}
+	}
+	if _, err := GetETHRelayingChainConfig(p.ETHRelayingHeaderChainID); err != nil {
+		return err
+	}
+	if _, err := p.GetLTCRelayingChainID(); err != nil {
+		return err
+	}
+	for _, ringSize := range p.CommitmentRingSizes {
+		if ringSize < 2 || ringSize&(ringSize-1) != 0 {
+			return fmt.Errorf("commitment ring size %v must be a power of 2", ringSize)
+		}
+	}
+	if _, err := wallet.Base58CheckDeserialize(p.IncognitoDAOAddress); err != nil {
+		return errors.Wrapf(err, "invalid incognito DAO address %v", p.IncognitoDAOAddress)
+	}
+	if _, err := wallet.Base58CheckDeserialize(p.CentralizedWebsitePaymentAddress); err != nil {
+		return errors.Wrapf(err, "invalid centralized website payment address %v", p.CentralizedWebsitePaymentAddress)
+	}
+	if p.GenesisParams == nil {
+		return errors.New("genesis params must not be empty")
+	}
+	return p.validateGenesisParams()
+}
+
+func (p *Params) validateGenesisParams() error {
+	genesisParams := p.GenesisParams
+	if genesisParams.GenesisBlockTime != "" {
+		if _, err := time.Parse(GenesisBlockTimeLayout, genesisParams.GenesisBlockTime); err != nil {
+			return errors.Wrapf(err, "invalid genesis block time %v", genesisParams.GenesisBlockTime)
+		}
+	}
+	err := validateGenesisCommittee("beacon", genesisParams.PreSelectBeaconNodeSerializedPubkey, genesisParams.PreSelectBeaconNodeSerializedPaymentAddress)
+	if err != nil {
+		return err
+	}
+	if len(genesisParams.PreSelectBeaconNodeSerializedPubkey) < p.MinBeaconCommitteeSize {
+		return fmt.Errorf("genesis beacon committee has %v validators, it must have at least min beacon committee size %v", len(genesisParams.PreSelectBeaconNodeSerializedPubkey), p.MinBeaconCommitteeSize)
+	}
+	err = validateGenesisCommittee("shard", genesisParams.PreSelectShardNodeSerializedPubkey, genesisParams.PreSelectShardNodeSerializedPaymentAddress)
+	if err != nil {
+		return err
+	}
+	if len(genesisParams.PreSelectShardNodeSerializedPubkey) != p.ActiveShards*p.MinShardCommitteeSize {
+		return fmt.Errorf("genesis shard committees have %v validators, they must have min shard committee size %v for each of %v shards", len(genesisParams.PreSelectShardNodeSerializedPubkey), p.MinShardCommitteeSize, p.ActiveShards)
+	}
+	// validators are paid the rewards of their shard only, on the shard of their payment address
+	for i, paymentAddress := range genesisParams.PreSelectShardNodeSerializedPaymentAddress {
+		shardID := i / p.MinShardCommitteeSize
+		keyWallet, _ := wallet.Base58CheckDeserialize(paymentAddress)
+		pk := keyWallet.KeySet.PaymentAddress.Pk
+		if len(pk) == 0 || int(pk[len(pk)-1])%p.ActiveShards != shardID {
+			return fmt.Errorf("payment address %v of genesis shard %v committee is not in shard %v", paymentAddress, shardID, shardID)
+		}
+	}
+	for _, epoch := range p.EpochBreakPointSwapNewKey {
+		if len(genesisParams.SelectBeaconNodeSerializedPubkeyV2[epoch]) < p.MinBeaconCommitteeSize ||
+			len(genesisParams.SelectShardNodeSerializedPubkeyV2[epoch]) < p.ActiveShards*p.MinShardCommitteeSize {
+			return fmt.Errorf("genesis params do not have the new committee keys for the key swap at epoch %v", epoch)
+		}
+	}
+	return nil
+}
+
+func validateGenesisCommittee(committee string, committeePublicKeys []string, paymentAddresses []string) error {
+	if len(committeePublicKeys) != len(paymentAddresses) {
+		return fmt.Errorf("genesis %v committee has %v committee public keys and %v payment addresses", committee, len(committeePublicKeys), len(paymentAddresses))
+	}
+	for i, committeePublicKey := range committeePublicKeys {
+		key := incognitokey.CommitteePublicKey{}
+		if err := key.FromBase58(committeePublicKey); err != nil {
+			return errors.Wrapf(err, "invalid committee public key %v of genesis %v committee", committeePublicKey, committee)
+		}
+		if _, err := wallet.Base58CheckDeserialize(paymentAddresses[i]); err != nil {
+			return errors.Wrapf(err, "invalid payment address %v of genesis %v committee", paymentAddresses[i], committee)
+		}
+	}
+	return nil
+}
+
+func isValidExternalTokenID(tokenID string) bool {
+	if len(tokenID) != 40 || strings.ToLower(tokenID) != tokenID {
+		return false
+	}
+	_, err := hex.DecodeString(tokenID)
+	return err == nil
+}
@@ -0,0 +1,216 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGenesisValidators returns count validators with payment addresses in shardID of 2 shards, in any shard if shardID is -1
+func newTestGenesisValidators(t *testing.T, seed string, count int, shardID int) []GenesisValidator {
+	masterKey, err := wallet.NewMasterKey([]byte(seed))
+	assert.Nil(t, err)
+	validators := []GenesisValidator{}
+	for i := 0; len(validators) < count; i++ {
+		child, err := masterKey.NewChildKey(uint32(i))
+		assert.Nil(t, err)
+		pk := child.KeySet.PaymentAddress.Pk
+		if shardID >= 0 && int(pk[len(pk)-1])%2 != shardID {
+			continue
+		}
+		committeeKey, err := incognitokey.NewCommitteeKeyFromSeed(common.HashB(child.KeySet.PrivateKey), child.KeySet.PaymentAddress.Pk)
+		assert.Nil(t, err)
+		committeeKeyStr, err := committeeKey.ToBase58()
+		assert.Nil(t, err)
+		validators = append(validators, GenesisValidator{
+			CommitteePublicKey: committeeKeyStr,
+			PaymentAddress:     child.Base58CheckSerialize(wallet.PaymentAddressType),
+		})
+	}
+	return validators
+}
+
+func newTestChainConfig(t *testing.T) *ChainConfig {
+	return &ChainConfig{
+		Base:   ChainConfigBaseTestnet,
+		Params: json.RawMessage(`{"Name": "devnet", "Net": 100, "ActiveShards": 2, "MinShardCommitteeSize": 2, "MaxShardCommitteeSize": 4, "MinBeaconCommitteeSize": 2, "MaxBeaconCommitteeSize": 4, "NumberOfFixedBlockValidators": 2, "Epoch": 20, "RandomTime": 10}`),
+		Genesis: &GenesisConfig{
+			BlockTime:       "2020-10-19T00:00:00.000Z",
+			BeaconCommittee: newTestGenesisValidators(t, "beacon-seed-for-test", 2, -1),
+			ShardCommittees: [][]GenesisValidator{
+				newTestGenesisValidators(t, "shard0-seed-for-test", 2, 0),
+				newTestGenesisValidators(t, "shard1-seed-for-test", 2, 1),
+			},
+		},
+	}
+}
+
+func TestChainConfig_ToParams(t *testing.T) {
+	SetupParam()
+	config := newTestChainConfig(t)
+	params, err := config.ToParams()
+	assert.Nil(t, err)
+	assert.Equal(t, "devnet", params.Name)
+	assert.Equal(t, uint32(100), params.Net)
+	assert.Equal(t, 2, params.ActiveShards)
+	assert.Equal(t, uint64(20), params.Epoch)
+	// fields not overridden keep the values of the base network
+	assert.Equal(t, ChainTestParam.Timeslot, params.Timeslot)
+	assert.Equal(t, ChainTestParam.MinShardBlockInterval, params.MinShardBlockInterval)
+	assert.Equal(t, len(ChainTestParam.PortalTokens), len(params.PortalTokens))
+	assert.Equal(t, 4, len(params.GenesisParams.PreSelectShardNodeSerializedPubkey))
+	assert.Equal(t, config.Genesis.ShardCommittees[1][0].CommitteePublicKey, params.GenesisParams.PreSelectShardNodeSerializedPubkey[2])
+	// the base params are not changed
+	assert.Equal(t, TestnetName, ChainTestParam.Name)
+
+	params.CreateGenesisBlocks()
+	genesisTime, _ := time.Parse(GenesisBlockTimeLayout, config.Genesis.BlockTime)
+	assert.Equal(t, genesisTime.Unix(), params.GenesisBeaconBlock.Header.Timestamp)
+
+	hash, err := params.Hash()
+	assert.Nil(t, err)
+	otherParams, err := newTestChainConfig(t).ToParams()
+	assert.Nil(t, err)
+	otherParams.IsBackup = true
+	otherHash, err := otherParams.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, hash, otherHash)
+	otherParams.Epoch = 30
+	otherHash, err = otherParams.Hash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestChainConfig_ToParams_Invalid(t *testing.T) {
+	SetupParam()
+	tests := []struct {
+		name   string
+		modify func(config *ChainConfig)
+	}{
+		{"unknown base", func(config *ChainConfig) { config.Base = "devnet" }},
+		{"unknown field", func(config *ChainConfig) { config.Params = json.RawMessage(`{"Epochs": 20}`) }},
+		{"genesis block in params", func(config *ChainConfig) { config.Params = json.RawMessage(`{"GenesisBeaconBlock": null}`) }},
+		{"random time after epoch", func(config *ChainConfig) {
+			config.Params = json.RawMessage(`{"ActiveShards": 2, "MinShardCommitteeSize": 2, "MinBeaconCommitteeSize": 2, "RandomTime": 200}`)
+		}},
+		{"too many shards", func(config *ChainConfig) { config.Params = json.RawMessage(`{"ActiveShards": 9}`) }},
+		{"ring size", func(config *ChainConfig) {
+			config.Params = json.RawMessage(`{"ActiveShards": 2, "MinShardCommitteeSize": 2, "MinBeaconCommitteeSize": 2, "CommitmentRingSizes": [16, 24]}`)
+		}},
+		{"missing shard committee", func(config *ChainConfig) { config.Genesis.ShardCommittees = config.Genesis.ShardCommittees[:1] }},
+		{"invalid committee key", func(config *ChainConfig) { config.Genesis.BeaconCommittee[0].CommitteePublicKey = "abc" }},
+		{"invalid website address", func(config *ChainConfig) {
+			config.Params = json.RawMessage(`{"ActiveShards": 2, "MinShardCommitteeSize": 2, "MinBeaconCommitteeSize": 2, "NumberOfFixedBlockValidators": 2, "CentralizedWebsitePaymentAddress": "abc"}`)
+		}},
+		{"shard validator paid in another shard", func(config *ChainConfig) {
+			config.Genesis.ShardCommittees[0][1], config.Genesis.ShardCommittees[1][0] = config.Genesis.ShardCommittees[1][0], config.Genesis.ShardCommittees[0][1]
+		}},
+		{"invalid block time", func(config *ChainConfig) { config.Genesis.BlockTime = "2020-10-19" }},
+		{"too few portal feeders", func(config *ChainConfig) {
+			config.Params = json.RawMessage(`{"ActiveShards": 2, "MinShardCommitteeSize": 2, "MinBeaconCommitteeSize": 2, "NumberOfFixedBlockValidators": 2, "BCHeightBreakPointPortalFeeders": 100, "PortalFeederAddresses": ["` +
+				TestnetPortalFeeder + `", "` + TestnetPortalFeeder + `", "` + MainnetPortalFeeder + `"]}`)
+		}},
+		{"unknown ETH relaying chain", func(config *ChainConfig) {
+			config.Params = json.RawMessage(`{"ActiveShards": 2, "MinShardCommitteeSize": 2, "MinBeaconCommitteeSize": 2, "NumberOfFixedBlockValidators": 2, "ETHRelayingHeaderChainID": "Ethereum-Kovan"}`)
+		}},
+		{"unknown portal chain", func(config *ChainConfig) {
+			config.PortalTokens = []PortalTokenConfig{{TokenID: common.PortalBTCIDStr, ChainName: "doge", ChainID: "Dogecoin-Testnet"}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestChainConfig(t)
+			tt.modify(config)
+			_, err := config.ToParams()
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestChainConfig_PortalTokens(t *testing.T) {
+	SetupParam()
+	pLTCTokenID := "d5a5fb4bd7b3e04a1b2b1d9c68b8ba5a1e69a6ca0b1a9f36e0ce6cf3b2e3a4b1"
+	config := newTestChainConfig(t)
+	config.PortalTokens = []PortalTokenConfig{{TokenID: pLTCTokenID, ChainName: PortalLTCChainName, ChainID: TestnetLTCChainID, MinConfirmations: 6, Decimals: 8, MinAmount: 100, BreakPoint: 100}}
+	params, err := config.ToParams()
+	assert.Nil(t, err)
+	chainID, err := params.GetLTCRelayingChainID()
+	assert.Nil(t, err)
+	assert.Equal(t, TestnetLTCChainID, chainID)
+
+	// pLTC is only supported from its break point
+	assert.Equal(t, []string{common.PortalBNBIDStr, common.PortalBTCIDStr}, params.GetPortalTokenIDs(99))
+	_, ok := params.GetPortalTokenProcessor(pLTCTokenID, 99)
+	assert.False(t, ok)
+	assert.Equal(t, []string{common.PortalBNBIDStr, pLTCTokenID, common.PortalBTCIDStr}, params.GetPortalTokenIDs(100))
+	processor, ok := params.GetPortalTokenProcessor(pLTCTokenID, 100)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), processor.GetMinAmount())
+	// the base params are not changed
+	_, ok = ChainTestParam.GetPortalTokenProcessor(pLTCTokenID, 100)
+	assert.False(t, ok)
+
+	// the break point is a part of the params hash
+	hash, err := params.Hash()
+	assert.Nil(t, err)
+	config.PortalTokens[0].BreakPoint = 200
+	otherParams, err := config.ToParams()
+	assert.Nil(t, err)
+	otherHash, err := otherParams.Hash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	// all pLTC tokens must be relayed by the same chain
+	config.PortalTokens = append(config.PortalTokens, PortalTokenConfig{TokenID: common.PortalBTCIDStr, ChainName: PortalLTCChainName, ChainID: MainnetLTCChainID})
+	_, err = config.ToParams()
+	assert.NotNil(t, err)
+}
+
+func TestLoadChainConfigFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chainconfig")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "devnet.yaml")
+	err = ioutil.WriteFile(path, []byte(`
+Base: testnet2
+Params:
+  Name: devnet
+  PortalParams:
+    0:
+      TP120: 120
+Genesis:
+  BlockTime: "2020-10-19T00:00:00.000Z"
+  BeaconCommittee:
+    - CommitteePublicKey: key
+      PaymentAddress: address
+`), 0644)
+	assert.Nil(t, err)
+	config, err := LoadChainConfigFromFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainConfigBaseTestnet2, config.Base)
+	assert.JSONEq(t, `{"Name": "devnet", "PortalParams": {"0": {"TP120": 120}}}`, string(config.Params))
+	assert.Equal(t, []GenesisValidator{{CommitteePublicKey: "key", PaymentAddress: "address"}}, config.Genesis.BeaconCommittee)
+
+	path = filepath.Join(dir, "devnet.json")
+	err = ioutil.WriteFile(path, []byte(`{"Base": "mainnet", "Unknown": 1}`), 0644)
+	assert.Nil(t, err)
+	_, err = LoadChainConfigFromFile(path)
+	assert.NotNil(t, err)
+}
+
+// networks of chain configs pay the centralized website address of their params
+func TestBlockChain_GetCentralizedWebsitePaymentAddress(t *testing.T) {
+	SetupParam()
+	params, err := newTestChainConfig(t).ToParams()
+	assert.Nil(t, err)
+	bc := &BlockChain{config: Config{ChainParams: params}}
+	assert.Equal(t, ChainTestParam.CentralizedWebsitePaymentAddress, bc.GetCentralizedWebsitePaymentAddress(1000000))
+}
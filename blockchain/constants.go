@@ -35,6 +35,12 @@ const (
 	burningAddress2 = "12RxahVABnAVCGP3LGwCn8jkQxgw7z1x14wztHzn455TTVpi1wBq9YGwkRMQg3J4e657AbAnCvYCJSdA9czBUNuCKwGSRQt55Xwz8WA"
 )
 
+// portal exchange rate feeders
+const (
+	maxPortalFeederSubmissions = uint64(100) // recent submissions kept in the history of a feeder
+	minPortalFeeders           = 3           // authorized feeders needed to aggregate final exchange rates by the median
+)
+
 // CONSTANT for network MAINNET
 const (
 	// ------------- Mainnet ---------------------------------------------
@@ -74,6 +80,8 @@ const (
 	MainnetBNBChainID        = "Binance-Chain-Tigris"
 	MainnetBTCChainID        = "Bitcoin-Mainnet"
 	MainnetBTCDataFolderName = "btcrelayingv7"
+	MainnetETHChainID        = "Ethereum-Mainnet"
+	MainnetLTCChainID        = "Litecoin-Mainnet"
 
 	// BNB fullnode
 	MainnetBNBFullNodeHost     = "dataseed1.ninicoin.io"
@@ -132,6 +140,8 @@ const (
 	TestnetBNBChainID        = "Binance-Chain-Ganges"
 	TestnetBTCChainID        = "Bitcoin-Testnet"
 	TestnetBTCDataFolderName = "btcrelayingv14"
+	TestnetETHChainID        = "Ethereum-Goerli"
+	TestnetLTCChainID        = "Litecoin-Testnet4"
 
 	// BNB fullnode
 	TestnetBNBFullNodeHost     = "data-seed-pre-0-s3.binance.org"
@@ -174,6 +184,8 @@ const (
 	Testnet2BNBChainID        = "Binance-Chain-Ganges"
 	Testnet2BTCChainID        = "Bitcoin-Testnet-2"
 	Testnet2BTCDataFolderName = "btcrelayingv11"
+	Testnet2ETHChainID        = "Ethereum-Goerli"
+	Testnet2LTCChainID        = "Litecoin-Testnet4"
 
 	// BNB fullnode
 	Testnet2BNBFullNodeHost     = "data-seed-pre-0-s3.binance.org"
@@ -326,6 +338,9 @@ var (
 	shardVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("shard/verify/postprocessing", nil)
 	shardStoreBlockTimer                   = metrics.NewRegisteredTimer("shard/storeblock", nil)
 	shardUpdateBestStateTimer              = metrics.NewRegisteredTimer("shard/updatebeststate", nil)
+	shardVerifyTxSanityTimer               = metrics.NewRegisteredTimer("shard/verify/txs/sanity", nil)
+	shardVerifyTxProofTimer                = metrics.NewRegisteredTimer("shard/verify/txs/proof", nil)
+	shardVerifyTxStatefulTimer             = metrics.NewRegisteredTimer("shard/verify/txs/stateful", nil)
 
 	beaconInsertBlockTimer                  = metrics.NewRegisteredTimer("beacon/insert", nil)
 	beaconVerifyPreprocesingTimer           = metrics.NewRegisteredTimer("beacon/verify/preprocessing", nil)
@@ -334,6 +349,8 @@ var (
 	beaconVerifyPostProcessingTimer         = metrics.NewRegisteredTimer("beacon/verify/postprocessing", nil)
 	beaconStoreBlockTimer                   = metrics.NewRegisteredTimer("beacon/storeblock", nil)
 	beaconUpdateBestStateTimer              = metrics.NewRegisteredTimer("beacon/updatebeststate", nil)
+
+	beaconInsertBlockLatencyTimer = metrics.NewRegisteredTimer(metrics.LabeledName(metrics.BlockInsertTime, "chain", "beacon"), nil)
 )
 
 const (
@@ -26,7 +26,9 @@ type TxPool interface {
 	RemoveCandidateList([]string)
 	EmptyPool() bool
 	MaybeAcceptTransactionForBlockProducing(metadata.Transaction, int64, *ShardBestState) (*metadata.TxDesc, error)
-	MaybeAcceptBatchTransactionForBlockProducing(byte, []metadata.Transaction, int64, *ShardBestState) ([]*metadata.TxDesc, error)
+	MaybeAcceptBatchTransactionForBlockProducing(context.Context, byte, []metadata.Transaction, int64, *ShardBestState) ([]*metadata.TxDesc, error)
+	// MaybeAcceptVerifiedBatchTransactionForBlockProducing skips the sanity data, signatures and proofs of the txs which are verified before
+	MaybeAcceptVerifiedBatchTransactionForBlockProducing(context.Context, byte, []metadata.Transaction, int64, *ShardBestState) ([]*metadata.TxDesc, error)
 	//CheckTransactionFee
 	// CheckTransactionFee(tx metadata.Transaction) (uint64, error)
 	// Check tx validate by it self
@@ -70,8 +72,8 @@ type Highway interface {
 }
 
 type Syncker interface {
-	GetCrossShardBlocksForShardProducer(toShard byte, list map[byte][]uint64) map[byte][]interface{}
-	GetCrossShardBlocksForShardValidator(toShard byte, list map[byte][]uint64) (map[byte][]interface{}, error)
+	GetCrossShardBlocksForShardProducer(ctx context.Context, toShard byte, list map[byte][]uint64) map[byte][]interface{}
+	GetCrossShardBlocksForShardValidator(ctx context.Context, toShard byte, list map[byte][]uint64) (map[byte][]interface{}, error)
 	SyncMissingBeaconBlock(ctx context.Context, peerID string, fromHash common.Hash)
 	SyncMissingShardBlock(ctx context.Context, peerID string, sid byte, fromHash common.Hash)
 }
@@ -1,11 +1,18 @@
 package blockchain
 
 import (
+	"math"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/relaying/bnb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
+// BreakPointNotScheduled is the break point height of a hard fork which is not scheduled on a network yet,
+// the forked rules stay disabled until a release sets its height, devnets set it in their chain config
+const BreakPointNotScheduled uint64 = math.MaxUint64
+
 type SlashLevel struct {
 	MinRange        uint8
 	PunishedEpoches uint8
@@ -30,6 +37,8 @@ type PortalParams struct {
 	SupportedCollateralTokens            []PortalCollateral
 	MinPortalFee                         uint64 // nano PRV
 	MinUnlockOverRateCollaterals         uint64
+	ExchangeRatesWindow                  uint64 // number of beacon blocks that the latest rates of feeders are used for the final rates
+	MaxPercentExchangeRateDeviation      uint64 // rates deviating from the final rates more than this percent are rejected, 0 is no limit
 }
 
 /*
@@ -79,6 +88,7 @@ type Params struct {
 	PortalParams                     map[uint64]PortalParams
 	PortalTokens                     map[string]PortalTokenProcessor
 	PortalFeederAddress              string
+	PortalFeederAddresses            []string // authorized feeders from BCHeightBreakPointPortalFeeders
 	EpochBreakPointSwapNewKey        []uint64
 	IsBackup                         bool
 	PreloadAddress                   string
@@ -87,6 +97,16 @@ type Params struct {
 	BCHeightBreakPointNewZKP         uint64
 	PortalETHContractAddressStr      string // smart contract of ETH for portal
 	BCHeightBreakPointPortalV3       uint64
+	BCHeightBreakPointRingSize       uint64 // from this beacon height, transactions version 2 can use larger ring sizes
+	CommitmentRingSizes              []int  // ring sizes supported by transactions version 2
+	ETHRelayingHeaderChainID         string
+	BCHeightBreakPointETHRelaying    uint64 // from this beacon height, ETH issuance reads headers from the relayed ETH header chain
+	BCHeightBreakPointPortalFeeders  uint64 // from this beacon height, final exchange rates are aggregated from multiple feeders
+	BCHeightBreakPointPDELimitOrders uint64 // from this beacon height, pde limit orders can be placed and cancelled
+	MaxPDELimitOrdersPerPair         int    // max number of resting limit orders of a pool pair
+	MaxPDELimitOrdersPerTrader       int    // max number of resting limit orders of a trader address
+	MaxPDELimitOrderLifetime         uint64 // max number of beacon blocks from placing a limit order to its expiry
+	BCHeightBreakPointBNBRelaying    uint64 // from this beacon height, relayed BNB headers are processed into the BNB header chain, with validator set changes across BNB epochs
 }
 
 type GenesisParams struct {
@@ -103,6 +123,7 @@ type GenesisParams struct {
 	SelectShardNodeSerializedPaymentAddressV2   map[uint64][]string
 	PreSelectShardNode                          []string
 	ConsensusAlgorithm                          string
+	GenesisBlockTime                            string // overrides the genesis block time of the network if not empty
 }
 
 var ChainTestParam = Params{}
@@ -118,12 +139,18 @@ func initPortalTokensForTestNet() map[string]PortalTokenProcessor {
 	return map[string]PortalTokenProcessor{
 		common.PortalBTCIDStr: &PortalBTCTokenProcessor{
 			&PortalToken{
-				ChainID: TestnetBTCChainID,
+				ChainID:          TestnetBTCChainID,
+				MinConfirmations: btcrelaying.BTCBlockConfirmations,
+				Decimals:         8,
+				MinAmount:        10,
 			},
 		},
 		common.PortalBNBIDStr: &PortalBNBTokenProcessor{
 			&PortalToken{
-				ChainID: TestnetBNBChainID,
+				ChainID:          TestnetBNBChainID,
+				MinConfirmations: bnb.MinConfirmationsBlock,
+				Decimals:         8,
+				MinAmount:        10,
 			},
 		},
 	}
@@ -133,12 +160,18 @@ func initPortalTokensForMainNet() map[string]PortalTokenProcessor {
 	return map[string]PortalTokenProcessor{
 		common.PortalBTCIDStr: &PortalBTCTokenProcessor{
 			&PortalToken{
-				ChainID: MainnetBTCChainID,
+				ChainID:          MainnetBTCChainID,
+				MinConfirmations: btcrelaying.BTCBlockConfirmations,
+				Decimals:         8,
+				MinAmount:        10,
 			},
 		},
 		common.PortalBNBIDStr: &PortalBNBTokenProcessor{
 			&PortalToken{
-				ChainID: MainnetBNBChainID,
+				ChainID:          MainnetBNBChainID,
+				MinConfirmations: bnb.MinConfirmationsBlock,
+				Decimals:         8,
+				MinAmount:        10,
 			},
 		},
 	}
@@ -252,6 +285,8 @@ func SetupParam() {
 				SupportedCollateralTokens:            getSupportedPortalCollateralsTestnet(), // todo: need to be updated before deploying
 				MinPortalFee:                         100,
 				MinUnlockOverRateCollaterals:         25,
+				ExchangeRatesWindow:                  60, // 10 minutes
+				MaxPercentExchangeRateDeviation:      20,
 			},
 		},
 		PortalTokens:                initPortalTokensForTestNet(),
@@ -264,6 +299,21 @@ func SetupParam() {
 
 		PortalETHContractAddressStr: "0x6D53de7aFa363F779B5e125876319695dC97171E", // todo: update sc address
 		BCHeightBreakPointPortalV3:  30158,
+		BCHeightBreakPointRingSize:  BreakPointNotScheduled,
+		CommitmentRingSizes:         []int{16, 32, 64},
+
+		ETHRelayingHeaderChainID:      TestnetETHChainID,
+		BCHeightBreakPointETHRelaying: BreakPointNotScheduled,
+
+		// scheduled once at least minPortalFeeders independent feeders are declared in PortalFeederAddresses
+		BCHeightBreakPointPortalFeeders: BreakPointNotScheduled,
+
+		BCHeightBreakPointPDELimitOrders: BreakPointNotScheduled,
+		MaxPDELimitOrdersPerPair:         100,
+		MaxPDELimitOrdersPerTrader:       10,
+		MaxPDELimitOrderLifetime:         60480, // a week of 10 second beacon blocks
+
+		BCHeightBreakPointBNBRelaying: BreakPointNotScheduled,
 	}
 	// END TESTNET
 
@@ -343,6 +393,8 @@ func SetupParam() {
 				MinPercentRedeemFee:                  0.01,
 				SupportedCollateralTokens:            getSupportedPortalCollateralsTestnet2(),
 				MinPortalFee:                         100,
+				ExchangeRatesWindow:                  60, // 10 minutes
+				MaxPercentExchangeRateDeviation:      20,
 			},
 		},
 		PortalTokens:                initPortalTokensForTestNet(),
@@ -354,6 +406,21 @@ func SetupParam() {
 		ETHRemoveBridgeSigEpoch:     2085,
 		PortalETHContractAddressStr: "0xF7befD2806afD96D3aF76471cbCa1cD874AA1F46",   // todo: update sc address
 		BCHeightBreakPointPortalV3:  1328816,
+		BCHeightBreakPointRingSize:  BreakPointNotScheduled,
+		CommitmentRingSizes:         []int{16, 32, 64},
+
+		ETHRelayingHeaderChainID:      Testnet2ETHChainID,
+		BCHeightBreakPointETHRelaying: BreakPointNotScheduled,
+
+		// scheduled once at least minPortalFeeders independent feeders are declared in PortalFeederAddresses
+		BCHeightBreakPointPortalFeeders: BreakPointNotScheduled,
+
+		BCHeightBreakPointPDELimitOrders: BreakPointNotScheduled,
+		MaxPDELimitOrdersPerPair:         100,
+		MaxPDELimitOrdersPerTrader:       10,
+		MaxPDELimitOrderLifetime:         60480, // a week of 10 second beacon blocks
+
+		BCHeightBreakPointBNBRelaying: BreakPointNotScheduled,
 	}
 	// END TESTNET-2
 
@@ -431,6 +498,8 @@ func SetupParam() {
 				MinPercentRedeemFee:                  0.01,
 				SupportedCollateralTokens:            getSupportedPortalCollateralsMainnet(),
 				MinPortalFee:                         100,
+				ExchangeRatesWindow:                  15, // 10 minutes
+				MaxPercentExchangeRateDeviation:      20,
 			},
 		},
 		PortalTokens:                initPortalTokensForMainNet(),
@@ -442,6 +511,21 @@ func SetupParam() {
 		ETHRemoveBridgeSigEpoch:     1973,
 		PortalETHContractAddressStr: "", // todo: update sc address
 		BCHeightBreakPointPortalV3:  40, // todo: should update before deploying
+		BCHeightBreakPointRingSize:  BreakPointNotScheduled,
+		CommitmentRingSizes:         []int{16, 32, 64},
+
+		ETHRelayingHeaderChainID:      MainnetETHChainID,
+		BCHeightBreakPointETHRelaying: BreakPointNotScheduled,
+
+		// scheduled once at least minPortalFeeders independent feeders are declared in PortalFeederAddresses
+		BCHeightBreakPointPortalFeeders: BreakPointNotScheduled,
+
+		BCHeightBreakPointPDELimitOrders: BreakPointNotScheduled,
+		MaxPDELimitOrdersPerPair:         100,
+		MaxPDELimitOrdersPerTrader:       10,
+		MaxPDELimitOrderLifetime:         60480, // a week of 10 second beacon blocks
+
+		BCHeightBreakPointBNBRelaying: BreakPointNotScheduled,
 	}
 	if IsTestNet {
 		if !IsTestNet2 {
@@ -464,6 +548,9 @@ func (p *Params) CreateGenesisBlocks() {
 	case Testnet2:
 		blockTime = Testnet2GenesisBlockTime
 	}
+	if p.GenesisParams.GenesisBlockTime != "" {
+		blockTime = p.GenesisParams.GenesisBlockTime
+	}
 	p.GenesisBeaconBlock = CreateGenesisBeaconBlock(1, uint16(p.Net), blockTime, p.GenesisParams)
 	p.GenesisShardBlock = CreateGenesisShardBlock(1, uint16(p.Net), blockTime, p.GenesisParams)
 	return
@@ -0,0 +1,154 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+)
+
+// maximum slippage in basis points, 100%
+const maxPDESlippageBasisPoints = 10000
+
+// PDETradeHop is a trade on a pool pair of a simulated trade
+type PDETradeHop struct {
+	PoolPairKey      string
+	TokenIDToSellStr string
+	TokenIDToBuyStr  string
+	SellAmount       uint64
+	ReceiveAmount    uint64
+	AddingFee        uint64
+}
+
+// PDETradeSimulation is the result of simulating a cross pool trade against a pde state
+type PDETradeSimulation struct {
+	TokenIDToSellStr string
+	TokenIDToBuyStr  string
+	SellAmount       uint64
+	ReceiveAmount    uint64
+	// amount received if the trade did not move the prices of the pools
+	SpotReceiveAmount uint64
+	TradingFee        uint64
+	// percentage of SpotReceiveAmount lost because of the trade size
+	PriceImpact float64
+	Hops        []*PDETradeHop
+}
+
+// buildSequentialTrades returns the trades on pool pairs which a cross pool trade request goes through,
+// a trade of two tokens other than PRV goes through PRV
+func buildSequentialTrades(tokenIDToSellStr string, tokenIDToBuyStr string, sellAmount uint64) []*tradeInfo {
+	prvIDStr := common.PRVCoinID.String()
+	if isTradingFairContainsPRV(tokenIDToSellStr, tokenIDToBuyStr) { // direct trade
+		return []*tradeInfo{
+			&tradeInfo{
+				tokenIDToBuyStr:  tokenIDToBuyStr,
+				tokenIDToSellStr: tokenIDToSellStr,
+				sellAmount:       sellAmount,
+			},
+		}
+	}
+	// cross pool trade
+	return []*tradeInfo{
+		&tradeInfo{
+			tokenIDToBuyStr:  prvIDStr,
+			tokenIDToSellStr: tokenIDToSellStr,
+			sellAmount:       sellAmount,
+		},
+		&tradeInfo{
+			tokenIDToBuyStr:  tokenIDToBuyStr,
+			tokenIDToSellStr: prvIDStr,
+			sellAmount:       uint64(0),
+		},
+	}
+}
+
+// SimulatePDECrossPoolTrade runs the pool math of a cross pool trade request against currentPDEState
+// without changing it, tradingFee is split to the pool pairs as the beacon producer does
+func SimulatePDECrossPoolTrade(
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+	tradingFee uint64,
+) (*PDETradeSimulation, error) {
+	if tokenIDToSellStr == tokenIDToBuyStr {
+		return nil, errors.New("token to sell and token to buy must be different")
+	}
+	if sellAmount == 0 {
+		return nil, errors.New("sell amount must be greater than zero")
+	}
+	if currentPDEState == nil || len(currentPDEState.PDEPoolPairs) == 0 {
+		return nil, errors.New("there is no pool pair in pde state")
+	}
+
+	sequentialTrades := buildSequentialTrades(tokenIDToSellStr, tokenIDToBuyStr, sellAmount)
+	proportionalFee := tradingFee / uint64(len(sequentialTrades))
+	spotAmt := new(big.Float).SetUint64(sellAmount)
+	amt := sellAmount
+	hops := []*PDETradeHop{}
+	for idx, tradeInf := range sequentialTrades {
+		if !isPoolPairExisting(beaconHeight, currentPDEState, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr) {
+			return nil, fmt.Errorf("pool pair of %v and %v does not exist or is empty", tradeInf.tokenIDToSellStr, tradeInf.tokenIDToBuyStr)
+		}
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
+		pdePoolPair := currentPDEState.PDEPoolPairs[pairKey]
+
+		tokenPoolValueToBuy := pdePoolPair.Token1PoolValue
+		tokenPoolValueToSell := pdePoolPair.Token2PoolValue
+		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			tokenPoolValueToBuy = pdePoolPair.Token2PoolValue
+			tokenPoolValueToSell = pdePoolPair.Token1PoolValue
+		}
+		spotAmt.Mul(spotAmt, new(big.Float).SetUint64(tokenPoolValueToBuy))
+		spotAmt.Quo(spotAmt, new(big.Float).SetUint64(tokenPoolValueToSell))
+
+		receiveAmt, _, _ := calcTradeValue(pdePoolPair, tradeInf.tokenIDToSellStr, amt)
+		if receiveAmt == 0 {
+			return nil, fmt.Errorf("pool pair of %v and %v does not have enough liquidity", tradeInf.tokenIDToSellStr, tradeInf.tokenIDToBuyStr)
+		}
+		addingFee := proportionalFee
+		if idx == len(sequentialTrades)-1 {
+			addingFee = tradingFee - uint64(len(sequentialTrades)-1)*proportionalFee
+		}
+		hops = append(hops, &PDETradeHop{
+			PoolPairKey:      pairKey,
+			TokenIDToSellStr: tradeInf.tokenIDToSellStr,
+			TokenIDToBuyStr:  tradeInf.tokenIDToBuyStr,
+			SellAmount:       amt,
+			ReceiveAmount:    receiveAmt,
+			AddingFee:        addingFee,
+		})
+		amt = receiveAmt
+	}
+
+	spotReceiveAmount, _ := spotAmt.Uint64()
+	priceImpact := float64(0)
+	if spotReceiveAmount > amt {
+		priceImpact = float64(spotReceiveAmount-amt) * 100 / float64(spotReceiveAmount)
+	}
+	return &PDETradeSimulation{
+		TokenIDToSellStr:  tokenIDToSellStr,
+		TokenIDToBuyStr:   tokenIDToBuyStr,
+		SellAmount:        sellAmount,
+		ReceiveAmount:     amt,
+		SpotReceiveAmount: spotReceiveAmount,
+		TradingFee:        tradingFee,
+		PriceImpact:       priceImpact,
+		Hops:              hops,
+	}, nil
+}
+
+// GetMinAcceptableAmount returns the min acceptable amount of a trade request which is refunded
+// if the receive amount goes down by more than slippageBasisPoints (1/100 of a percent)
+func (s *PDETradeSimulation) GetMinAcceptableAmount(slippageBasisPoints uint64) (uint64, error) {
+	if slippageBasisPoints > maxPDESlippageBasisPoints {
+		return 0, fmt.Errorf("slippage %v basis points is greater than %v", slippageBasisPoints, maxPDESlippageBasisPoints)
+	}
+	minAcceptableAmount := new(big.Int).SetUint64(s.ReceiveAmount)
+	minAcceptableAmount.Mul(minAcceptableAmount, new(big.Int).SetUint64(maxPDESlippageBasisPoints-slippageBasisPoints))
+	minAcceptableAmount.Div(minAcceptableAmount, big.NewInt(maxPDESlippageBasisPoints))
+	return minAcceptableAmount.Uint64(), nil
+}
@@ -0,0 +1,126 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	quoteTestTokenAIDStr = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	quoteTestTokenBIDStr = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	quoteTestTokenCIDStr = "cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+)
+
+// newQuoteTestPDEState returns a pde state with the pools PRV/A (1M PRV, 2M A) and PRV/B (4M PRV, 1M B) at beaconHeight
+func newQuoteTestPDEState(beaconHeight uint64) *CurrentPDEState {
+	prvIDStr := common.PRVCoinID.String()
+	currentPDEState := &CurrentPDEState{
+		WaitingPDEContributions:        make(map[string]*rawdbv2.PDEContribution),
+		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		PDEPoolPairs:                   make(map[string]*rawdbv2.PDEPoolForPair),
+		PDEShares:                      make(map[string]uint64),
+		PDETradingFees:                 make(map[string]uint64),
+	}
+	currentPDEState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, quoteTestTokenAIDStr))] =
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 1000000, quoteTestTokenAIDStr, 2000000)
+	currentPDEState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, quoteTestTokenBIDStr))] =
+		rawdbv2.NewPDEPoolForPair(prvIDStr, 4000000, quoteTestTokenBIDStr, 1000000)
+	return currentPDEState
+}
+
+func TestSimulatePDECrossPoolTrade_Direct(t *testing.T) {
+	beaconHeight := uint64(100)
+	currentPDEState := newQuoteTestPDEState(beaconHeight)
+	simulation, err := SimulatePDECrossPoolTrade(currentPDEState, beaconHeight, quoteTestTokenAIDStr, common.PRVIDStr, 10000, 7)
+	assert.Nil(t, err)
+	// 2M * 1M / (2M + 10000) rounded up is 995025 PRV left in the pool
+	assert.Equal(t, uint64(4975), simulation.ReceiveAmount)
+	assert.Equal(t, uint64(5000), simulation.SpotReceiveAmount)
+	assert.InDelta(t, 0.5, simulation.PriceImpact, 1e-9)
+	assert.Equal(t, 1, len(simulation.Hops))
+	assert.Equal(t, uint64(7), simulation.Hops[0].AddingFee)
+	assert.Equal(t, string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, common.PRVIDStr, quoteTestTokenAIDStr)), simulation.Hops[0].PoolPairKey)
+
+	// buying the token of the pool
+	simulation, err = SimulatePDECrossPoolTrade(currentPDEState, beaconHeight, common.PRVIDStr, quoteTestTokenAIDStr, 10000, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(19801), simulation.ReceiveAmount)
+	assert.Equal(t, uint64(20000), simulation.SpotReceiveAmount)
+
+	// the pde state is not changed
+	assert.Equal(t, newQuoteTestPDEState(beaconHeight), currentPDEState)
+}
+
+func TestSimulatePDECrossPoolTrade_CrossPool(t *testing.T) {
+	beaconHeight := uint64(100)
+	currentPDEState := newQuoteTestPDEState(beaconHeight)
+	simulation, err := SimulatePDECrossPoolTrade(currentPDEState, beaconHeight, quoteTestTokenAIDStr, quoteTestTokenBIDStr, 10000, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(simulation.Hops))
+	// A is sold for PRV, then the PRV is sold for B
+	assert.Equal(t, quoteTestTokenAIDStr, simulation.Hops[0].TokenIDToSellStr)
+	assert.Equal(t, common.PRVIDStr, simulation.Hops[0].TokenIDToBuyStr)
+	assert.Equal(t, uint64(4975), simulation.Hops[0].ReceiveAmount)
+	assert.Equal(t, common.PRVIDStr, simulation.Hops[1].TokenIDToSellStr)
+	assert.Equal(t, uint64(4975), simulation.Hops[1].SellAmount)
+	assert.Equal(t, uint64(1242), simulation.Hops[1].ReceiveAmount)
+	assert.Equal(t, uint64(1242), simulation.ReceiveAmount)
+	assert.Equal(t, uint64(1250), simulation.SpotReceiveAmount)
+	assert.InDelta(t, 0.64, simulation.PriceImpact, 1e-9)
+	// the trading fee is split to the pools, the last one gets the remainder
+	assert.Equal(t, uint64(3), simulation.Hops[0].AddingFee)
+	assert.Equal(t, uint64(4), simulation.Hops[1].AddingFee)
+}
+
+func TestSimulatePDECrossPoolTrade_Invalid(t *testing.T) {
+	beaconHeight := uint64(100)
+	currentPDEState := newQuoteTestPDEState(beaconHeight)
+	emptyPoolState := newQuoteTestPDEState(beaconHeight)
+	emptyPoolState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, common.PRVIDStr, quoteTestTokenBIDStr))].Token2PoolValue = 0
+	tests := []struct {
+		name             string
+		currentPDEState  *CurrentPDEState
+		beaconHeight     uint64
+		tokenIDToSellStr string
+		tokenIDToBuyStr  string
+		sellAmount       uint64
+	}{
+		{"same tokens", currentPDEState, beaconHeight, quoteTestTokenAIDStr, quoteTestTokenAIDStr, 10000},
+		{"zero sell amount", currentPDEState, beaconHeight, quoteTestTokenAIDStr, common.PRVIDStr, 0},
+		{"no pde state", nil, beaconHeight, quoteTestTokenAIDStr, common.PRVIDStr, 10000},
+		{"pools of another beacon height", currentPDEState, beaconHeight + 1, quoteTestTokenAIDStr, common.PRVIDStr, 10000},
+		{"missing direct pool", currentPDEState, beaconHeight, quoteTestTokenCIDStr, common.PRVIDStr, 10000},
+		{"missing second pool", currentPDEState, beaconHeight, quoteTestTokenAIDStr, quoteTestTokenCIDStr, 10000},
+		{"missing first pool", currentPDEState, beaconHeight, quoteTestTokenCIDStr, quoteTestTokenBIDStr, 10000},
+		{"empty pool", emptyPoolState, beaconHeight, quoteTestTokenAIDStr, quoteTestTokenBIDStr, 10000},
+		{"receive nothing", currentPDEState, beaconHeight, quoteTestTokenAIDStr, common.PRVIDStr, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SimulatePDECrossPoolTrade(tt.currentPDEState, tt.beaconHeight, tt.tokenIDToSellStr, tt.tokenIDToBuyStr, tt.sellAmount, 0)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestPDETradeSimulation_GetMinAcceptableAmount(t *testing.T) {
+	simulation := &PDETradeSimulation{ReceiveAmount: 4975}
+	tests := []struct {
+		slippageBasisPoints uint64
+		want                uint64
+		wantErr             bool
+	}{
+		{0, 4975, false},
+		{100, 4925, false}, // 1%, rounded down
+		{maxPDESlippageBasisPoints, 0, false},
+		{maxPDESlippageBasisPoints + 1, 0, true},
+	}
+	for _, tt := range tests {
+		minAcceptableAmount, err := simulation.GetMinAcceptableAmount(tt.slippageBasisPoints)
+		assert.Equal(t, tt.wantErr, err != nil)
+		assert.Equal(t, tt.want, minAcceptableAmount)
+	}
+}
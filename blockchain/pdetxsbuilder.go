@@ -705,3 +705,101 @@ func (blockGenerator *BlockGenerator) buildPDEFeeWithdrawalTx(
 	}
 	return resTx, nil
 }
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderFilledTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	var filledContent metadata.PDELimitOrderFilledContent
+	err := json.Unmarshal([]byte(contentStr), &filledContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order filled content: %+v", err)
+		return nil, nil
+	}
+	if shardID != filledContent.ShardID {
+		return nil, nil
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		instStatus,
+		filledContent.OrderID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		filledContent.TraderAddressStr,
+		filledContent.ReceiveAmount,
+		filledContent.TokenIDToBuyStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing limit order filled response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Info("[PDE Limit Order] Create filled tx ok.")
+	return resTx, nil
+}
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderRefundTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	var refundContent metadata.PDERefundLimitOrder
+	err := json.Unmarshal([]byte(contentStr), &refundContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order refund content: %+v", err)
+		return nil, nil
+	}
+	if shardID != refundContent.ShardID {
+		return nil, nil
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		instStatus,
+		refundContent.OrderID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		refundContent.TraderAddressStr,
+		refundContent.Amount,
+		refundContent.TokenIDStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing limit order refund response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Info("[PDE Limit Order] Create refunded tx ok.")
+	return resTx, nil
+}
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderIssuanceTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	Logger.log.Info("[PDE Limit Order] Starting...")
+	switch instStatus {
+	case common.PDELimitOrderFilledChainStatus:
+		return blockGenerator.buildPDELimitOrderFilledTx(instStatus, contentStr, producerPrivateKey, shardID, shardView, beaconView)
+	case common.PDELimitOrderFeeRefundChainStatus, common.PDELimitOrderSellingTokenRefundChainStatus:
+		return blockGenerator.buildPDELimitOrderRefundTx(instStatus, contentStr, producerPrivateKey, shardID, shardView, beaconView)
+	}
+	return nil, nil
+}
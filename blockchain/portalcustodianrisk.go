@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// CustodianTokenRisk is the liquidation risk of a custodian for one portal token,
+// the amounts in usdt are in nano unit and the ratios are in percent
+type CustodianTokenRisk struct {
+	PortalTokenID          string
+	LockedCollateralInUSDT uint64
+	HoldingPubTokenAmount  uint64
+	HoldingPubTokenInUSDT  uint64
+	PubTokenRate           uint64
+	Ratio                  uint64
+	LiquidationRatio       uint64
+	// the usdt rate of the portal token and its increase in basis points from the current rate,
+	// where the custodian is liquidated if the collateral rates do not change
+	LiquidationPubTokenRate    uint64
+	PriceIncreaseToLiquidation uint64
+	IsLiquidatable             bool
+}
+
+// convertLockedPRVCollateralsToUSDTExcludeWPorting converts the locked prv collaterals of a custodian
+// except the ones of waiting porting requests to usdt, the way the liquidation before portal v3 counts them
+func convertLockedPRVCollateralsToUSDTExcludeWPorting(
+	exchangeTool *PortalExchangeRateTool,
+	custodianState *statedb.CustodianState,
+	portalState *CurrentPortalState,
+) (map[string]uint64, error) {
+	lockedPRV := make(map[string]uint64)
+	for tokenID, amount := range custodianState.GetLockedAmountCollateral() {
+		lockedPRV[tokenID] = amount
+	}
+	for _, waitingPortingReq := range portalState.WaitingPortingRequests {
+		for _, matchingCus := range waitingPortingReq.Custodians() {
+			if matchingCus.IncAddress == custodianState.GetIncognitoAddress() {
+				lockedPRV[waitingPortingReq.TokenID()] -= matchingCus.LockedAmountCollateral
+				break
+			}
+		}
+	}
+	result := make(map[string]uint64)
+	for tokenID, amount := range lockedPRV {
+		amountInUSDT, err := exchangeTool.ConvertToUSD(common.PRVIDStr, amount)
+		if err != nil {
+			return nil, fmt.Errorf("Error when converting locked prv collaterals of %v to usdt: %v", tokenID, err)
+		}
+		result[tokenID] = amountInUSDT
+	}
+	return result, nil
+}
+
+// CalCustodianRisk calculates the liquidation risk of a custodian by the same ratio between locked collaterals
+// and holding public tokens (including the ones in waiting and matched redeem requests) as the liquidation by exchange rates,
+// only prv collaterals are counted before portal v3
+func CalCustodianRisk(
+	portalState *CurrentPortalState,
+	custodianState *statedb.CustodianState,
+	portalParams PortalParams,
+	isPortalV3 bool,
+) ([]CustodianTokenRisk, error) {
+	if portalState.FinalExchangeRatesState == nil {
+		return nil, errors.New("Final exchange rate is empty")
+	}
+	exchangeTool := NewPortalExchangeRateTool(portalState.FinalExchangeRatesState, portalParams.SupportedCollateralTokens)
+
+	var lockedAmount map[string]uint64
+	var err error
+	if isPortalV3 {
+		lockedAmount, err = convertLockCollateralsToUSDTExcludeWPorting(exchangeTool, custodianState, portalState)
+	} else {
+		lockedAmount, err = convertLockedPRVCollateralsToUSDTExcludeWPorting(exchangeTool, custodianState, portalState)
+	}
+	if err != nil {
+		return nil, err
+	}
+	totalHoldPubToken, _, _, _ := GetHoldPubTokensByCustodian(portalState, custodianState)
+
+	tokenIDs := make([]string, 0)
+	for tokenID := range lockedAmount {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	for tokenID := range totalHoldPubToken {
+		if _, ok := lockedAmount[tokenID]; !ok {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	sort.Strings(tokenIDs)
+
+	result := make([]CustodianTokenRisk, 0)
+	for _, tokenID := range tokenIDs {
+		risk := CustodianTokenRisk{
+			PortalTokenID:          tokenID,
+			LockedCollateralInUSDT: lockedAmount[tokenID],
+			HoldingPubTokenAmount:  totalHoldPubToken[tokenID],
+			PubTokenRate:           exchangeTool.Rates[tokenID].Rate,
+			LiquidationRatio:       portalParams.TP120,
+		}
+		if risk.HoldingPubTokenAmount == 0 {
+			// nothing to be liquidated
+			result = append(result, risk)
+			continue
+		}
+		risk.HoldingPubTokenInUSDT, err = exchangeTool.ConvertToUSD(tokenID, risk.HoldingPubTokenAmount)
+		if err != nil {
+			return nil, fmt.Errorf("Error when converting holding public tokens %v to usdt: %v", tokenID, err)
+		}
+		if risk.HoldingPubTokenInUSDT == 0 {
+			result = append(result, risk)
+			continue
+		}
+
+		// lockedAmountInUSDT * 100 / holdingPubTokenInUSDT
+		ratio := new(big.Int).Mul(new(big.Int).SetUint64(risk.LockedCollateralInUSDT), big.NewInt(100))
+		ratio = ratio.Div(ratio, new(big.Int).SetUint64(risk.HoldingPubTokenInUSDT))
+		risk.Ratio = ratio.Uint64()
+		if risk.Ratio <= portalParams.TP120 {
+			risk.IsLiquidatable = true
+			risk.LiquidationPubTokenRate = risk.PubTokenRate
+			result = append(result, risk)
+			continue
+		}
+
+		// the ratio goes down with the same proportion as the rate of the portal token goes up:
+		// liquidationRate = rate * ratio / TP120
+		liquidationRate := new(big.Int).Mul(new(big.Int).SetUint64(risk.PubTokenRate), new(big.Int).SetUint64(risk.Ratio))
+		liquidationRate = liquidationRate.Div(liquidationRate, new(big.Int).SetUint64(portalParams.TP120))
+		risk.LiquidationPubTokenRate = liquidationRate.Uint64()
+		// (ratio - TP120) * 10000 / TP120
+		priceIncrease := new(big.Int).Mul(new(big.Int).SetUint64(risk.Ratio-portalParams.TP120), big.NewInt(10000))
+		priceIncrease = priceIncrease.Div(priceIncrease, new(big.Int).SetUint64(portalParams.TP120))
+		risk.PriceIncreaseToLiquidation = priceIncrease.Uint64()
+		result = append(result, risk)
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/stretchr/testify/assert"
+)
+
+const riskTestUSDTIDStr = "64fbdbc6bf5b228814b58706d91ed03777f0edf6"
+
+// newCustodianRiskTestPortalState returns a portal state where 1 PRV = 1 USDT and 1 BNB = 20 USDT,
+// the custodian holds 10 BNB and locks 300 PRV and 60 USDT for them, 20 PRV of which are locked for a waiting porting request
+func newCustodianRiskTestPortalState() (*CurrentPortalState, *statedb.CustodianState, PortalParams) {
+	portalParams := PortalParams{
+		TP120: 120,
+		TP130: 130,
+		SupportedCollateralTokens: []PortalCollateral{
+			{ExternalTokenID: riskTestUSDTIDStr, Decimal: 6},
+		},
+	}
+	custodian := statedb.NewCustodianStateWithValue(
+		"custodian",
+		500*1e9,
+		100*1e9,
+		map[string]uint64{common.PortalBNBIDStr: 10 * 1e9},
+		map[string]uint64{common.PortalBNBIDStr: 300 * 1e9, common.PortalBTCIDStr: 100 * 1e9},
+		map[string]string{},
+		map[string]uint64{},
+		map[string]uint64{riskTestUSDTIDStr: 60 * 1e6},
+		map[string]uint64{},
+		map[string]map[string]uint64{common.PortalBNBIDStr: {riskTestUSDTIDStr: 60 * 1e6}},
+	)
+	waitingPorting := statedb.NewWaitingPortingRequestWithValue(
+		"porting", common.Hash{}, common.PortalBNBIDStr, "porter", 1e9,
+		[]*statedb.MatchingPortingCustodianDetail{{IncAddress: "custodian", Amount: 1e9, LockedAmountCollateral: 20 * 1e9}},
+		0, 1, 1, 0,
+	)
+	portalState := &CurrentPortalState{
+		CustodianPoolState:     map[string]*statedb.CustodianState{"custodian": custodian},
+		WaitingPortingRequests: map[string]*statedb.WaitingPortingRequest{"porting": waitingPorting},
+		WaitingRedeemRequests:  map[string]*statedb.RedeemRequest{},
+		MatchedRedeemRequests:  map[string]*statedb.RedeemRequest{},
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(
+			map[string]statedb.FinalExchangeRatesDetail{
+				common.PRVIDStr:       {Amount: 1e9},
+				common.PortalBNBIDStr: {Amount: 20 * 1e9},
+				riskTestUSDTIDStr:     {Amount: 1e9},
+			}),
+	}
+	return portalState, custodian, portalParams
+}
+
+func TestCalCustodianRisk(t *testing.T) {
+	portalState, custodian, portalParams := newCustodianRiskTestPortalState()
+	tests := []struct {
+		name       string
+		isPortalV3 bool
+		want       []CustodianTokenRisk
+	}{
+		{
+			name:       "portal v3 counts prv and token collaterals",
+			isPortalV3: true,
+			want: []CustodianTokenRisk{
+				{
+					PortalTokenID:              common.PortalBNBIDStr,
+					LockedCollateralInUSDT:     340 * 1e9,
+					HoldingPubTokenAmount:      10 * 1e9,
+					HoldingPubTokenInUSDT:      200 * 1e9,
+					PubTokenRate:               20 * 1e9,
+					Ratio:                      170,
+					LiquidationRatio:           120,
+					LiquidationPubTokenRate:    28333333333,
+					PriceIncreaseToLiquidation: 4166,
+				},
+				{PortalTokenID: common.PortalBTCIDStr, LockedCollateralInUSDT: 100 * 1e9, LiquidationRatio: 120},
+			},
+		},
+		{
+			name:       "before portal v3 only prv collaterals are counted",
+			isPortalV3: false,
+			want: []CustodianTokenRisk{
+				{
+					PortalTokenID:              common.PortalBNBIDStr,
+					LockedCollateralInUSDT:     280 * 1e9,
+					HoldingPubTokenAmount:      10 * 1e9,
+					HoldingPubTokenInUSDT:      200 * 1e9,
+					PubTokenRate:               20 * 1e9,
+					Ratio:                      140,
+					LiquidationRatio:           120,
+					LiquidationPubTokenRate:    23333333333,
+					PriceIncreaseToLiquidation: 1666,
+				},
+				{PortalTokenID: common.PortalBTCIDStr, LockedCollateralInUSDT: 100 * 1e9, LiquidationRatio: 120},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risks, err := CalCustodianRisk(portalState, custodian, portalParams, tt.isPortalV3)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, risks)
+		})
+	}
+}
+
+func TestCalCustodianRisk_Liquidatable(t *testing.T) {
+	portalState, custodian, portalParams := newCustodianRiskTestPortalState()
+	// 5 more BNB in a waiting redeem request, 15 BNB are worth 300 USDT
+	portalState.WaitingRedeemRequests["redeem"] = statedb.NewRedeemRequestWithValue(
+		"redeem", common.PortalBNBIDStr, "redeemer", "remote", 5*1e9,
+		[]*statedb.MatchingRedeemCustodianDetail{statedb.NewMatchingRedeemCustodianDetailWithValue("custodian", "remote", 5*1e9)},
+		0, 1, common.Hash{}, 0, 1, "",
+	)
+	risks, err := CalCustodianRisk(portalState, custodian, portalParams, false)
+	assert.Nil(t, err)
+	assert.Equal(t, CustodianTokenRisk{
+		PortalTokenID:           common.PortalBNBIDStr,
+		LockedCollateralInUSDT:  280 * 1e9,
+		HoldingPubTokenAmount:   15 * 1e9,
+		HoldingPubTokenInUSDT:   300 * 1e9,
+		PubTokenRate:            20 * 1e9,
+		Ratio:                   93,
+		LiquidationRatio:        120,
+		LiquidationPubTokenRate: 20 * 1e9,
+		IsLiquidatable:          true,
+	}, risks[0])
+
+	portalState.FinalExchangeRatesState = nil
+	_, err = CalCustodianRisk(portalState, custodian, portalParams, true)
+	assert.NotNil(t, err)
+}
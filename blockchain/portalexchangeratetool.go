@@ -4,7 +4,6 @@ import (
 	"errors"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
-	"github.com/incognitochain/incognito-chain/metadata"
 	"math"
 	"math/big"
 	"sort"
@@ -18,18 +17,16 @@ type PortalExchangeRateTool struct {
 	Rates map[string]RateInfo
 }
 
-// getDecimal returns decimal for portal token or collateral tokens
+// getDecimal returns decimal for portal token or collateral tokens,
+// portal tokens and PRV have the decimal of ptokens in inc chain
 func getDecimal(supportPortalCollateral []PortalCollateral, tokenID string) uint8 {
-	if metadata.IsPortalToken(tokenID) || tokenID == common.PRVIDStr {
-		return 9
-	}
 	for _, col := range supportPortalCollateral {
 		if tokenID == col.ExternalTokenID {
 			return col.Decimal
 		}
 	}
 
-	return 0
+	return common.PortalPTokenDecimals
 }
 
 func NewPortalExchangeRateTool(
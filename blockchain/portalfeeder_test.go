@@ -0,0 +1,135 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPortalFeeders = []string{"feeder-1", "feeder-2", "feeder-3"}
+
+func newPortalFeederTestState(feederRates map[string]map[string]statedb.PortalFeederRate) *CurrentPortalState {
+	feederStates := map[string]*statedb.PortalFeederState{}
+	for feederAddress, latestRates := range feederRates {
+		feederStates[feederAddress] = statedb.NewPortalFeederStateWithValue(feederAddress, latestRates, 1, uint64(len(latestRates)), 0)
+	}
+	return &CurrentPortalState{
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PortalBNBIDStr: {Amount: 1000},
+		}),
+		ExchangeRatesRequests: map[string]*metadata.ExchangeRatesRequestStatus{},
+		FeederStates:          feederStates,
+	}
+}
+
+func TestFilterPortalExchangeRates(t *testing.T) {
+	portalParams := PortalParams{ExchangeRatesWindow: 10, MaxPercentExchangeRateDeviation: 20}
+	recentState := newPortalFeederTestState(map[string]map[string]statedb.PortalFeederRate{
+		"feeder-1": {common.PortalBNBIDStr: {Rate: 1000, BeaconHeight: 95}},
+	})
+	staleState := newPortalFeederTestState(map[string]map[string]statedb.PortalFeederRate{
+		"feeder-1": {common.PortalBNBIDStr: {Rate: 1000, BeaconHeight: 80}},
+	})
+	rates := []*metadata.ExchangeRateInfo{
+		{PTokenID: common.PortalBNBIDStr, Rate: 1200},
+		{PTokenID: common.PortalBNBIDStr, Rate: 800},
+		{PTokenID: common.PortalBNBIDStr, Rate: 1201},
+		{PTokenID: common.PortalBNBIDStr, Rate: 799},
+		// no final rate
+		{PTokenID: common.PortalBTCIDStr, Rate: 1},
+	}
+	tests := []struct {
+		name         string
+		portalState  *CurrentPortalState
+		portalParams PortalParams
+		wantAccepted []*metadata.ExchangeRateInfo
+		wantRejected []*metadata.ExchangeRateInfo
+	}{
+		{"deviating rates are rejected", recentState, portalParams, []*metadata.ExchangeRateInfo{rates[0], rates[1], rates[4]}, []*metadata.ExchangeRateInfo{rates[2], rates[3]}},
+		{"final rates without recent feeder rates are not compared", staleState, portalParams, rates, nil},
+		{"no deviation limit", recentState, PortalParams{ExchangeRatesWindow: 10}, rates, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accepted, rejected := filterPortalExchangeRates(rates, tt.portalState, 100, tt.portalParams)
+			assert.Equal(t, tt.wantAccepted, accepted)
+			assert.Equal(t, tt.wantRejected, rejected)
+		})
+	}
+}
+
+func TestPickExchangesRatesFinal_Feeders(t *testing.T) {
+	bc := &BlockChain{
+		config: Config{
+			ChainParams: &Params{
+				PortalFeederAddress:             "feeder-0",
+				PortalFeederAddresses:           testPortalFeeders,
+				BCHeightBreakPointPortalFeeders: 50,
+			},
+		},
+	}
+	portalParams := PortalParams{ExchangeRatesWindow: 10}
+	portalState := newPortalFeederTestState(map[string]map[string]statedb.PortalFeederRate{
+		"feeder-1": {common.PortalBNBIDStr: {Rate: 1100, BeaconHeight: 99}, common.PortalBTCIDStr: {Rate: 50, BeaconHeight: 99}},
+		"feeder-2": {common.PortalBNBIDStr: {Rate: 900, BeaconHeight: 95}},
+		// out of the window
+		"feeder-3": {common.PortalBNBIDStr: {Rate: 5000, BeaconHeight: 90}},
+		// not authorized
+		"feeder-4": {common.PortalBNBIDStr: {Rate: 7000, BeaconHeight: 99}},
+	})
+	bc.pickExchangesRatesFinal(portalState, 100, portalParams)
+	assert.Equal(t, map[string]statedb.FinalExchangeRatesDetail{
+		common.PortalBNBIDStr: {Amount: 1000},
+		common.PortalBTCIDStr: {Amount: 50},
+	}, portalState.FinalExchangeRatesState.Rates())
+
+	// the requests of the block are aggregated before the break point
+	portalState = newPortalFeederTestState(nil)
+	portalState.ExchangeRatesRequests["tx-1"] = metadata.NewExchangeRatesRequestStatus(common.PortalExchangeRatesAcceptedStatus, "feeder-0",
+		[]*metadata.ExchangeRateInfo{{PTokenID: common.PortalBNBIDStr, Rate: 1300}})
+	bc.pickExchangesRatesFinal(portalState, 40, portalParams)
+	assert.Equal(t, uint64(1300), portalState.FinalExchangeRatesState.Rates()[common.PortalBNBIDStr].Amount)
+}
+
+func TestAddPortalFeederSubmission(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dbPath)
+	diskDB, _ := incdb.Open("leveldb", dbPath)
+	stateDB, _ := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+
+	portalState := newPortalFeederTestState(nil)
+	total := maxPortalFeederSubmissions + 5
+	for i := uint64(0); i < total; i++ {
+		err := addPortalFeederSubmission(stateDB, portalState, i, metadata.PortalExchangeRatesContent{
+			SenderAddress: "feeder-1",
+			Rates:         []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBNBIDStr, Rate: 1000 + i}},
+			RejectedRates: []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: 1}},
+			TxReqID:       common.HashH([]byte{byte(i)}),
+		})
+		assert.Nil(t, err)
+	}
+	feederState := portalState.FeederStates["feeder-1"]
+	assert.Equal(t, total, feederState.TotalSubmissions())
+	assert.Equal(t, total, feederState.TotalAcceptedRates())
+	assert.Equal(t, total, feederState.TotalRejectedRates())
+	assert.Equal(t, statedb.PortalFeederRate{Rate: 1000 + total - 1, BeaconHeight: total - 1}, feederState.LatestRates()[common.PortalBNBIDStr])
+
+	// only the recent submissions are kept, the most recent first
+	submissions, err := GetPortalFeederSubmissions(stateDB, feederState)
+	assert.Nil(t, err)
+	assert.Equal(t, int(maxPortalFeederSubmissions), len(submissions))
+	assert.Equal(t, total-1, submissions[0].BeaconHeight)
+	assert.Equal(t, uint64(5), submissions[len(submissions)-1].BeaconHeight)
+	assert.Equal(t, map[string]uint64{common.PortalBNBIDStr: 1000 + total - 1}, submissions[0].AcceptedRates)
+	assert.Equal(t, map[string]uint64{common.PortalBTCIDStr: 1}, submissions[0].RejectedRates)
+}
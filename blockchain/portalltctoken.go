@@ -0,0 +1,137 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
+)
+
+// PortalLTCTokenProcessor is the processor of pLTC, it is the template for UTXO chains relayed by a header chain:
+// a proof is a merkle proof of a tx in a relayed block and the porting or redeem ID is attached to the tx by OP_RETURN
+type PortalLTCTokenProcessor struct {
+	*PortalToken
+}
+
+// GetLTCRelayingChainID returns the chain ID of the LTC network relayed for the pLTC tokens of the params,
+// it is empty if there is no pLTC token. The relaying chain is created before the break point of the tokens
+// so every node has it once LTC headers are relayed.
+func (p *Params) GetLTCRelayingChainID() (string, error) {
+	chainID := ""
+	for tokenID, processor := range p.PortalTokens {
+		if _, ok := processor.(*PortalLTCTokenProcessor); !ok {
+			continue
+		}
+		if chainID != "" && chainID != processor.GetChainID() {
+			return "", fmt.Errorf("pLTC token %v is on %v, other pLTC tokens are on %v", tokenID, processor.GetChainID(), chainID)
+		}
+		chainID = processor.GetChainID()
+	}
+	return chainID, nil
+}
+
+func (p *PortalLTCTokenProcessor) getLTCChain(bc *BlockChain) (*ltcrelaying.HeaderChain, error) {
+	ltcChain := bc.config.LTCChain
+	if ltcChain == nil {
+		Logger.log.Error("LTC relaying chain should not be null")
+		return nil, errors.New("LTC relaying chain should not be null")
+	}
+	return ltcChain, nil
+}
+
+func (p *PortalLTCTokenProcessor) parseAndVerifyProof(proof string, ltcChain *ltcrelaying.HeaderChain, expectedMsg string) (*ltcrelaying.LTCProof, error) {
+	ltcTxProof, err := ltcrelaying.ParseLTCProofFromB64EncodeStr(proof)
+	if err != nil {
+		Logger.log.Errorf("LTC proof is invalid %v\n", err)
+		return nil, fmt.Errorf("LTC proof is invalid %v\n", err)
+	}
+
+	isValid, err := ltcChain.VerifyTxWithMerkleProofs(ltcTxProof, p.GetMinConfirmations())
+	if !isValid || err != nil {
+		Logger.log.Errorf("Verify ltcTxProof failed %v", err)
+		return nil, fmt.Errorf("Verify ltcTxProof failed %v", err)
+	}
+
+	ltcAttachedMsg, err := btcrelaying.ExtractAttachedMsgFromTx(ltcTxProof.LTCTx)
+	if err != nil {
+		Logger.log.Errorf("Could not extract attached message from LTC tx proof with err: %v", err)
+		return nil, fmt.Errorf("Could not extract attached message from LTC tx proof with err: %v", err)
+	}
+	encodedMsg := btcrelaying.HashAndEncodeBase58(expectedMsg)
+	if ltcAttachedMsg != encodedMsg {
+		Logger.log.Errorf("The attached message %v of ltc tx is not matched with the expected one %v", ltcAttachedMsg, encodedMsg)
+		return nil, fmt.Errorf("The attached message %v of ltc tx is not matched with the expected one %v", ltcAttachedMsg, encodedMsg)
+	}
+	return ltcTxProof, nil
+}
+
+// checkTransferredAmount checks that the tx of the proof transfers at least incAmount to remoteAddress
+func (p *PortalLTCTokenProcessor) checkTransferredAmount(ltcTxProof *ltcrelaying.LTCProof, ltcChain *ltcrelaying.HeaderChain, remoteAddress string, incAmount uint64) error {
+	amountInLTC := p.ConvertIncToExternalAmount(int64(incAmount))
+	for _, out := range ltcTxProof.LTCTx.TxOut {
+		addrStr, err := ltcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		if err != nil {
+			Logger.log.Warnf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
+			continue
+		}
+		if addrStr != remoteAddress {
+			continue
+		}
+		if out.Value < amountInLTC {
+			Logger.log.Errorf("LTC-TxProof is invalid - the transferred amount to %s must be equal to or greater than %d, but got %d", addrStr, amountInLTC, out.Value)
+			return fmt.Errorf("LTC-TxProof is invalid - the transferred amount to %s must be equal to or greater than %d, but got %d", addrStr, amountInLTC, out.Value)
+		}
+		return nil
+	}
+	Logger.log.Errorf("LTC-TxProof is invalid - there is no output to %v", remoteAddress)
+	return fmt.Errorf("LTC-TxProof is invalid - there is no output to %v", remoteAddress)
+}
+
+func (p *PortalLTCTokenProcessor) ParseAndVerifyProofForPorting(proof string, portingReq *statedb.WaitingPortingRequest, bc *BlockChain) (bool, error) {
+	ltcChain, err := p.getLTCChain(bc)
+	if err != nil {
+		return false, err
+	}
+	ltcTxProof, err := p.parseAndVerifyProof(proof, ltcChain, portingReq.UniquePortingID())
+	if err != nil {
+		return false, err
+	}
+	for _, cusDetail := range portingReq.Custodians() {
+		err = p.checkTransferredAmount(ltcTxProof, ltcChain, cusDetail.RemoteAddress, cusDetail.Amount)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p *PortalLTCTokenProcessor) ParseAndVerifyProofForRedeem(
+	proof string,
+	redeemReq *statedb.RedeemRequest,
+	bc *BlockChain,
+	matchedCustodian *statedb.MatchingRedeemCustodianDetail) (bool, error) {
+	ltcChain, err := p.getLTCChain(bc)
+	if err != nil {
+		return false, err
+	}
+	rawMsg := fmt.Sprintf("%s%s", redeemReq.GetUniqueRedeemID(), matchedCustodian.GetIncognitoAddress())
+	ltcTxProof, err := p.parseAndVerifyProof(proof, ltcChain, rawMsg)
+	if err != nil {
+		return false, err
+	}
+	err = p.checkTransferredAmount(ltcTxProof, ltcChain, redeemReq.GetRedeemerRemoteAddress(), matchedCustodian.GetAmount())
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *PortalLTCTokenProcessor) IsValidRemoteAddress(address string, bc *BlockChain) (bool, error) {
+	ltcChain, err := p.getLTCChain(bc)
+	if err != nil {
+		return false, err
+	}
+	return ltcChain.IsLTCAddressValid(address), nil
+}
@@ -13,6 +13,7 @@ type relayingProcessor interface {
 		blockchain *BlockChain,
 		relayingHeaderAction metadata.RelayingHeaderAction,
 		relayingState *RelayingHeaderChainState,
+		beaconHeight uint64,
 	) [][]string
 	buildHeaderRelayingInst(
 		senderAddressStr string,
@@ -60,10 +61,22 @@ func NewPortalManager() *portalManager {
 			actions: [][]string{},
 		},
 	}
+	rethChain := &relayingETHChain{
+		relayingChain: &relayingChain{
+			actions: [][]string{},
+		},
+	}
+	rltcChain := &relayingLTCChain{
+		relayingChain: &relayingChain{
+			actions: [][]string{},
+		},
+	}
 
 	relayingChainProcessor := map[int]relayingProcessor{
 		metadata.RelayingBNBHeaderMeta: rbnbChain,
 		metadata.RelayingBTCHeaderMeta: rbtcChain,
+		metadata.RelayingETHHeaderMeta: rethChain,
+		metadata.RelayingLTCHeaderMeta: rltcChain,
 	}
 
 	portalInstProcessor := map[int]portalInstructionProcessor{
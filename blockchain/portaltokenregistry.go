@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	PortalBTCChainName = "btc"
+	PortalBNBChainName = "bnb"
+	PortalLTCChainName = "ltc"
+)
+
+// PortalTokenConfig is the config of a portal token, it is a part of the chain params
+// so all nodes of a network must load the same configs
+type PortalTokenConfig struct {
+	TokenID          string // the incognito token ID of the ptoken
+	ChainName        string // name of the registered processor builder, e.g. btc, bnb, ltc
+	ChainID          string // the network of the external chain, e.g. Litecoin-Mainnet
+	MinConfirmations uint64
+	Decimals         uint8
+	MinAmount        uint64 // min amount of ptoken in porting and redeem requests
+	BreakPoint       uint64 // beacon height from which the token is supported
+}
+
+// PortalTokenProcessorBuilder creates the processor of a portal token on an external chain
+type PortalTokenProcessorBuilder func(portalToken *PortalToken) PortalTokenProcessor
+
+var (
+	portalTokenProcessorBuilders = map[string]PortalTokenProcessorBuilder{
+		PortalBTCChainName: func(portalToken *PortalToken) PortalTokenProcessor {
+			return &PortalBTCTokenProcessor{portalToken}
+		},
+		PortalBNBChainName: func(portalToken *PortalToken) PortalTokenProcessor {
+			return &PortalBNBTokenProcessor{portalToken}
+		},
+		PortalLTCChainName: func(portalToken *PortalToken) PortalTokenProcessor {
+			return &PortalLTCTokenProcessor{portalToken}
+		},
+	}
+	portalTokenProcessorBuildersLock sync.RWMutex
+)
+
+// RegisterPortalTokenProcessorBuilder adds the processor builder of an external chain,
+// it must be called before portal token configs are loaded
+func RegisterPortalTokenProcessorBuilder(chainName string, builder PortalTokenProcessorBuilder) error {
+	portalTokenProcessorBuildersLock.Lock()
+	defer portalTokenProcessorBuildersLock.Unlock()
+	if _, ok := portalTokenProcessorBuilders[chainName]; ok {
+		return fmt.Errorf("portal token processor builder of chain %v is registered already", chainName)
+	}
+	portalTokenProcessorBuilders[chainName] = builder
+	return nil
+}
+
+// NewPortalTokenProcessor creates the processor of config by the builder of its chain
+func NewPortalTokenProcessor(config PortalTokenConfig) (PortalTokenProcessor, error) {
+	portalTokenProcessorBuildersLock.RLock()
+	builder, ok := portalTokenProcessorBuilders[config.ChainName]
+	portalTokenProcessorBuildersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("there is no portal token processor builder of chain %v", config.ChainName)
+	}
+	if config.ChainID == "" {
+		return nil, errors.New("chain ID of portal token must not be empty")
+	}
+	return builder(&PortalToken{
+		ChainID:          config.ChainID,
+		MinConfirmations: config.MinConfirmations,
+		Decimals:         config.Decimals,
+		MinAmount:        config.MinAmount,
+		BreakPoint:       config.BreakPoint,
+	}), nil
+}
+
+// RegisterPortalToken adds a portal token to the params, it is supported from the break point of its processor
+func (p *Params) RegisterPortalToken(tokenID string, processor PortalTokenProcessor) error {
+	_, err := common.Hash{}.NewHashFromStr(tokenID)
+	if err != nil {
+		return errors.Wrapf(err, "invalid portal token ID %v", tokenID)
+	}
+	if p.PortalTokens == nil {
+		p.PortalTokens = map[string]PortalTokenProcessor{}
+	}
+	p.PortalTokens[tokenID] = processor
+	return nil
+}
+
+// LoadPortalTokens creates the processors of configs and registers them to the params
+func (p *Params) LoadPortalTokens(configs []PortalTokenConfig) error {
+	for _, config := range configs {
+		processor, err := NewPortalTokenProcessor(config)
+		if err != nil {
+			return errors.Wrapf(err, "portal token %v", config.TokenID)
+		}
+		err = p.RegisterPortalToken(config.TokenID, processor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPortalTokenProcessor returns the processor of a portal token supported at beaconHeight
+func (p *Params) GetPortalTokenProcessor(tokenID string, beaconHeight uint64) (PortalTokenProcessor, bool) {
+	processor, ok := p.PortalTokens[tokenID]
+	if !ok || processor == nil || beaconHeight < processor.GetBreakPoint() {
+		return nil, false
+	}
+	return processor, true
+}
+
+// GetPortalTokenIDs returns the sorted IDs of the portal tokens supported at beaconHeight
+func (p *Params) GetPortalTokenIDs(beaconHeight uint64) []string {
+	tokenIDs := []string{}
+	for tokenID := range p.PortalTokens {
+		if _, ok := p.GetPortalTokenProcessor(tokenID, beaconHeight); ok {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs
+}
@@ -13,15 +13,65 @@ import (
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
+// PortalTokenProcessor verifies the external chain side of a portal token,
+// processors of new chains are added by RegisterPortalTokenProcessorBuilder
 type PortalTokenProcessor interface {
 	ParseAndVerifyProofForPorting(proof string, portingReq *statedb.WaitingPortingRequest, bc *BlockChain) (bool, error)
 	ParseAndVerifyProofForRedeem(proof string, redeemReq *statedb.RedeemRequest, bc *BlockChain, matchedCustodian *statedb.MatchingRedeemCustodianDetail) (bool, error)
-	IsValidRemoteAddress(address string) (bool, error)
+	IsValidRemoteAddress(address string, bc *BlockChain) (bool, error)
 	GetChainID() (string)
+	GetMinConfirmations() uint64
+	GetDecimals() uint8
+	GetMinAmount() uint64
+	GetBreakPoint() uint64
 }
 
 type PortalToken struct {
-	ChainID string
+	ChainID          string
+	MinConfirmations uint64 // number of external blocks on top of the block of a proof
+	Decimals         uint8  // decimals of the token on the external chain
+	MinAmount        uint64 // min amount of ptoken in porting and redeem requests
+	BreakPoint       uint64 // beacon height from which the token is supported
+}
+
+func (p *PortalToken) GetChainID() string {
+	return p.ChainID
+}
+
+func (p *PortalToken) GetMinConfirmations() uint64 {
+	return p.MinConfirmations
+}
+
+func (p *PortalToken) GetDecimals() uint8 {
+	return p.Decimals
+}
+
+func (p *PortalToken) GetMinAmount() uint64 {
+	return p.MinAmount
+}
+
+func (p *PortalToken) GetBreakPoint() uint64 {
+	return p.BreakPoint
+}
+
+// ConvertIncToExternalAmount converts amount in inc chain (decimal 9) to amount in the external chain
+func (p *PortalToken) ConvertIncToExternalAmount(incAmount int64) int64 {
+	return convertAmountByDecimals(incAmount, common.PortalPTokenDecimals, p.Decimals)
+}
+
+// ConvertExternalToIncAmount converts amount in the external chain to amount in inc chain (decimal 9)
+func (p *PortalToken) ConvertExternalToIncAmount(externalAmount int64) int64 {
+	return convertAmountByDecimals(externalAmount, p.Decimals, common.PortalPTokenDecimals)
+}
+
+func convertAmountByDecimals(amount int64, fromDecimals uint8, toDecimals uint8) int64 {
+	for ; fromDecimals > toDecimals; fromDecimals-- {
+		amount /= 10
+	}
+	for ; fromDecimals < toDecimals; fromDecimals++ {
+		amount *= 10
+	}
+	return amount
 }
 
 type PortalBTCTokenProcessor struct {
@@ -171,13 +221,12 @@ func (p *PortalBTCTokenProcessor) ParseAndVerifyProofForRedeem(
 	return true, nil
 }
 
-//todo:
-func (p *PortalBTCTokenProcessor) IsValidRemoteAddress(address string) (bool, error) {
-	return true, nil
-}
-
-func (p *PortalBTCTokenProcessor) GetChainID() string {
-	return p.ChainID
+func (p *PortalBTCTokenProcessor) IsValidRemoteAddress(address string, bc *BlockChain) (bool, error) {
+	btcChain := bc.config.BTCChain
+	if btcChain == nil {
+		return false, errors.New("BTC relaying chain should not be null")
+	}
+	return btcChain.IsBTCAddressValid(address), nil
 }
 
 type PortalBNBTokenProcessor struct {
@@ -199,11 +248,11 @@ func (p *PortalBNBTokenProcessor) ParseAndVerifyProofForPorting(proof string, po
 		return false, fmt.Errorf("Can not get latest relaying bnb block height %v\n", err)
 	}
 
-	if latestBNBBlockHeight < txProofBNB.BlockHeight+bnb.MinConfirmationsBlock {
+	if latestBNBBlockHeight < txProofBNB.BlockHeight+int64(p.GetMinConfirmations()) {
 		Logger.log.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-			bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+			p.GetMinConfirmations(), latestBNBBlockHeight, txProofBNB.BlockHeight)
 		return false, fmt.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-			bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+			p.GetMinConfirmations(), latestBNBBlockHeight, txProofBNB.BlockHeight)
 	}
 	dataHash, err2 := bc.GetBNBDataHash(txProofBNB.BlockHeight)
 	if err2 != nil {
@@ -306,11 +355,11 @@ func (p *PortalBNBTokenProcessor) ParseAndVerifyProofForRedeem(proof string, red
 		return false, fmt.Errorf("Can not get latest relaying bnb block height %v\n", err)
 	}
 
-	if latestBNBBlockHeight < txProofBNB.BlockHeight+bnb.MinConfirmationsBlock {
+	if latestBNBBlockHeight < txProofBNB.BlockHeight+int64(p.GetMinConfirmations()) {
 		Logger.log.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-			bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+			p.GetMinConfirmations(), latestBNBBlockHeight, txProofBNB.BlockHeight)
 		return false, fmt.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-			bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+			p.GetMinConfirmations(), latestBNBBlockHeight, txProofBNB.BlockHeight)
 	}
 
 	dataHash, err2 := bc.GetBNBDataHash(txProofBNB.BlockHeight)
@@ -400,10 +449,6 @@ func (p *PortalBNBTokenProcessor) ParseAndVerifyProofForRedeem(proof string, red
 	return true, nil
 }
 
-func (p *PortalBNBTokenProcessor) IsValidRemoteAddress(address string) (bool, error) {
+func (p *PortalBNBTokenProcessor) IsValidRemoteAddress(address string, bc *BlockChain) (bool, error) {
 	return bnb.IsValidBNBAddress(address, p.ChainID), nil
 }
-
-func (p *PortalBNBTokenProcessor) GetChainID() string {
-	return p.ChainID
-}
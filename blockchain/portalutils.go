@@ -26,6 +26,8 @@ type CurrentPortalState struct {
 	LockedCollateralForRewards *statedb.LockedCollateralState
 	//Store temporary exchange rates requests
 	ExchangeRatesRequests map[string]*metadata.ExchangeRatesRequestStatus // key : hash(beaconHeight | TxID)
+	// latest rates and submission history of exchange rate feeders
+	FeederStates map[string]*statedb.PortalFeederState // key : feeder address
 }
 
 type CustodianStateSlice struct {
@@ -73,6 +75,10 @@ func InitCurrentPortalStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	feederStates, err := statedb.GetPortalFeederStates(stateDB)
+	if err != nil {
+		return nil, err
+	}
 
 	return &CurrentPortalState{
 		CustodianPoolState:         custodianPoolState,
@@ -83,6 +89,7 @@ func InitCurrentPortalStateFromDB(
 		ExchangeRatesRequests:      make(map[string]*metadata.ExchangeRatesRequestStatus),
 		LiquidationPool:            liquidateExchangeRatesPool,
 		LockedCollateralForRewards: lockedCollateralState,
+		FeederStates:               feederStates,
 	}, nil
 }
 
@@ -118,6 +125,10 @@ func storePortalStateToDB(
 	if err != nil {
 		return err
 	}
+	err = statedb.StorePortalFeederStates(stateDB, currentPortalState.FeederStates)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1658,7 +1669,7 @@ func UpdateLockedCollateralForRewards(currentPortalState *CurrentPortalState, po
 	currentPortalState.LockedCollateralForRewards.SetLockedCollateralDetail(lockedCollateralDetails)
 }
 
-func UpdateLockedCollateralForRewardsV3(currentPortalState *CurrentPortalState, portalParam PortalParams) {
+func UpdateLockedCollateralForRewardsV3(currentPortalState *CurrentPortalState, portalParam PortalParams, portalTokenIDs []string) {
 	exchangeTool := NewPortalExchangeRateTool(currentPortalState.FinalExchangeRatesState, portalParam.SupportedCollateralTokens)
 
 	totalLockedCollateralAmount := currentPortalState.LockedCollateralForRewards.GetTotalLockedCollateralForRewards()
@@ -1666,7 +1677,6 @@ func UpdateLockedCollateralForRewardsV3(currentPortalState *CurrentPortalState,
 	if lockedCollateralDetails == nil {
 		lockedCollateralDetails = map[string]uint64{}
 	}
-	portalTokenIDs := common.PortalSupportedIncTokenIDs
 	for _, custodianState := range currentPortalState.CustodianPoolState {
 		for _, tokenID := range portalTokenIDs {
 			holdPubTokenAmount := GetTotalHoldPubTokenAmount(currentPortalState, custodianState, tokenID)
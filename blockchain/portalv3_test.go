@@ -71,6 +71,7 @@ var supportedCollaterals = []PortalCollateral{
 }
 
 func (s *PortalTestSuiteV3) SetupTest() {
+	metadata.Logger.Init(common.NewBackend(nil).Logger("test", true))
 	dbPath, err := ioutil.TempDir(os.TempDir(), "portal_test_statedb_")
 	if err != nil {
 		panic(err)
@@ -406,7 +407,7 @@ func processPortalInstructions(
 	}
 
 	//save final exchangeRates
-	blockchain.pickExchangesRatesFinal(currentPortalState)
+	blockchain.pickExchangesRatesFinal(currentPortalState, beaconHeight, portalParams)
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
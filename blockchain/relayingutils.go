@@ -1,12 +1,19 @@
 package blockchain
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/types"
 	"strconv"
@@ -21,6 +28,12 @@ type relayingBNBChain struct {
 type relayingBTCChain struct {
 	*relayingChain
 }
+type relayingETHChain struct {
+	*relayingChain
+}
+type relayingLTCChain struct {
+	*relayingChain
+}
 
 func (rChain *relayingChain) getActions() [][]string {
 	return rChain.actions
@@ -58,6 +71,7 @@ func (rbnbChain *relayingBNBChain) buildRelayingInst(
 	blockchain *BlockChain,
 	relayingHeaderAction metadata.RelayingHeaderAction,
 	relayingHeaderChain *RelayingHeaderChainState,
+	beaconHeight uint64,
 ) [][]string {
 	meta := relayingHeaderAction.Meta
 	// parse bnb block header
@@ -122,6 +136,7 @@ func (rbtcChain *relayingBTCChain) buildRelayingInst(
 	blockchain *BlockChain,
 	relayingHeaderAction metadata.RelayingHeaderAction,
 	relayingState *RelayingHeaderChainState,
+	beaconHeight uint64,
 ) [][]string {
 	Logger.log.Info("[BTC Relaying] - Processing buildRelayingInst...")
 	inst := rbtcChain.buildHeaderRelayingInst(
@@ -136,19 +151,133 @@ func (rbtcChain *relayingBTCChain) buildRelayingInst(
 	return [][]string{inst}
 }
 
+func (rethChain *relayingETHChain) buildRelayingInst(
+	blockchain *BlockChain,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+	beaconHeight uint64,
+) [][]string {
+	status := common.RelayingHeaderConsideringChainStatus
+	header, err := parseETHHeader(relayingHeaderAction.Meta.Header)
+	if err != nil {
+		Logger.log.Errorf("Error - [buildInstructionsForETHHeaderRelaying]: Cannot parse header.%v\n", err)
+		status = common.RelayingHeaderRejectedChainStatus
+	} else if header.Number.Uint64() != relayingHeaderAction.Meta.BlockHeight {
+		Logger.log.Errorf("Error - [buildInstructionsForETHHeaderRelaying]: Block height in metadata is unmatched with block height in new header.")
+		status = common.RelayingHeaderRejectedChainStatus
+	}
+	inst := rethChain.buildHeaderRelayingInst(
+		relayingHeaderAction.Meta.IncogAddressStr,
+		relayingHeaderAction.Meta.Header,
+		relayingHeaderAction.Meta.BlockHeight,
+		relayingHeaderAction.Meta.Type,
+		relayingHeaderAction.ShardID,
+		relayingHeaderAction.TxReqID,
+		status,
+	)
+	return [][]string{inst}
+}
+
+// parseETHHeader parses a base64 encoded json ETH header
+func parseETHHeader(headerStr string) (*ethtypes.Header, error) {
+	headerBytes, err := base64.StdEncoding.DecodeString(headerStr)
+	if err != nil {
+		return nil, err
+	}
+	header := new(ethtypes.Header)
+	err = json.Unmarshal(headerBytes, header)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (rltcChain *relayingLTCChain) buildRelayingInst(
+	blockchain *BlockChain,
+	relayingHeaderAction metadata.RelayingHeaderAction,
+	relayingState *RelayingHeaderChainState,
+	beaconHeight uint64,
+) [][]string {
+	status := common.RelayingHeaderConsideringChainStatus
+	if !blockchain.IsLTCRelayingEnabled(beaconHeight) {
+		Logger.log.Errorf("Error - [buildInstructionsForLTCHeaderRelaying]: LTC relaying is not enabled at beacon height %v.", beaconHeight)
+		status = common.RelayingHeaderRejectedChainStatus
+	} else if relayingState.LTCHeaderChain == nil {
+		Logger.log.Errorf("Error - [buildInstructionsForLTCHeaderRelaying]: LTC relaying chain should not be null.")
+		status = common.RelayingHeaderRejectedChainStatus
+	} else if _, err := parseLTCHeader(relayingHeaderAction.Meta.Header); err != nil {
+		Logger.log.Errorf("Error - [buildInstructionsForLTCHeaderRelaying]: Cannot parse header.%v\n", err)
+		status = common.RelayingHeaderRejectedChainStatus
+	}
+	inst := rltcChain.buildHeaderRelayingInst(
+		relayingHeaderAction.Meta.IncogAddressStr,
+		relayingHeaderAction.Meta.Header,
+		relayingHeaderAction.Meta.BlockHeight,
+		relayingHeaderAction.Meta.Type,
+		relayingHeaderAction.ShardID,
+		relayingHeaderAction.TxReqID,
+		status,
+	)
+	return [][]string{inst}
+}
 
+// parseLTCHeader parses a base64 encoded 80-byte LTC header
+func parseLTCHeader(headerStr string) (*wire.BlockHeader, error) {
+	headerBytes, err := base64.StdEncoding.DecodeString(headerStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(headerBytes) != wire.MaxBlockHeaderPayload {
+		return nil, errors.Errorf("length of header must be %v, got %v", wire.MaxBlockHeaderPayload, len(headerBytes))
+	}
+	header := new(wire.BlockHeader)
+	err = header.Deserialize(bytes.NewReader(headerBytes))
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
 
 type RelayingHeaderChainState struct {
 	BNBHeaderChain *bnbrelaying.BNBChainState
 	BTCHeaderChain *btcrelaying.BlockChain
+	ETHHeaderChain *ethrelaying.HeaderChain
+	LTCHeaderChain *ltcrelaying.HeaderChain
+}
+
+// GetBTCRelayingChainParams returns the params and the genesis block height of the relayed BTC header chain
+func GetBTCRelayingChainParams(btcRelayingChainID string) (*chaincfg.Params, int32, error) {
+	switch btcRelayingChainID {
+	case TestnetBTCChainID:
+		return btcrelaying.GetTestNet3Params(), btcrelaying.TestNet3GenesisBlockHeight, nil
+	case Testnet2BTCChainID:
+		return btcrelaying.GetTestNet3ParamsForInc2(), btcrelaying.TestNet3GenesisBlockHeightForInc2, nil
+	case MainnetBTCChainID:
+		return btcrelaying.GetMainNetParams(), btcrelaying.MainNetGenesisBlockHeight, nil
+	}
+	return nil, 0, fmt.Errorf("BTC relaying chain %v is not supported", btcRelayingChainID)
+}
+
+// GetETHRelayingChainConfig returns the config of the relayed ETH header chain
+func GetETHRelayingChainConfig(ethRelayingChainID string) (*ethrelaying.HeaderChainConfig, error) {
+	switch ethRelayingChainID {
+	case TestnetETHChainID: // testnet-2 relays Goerli too
+		return ethrelaying.GetGoerliConfig(), nil
+	case MainnetETHChainID:
+		return ethrelaying.GetMainnetConfig(), nil
+	}
+	return nil, fmt.Errorf("ETH relaying chain %v is not supported", ethRelayingChainID)
 }
 
 func (bc *BlockChain) InitRelayingHeaderChainStateFromDB() (*RelayingHeaderChainState, error) {
 	bnbChain := bc.GetBNBChainState()
 	btcChain := bc.config.BTCChain
+	ethChain := bc.config.ETHChain
 	return &RelayingHeaderChainState{
 		BNBHeaderChain: bnbChain,
 		BTCHeaderChain: btcChain,
+		ETHHeaderChain: ethChain,
+		LTCHeaderChain: bc.config.LTCChain,
 	}, nil
 }
 
@@ -159,12 +288,11 @@ func (bc *BlockChain) GetBNBChainState() *bnbrelaying.BNBChainState {
 
 // GetLatestBNBBlockHeight return latest block height of bnb chain
 func (bc *BlockChain) GetLatestBNBBlockHeight() (int64, error) {
-	bnbChainState := bc.GetBNBChainState()
-
-	if bnbChainState.LatestBlock == nil {
+	latestBlock := bc.GetBNBChainState().Snapshot().LatestBlock
+	if latestBlock == nil {
 		return int64(0), errors.New("Latest bnb block is nil")
 	}
-	return bnbChainState.LatestBlock.Height, nil
+	return latestBlock.Height, nil
 }
 
 // GetBNBBlockByHeight gets bnb header by height
@@ -5,9 +5,6 @@ func (blockchain *BlockChain) GetStakingAmountShard() uint64 {
 }
 
 func (blockchain *BlockChain) GetCentralizedWebsitePaymentAddress(beaconHeight uint64) string {
-	if blockchain.config.ChainParams.Net == Testnet || blockchain.config.ChainParams.Net == Testnet2 {
-		return blockchain.config.ChainParams.CentralizedWebsitePaymentAddress
-	}
 	if blockchain.config.ChainParams.Net == Mainnet {
 		if beaconHeight >= 677000 {
 			// use new address
@@ -20,7 +17,7 @@ func (blockchain *BlockChain) GetCentralizedWebsitePaymentAddress(beaconHeight u
 			return blockchain.config.ChainParams.CentralizedWebsitePaymentAddress
 		}
 	}
-	return ""
+	return blockchain.config.ChainParams.CentralizedWebsitePaymentAddress
 }
 
 func (blockchain *BlockChain) GetBeaconHeightBreakPointBurnAddr() uint64 {
@@ -35,6 +32,10 @@ func (blockchain *BlockChain) GetBCHeightBreakPointPortalV3() uint64 {
 	return blockchain.config.ChainParams.BCHeightBreakPointPortalV3
 }
 
+func (blockchain *BlockChain) GetBCHeightBreakPointPDELimitOrders() uint64 {
+	return blockchain.config.ChainParams.BCHeightBreakPointPDELimitOrders
+}
+
 func (blockchain *BlockChain) GetBurningAddress(beaconHeight uint64) string {
 	breakPoint := blockchain.GetBeaconHeightBreakPointBurnAddr()
 	if beaconHeight == 0 {
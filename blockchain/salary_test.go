@@ -567,6 +567,7 @@ func Test_getPercentForIncognitoDAO(t *testing.T) {
 }
 
 func TestBlockChain_addShardRewardRequestToBeacon(t *testing.T) {
+	SetupParam()
 	config := Config{}
 	config.ChainParams = &ChainMainParam
 	sDB, _ := statedb.NewWithPrefixTrie(common.EmptyRoot, wrarperDB)
@@ -649,10 +650,10 @@ func TestBlockChain_addShardRewardRequestToBeacon(t *testing.T) {
 
 func TestBlockChain_buildInstRewardForBeacons(t *testing.T) {
 	type fields struct {
-		BestState *BestState
+		view *BeaconBestState
 	}
 	fields1 := fields{
-		BestState: &BestState{Beacon: &BeaconBestState{BeaconCommittee: committeesKeys}},
+		view: &BeaconBestState{BeaconCommittee: committeesKeys},
 	}
 	totalReward1 := make(map[common.Hash]uint64)
 	totalReward1_1 := make(map[common.Hash]uint64)
@@ -685,10 +686,7 @@ func TestBlockChain_buildInstRewardForBeacons(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			blockchain := &BlockChain{
-				BestState: tt.fields.BestState,
-			}
-			got, err := blockchain.buildInstRewardForBeacons(tt.args.epoch, tt.args.totalReward)
+			got, err := tt.fields.view.buildInstRewardForBeacons(tt.args.epoch, tt.args.totalReward)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildInstRewardForBeacons() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"sync"
@@ -152,10 +153,10 @@ func (chain *ShardChain) GetLastProposerIndex() int {
 	return chain.GetBestState().ShardProposerIdx
 }
 
-func (chain *ShardChain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+func (chain *ShardChain) CreateNewBlock(ctx context.Context, version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
 	Logger.log.Infof("Begin Start New Block Shard %+v", time.Unix(startTime, 0))
 	time1 := time.Now()
-	newBlock, err := chain.Blockchain.NewBlockShard(chain.GetBestState(), version, proposer, round, startTime)
+	newBlock, err := chain.Blockchain.NewBlockShard(ctx, chain.GetBestState(), version, proposer, round, startTime)
 	Logger.log.Infof("Finish New Block Shard %+v", time.Since(time1).Seconds())
 	if err != nil {
 		Logger.log.Error(err)
@@ -210,7 +211,7 @@ func (chain *ShardChain) ValidateBlockSignatures(block common.BlockInterface, co
 }
 
 func (chain *ShardChain) InsertBlk(block common.BlockInterface, shouldValidate bool) error {
-	err := chain.Blockchain.InsertShardBlock(block.(*ShardBlock), shouldValidate)
+	err := chain.Blockchain.InsertShardBlock(context.Background(), block.(*ShardBlock), shouldValidate)
 	if err != nil {
 		Logger.log.Error(err)
 	}
@@ -223,9 +224,9 @@ func (chain *ShardChain) CheckExistedBlk(block common.BlockInterface) bool {
 	return err == nil
 }
 
-func (chain *ShardChain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+func (chain *ShardChain) InsertAndBroadcastBlock(ctx context.Context, block common.BlockInterface) error {
 	go chain.Blockchain.config.Server.PushBlockToAll(block, false)
-	err := chain.Blockchain.InsertShardBlock(block.(*ShardBlock), false)
+	err := chain.Blockchain.InsertShardBlock(ctx, block.(*ShardBlock), false)
 	if err != nil {
 		Logger.log.Error(err)
 		return err
@@ -258,8 +259,8 @@ func (chain *ShardChain) UnmarshalBlock(blockString []byte) (common.BlockInterfa
 	return &shardBlk, nil
 }
 
-func (chain *ShardChain) ValidatePreSignBlock(block common.BlockInterface) error {
-	err := chain.Blockchain.VerifyPreSignShardBlock(block.(*ShardBlock), byte(block.(*ShardBlock).GetShardID()))
+func (chain *ShardChain) ValidatePreSignBlock(ctx context.Context, block common.BlockInterface) error {
+	err := chain.Blockchain.VerifyPreSignShardBlock(ctx, block.(*ShardBlock), byte(block.(*ShardBlock).GetShardID()))
 	if err != nil {
 		Logger.log.Error("ValidatePreSignBlock Shard", err)
 	}
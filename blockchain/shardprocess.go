@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -17,7 +19,9 @@ import (
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/pkg/errors"
 )
@@ -25,13 +29,18 @@ import (
 // VerifyPreSignShardBlock Verify Shard Block Before Signing
 // Used for PBFT consensus
 // this block doesn't have full information (incomplete block)
-func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, shardID byte) error {
+func (blockchain *BlockChain) VerifyPreSignShardBlock(ctx context.Context, shardBlock *ShardBlock, shardID byte) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "shard.VerifyPreSignBlock", "shardID", int(shardID), "height", shardBlock.GetHeight())
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	//get view that block link to
 	preHash := shardBlock.Header.PreviousBlockHash
 	view := blockchain.ShardChain[int(shardID)].GetViewByHash(preHash)
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+	waitCtx, _ := context.WithTimeout(ctx, 5*time.Second)
 	if view == nil {
-		blockchain.config.Syncker.SyncMissingShardBlock(ctx, "", shardID, preHash)
+		blockchain.config.Syncker.SyncMissingShardBlock(waitCtx, "", shardID, preHash)
 	}
 	var checkShardUntilTimeout = func(ctx context.Context) error {
 		for {
@@ -47,7 +56,7 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 			}
 		}
 	}
-	if err := checkShardUntilTimeout(ctx); err != nil {
+	if err := checkShardUntilTimeout(waitCtx); err != nil {
 		return err
 	}
 
@@ -69,8 +78,8 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 			}
 		}
 	}
-	ctx, _ = context.WithTimeout(context.Background(), time.Second*5)
-	if checkBeaconUntilTimeout(ctx) != nil {
+	waitCtx, _ = context.WithTimeout(ctx, time.Second*5)
+	if checkBeaconUntilTimeout(waitCtx) != nil {
 		return errors.New(fmt.Sprintf("Beacon %d not ready, latest is %d", shardBlock.Header.BeaconHeight, blockchain.GetBeaconBestState().BeaconHeight))
 	}
 
@@ -80,7 +89,7 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 	}
 
 	//========Verify shardBlock only
-	if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, true); err != nil {
+	if err := blockchain.verifyPreProcessingShardBlock(ctx, curView, shardBlock, beaconBlocks, shardID, true); err != nil {
 		return err
 	}
 	//========Verify shardBlock with previous best state
@@ -105,16 +114,21 @@ func (blockchain *BlockChain) VerifyPreSignShardBlock(shardBlock *ShardBlock, sh
 
 // InsertShardBlock Insert Shard Block into blockchain
 // this block must have full information (complete block)
-func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldValidate bool) error {
+func (blockchain *BlockChain) InsertShardBlock(ctx context.Context, shardBlock *ShardBlock, shouldValidate bool) (err error) {
 	blockHash := shardBlock.Header.Hash()
 	blockHeight := shardBlock.Header.Height
 	shardID := shardBlock.Header.ShardID
 	preHash := shardBlock.Header.PreviousBlockHash
+	ctx, span := tracing.StartSpan(ctx, "shard.InsertBlock", "shardID", int(shardID), "height", blockHeight, "hash", blockHash.String(), "shouldValidate", shouldValidate)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 
 	Logger.log.Infof("SHARD %+v | InsertShardBlock %+v with hash %+v \nPrev hash: %+v", shardID, blockHeight, blockHash, preHash)
 	blockchain.ShardChain[int(shardID)].insertLock.Lock()
 	defer blockchain.ShardChain[int(shardID)].insertLock.Unlock()
-	//startTimeInsertShardBlock := time.Now()
+	startTimeInsertShardBlock := time.Now()
 	committeeChange := newCommitteeChange()
 
 	//check if view is committed
@@ -144,7 +158,7 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 	if shouldValidate {
 		Logger.log.Infof("SHARD %+v | Verify Pre Processing, block height %+v with hash %+vt \n", shardID, blockHeight, blockHash)
-		if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, false); err != nil {
+		if err := blockchain.verifyPreProcessingShardBlock(ctx, curView, shardBlock, beaconBlocks, shardID, false); err != nil {
 			return err
 		}
 	} else {
@@ -154,7 +168,11 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	if shouldValidate {
 		// Verify block with previous best state
 		Logger.log.Debugf("SHARD %+v | Verify BestState With Shard Block, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
-		if err := curView.verifyBestStateWithShardBlock(blockchain, shardBlock, true, shardID); err != nil {
+		_, verifyBestStateSpan := tracing.StartSpan(ctx, "shard.verifyBestStateWithShardBlock")
+		err := curView.verifyBestStateWithShardBlock(blockchain, shardBlock, true, shardID)
+		verifyBestStateSpan.SetError(err)
+		verifyBestStateSpan.End()
+		if err != nil {
 			return err
 		}
 	} else {
@@ -162,7 +180,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 
 	Logger.log.Debugf("SHARD %+v | Update ShardBestState, block height %+v with hash %+v \n", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
+	_, updateBestStateSpan := tracing.StartSpan(ctx, "shard.updateShardBestState")
 	newBestState, err := curView.updateShardBestState(blockchain, shardBlock, beaconBlocks, committeeChange)
+	updateBestStateSpan.SetError(err)
+	updateBestStateSpan.End()
 	if err != nil {
 		return err
 	}
@@ -174,7 +195,11 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	//========Post verification: verify new beaconstate with corresponding block
 	if shouldValidate {
 		Logger.log.Infof("SHARD %+v | Verify Post Processing, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
-		if err := blockchain.verifyPostProcessingShardBlock(newBestState, shardBlock, shardID); err != nil {
+		_, verifyPostProcessingSpan := tracing.StartSpan(ctx, "shard.verifyPostProcessing")
+		err := blockchain.verifyPostProcessingShardBlock(newBestState, shardBlock, shardID)
+		verifyPostProcessingSpan.SetError(err)
+		verifyPostProcessingSpan.End()
+		if err != nil {
 			fmt.Println("Instructions", shardBlock.Body.Instructions)
 			return err
 		}
@@ -189,7 +214,10 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 	Logger.log.Infof("SHARD %+v | Store New Shard Block And Update Data, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
 	//========Store new  Shard block and new shard bestState
+	_, storeBlockSpan := tracing.StartSpan(ctx, "shard.processStoreShardBlock", "txs", len(shardBlock.Body.Transactions))
 	err = blockchain.processStoreShardBlock(newBestState, shardBlock, committeeChange, beaconBlocks)
+	storeBlockSpan.SetError(err)
+	storeBlockSpan.End()
 	if err != nil {
 
 		return err
@@ -197,10 +225,17 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	blockchain.removeOldDataAfterProcessingShardBlock(shardBlock, shardID)
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, newBestState))
+	getShardInsertBlockLatencyTimer(shardID).UpdateSince(startTimeInsertShardBlock)
 	Logger.log.Infof("SHARD %+v | Finish Insert new block %d, with hash %+v 🔗", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 	return nil
 }
 
+// getShardInsertBlockLatencyTimer returns the block insert latency timer labeled with shardID
+func getShardInsertBlockLatencyTimer(shardID byte) metrics.Timer {
+	name := metrics.LabeledName(metrics.BlockInsertTime, "chain", "shard", "shard", strconv.Itoa(int(shardID)))
+	return metrics.GetOrRegisterTimer(name, nil)
+}
+
 // updateNumOfBlocksByProducers updates number of blocks produced by producers to shard best state
 func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *ShardBlock) {
 	isSwapInstContained := false
@@ -252,7 +287,12 @@ func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *S
 //	- Validate transaction created from miner via instruction
 //	- Validate Response Transaction From Transaction with Metadata
 //	- ALL Transaction in block: see in verifyTransactionFromNewBlock
-func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, shardID byte, isPreSign bool) error {
+func (blockchain *BlockChain) verifyPreProcessingShardBlock(ctx context.Context, curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, shardID byte, isPreSign bool) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "shard.verifyPreProcessing", "isPreSign", isPreSign)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	startTimeVerifyPreProcessingShardBlock := time.Now()
 	Logger.log.Debugf("SHARD %+v | Begin verifyPreProcessingShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash().String())
 	if shardBlock.Header.ShardID != shardID {
@@ -308,7 +348,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 		return NewBlockChainError(CrossShardTransactionRootHashError, fmt.Errorf("Expect cross shard transaction root hash %+v", shardBlock.Header.CrossTransactionRoot))
 	}
 	// Verify Action
-	txInstructions, err := CreateShardInstructionsFromTransactionAndInstruction(shardBlock.Body.Transactions, blockchain, shardID, shardBlock.Header.Height)
+	txInstructions, err := CreateShardInstructionsFromTransactionAndInstruction(shardBlock.Body.Transactions, blockchain, shardID, shardBlock.Header.Height, shardBlock.Header.BeaconHeight)
 	if err != nil {
 		Logger.log.Error(err)
 		return NewBlockChainError(ShardIntructionFromTransactionAndInstructionError, err)
@@ -434,7 +474,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 	shardVerifyPreprocesingTimer.UpdateSince(startTimeVerifyPreProcessingShardBlock)
 	// Get cross shard shardBlock from pool
 	if isPreSign {
-		err := blockchain.verifyPreProcessingShardBlockForSigning(curView, shardBlock, beaconBlocks, txInstructions, shardID)
+		err := blockchain.verifyPreProcessingShardBlockForSigning(ctx, curView, shardBlock, beaconBlocks, txInstructions, shardID)
 		if err != nil {
 			return err
 		}
@@ -449,14 +489,14 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 //	- Get Cross Output Data from cross shard block (shard pool) and verify cross transaction hash
 //	- Get Cross Tx Custom Token from cross shard block (shard pool) then verify
 //
-func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, txInstructions [][]string, shardID byte) error {
+func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(ctx context.Context, curView *ShardBestState, shardBlock *ShardBlock, beaconBlocks []*BeaconBlock, txInstructions [][]string, shardID byte) error {
 	var err error
 	var isOldBeaconHeight = false
 	startTimeVerifyPreProcessingShardBlockForSigning := time.Now()
 	// Verify Transaction
 	//get beacon height from shard block
 	beaconHeight := shardBlock.Header.BeaconHeight
-	if err := blockchain.verifyTransactionFromNewBlock(shardID, shardBlock.Body.Transactions, int64(beaconHeight), curView); err != nil {
+	if err := blockchain.verifyTransactionFromNewBlock(ctx, shardID, shardBlock.Body.Transactions, int64(beaconHeight), curView); err != nil {
 		return NewBlockChainError(TransactionFromNewBlockError, err)
 	}
 	// Verify Instruction
@@ -494,7 +534,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlockForSigning(curView *S
 			crossShardRequired[fromShard] = append(crossShardRequired[fromShard], crossTransaction.BlockHeight)
 		}
 	}
-	crossShardBlksFromPool, err := blockchain.config.Syncker.GetCrossShardBlocksForShardValidator(toShard, crossShardRequired)
+	crossShardBlksFromPool, err := blockchain.config.Syncker.GetCrossShardBlocksForShardValidator(ctx, toShard, crossShardRequired)
 	if err != nil {
 		return NewBlockChainError(CrossShardBlockError, fmt.Errorf("Unable to get required crossShard blocks from pool in time"))
 	}
@@ -974,10 +1014,15 @@ func (blockchain *BlockChain) verifyPostProcessingShardBlock(shardBestState *Sha
 //	9. Not accept a salary tx
 //	10. Check duplicate staker public key in block
 //	11. Check duplicate Init Custom Token in block
-func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []metadata.Transaction, beaconHeight int64, curView *ShardBestState) error {
+func (blockchain *BlockChain) verifyTransactionFromNewBlock(ctx context.Context, shardID byte, txs []metadata.Transaction, beaconHeight int64, curView *ShardBestState) (err error) {
 	if len(txs) == 0 {
 		return nil
 	}
+	ctx, span := tracing.StartSpan(ctx, "shard.verifyTransactionFromNewBlock", "txs", len(txs))
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	isEmpty := blockchain.config.TempTxPool.EmptyPool()
 	if !isEmpty {
 		panic("TempTxPool Is not Empty")
@@ -989,7 +1034,13 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []
 			listTxs = append(listTxs, tx)
 		}
 	}
-	_, err := blockchain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(shardID, listTxs, beaconHeight, curView)
+	// stateless checks run in parallel, stateful checks depend on the transactions accepted before so they keep the block order
+	err = blockchain.verifyTransactionsByItself(listTxs, curView, uint64(beaconHeight))
+	if err == nil {
+		startTimeVerifyTxStateful := time.Now()
+		_, err = blockchain.config.TempTxPool.MaybeAcceptVerifiedBatchTransactionForBlockProducing(ctx, shardID, listTxs, beaconHeight, curView)
+		shardVerifyTxStatefulTimer.UpdateSince(startTimeVerifyTxStateful)
+	}
 	if err != nil {
 		Logger.log.Errorf("Batching verify transactions from new block err: %+v\n Trying verify one by one", err)
 		for index, tx := range listTxs {
@@ -1006,6 +1057,90 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []
 	return nil
 }
 
+// verifyTransactionsByItself runs the checks of txs which depend neither on the chain state nor on each other:
+// sanity data, then signatures and privacy proofs, by at most TxValidationWorkers goroutines.
+// beaconHeight is the beacon height of the block the txs are in, the forks of the txs are checked at this height
+func (blockchain *BlockChain) verifyTransactionsByItself(txs []metadata.Transaction, curView *ShardBestState, beaconHeight uint64) error {
+	// the temp pool validates the batch against the beacon view of the best block as well
+	beaconView, err := blockchain.GetBeaconViewStateDataFromBlockHash(curView.BestBlock.Header.BeaconHash)
+	if err != nil {
+		return err
+	}
+	numWorkers := blockchain.getTxValidationWorkers()
+
+	startTimeVerifyTxSanity := time.Now()
+	err = blockchain.verifyTransactionsSanity(txs, curView, beaconView, beaconHeight, numWorkers)
+	shardVerifyTxSanityTimer.UpdateSince(startTimeVerifyTxSanity)
+	if err != nil {
+		return err
+	}
+
+	startTimeVerifyTxProof := time.Now()
+	defer shardVerifyTxProofTimer.UpdateSince(startTimeVerifyTxProof)
+	boolParams := make(map[string]bool)
+	boolParams["isNewTransaction"] = false
+	boolParams["isBatch"] = true
+	boolParams["isNewZKP"] = blockchain.IsAfterNewZKPCheckPoint(beaconHeight)
+	ok, err, index := transaction.NewBatchTransaction(txs).ValidateParallel(curView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), boolParams, numWorkers)
+	if !ok {
+		return fmt.Errorf("verify batch transaction failed at index %+v: %+v", index, err)
+	}
+	return nil
+}
+
+// verifyTransactionsSanity validates the sanity data of txs at beaconHeight by at most numWorkers goroutines
+func (blockchain *BlockChain) verifyTransactionsSanity(txs []metadata.Transaction, curView *ShardBestState, beaconView *BeaconBestState, beaconHeight uint64, numWorkers int) error {
+	return validateInParallel(len(txs), numWorkers, func(index int) error {
+		ok, err := txs[index].ValidateSanityData(blockchain, curView, beaconView, beaconHeight)
+		if !ok {
+			return fmt.Errorf("transaction %+v, index %+v has invalid sanity data: %+v", *txs[index].Hash(), index, err)
+		}
+		return nil
+	})
+}
+
+// getTxValidationWorkers returns the number of goroutines validating transactions of a shard block
+func (blockchain *BlockChain) getTxValidationWorkers() int {
+	if blockchain.config.TxValidationWorkers > 0 {
+		return blockchain.config.TxValidationWorkers
+	}
+	return runtime.NumCPU()
+}
+
+// validateInParallel calls validate for every index in [0, n) by at most numWorkers goroutines,
+// it returns the error of the lowest failed index so the result does not depend on scheduling
+func validateInParallel(n int, numWorkers int, validate func(index int) error) error {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	if numWorkers > n {
+		numWorkers = n
+	}
+	errs := make([]error, n)
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = validate(i)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // processStoreShardBlock Store All information after Insert
 //	- Shard Block
 //	- Shard Best State
@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateInParallel(t *testing.T) {
+	var running, maxRunning int32
+	validated := make([]int32, 20)
+	err := validateInParallel(len(validated), 3, func(index int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&validated[index], 1)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, maxRunning <= 3)
+	for index, count := range validated {
+		assert.Equal(t, int32(1), count, "index %v", index)
+	}
+
+	// the error of the lowest index is returned whatever worker finishes first
+	err = validateInParallel(10, 4, func(index int) error {
+		if index == 7 || index == 3 {
+			return fmt.Errorf("invalid %v", index)
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "invalid 3")
+
+	assert.Nil(t, validateInParallel(0, 4, func(index int) error { return fmt.Errorf("invalid %v", index) }))
+	assert.EqualError(t, validateInParallel(1, 0, func(index int) error { return fmt.Errorf("invalid %v", index) }), "invalid 0")
+}
+
+func TestVerifyTransactionsSanityAtRingSizeFork(t *testing.T) {
+	breakPoint := uint64(100)
+	blockchain := &BlockChain{config: Config{ChainParams: &Params{
+		BCHeightBreakPointRingSize: breakPoint,
+		CommitmentRingSizes:        []int{16, 32, 64},
+	}}}
+	// the best block of the shard is far past the fork, only the beacon height of the new block counts
+	curView := &ShardBestState{BestBlock: &ShardBlock{Header: ShardHeader{BeaconHeight: breakPoint + 1000}}}
+
+	// tx with ring size 32, checked against the ring sizes supported by the chain as transaction.Tx does
+	tx := &mocks.Transaction{}
+	tx.On("Hash").Return(&common.Hash{})
+	tx.On("ValidateSanityData", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(bcr metadata.ChainRetriever, shardView metadata.ShardViewRetriever, beaconView metadata.BeaconViewRetriever, beaconHeight uint64) bool {
+			for _, ringSize := range bcr.GetSupportedCommitmentRingSizes(beaconHeight) {
+				if ringSize == 32 {
+					return true
+				}
+			}
+			return false
+		}, nil)
+	txs := []metadata.Transaction{tx}
+
+	assert.NotNil(t, blockchain.verifyTransactionsSanity(txs, curView, nil, breakPoint-1, 1), "ring size 32 one height before the fork")
+	assert.Nil(t, blockchain.verifyTransactionsSanity(txs, curView, nil, breakPoint, 1), "ring size 32 at the fork")
+	assert.Nil(t, blockchain.verifyTransactionsSanity(txs, curView, nil, breakPoint+1, 1), "ring size 32 one height after the fork")
+}
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -13,6 +14,7 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 )
 
@@ -53,7 +55,12 @@ import (
 //	5. Create Root Hash from New Shard Block and updated Clone Shard Beststate Data
 // REVIEW: @hung
 // - Possible reduction of return value for processInstructionFromBeacon
-func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int, proposer string, round int, start int64) (*ShardBlock, error) {
+func (blockchain *BlockChain) NewBlockShard(ctx context.Context, curView *ShardBestState, version int, proposer string, round int, start int64) (block *ShardBlock, err error) {
+	ctx, span := tracing.StartSpan(ctx, "shard.NewBlockShard", "shardID", int(curView.ShardID), "height", curView.ShardHeight+1, "round", round)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	time1 := time.Now()
 	var (
 		transactionsForNewBlock = make([]metadata.Transaction, 0)
@@ -123,7 +130,10 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	}
 	Logger.log.Infof("Get Beacon Block With Height %+v, Shard BestState %+v", beaconHeight, shardBestState.BeaconHeight)
 	//Fetch beacon block from height
+	_, fetchBeaconSpan := tracing.StartSpan(ctx, "shard.FetchBeaconBlockFromHeight", "from", shardBestState.BeaconHeight+1, "to", beaconHeight)
 	beaconBlocks, err := FetchBeaconBlockFromHeight(blockchain, shardBestState.BeaconHeight+1, beaconHeight)
+	fetchBeaconSpan.SetError(err)
+	fetchBeaconSpan.End()
 	if err != nil {
 		return nil, err
 	}
@@ -134,22 +144,28 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	//==========Build block body============
 	// Get Transaction For new Block
 	// Get Cross output coin from other shard && produce cross shard transaction
-	crossTransactions := blockchain.config.BlockGen.getCrossShardData(shardID, shardBestState.BeaconHeight, beaconHeight)
+	crossTransactions := blockchain.config.BlockGen.getCrossShardData(ctx, shardID, shardBestState.BeaconHeight, beaconHeight)
 	Logger.log.Critical("Cross Transaction: ", crossTransactions)
 	// Get Transaction for new block
 	// // startStep = time.Now()
 	blockCreationLeftOver := curView.BlockMaxCreateTime.Nanoseconds() - time.Since(time1).Nanoseconds()
-	txsToAddFromBlock, err := blockchain.config.BlockGen.getTransactionForNewBlock(curView, &tempPrivateKey, shardID, beaconBlocks, blockCreationLeftOver, beaconHeight)
+	txsToAddFromBlock, err := blockchain.config.BlockGen.getTransactionForNewBlock(ctx, curView, &tempPrivateKey, shardID, beaconBlocks, blockCreationLeftOver, beaconHeight)
 	if err != nil {
 		return nil, err
 	}
 	transactionsForNewBlock = append(transactionsForNewBlock, txsToAddFromBlock...)
 	// build txs with metadata
+	_, buildResponseSpan := tracing.StartSpan(ctx, "shard.BuildResponseTransactionFromTxsWithMetadata", "txs", len(transactionsForNewBlock))
 	transactionsForNewBlock, err = blockchain.BuildResponseTransactionFromTxsWithMetadata(curView, transactionsForNewBlock, &tempPrivateKey, shardID)
+	buildResponseSpan.SetError(err)
+	buildResponseSpan.End()
 	// process instruction from beacon block
 	shardPendingValidator, _, _ = blockchain.processInstructionFromBeacon(curView, beaconBlocks, shardID, committeeChange)
 	// Create Instruction
+	_, generateInstructionSpan := tracing.StartSpan(ctx, "shard.generateInstruction")
 	instructions, _, _, err = blockchain.generateInstruction(curView, shardID, beaconHeight, isOldBeaconHeight, beaconBlocks, shardPendingValidator, currentCommitteePubKeys)
+	generateInstructionSpan.SetError(err)
+	generateInstructionSpan.End()
 	if err != nil {
 		return nil, NewBlockChainError(GenerateInstructionError, err)
 	}
@@ -187,7 +203,10 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	}
 	//============Update Shard BestState=============
 	// startStep = time.Now()
+	_, updateBestStateSpan := tracing.StartSpan(ctx, "shard.updateShardBestState")
 	newShardBestState, err := shardBestState.updateShardBestState(blockchain, newShardBlock, beaconBlocks, committeeChange)
+	updateBestStateSpan.SetError(err)
+	updateBestStateSpan.End()
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +221,7 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 	if err != nil {
 		return nil, err
 	}
-	txInstructions, err := CreateShardInstructionsFromTransactionAndInstruction(newShardBlock.Body.Transactions, blockchain, shardID, newShardBlock.Header.Height)
+	txInstructions, err := CreateShardInstructionsFromTransactionAndInstruction(newShardBlock.Body.Transactions, blockchain, shardID, newShardBlock.Header.Height, beaconHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -269,8 +288,8 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 // 3. Build response Transaction For Shard
 // 4. Build response Transaction For Beacon
 // 5. Return valid transaction from pending, response transactions from shard and beacon
-func (blockGenerator *BlockGenerator) getTransactionForNewBlock(curView *ShardBestState, privatekey *privacy.PrivateKey, shardID byte, beaconBlocks []*BeaconBlock, blockCreation int64, beaconHeight uint64) ([]metadata.Transaction, error) {
-	txsToAdd, txToRemove, _ := blockGenerator.getPendingTransaction(shardID, beaconBlocks, blockCreation, beaconHeight, curView)
+func (blockGenerator *BlockGenerator) getTransactionForNewBlock(ctx context.Context, curView *ShardBestState, privatekey *privacy.PrivateKey, shardID byte, beaconBlocks []*BeaconBlock, blockCreation int64, beaconHeight uint64) ([]metadata.Transaction, error) {
+	txsToAdd, txToRemove, _ := blockGenerator.getPendingTransaction(ctx, shardID, beaconBlocks, blockCreation, beaconHeight, curView)
 	if len(txsToAdd) == 0 {
 		Logger.log.Info("Creating empty block...")
 	}
@@ -281,7 +300,11 @@ func (blockGenerator *BlockGenerator) getTransactionForNewBlock(curView *ShardBe
 	cError = make(chan error)
 	go func() {
 		var err error
+		_, span := tracing.StartSpan(ctx, "shard.buildResponseTxsFromBeaconInstructions", "beaconBlocks", len(beaconBlocks))
 		responseTxsBeacon, errInstructions, err = blockGenerator.buildResponseTxsFromBeaconInstructions(curView, beaconBlocks, privatekey, shardID)
+		span.SetAttributes("txs", len(responseTxsBeacon))
+		span.SetError(err)
+		span.End()
 		cError <- err
 	}()
 	nilCount := 0
@@ -339,6 +362,10 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDECrossPoolTradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
+			case metadata.PDELimitOrderRequestMeta:
+				if len(l) >= 4 {
+					newTx, err = blockGenerator.buildPDELimitOrderIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
 			case metadata.PDEWithdrawalRequestMeta:
 				if len(l) >= 4 && l[2] == common.PDEWithdrawalAcceptedChainStatus {
 					newTx, err = blockGenerator.buildPDEWithdrawalTx(l[3], producerPrivateKey, shardID, curView, beaconView)
@@ -636,11 +663,13 @@ func (blockchain *BlockChain) generateInstruction(view *ShardBestState, shardID
 //	  - Process valid block to extract:
 //	   + Cross output coin
 //	   + Cross Normal Token
-func (blockGenerator *BlockGenerator) getCrossShardData(toShard byte, lastBeaconHeight uint64, currentBeaconHeight uint64) map[byte][]CrossTransaction {
+func (blockGenerator *BlockGenerator) getCrossShardData(ctx context.Context, toShard byte, lastBeaconHeight uint64, currentBeaconHeight uint64) map[byte][]CrossTransaction {
+	ctx, span := tracing.StartSpan(ctx, "shard.getCrossShardData")
+	defer span.End()
 	crossTransactions := make(map[byte][]CrossTransaction)
 	// get cross shard block
 	var allCrossShardBlock = make([][]*CrossShardBlock, blockGenerator.chain.config.ChainParams.ActiveShards)
-	for sid, v := range blockGenerator.syncker.GetCrossShardBlocksForShardProducer(toShard, nil) {
+	for sid, v := range blockGenerator.syncker.GetCrossShardBlocksForShardProducer(ctx, toShard, nil) {
 		heightList := make([]uint64, len(v))
 		for i, b := range v {
 			allCrossShardBlock[sid] = append(allCrossShardBlock[sid], b.(*CrossShardBlock))
@@ -661,6 +690,7 @@ func (blockGenerator *BlockGenerator) getCrossShardData(toShard byte, lastBeacon
 			crossTransactions[blk.Header.ShardID] = append(crossTransactions[blk.Header.ShardID], crossTransaction)
 		}
 	}
+	span.SetAttributes("crossShardBlocks", len(crossTransactions))
 	return crossTransactions
 }
 
@@ -668,12 +698,18 @@ func (blockGenerator *BlockGenerator) getCrossShardData(toShard byte, lastBeacon
 	Verify Transaction with these condition: defined in mempool.go
 */
 func (blockGenerator *BlockGenerator) getPendingTransaction(
+	ctx context.Context,
 	shardID byte,
 	beaconBlocks []*BeaconBlock,
 	blockCreationTimeLeftOver int64,
 	beaconHeight uint64,
 	curView *ShardBestState,
 ) (txsToAdd []metadata.Transaction, txToRemove []metadata.Transaction, totalFee uint64) {
+	ctx, span := tracing.StartSpan(ctx, "shard.getPendingTransaction")
+	defer func() {
+		span.SetAttributes("txsToAdd", len(txsToAdd), "txsToRemove", len(txToRemove))
+		span.End()
+	}()
 	spareTime := SpareTime * time.Millisecond
 	maxBlockCreationTimeLeftTime := blockCreationTimeLeftOver - spareTime.Nanoseconds()
 	startTime := time.Now()
@@ -707,7 +743,7 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 		}
 		listBatchTxs = append(listBatchTxs, tx)
 		if ((index+1)%TransactionBatchSize == 0) || (index == len(preparedTxForNewBlock)-1) {
-			tempTxDesc, err := blockGenerator.chain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(shardID, listBatchTxs, int64(beaconHeight), curView)
+			tempTxDesc, err := blockGenerator.chain.config.TempTxPool.MaybeAcceptBatchTransactionForBlockProducing(ctx, shardID, listBatchTxs, int64(beaconHeight), curView)
 			if err != nil {
 				Logger.log.Errorf("SHARD %+v | Verify Batch Transaction for new block error %+v", shardID, err)
 				for _, tx2 := range listBatchTxs {
@@ -0,0 +1,44 @@
+package blockexporter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const checkpointFileName = "checkpoint.json"
+
+// checkpoint is the height of the latest exported block of every chain, mapped by chain name.
+// It is written after the part files of the blocks so an export resumed from it never misses a block,
+// part files written after the checkpoint are overwritten when the blocks are exported again
+type checkpoint map[string]uint64
+
+func loadCheckpoint(dir string) (checkpoint, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, checkpointFileName))
+	if os.IsNotExist(err) {
+		return checkpoint{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cp := checkpoint{}
+	err = json.Unmarshal(data, &cp)
+	if err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+func (cp checkpoint) save(dir string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(dir, checkpointFileName)
+	err = ioutil.WriteFile(fileName+".tmp", data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(fileName+".tmp", fileName)
+}
@@ -0,0 +1,280 @@
+package blockexporter
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockfollower"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+const (
+	defaultBatchSize     = 1000
+	defaultPartitionSize = 10000
+	defaultFlushInterval = 10 * time.Minute
+)
+
+var tables = []string{BeaconBlockTable, ShardBlockTable, TransactionTable, InstructionTable, EventTable}
+
+var partFileRegexp = regexp.MustCompile(`^part-(\d+)-(\d+)\.`)
+
+// ChainReader is the access of the exporter to the chains
+type ChainReader interface {
+	blockfollower.BeaconChain
+	blockfollower.ShardChain
+}
+
+type Config struct {
+	Dir           string
+	Format        string // NDJSONFormat or ParquetFormat
+	ChainIDs      []int  // common.BeaconChainDataBaseID for the beacon chain
+	FromHeight    uint64 // first height exported of a chain without checkpoint, default is 1
+	ToHeight      uint64 // last height exported, no limit if 0
+	BatchSize     uint64 // max number of blocks in a part file, default is 1000
+	PartitionSize uint64 // number of heights in a partition folder, default is 10000
+	FlushInterval time.Duration
+	BlockChain    ChainReader
+	PubSubManager *pubsub.PubSubManager
+}
+
+// chainBuffer holds the records of blocks of a chain which are not written yet
+type chainBuffer struct {
+	fromHeight uint64
+	toHeight   uint64
+	records    map[string][]interface{}
+}
+
+// Exporter writes beacon blocks, shard blocks, transactions, instructions and pde/portal events of final blocks
+// to ndjson or parquet files partitioned by chain and height, the latest exported height of every chain is kept in
+// a checkpoint file so the export is resumed from it
+type Exporter struct {
+	config   Config
+	follower *blockfollower.Follower
+
+	mtx        sync.Mutex
+	checkpoint checkpoint
+	buffers    map[int]*chainBuffer
+	lastFlush  time.Time
+}
+
+func NewExporter(config Config) (*Exporter, error) {
+	if config.Dir == "" || config.BlockChain == nil {
+		return nil, errors.New("dir and blockchain of block exporter must not be empty")
+	}
+	if config.Format == "" {
+		config.Format = NDJSONFormat
+	}
+	if config.Format != NDJSONFormat && config.Format != ParquetFormat {
+		return nil, errors.New("block export format must be " + NDJSONFormat + " or " + ParquetFormat)
+	}
+	if len(config.ChainIDs) == 0 {
+		return nil, errors.New("no chain to export")
+	}
+	if config.FromHeight == 0 {
+		config.FromHeight = 1
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.PartitionSize == 0 {
+		config.PartitionSize = defaultPartitionSize
+	}
+	if config.FlushInterval == 0 {
+		config.FlushInterval = defaultFlushInterval
+	}
+	err := os.MkdirAll(config.Dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := loadCheckpoint(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	exporter := &Exporter{
+		config:     config,
+		checkpoint: cp,
+		buffers:    make(map[int]*chainBuffer),
+		lastFlush:  time.Now(),
+	}
+	exporter.follower = blockfollower.New(config.PubSubManager, []string{pubsub.NewBeaconBlockTopic, pubsub.NewShardblockTopic}, exporter.follow)
+	return exporter, nil
+}
+
+// Start exports final blocks in background
+func (exporter *Exporter) Start() error {
+	return exporter.follower.Start()
+}
+
+// Stop stops exporting and writes the buffered records
+func (exporter *Exporter) Stop() {
+	exporter.follower.Stop()
+	err := exporter.Flush()
+	if err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+// follow exports final blocks and writes the buffered records every FlushInterval
+func (exporter *Exporter) follow() {
+	err := exporter.ExportFinalBlocks()
+	if err != nil {
+		Logger.log.Errorf("Export final blocks failed: %v", err)
+	}
+	exporter.mtx.Lock()
+	lastFlush := exporter.lastFlush
+	exporter.mtx.Unlock()
+	if time.Since(lastFlush) < exporter.config.FlushInterval {
+		return
+	}
+	err = exporter.Flush()
+	if err != nil {
+		Logger.log.Errorf("Write exported blocks failed: %v", err)
+	}
+}
+
+// GetLatestExportedHeight returns the height of the latest block of a chain written to the export files
+func (exporter *Exporter) GetLatestExportedHeight(chainID int) uint64 {
+	exporter.mtx.Lock()
+	defer exporter.mtx.Unlock()
+	return exporter.checkpoint[chainName(chainID)]
+}
+
+// ExportFinalBlocks exports the blocks of every chain from the latest exported one to the final view,
+// records are buffered until a batch or a partition is complete
+func (exporter *Exporter) ExportFinalBlocks() error {
+	exporter.mtx.Lock()
+	defer exporter.mtx.Unlock()
+	for _, chainID := range exporter.config.ChainIDs {
+		err := exporter.exportChain(chainID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes the buffered records of every chain
+func (exporter *Exporter) Flush() error {
+	exporter.mtx.Lock()
+	defer exporter.mtx.Unlock()
+	for _, chainID := range exporter.config.ChainIDs {
+		err := exporter.flushChain(chainID)
+		if err != nil {
+			return err
+		}
+	}
+	exporter.lastFlush = time.Now()
+	return nil
+}
+
+func (exporter *Exporter) exportChain(chainID int) error {
+	bc := exporter.config.BlockChain
+	var finalHeight uint64
+	if chainID == common.BeaconChainDataBaseID {
+		finalHeight = bc.GetFinalBeaconHeight()
+	} else {
+		finalHeight = bc.GetFinalShardHeight(byte(chainID))
+	}
+	if exporter.config.ToHeight != 0 && finalHeight > exporter.config.ToHeight {
+		finalHeight = exporter.config.ToHeight
+	}
+	height := exporter.config.FromHeight
+	if buffer, ok := exporter.buffers[chainID]; ok {
+		height = buffer.toHeight + 1
+	} else if exportedHeight, ok := exporter.checkpoint[chainName(chainID)]; ok {
+		height = exportedHeight + 1
+	}
+	return exporter.follower.FollowHeights(height, finalHeight, func(height uint64) error {
+		var records map[string][]interface{}
+		if chainID == common.BeaconChainDataBaseID {
+			block, err := bc.GetBeaconBlockByHeightV1(height)
+			if err != nil {
+				return err
+			}
+			records = buildBeaconBlockRecords(block)
+		} else {
+			block, err := bc.GetShardBlockByHeightV1(height, byte(chainID))
+			if err != nil {
+				return err
+			}
+			records = buildShardBlockRecords(block)
+		}
+		buffer, ok := exporter.buffers[chainID]
+		if !ok {
+			buffer = &chainBuffer{fromHeight: height, records: make(map[string][]interface{})}
+			exporter.buffers[chainID] = buffer
+		}
+		buffer.toHeight = height
+		for table, tableRecords := range records {
+			buffer.records[table] = append(buffer.records[table], tableRecords...)
+		}
+		if buffer.toHeight-buffer.fromHeight+1 >= exporter.config.BatchSize || height%exporter.config.PartitionSize == 0 {
+			return exporter.flushChain(chainID)
+		}
+		return nil
+	})
+}
+
+// flushChain writes a part file for each table with records of the buffered blocks of a chain then updates the checkpoint
+func (exporter *Exporter) flushChain(chainID int) error {
+	buffer, ok := exporter.buffers[chainID]
+	if !ok {
+		return nil
+	}
+	for _, table := range tables {
+		err := removeStaleParts(partitionDir(exporter.config.Dir, table, chainID, buffer.fromHeight, exporter.config.PartitionSize), buffer.fromHeight)
+		if err != nil {
+			return err
+		}
+		records := buffer.records[table]
+		if len(records) == 0 {
+			continue
+		}
+		_, err = writeTableFile(exporter.config.Dir, exporter.config.Format, table, chainID, buffer.fromHeight, buffer.toHeight, exporter.config.PartitionSize, records)
+		if err != nil {
+			return err
+		}
+	}
+	exporter.checkpoint[chainName(chainID)] = buffer.toHeight
+	err := exporter.checkpoint.save(exporter.config.Dir)
+	if err != nil {
+		return err
+	}
+	delete(exporter.buffers, chainID)
+	Logger.log.Infof("Exported %v blocks %v to %v", chainName(chainID), buffer.fromHeight, buffer.toHeight)
+	return nil
+}
+
+// removeStaleParts removes part files of blocks from fromHeight in a partition folder, they were written
+// by an export stopped before the checkpoint was updated
+func removeStaleParts(partDir string, fromHeight uint64) error {
+	files, err := ioutil.ReadDir(partDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		matches := partFileRegexp.FindStringSubmatch(file.Name())
+		if matches == nil {
+			continue
+		}
+		partFromHeight, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil || partFromHeight < fromHeight {
+			continue
+		}
+		err = os.Remove(filepath.Join(partDir, file.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package blockexporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower/followertest"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+type fakeChain struct {
+	*followertest.Chain
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{followertest.NewChain()}
+}
+
+// addBlocks adds beacon and shard blocks up to height, every beacon block has a pde trade instruction
+// and every shard block has a transaction
+func (bc *fakeChain) addBlocks(height uint64) {
+	for h := bc.FinalBeaconHeight + 1; h <= height; h++ {
+		bc.AddBeaconBlock(&blockchain.BeaconBlock{
+			Header: blockchain.BeaconHeader{Height: h, Timestamp: int64(h * 40)},
+			Body: blockchain.BeaconBody{Instructions: [][]string{
+				{"random", "1"},
+				{strconv.Itoa(metadata.PDETradeRequestMeta), "0", common.PDETradeAcceptedChainStatus, "{}"},
+			}},
+		})
+		bc.AddShardBlock(&blockchain.ShardBlock{
+			Header: blockchain.ShardHeader{Height: h, ShardID: 0, Timestamp: int64(h * 40)},
+			Body: blockchain.ShardBody{Transactions: []metadata.Transaction{
+				&transaction.Tx{Type: common.TxNormalType, Fee: h, LockTime: int64(h)},
+			}},
+		})
+	}
+}
+
+func newTestExporter(t *testing.T, dir string, bc *fakeChain, format string) *Exporter {
+	exporter, err := NewExporter(Config{
+		Dir:           dir,
+		Format:        format,
+		ChainIDs:      []int{common.BeaconChainDataBaseID, 0},
+		BatchSize:     4,
+		PartitionSize: 10,
+		BlockChain:    bc,
+	})
+	assert.Nil(t, err)
+	return exporter
+}
+
+// listParts returns the part files of a table of a chain relative to the table folder
+func listParts(t *testing.T, dir string, table string, chain string) []string {
+	result := []string{}
+	root := filepath.Join(dir, table, "chain="+chain)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		result = append(result, rel)
+		return nil
+	})
+	if !os.IsNotExist(err) {
+		assert.Nil(t, err)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func readNDJSON(t *testing.T, fileName string, newRecord func() interface{}) []interface{} {
+	f, err := os.Open(fileName)
+	assert.Nil(t, err)
+	defer f.Close()
+	records := []interface{}{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		record := newRecord()
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestExportFinalBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockexport")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc := newFakeChain()
+	bc.addBlocks(12)
+
+	exporter := newTestExporter(t, dir, bc, NDJSONFormat)
+	assert.Nil(t, exporter.ExportFinalBlocks())
+	// batches are full at 4 and 8, the partition ends at 10, 11 and 12 are buffered
+	assert.Equal(t, uint64(10), exporter.GetLatestExportedHeight(common.BeaconChainDataBaseID))
+	assert.Equal(t, []string{
+		"height=1-10/part-1-4.ndjson",
+		"height=1-10/part-5-8.ndjson",
+		"height=1-10/part-9-10.ndjson",
+	}, listParts(t, dir, BeaconBlockTable, "beacon"))
+	assert.Equal(t, []string{}, listParts(t, dir, ShardBlockTable, "beacon"))
+
+	assert.Nil(t, exporter.Flush())
+	assert.Equal(t, uint64(12), exporter.GetLatestExportedHeight(0))
+	assert.Equal(t, "height=11-20/part-11-12.ndjson", listParts(t, dir, TransactionTable, "shard-0")[3])
+
+	txs := readNDJSON(t, filepath.Join(dir, TransactionTable, "chain=shard-0", "height=1-10", "part-5-8.ndjson"), func() interface{} { return &Transaction{} })
+	assert.Equal(t, 4, len(txs))
+	tx := txs[1].(*Transaction)
+	assert.Equal(t, uint64(6), tx.BlockHeight)
+	assert.Equal(t, uint64(6), tx.Fee)
+	assert.Equal(t, common.TxNormalType, tx.Type)
+	assert.Equal(t, common.PRVCoinID.String(), tx.TokenID)
+
+	insts := readNDJSON(t, filepath.Join(dir, InstructionTable, "chain=beacon", "height=1-10", "part-1-4.ndjson"), func() interface{} { return &Instruction{} })
+	assert.Equal(t, 8, len(insts))
+	assert.Equal(t, `["random","1"]`, insts[0].(*Instruction).Content)
+	assert.Equal(t, common.BeaconChainDataBaseID, insts[0].(*Instruction).ChainID)
+
+	events := readNDJSON(t, filepath.Join(dir, EventTable, "chain=beacon", "height=11-20", "part-11-12.ndjson"), func() interface{} { return &Event{} })
+	assert.Equal(t, 2, len(events))
+	event := events[0].(*Event)
+	assert.Equal(t, PDEEvent, event.Module)
+	assert.Equal(t, metadata.PDETradeRequestMeta, event.MetadataType)
+	assert.Equal(t, uint64(11), event.BeaconHeight)
+	assert.Equal(t, 1, event.Index)
+	assert.Equal(t, common.PDETradeAcceptedChainStatus, event.Status)
+
+	// resume from the checkpoint with a new exporter
+	bc.addBlocks(14)
+	exporter = newTestExporter(t, dir, bc, NDJSONFormat)
+	assert.Nil(t, exporter.ExportFinalBlocks())
+	assert.Nil(t, exporter.Flush())
+	assert.Equal(t, []string{
+		"height=1-10/part-1-4.ndjson",
+		"height=1-10/part-5-8.ndjson",
+		"height=1-10/part-9-10.ndjson",
+		"height=11-20/part-11-12.ndjson",
+		"height=11-20/part-13-14.ndjson",
+	}, listParts(t, dir, ShardBlockTable, "shard-0"))
+}
+
+func TestExportRemovesStaleParts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blockexport")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	bc := newFakeChain()
+	bc.addBlocks(4)
+
+	exporter := newTestExporter(t, dir, bc, ParquetFormat)
+	assert.Nil(t, exporter.ExportFinalBlocks())
+	// part file written by an export stopped before the checkpoint is updated
+	staleDir := partitionDir(dir, EventTable, common.BeaconChainDataBaseID, 5, 10)
+	assert.Nil(t, os.MkdirAll(staleDir, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(staleDir, "part-5-6.ndjson"), []byte("{}\n"), 0644))
+
+	bc.addBlocks(7)
+	assert.Nil(t, exporter.ExportFinalBlocks())
+	assert.Nil(t, exporter.Flush())
+	assert.Equal(t, []string{
+		"height=1-10/part-1-4.parquet",
+		"height=1-10/part-5-7.parquet",
+	}, listParts(t, dir, EventTable, "beacon"))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, checkpointFileName))
+	assert.Nil(t, err)
+	cp := checkpoint{}
+	assert.Nil(t, json.Unmarshal(data, &cp))
+	assert.Equal(t, checkpoint{"beacon": 7, "shard-0": 7}, cp)
+}
+
+func TestNewExporterWrongFormat(t *testing.T) {
+	_, err := NewExporter(Config{Dir: os.TempDir(), Format: "csv", ChainIDs: []int{0}, BlockChain: newFakeChain()})
+	assert.NotNil(t, err)
+}
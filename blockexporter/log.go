@@ -0,0 +1,14 @@
+package blockexporter
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type BlockExporterLogger struct {
+	log common.Logger
+}
+
+func (logger *BlockExporterLogger) Init(inst common.Logger) {
+	logger.log = inst
+}
+
+// Global instant to use
+var Logger = BlockExporterLogger{}
@@ -0,0 +1,303 @@
+package blockexporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// A minimal writer of parquet files, https://github.com/apache/parquet-format.
+// Records are flat structs, every field is a required column of the only row group,
+// written as one uncompressed PLAIN encoded data page.
+
+var parquetMagic = []byte("PAR1")
+
+// parquet physical types
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetByteArray = 6
+)
+
+// parquet converted types
+const (
+	parquetUTF8   = 0
+	parquetUint64 = 14
+)
+
+const (
+	parquetRequired        = 0
+	parquetEncodingPlain   = 0
+	parquetEncodingRLE     = 3
+	parquetUncompressed    = 0
+	parquetDataPage        = 0
+	parquetFormatVersion   = 1
+	parquetCreatedBy       = "incognito-chain blockexporter"
+	parquetNoConvertedType = -1
+)
+
+type parquetColumn struct {
+	name          string
+	fieldIndex    int
+	physicalType  int32
+	convertedType int32
+}
+
+// parquetSchema returns the columns of the fields of a record struct type
+func parquetSchema(recordType reflect.Type) ([]parquetColumn, error) {
+	columns := []parquetColumn{}
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		column := parquetColumn{name: field.Name, fieldIndex: i, convertedType: parquetNoConvertedType}
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			column.physicalType = parquetBoolean
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			column.physicalType = parquetInt64
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			column.physicalType = parquetInt64
+			column.convertedType = parquetUint64
+		case reflect.String:
+			column.physicalType = parquetByteArray
+			column.convertedType = parquetUTF8
+		default:
+			return nil, fmt.Errorf("field %v of %v has no parquet type", field.Name, recordType.Name())
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// encodePlain encodes the values of a column of records with the PLAIN encoding
+func (column parquetColumn) encodePlain(records []reflect.Value) []byte {
+	buf := new(bytes.Buffer)
+	switch column.physicalType {
+	case parquetBoolean:
+		bits := make([]byte, (len(records)+7)/8)
+		for i, record := range records {
+			if record.Field(column.fieldIndex).Bool() {
+				bits[i/8] |= 1 << uint(i%8)
+			}
+		}
+		buf.Write(bits)
+	case parquetInt64:
+		for _, record := range records {
+			value := record.Field(column.fieldIndex)
+			var v uint64
+			if column.convertedType == parquetUint64 {
+				v = value.Uint()
+			} else {
+				v = uint64(value.Int())
+			}
+			binary.Write(buf, binary.LittleEndian, v)
+		}
+	case parquetByteArray:
+		for _, record := range records {
+			value := record.Field(column.fieldIndex).String()
+			binary.Write(buf, binary.LittleEndian, uint32(len(value)))
+			buf.WriteString(value)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeParquet writes records, pointers to structs of the same type, as a parquet file
+func writeParquet(w io.Writer, records []interface{}) error {
+	if len(records) == 0 {
+		return fmt.Errorf("no record to write")
+	}
+	recordType := reflect.TypeOf(records[0]).Elem()
+	columns, err := parquetSchema(recordType)
+	if err != nil {
+		return err
+	}
+	values := make([]reflect.Value, len(records))
+	for i, record := range records {
+		value := reflect.ValueOf(record).Elem()
+		if value.Type() != recordType {
+			return fmt.Errorf("record %v is a %v, not a %v", i, value.Type().Name(), recordType.Name())
+		}
+		values[i] = value
+	}
+
+	buf := new(bytes.Buffer)
+	buf.Write(parquetMagic)
+	chunks := new(thriftWriter)
+	chunks.listHeader(thriftStruct, len(columns))
+	totalSize := int64(0)
+	for _, column := range columns {
+		offset := int64(buf.Len())
+		data := column.encodePlain(values)
+		pageHeader := new(thriftWriter)
+		pageHeader.i32Field(1, parquetDataPage)
+		pageHeader.i32Field(2, int32(len(data)))
+		pageHeader.i32Field(3, int32(len(data)))
+		pageHeader.structField(5)
+		pageHeader.i32Field(1, int32(len(values)))
+		pageHeader.i32Field(2, parquetEncodingPlain)
+		pageHeader.i32Field(3, parquetEncodingRLE)
+		pageHeader.i32Field(4, parquetEncodingRLE)
+		pageHeader.structEnd()
+		pageHeader.structEnd()
+		buf.Write(pageHeader.Bytes())
+		buf.Write(data)
+		size := int64(buf.Len()) - offset
+		totalSize += size
+
+		// ColumnChunk
+		chunks.structBegin()
+		chunks.i64Field(2, offset)
+		chunks.structField(3)
+		chunks.i32Field(1, column.physicalType)
+		chunks.listField(2, thriftI32, 1)
+		chunks.i32(parquetEncodingPlain)
+		chunks.listField(3, thriftBinary, 1)
+		chunks.binary(column.name)
+		chunks.i32Field(4, parquetUncompressed)
+		chunks.i64Field(5, int64(len(values)))
+		chunks.i64Field(6, size)
+		chunks.i64Field(7, size)
+		chunks.i64Field(9, offset)
+		chunks.structEnd()
+		chunks.structEnd()
+	}
+
+	// FileMetaData
+	meta := new(thriftWriter)
+	meta.i32Field(1, parquetFormatVersion)
+	meta.listField(2, thriftStruct, len(columns)+1)
+	meta.structBegin()
+	meta.binaryField(4, "schema")
+	meta.i32Field(5, int32(len(columns)))
+	meta.structEnd()
+	for _, column := range columns {
+		meta.structBegin()
+		meta.i32Field(1, column.physicalType)
+		meta.i32Field(3, parquetRequired)
+		meta.binaryField(4, column.name)
+		if column.convertedType != parquetNoConvertedType {
+			meta.i32Field(6, column.convertedType)
+		}
+		meta.structEnd()
+	}
+	meta.i64Field(3, int64(len(values)))
+	meta.listField(4, thriftStruct, 1)
+	// RowGroup
+	meta.structBegin()
+	meta.rawField(1, thriftList)
+	meta.Write(chunks.Bytes())
+	meta.i64Field(2, totalSize)
+	meta.i64Field(3, int64(len(values)))
+	meta.structEnd()
+	meta.binaryField(6, parquetCreatedBy)
+	meta.structEnd()
+
+	buf.Write(meta.Bytes())
+	binary.Write(buf, binary.LittleEndian, uint32(meta.Len()))
+	buf.Write(parquetMagic)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs with the thrift compact protocol, the writer starts inside a struct,
+// lastFieldIDs keeps the last field ID of every struct being written
+type thriftWriter struct {
+	bytes.Buffer
+	lastFieldID  int16
+	lastFieldIDs []int16
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	for v >= 0x80 {
+		w.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	w.WriteByte(byte(v))
+}
+
+func (w *thriftWriter) rawField(id int16, fieldType byte) {
+	delta := id - w.lastFieldID
+	if delta > 0 && delta <= 15 {
+		w.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.WriteByte(fieldType)
+		w.varint(uint64((id << 1) ^ (id >> 15)))
+	}
+	w.lastFieldID = id
+}
+
+func (w *thriftWriter) i32(v int32) {
+	w.varint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (w *thriftWriter) i64(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) binary(v string) {
+	w.varint(uint64(len(v)))
+	w.WriteString(v)
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.rawField(id, thriftI32)
+	w.i32(v)
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.rawField(id, thriftI64)
+	w.i64(v)
+}
+
+func (w *thriftWriter) binaryField(id int16, v string) {
+	w.rawField(id, thriftBinary)
+	w.binary(v)
+}
+
+func (w *thriftWriter) listHeader(elemType byte, size int) {
+	if size < 15 {
+		w.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.WriteByte(0xf0 | elemType)
+		w.varint(uint64(size))
+	}
+}
+
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.rawField(id, thriftList)
+	w.listHeader(elemType, size)
+}
+
+// structBegin begins a struct which is an element of a list
+func (w *thriftWriter) structBegin() {
+	w.lastFieldIDs = append(w.lastFieldIDs, w.lastFieldID)
+	w.lastFieldID = 0
+}
+
+func (w *thriftWriter) structField(id int16) {
+	w.rawField(id, thriftStruct)
+	w.structBegin()
+}
+
+// structEnd ends the struct being written, including the outermost one
+func (w *thriftWriter) structEnd() {
+	w.WriteByte(0)
+	if n := len(w.lastFieldIDs); n > 0 {
+		w.lastFieldID = w.lastFieldIDs[n-1]
+		w.lastFieldIDs = w.lastFieldIDs[:n-1]
+	}
+}
@@ -0,0 +1,176 @@
+package blockexporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecord struct {
+	Height  uint64
+	Shard   int
+	Hash    string
+	Privacy bool
+}
+
+// thriftReader decodes structs of the thrift compact protocol into maps of field ID to value
+type thriftReader struct {
+	*bytes.Reader
+}
+
+func (r thriftReader) varint() uint64 {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (r thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r thriftReader) value(fieldType byte) interface{} {
+	switch fieldType {
+	case 1:
+		return true
+	case 2:
+		return false
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		data := make([]byte, r.varint())
+		r.Read(data)
+		return string(data)
+	case thriftList:
+		header, _ := r.ReadByte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.varint())
+		}
+		list := []interface{}{}
+		for i := 0; i < size; i++ {
+			list = append(list, r.value(header&0x0f))
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	}
+	panic(fmt.Sprintf("unexpected thrift type %v", fieldType))
+}
+
+func (r thriftReader) structValue() map[int16]interface{} {
+	result := map[int16]interface{}{}
+	lastFieldID := int16(0)
+	for {
+		header, _ := r.ReadByte()
+		if header == 0 {
+			return result
+		}
+		fieldID := lastFieldID + int16(header>>4)
+		if header>>4 == 0 {
+			fieldID = int16(r.zigzag())
+		}
+		result[fieldID] = r.value(header & 0x0f)
+		lastFieldID = fieldID
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	records := []interface{}{
+		&testRecord{Height: 1, Shard: -1, Hash: "a", Privacy: true},
+		&testRecord{Height: 2, Shard: 3, Hash: "bc", Privacy: false},
+		&testRecord{Height: 1 << 40, Shard: 0, Hash: "", Privacy: true},
+	}
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	assert.Nil(t, writeParquet(w, records))
+	assert.Nil(t, w.Flush())
+	data := buf.Bytes()
+	assert.Equal(t, parquetMagic, data[:4])
+	assert.Equal(t, parquetMagic, data[len(data)-4:])
+
+	metaLen := binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4])
+	meta := thriftReader{bytes.NewReader(data[len(data)-8-int(metaLen) : len(data)-8])}.structValue()
+	assert.Equal(t, int64(3), meta[3])
+	schema := meta[2].([]interface{})
+	assert.Equal(t, 5, len(schema))
+	assert.Equal(t, "schema", schema[0].(map[int16]interface{})[4])
+	assert.Equal(t, int64(4), schema[0].(map[int16]interface{})[5])
+	names := []string{}
+	for _, element := range schema[1:] {
+		names = append(names, element.(map[int16]interface{})[4].(string))
+	}
+	assert.Equal(t, []string{"Height", "Shard", "Hash", "Privacy"}, names)
+	assert.Equal(t, int64(parquetUint64), schema[1].(map[int16]interface{})[6])
+
+	rowGroups := meta[4].([]interface{})
+	assert.Equal(t, 1, len(rowGroups))
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+	assert.Equal(t, 4, len(chunks))
+
+	readPage := func(chunk interface{}) []byte {
+		columnMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		assert.Equal(t, int64(3), columnMeta[5])
+		r := thriftReader{bytes.NewReader(data[columnMeta[9].(int64):])}
+		pageHeader := r.structValue()
+		assert.Equal(t, int64(3), pageHeader[5].(map[int16]interface{})[1])
+		page := make([]byte, pageHeader[3].(int64))
+		r.Read(page)
+		return page
+	}
+	heights := readPage(chunks[0])
+	assert.Equal(t, uint64(1<<40), binary.LittleEndian.Uint64(heights[16:]))
+	shards := readPage(chunks[1])
+	assert.Equal(t, int64(-1), int64(binary.LittleEndian.Uint64(shards[:8])))
+	hashes := readPage(chunks[2])
+	assert.Equal(t, []byte{1, 0, 0, 0, 'a', 2, 0, 0, 0, 'b', 'c', 0, 0, 0, 0}, hashes)
+	privacy := readPage(chunks[3])
+	assert.Equal(t, []byte{5}, privacy)
+}
+
+func TestWriteParquetMixedRecords(t *testing.T) {
+	err := writeParquet(new(bytes.Buffer), []interface{}{&testRecord{}, &Event{}})
+	assert.NotNil(t, err)
+}
+
+// TestWriteParquetPyarrow checks that pyarrow, a parquet reader independent of this package, reads the written file
+func TestWriteParquetPyarrow(t *testing.T) {
+	if err := exec.Command("python3", "-c", "import pyarrow.parquet").Run(); err != nil {
+		t.Skip("pyarrow is not installed")
+	}
+	dir, err := ioutil.TempDir("", "parquet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	records := []interface{}{
+		&testRecord{Height: 1, Shard: -1, Hash: "a", Privacy: true},
+		&testRecord{Height: 2, Shard: 3, Hash: "bc", Privacy: false},
+		&testRecord{Height: 1 << 40, Shard: 0, Hash: "", Privacy: true},
+	}
+	buf := new(bytes.Buffer)
+	assert.Nil(t, writeParquet(buf, records))
+	parquetFile := filepath.Join(dir, "records.parquet")
+	assert.Nil(t, ioutil.WriteFile(parquetFile, buf.Bytes(), 0644))
+
+	expected, err := json.Marshal(map[string]interface{}{
+		"schema": map[string]string{"Height": "uint64", "Shard": "int64", "Hash": "string", "Privacy": "bool"},
+		"rows":   records,
+	})
+	assert.Nil(t, err)
+	expectedFile := filepath.Join(dir, "expected.json")
+	assert.Nil(t, ioutil.WriteFile(expectedFile, expected, 0644))
+
+	output, err := exec.Command("python3", filepath.Join("testdata", "check_parquet.py"), parquetFile, expectedFile).CombinedOutput()
+	assert.Nil(t, err, string(output))
+}
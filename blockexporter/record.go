@@ -0,0 +1,313 @@
+package blockexporter
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// tables the records are exported to, each table is a folder of the export dir
+const (
+	BeaconBlockTable = "beacon_blocks"
+	ShardBlockTable  = "shard_blocks"
+	TransactionTable = "transactions"
+	InstructionTable = "instructions"
+	EventTable       = "events"
+)
+
+const (
+	PDEEvent    = "pde"
+	PortalEvent = "portal"
+)
+
+// metadata types of the beacon instructions which are exported as pde events
+var pdeEventMetaTypes = map[int]bool{
+	metadata.PDEContributionMeta:                   true,
+	metadata.PDEPRVRequiredContributionRequestMeta: true,
+	metadata.PDETradeRequestMeta:                   true,
+	metadata.PDECrossPoolTradeRequestMeta:          true,
+	metadata.PDEWithdrawalRequestMeta:              true,
+	metadata.PDEFeeWithdrawalRequestMeta:           true,
+	metadata.PDETradingFeesDistributionMeta:        true,
+	metadata.PDELimitOrderRequestMeta:              true,
+	metadata.PDECancelLimitOrderRequestMeta:        true,
+}
+
+// metadata types of the beacon instructions which are exported as portal events
+var portalEventMetaTypes = map[int]bool{
+	metadata.PortalExchangeRatesMeta:                      true,
+	metadata.PortalCustodianDepositMeta:                   true,
+	metadata.PortalCustodianWithdrawRequestMeta:           true,
+	metadata.PortalCustodianDepositMetaV3:                 true,
+	metadata.PortalCustodianWithdrawRequestMetaV3:         true,
+	metadata.PortalCustodianWithdrawConfirmMetaV3:         true,
+	metadata.PortalUnlockOverRateCollateralsMeta:          true,
+	metadata.PortalRequestPortingMeta:                     true,
+	metadata.PortalRequestPortingMetaV3:                   true,
+	metadata.PortalUserRequestPTokenMeta:                  true,
+	metadata.PortalRedeemRequestMeta:                      true,
+	metadata.PortalRedeemRequestMetaV3:                    true,
+	metadata.PortalReqMatchingRedeemMeta:                  true,
+	metadata.PortalPickMoreCustodianForRedeemMeta:         true,
+	metadata.PortalRequestUnlockCollateralMeta:            true,
+	metadata.PortalRequestUnlockCollateralMetaV3:          true,
+	metadata.PortalLiquidateCustodianMeta:                 true,
+	metadata.PortalLiquidateCustodianMetaV3:               true,
+	metadata.PortalLiquidateTPExchangeRatesMeta:           true,
+	metadata.PortalLiquidateByRatesMetaV3:                 true,
+	metadata.PortalCustodianTopupMetaV2:                   true,
+	metadata.PortalCustodianTopupMetaV3:                   true,
+	metadata.PortalTopUpWaitingPortingRequestMeta:         true,
+	metadata.PortalTopUpWaitingPortingRequestMetaV3:       true,
+	metadata.PortalRedeemFromLiquidationPoolMeta:          true,
+	metadata.PortalRedeemFromLiquidationPoolMetaV3:        true,
+	metadata.PortalExpiredWaitingPortingReqMeta:           true,
+	metadata.PortalRewardMeta:                             true,
+	metadata.PortalRewardMetaV3:                           true,
+	metadata.PortalRequestWithdrawRewardMeta:              true,
+	metadata.PortalTotalRewardCustodianMeta:               true,
+	metadata.PortalRedeemFromLiquidationPoolConfirmMetaV3: true,
+	metadata.PortalLiquidateRunAwayCustodianConfirmMetaV3: true,
+}
+
+// BeaconBlock is a row of the beacon_blocks table
+type BeaconBlock struct {
+	Height            uint64
+	Hash              string
+	PreviousBlockHash string
+	Version           int
+	Epoch             uint64
+	Round             int
+	Timestamp         int64
+	Producer          string
+	Proposer          string
+	ProposeTime       int64
+	ConsensusType     string
+	NumShardStates    int
+	NumInstructions   int
+}
+
+// ShardBlock is a row of the shard_blocks table
+type ShardBlock struct {
+	ShardID           int
+	Height            uint64
+	Hash              string
+	PreviousBlockHash string
+	Version           int
+	Epoch             uint64
+	Round             int
+	Timestamp         int64
+	Producer          string
+	Proposer          string
+	ProposeTime       int64
+	BeaconHeight      uint64
+	BeaconHash        string
+	TotalTxsFee       uint64 // fee in PRV
+	NumTxs            int
+	NumCrossTxs       int
+	NumInstructions   int
+}
+
+// Transaction is a row of the transactions table, input and output coins of a privacy token transaction
+// are counted separately from those paying the PRV fee
+type Transaction struct {
+	ShardID         int
+	BlockHeight     uint64
+	BlockHash       string
+	Index           int
+	Hash            string
+	Type            string
+	LockTime        int64
+	Size            uint64
+	MetadataType    int
+	Fee             uint64
+	FeeToken        uint64
+	TokenID         string
+	IsPrivacy       bool
+	NumInputCoins   int
+	NumOutputCoins  int
+	NumTokenInputs  int
+	NumTokenOutputs int
+}
+
+// Instruction is a row of the instructions table, Content is the json array of the instruction
+type Instruction struct {
+	ChainID     int // common.BeaconChainDataBaseID for the beacon chain
+	BlockHeight uint64
+	BlockHash   string
+	Index       int
+	Action      string
+	Content     string
+}
+
+// Event is a row of the events table, a pde or portal instruction of a beacon block
+type Event struct {
+	BeaconHeight uint64
+	BeaconHash   string
+	Timestamp    int64
+	Index        int // index of the instruction in the block
+	Module       string
+	MetadataType int
+	ShardID      int
+	Status       string
+	Content      string
+}
+
+// buildBeaconBlockRecords returns the records of every table of a beacon block
+func buildBeaconBlockRecords(block *blockchain.BeaconBlock) map[string][]interface{} {
+	hash := block.Hash().String()
+	numShardStates := 0
+	for _, states := range block.Body.ShardState {
+		numShardStates += len(states)
+	}
+	records := map[string][]interface{}{
+		BeaconBlockTable: {&BeaconBlock{
+			Height:            block.Header.Height,
+			Hash:              hash,
+			PreviousBlockHash: block.Header.PreviousBlockHash.String(),
+			Version:           block.Header.Version,
+			Epoch:             block.Header.Epoch,
+			Round:             block.Header.Round,
+			Timestamp:         block.Header.Timestamp,
+			Producer:          block.Header.Producer,
+			Proposer:          block.Header.Proposer,
+			ProposeTime:       block.Header.ProposeTime,
+			ConsensusType:     block.Header.ConsensusType,
+			NumShardStates:    numShardStates,
+			NumInstructions:   len(block.Body.Instructions),
+		}},
+	}
+	records[InstructionTable] = buildInstructionRecords(common.BeaconChainDataBaseID, block.Header.Height, hash, block.Body.Instructions)
+	for idx, inst := range block.Body.Instructions {
+		if event := buildEventRecord(inst); event != nil {
+			event.BeaconHeight = block.Header.Height
+			event.BeaconHash = hash
+			event.Timestamp = block.Header.Timestamp
+			event.Index = idx
+			records[EventTable] = append(records[EventTable], event)
+		}
+	}
+	return records
+}
+
+// buildShardBlockRecords returns the records of every table of a shard block
+func buildShardBlockRecords(block *blockchain.ShardBlock) map[string][]interface{} {
+	hash := block.Hash().String()
+	shardID := int(block.Header.ShardID)
+	numCrossTxs := 0
+	for _, crossTxs := range block.Body.CrossTransactions {
+		numCrossTxs += len(crossTxs)
+	}
+	records := map[string][]interface{}{
+		ShardBlockTable: {&ShardBlock{
+			ShardID:           shardID,
+			Height:            block.Header.Height,
+			Hash:              hash,
+			PreviousBlockHash: block.Header.PreviousBlockHash.String(),
+			Version:           block.Header.Version,
+			Epoch:             block.Header.Epoch,
+			Round:             block.Header.Round,
+			Timestamp:         block.Header.Timestamp,
+			Producer:          block.Header.Producer,
+			Proposer:          block.Header.Proposer,
+			ProposeTime:       block.Header.ProposeTime,
+			BeaconHeight:      block.Header.BeaconHeight,
+			BeaconHash:        block.Header.BeaconHash.String(),
+			TotalTxsFee:       block.Header.TotalTxsFee[common.PRVCoinID],
+			NumTxs:            len(block.Body.Transactions),
+			NumCrossTxs:       numCrossTxs,
+			NumInstructions:   len(block.Body.Instructions),
+		}},
+	}
+	for idx, tx := range block.Body.Transactions {
+		record := &Transaction{
+			ShardID:      shardID,
+			BlockHeight:  block.Header.Height,
+			BlockHash:    hash,
+			Index:        idx,
+			Hash:         tx.Hash().String(),
+			Type:         tx.GetType(),
+			LockTime:     tx.GetLockTime(),
+			Size:         tx.GetTxActualSize(),
+			MetadataType: tx.GetMetadataType(),
+			Fee:          tx.GetTxFee(),
+			FeeToken:     tx.GetTxFeeToken(),
+			IsPrivacy:    tx.IsPrivacy(),
+		}
+		if tokenID := tx.GetTokenID(); tokenID != nil {
+			record.TokenID = tokenID.String()
+		}
+		record.NumInputCoins, record.NumOutputCoins = countCoins(tx.GetProof())
+		if tokenTx, ok := tx.(*transaction.TxCustomTokenPrivacy); ok {
+			record.NumTokenInputs, record.NumTokenOutputs = countCoins(tokenTx.TxPrivacyTokenData.TxNormal.Proof)
+		}
+		records[TransactionTable] = append(records[TransactionTable], record)
+	}
+	records[InstructionTable] = buildInstructionRecords(shardID, block.Header.Height, hash, block.Body.Instructions)
+	return records
+}
+
+func countCoins(proof *zkp.PaymentProof) (int, int) {
+	if proof == nil {
+		return 0, 0
+	}
+	return len(proof.GetInputCoins()), len(proof.GetOutputCoins())
+}
+
+func buildInstructionRecords(chainID int, height uint64, hash string, insts [][]string) []interface{} {
+	records := []interface{}{}
+	for idx, inst := range insts {
+		if len(inst) == 0 {
+			continue
+		}
+		content, err := json.Marshal(inst)
+		if err != nil {
+			continue
+		}
+		records = append(records, &Instruction{
+			ChainID:     chainID,
+			BlockHeight: height,
+			BlockHash:   hash,
+			Index:       idx,
+			Action:      inst[0],
+			Content:     string(content),
+		})
+	}
+	return records
+}
+
+// buildEventRecord returns the event of a pde or portal instruction, nil for other instructions.
+// These instructions are in the form of [metadata type, shard ID, status, content]
+func buildEventRecord(inst []string) *Event {
+	if len(inst) < 4 {
+		return nil
+	}
+	metaType, err := strconv.Atoi(inst[0])
+	if err != nil {
+		return nil
+	}
+	module := ""
+	if pdeEventMetaTypes[metaType] {
+		module = PDEEvent
+	} else if portalEventMetaTypes[metaType] {
+		module = PortalEvent
+	} else {
+		return nil
+	}
+	shardID, err := strconv.Atoi(inst[1])
+	if err != nil {
+		shardID = -1
+	}
+	return &Event{
+		Module:       module,
+		MetadataType: metaType,
+		ShardID:      shardID,
+		Status:       inst[2],
+		Content:      inst[3],
+	}
+}
@@ -0,0 +1,25 @@
+import json
+import sys
+
+import pyarrow.parquet as pq
+
+
+# usage: check_parquet.py <parquet file> <expected json>
+# the expected json holds the arrow type of every column and the rows of the file
+def main():
+  table = pq.read_table(sys.argv[1])
+  with open(sys.argv[2]) as f:
+    expected = json.load(f)
+  schema = {field.name: str(field.type) for field in table.schema}
+  if schema != expected['schema']:
+    print('schema', schema, 'is not', expected['schema'])
+    sys.exit(1)
+  rows = table.to_pylist()
+  if rows != expected['rows']:
+    print('rows', rows, 'are not', expected['rows'])
+    sys.exit(1)
+  print('read', table.num_rows, 'rows')
+
+
+if __name__ == '__main__':
+  main()
@@ -0,0 +1,85 @@
+package blockexporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	NDJSONFormat  = "ndjson"
+	ParquetFormat = "parquet"
+)
+
+// chainName returns the name of a chain in the partition folders and the checkpoint
+func chainName(chainID int) string {
+	if chainID == common.BeaconChainDataBaseID {
+		return "beacon"
+	}
+	return "shard-" + strconv.Itoa(chainID)
+}
+
+// partitionDir returns the folder of the records of a table of a chain from height fromHeight, folders are
+// named hive style <table>/chain=<chain>/height=<start>-<end> with partitionSize heights in each folder
+func partitionDir(dir string, table string, chainID int, fromHeight uint64, partitionSize uint64) string {
+	start := (fromHeight-1)/partitionSize*partitionSize + 1
+	return filepath.Join(
+		dir,
+		table,
+		"chain="+chainName(chainID),
+		fmt.Sprintf("height=%d-%d", start, start+partitionSize-1),
+	)
+}
+
+// writeTableFile writes records of blocks fromHeight to toHeight to a part file of their partition, the file is
+// written to a temp file first so a part file is never partially written
+func writeTableFile(dir string, format string, table string, chainID int, fromHeight uint64, toHeight uint64, partitionSize uint64, records []interface{}) (string, error) {
+	partDir := partitionDir(dir, table, chainID, fromHeight, partitionSize)
+	err := os.MkdirAll(partDir, 0755)
+	if err != nil {
+		return "", err
+	}
+	fileName := filepath.Join(partDir, fmt.Sprintf("part-%d-%d.%s", fromHeight, toHeight, format))
+	tempFileName := fileName + ".tmp"
+	f, err := os.Create(tempFileName)
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriter(f)
+	switch format {
+	case ParquetFormat:
+		err = writeParquet(w, records)
+	default:
+		err = writeNDJSON(w, records)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempFileName)
+		return "", err
+	}
+	return fileName, os.Rename(tempFileName, fileName)
+}
+
+func writeNDJSON(w *bufio.Writer, records []interface{}) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		err := encoder.Encode(record)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
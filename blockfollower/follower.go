@@ -0,0 +1,155 @@
+// Package blockfollower runs the processing of final blocks shared by the indexers and the block exporter.
+// Only blocks of the final views are followed, so what is built from them is never reverted.
+package blockfollower
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// interval to check for new final blocks if no new block is published
+const catchUpInterval = 30 * time.Second
+
+// BeaconChain is the access of a follower to the final beacon blocks
+type BeaconChain interface {
+	GetFinalBeaconHeight() uint64
+	GetBeaconBlockByHeightV1(height uint64) (*blockchain.BeaconBlock, error)
+}
+
+// ShardChain is the access of a follower to the final shard blocks
+type ShardChain interface {
+	GetFinalShardHeight(shardID byte) uint64
+	GetShardBlockByHeightV1(height uint64, shardID byte) (*blockchain.ShardBlock, error)
+}
+
+// Follower calls its follow function in background when a block of its topics is published,
+// and every catchUpInterval to catch up with blocks finalized without a new block being published
+type Follower struct {
+	pubSubManager *pubsub.PubSubManager
+	topics        []string
+	follow        func()
+
+	cNewBlock chan struct{}
+	cQuit     chan struct{}
+}
+
+// New returns a follower calling follow for new blocks of topics, blocks are not subscribed to if pubSubManager is nil
+func New(pubSubManager *pubsub.PubSubManager, topics []string, follow func()) *Follower {
+	return &Follower{
+		pubSubManager: pubSubManager,
+		topics:        topics,
+		follow:        follow,
+		cNewBlock:     make(chan struct{}, 1),
+		cQuit:         make(chan struct{}),
+	}
+}
+
+// Start subscribes to the topics and calls the follow function in background until the follower is stopped
+func (follower *Follower) Start() error {
+	if follower.pubSubManager != nil {
+		for _, topic := range follower.topics {
+			subID, subChan, err := follower.pubSubManager.RegisterNewSubscriber(topic)
+			if err != nil {
+				return err
+			}
+			go func(topic string) {
+				defer follower.pubSubManager.Unsubscribe(topic, subID)
+				for {
+					select {
+					case <-subChan:
+						select {
+						case follower.cNewBlock <- struct{}{}:
+						default:
+						}
+					case <-follower.cQuit:
+						return
+					}
+				}
+			}(topic)
+		}
+	}
+	go follower.loop()
+	return nil
+}
+
+// Stop stops the background calls, a call in progress returns at the next block it follows
+func (follower *Follower) Stop() {
+	close(follower.cQuit)
+}
+
+func (follower *Follower) isStopped() bool {
+	select {
+	case <-follower.cQuit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (follower *Follower) loop() {
+	ticker := time.NewTicker(catchUpInterval)
+	defer ticker.Stop()
+	for {
+		follower.follow()
+		select {
+		case <-follower.cNewBlock:
+		case <-ticker.C:
+		case <-follower.cQuit:
+			return
+		}
+	}
+}
+
+// FollowHeights calls f with every height from fromHeight to toHeight, it returns the first error of f
+// or nil when the follower is stopped
+func (follower *Follower) FollowHeights(fromHeight uint64, toHeight uint64, f func(height uint64) error) error {
+	for height := fromHeight; height <= toHeight; height++ {
+		if follower.isStopped() {
+			return nil
+		}
+		err := f(height)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FollowBeacon calls f with the final beacon blocks from fromHeight
+func (follower *Follower) FollowBeacon(bc BeaconChain, fromHeight uint64, f func(block *blockchain.BeaconBlock) error) error {
+	return follower.FollowHeights(fromHeight, bc.GetFinalBeaconHeight(), func(height uint64) error {
+		block, err := bc.GetBeaconBlockByHeightV1(height)
+		if err != nil {
+			return err
+		}
+		return f(block)
+	})
+}
+
+// FollowShard calls f with the final blocks of a shard from fromHeight
+func (follower *Follower) FollowShard(bc ShardChain, shardID byte, fromHeight uint64, f func(block *blockchain.ShardBlock) error) error {
+	return follower.FollowHeights(fromHeight, bc.GetFinalShardHeight(shardID), func(height uint64) error {
+		block, err := bc.GetShardBlockByHeightV1(height, shardID)
+		if err != nil {
+			return err
+		}
+		return f(block)
+	})
+}
+
+// GetStoredHeight returns the height stored at key, 0 if nothing is stored yet
+func GetStoredHeight(db incdb.KeyValueReader, key []byte) (uint64, error) {
+	heightBytes, err := db.Get(key)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(heightBytes), 10, 64)
+}
@@ -0,0 +1,111 @@
+package blockfollower
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower/followertest"
+	"github.com/incognitochain/incognito-chain/mocks"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollower_FollowBeaconAndShard(t *testing.T) {
+	bc := followertest.NewChain()
+	for height := uint64(1); height <= 3; height++ {
+		bc.AddBeaconBlock(&blockchain.BeaconBlock{Header: blockchain.BeaconHeader{Height: height}})
+		bc.AddShardBlock(&blockchain.ShardBlock{Header: blockchain.ShardHeader{Height: height, ShardID: 1}})
+	}
+	follower := New(nil, nil, func() {})
+
+	heights := []uint64{}
+	err := follower.FollowBeacon(bc, 2, func(block *blockchain.BeaconBlock) error {
+		heights = append(heights, block.Header.Height)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3}, heights)
+
+	heights = []uint64{}
+	err = follower.FollowShard(bc, 1, 1, func(block *blockchain.ShardBlock) error {
+		heights = append(heights, block.Header.Height)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, heights)
+
+	// the walk stops at the first error
+	heights = []uint64{}
+	err = follower.FollowBeacon(bc, 1, func(block *blockchain.BeaconBlock) error {
+		heights = append(heights, block.Header.Height)
+		if block.Header.Height == 2 {
+			return errors.New("index failed")
+		}
+		return nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, []uint64{1, 2}, heights)
+
+	// a missing block is an error
+	delete(bc.BeaconBlocks, 3)
+	err = follower.FollowBeacon(bc, 3, func(block *blockchain.BeaconBlock) error { return nil })
+	assert.NotNil(t, err)
+}
+
+func TestFollower_Stop(t *testing.T) {
+	follower := New(nil, nil, func() {})
+	heights := []uint64{}
+	err := follower.FollowHeights(1, 10, func(height uint64) error {
+		heights = append(heights, height)
+		if height == 3 {
+			follower.Stop()
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, heights)
+}
+
+func TestFollower_Start(t *testing.T) {
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	calls := make(chan struct{}, 10)
+	follower := New(pubSubManager, []string{pubsub.NewBeaconBlockTopic}, func() { calls <- struct{}{} })
+	assert.Nil(t, follower.Start())
+	defer follower.Stop()
+
+	waitCall := func() {
+		select {
+		case <-calls:
+		case <-time.After(5 * time.Second):
+			t.Fatal("follow function is not called")
+		}
+	}
+	// blocks are followed once when the follower starts
+	waitCall()
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, &blockchain.BeaconBlock{}))
+	waitCall()
+}
+
+func TestGetStoredHeight(t *testing.T) {
+	db, cleanup := followertest.OpenDB(t)
+	defer cleanup()
+	key := []byte("height")
+
+	height, err := GetStoredHeight(db, key)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), height)
+
+	assert.Nil(t, db.Put(key, []byte("42")))
+	height, err = GetStoredHeight(db, key)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(42), height)
+
+	// a read error does not mean that nothing is indexed
+	failingDB := &mocks.Database{}
+	failingDB.On("Get", key).Return(nil, errors.New("read failed"))
+	_, err = GetStoredHeight(failingDB, key)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,85 @@
+// Package followertest provides the fake chain and database used by the tests of the block followers
+package followertest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+// Chain holds beacon and shard blocks in memory, the latest added block of a chain is final
+type Chain struct {
+	BeaconBlocks      map[uint64]*blockchain.BeaconBlock
+	ShardBlocks       map[byte]map[uint64]*blockchain.ShardBlock
+	FinalBeaconHeight uint64
+	FinalShardHeights map[byte]uint64
+}
+
+func NewChain() *Chain {
+	return &Chain{
+		BeaconBlocks:      map[uint64]*blockchain.BeaconBlock{},
+		ShardBlocks:       map[byte]map[uint64]*blockchain.ShardBlock{},
+		FinalShardHeights: map[byte]uint64{},
+	}
+}
+
+// AddBeaconBlock adds a final beacon block at the height of its header
+func (bc *Chain) AddBeaconBlock(block *blockchain.BeaconBlock) {
+	bc.BeaconBlocks[block.Header.Height] = block
+	bc.FinalBeaconHeight = block.Header.Height
+}
+
+// AddShardBlock adds a final shard block at the shard and height of its header
+func (bc *Chain) AddShardBlock(block *blockchain.ShardBlock) {
+	shardID := block.Header.ShardID
+	if bc.ShardBlocks[shardID] == nil {
+		bc.ShardBlocks[shardID] = map[uint64]*blockchain.ShardBlock{}
+	}
+	bc.ShardBlocks[shardID][block.Header.Height] = block
+	bc.FinalShardHeights[shardID] = block.Header.Height
+}
+
+func (bc *Chain) GetFinalBeaconHeight() uint64 {
+	return bc.FinalBeaconHeight
+}
+
+func (bc *Chain) GetBeaconBlockByHeightV1(height uint64) (*blockchain.BeaconBlock, error) {
+	block, ok := bc.BeaconBlocks[height]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return block, nil
+}
+
+func (bc *Chain) GetFinalShardHeight(shardID byte) uint64 {
+	return bc.FinalShardHeights[shardID]
+}
+
+func (bc *Chain) GetShardBlockByHeightV1(height uint64, shardID byte) (*blockchain.ShardBlock, error) {
+	block, ok := bc.ShardBlocks[shardID][height]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return block, nil
+}
+
+// OpenDB opens a leveldb database in a temporary directory, the returned function closes and removes it
+func OpenDB(t *testing.T) (incdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "followertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
@@ -8,7 +8,10 @@ import (
 
 // See loadConfig for details on the configuration load process.
 type config struct {
-	RPCPort int `long:"rpcport" short:"p" description:"Linsten port of RPC server"`
+	RPCPort     int      `long:"rpcport" short:"p" description:"Linsten port of RPC server"`
+	HTTPPort    int      `long:"httpport" description:"Listen port of the JSON-HTTP API, not served if 0"`
+	DataDir     string   `long:"datadir" short:"b" description:"Directory signed peer records are saved to, they are kept in memory only if empty"`
+	GossipPeers []string `long:"gossippeer" description:"RPC address of another bootnode to exchange peer records with, can be repeated"`
 }
 
 // newConfigParser returns a new command line flags parser.
@@ -25,6 +28,7 @@ func loadConfig() (*config, error) {
 	// create config object from default values
 	cfg := config{
 		RPCPort: defaultRPCServerPort,
+		DataDir: defaultDataDir,
 	}
 
 	//preCfg := cfg
@@ -1,6 +1,7 @@
 package main
 
 const (
-	version              = "1.1.0"
+	version              = "1.2.0"
 	defaultRPCServerPort = 9330
+	defaultDataDir       = "data"
 )
@@ -28,13 +28,26 @@ func main() {
 
 	// create RPC config for RPC server
 	rpcConfig := server.RpcServerConfig{
-		Port: cfg.RPCPort,
+		Port:        cfg.RPCPort,
+		HTTPPort:    cfg.HTTPPort,
+		DataDir:     cfg.DataDir,
+		GossipPeers: cfg.GossipPeers,
 	}
 
 	// Init RPC Serer in golang
 	rpcServer := &server.RpcServer{}
 	log.Printf("Init rpcServer with config \n")
-	rpcServer.Init(&rpcConfig)
+	err = rpcServer.Init(&rpcConfig)
+	if err != nil {
+		log.Println("Init rpcServer error", err.Error())
+		return
+	}
+
+	if cfg.HTTPPort != 0 {
+		go func() {
+			log.Println("JSON-HTTP API stopped", rpcServer.StartHTTP())
+		}()
+	}
 
 	log.Printf("Start rpcServer with config \n %+v\n", rpcServer.Config)
 	for {
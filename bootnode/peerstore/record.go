@@ -0,0 +1,113 @@
+package peerstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+)
+
+// PeerRecord is the address and role of a node signed with the bridge key of its mining key,
+// so bootnodes and nodes receiving it from a bootnode can check that it comes from the node itself
+type PeerRecord struct {
+	RawAddress    string
+	PublicKeyType string
+	PublicKey     string // mining public key, json of the map of consensus name to key bytes
+	Role          string // common.BeaconRole, common.ShardRole or empty for other nodes
+	ShardID       int    // shard of a shard node, -1 for other nodes
+	Timestamp     int64  // unix time in nanoseconds the record is signed at, a newer record of a node replaces the older one
+	Signature     string // base58 check encoded bridge signature of SignData
+}
+
+// SignData returns the data of the record which is signed, every field except the signature
+func (record *PeerRecord) SignData() []byte {
+	return []byte(fmt.Sprintf(
+		"%v|%v|%v|%v|%v|%v",
+		record.RawAddress,
+		record.PublicKeyType,
+		record.PublicKey,
+		record.Role,
+		record.ShardID,
+		record.Timestamp,
+	))
+}
+
+// Sign signs the record with the private key of its bridge key
+func (record *PeerRecord) Sign(bridgePrivateKey []byte) error {
+	sig, err := bridgesig.Sign(bridgePrivateKey, record.SignData())
+	if err != nil {
+		return err
+	}
+	record.Signature = base58.Base58Check{}.Encode(sig, common.Base58Version)
+	return nil
+}
+
+// Verify checks the fields of the record and that it is signed with the bridge key of its public key
+func (record *PeerRecord) Verify() error {
+	if record.RawAddress == "" {
+		return errors.New("raw address of peer record is empty")
+	}
+	if record.PublicKeyType != common.BlsConsensus {
+		return fmt.Errorf("public key type %v of peer record is not supported", record.PublicKeyType)
+	}
+	switch record.Role {
+	case common.ShardRole:
+		if record.ShardID < 0 || record.ShardID >= common.MaxShardNumber {
+			return fmt.Errorf("shard ID %v of peer record is out of range", record.ShardID)
+		}
+	case common.BeaconRole, "":
+		if record.ShardID != -1 {
+			return fmt.Errorf("peer record of role %v must not have a shard ID", record.Role)
+		}
+	default:
+		return fmt.Errorf("role %v of peer record is not supported", record.Role)
+	}
+	miningKey := map[string][]byte{}
+	err := json.Unmarshal([]byte(record.PublicKey), &miningKey)
+	if err != nil {
+		return err
+	}
+	bridgeKey, ok := miningKey[common.BridgeConsensus]
+	if !ok {
+		return errors.New("ECDSA Public key not found")
+	}
+	sig, _, err := base58.Base58Check{}.Decode(record.Signature)
+	if err != nil {
+		return err
+	}
+	valid, err := bridgesig.Verify(bridgeKey, record.SignData(), sig)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("signature of peer record is invalid")
+	}
+	return nil
+}
+
+// Filter selects peer records by role and shard, empty fields select every record
+type Filter struct {
+	Role     string
+	ShardIDs []int
+}
+
+func (filter *Filter) match(record *PeerRecord) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Role != "" && filter.Role != record.Role {
+		return false
+	}
+	if len(filter.ShardIDs) == 0 {
+		return true
+	}
+	for _, shardID := range filter.ShardIDs {
+		if shardID == record.ShardID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package peerstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// max time the timestamp of a record may be ahead of the clock of the bootnode
+	maxClockSkew = time.Minute
+	// max number of records kept by a store
+	maxRecords = 10000
+)
+
+// Store keeps the latest valid record of every node until it expires, records are mapped by public key
+// and saved to a json file so they are restored when the bootnode restarts
+type Store struct {
+	mtx      sync.RWMutex
+	records  map[string]*PeerRecord
+	fileName string
+	ttl      time.Duration
+	dirty    bool
+	now      func() time.Time
+}
+
+// NewStore returns a store keeping records for ttl after they are signed, records saved in fileName are loaded,
+// records are not saved if fileName is empty
+func NewStore(fileName string, ttl time.Duration) (*Store, error) {
+	store := &Store{
+		records:  make(map[string]*PeerRecord),
+		fileName: fileName,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+	if fileName == "" {
+		return store, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	records := []*PeerRecord{}
+	err = json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		// the file is not trusted more than records received from the network
+		_, err := store.Add(record)
+		if err != nil {
+			continue
+		}
+	}
+	store.dirty = false
+	return store, nil
+}
+
+// Add verifies a record and keeps it if it is newer than the record of the node in the store,
+// it returns whether the record is kept
+func (store *Store) Add(record *PeerRecord) (bool, error) {
+	err := record.Verify()
+	if err != nil {
+		return false, err
+	}
+	now := store.now()
+	signedAt := time.Unix(0, record.Timestamp)
+	if signedAt.After(now.Add(maxClockSkew)) {
+		return false, fmt.Errorf("timestamp %v of peer record is in the future", signedAt)
+	}
+	if store.isExpired(record, now) {
+		return false, fmt.Errorf("peer record signed at %v is expired", signedAt)
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	oldRecord, ok := store.records[record.PublicKey]
+	if ok && oldRecord.Timestamp >= record.Timestamp {
+		return false, nil
+	}
+	if !ok && len(store.records) >= maxRecords {
+		return false, errors.New("peer store is full")
+	}
+	newRecord := *record
+	store.records[record.PublicKey] = &newRecord
+	store.dirty = true
+	return true, nil
+}
+
+func (store *Store) isExpired(record *PeerRecord, now time.Time) bool {
+	return now.Sub(time.Unix(0, record.Timestamp)) > store.ttl
+}
+
+// Get returns copies of the records matching filter, sorted by public key
+func (store *Store) Get(filter *Filter) []PeerRecord {
+	store.mtx.RLock()
+	defer store.mtx.RUnlock()
+	result := []PeerRecord{}
+	for _, record := range store.records {
+		if filter.match(record) {
+			result = append(result, *record)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PublicKey < result[j].PublicKey
+	})
+	return result
+}
+
+// RemoveExpired removes records older than the ttl of the store and returns the number of removed records
+func (store *Store) RemoveExpired() int {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	now := store.now()
+	count := 0
+	for publicKey, record := range store.records {
+		if store.isExpired(record, now) {
+			delete(store.records, publicKey)
+			count++
+		}
+	}
+	if count > 0 {
+		store.dirty = true
+	}
+	return count
+}
+
+// Save writes the records to the file of the store if they have changed since the last save
+func (store *Store) Save() error {
+	if store.fileName == "" {
+		return nil
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if !store.dirty {
+		return nil
+	}
+	records := []*PeerRecord{}
+	for _, record := range store.records {
+		records = append(records, record)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(store.fileName+".tmp", data, 0644)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(store.fileName+".tmp", store.fileName)
+	if err != nil {
+		return err
+	}
+	store.dirty = false
+	return nil
+}
@@ -0,0 +1,177 @@
+package peerstore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+)
+
+func newSignedRecord(t *testing.T, seed string, role string, shardID int, timestamp time.Time) *PeerRecord {
+	privateKey, publicKey := bridgesig.KeyGen([]byte(seed))
+	miningKey, err := json.Marshal(map[string][]byte{common.BridgeConsensus: bridgesig.PKBytes(&publicKey)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := &PeerRecord{
+		RawAddress:    "/ip4/127.0.0.1/tcp/9433/p2p/" + seed,
+		PublicKeyType: common.BlsConsensus,
+		PublicKey:     string(miningKey),
+		Role:          role,
+		ShardID:       shardID,
+		Timestamp:     timestamp.UnixNano(),
+	}
+	err = record.Sign(bridgesig.SKBytes(&privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+func TestPeerRecord_Verify(t *testing.T) {
+	record := newSignedRecord(t, "node1", common.ShardRole, 1, time.Now())
+	if err := record.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	tampered := *record
+	tampered.RawAddress = "/ip4/10.0.0.1/tcp/9433/p2p/node1"
+	if err := tampered.Verify(); err == nil {
+		t.Error("tampered address must not verify")
+	}
+	tampered = *record
+	tampered.ShardID = 2
+	if err := tampered.Verify(); err == nil {
+		t.Error("tampered shard must not verify")
+	}
+	other := newSignedRecord(t, "node2", common.ShardRole, 1, time.Now())
+	tampered = *record
+	tampered.PublicKey = other.PublicKey
+	if err := tampered.Verify(); err == nil {
+		t.Error("record signed by another key must not verify")
+	}
+	beacon := newSignedRecord(t, "node3", common.BeaconRole, 0, time.Now())
+	if err := beacon.Verify(); err == nil {
+		t.Error("beacon record with a shard must not verify")
+	}
+}
+
+func TestStore_Add(t *testing.T) {
+	now := time.Now()
+	store, err := NewStore("", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.now = func() time.Time { return now }
+
+	older := newSignedRecord(t, "node1", common.ShardRole, 0, now.Add(-time.Minute))
+	newer := newSignedRecord(t, "node1", common.ShardRole, 1, now)
+	if ok, err := store.Add(newer); !ok || err != nil {
+		t.Fatalf("Add newer = %v, %v", ok, err)
+	}
+	if ok, err := store.Add(older); ok || err != nil {
+		t.Fatalf("Add older = %v, %v, want false, nil", ok, err)
+	}
+	records := store.Get(nil)
+	if len(records) != 1 || records[0].ShardID != 1 {
+		t.Fatalf("Get = %+v, want the newer record", records)
+	}
+
+	future := newSignedRecord(t, "node2", "", -1, now.Add(2*maxClockSkew))
+	if _, err := store.Add(future); err == nil {
+		t.Error("record from the future must be rejected")
+	}
+	expired := newSignedRecord(t, "node3", "", -1, now.Add(-2*time.Hour))
+	if _, err := store.Add(expired); err == nil {
+		t.Error("expired record must be rejected")
+	}
+	invalid := *newSignedRecord(t, "node4", "", -1, now)
+	invalid.Signature = newer.Signature
+	if _, err := store.Add(&invalid); err == nil {
+		t.Error("record with invalid signature must be rejected")
+	}
+}
+
+func TestStore_GetFilter(t *testing.T) {
+	now := time.Now()
+	store, err := NewStore("", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range []*PeerRecord{
+		newSignedRecord(t, "beacon", common.BeaconRole, -1, now),
+		newSignedRecord(t, "shard0", common.ShardRole, 0, now),
+		newSignedRecord(t, "shard1", common.ShardRole, 1, now),
+		newSignedRecord(t, "shard2", common.ShardRole, 2, now),
+		newSignedRecord(t, "other", "", -1, now),
+	} {
+		if _, err := store.Add(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   int
+	}{
+		{"all", nil, 5},
+		{"empty", &Filter{}, 5},
+		{"beacon", &Filter{Role: common.BeaconRole}, 1},
+		{"shard", &Filter{Role: common.ShardRole}, 3},
+		{"shard 0 and 2", &Filter{Role: common.ShardRole, ShardIDs: []int{0, 2}}, 2},
+		{"shard 3", &Filter{ShardIDs: []int{3}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := store.Get(tt.filter); len(got) != tt.want {
+				t.Errorf("Get(%+v) returned %v records, want %v", tt.filter, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_SaveAndRemoveExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "peerstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "peers.json")
+
+	now := time.Now()
+	store, err := NewStore(fileName, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range []*PeerRecord{
+		newSignedRecord(t, "node1", common.ShardRole, 0, now.Add(-50*time.Minute)),
+		newSignedRecord(t, "node2", common.BeaconRole, -1, now),
+	} {
+		if _, err := store.Add(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewStore(fileName, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Get(nil); len(got) != 2 {
+		t.Fatalf("loaded %v records, want 2", len(got))
+	}
+
+	loaded.now = func() time.Time { return now.Add(20 * time.Minute) }
+	if removed := loaded.RemoveExpired(); removed != 1 {
+		t.Fatalf("RemoveExpired = %v, want 1", removed)
+	}
+	if got := loaded.Get(nil); len(got) != 1 || got[0].Role != common.BeaconRole {
+		t.Fatalf("Get = %+v, want the beacon record", got)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"log"
+	"net/rpc"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
+)
+
+const gossipInterval = 30 * time.Second
+
+// gossip - loop forever to exchange peer records with the other bootnodes every gossipInterval,
+// records are signed by the nodes so records of a bootnode are checked like those of a node
+func (rpcServer *RpcServer) gossip() {
+	for {
+		for _, address := range rpcServer.Config.GossipPeers {
+			count, err := rpcServer.exchangeRecords(address)
+			if err != nil {
+				log.Printf("Exchange peer records with bootnode %v error %v", address, err)
+				continue
+			}
+			if count > 0 {
+				log.Printf("Got %v new peer records from bootnode %v", count, address)
+			}
+		}
+		time.Sleep(gossipInterval)
+	}
+}
+
+// exchangeRecords - push our peer records to a bootnode and keep the records it responses,
+// return the number of new records
+func (rpcServer *RpcServer) exchangeRecords(address string) (int, error) {
+	client, err := rpc.Dial("tcp", address)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	var response []peerstore.PeerRecord
+	err = client.Call("Handler.PushRecords", rpcServer.GetPeerRecords(nil), &response)
+	if err != nil {
+		return 0, err
+	}
+	return rpcServer.addPeerRecords(response), nil
+}
+
+func (rpcServer *RpcServer) addPeerRecords(records []peerstore.PeerRecord) int {
+	count := 0
+	for i := range records {
+		added, err := rpcServer.AddPeerRecord(&records[i])
+		if err != nil {
+			log.Println("Add peer record error", err)
+			continue
+		}
+		if added {
+			count++
+		}
+	}
+	return count
+}
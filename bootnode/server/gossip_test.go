@@ -0,0 +1,140 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+)
+
+func newTestPeerRecord(t *testing.T, seed string, role string, shardID int, timestamp time.Time) *peerstore.PeerRecord {
+	privateKey, publicKey := bridgesig.KeyGen([]byte(seed))
+	miningKey, err := json.Marshal(map[string][]byte{common.BridgeConsensus: bridgesig.PKBytes(&publicKey)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := &peerstore.PeerRecord{
+		RawAddress:    "/ip4/127.0.0.1/tcp/9433/p2p/" + seed,
+		PublicKeyType: common.BlsConsensus,
+		PublicKey:     string(miningKey),
+		Role:          role,
+		ShardID:       shardID,
+		Timestamp:     timestamp.UnixNano(),
+	}
+	err = record.Sign(bridgesig.SKBytes(&privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+// newTestRpcServer returns a server with an in memory store, without the heartbeat and gossip loops
+func newTestRpcServer(t *testing.T) *RpcServer {
+	store, err := peerstore.NewStore("", peerRecordTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &RpcServer{peers: make(map[string]*peer), store: store, server: rpc.NewServer()}
+}
+
+// listen serves the rpc handler of rpcServer on a random local port and returns its address
+func listen(t *testing.T, rpcServer *RpcServer) string {
+	err := rpcServer.server.Register(&Handler{rpcServer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go rpcServer.server.Accept(l)
+	return l.Addr().String()
+}
+
+func TestRpcServer_ExchangeRecords(t *testing.T) {
+	now := time.Now()
+	local := newTestRpcServer(t)
+	remote := newTestRpcServer(t)
+	address := listen(t, remote)
+
+	if _, err := local.AddPeerRecord(newTestPeerRecord(t, "node1", common.ShardRole, 0, now)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := remote.AddPeerRecord(newTestPeerRecord(t, "node2", common.BeaconRole, -1, now)); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := local.exchangeRecords(address)
+	if err != nil || count != 1 {
+		t.Fatalf("exchangeRecords = %v, %v, want 1, nil", count, err)
+	}
+	if n := len(local.GetPeerRecords(nil)); n != 2 {
+		t.Errorf("local bootnode has %v records, want 2", n)
+	}
+	if n := len(remote.GetPeerRecords(nil)); n != 2 {
+		t.Errorf("remote bootnode has %v records, want 2", n)
+	}
+
+	count, err = local.exchangeRecords(address)
+	if err != nil || count != 0 {
+		t.Fatalf("exchangeRecords again = %v, %v, want 0, nil", count, err)
+	}
+
+	if _, err := local.exchangeRecords("127.0.0.1:1"); err == nil {
+		t.Error("exchange with an unreachable bootnode must fail")
+	}
+}
+
+func TestRpcServer_AddPeerRecordsDropsInvalid(t *testing.T) {
+	now := time.Now()
+	rpcServer := newTestRpcServer(t)
+	valid := newTestPeerRecord(t, "node1", common.ShardRole, 0, now)
+	invalid := *newTestPeerRecord(t, "node2", common.ShardRole, 1, now)
+	invalid.Signature = valid.Signature
+
+	count := rpcServer.addPeerRecords([]peerstore.PeerRecord{*valid, invalid})
+	if count != 1 {
+		t.Fatalf("addPeerRecords = %v, want 1", count)
+	}
+	records := rpcServer.GetPeerRecords(nil)
+	if len(records) != 1 || records[0].PublicKey != valid.PublicKey {
+		t.Fatalf("GetPeerRecords = %+v, want the valid record", records)
+	}
+}
+
+// records saved before a restart are loaded even if the bootnode was down for longer than heartbeatTimeout
+func TestRpcServer_InitLoadsSavedRecords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bootnode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	record := newTestPeerRecord(t, "node1", common.ShardRole, 0, time.Now().Add(-10*time.Minute))
+	data, err := json.Marshal([]*peerstore.PeerRecord{record})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, peerStoreFileName), data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rpcServer := &RpcServer{}
+	err = rpcServer.Init(&RpcServerConfig{DataDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := rpcServer.GetPeerRecords(nil)
+	if len(records) != 1 || records[0].PublicKey != record.PublicKey {
+		t.Fatalf("GetPeerRecords = %+v, want the saved record", records)
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -13,9 +14,7 @@ type Handler struct {
 func (s Handler) GetPeers(args string, responseMessagePeers *[]wire.RawPeer) error {
 	fmt.Println(args)
 	// return note list
-	for _, p := range s.rpcServer.peers {
-		*responseMessagePeers = append(*responseMessagePeers, wire.RawPeer{p.rawAddress, p.publickeyType, p.publicKey})
-	}
+	*responseMessagePeers = append(*responseMessagePeers, s.rpcServer.getRawPeers()...)
 	fmt.Println("Response", *responseMessagePeers)
 	return nil
 }
@@ -31,12 +30,35 @@ func (s Handler) Ping(args *PingArgs, responseMessagePeers *[]wire.RawPeer) erro
 		return err
 	}
 
-	s.rpcServer.peersMtx.Lock()
-	defer s.rpcServer.peersMtx.Unlock()
 	// return note list
-	for _, p := range s.rpcServer.peers {
-		*responseMessagePeers = append(*responseMessagePeers, wire.RawPeer{p.rawAddress, p.publickeyType, p.publicKey})
-	}
+	*responseMessagePeers = append(*responseMessagePeers, s.rpcServer.getRawPeers()...)
 	fmt.Println("Response", *responseMessagePeers)
 	return nil
 }
+
+// PingRecord - handler func which receive the signed peer record of a node,
+// keep it and response all peer records to the node, a record without public key is not kept
+func (s Handler) PingRecord(record *peerstore.PeerRecord, responseRecords *[]peerstore.PeerRecord) error {
+	if record.PublicKey != "" {
+		_, err := s.rpcServer.AddPeerRecord(record)
+		if err != nil {
+			return err
+		}
+	}
+	*responseRecords = s.rpcServer.GetPeerRecords(nil)
+	return nil
+}
+
+// GetPeerRecords - response the peer records of nodes matching filter
+func (s Handler) GetPeerRecords(filter *peerstore.Filter, responseRecords *[]peerstore.PeerRecord) error {
+	*responseRecords = s.rpcServer.GetPeerRecords(filter)
+	return nil
+}
+
+// PushRecords - handler func which receive peer records gossiped by another bootnode,
+// keep the valid ones and response all peer records so the other bootnode gets ours
+func (s Handler) PushRecords(records []peerstore.PeerRecord, responseRecords *[]peerstore.PeerRecord) error {
+	s.rpcServer.addPeerRecords(records)
+	*responseRecords = s.rpcServer.GetPeerRecords(nil)
+	return nil
+}
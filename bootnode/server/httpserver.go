@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
+)
+
+// maxHTTPBodySize is the max size of a request body of the json http api
+const maxHTTPBodySize = 10 << 20
+
+// StartHTTP - serve the json http api of the bootnode on the http port:
+// GET /peers?role=shard&shard=0,1 returns the peer records matching role and shards,
+// POST /ping takes the peer record of a node and returns all peer records like PingRecord,
+// POST /records takes peer records gossiped by a bootnode and returns all peer records
+func (rpcServer *RpcServer) StartHTTP() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/peers", rpcServer.handleHTTPPeers)
+	mux.HandleFunc("/ping", rpcServer.handleHTTPPing)
+	mux.HandleFunc("/records", rpcServer.handleHTTPRecords)
+	return http.ListenAndServe(fmt.Sprintf(":%d", rpcServer.Config.HTTPPort), mux)
+}
+
+func (rpcServer *RpcServer) handleHTTPPeers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	filter := &peerstore.Filter{Role: r.URL.Query().Get("role")}
+	if shards := r.URL.Query().Get("shard"); shards != "" {
+		for _, shard := range strings.Split(shards, ",") {
+			shardID, err := strconv.Atoi(shard)
+			if err != nil {
+				http.Error(w, "invalid shard "+shard, http.StatusBadRequest)
+				return
+			}
+			filter.ShardIDs = append(filter.ShardIDs, shardID)
+		}
+	}
+	writeJSON(w, rpcServer.GetPeerRecords(filter))
+}
+
+func (rpcServer *RpcServer) handleHTTPPing(w http.ResponseWriter, r *http.Request) {
+	record := &peerstore.PeerRecord{}
+	if !readJSON(w, r, record) {
+		return
+	}
+	if record.PublicKey != "" {
+		_, err := rpcServer.AddPeerRecord(record)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	writeJSON(w, rpcServer.GetPeerRecords(nil))
+}
+
+func (rpcServer *RpcServer) handleHTTPRecords(w http.ResponseWriter, r *http.Request) {
+	records := []peerstore.PeerRecord{}
+	if !readJSON(w, r, &records) {
+		return
+	}
+	rpcServer.addPeerRecords(records)
+	writeJSON(w, rpcServer.GetPeerRecords(nil))
+}
+
+// readJSON decodes the json body of a POST request to v, it writes the error response and returns false on failure
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPBodySize)).Decode(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// serveHTTP calls handler with a request of method to target with body encoded as json, and returns the response
+func serveHTTP(handler http.HandlerFunc, method string, target string, body interface{}) *httptest.ResponseRecorder {
+	data := []byte{}
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(method, target, bytes.NewReader(data)))
+	return w
+}
+
+func decodeRecords(t *testing.T, w *httptest.ResponseRecorder) []peerstore.PeerRecord {
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %v, body %v", w.Code, w.Body.String())
+	}
+	records := []peerstore.PeerRecord{}
+	err := json.Unmarshal(w.Body.Bytes(), &records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestRpcServer_HandleHTTPPeers(t *testing.T) {
+	now := time.Now()
+	rpcServer := newTestRpcServer(t)
+	rpcServer.addPeerRecords([]peerstore.PeerRecord{
+		*newTestPeerRecord(t, "node1", common.ShardRole, 0, now),
+		*newTestPeerRecord(t, "node2", common.ShardRole, 1, now),
+		*newTestPeerRecord(t, "node3", common.BeaconRole, -1, now),
+	})
+
+	if n := len(decodeRecords(t, serveHTTP(rpcServer.handleHTTPPeers, http.MethodGet, "/peers", nil))); n != 3 {
+		t.Errorf("GET /peers returns %v records, want 3", n)
+	}
+	records := decodeRecords(t, serveHTTP(rpcServer.handleHTTPPeers, http.MethodGet, "/peers?role=shard&shard=1,2", nil))
+	if len(records) != 1 || records[0].ShardID != 1 {
+		t.Errorf("GET /peers of shard 1, 2 = %+v, want the record of shard 1", records)
+	}
+	if w := serveHTTP(rpcServer.handleHTTPPeers, http.MethodGet, "/peers?shard=x", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid shard status %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	if w := serveHTTP(rpcServer.handleHTTPPeers, http.MethodPost, "/peers", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /peers status %v, want %v", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestRpcServer_HandleHTTPPing(t *testing.T) {
+	now := time.Now()
+	rpcServer := newTestRpcServer(t)
+	rpcServer.addPeerRecords([]peerstore.PeerRecord{*newTestPeerRecord(t, "node1", common.ShardRole, 0, now)})
+
+	record := newTestPeerRecord(t, "node2", common.BeaconRole, -1, now)
+	if n := len(decodeRecords(t, serveHTTP(rpcServer.handleHTTPPing, http.MethodPost, "/ping", record))); n != 2 {
+		t.Errorf("POST /ping returns %v records, want 2", n)
+	}
+	// a node without mining key only gets the records
+	anonymous := &peerstore.PeerRecord{RawAddress: "/ip4/127.0.0.1/tcp/9433"}
+	if n := len(decodeRecords(t, serveHTTP(rpcServer.handleHTTPPing, http.MethodPost, "/ping", anonymous))); n != 2 {
+		t.Errorf("POST /ping without public key returns %v records, want 2", n)
+	}
+
+	invalid := *newTestPeerRecord(t, "node3", common.ShardRole, 1, now)
+	invalid.Signature = record.Signature
+	if w := serveHTTP(rpcServer.handleHTTPPing, http.MethodPost, "/ping", &invalid); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid record status %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	if w := serveHTTP(rpcServer.handleHTTPPing, http.MethodGet, "/ping", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /ping status %v, want %v", w.Code, http.StatusMethodNotAllowed)
+	}
+	w := httptest.NewRecorder()
+	rpcServer.handleHTTPPing(w, httptest.NewRequest(http.MethodPost, "/ping", bytes.NewReader([]byte("{"))))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("malformed body status %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRpcServer_HandleHTTPRecords(t *testing.T) {
+	now := time.Now()
+	rpcServer := newTestRpcServer(t)
+	valid := newTestPeerRecord(t, "node1", common.ShardRole, 0, now)
+	invalid := *newTestPeerRecord(t, "node2", common.ShardRole, 1, now)
+	invalid.Signature = valid.Signature
+
+	records := decodeRecords(t, serveHTTP(rpcServer.handleHTTPRecords, http.MethodPost, "/records", []peerstore.PeerRecord{*valid, invalid}))
+	if len(records) != 1 || records[0].PublicKey != valid.PublicKey {
+		t.Errorf("POST /records = %+v, want the valid record only", records)
+	}
+}
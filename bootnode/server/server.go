@@ -7,17 +7,25 @@ import (
 	"log"
 	"net"
 	"net/rpc"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/wire"
 )
 
 const (
 	heartbeatInterval = 10
 	heartbeatTimeout  = 120
+	peerStoreFileName = "peers.json"
+	// peer records are kept and saved for peerRecordTTL after they are signed, much longer than heartbeatTimeout
+	// so the records saved before a restart are still loaded and served until their nodes ping again
+	peerRecordTTL = 24 * time.Hour
 )
 
 type peer struct {
@@ -33,21 +41,42 @@ type peer struct {
 type RpcServer struct {
 	peers    map[string]*peer // list peers which are still pinging to bootnode continuously
 	peersMtx sync.Mutex
+	store    *peerstore.Store // signed peer records of nodes pinging with PingRecord or gossiped by other bootnodes
 	server   *rpc.Server
 	Config   RpcServerConfig // config for RPC server
 }
 
 type RpcServerConfig struct {
-	Port int // rpc port
+	Port        int      // rpc port
+	HTTPPort    int      // port of the json http api, not served if 0
+	DataDir     string   // directory the peer records are saved to, they are kept in memory only if empty
+	GossipPeers []string // rpc addresses of other bootnodes peer records are exchanged with
 }
 
-func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
+func (rpcServer *RpcServer) Init(config *RpcServerConfig) error {
 	// get config and init list Peers
 	rpcServer.Config = *config
 	rpcServer.peers = make(map[string]*peer)
 	rpcServer.server = rpc.NewServer()
+	storeFileName := ""
+	if config.DataDir != "" {
+		err := os.MkdirAll(config.DataDir, 0755)
+		if err != nil {
+			return err
+		}
+		storeFileName = filepath.Join(config.DataDir, peerStoreFileName)
+	}
+	store, err := peerstore.NewStore(storeFileName, peerRecordTTL)
+	if err != nil {
+		return err
+	}
+	rpcServer.store = store
 	// start go routin hertbeat to check invalid peers
 	go rpcServer.PeerHeartBeat(heartbeatTimeout)
+	if len(config.GossipPeers) > 0 {
+		go rpcServer.gossip()
+	}
+	return nil
 }
 
 // Start - create handler and add into rpc server
@@ -113,6 +142,35 @@ func (rpcServer *RpcServer) AddOrUpdatePeer(rawAddress string, publicKeyType str
 	return nil
 }
 
+// AddPeerRecord - verify a peer record signed by a node and keep it if it is newer than the record of the node
+func (rpcServer *RpcServer) AddPeerRecord(record *peerstore.PeerRecord) (bool, error) {
+	return rpcServer.store.Add(record)
+}
+
+// GetPeerRecords - return the peer records matching filter
+func (rpcServer *RpcServer) GetPeerRecords(filter *peerstore.Filter) []peerstore.PeerRecord {
+	return rpcServer.store.Get(filter)
+}
+
+// getRawPeers - return the peers pinging with Ping and the nodes of peer records, a node in both is returned once
+func (rpcServer *RpcServer) getRawPeers() []wire.RawPeer {
+	rawPeers := []wire.RawPeer{}
+	records := rpcServer.store.Get(nil)
+	publicKeys := make(map[string]bool)
+	for _, record := range records {
+		publicKeys[record.PublicKey] = true
+		rawPeers = append(rawPeers, wire.RawPeer{RawAddress: record.RawAddress, PublicKeyType: record.PublicKeyType, PublicKey: record.PublicKey})
+	}
+	rpcServer.peersMtx.Lock()
+	defer rpcServer.peersMtx.Unlock()
+	for _, p := range rpcServer.peers {
+		if !publicKeys[p.publicKey] {
+			rawPeers = append(rawPeers, wire.RawPeer{RawAddress: p.rawAddress, PublicKeyType: p.publickeyType, PublicKey: p.publicKey})
+		}
+	}
+	return rawPeers
+}
+
 // RemovePeerByPbk - remove peer from mem of bootnode
 func (rpcServer *RpcServer) RemovePeerByPbk(publicKey string) {
 	delete(rpcServer.peers, publicKey)
@@ -128,18 +186,22 @@ func (rpcServer *RpcServer) CombineID(rawAddress string, publicKey string) strin
 // PeerHeartBeat - loop forever after heartbeatInterval to check peers
 // which are not connected to remove from bootnode
 // use Last Ping time to compare with time.now
+// expired peer records are removed and the records are saved to the data dir too
 func (rpcServer *RpcServer) PeerHeartBeat(heartbeatTimeout int) {
 	for {
 		now := time.Now().Local()
-		if len(rpcServer.peers) > 0 {
-		loop:
-			for publicKey, peer := range rpcServer.peers {
-				if now.Sub(peer.lastPing).Seconds() > float64(heartbeatTimeout) {
-					rpcServer.RemovePeerByPbk(publicKey)
-					goto loop
-				}
+		rpcServer.peersMtx.Lock()
+		for publicKey, peer := range rpcServer.peers {
+			if now.Sub(peer.lastPing).Seconds() > float64(heartbeatTimeout) {
+				rpcServer.RemovePeerByPbk(publicKey)
 			}
 		}
+		rpcServer.peersMtx.Unlock()
+		rpcServer.store.RemoveExpired()
+		err := rpcServer.store.Save()
+		if err != nil {
+			log.Println("Save peer records error", err)
+		}
 		time.Sleep(heartbeatInterval * time.Second)
 	}
 }
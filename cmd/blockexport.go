@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockexporter"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// chainDBReader reads final blocks of the chains from their databases for the block exporter,
+// the final height of a chain is the height of the lowest stored view
+type chainDBReader struct {
+	indexes      map[int]*chainIndex
+	finalHeights map[int]uint64
+}
+
+func newChainDBReader(chainDataDir string, chainIDs []int) (*chainDBReader, error) {
+	reader := &chainDBReader{indexes: make(map[int]*chainIndex), finalHeights: make(map[int]uint64)}
+	for _, chainID := range chainIDs {
+		c, err := openChainDB(chainDataDir, chainID, true)
+		if err != nil {
+			return nil, err
+		}
+		views, err := c.getViews()
+		if err != nil {
+			return nil, err
+		}
+		index, err := c.newChainIndex()
+		if err != nil {
+			return nil, err
+		}
+		reader.indexes[chainID] = index
+		reader.finalHeights[chainID] = views[0].Height
+	}
+	return reader, nil
+}
+
+func (reader *chainDBReader) getBlock(chainID int, height uint64) (*chainBlock, error) {
+	index, ok := reader.indexes[chainID]
+	if !ok {
+		return nil, fmt.Errorf("chain %v is not opened", chainID)
+	}
+	hash, err := index.getHash(height)
+	if err != nil {
+		return nil, fmt.Errorf("block hash of %v at height %v is not found", index.name(), height)
+	}
+	return index.getBlock(*hash)
+}
+
+func (reader *chainDBReader) GetFinalBeaconHeight() uint64 {
+	return reader.finalHeights[common.BeaconChainDataBaseID]
+}
+
+func (reader *chainDBReader) GetBeaconBlockByHeightV1(height uint64) (*blockchain.BeaconBlock, error) {
+	block, err := reader.getBlock(common.BeaconChainDataBaseID, height)
+	if err != nil {
+		return nil, err
+	}
+	return block.beaconBlock, nil
+}
+
+func (reader *chainDBReader) GetFinalShardHeight(shardID byte) uint64 {
+	return reader.finalHeights[int(shardID)]
+}
+
+func (reader *chainDBReader) GetShardBlockByHeightV1(height uint64, shardID byte) (*blockchain.ShardBlock, error) {
+	block, err := reader.getBlock(int(shardID), height)
+	if err != nil {
+		return nil, err
+	}
+	return block.shardBlock, nil
+}
+
+// exportBlocks exports the final blocks of chains to outDataDir, resuming from the checkpoint in outDataDir
+func exportBlocks(chainDataDir string, chainIDs []int, outDataDir string, format string, fromHeight uint64, toHeight uint64) error {
+	if outDataDir == "" {
+		return errors.New("no directory to export blocks to")
+	}
+	blockexporter.Logger.Init(common.NewBackend(nil).Logger("BlockExportCMD", true))
+	reader, err := newChainDBReader(chainDataDir, chainIDs)
+	if err != nil {
+		return err
+	}
+	exporter, err := blockexporter.NewExporter(blockexporter.Config{
+		Dir:        outDataDir,
+		Format:     format,
+		ChainIDs:   chainIDs,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		BlockChain: reader,
+	})
+	if err != nil {
+		return err
+	}
+	err = exporter.ExportFinalBlocks()
+	if err != nil {
+		// blocks exported before the error are written so the next export resumes from them
+		if flushErr := exporter.Flush(); flushErr != nil {
+			log.Println(flushErr)
+		}
+		return err
+	}
+	err = exporter.Flush()
+	if err != nil {
+		return err
+	}
+	for _, chainID := range chainIDs {
+		log.Printf("Exported %v to height %+v", reader.indexes[chainID].name(), exporter.GetLatestExportedHeight(chainID))
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+type btcProofResult struct {
+	IsValid       bool
+	TxHash        string
+	AttachedMsg   string
+	BlockHash     string
+	BlockHeight   int32
+	BestHeight    int32
+	Confirmations int32
+}
+
+func getBTCRelayingChainID(testNet bool, btcChainID string) string {
+	if btcChainID != "" {
+		return btcChainID
+	}
+	if testNet {
+		return blockchain.TestnetBTCChainID
+	}
+	return blockchain.MainnetBTCChainID
+}
+
+// openBTCRelayingChain opens (or creates) the relayed BTC header chain database at dbPath
+func openBTCRelayingChain(dbPath string, btcChainID string) (*btcrelaying.BlockChain, error) {
+	btcrelaying.Logger.Init(common.NewBackend(nil).Logger("BTCRelayingCMD", true))
+	params, genesisBlkHeight, err := blockchain.GetBTCRelayingChainParams(btcChainID)
+	if err != nil {
+		return nil, err
+	}
+	return btcrelaying.GetChainV2(dbPath, params, genesisBlkHeight)
+}
+
+func readBTCHeadersFile(fileName string) ([]*wire.BlockHeader, error) {
+	fileHandler, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer fileHandler.Close()
+	return btcrelaying.ReadHeaders(fileHandler)
+}
+
+// exportBTCHeaders writes the headers of the relayed BTC chain to a file of 80-byte headers
+func exportBTCHeaders(dbPath string, outDatadir string, fileName string, btcChainID string) error {
+	if fileName == "" {
+		fileName = "export-btc-headers"
+	}
+	if outDatadir == "" {
+		outDatadir = "./"
+	}
+	btcChain, err := openBTCRelayingChain(dbPath, btcChainID)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(outDatadir, fileName)
+	fileHandler, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fileHandler.Close()
+	count, err := btcChain.ExportHeaders(fileHandler)
+	if err != nil {
+		return err
+	}
+	log.Printf("Export %+v BTC headers, best height %+v, file %+v", count, btcChain.BestSnapshot().Height, file)
+	return nil
+}
+
+// importBTCHeaders validates the headers of the file and inserts them into the relayed BTC chain
+func importBTCHeaders(dbPath string, fileName string, btcChainID string) error {
+	headers, err := readBTCHeadersFile(fileName)
+	if err != nil {
+		return err
+	}
+	btcChain, err := openBTCRelayingChain(dbPath, btcChainID)
+	if err != nil {
+		return err
+	}
+	imported, err := btcChain.ImportHeaders(headers)
+	if err != nil {
+		return err
+	}
+	log.Printf("Import %+v of %+v BTC headers, best height %+v", imported, len(headers), btcChain.BestSnapshot().Height)
+	return nil
+}
+
+// verifyBTCProof checks a portal BTC proof against a headers file without a running node,
+// the headers are validated into a temporary chain database which is removed afterwards
+func verifyBTCProof(fileName string, proofStr string, btcChainID string) (*btcProofResult, error) {
+	proof, err := btcrelaying.ParseBTCProofFromB64EncodeStr(proofStr)
+	if err != nil {
+		return nil, err
+	}
+	if proof.BTCTx == nil || proof.BlockHash == nil {
+		return nil, errors.New("BTC proof must contain the transaction and the block hash")
+	}
+	headers, err := readBTCHeadersFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	tempDir, err := ioutil.TempDir("", "btcverifier")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	btcChain, err := openBTCRelayingChain(filepath.Join(tempDir, "btcrelaying"), btcChainID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = btcChain.ImportHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &btcProofResult{
+		TxHash:     proof.BTCTx.TxHash().String(),
+		BlockHash:  proof.BlockHash.String(),
+		BestHeight: btcChain.BestSnapshot().Height,
+	}
+	result.AttachedMsg, err = btcrelaying.ExtractAttachedMsgFromTx(proof.BTCTx)
+	if err != nil {
+		return nil, err
+	}
+	result.BlockHeight, err = btcChain.BlockHeightByHash(proof.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	result.Confirmations = result.BestHeight - result.BlockHeight
+	result.IsValid, err = btcChain.VerifyTxWithMerkleProofs(proof)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
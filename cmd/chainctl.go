@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/dataaccessobject"
 	"github.com/incognitochain/incognito-chain/peerv2"
@@ -27,7 +28,7 @@ func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, e
 	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	db, err := incdb.Open("leveldb", filepath.Join(databaseDir))
+	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(databaseDir))
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +171,7 @@ func RestoreShardChain(bc *blockchain.BlockChain, filename string) error {
 		if block.Header.Height%100 == 0 {
 			log.Printf("Restore Shard %+v Block %+v \n", block.Header.ShardID, block.Header.Height)
 		}
-		err = bc.InsertShardBlock(block, true)
+		err = bc.InsertShardBlock(context.Background(), block, true)
 		if bcErr, ok := err.(*blockchain.BlockChainError); ok {
 			if bcErr.Code == blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
 				continue
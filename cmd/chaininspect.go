@@ -0,0 +1,849 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// chainDB reads blocks, views and state roots of one chain directly from its database,
+// without initializing a blockchain
+type chainDB struct {
+	db      incdb.Database
+	chainID int // common.BeaconChainDataBaseID or shard ID
+}
+
+// chainBlock is a beacon or shard block with the fields shared by both
+type chainBlock struct {
+	Hash              common.Hash
+	PreviousBlockHash common.Hash
+	Height            uint64
+	beaconBlock       *blockchain.BeaconBlock
+	shardBlock        *blockchain.ShardBlock
+}
+
+// chainView is a stored beacon or shard view
+type chainView struct {
+	BestBlockHash common.Hash
+	Height        uint64
+	beaconView    *blockchain.BeaconBestState
+	shardView     *blockchain.ShardBestState
+}
+
+// stateRoot is a named state DB root hash of a block
+type stateRoot struct {
+	Name string
+	Root common.Hash
+}
+
+// chainIssue is a problem found in a chain database
+type chainIssue struct {
+	Chain  string
+	Height uint64
+	Hash   string `json:",omitempty"`
+	Issue  string
+}
+
+type bestStateResult struct {
+	Chain      string
+	BestHeight uint64
+	Views      interface{}
+}
+
+// openChainDB opens the database of chain chainID in chainDataDir, the directory holding the databases of
+// all chains, e.g. data/testnet/block
+func openChainDB(chainDataDir string, chainID int, readOnly bool) (*chainDB, error) {
+	dbPath := incdb.GetChainDBPath(chainDataDir, chainID)
+	db, err := incdb.Open("leveldb", dbPath, readOnly)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Open leveldb at %+v successfully, read only %+v", dbPath, readOnly)
+	return &chainDB{db: db, chainID: chainID}, nil
+}
+
+func (c *chainDB) isBeacon() bool {
+	return c.chainID == common.BeaconChainDataBaseID
+}
+
+func (c *chainDB) name() string {
+	if c.isBeacon() {
+		return "beacon"
+	}
+	return "shard " + strconv.Itoa(c.chainID)
+}
+
+func (c *chainDB) getBlock(hash common.Hash) (*chainBlock, error) {
+	if c.isBeacon() {
+		data, err := rawdbv2.GetBeaconBlockByHash(c.db, hash)
+		if err != nil {
+			return nil, err
+		}
+		block := blockchain.NewBeaconBlock()
+		if err := json.Unmarshal(data, block); err != nil {
+			return nil, err
+		}
+		return &chainBlock{
+			Hash:              *block.Hash(),
+			PreviousBlockHash: block.Header.PreviousBlockHash,
+			Height:            block.Header.Height,
+			beaconBlock:       block,
+		}, nil
+	}
+	data, err := rawdbv2.GetShardBlockByHash(c.db, hash)
+	if err != nil {
+		return nil, err
+	}
+	block := blockchain.NewShardBlock()
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return &chainBlock{
+		Hash:              *block.Hash(),
+		PreviousBlockHash: block.Header.PreviousBlockHash,
+		Height:            block.Header.Height,
+		shardBlock:        block,
+	}, nil
+}
+
+// getFinalizedHash returns the hash of the finalized block at height
+func (c *chainDB) getFinalizedHash(height uint64) (*common.Hash, error) {
+	if c.isBeacon() {
+		return rawdbv2.GetFinalizedBeaconBlockHashByIndex(c.db, height)
+	}
+	return rawdbv2.GetFinalizedShardBlockHashByIndex(c.db, byte(c.chainID), height)
+}
+
+func (c *chainDB) getStateRoots(hash common.Hash) ([]stateRoot, error) {
+	if c.isBeacon() {
+		data, err := rawdbv2.GetBeaconRootsHash(c.db, hash)
+		if err != nil {
+			return nil, err
+		}
+		roots := blockchain.BeaconRootHash{}
+		if err := json.Unmarshal(data, &roots); err != nil {
+			return nil, err
+		}
+		return []stateRoot{
+			{"ConsensusStateDB", roots.ConsensusStateDBRootHash},
+			{"FeatureStateDB", roots.FeatureStateDBRootHash},
+			{"RewardStateDB", roots.RewardStateDBRootHash},
+			{"SlashStateDB", roots.SlashStateDBRootHash},
+		}, nil
+	}
+	data, err := rawdbv2.GetShardRootsHash(c.db, byte(c.chainID), hash)
+	if err != nil {
+		return nil, err
+	}
+	roots := blockchain.ShardRootHash{}
+	if err := json.Unmarshal(data, &roots); err != nil {
+		return nil, err
+	}
+	return []stateRoot{
+		{"ConsensusStateDB", roots.ConsensusStateDBRootHash},
+		{"TransactionStateDB", roots.TransactionStateDBRootHash},
+		{"FeatureStateDB", roots.FeatureStateDBRootHash},
+		{"RewardStateDB", roots.RewardStateDBRootHash},
+		{"SlashStateDB", roots.SlashStateDBRootHash},
+	}, nil
+}
+
+// getViews returns the views stored for restoring the best state, the best view is the last one
+func (c *chainDB) getViews() ([]*chainView, error) {
+	result := []*chainView{}
+	if c.isBeacon() {
+		data, err := rawdbv2.GetBeaconViews(c.db)
+		if err != nil {
+			return nil, err
+		}
+		views := []*blockchain.BeaconBestState{}
+		if err := json.Unmarshal(data, &views); err != nil {
+			return nil, err
+		}
+		for _, v := range views {
+			result = append(result, &chainView{BestBlockHash: v.BestBlockHash, Height: v.BeaconHeight, beaconView: v})
+		}
+	} else {
+		data, err := rawdbv2.GetShardBestState(c.db, byte(c.chainID))
+		if err != nil {
+			return nil, err
+		}
+		views := []*blockchain.ShardBestState{}
+		if err := json.Unmarshal(data, &views); err != nil {
+			return nil, err
+		}
+		for _, v := range views {
+			result = append(result, &chainView{BestBlockHash: v.BestBlockHash, Height: v.ShardHeight, shardView: v})
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no view stored for %v", c.name())
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Height < result[j].Height
+	})
+	return result, nil
+}
+
+// walkBack calls f with the block of hash and its ancestors down to height 1, until f returns true
+func (c *chainDB) walkBack(hash common.Hash, f func(block *chainBlock) (bool, error)) error {
+	for {
+		block, err := c.getBlock(hash)
+		if err != nil {
+			return err
+		}
+		stop, err := f(block)
+		if err != nil || stop || block.Height <= 1 {
+			return err
+		}
+		hash = block.PreviousBlockHash
+	}
+}
+
+// chainIndex maps heights to block hashes of the best chain, blocks which are not finalized yet
+// are found by walking back from the best view
+type chainIndex struct {
+	*chainDB
+	bestHeight uint64
+	tail       map[uint64]common.Hash
+}
+
+func (c *chainDB) newChainIndex() (*chainIndex, error) {
+	views, err := c.getViews()
+	if err != nil {
+		return nil, err
+	}
+	bestView := views[len(views)-1]
+	index := &chainIndex{chainDB: c, bestHeight: bestView.Height, tail: make(map[uint64]common.Hash)}
+	hash := bestView.BestBlockHash
+	for height := bestView.Height; height >= 1; height-- {
+		if finalizedHash, err := c.getFinalizedHash(height); err == nil && finalizedHash.IsEqual(&hash) {
+			break
+		}
+		index.tail[height] = hash
+		block, err := c.getBlock(hash)
+		if err != nil {
+			// a missing block is reported when the chain is verified
+			break
+		}
+		hash = block.PreviousBlockHash
+	}
+	return index, nil
+}
+
+func (index *chainIndex) getHash(height uint64) (*common.Hash, error) {
+	if hash, ok := index.tail[height]; ok {
+		return &hash, nil
+	}
+	return index.getFinalizedHash(height)
+}
+
+// heightRange returns the range to process, toHeight 0 means the best height
+func (index *chainIndex) heightRange(fromHeight uint64, toHeight uint64) (uint64, uint64, error) {
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	if toHeight == 0 || toHeight > index.bestHeight {
+		toHeight = index.bestHeight
+	}
+	if fromHeight > toHeight {
+		return 0, 0, fmt.Errorf("from height %v is above to height %v of %v", fromHeight, toHeight, index.name())
+	}
+	return fromHeight, toHeight, nil
+}
+
+func (c *chainDB) newIssue(height uint64, hash *common.Hash, format string, args ...interface{}) chainIssue {
+	issue := chainIssue{Chain: c.name(), Height: height, Issue: fmt.Sprintf(format, args...)}
+	if hash != nil {
+		issue.Hash = hash.String()
+	}
+	return issue
+}
+
+// getBestState returns the views stored for restoring the best state of the chain
+func getBestState(c *chainDB) (*bestStateResult, error) {
+	views, err := c.getViews()
+	if err != nil {
+		return nil, err
+	}
+	result := &bestStateResult{Chain: c.name(), BestHeight: views[len(views)-1].Height}
+	if c.isBeacon() {
+		beaconViews := []*blockchain.BeaconBestState{}
+		for _, v := range views {
+			beaconViews = append(beaconViews, v.beaconView)
+		}
+		result.Views = beaconViews
+	} else {
+		shardViews := []*blockchain.ShardBestState{}
+		for _, v := range views {
+			shardViews = append(shardViews, v.shardView)
+		}
+		result.Views = shardViews
+	}
+	return result, nil
+}
+
+// verifyChain walks blocks from fromHeight to toHeight and checks that every block is stored at its height
+// and links to the block below
+func verifyChain(c *chainDB, fromHeight uint64, toHeight uint64) ([]chainIssue, error) {
+	index, err := c.newChainIndex()
+	if err != nil {
+		return nil, err
+	}
+	fromHeight, toHeight, err = index.heightRange(fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	issues := []chainIssue{}
+	var prevHash *common.Hash
+	if fromHeight > 1 {
+		prevHash, _ = index.getHash(fromHeight - 1)
+	}
+	for height := fromHeight; height <= toHeight; height++ {
+		hash, err := index.getHash(height)
+		if err != nil {
+			issues = append(issues, c.newIssue(height, nil, "no block hash at height"))
+			prevHash = nil
+			continue
+		}
+		block, err := c.getBlock(*hash)
+		if err != nil {
+			issues = append(issues, c.newIssue(height, hash, "block not found"))
+			prevHash = nil
+			continue
+		}
+		if !block.Hash.IsEqual(hash) {
+			issues = append(issues, c.newIssue(height, hash, "stored block has hash %v", block.Hash))
+		}
+		if block.Height != height {
+			issues = append(issues, c.newIssue(height, hash, "stored block has height %v", block.Height))
+		}
+		if prevHash != nil && !block.PreviousBlockHash.IsEqual(prevHash) {
+			issues = append(issues, c.newIssue(height, hash, "previous block hash %v does not match block %v at height %v", block.PreviousBlockHash, prevHash, height-1))
+		}
+		prevHash = hash
+		if height%1000 == 0 {
+			log.Printf("Verify %v block %+v", c.name(), height)
+		}
+	}
+	return issues, nil
+}
+
+// recomputeStateRoot reads every leaf of the state trie at root and returns the root of a new trie built from them
+func recomputeStateRoot(db incdb.Database, root common.Hash) (common.Hash, error) {
+	if root == (common.Hash{}) {
+		return root, nil
+	}
+	intermediateWriter := trie.NewIntermediateWriter(db)
+	storedTrie, err := trie.New(root, intermediateWriter)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	rebuiltTrie, err := trie.New(common.Hash{}, intermediateWriter)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	it := trie.NewIterator(storedTrie.NodeIterator(nil))
+	for it.Next() {
+		if err := rebuiltTrie.TryUpdate(it.Key, it.Value); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	if it.Err != nil {
+		return common.Hash{}, it.Err
+	}
+	return rebuiltTrie.Hash(), nil
+}
+
+// verifyStateRoots recomputes the state DB roots of blocks from fromHeight to toHeight from the stored tries
+// and compares them with the roots stored for these blocks
+func verifyStateRoots(c *chainDB, fromHeight uint64, toHeight uint64) ([]chainIssue, error) {
+	index, err := c.newChainIndex()
+	if err != nil {
+		return nil, err
+	}
+	fromHeight, toHeight, err = index.heightRange(fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	issues := []chainIssue{}
+	verified := make(map[common.Hash]bool)
+	for height := fromHeight; height <= toHeight; height++ {
+		hash, err := index.getHash(height)
+		if err != nil {
+			issues = append(issues, c.newIssue(height, nil, "no block hash at height"))
+			continue
+		}
+		roots, err := c.getStateRoots(*hash)
+		if err != nil {
+			issues = append(issues, c.newIssue(height, hash, "state roots not found"))
+			continue
+		}
+		for _, root := range roots {
+			// a state DB is often unchanged by a block, its trie is only checked once
+			if verified[root.Root] {
+				continue
+			}
+			recomputedRoot, err := recomputeStateRoot(c.db, root.Root)
+			if err != nil {
+				issues = append(issues, c.newIssue(height, hash, "%v root %v can not be read: %v", root.Name, root.Root, err))
+				continue
+			}
+			if !recomputedRoot.IsEqual(&root.Root) {
+				issues = append(issues, c.newIssue(height, hash, "%v root %v is recomputed as %v", root.Name, root.Root, recomputedRoot))
+				continue
+			}
+			verified[root.Root] = true
+		}
+		if height%1000 == 0 {
+			log.Printf("Verify %v state roots %+v", c.name(), height)
+		}
+	}
+	return issues, nil
+}
+
+// findMissing reports the blocks, block hashes and state roots missing from fromHeight to toHeight,
+// and the stored views whose block or state is missing
+func findMissing(c *chainDB, fromHeight uint64, toHeight uint64) ([]chainIssue, error) {
+	views, err := c.getViews()
+	if err != nil {
+		return nil, err
+	}
+	issues := []chainIssue{}
+	for _, view := range views {
+		if _, err := c.getBlock(view.BestBlockHash); err != nil {
+			issues = append(issues, c.newIssue(view.Height, &view.BestBlockHash, "block of view not found"))
+		}
+		roots, err := c.getStateRoots(view.BestBlockHash)
+		if err != nil {
+			issues = append(issues, c.newIssue(view.Height, &view.BestBlockHash, "state roots of view not found"))
+			continue
+		}
+		for _, root := range roots {
+			if _, err := trie.New(root.Root, trie.NewIntermediateWriter(c.db)); err != nil {
+				issues = append(issues, c.newIssue(view.Height, &view.BestBlockHash, "%v root %v of view not found: %v", root.Name, root.Root, err))
+			}
+		}
+	}
+
+	index, err := c.newChainIndex()
+	if err != nil {
+		return nil, err
+	}
+	fromHeight, toHeight, err = index.heightRange(fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	for height := fromHeight; height <= toHeight; height++ {
+		hash, err := index.getHash(height)
+		if err != nil {
+			issues = append(issues, c.newIssue(height, nil, "no block hash at height"))
+			continue
+		}
+		if c.isBeacon() {
+			if ok, _ := rawdbv2.HasBeaconBlock(c.db, *hash); !ok {
+				issues = append(issues, c.newIssue(height, hash, "block not found"))
+			}
+		} else if ok, _ := rawdbv2.HasShardBlock(c.db, *hash); !ok {
+			issues = append(issues, c.newIssue(height, hash, "block not found"))
+		}
+		if _, err := c.getStateRoots(*hash); err != nil {
+			issues = append(issues, c.newIssue(height, hash, "state roots not found"))
+		}
+	}
+	return issues, nil
+}
+
+// relayingActions are the instructions of relayed external chain headers, the header chains they update are
+// stored in their own databases and can not be rolled back with the beacon chain
+var relayingActions = []string{
+	strconv.Itoa(metadata.RelayingBNBHeaderMeta),
+	strconv.Itoa(metadata.RelayingBTCHeaderMeta),
+	strconv.Itoa(metadata.RelayingETHHeaderMeta),
+	strconv.Itoa(metadata.RelayingLTCHeaderMeta),
+}
+
+func containsInstruction(instructions [][]string, action string) bool {
+	for _, inst := range instructions {
+		if len(inst) > 0 && inst[0] == action {
+			return true
+		}
+	}
+	return false
+}
+
+// getProposerIndex returns the index of producer in the committee stored at the consensus root of block hash
+func (c *chainDB) getProposerIndex(hash common.Hash, producer string) (int, error) {
+	roots, err := c.getStateRoots(hash)
+	if err != nil {
+		return 0, err
+	}
+	consensusStateDB, err := statedb.NewWithPrefixTrie(roots[0].Root, statedb.NewDatabaseAccessWarper(c.db))
+	if err != nil {
+		return 0, err
+	}
+	var committee []string
+	if c.isBeacon() {
+		committee, err = incognitokey.CommitteeKeyListToString(statedb.GetBeaconCommittee(consensusStateDB))
+	} else {
+		committee, err = incognitokey.CommitteeKeyListToString(statedb.GetOneShardCommittee(consensusStateDB, byte(c.chainID)))
+	}
+	if err != nil {
+		return 0, err
+	}
+	if index := common.IndexOfStr(producer, committee); index > -1 {
+		return index, nil
+	}
+	return 0, nil
+}
+
+// rollbackChain rewrites the views stored for restoring the best state with a single view at height, it is built
+// from the best view by undoing the blocks above height, fields reset by one of these blocks are rebuilt by walking
+// back the chain from height
+// A beacon rollback is refused below a block relaying external chain headers, the relaying databases can not be rolled back
+// Blocks above height are kept in the database, the node fetches and inserts the best chain again when it resumes
+func rollbackChain(c *chainDB, height uint64, chainParams *blockchain.Params) (*chainView, error) {
+	views, err := c.getViews()
+	if err != nil {
+		return nil, err
+	}
+	bestView := views[len(views)-1]
+	if height == 0 || height > bestView.Height {
+		return nil, fmt.Errorf("height %v is out of range [1, %v] of %v", height, bestView.Height, c.name())
+	}
+	if c.isBeacon() {
+		err = rollbackBeaconView(c, bestView.beaconView, height, chainParams)
+	} else {
+		err = rollbackShardView(c, bestView.shardView, height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.isBeacon() {
+		data, err := json.Marshal([]*blockchain.BeaconBestState{bestView.beaconView})
+		if err != nil {
+			return nil, err
+		}
+		err = rawdbv2.StoreBeaconViews(c.db, data)
+		if err != nil {
+			return nil, err
+		}
+		return &chainView{BestBlockHash: bestView.beaconView.BestBlockHash, Height: height, beaconView: bestView.beaconView}, nil
+	}
+	err = rawdbv2.StoreShardBestState(c.db, byte(c.chainID), []*blockchain.ShardBestState{bestView.shardView})
+	if err != nil {
+		return nil, err
+	}
+	return &chainView{BestBlockHash: bestView.shardView.BestBlockHash, Height: height, shardView: bestView.shardView}, nil
+}
+
+func rollbackShardView(c *chainDB, view *blockchain.ShardBestState, height uint64) error {
+	crossShardUndone := make(map[byte]bool)
+	swapUndone := false
+	hash := view.BestBlockHash
+	for view.ShardHeight > height {
+		block, err := c.getBlock(hash)
+		if err != nil {
+			return err
+		}
+		if block.Height != view.ShardHeight {
+			return fmt.Errorf("block %v has height %v instead of %v", hash, block.Height, view.ShardHeight)
+		}
+		shardBlock := block.shardBlock
+		view.TotalTxns -= uint64(len(shardBlock.Body.Transactions))
+		for _, tx := range shardBlock.Body.Transactions {
+			if !tx.IsSalaryTx() {
+				view.TotalTxnsExcludeSalary--
+			}
+		}
+		for fromShardID := range shardBlock.Body.CrossTransactions {
+			crossShardUndone[fromShardID] = true
+		}
+		if containsInstruction(shardBlock.Body.Instructions, blockchain.SwapAction) {
+			swapUndone = true
+		} else if producer := shardBlock.GetProducerPubKeyStr(); !swapUndone && view.NumOfBlocksByProducers[producer] > 0 {
+			view.NumOfBlocksByProducers[producer]--
+			if view.NumOfBlocksByProducers[producer] == 0 {
+				delete(view.NumOfBlocksByProducers, producer)
+			}
+		}
+		hash = block.PreviousBlockHash
+		view.ShardHeight = block.Height - 1
+	}
+
+	block, err := c.getBlock(hash)
+	if err != nil {
+		return err
+	}
+	shardBlock := block.shardBlock
+	view.BestBlockHash = hash
+	view.BestBeaconHash = shardBlock.Header.BeaconHash
+	view.BeaconHeight = shardBlock.Header.BeaconHeight
+	view.Epoch = shardBlock.Header.Epoch
+	view.ShardHeight = height
+	view.NumTxns = uint64(len(shardBlock.Body.Transactions))
+	roots, err := c.getStateRoots(hash)
+	if err != nil {
+		return err
+	}
+	view.ConsensusStateDBRootHash = roots[0].Root
+	view.TransactionStateDBRootHash = roots[1].Root
+	view.FeatureStateDBRootHash = roots[2].Root
+	view.RewardStateDBRootHash = roots[3].Root
+	view.SlashStateDBRootHash = roots[4].Root
+	view.ShardProposerIdx = 0
+	if height > 1 {
+		view.ShardProposerIdx, err = c.getProposerIndex(block.PreviousBlockHash, shardBlock.Header.Producer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if view.BestCrossShard == nil {
+		view.BestCrossShard = make(map[byte]uint64)
+	}
+	for fromShardID := range crossShardUndone {
+		delete(view.BestCrossShard, fromShardID)
+		err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+			crossTransactions, ok := block.shardBlock.Body.CrossTransactions[fromShardID]
+			if ok && len(crossTransactions) > 0 {
+				view.BestCrossShard[fromShardID] = crossTransactions[len(crossTransactions)-1].BlockHeight
+			}
+			return ok, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if swapUndone {
+		// count blocks produced since the last swap
+		view.NumOfBlocksByProducers = make(map[string]uint64)
+		err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+			view.NumOfBlocksByProducers[block.shardBlock.GetProducerPubKeyStr()]++
+			return containsInstruction(block.shardBlock.Body.Instructions, blockchain.SwapAction), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rollbackBeaconView(c *chainDB, view *blockchain.BeaconBestState, height uint64, chainParams *blockchain.Params) error {
+	if chainParams.Epoch == 0 {
+		return errors.New("epoch of chain params is not set")
+	}
+	shardStateUndone := make(map[byte]bool)
+	crossShardUndone := make(map[byte]map[byte]bool)
+	randomUndone := false
+	randomTimeUndone := false
+	hash := view.BestBlockHash
+	for view.BeaconHeight > height {
+		block, err := c.getBlock(hash)
+		if err != nil {
+			return err
+		}
+		if block.Height != view.BeaconHeight {
+			return fmt.Errorf("block %v has height %v instead of %v", hash, block.Height, view.BeaconHeight)
+		}
+		for _, action := range relayingActions {
+			if containsInstruction(block.beaconBlock.Body.Instructions, action) {
+				return fmt.Errorf("block %v at height %v relays external chain headers, the relaying databases would stay ahead of the beacon chain, roll back to height %v or above", hash, block.Height, block.Height)
+			}
+		}
+		for shardID, shardStates := range block.beaconBlock.Body.ShardState {
+			shardStateUndone[shardID] = true
+			for _, shardState := range shardStates {
+				for _, toShardID := range shardState.CrossShard {
+					if crossShardUndone[shardID] == nil {
+						crossShardUndone[shardID] = make(map[byte]bool)
+					}
+					crossShardUndone[shardID][toShardID] = true
+				}
+			}
+		}
+		if containsInstruction(block.beaconBlock.Body.Instructions, blockchain.RandomAction) {
+			randomUndone = true
+		}
+		if block.Height%chainParams.Epoch == chainParams.RandomTime {
+			randomTimeUndone = true
+		}
+		hash = block.PreviousBlockHash
+		view.BeaconHeight = block.Height - 1
+	}
+
+	block, err := c.getBlock(hash)
+	if err != nil {
+		return err
+	}
+	beaconBlock := block.beaconBlock
+	view.BestBlockHash = hash
+	view.PreviousBestBlockHash = beaconBlock.Header.PreviousBlockHash
+	view.Epoch = beaconBlock.Header.Epoch
+	view.BeaconHeight = height
+	roots, err := c.getStateRoots(hash)
+	if err != nil {
+		return err
+	}
+	view.ConsensusStateDBRootHash = roots[0].Root
+	view.FeatureStateDBRootHash = roots[1].Root
+	view.RewardStateDBRootHash = roots[2].Root
+	view.SlashStateDBRootHash = roots[3].Root
+	view.BeaconProposerIndex = 0
+	if height > 1 {
+		view.BeaconProposerIndex, err = c.getProposerIndex(block.PreviousBlockHash, beaconBlock.Header.Producer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if view.BestShardHash == nil {
+		view.BestShardHash = make(map[byte]common.Hash)
+	}
+	if view.BestShardHeight == nil {
+		view.BestShardHeight = make(map[byte]uint64)
+	}
+	if view.LastCrossShardState == nil {
+		view.LastCrossShardState = make(map[byte]map[byte]uint64)
+	}
+	for shardID := range shardStateUndone {
+		delete(view.BestShardHash, shardID)
+		delete(view.BestShardHeight, shardID)
+		err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+			shardStates := block.beaconBlock.Body.ShardState[shardID]
+			if len(shardStates) == 0 {
+				return false, nil
+			}
+			view.BestShardHash[shardID] = shardStates[len(shardStates)-1].Hash
+			view.BestShardHeight[shardID] = shardStates[len(shardStates)-1].Height
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for fromShardID, toShardIDs := range crossShardUndone {
+		for toShardID := range toShardIDs {
+			if fromShardID == toShardID {
+				continue
+			}
+			delete(view.LastCrossShardState[fromShardID], toShardID)
+			err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+				shardStates := block.beaconBlock.Body.ShardState[fromShardID]
+				for i := len(shardStates) - 1; i >= 0; i-- {
+					if common.IndexOfByte(toShardID, shardStates[i].CrossShard) > -1 {
+						if view.LastCrossShardState[fromShardID] == nil {
+							view.LastCrossShardState[fromShardID] = make(map[byte]uint64)
+						}
+						view.LastCrossShardState[fromShardID][toShardID] = shardStates[i].Height
+						return true, nil
+					}
+				}
+				return false, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if randomUndone {
+		view.CurrentRandomNumber = -1
+		err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+			instructions := block.beaconBlock.Body.Instructions
+			for i := len(instructions) - 1; i >= 0; i-- {
+				if len(instructions[i]) > 1 && instructions[i][0] == blockchain.RandomAction {
+					randomNumber, err := strconv.ParseInt(instructions[i][1], 10, 64)
+					if err != nil {
+						return false, err
+					}
+					view.CurrentRandomNumber = randomNumber
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if randomTimeUndone {
+		view.CurrentRandomTimeStamp = 0
+		err := c.walkBack(hash, func(block *chainBlock) (bool, error) {
+			if block.Height%chainParams.Epoch != chainParams.RandomTime {
+				return false, nil
+			}
+			view.CurrentRandomTimeStamp = block.beaconBlock.Header.Timestamp
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// random number flag and number of blocks by producers are reset at the first block of each epoch
+	view.IsGetRandomNumber = false
+	view.NumOfBlocksByProducers = make(map[string]uint64)
+	err = c.walkBack(hash, func(block *chainBlock) (bool, error) {
+		view.NumOfBlocksByProducers[block.beaconBlock.GetProducerPubKeyStr()]++
+		isEpochStart := block.Height%chainParams.Epoch == 1
+		if !isEpochStart || block.Height == 1 {
+			if block.Height%chainParams.Epoch >= chainParams.RandomTime && containsInstruction(block.beaconBlock.Body.Instructions, blockchain.RandomAction) {
+				view.IsGetRandomNumber = true
+			}
+		}
+		return isEpochStart, nil
+	})
+	return err
+}
+
+// getChainParams returns the params of testnet or mainnet
+func getChainParams(testNet bool) *blockchain.Params {
+	if blockchain.ChainMainParam.Epoch == 0 {
+		blockchain.SetupParam()
+	}
+	if testNet {
+		return &blockchain.ChainTestParam
+	}
+	return &blockchain.ChainMainParam
+}
+
+// processChains opens the database of every chain of chainIDs and prints the result of f for it
+func processChains(chainDataDir string, chainIDs []int, readOnly bool, f func(c *chainDB) (interface{}, error)) {
+	for _, chainID := range chainIDs {
+		c, err := openChainDB(chainDataDir, chainID, readOnly)
+		if err != nil {
+			log.Printf("Open chain %+v database failed, err %+v", chainID, err)
+			continue
+		}
+		result, err := f(c)
+		if err != nil {
+			log.Printf("Process %v failed, err %+v", c.name(), err)
+			continue
+		}
+		if issues, ok := result.([]chainIssue); ok {
+			log.Printf("Found %+v issues in %v", len(issues), c.name())
+		}
+		if result == nil {
+			continue
+		}
+		data, err := parseToJsonString(result)
+		if err != nil {
+			continue
+		}
+		log.Println(string(data))
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+var emptyRoot = common.HexToHash(common.HexEmptyRoot)
+
+func newTestChainDB(t *testing.T, chainID int) *chainDB {
+	dir, err := ioutil.TempDir("", "chaininspect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.RemoveAll(dir)
+	})
+	return &chainDB{db: db, chainID: chainID}
+}
+
+// storeShardChain stores blocks as a chain of shard 0 with finalized indexes and empty state roots,
+// and returns their hashes by height
+func storeShardChain(t *testing.T, c *chainDB, blocks []*blockchain.ShardBlock) map[uint64]common.Hash {
+	hashes := make(map[uint64]common.Hash)
+	prevHash := common.Hash{}
+	for i, block := range blocks {
+		block.Header.Height = uint64(i + 1)
+		block.Header.PreviousBlockHash = prevHash
+		if block.Header.Height > 1 {
+			// stored blocks pass the sanity check of unmarshalling
+			block.ValidationData = "validation"
+			block.Header.CommitteeRoot = common.HashH([]byte("committee"))
+		}
+		hash := *block.Hash()
+		assert.Nil(t, rawdbv2.StoreShardBlock(c.db, hash, block))
+		assert.Nil(t, rawdbv2.StoreFinalizedShardBlockHashByIndex(c.db, 0, block.Header.Height, hash))
+		roots := blockchain.ShardRootHash{
+			ConsensusStateDBRootHash:   emptyRoot,
+			TransactionStateDBRootHash: emptyRoot,
+			FeatureStateDBRootHash:     emptyRoot,
+			RewardStateDBRootHash:      emptyRoot,
+			SlashStateDBRootHash:       emptyRoot,
+		}
+		assert.Nil(t, rawdbv2.StoreShardRootsHash(c.db, 0, hash, roots))
+		hashes[block.Header.Height] = hash
+		prevHash = hash
+	}
+	return hashes
+}
+
+// storeBeaconChain is storeShardChain for beacon blocks
+func storeBeaconChain(t *testing.T, c *chainDB, blocks []*blockchain.BeaconBlock) map[uint64]common.Hash {
+	hashes := make(map[uint64]common.Hash)
+	prevHash := common.Hash{}
+	for i, block := range blocks {
+		block.Header.Height = uint64(i + 1)
+		block.Header.PreviousBlockHash = prevHash
+		block.Header.Timestamp = int64(1000 + i)
+		hash := *block.Hash()
+		assert.Nil(t, rawdbv2.StoreBeaconBlockByHash(c.db, hash, block))
+		assert.Nil(t, rawdbv2.StoreFinalizedBeaconBlockHashByIndex(c.db, block.Header.Height, hash))
+		roots := blockchain.BeaconRootHash{
+			ConsensusStateDBRootHash: emptyRoot,
+			FeatureStateDBRootHash:   emptyRoot,
+			RewardStateDBRootHash:    emptyRoot,
+			SlashStateDBRootHash:     emptyRoot,
+		}
+		assert.Nil(t, rawdbv2.StoreBeaconRootsHash(c.db, hash, roots))
+		hashes[block.Header.Height] = hash
+		prevHash = hash
+	}
+	return hashes
+}
+
+func newTestShardBlock(producer string) *blockchain.ShardBlock {
+	block := blockchain.NewShardBlock()
+	block.Header.ProducerPubKeyStr = producer
+	block.Header.Version = blockchain.SHARD_BLOCK_VERSION
+	block.Header.Round = 1
+	block.Header.Epoch = 1
+	block.Header.Timestamp = 1000
+	block.Header.BeaconHash = common.HashH([]byte("beacon"))
+	block.Header.TotalTxsFee = make(map[common.Hash]uint64)
+	return block
+}
+
+func newTestBeaconBlock(producer string) *blockchain.BeaconBlock {
+	block := blockchain.NewBeaconBlock()
+	block.Header.ProducerPubKeyStr = producer
+	block.Body.ShardState = make(map[byte][]blockchain.ShardState)
+	return block
+}
+
+func TestRollbackChain_Shard(t *testing.T) {
+	c := newTestChainDB(t, 0)
+	blocks := []*blockchain.ShardBlock{}
+	for i := 0; i < 6; i++ {
+		block := newTestShardBlock([]string{"A", "B"}[i%2])
+		block.Header.BeaconHeight = uint64(10 * (i + 1))
+		blocks = append(blocks, block)
+	}
+	blocks[2].Body.CrossTransactions[1] = []blockchain.CrossTransaction{{BlockHeight: 7}}
+	blocks[4].Body.CrossTransactions[1] = []blockchain.CrossTransaction{{BlockHeight: 9}}
+	blocks[4].Body.Instructions = [][]string{{blockchain.SwapAction}}
+	hashes := storeShardChain(t, c, blocks)
+
+	bestView := &blockchain.ShardBestState{
+		BestBlockHash:          hashes[6],
+		ShardHeight:            6,
+		BeaconHeight:           60,
+		BestCrossShard:         map[byte]uint64{1: 9},
+		NumOfBlocksByProducers: map[string]uint64{"A": 1, "B": 1},
+	}
+	assert.Nil(t, rawdbv2.StoreShardBestState(c.db, 0, []*blockchain.ShardBestState{bestView}))
+
+	_, err := rollbackChain(c, 7, nil)
+	assert.NotNil(t, err)
+
+	view, err := rollbackChain(c, 4, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), view.Height)
+	assert.Equal(t, hashes[4], view.BestBlockHash)
+
+	views, err := c.getViews()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(views))
+	shardView := views[0].shardView
+	assert.Equal(t, hashes[4], shardView.BestBlockHash)
+	assert.Equal(t, uint64(4), shardView.ShardHeight)
+	assert.Equal(t, uint64(40), shardView.BeaconHeight)
+	assert.Equal(t, map[byte]uint64{1: 7}, shardView.BestCrossShard)
+	// the undone swap makes blocks be counted again since the previous swap, there is none below height 4
+	assert.Equal(t, map[string]uint64{"A": 2, "B": 2}, shardView.NumOfBlocksByProducers)
+	assert.Equal(t, emptyRoot, shardView.ConsensusStateDBRootHash)
+}
+
+func newTestBeaconChain(t *testing.T, relayingHeight uint64) (*chainDB, map[uint64]common.Hash) {
+	c := newTestChainDB(t, common.BeaconChainDataBaseID)
+	blocks := []*blockchain.BeaconBlock{}
+	for i := 0; i < 8; i++ {
+		blocks = append(blocks, newTestBeaconBlock([]string{"A", "B"}[i%2]))
+	}
+	blocks[1].Body.ShardState[0] = []blockchain.ShardState{{Height: 1, Hash: common.HashH([]byte{1}), CrossShard: []byte{1}}}
+	blocks[6].Body.ShardState[0] = []blockchain.ShardState{{Height: 2, Hash: common.HashH([]byte{2}), CrossShard: []byte{1}}}
+	blocks[2].Body.Instructions = [][]string{{blockchain.RandomAction, "42"}}
+	blocks[7].Body.Instructions = [][]string{{blockchain.RandomAction, "99"}}
+	if relayingHeight > 0 {
+		blocks[relayingHeight-1].Body.Instructions = append(blocks[relayingHeight-1].Body.Instructions,
+			[]string{strconv.Itoa(metadata.RelayingBTCHeaderMeta), "-1", "0", "{}"})
+	}
+	hashes := storeBeaconChain(t, c, blocks)
+
+	bestView := blockchain.NewBeaconBestState()
+	bestView.BestBlockHash = hashes[8]
+	bestView.BeaconHeight = 8
+	bestView.BestShardHash = map[byte]common.Hash{0: common.HashH([]byte{2})}
+	bestView.BestShardHeight = map[byte]uint64{0: 2}
+	bestView.LastCrossShardState = map[byte]map[byte]uint64{0: {1: 2}}
+	bestView.CurrentRandomNumber = 99
+	bestView.IsGetRandomNumber = true
+	data, err := json.Marshal([]*blockchain.BeaconBestState{bestView})
+	assert.Nil(t, err)
+	assert.Nil(t, rawdbv2.StoreBeaconViews(c.db, data))
+	return c, hashes
+}
+
+func TestRollbackChain_Beacon(t *testing.T) {
+	c, hashes := newTestBeaconChain(t, 0)
+	chainParams := &blockchain.Params{Epoch: 5, RandomTime: 3}
+
+	view, err := rollbackChain(c, 6, chainParams)
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[6], view.BestBlockHash)
+
+	views, err := c.getViews()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(views))
+	beaconView := views[0].beaconView
+	assert.Equal(t, uint64(6), beaconView.BeaconHeight)
+	assert.Equal(t, hashes[6], beaconView.BestBlockHash)
+	assert.Equal(t, hashes[5], beaconView.PreviousBestBlockHash)
+	assert.Equal(t, common.HashH([]byte{1}), beaconView.BestShardHash[0])
+	assert.Equal(t, uint64(1), beaconView.BestShardHeight[0])
+	assert.Equal(t, uint64(1), beaconView.LastCrossShardState[0][1])
+	assert.Equal(t, int64(42), beaconView.CurrentRandomNumber)
+	// block 8 is at random time of its epoch, the time stamp is taken from block 3 again
+	assert.Equal(t, int64(1002), beaconView.CurrentRandomTimeStamp)
+	// block 6 starts an epoch
+	assert.Equal(t, false, beaconView.IsGetRandomNumber)
+	assert.Equal(t, map[string]uint64{"B": 1}, beaconView.NumOfBlocksByProducers)
+}
+
+func TestRollbackChain_BeaconRelaying(t *testing.T) {
+	c, hashes := newTestBeaconChain(t, 7)
+	chainParams := &blockchain.Params{Epoch: 5, RandomTime: 3}
+
+	_, err := rollbackChain(c, 6, chainParams)
+	assert.NotNil(t, err)
+	views, err := c.getViews()
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[8], views[0].BestBlockHash)
+
+	view, err := rollbackChain(c, 7, chainParams)
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[7], view.BestBlockHash)
+}
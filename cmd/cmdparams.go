@@ -39,15 +39,32 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	FromHeight   uint64 `long:"fromheight" description:"First block height to inspect, default is 1"`
+	ToHeight     uint64 `long:"toheight" description:"Last block height to inspect, default is the best height"`
+	Height       uint64 `long:"height" description:"Block height the best state is rolled back to"`
+	ExportFormat string `long:"exportformat" description:"Format of exported blocks, ndjson or parquet, default is ndjson"`
+	// devnet genesis
+	KeyList             string `long:"keylist" description:"keylist.json of utility/genkeylistjson the genesis committees are taken from"`
+	NumShards           int    `long:"numshards" description:"Number of shards of the devnet, default is 2"`
+	BeaconCommitteeSize int    `long:"beaconcommitteesize" description:"Size of the genesis beacon committee, default is 4"`
+	ShardCommitteeSize  int    `long:"shardcommitteesize" description:"Size of the genesis committee of every shard, default is 4"`
 	// wallet
-	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
-	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
-	WalletAccountName string `long:"walletaccountname" description:"Wallet account name"`
-	ShardID           int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
+	WalletName          string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
+	WalletPassphrase    string `long:"walletpassphrase" description:"Wallet passphrase"`
+	NewWalletPassphrase string `long:"newwalletpassphrase" description:"New wallet passphrase the wallet is re-encrypted with"`
+	WalletAccountName   string `long:"walletaccountname" description:"Wallet account name"`
+	ShardID             int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
+	// offline signing
+	InputCoins string `long:"inputcoins" description:"Json file of the coins of listoutputcoins the serial numbers are derived for"`
+	TxBundle   string `long:"txbundle" description:"File of the bundle of createunsignedtransaction to sign"`
 
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
 	PToken   string `long:"pToken" description:"Bridge token"`
+
+	// BTC relaying
+	BTCChainID string `long:"btcchainid" description:"BTC relaying chain ID, default depends on testnet flag"`
+	BTCProof   string `long:"btcproof" description:"Base64 encoded BTC proof to verify"`
 }
 
 // newConfigParser returns a new command line flags parser.
@@ -8,6 +8,19 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	exportBTCHeadersCmd    = "exportbtcheaders"
+	importBTCHeadersCmd    = "importbtcheaders"
+	verifyBTCProofCmd      = "verifybtcproof"
+	changeWalletPassCmd    = "changewalletpassphrase"
+	bestStateCmd           = "beststate"
+	verifyChainCmd         = "verifychain"
+	verifyStateRootsCmd    = "verifystateroots"
+	findMissingCmd         = "findmissing"
+	rollbackChainCmd       = "rollbackchain"
+	exportBlocksCmd        = "exportblocks"
+	genDevnetGenesisCmd    = "gendevnetgenesis"
+	deriveSerialNumbersCmd = "deriveserialnumbers"
+	signTxBundleCmd        = "signtxbundle" // signs the bundle of the createunsignedtransaction rpc offline, the result is sent by the sendtransaction rpc
 )
 
 var CmdList = []string{
@@ -18,4 +31,17 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	exportBTCHeadersCmd,
+	importBTCHeadersCmd,
+	verifyBTCProofCmd,
+	changeWalletPassCmd,
+	bestStateCmd,
+	verifyChainCmd,
+	verifyStateRootsCmd,
+	findMissingCmd,
+	rollbackChainCmd,
+	exportBlocksCmd,
+	genDevnetGenesisCmd,
+	deriveSerialNumbersCmd,
+	signTxBundleCmd,
 }
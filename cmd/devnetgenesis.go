@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+const (
+	devnetName                       = "devnet"
+	devnetNet                        = 0x64
+	defaultDevnetChainConfigFileName = "devnet-chainconfig.json"
+	defaultDevnetNumShards           = 2
+	defaultDevnetCommitteeSize       = 4
+)
+
+// devnetKeyList is keylist.json written by utility/genkeylistjson, keys of a shard have payment addresses in the shard
+type devnetKeyList struct {
+	Shard  map[int][]blockchain.GenesisValidator
+	Beacon []blockchain.GenesisValidator
+}
+
+// genDevnetGenesis writes the chain config of a devnet based on testnet, its genesis committees are the first keys
+// of the beacon and of every shard in the key list, it returns the path of the chain config and the hash of its params
+func genDevnetGenesis(keyListPath string, outDataDir string, fileName string, numShards int, beaconCommitteeSize int, shardCommitteeSize int) (string, string, error) {
+	if numShards == 0 {
+		numShards = defaultDevnetNumShards
+	}
+	if beaconCommitteeSize == 0 {
+		beaconCommitteeSize = defaultDevnetCommitteeSize
+	}
+	if shardCommitteeSize == 0 {
+		shardCommitteeSize = defaultDevnetCommitteeSize
+	}
+	if fileName == "" {
+		fileName = defaultDevnetChainConfigFileName
+	}
+	data, err := ioutil.ReadFile(keyListPath)
+	if err != nil {
+		return "", "", err
+	}
+	keyList := devnetKeyList{}
+	err = json.Unmarshal(data, &keyList)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(keyList.Beacon) < beaconCommitteeSize {
+		return "", "", fmt.Errorf("key list has %v beacon keys, %v are needed", len(keyList.Beacon), beaconCommitteeSize)
+	}
+	genesis := &blockchain.GenesisConfig{
+		BlockTime:       time.Now().UTC().Format(blockchain.GenesisBlockTimeLayout),
+		BeaconCommittee: keyList.Beacon[:beaconCommitteeSize],
+		ShardCommittees: [][]blockchain.GenesisValidator{},
+	}
+	for shardID := 0; shardID < numShards; shardID++ {
+		if len(keyList.Shard[shardID]) < shardCommitteeSize {
+			return "", "", fmt.Errorf("key list has %v keys of shard %v, %v are needed", len(keyList.Shard[shardID]), shardID, shardCommitteeSize)
+		}
+		genesis.ShardCommittees = append(genesis.ShardCommittees, keyList.Shard[shardID][:shardCommitteeSize])
+	}
+
+	base := getChainParams(true)
+	params, err := json.Marshal(map[string]interface{}{
+		"Name":                         devnetName,
+		"Net":                          devnetNet,
+		"ActiveShards":                 numShards,
+		"MinBeaconCommitteeSize":       beaconCommitteeSize,
+		"MaxBeaconCommitteeSize":       maxInt(beaconCommitteeSize, base.MaxBeaconCommitteeSize),
+		"MinShardCommitteeSize":        shardCommitteeSize,
+		"MaxShardCommitteeSize":        maxInt(shardCommitteeSize, base.MaxShardCommitteeSize),
+		"NumberOfFixedBlockValidators": shardCommitteeSize,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	chainConfig := &blockchain.ChainConfig{
+		Base:    blockchain.ChainConfigBaseTestnet,
+		Params:  params,
+		Genesis: genesis,
+	}
+	chainParams, err := chainConfig.ToParams()
+	if err != nil {
+		return "", "", err
+	}
+	paramsHash, err := chainParams.Hash()
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err = json.MarshalIndent(chainConfig, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	err = os.MkdirAll(outDataDir, os.ModePerm)
+	if err != nil {
+		return "", "", err
+	}
+	path := filepath.Join(outDataDir, fileName)
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", "", err
+	}
+	return path, paramsHash.String(), nil
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
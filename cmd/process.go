@@ -2,12 +2,12 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"log"
 	"strconv"
 	"strings"
 
-	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 )
 
@@ -20,6 +20,56 @@ func parseToJsonString(data interface{}) ([]byte, error) {
 	return result, nil
 }
 
+// parseShardIDs parses the shardids flag, "all" or shard IDs separated by ","
+func parseShardIDs(shardIDsStr string, testNet bool) ([]byte, error) {
+	var shardIDs = []byte{}
+	// all shard
+	if shardIDsStr == "all" {
+		for i := 0; i < getChainParams(testNet).ActiveShards; i++ {
+			shardIDs = append(shardIDs, byte(i))
+		}
+		return shardIDs, nil
+	}
+	// some particular shard
+	strs := strings.Split(shardIDsStr, ",")
+	if len(strs) > 256 {
+		return nil, errors.New("Number of shard id to process exceed limit")
+	}
+	for _, value := range strs {
+		temp, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("ShardID Params MUST contain number only in range 0-255")
+		}
+		if temp > 256 {
+			return nil, errors.New("ShardID exceed MAX value (> 255)")
+		}
+		shardID := byte(temp)
+		if common.IndexOfByte(shardID, shardIDs) > -1 {
+			continue
+		}
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs, nil
+}
+
+// getChainIDs returns the database IDs of the chains selected by the beacon and shardids flags
+func getChainIDs(beacon bool, shardIDsStr string, testNet bool) ([]int, error) {
+	chainIDs := []int{}
+	if beacon {
+		chainIDs = append(chainIDs, common.BeaconChainDataBaseID)
+	}
+	if shardIDsStr != "" {
+		shardIDs, err := parseShardIDs(shardIDsStr, testNet)
+		if err != nil {
+			return nil, err
+		}
+		for _, shardID := range shardIDs {
+			chainIDs = append(chainIDs, int(shardID))
+		}
+	}
+	return chainIDs, nil
+}
+
 func processCmd() {
 	switch cfg.Command {
 	case getPrivacyTokenID:
@@ -124,6 +174,18 @@ func processCmd() {
 			}
 			log.Println(string(result))
 		}
+	case changeWalletPassCmd:
+		{
+			if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.NewWalletPassphrase == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := changeWalletPassphrase(cfg.NewWalletPassphrase)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
 	case backupChain:
 		{
 			if cfg.Beacon == false && cfg.ShardIDs == "" {
@@ -141,42 +203,11 @@ func processCmd() {
 					log.Printf("Beacon Beackup failed, err %+v", err)
 				}
 			}
-			var shardIDs = []byte{}
 			if cfg.ShardIDs != "" {
-				// all shard
-				if cfg.ShardIDs == "all" {
-					var numberOfShards int
-					if cfg.TestNet {
-						numberOfShards = blockchain.ChainTestParam.ActiveShards
-					} else {
-						numberOfShards = blockchain.ChainMainParam.ActiveShards
-					}
-					for i := 0; i < numberOfShards; i++ {
-						shardIDs = append(shardIDs, byte(i))
-					}
-				} else {
-					// some particular shard
-					strs := strings.Split(cfg.ShardIDs, ",")
-					if len(strs) > 256 {
-						log.Println("Number of shard id to process exceed limit")
-						return
-					}
-					for _, value := range strs {
-						temp, err := strconv.Atoi(value)
-						if err != nil {
-							log.Println("ShardID Params MUST contain number only in range 0-255")
-							return
-						}
-						if temp > 256 {
-							log.Println("ShardID exceed MAX value (> 255)")
-							return
-						}
-						shardID := byte(temp)
-						if common.IndexOfByte(shardID, shardIDs) > 0 {
-							continue
-						}
-						shardIDs = append(shardIDs, shardID)
-					}
+				shardIDs, err := parseShardIDs(cfg.ShardIDs, cfg.TestNet)
+				if err != nil {
+					log.Println(err)
+					return
 				}
 				//backup shard
 				for _, shardID := range shardIDs {
@@ -213,5 +244,155 @@ func processCmd() {
 				}
 			}
 		}
+	case bestStateCmd, verifyChainCmd, verifyStateRootsCmd, findMissingCmd:
+		{
+			chainIDs, err := getChainIDs(cfg.Beacon, cfg.ShardIDs, cfg.TestNet)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			if cfg.ChainDataDir == "" || len(chainIDs) == 0 {
+				log.Println("Wrong param")
+				return
+			}
+			processChains(cfg.ChainDataDir, chainIDs, true, func(c *chainDB) (interface{}, error) {
+				switch cfg.Command {
+				case bestStateCmd:
+					return getBestState(c)
+				case verifyChainCmd:
+					return verifyChain(c, cfg.FromHeight, cfg.ToHeight)
+				case verifyStateRootsCmd:
+					return verifyStateRoots(c, cfg.FromHeight, cfg.ToHeight)
+				default:
+					return findMissing(c, cfg.FromHeight, cfg.ToHeight)
+				}
+			})
+		}
+	case rollbackChainCmd:
+		{
+			chainIDs, err := getChainIDs(cfg.Beacon, cfg.ShardIDs, cfg.TestNet)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			if cfg.ChainDataDir == "" || len(chainIDs) == 0 || cfg.Height == 0 {
+				log.Println("Wrong param")
+				return
+			}
+			chainParams := getChainParams(cfg.TestNet)
+			processChains(cfg.ChainDataDir, chainIDs, false, func(c *chainDB) (interface{}, error) {
+				view, err := rollbackChain(c, cfg.Height, chainParams)
+				if err != nil {
+					return nil, err
+				}
+				log.Printf("Roll back %v best state to height %+v, block %+v", c.name(), view.Height, view.BestBlockHash)
+				return nil, nil
+			})
+		}
+	case exportBlocksCmd:
+		{
+			chainIDs, err := getChainIDs(cfg.Beacon, cfg.ShardIDs, cfg.TestNet)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			if cfg.ChainDataDir == "" || cfg.OutDataDir == "" || len(chainIDs) == 0 {
+				log.Println("Wrong param")
+				return
+			}
+			err = exportBlocks(cfg.ChainDataDir, chainIDs, cfg.OutDataDir, cfg.ExportFormat, cfg.FromHeight, cfg.ToHeight)
+			if err != nil {
+				log.Printf("Export blocks failed, err %+v", err)
+			}
+		}
+	case genDevnetGenesisCmd:
+		{
+			if cfg.KeyList == "" || cfg.OutDataDir == "" {
+				log.Println("Wrong param")
+				return
+			}
+			path, paramsHash, err := genDevnetGenesis(cfg.KeyList, cfg.OutDataDir, cfg.FileName, cfg.NumShards, cfg.BeaconCommitteeSize, cfg.ShardCommitteeSize)
+			if err != nil {
+				log.Printf("Generate devnet genesis failed, err %+v", err)
+				return
+			}
+			log.Printf("Devnet chain config is written to %+v, params hash %+v", path, paramsHash)
+		}
+	case deriveSerialNumbersCmd:
+		{
+			if cfg.WalletName == "" || cfg.WalletAccountName == "" || cfg.InputCoins == "" {
+				log.Println("Wrong param")
+				return
+			}
+			inputCoins, err := deriveSerialNumbers(cfg.WalletAccountName, cfg.InputCoins)
+			if err != nil {
+				log.Printf("Derive serial numbers failed, err %+v", err)
+				return
+			}
+			result, err := parseToJsonString(inputCoins)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
+	case signTxBundleCmd:
+		{
+			if cfg.WalletName == "" || cfg.WalletAccountName == "" || cfg.TxBundle == "" {
+				log.Println("Wrong param")
+				return
+			}
+			signedTx, err := signTxBundle(cfg.WalletAccountName, cfg.TxBundle)
+			if err != nil {
+				log.Printf("Sign tx bundle failed, err %+v", err)
+				return
+			}
+			result, err := parseToJsonString(signedTx)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
+	case exportBTCHeadersCmd:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("No BTC Relaying Database to Process")
+				return
+			}
+			err := exportBTCHeaders(cfg.ChainDataDir, cfg.OutDataDir, cfg.FileName, getBTCRelayingChainID(cfg.TestNet, cfg.BTCChainID))
+			if err != nil {
+				log.Printf("BTC Headers Export failed, err %+v", err)
+			}
+		}
+	case importBTCHeadersCmd:
+		{
+			if cfg.ChainDataDir == "" || cfg.FileName == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := importBTCHeaders(cfg.ChainDataDir, cfg.FileName, getBTCRelayingChainID(cfg.TestNet, cfg.BTCChainID))
+			if err != nil {
+				log.Printf("BTC Headers Import failed, err %+v", err)
+			}
+		}
+	case verifyBTCProofCmd:
+		{
+			if cfg.FileName == "" || cfg.BTCProof == "" {
+				log.Println("Wrong param")
+				return
+			}
+			proofResult, err := verifyBTCProof(cfg.FileName, cfg.BTCProof, getBTCRelayingChainID(cfg.TestNet, cfg.BTCChainID))
+			if err != nil {
+				log.Printf("BTC Proof Verification failed, err %+v", err)
+				return
+			}
+			result, err := parseToJsonString(proofResult)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
 	}
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// getSigningKey returns the private key of the wallet account, watch only accounts can not sign
+func getSigningKey(accountName string) (*privacy.PrivateKey, error) {
+	walletObj, err := loadWallet()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range walletObj.ListAccounts() {
+		if account.Name != accountName {
+			continue
+		}
+		if account.IsWatchOnly || len(account.Key.KeySet.PrivateKey) == 0 {
+			return nil, fmt.Errorf("account %s has no private key", accountName)
+		}
+		return &account.Key.KeySet.PrivateKey, nil
+	}
+	return nil, errors.New("Not found")
+}
+
+// deriveSerialNumbers reads the coins of listoutputcoins from inputCoinsFile and returns them with the serial numbers
+// derived from the private key of the account, they are the input coins of createunsignedtransaction
+func deriveSerialNumbers(accountName string, inputCoinsFile string) ([]privacy.CoinObject, error) {
+	senderSK, err := getSigningKey(accountName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(inputCoinsFile)
+	if err != nil {
+		return nil, err
+	}
+	coinObjs := []privacy.CoinObject{}
+	err = json.Unmarshal(data, &coinObjs)
+	if err != nil {
+		return nil, err
+	}
+	senderPK := privacy.GeneratePublicKey(*senderSK)
+	inputCoins := make([]*privacy.InputCoin, len(coinObjs))
+	for i, coinObj := range coinObjs {
+		inputCoins[i] = new(privacy.InputCoin).Init()
+		err = inputCoins[i].ParseCoinObjectToInputCoin(coinObj)
+		if err != nil {
+			return nil, fmt.Errorf("input coin %d is invalid %v", i, err)
+		}
+		if inputCoins[i].CoinDetails.GetPublicKey() == nil || inputCoins[i].CoinDetails.GetSNDerivator() == nil {
+			return nil, fmt.Errorf("input coin %d has no public key or snd", i)
+		}
+		if !bytes.Equal(inputCoins[i].CoinDetails.GetPublicKey().ToBytesS(), senderPK) {
+			return nil, fmt.Errorf("input coin %d is not owned by account %s", i, accountName)
+		}
+	}
+	transaction.DeriveSerialNumbers(inputCoins, senderSK)
+	for i, inputCoin := range inputCoins {
+		coinObjs[i].SerialNumber = base58.Base58Check{}.Encode(inputCoin.CoinDetails.GetSerialNumber().ToBytesS(), common.Base58Version)
+	}
+	return coinObjs, nil
+}
+
+// signTxBundle signs the bundle of createunsignedtransaction stored in bundleFile with the private key of the account,
+// it does not connect to any node, the result is sent by sendtransaction
+func signTxBundle(accountName string, bundleFile string) (*jsonresult.CreateTransactionResult, error) {
+	senderSK, err := getSigningKey(accountName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(bundleFile)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := transaction.DecodeUnsignedTxBundle(strings.Trim(strings.TrimSpace(string(data)), "\""))
+	if err != nil {
+		return nil, err
+	}
+	tx, err := transaction.SignUnsignedTxBundle(bundle, senderSK, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	result := jsonresult.NewCreateTransactionResult(tx.Hash(), common.EmptyString, txBytes, common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte()))
+	return &result, nil
+}
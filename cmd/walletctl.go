@@ -57,9 +57,15 @@ func listAccounts() (interface{}, error) {
 		return nil, err
 	}
 	accounts := walletObj.ListAccounts()
-	result := []string{}
-	for accountName := range accounts {
-		result = append(result, accountName)
+	result := make(map[string]interface{})
+	for accountName, account := range accounts {
+		result[accountName] = map[string]interface{}{
+			"Path":        account.Path,
+			"IsImported":  account.IsImported,
+			"IsWatchOnly": account.IsWatchOnly,
+			"Label":       account.Label,
+			"Metadata":    account.Metadata,
+		}
 	}
 	return result, err
 }
@@ -75,9 +81,16 @@ func getAccount(accountName string) (interface{}, error) {
 		if accountName == account.Name {
 			result := make(map[string]interface{})
 			result["Name"] = accountName
-			result["PrivateKey"] = account.Key.Base58CheckSerialize(wallet.PriKeyType)
+			if !account.IsWatchOnly {
+				result["PrivateKey"] = account.Key.Base58CheckSerialize(wallet.PriKeyType)
+			}
 			result["PaymentAddress"] = account.Key.Base58CheckSerialize(wallet.PaymentAddressType)
 			result["ReadonlyKey"] = account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType)
+			result["Path"] = account.Path
+			result["IsImported"] = account.IsImported
+			result["IsWatchOnly"] = account.IsWatchOnly
+			result["Label"] = account.Label
+			result["Metadata"] = account.Metadata
 			return result, nil
 		}
 	}
@@ -110,3 +123,16 @@ func createAccount(accountName string, shardID *byte) (interface{}, error) {
 	}
 	return nil, errors.New("Can not load wallet")
 }
+
+func changeWalletPassphrase(newPassphrase string) error {
+	walletObj, err := loadWallet()
+	if err != nil {
+		return err
+	}
+	err = walletObj.ChangePassPhrase(cfg.WalletPassphrase, newPassphrase)
+	if err != nil {
+		return err
+	}
+	log.Printf("Change passphrase of wallet %s successfully", cfg.WalletName)
+	return nil
+}
@@ -135,6 +135,16 @@ const (
 	PDEFeeWithdrawalAcceptedStatus = 1
 	PDEFeeWithdrawalRejectedStatus = 2
 
+	PDELimitOrderOpenStatus            = 1
+	PDELimitOrderPartiallyFilledStatus = 2
+	PDELimitOrderFilledStatus          = 3
+	PDELimitOrderCancelledStatus       = 4
+	PDELimitOrderExpiredStatus         = 5
+	PDELimitOrderRejectedStatus        = 6
+
+	PDECancelLimitOrderAcceptedStatus = 1
+	PDECancelLimitOrderRejectedStatus = 2
+
 	MinTxFeesOnTokenRequirement                             = 10000000000000 // 10000 prv, this requirement is applied from beacon height 87301 mainnet
 	BeaconBlockHeighMilestoneForMinTxFeesOnTokenRequirement = 87301          // milestone of beacon height, when apply min fee on token requirement
 
@@ -228,6 +238,14 @@ const (
 	PDECrossPoolTradeFeeRefundChainStatus          = "xPoolTradeRefundFee"
 	PDECrossPoolTradeSellingTokenRefundChainStatus = "xPoolTradeRefundSellingToken"
 	PDECrossPoolTradeAcceptedChainStatus           = "xPoolTradeAccepted"
+
+	PDELimitOrderAcceptedChainStatus           = "limitOrderAccepted"
+	PDELimitOrderFilledChainStatus             = "limitOrderFilled"
+	PDELimitOrderFeeRefundChainStatus          = "limitOrderRefundFee"
+	PDELimitOrderSellingTokenRefundChainStatus = "limitOrderRefundSellingToken"
+
+	PDECancelLimitOrderAcceptedChainStatus = "accepted"
+	PDECancelLimitOrderRejectedChainStatus = "rejected"
 )
 
 // Portal status for chain
@@ -301,17 +319,8 @@ const PortalBTCIDStr = "ef5947f70ead81a76a53c7c8b7317dd5245510c665d3a13921dc9a58
 const PortalBNBIDStr = "6abd698ea7ddd1f98b1ecaaddab5db0453b8363ff092f0d8d7d4c6b1155fb693"
 const PRVIDStr = "0000000000000000000000000000000000000000000000000000000000000004"
 
-var PortalSupportedIncTokenIDs = []string{
-	PortalBTCIDStr, // pBTC
-	PortalBNBIDStr, // pBNB
-}
-
-// set MinAmountPortalPToken to avoid attacking with amount is less than smallest unit of cryptocurrency
-// such as satoshi in BTC
-var MinAmountPortalPToken = map[string]uint64{
-	PortalBTCIDStr: 10,
-	PortalBNBIDStr: 10,
-}
+// PortalPTokenDecimals is the decimals of portal ptokens in inc chain
+const PortalPTokenDecimals = 9
 
 const ETHChainName = "eth"
 
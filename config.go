@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/jessevdk/go-flags"
 )
@@ -101,6 +102,8 @@ type config struct {
 	RPCPass                     string   `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
 	RPCLimitUser                string   `long:"rpclimituser" description:"Username for limited RPC connections"`
 	RPCLimitPass                string   `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
+	RPCAPIKeyFile               string   `long:"rpcapikeyfile" description:"File containing api keys with their method allow-list and rate limits"`
+	RPCAuditLogFile             string   `long:"rpcauditlog" description:"File to append audit records of requests authenticated by api key"`
 	RPCListeners                []string `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9334, testnet: 9334)"`
 	RPCWSListeners              []string `long:"rpcwslisten" description:"Add an interface/port to listen for RPC Websocket connections (default port: 19334, testnet: 19334)"`
 	RPCCert                     string   `long:"rpccert" description:"File containing the certificate file"`
@@ -137,6 +140,24 @@ type config struct {
 	TxPoolMaxTx uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
 	LimitFee    uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 
+	TxValidationWorkers int `long:"txvalidationworkers" description:"Number of goroutines validating transactions of a shard block in parallel, default is number of CPUs"`
+
+	PDEIndex bool `long:"pdeindex" description:"Index pool reserves, trades, trading fees and liquidity events of the PDE for the PDE analytics RPCs, the index is stored in the pdeindex folder of the data dir"`
+
+	ValidatorIndex bool `long:"validatorindex" description:"Index staking, assignment, swap and stop auto staking of validators for the getvalidatorstatus RPC and the subcribevalidatorstatus subscription, the index is stored in the validatorindex folder of the data dir"`
+
+	RewardIndex bool `long:"rewardindex" description:"Index rewards earned by every public key in every epoch, the DAO split and reward withdrawals for the getrewardhistory RPC, the index is stored in the rewardindex folder of the data dir"`
+
+	ExportDir    string `long:"exportdir" description:"Directory final blocks, transactions, instructions and PDE/portal events are exported to, partitioned by chain and height, nothing is exported if empty"`
+	ExportFormat string `long:"exportformat" description:"Format of the exported files, ndjson or parquet, default is ndjson"`
+
+	MetricsListen   string `long:"metricslisten" description:"Add an interface/port to serve Prometheus metrics on at /metrics, metrics are not served if empty"`
+	MonitorEndpoint string `long:"monitorendpoint" description:"URL the node status is reported to every few seconds, nothing is reported if empty"`
+	TraceFile       string `long:"tracefile" description:"File spans of block production, validation and consensus are appended to as json lines, tracing is disabled if both tracefile and traceendpoint are empty"`
+	TraceEndpoint   string `long:"traceendpoint" description:"OTLP/HTTP traces URL of an OpenTelemetry collector spans are sent to, e.g. http://localhost:4318/v1/traces"`
+
+	ChainConfig string `long:"chainconfig" description:"Json or yaml file of the chain params and genesis of a private network, it overrides the network selected by the testnet flags and must be the same on all nodes of the network"`
+
 	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
 	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
 	MetricUrl         string `long:"metricurl" description:"Metric URL"`
@@ -451,6 +472,17 @@ func loadConfig() (*config, []string, error) {
 		os.Exit(common.ExitCodeUnknow)
 	}
 
+	if cfg.ChainConfig != "" {
+		chainParams, err := loadChainConfigParams(cfg.ChainConfig)
+		if err != nil {
+			err := fmt.Errorf("%s: Failed to load chain config: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		activeNetParams = chainParams
+		blockchain.GenesisParam = chainParams.GenesisParams
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network.  In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
@@ -523,10 +555,10 @@ func loadConfig() (*config, []string, error) {
 			return nil, nil, err
 		}
 
-		// The RPC server is disabled if no username or password is provided.
+		// The RPC server is disabled if no username or password or api key file is provided.
 		if (cfg.RPCUser == "" || cfg.RPCPass == "") &&
-			(cfg.RPCLimitUser == "" || cfg.RPCLimitPass == "") {
-			Logger.log.Info("The RPC server is disabled if no username or password is provided.")
+			(cfg.RPCLimitUser == "" || cfg.RPCLimitPass == "") && cfg.RPCAPIKeyFile == "" {
+			Logger.log.Info("The RPC server is disabled if no username or password or api key file is provided.")
 			cfg.DisableRPC = true
 		}
 	}
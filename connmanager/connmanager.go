@@ -13,6 +13,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
 	"github.com/incognitochain/incognito-chain/bootnode/server"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/peer"
@@ -426,19 +427,36 @@ func (connManager *ConnManager) processDiscoverPeers() error {
 		// 		Logger.log.Error(err)
 		// 	}
 		// }
-		publicKeyMining, publicKeyType, signDataInBase58CheckEncode, err := listener.GetConfig().ConsensusEngine.SignDataWithCurrentMiningKey([]byte(rawAddress))
-		// fmt.Printf("CONNLog User Publickey in Ping message RawAddress %v PublicKey %v \n", rawAddress, publicKeyMining)
+		// packing in a peer record signed with our mining key,
+		// the key is got by signing the raw address first as it is part of the signed record
+		record := connManager.newPeerRecord(rawAddress)
+		consensusEngine := listener.GetConfig().ConsensusEngine
+		publicKeyMining, publicKeyType, signDataInBase58CheckEncode, err := consensusEngine.SignDataWithCurrentMiningKey([]byte(rawAddress))
 		if err != nil {
 			Logger.log.Error(err)
-			//return NewConnManagerError(UnexpectedError, err)
 		}
-		//TODO hy init ping
-		// packing in a object PingArgs
-		args := &server.PingArgs{}
-		args.Init(rawAddress, publicKeyType, publicKeyMining, signDataInBase58CheckEncode)
-		Logger.log.Debugf("[Exchange Peers] Ping %+v", args)
-
-		err = client.Call("Handler.Ping", args, &response)
+		if publicKeyMining != common.EmptyString && rawAddress != common.EmptyString {
+			record.PublicKey = publicKeyMining
+			record.PublicKeyType = publicKeyType
+			_, _, record.Signature, err = consensusEngine.SignDataWithCurrentMiningKey(record.SignData())
+			if err != nil {
+				Logger.log.Error(err)
+				record.PublicKey = common.EmptyString
+			}
+		}
+		Logger.log.Debugf("[Exchange Peers] Ping %+v", record)
+
+		var records []peerstore.PeerRecord
+		err = client.Call("Handler.PingRecord", record, &records)
+		if isMethodNotFound(err) {
+			// bootnodes which are not upgraded have no PingRecord, they are pinged with the signed raw address
+			// and their unsigned peers are used like before
+			Logger.log.Infof("[Exchange Peers] Bootnode has no PingRecord, fall back to Ping")
+			args := &server.PingArgs{}
+			args.Init(rawAddress, publicKeyType, publicKeyMining, signDataInBase58CheckEncode)
+			records = nil
+			err = client.Call("Handler.Ping", args, &response)
+		}
 
 		if err != nil {
 			fmt.Println("CONN: cannot ping bootnode")
@@ -448,7 +466,20 @@ func (connManager *ConnManager) processDiscoverPeers() error {
 			client = nil
 			return err
 		} else {
-			fmt.Println("CONN: bootnode return", len(response))
+			fmt.Println("CONN: bootnode return", len(records)+len(response))
+		}
+		// only records signed by the nodes themselves are used
+		for i := range records {
+			err := records[i].Verify()
+			if err != nil {
+				Logger.log.Warnf("[Exchange Peers] Drop peer record of %v from bootnode: %v", records[i].RawAddress, err)
+				continue
+			}
+			response = append(response, wire.RawPeer{
+				RawAddress:    records[i].RawAddress,
+				PublicKeyType: records[i].PublicKeyType,
+				PublicKey:     records[i].PublicKey,
+			})
 		}
 		// make models
 		responsePeers := make(map[string]*wire.RawPeer)
@@ -476,6 +507,34 @@ func (connManager *ConnManager) processDiscoverPeers() error {
 	return nil
 }
 
+// isMethodNotFound - return whether err is the error of a call to a method the rpc server does not have
+func isMethodNotFound(err error) bool {
+	serverErr, ok := err.(rpc.ServerError)
+	return ok && strings.HasPrefix(string(serverErr), "rpc: can't find method")
+}
+
+// newPeerRecord - return an unsigned peer record of our node with the role and shard of the consensus state
+func (connManager *ConnManager) newPeerRecord(rawAddress string) *peerstore.PeerRecord {
+	record := &peerstore.PeerRecord{
+		RawAddress: rawAddress,
+		ShardID:    -1,
+		Timestamp:  time.Now().UnixNano(),
+	}
+	consensusState := connManager.config.ConsensusState
+	if consensusState == nil {
+		return record
+	}
+	consensusState.Lock()
+	defer consensusState.Unlock()
+	if consensusState.currentShard != nil {
+		record.Role = common.ShardRole
+		record.ShardID = int(*consensusState.currentShard)
+	} else if consensusState.role == common.BeaconRole {
+		record.Role = common.BeaconRole
+	}
+	return record
+}
+
 // getPeerConnOfShard - return connection which you connect in shard
 func (connManager *ConnManager) getPeerConnOfShard(shard *byte) []*peer.PeerConn {
 	c := make([]*peer.PeerConn, 0)
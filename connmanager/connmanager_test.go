@@ -2,13 +2,18 @@ package connmanager
 
 import (
 	"fmt"
+	"net"
+	"net/rpc"
 	"sync"
 	"testing"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/bootnode/peerstore"
+	"github.com/incognitochain/incognito-chain/bootnode/server"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/peer"
+	"github.com/incognitochain/incognito-chain/wire"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -180,3 +185,34 @@ func TestConnManager_Start(t *testing.T) {
 	err := connManager.Stop()
 	assert.Equal(t, nil, err)
 }
+
+// legacyHandler is the rpc handler of a bootnode which has Ping only
+type legacyHandler struct{}
+
+func (h legacyHandler) Ping(args *server.PingArgs, responseMessagePeers *[]wire.RawPeer) error {
+	*responseMessagePeers = append(*responseMessagePeers, wire.RawPeer{RawAddress: args.RawAddress})
+	return nil
+}
+
+func TestIsMethodNotFound(t *testing.T) {
+	rpcServer := rpc.NewServer()
+	assert.Nil(t, rpcServer.RegisterName("Handler", legacyHandler{}))
+	serverConn, clientConn := net.Pipe()
+	go rpcServer.ServeConn(serverConn)
+	client := rpc.NewClient(clientConn)
+	defer client.Close()
+
+	var records []peerstore.PeerRecord
+	err := client.Call("Handler.PingRecord", &peerstore.PeerRecord{}, &records)
+	assert.True(t, isMethodNotFound(err))
+
+	var response []wire.RawPeer
+	args := &server.PingArgs{}
+	args.Init("/ip4/127.0.0.1/tcp/9433", "", "", "")
+	err = client.Call("Handler.Ping", args, &response)
+	assert.Nil(t, err)
+	assert.False(t, isMethodNotFound(err))
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/9433", response[0].RawAddress)
+
+	assert.False(t, isMethodNotFound(fmt.Errorf("rpc: can't find method Handler.PingRecord")))
+}
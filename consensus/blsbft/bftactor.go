@@ -1,6 +1,7 @@
 package blsbft
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -228,7 +229,7 @@ func (e *BLSBFT) Start() error {
 						monitor.SetGlobalParam("ReceiveBlockTime", time.Since(e.RoundData.TimeStart).Seconds())
 						//fmt.Println("CONSENSUS: listen phase 2")
 
-						if err := e.Chain.ValidatePreSignBlock(e.Blocks[roundKey]); err != nil {
+						if err := e.Chain.ValidatePreSignBlock(context.Background(), e.Blocks[roundKey]); err != nil {
 							delete(e.Blocks, roundKey)
 							e.logger.Error(err)
 							continue
@@ -288,7 +289,7 @@ func (e *BLSBFT) Start() error {
 							continue
 						}
 
-						if err := e.Chain.InsertAndBroadcastBlock(e.RoundData.Block); err != nil {
+						if err := e.Chain.InsertAndBroadcastBlock(context.Background(), e.RoundData.Block); err != nil {
 							e.logger.Error(err)
 							if blockchainError, ok := err.(*blockchain.BlockChainError); ok {
 								if blockchainError.Code != blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
@@ -434,7 +435,7 @@ func (e *BLSBFT) createNewBlock() (common.BlockInterface, error) {
 			return
 		}
 
-		block, err = e.Chain.CreateNewBlock(1, base58Str, int(e.RoundData.Round), e.RoundData.TimeStart.Unix())
+		block, err = e.Chain.CreateNewBlock(context.Background(), 1, base58Str, int(e.RoundData.Round), e.RoundData.TimeStart.Unix())
 		if block != nil {
 			e.logger.Info("create block", block.GetHeight(), time.Since(time1).Seconds())
 		} else {
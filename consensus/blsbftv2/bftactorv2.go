@@ -13,6 +13,7 @@ import (
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -61,6 +62,8 @@ type ProposeBlockInfo struct {
 	votes      map[string]BFTVote //pk->BFTVote
 	isValid    bool
 	hasNewVote bool
+	// traceContext is the trace of the block, received with the propose message
+	traceContext tracing.SpanContext
 }
 
 func (e *BLSBFT_V2) GetConsensusName() string {
@@ -130,6 +133,7 @@ func (e *BLSBFT_V2) Start() error {
 				} else {
 					e.receiveBlockByHash[blkHash].block = block
 				}
+				e.setBlockTrace(e.receiveBlockByHash[blkHash], proposeMsg.TraceContext)
 
 				if block.GetHeight() <= e.Chain.GetBestViewHeight() {
 					e.Logger.Info("Receive block create from old view. Rejected!")
@@ -319,7 +323,10 @@ func (e *BLSBFT_V2) processIfBlockGetEnoughVote(blockHash string, v *ProposeBloc
 			if len(dsaKey) == 0 {
 				e.Logger.Error("canot find dsa key")
 			}
+			span := e.startVoteSpan(vote)
 			err := vote.validateVoteOwner(dsaKey)
+			span.SetError(err)
+			span.End()
 			if err != nil {
 				e.Logger.Error(dsaKey)
 				e.Logger.Error(err)
@@ -364,7 +371,12 @@ func (e *BLSBFT_V2) processIfBlockGetEnoughVote(blockHash string, v *ProposeBloc
 			return
 		}
 
-		go e.Chain.InsertAndBroadcastBlock(v.block)
+		ctx, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), v.traceContext), "bft.Commit", "chain", e.ChainKey, "height", v.block.GetHeight(), "votes", validVote)
+		go func(block common.BlockInterface) {
+			err := e.Chain.InsertAndBroadcastBlock(ctx, block)
+			span.SetError(err)
+			span.End()
+		}(v.block)
 
 		delete(e.receiveBlockByHash, blockHash)
 	}
@@ -383,7 +395,11 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	_, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
-	if err := e.Chain.ValidatePreSignBlock(v.block); err != nil {
+	ctx, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), v.traceContext), "bft.ValidatePreSignBlock", "chain", e.ChainKey, "height", v.block.GetHeight())
+	err := e.Chain.ValidatePreSignBlock(ctx, v.block)
+	span.SetError(err)
+	span.End()
+	if err != nil {
 		e.Logger.Error(err)
 		return err
 	}
@@ -420,6 +436,7 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	userPk := e.UserKeySet.GetPublicKey()
 	Vote.Validator = userPk.GetMiningKeyBase58(common.BlsConsensus)
 	Vote.PrevBlockHash = v.block.GetPrevHash().String()
+	Vote.TraceContext = span.SpanContext().String()
 	err = Vote.signVote(e.UserKeySet)
 	if err != nil {
 		e.Logger.Error(err)
@@ -445,14 +462,15 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, block common.BlockInterface) (common.BlockInterface, error) {
 	time1 := time.Now()
 	b58Str, _ := proposerPk.ToBase58()
+	ctx, span := tracing.StartSpan(context.Background(), "bft.ProposeBlock", "chain", e.ChainKey)
+	defer span.End()
 	var err error
 	if block == nil {
-		ctx := context.Background()
-		ctx, cancel := context.WithTimeout(ctx, common.TIMESLOT/2)
+		ctx, cancel := context.WithTimeout(ctx, (time.Duration(common.TIMESLOT)*time.Second)/2)
 		defer cancel()
 		//block, _ = e.Chain.CreateNewBlock(ctx, e.currentTimeSlot, e.UserKeySet.GetPublicKeyBase58())
 		e.Logger.Info("debug CreateNewBlock")
-		block, err = e.Chain.CreateNewBlock(2, b58Str, 1, e.currentTime)
+		block, err = e.Chain.CreateNewBlock(ctx, 2, b58Str, 1, e.currentTime)
 	} else {
 		e.Logger.Info("debug CreateNewBlockFromOldBlock")
 		block, err = e.Chain.CreateNewBlockFromOldBlock(block, b58Str, e.currentTime)
@@ -460,11 +478,13 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 		//block = e.voteHistory[e.Chain.GetBestViewHeight()+1]
 	}
 	if err != nil {
+		span.SetError(err)
 		return nil, NewConsensusError(BlockCreationError, err)
 	}
 
 	if block != nil {
 		e.Logger.Infof("create block %v hash %v, propose time %v, produce time %v", block.GetHeight(), block.Hash().String(), block.(common.BlockInterface).GetProposeTime(), block.(common.BlockInterface).GetProduceTime())
+		span.SetAttributes("height", block.GetHeight())
 	} else {
 		e.Logger.Infof("create block fail, time: %v", time.Since(time1).Seconds())
 		return nil, NewConsensusError(BlockCreationError, errors.New("block is nil"))
@@ -477,6 +497,7 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 	var proposeCtn = new(BFTPropose)
 	proposeCtn.Block = blockData
 	proposeCtn.PeerID = e.Node.GetSelfPeerID().String()
+	proposeCtn.TraceContext = span.SpanContext().String()
 	msg, _ := MakeBFTProposeMsg(proposeCtn, e.ChainKey, e.currentTimeSlot, block.GetHeight())
 	go e.ProcessBFTMsg(msg.(*wire.MessageBFT))
 	go e.Node.PushMessageToChain(msg, e.Chain)
@@ -509,6 +530,31 @@ func (e *BLSBFT_V2) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 	}
 }
 
+// setBlockTrace keeps the trace of a proposed block so that validating, voting and committing it continue the trace
+func (e *BLSBFT_V2) setBlockTrace(proposeBlockInfo *ProposeBlockInfo, traceContext string) {
+	if traceContext == "" {
+		return
+	}
+	sc, err := tracing.ParseSpanContext(traceContext)
+	if err != nil {
+		e.Logger.Debug(err)
+		return
+	}
+	proposeBlockInfo.traceContext = sc
+}
+
+// startVoteSpan starts the span of validating a vote as a child of the span that sent it
+func (e *BLSBFT_V2) startVoteSpan(vote BFTVote) *tracing.Span {
+	ctx := context.Background()
+	if vote.TraceContext != "" {
+		if sc, err := tracing.ParseSpanContext(vote.TraceContext); err == nil {
+			ctx = tracing.ContextWithSpanContext(ctx, sc)
+		}
+	}
+	_, span := tracing.StartSpan(ctx, "bft.ValidateVote", "chain", e.ChainKey, "blockHash", vote.BlockHash, "validator", vote.Validator)
+	return span
+}
+
 func (e *BLSBFT_V2) preValidateVote(blockHash []byte, Vote *BFTVote, candidate []byte) error {
 	data := []byte{}
 	data = append(data, blockHash...)
@@ -1,6 +1,7 @@
 package blsbftv2
 
 import (
+	"context"
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -39,12 +40,12 @@ type ChainInterface interface {
 	GetPubKeyCommitteeIndex(string) int
 	GetLastProposerIndex() int
 	UnmarshalBlock(blockString []byte) (common.BlockInterface, error)
-	CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error)
+	CreateNewBlock(ctx context.Context, version int, proposer string, round int, startTime int64) (common.BlockInterface, error)
 	CreateNewBlockFromOldBlock(oldBlock common.BlockInterface, proposer string, startTime int64) (common.BlockInterface, error)
-	InsertAndBroadcastBlock(block common.BlockInterface) error
+	InsertAndBroadcastBlock(ctx context.Context, block common.BlockInterface) error
 	// ValidateAndInsertBlock(block common.BlockInterface) error
 	ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
-	ValidatePreSignBlock(block common.BlockInterface) error
+	ValidatePreSignBlock(ctx context.Context, block common.BlockInterface) error
 	GetShardID() int
 
 	//for new syncker
@@ -1,6 +1,7 @@
 package blsbft
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -48,8 +50,11 @@ type BLSBFT struct {
 			ByteList   []blsmultisig.PublicKey
 		}
 		LastProposerIndex int
+		// TraceContext is the trace of the block of the round, received with the propose message
+		TraceContext tracing.SpanContext
 	}
 	Blocks         map[string]common.BlockInterface
+	blockTraces    map[string]tracing.SpanContext
 	EarlyVotes     map[string]map[string]vote
 	lockEarlyVotes sync.Mutex
 	isOngoing      bool
@@ -98,6 +103,7 @@ func (e *BLSBFT) Start() error {
 	e.StopCh = make(chan struct{})
 	e.EarlyVotes = make(map[string]map[string]vote)
 	e.Blocks = map[string]common.BlockInterface{}
+	e.blockTraces = map[string]tracing.SpanContext{}
 	e.ProposeMessageCh = make(chan BFTPropose)
 	e.VoteMessageCh = make(chan BFTVote)
 	e.InitRoundData()
@@ -125,11 +131,13 @@ func (e *BLSBFT) Start() error {
 					if e.RoundData.Round == block.GetRound() {
 						if e.RoundData.Block == nil {
 							e.Blocks[blockRoundKey] = block
+							e.setBlockTrace(blockRoundKey, proposeMsg.TraceContext)
 							continue
 						}
 					} else {
 						if e.RoundData.Round < block.GetRound() {
 							e.Blocks[blockRoundKey] = block
+							e.setBlockTrace(blockRoundKey, proposeMsg.TraceContext)
 							continue
 						}
 					}
@@ -137,6 +145,7 @@ func (e *BLSBFT) Start() error {
 				}
 				if block.GetHeight() > e.RoundData.NextHeight {
 					e.Blocks[blockRoundKey] = block
+					e.setBlockTrace(blockRoundKey, proposeMsg.TraceContext)
 					continue
 				}
 			case msg := <-e.VoteMessageCh:
@@ -162,12 +171,16 @@ func (e *BLSBFT) Start() error {
 							// committeeArr = append(committeeArr, e.RoundData.Committee...)
 							e.RoundData.lockVotes.Unlock()
 							go func(voteMsg BFTVote, blockHash common.Hash, committee []incognitokey.CommitteePublicKey) {
+								span := e.startVoteSpan(voteMsg)
+								defer span.End()
 								if err := e.preValidateVote(blockHash.GetBytes(), &(voteMsg.Vote), committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
+									span.SetError(err)
 									e.logger.Error(err)
 									return
 								}
 								if len(voteMsg.Vote.BRI) != 0 {
 									if err := validateSingleBriSig(&blockHash, voteMsg.Vote.BRI, committee[validatorIdx].MiningPubKey[common.BridgeConsensus]); err != nil {
+										span.SetError(err)
 										e.logger.Error(err)
 										return
 									}
@@ -236,8 +249,14 @@ func (e *BLSBFT) Start() error {
 
 					if e.Blocks[roundKey] != nil {
 						monitor.SetGlobalParam("ReceiveBlockTime", time.Since(e.RoundData.TimeStart).Seconds())
-						if err := e.Chain.ValidatePreSignBlock(e.Blocks[roundKey]); err != nil {
+						e.updatePhaseTimer("receiveblock", time.Since(e.RoundData.TimeStart))
+						ctx, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), e.blockTraces[roundKey]), "bft.ValidatePreSignBlock", "chain", e.ChainKey, "height", e.RoundData.NextHeight, "round", e.RoundData.Round)
+						err := e.Chain.ValidatePreSignBlock(ctx, e.Blocks[roundKey])
+						span.SetError(err)
+						span.End()
+						if err != nil {
 							delete(e.Blocks, roundKey)
+							delete(e.blockTraces, roundKey)
 							e.logger.Error(err)
 							continue
 						}
@@ -249,6 +268,7 @@ func (e *BLSBFT) Start() error {
 
 							e.RoundData.Block = e.Blocks[roundKey]
 							e.RoundData.BlockHash = *e.RoundData.Block.Hash()
+							e.RoundData.TraceContext = e.blockTraces[roundKey]
 							valData, err := DecodeValidationData(e.RoundData.Block.GetValidationField())
 							if err != nil {
 								e.logger.Error(err)
@@ -296,7 +316,11 @@ func (e *BLSBFT) Start() error {
 							continue
 						}
 
-						if err := e.Chain.InsertAndBroadcastBlock(e.RoundData.Block); err != nil {
+						ctx, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), e.RoundData.TraceContext), "bft.Commit", "chain", e.ChainKey, "height", e.RoundData.NextHeight, "round", e.RoundData.Round, "votes", len(e.RoundData.Votes))
+						err = e.Chain.InsertAndBroadcastBlock(ctx, e.RoundData.Block)
+						span.SetError(err)
+						span.End()
+						if err != nil {
 							e.logger.Error(err)
 							if blockchainError, ok := err.(*blockchain.BlockChainError); ok {
 								if blockchainError.Code != blockchain.ErrCodeMessage[blockchain.DuplicateShardBlockError].Code {
@@ -306,6 +330,7 @@ func (e *BLSBFT) Start() error {
 							continue
 						}
 						monitor.SetGlobalParam("CommitTime", time.Since(time.Unix(e.Chain.GetLastBlockTimeStamp(), 0)).Seconds())
+						e.updatePhaseTimer("commit", time.Since(time.Unix(e.Chain.GetLastBlockTimeStamp(), 0)))
 						// e.Node.PushMessageToAll()
 						e.logger.Infof("Commit block (%d votes) %+v hash=%+v \n Wait for next round", len(e.RoundData.Votes), e.RoundData.Block.GetHeight(), e.RoundData.Block.Hash().String())
 						e.enterNewRound()
@@ -323,9 +348,13 @@ func (e *BLSBFT) enterProposePhase(keyset *signatureschemes2.MiningKey) {
 	}
 	e.setState(proposePhase)
 	e.isOngoing = true
-	block, err := e.createNewBlock(keyset)
+	ctx, span := tracing.StartSpan(context.Background(), "bft.ProposeBlock", "chain", e.ChainKey, "height", e.RoundData.NextHeight, "round", e.RoundData.Round)
+	defer span.End()
+	block, err := e.createNewBlock(ctx, keyset)
 	monitor.SetGlobalParam("CreateTime", time.Since(e.RoundData.TimeStart).Seconds())
+	e.updatePhaseTimer("createblock", time.Since(e.RoundData.TimeStart))
 	if err != nil {
+		span.SetError(err)
 		e.isOngoing = false
 		e.logger.Error("can't create block", err)
 		return
@@ -345,9 +374,10 @@ func (e *BLSBFT) enterProposePhase(keyset *signatureschemes2.MiningKey) {
 	e.RoundData.Block = block
 	e.RoundData.BlockHash = *block.Hash()
 	e.RoundData.BlockValidateData = validationData
+	e.RoundData.TraceContext = span.SpanContext()
 
 	blockData, _ := json.Marshal(e.RoundData.Block)
-	msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, keyset)
+	msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, keyset, span.SpanContext().String())
 	// e.logger.Info("push block", time.Since(time1).Seconds())
 	go e.Node.PushMessageToChain(msg, e.Chain)
 }
@@ -439,7 +469,7 @@ func (e *BLSBFT) addEarlyVote(voteMsg BFTVote) {
 	return
 }
 
-func (e *BLSBFT) createNewBlock(userKey *signatureschemes2.MiningKey) (common.BlockInterface, error) {
+func (e *BLSBFT) createNewBlock(ctx context.Context, userKey *signatureschemes2.MiningKey) (common.BlockInterface, error) {
 
 	var errCh chan error
 	var block common.BlockInterface = nil
@@ -458,7 +488,7 @@ func (e *BLSBFT) createNewBlock(userKey *signatureschemes2.MiningKey) (common.Bl
 			return
 		}
 
-		block, err = e.Chain.CreateNewBlock(1, base58Str, int(e.RoundData.Round), e.RoundData.TimeStart.Unix())
+		block, err = e.Chain.CreateNewBlock(ctx, 1, base58Str, int(e.RoundData.Round), e.RoundData.TimeStart.Unix())
 		if block != nil {
 			e.logger.Info("create block", block.GetHeight(), time.Since(time1).Seconds())
 		} else {
@@ -1,6 +1,8 @@
 package blsbft
 
 import (
+	"context"
+
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -32,11 +34,11 @@ type ChainInterface interface {
 	GetLastProposerIndex() int
 	UnmarshalBlock(blockString []byte) (common.BlockInterface, error)
 
-	InsertAndBroadcastBlock(block common.BlockInterface) error
-	CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error)
+	InsertAndBroadcastBlock(ctx context.Context, block common.BlockInterface) error
+	CreateNewBlock(ctx context.Context, version int, proposer string, round int, startTime int64) (common.BlockInterface, error)
 	// ValidateAndInsertBlock(block common.BlockInterface) error
 	ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
-	ValidatePreSignBlock(block common.BlockInterface) error
+	ValidatePreSignBlock(ctx context.Context, block common.BlockInterface) error
 	GetShardID() int
 
 	//for new syncker
@@ -1,9 +1,11 @@
 package blsbft
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -16,18 +18,21 @@ const (
 )
 
 type BFTPropose struct {
-	Block json.RawMessage
+	Block        json.RawMessage
+	TraceContext string `json:",omitempty"`
 }
 
 type BFTVote struct {
-	RoundKey  string
-	Validator string
-	Vote      vote
+	RoundKey     string
+	Validator    string
+	Vote         vote
+	TraceContext string `json:",omitempty"`
 }
 
-func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *signatureschemes.MiningKey) (wire.Message, error) {
+func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *signatureschemes.MiningKey, traceContext string) (wire.Message, error) {
 	var proposeCtn BFTPropose
 	proposeCtn.Block = block
+	proposeCtn.TraceContext = traceContext
 	proposeCtnBytes, err := json.Marshal(proposeCtn)
 	if err != nil {
 		return nil, NewConsensusError(UnExpectedError, err)
@@ -39,11 +44,12 @@ func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *signatureschem
 	return msg, nil
 }
 
-func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, vote vote) (wire.Message, error) {
+func MakeBFTVoteMsg(userPublicKey string, chainKey, roundKey string, vote vote, traceContext string) (wire.Message, error) {
 	var voteCtn BFTVote
 	voteCtn.RoundKey = roundKey
 	voteCtn.Validator = userPublicKey
 	voteCtn.Vote = vote
+	voteCtn.TraceContext = traceContext
 	voteCtnBytes, err := json.Marshal(voteCtn)
 	if err != nil {
 		return nil, NewConsensusError(UnExpectedError, err)
@@ -100,7 +106,12 @@ func (e *BLSBFT) preValidateVote(blockHash []byte, Vote *vote, candidate []byte)
 	return err
 }
 
-func (e *BLSBFT) sendVote() error {
+func (e *BLSBFT) sendVote() (err error) {
+	_, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), e.RoundData.TraceContext), "bft.SendVote", "chain", e.ChainKey, "height", e.RoundData.NextHeight, "round", e.RoundData.Round)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	var Vote vote
 	for _, userKey := range e.UserKeySet {
 		pubKey := userKey.GetPublicKey()
@@ -128,7 +139,7 @@ func (e *BLSBFT) sendVote() error {
 			}
 			key := userKey.GetPublicKey()
 
-			msg, err := MakeBFTVoteMsg(key.GetMiningKeyBase58(consensusName), e.ChainKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), Vote)
+			msg, err := MakeBFTVoteMsg(key.GetMiningKeyBase58(consensusName), e.ChainKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), Vote, span.SpanContext().String())
 			if err != nil {
 				return NewConsensusError(UnExpectedError, err)
 			}
@@ -1,8 +1,11 @@
 package blsbft
 
 import (
+	"context"
 	"fmt"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"reflect"
 	"strconv"
 	"strings"
@@ -94,6 +97,7 @@ func (e *BLSBFT) isHasMajorityVotes() bool {
 		}
 	}
 	monitor.SetGlobalParam("NVote", len(e.RoundData.Votes))
+	metrics.GetOrRegisterGauge(metrics.LabeledName(metrics.BFTVoteCount, "chain", e.ChainKey), nil).Update(int64(len(e.RoundData.Votes)))
 	if len(e.RoundData.Votes) > 2*committeeSize/3 {
 		return true
 	}
@@ -151,6 +155,7 @@ func (e *BLSBFT) InitRoundData() {
 	if _, ok := e.Blocks[roundKey]; ok {
 		delete(e.Blocks, roundKey)
 	}
+	delete(e.blockTraces, roundKey)
 	e.RoundData.NextHeight = e.Chain.CurrentHeight() + 1
 	e.RoundData.Round = e.getCurrentRound()
 	e.RoundData.Votes = make(map[string]vote)
@@ -159,6 +164,37 @@ func (e *BLSBFT) InitRoundData() {
 	e.RoundData.NotYetSendVote = true
 	e.RoundData.TimeStart = time.Now()
 	e.RoundData.LastProposerIndex = e.Chain.GetLastProposerIndex()
+	e.RoundData.TraceContext = tracing.SpanContext{}
 	e.UpdateCommitteeBLSList()
 	e.setState(newround)
 }
+
+// updatePhaseTimer records how long a consensus phase took, labeled with the chain and the phase
+func (e *BLSBFT) updatePhaseTimer(phase string, d time.Duration) {
+	metrics.GetOrRegisterTimer(metrics.LabeledName(metrics.BFTPhaseTime, "chain", e.ChainKey, "phase", phase), nil).Update(d)
+}
+
+// setBlockTrace keeps the trace of a proposed block so that validating, voting and committing it continue the trace
+func (e *BLSBFT) setBlockTrace(roundKey string, traceContext string) {
+	if traceContext == "" {
+		return
+	}
+	sc, err := tracing.ParseSpanContext(traceContext)
+	if err != nil {
+		e.logger.Debug(err)
+		return
+	}
+	e.blockTraces[roundKey] = sc
+}
+
+// startVoteSpan starts the span of validating a vote as a child of the span that sent it
+func (e *BLSBFT) startVoteSpan(voteMsg BFTVote) *tracing.Span {
+	ctx := context.Background()
+	if voteMsg.TraceContext != "" {
+		if sc, err := tracing.ParseSpanContext(voteMsg.TraceContext); err == nil {
+			ctx = tracing.ContextWithSpanContext(ctx, sc)
+		}
+	}
+	_, span := tracing.StartSpan(ctx, "bft.ValidateVote", "chain", e.ChainKey, "roundKey", voteMsg.RoundKey, "validator", voteMsg.Validator)
+	return span
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -51,7 +52,7 @@ func (s *Chain) UnmarshalBlock(blockString []byte) (common.BlockInterface, error
 	return blk, nil
 }
 
-func (c *Chain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+func (c *Chain) CreateNewBlock(ctx context.Context, version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
 	newBlock := NewBlock(c.GetBestView().GetHeight()+1, time.Now().Unix(), proposer, *c.GetBestView().GetHash())
 	return newBlock, nil
 }
@@ -64,7 +65,7 @@ func (c *Chain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterface, propo
 	return oldBlock, nil
 }
 
-func (s *Chain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+func (s *Chain) InsertAndBroadcastBlock(ctx context.Context, block common.BlockInterface) error {
 	state := &State{
 		block,
 		s.multiview.GetBestView().GetCommittee(),
@@ -73,7 +74,7 @@ func (s *Chain) InsertAndBroadcastBlock(block common.BlockInterface) error {
 	return nil
 }
 
-func (s *Chain) ValidatePreSignBlock(block common.BlockInterface) error {
+func (s *Chain) ValidatePreSignBlock(ctx context.Context, block common.BlockInterface) error {
 	return nil
 }
 
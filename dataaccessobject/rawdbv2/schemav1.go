@@ -19,6 +19,9 @@ var (
 	PDETradeStatusPrefix         = []byte("pdetradestatus-")
 	PDEWithdrawalStatusPrefix    = []byte("pdewithdrawalstatus-")
 	PDEFeeWithdrawalStatusPrefix = []byte("pdefeewithdrawalstatus-")
+	PDELimitOrderPrefix          = []byte("pdelimitorder-")
+	PDELimitOrderStatusPrefix    = []byte("pdelimitorderstatus-")
+	PDECancelOrderStatusPrefix   = []byte("pdecancellimitorderstatus-")
 )
 
 // TODO - change json to CamelCase
@@ -56,6 +59,49 @@ func NewPDEPoolForPair(token1IDStr string, token1PoolValue uint64, token2IDStr s
 	return &PDEPoolForPair{Token1IDStr: token1IDStr, Token1PoolValue: token1PoolValue, Token2IDStr: token2IDStr, Token2PoolValue: token2PoolValue}
 }
 
+// PDELimitOrder is a limit order resting on the pde, its limit price is MinAcceptableAmount / SellAmount
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	RemainingSellAmount uint64
+	ReceivedAmount      uint64
+	TradingFee          uint64 // trading fee not paid to the pool pair yet
+	ExpiryBeaconHeight  uint64
+	ShardID             byte
+}
+
+func NewPDELimitOrder(
+	orderID common.Hash,
+	traderAddressStr string,
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	remainingSellAmount uint64,
+	receivedAmount uint64,
+	tradingFee uint64,
+	expiryBeaconHeight uint64,
+	shardID byte,
+) *PDELimitOrder {
+	return &PDELimitOrder{
+		OrderID:             orderID,
+		TraderAddressStr:    traderAddressStr,
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		RemainingSellAmount: remainingSellAmount,
+		ReceivedAmount:      receivedAmount,
+		TradingFee:          tradingFee,
+		ExpiryBeaconHeight:  expiryBeaconHeight,
+		ShardID:             shardID,
+	}
+}
+
 func BuildPDESharesKey(
 	beaconHeight uint64,
 	token1IDStr string,
@@ -129,3 +175,12 @@ func BuildWaitingPDEContributionKey(
 	waitingPDEContribByBCHeightPrefix := append(WaitingPDEContributionPrefix, beaconHeightBytes...)
 	return append(waitingPDEContribByBCHeightPrefix, []byte(pairID)...)
 }
+
+func BuildPDELimitOrderKey(
+	beaconHeight uint64,
+	orderIDStr string,
+) []byte {
+	beaconHeightBytes := []byte(fmt.Sprintf("%d-", beaconHeight))
+	pdeLimitOrderByBCHeightPrefix := append(PDELimitOrderPrefix, beaconHeightBytes...)
+	return append(pdeLimitOrderByBCHeightPrefix, []byte(orderIDStr)...)
+}
@@ -185,3 +185,57 @@ func GetPDETradingFees(stateDB *StateDB, beaconHeight uint64) (map[string]uint64
 	}
 	return pdeTradingFees, nil
 }
+
+func StorePDELimitOrders(stateDB *StateDB, beaconHeight uint64, pdeLimitOrders map[string]*rawdbv2.PDELimitOrder) error {
+	for _, order := range pdeLimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID.String())
+		value := NewPDELimitOrderStateWithValue(
+			order.OrderID,
+			order.TraderAddressStr,
+			order.TokenIDToBuyStr,
+			order.TokenIDToSellStr,
+			order.SellAmount,
+			order.MinAcceptableAmount,
+			order.RemainingSellAmount,
+			order.ReceivedAmount,
+			order.TradingFee,
+			order.ExpiryBeaconHeight,
+			order.ShardID,
+		)
+		err := stateDB.SetStateObject(PDELimitOrderObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDELimitOrderError, err)
+		}
+	}
+	return nil
+}
+
+func GetPDELimitOrders(stateDB *StateDB, beaconHeight uint64) (map[string]*rawdbv2.PDELimitOrder, error) {
+	pdeLimitOrders := make(map[string]*rawdbv2.PDELimitOrder)
+	pdeLimitOrderStates := stateDB.getAllPDELimitOrderState()
+	for _, loState := range pdeLimitOrderStates {
+		key := string(GetPDELimitOrderKey(beaconHeight, loState.OrderID().String()))
+		value := rawdbv2.NewPDELimitOrder(
+			loState.OrderID(),
+			loState.TraderAddress(),
+			loState.TokenIDToBuy(),
+			loState.TokenIDToSell(),
+			loState.SellAmount(),
+			loState.MinAcceptableAmount(),
+			loState.RemainingSellAmount(),
+			loState.ReceivedAmount(),
+			loState.TradingFee(),
+			loState.ExpiryBeaconHeight(),
+			loState.ShardID(),
+		)
+		pdeLimitOrders[key] = value
+	}
+	return pdeLimitOrders, nil
+}
+
+func DeletePDELimitOrders(stateDB *StateDB, deletedPDELimitOrders map[string]*rawdbv2.PDELimitOrder) {
+	for _, order := range deletedPDELimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID.String())
+		stateDB.MarkDeleteStateObject(PDELimitOrderObjectType, key)
+	}
+}
@@ -2,6 +2,7 @@ package statedb
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 )
@@ -274,6 +275,60 @@ func StoreBulkFinalExchangeRatesState(
 	return nil
 }
 
+//======================  Exchange rate feeders  ======================
+func GetPortalFeederStates(
+	stateDB *StateDB,
+) (map[string]*PortalFeederState, error) {
+	return stateDB.getAllPortalFeederStates(), nil
+}
+
+func GetPortalFeederStateByAddress(stateDB *StateDB, feederAddress string) (*PortalFeederState, bool, error) {
+	key := GeneratePortalFeederStateObjectKey(feederAddress)
+	feederState, has, err := stateDB.getPortalFeederState(key)
+	if err != nil {
+		return nil, false, NewStatedbError(GetPortalFeederStateError, err)
+	}
+	return feederState, has, nil
+}
+
+func StorePortalFeederStates(
+	stateDB *StateDB,
+	feederStates map[string]*PortalFeederState) error {
+	for feederAddress, feederState := range feederStates {
+		key := GeneratePortalFeederStateObjectKey(feederAddress)
+		err := stateDB.SetStateObject(PortalFeederStateObjectType, key, feederState)
+		if err != nil {
+			return NewStatedbError(StorePortalFeederStateError, err)
+		}
+	}
+	return nil
+}
+
+// StorePortalFeederSubmission stores a submission of a feeder into one of the slots of its recent submissions,
+// the caller picks the slot so old submissions are overwritten
+func StorePortalFeederSubmission(stateDB *StateDB, feederAddress string, slot uint64, submission PortalFeederSubmission) error {
+	statusSuffix := []byte(fmt.Sprintf("%s-%d", feederAddress, slot))
+	content, err := json.Marshal(submission)
+	if err != nil {
+		return NewStatedbError(StorePortalStatusError, err)
+	}
+	return StorePortalStatus(stateDB, PortalFeederSubmissionStatusPrefix(), statusSuffix, content)
+}
+
+func GetPortalFeederSubmission(stateDB *StateDB, feederAddress string, slot uint64) (*PortalFeederSubmission, error) {
+	statusSuffix := []byte(fmt.Sprintf("%s-%d", feederAddress, slot))
+	content, err := GetPortalStatus(stateDB, PortalFeederSubmissionStatusPrefix(), statusSuffix)
+	if err != nil {
+		return nil, err
+	}
+	submission := new(PortalFeederSubmission)
+	err = json.Unmarshal(content, submission)
+	if err != nil {
+		return nil, NewStatedbError(GetPortalStatusError, err)
+	}
+	return submission, nil
+}
+
 //======================  Custodian unlock over rate collaterals  ======================
 func StoreBulkUnlockOverRateCollateralsState(
 	stateDB *StateDB,
@@ -52,6 +52,12 @@ const (
 	PortalExternalTxObjectType
 	PortalConfirmProofObjectType
 	PortalUnlockOverRateCollaterals
+
+	// PDEX limit orders
+	PDELimitOrderObjectType
+
+	// Portal exchange rate feeders
+	PortalFeederStateObjectType
 )
 
 // Prefix length
@@ -45,6 +45,8 @@ const (
 	ErrInvalidBlockHashType                      = "invalid block hash type"
 	ErrInvalidPortalExternalTxStateType          = "invalid portal external tx state type"
 	ErrInvalidPortalConfirmProofStateType        = "invalid portal confirm proof state type"
+	ErrInvalidPDELimitOrderStateType             = "invalid pde limit order state type"
+	ErrInvalidPortalFeederStateType              = "invalid portal feeder state type"
 )
 const (
 	InvalidByteArrayTypeError = iota
@@ -192,6 +194,13 @@ const (
 	GetWithdrawCollateralConfirmError
 	StorePortalUnlockOverRateCollateralsError
 	GetPortalUnlockOverRateCollateralsStatusError
+
+	// PDEX limit orders
+	StorePDELimitOrderError
+
+	// Portal exchange rate feeders
+	StorePortalFeederStateError
+	GetPortalFeederStateError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -255,6 +264,7 @@ var ErrCodeMessage = map[int]struct {
 	GetPDEPoolForPairError:           {-4003, "Get PDEX Pool Pair Error"},
 	TrackPDEStatusError:              {-4004, "Track PDEX Status Error"},
 	GetPDEStatusError:                {-4005, "Get PDEX Status Error"},
+	StorePDELimitOrderError:          {-4006, "Store PDEX Limit Order Error"},
 	// -5xxx: bridge error
 	BridgeInsertETHTxHashIssuedError: {-5000, "Bridge Insert ETH Tx Hash Issued Error"},
 	IsETHTxHashIssuedError:           {-5001, "Is ETH Tx Hash Issued Error"},
@@ -329,6 +339,9 @@ var ErrCodeMessage = map[int]struct {
 	// portal unlock over rate collaterals
 	StorePortalUnlockOverRateCollateralsError:     {-14048, "Store portal unlock over rate collaterals error"},
 	GetPortalUnlockOverRateCollateralsStatusError: {-14049, "Get portal unlock over rate collaterals error"},
+	// portal exchange rate feeders
+	StorePortalFeederStateError: {-14050, "Store portal feeder state error"},
+	GetPortalFeederStateError:   {-14051, "Get portal feeder state error"},
 	// feature reward
 	StoreRewardFeatureError:              {-15000, "Store reward feature state error"},
 	GetRewardFeatureError:                {-15001, "Get reward feature state error"},
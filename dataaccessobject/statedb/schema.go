@@ -37,6 +37,7 @@ var (
 	pdeTradeStatusPrefix               = []byte("pdetradestatus-")
 	pdeWithdrawalStatusPrefix          = []byte("pdewithdrawalstatus-")
 	pdeStatusPrefix                    = []byte("pdestatus-")
+	pdeLimitOrderPrefix                = []byte("pdelimitorder-")
 	bridgeEthTxPrefix                  = []byte("bri-eth-tx-")
 	bridgeCentralizedTokenInfoPrefix   = []byte("bri-cen-token-info-")
 	bridgeDecentralizedTokenInfoPrefix = []byte("bri-de-token-info-")
@@ -76,6 +77,7 @@ var (
 	portalRequestUnlockCollateralStatusPrefix    = []byte("requestunlockcollateral-")
 	portalRequestWithdrawRewardStatusPrefix      = []byte("requestwithdrawportalreward-")
 	portalReqMatchingRedeemStatusByTxReqIDPrefix = []byte("reqmatchredeembytxid-")
+	portalFeederSubmissionStatusPrefix           = []byte("portalfeedersubmission-")
 
 	// liquidation for portal
 	portalLiquidateCustodianRunAwayPrefix = []byte("portalliquidaterunaway-")
@@ -92,6 +94,7 @@ var (
 
 	portalExternalTxPrefix      = []byte("portalexttx-")
 	portalConfirmProofPrefix    = []byte("portalproof-")
+	portalFeederStatePrefix     = []byte("portalfeeder-")
 	withdrawCollateralProofType = []byte("0-")
 )
 
@@ -223,6 +226,11 @@ func GetPDEStatusPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPDELimitOrderPrefix() []byte {
+	h := common.HashH(pdeLimitOrderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetBridgeEthTxPrefix() []byte {
 	h := common.HashH(bridgeEthTxPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -303,6 +311,12 @@ func GetPDEStatusKey(prefix []byte, suffix []byte) []byte {
 	return append(prefix, suffix...)
 }
 
+// GetPDELimitOrderKey: PDELimitOrderPrefix + beacon height + order id
+func GetPDELimitOrderKey(beaconHeight uint64, orderID string) []byte {
+	prefix := append(pdeLimitOrderPrefix, []byte(fmt.Sprintf("%d-", beaconHeight))...)
+	return append(prefix, []byte(orderID)...)
+}
+
 // Portal
 func GetFinalExchangeRatesStatePrefix() []byte {
 	h := common.HashH(portalFinaExchangeRatesStatePrefix)
@@ -321,6 +335,10 @@ func PortalExchangeRatesRequestStatusPrefix() []byte {
 	return portalExchangeRatesRequestStatusPrefix
 }
 
+func PortalFeederSubmissionStatusPrefix() []byte {
+	return portalFeederSubmissionStatusPrefix
+}
+
 func PortalUnlockOverRateCollateralsRequestStatusPrefix() []byte {
 	return portalUnlockOverRateCollateralsRequestStatusPrefix
 }
@@ -413,6 +431,11 @@ func GetRewardFeatureStatePrefix(epoch uint64) []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPortalFeederStatePrefix() []byte {
+	h := common.HashH(portalFeederStatePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetPortalExternalTxPrefix() []byte {
 	h := common.HashH(portalExternalTxPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -1334,6 +1334,24 @@ func (stateDB *StateDB) getPDEStatusByKey(key common.Hash) (*PDEStatusState, boo
 	return NewPDEStatusState(), false, nil
 }
 
+func (stateDB *StateDB) getAllPDELimitOrderState() []*PDELimitOrderState {
+	pdeLimitOrderStates := []*PDELimitOrderState{}
+	temp := stateDB.trie.NodeIterator(GetPDELimitOrderPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		lo := NewPDELimitOrderState()
+		err := json.Unmarshal(newValue, lo)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdeLimitOrderStates = append(pdeLimitOrderStates, lo)
+	}
+	return pdeLimitOrderStates
+}
+
 // ================================= Bridge OBJECT =======================================
 func (stateDB *StateDB) getBridgeEthTxState(key common.Hash) (*BridgeEthTxState, bool, error) {
 	ethTxState, err := stateDB.getStateObject(BridgeEthTxObjectType, key)
@@ -1690,3 +1708,33 @@ func (stateDB *StateDB) getPortalConfirmProofState(key common.Hash) (*PortalConf
 	}
 	return NewPortalConfirmProofState(), false, nil
 }
+
+// ================================= Portal feeder OBJECT =======================================
+func (stateDB *StateDB) getPortalFeederState(key common.Hash) (*PortalFeederState, bool, error) {
+	portalFeederState, err := stateDB.getStateObject(PortalFeederStateObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if portalFeederState != nil {
+		return portalFeederState.GetValue().(*PortalFeederState), true, nil
+	}
+	return NewPortalFeederState(), false, nil
+}
+
+func (stateDB *StateDB) getAllPortalFeederStates() map[string]*PortalFeederState {
+	feederStates := make(map[string]*PortalFeederState)
+	temp := stateDB.trie.NodeIterator(GetPortalFeederStatePrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		feederState := NewPortalFeederState()
+		err := json.Unmarshal(newValue, feederState)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		feederStates[feederState.FeederAddress()] = feederState
+	}
+	return feederStates
+}
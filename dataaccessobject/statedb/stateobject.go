@@ -55,6 +55,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newPDETradingFeeObjectWithValue(db, hash, value)
 	case PDEStatusObjectType:
 		return newPDEStatusObjectWithValue(db, hash, value)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObjectWithValue(db, hash, value)
 	case BridgeEthTxObjectType:
 		return newBridgeEthTxObjectWithValue(db, hash, value)
 	case BridgeTokenInfoObjectType:
@@ -89,6 +91,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newPortalExternalTxObjectWithValue(db, hash, value)
 	case PortalConfirmProofObjectType:
 		return newPortalConfirmProofStateObjectWithValue(db, hash, value)
+	case PortalFeederStateObjectType:
+		return newPortalFeederStateObjectWithValue(db, hash, value)
 	case StakerObjectType:
 		return newStakerObjectWithValue(db, hash, value)
 	default:
@@ -128,6 +132,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newPDETradingFeeObject(db, hash)
 	case PDEStatusObjectType:
 		return newPDEStatusObject(db, hash)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObject(db, hash)
 	case BridgeEthTxObjectType:
 		return newBridgeEthTxObject(db, hash)
 	case BridgeTokenInfoObjectType:
@@ -160,6 +166,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newPortalExternalTxObject(db, hash)
 	case PortalConfirmProofObjectType:
 		return newPortalConfirmProofStateObject(db, hash)
+	case PortalFeederStateObjectType:
+		return newPortalFeederStateObject(db, hash)
 	case StakerObjectType:
 		return newStakerObject(db, hash)
 	default:
@@ -0,0 +1,337 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+type PDELimitOrderState struct {
+	orderID             common.Hash
+	traderAddress       string
+	tokenIDToBuy        string
+	tokenIDToSell       string
+	sellAmount          uint64
+	minAcceptableAmount uint64
+	remainingSellAmount uint64
+	receivedAmount      uint64
+	tradingFee          uint64
+	expiryBeaconHeight  uint64
+	shardID             byte
+}
+
+func (lo PDELimitOrderState) OrderID() common.Hash {
+	return lo.orderID
+}
+
+func (lo *PDELimitOrderState) SetOrderID(orderID common.Hash) {
+	lo.orderID = orderID
+}
+
+func (lo PDELimitOrderState) TraderAddress() string {
+	return lo.traderAddress
+}
+
+func (lo *PDELimitOrderState) SetTraderAddress(traderAddress string) {
+	lo.traderAddress = traderAddress
+}
+
+func (lo PDELimitOrderState) TokenIDToBuy() string {
+	return lo.tokenIDToBuy
+}
+
+func (lo *PDELimitOrderState) SetTokenIDToBuy(tokenIDToBuy string) {
+	lo.tokenIDToBuy = tokenIDToBuy
+}
+
+func (lo PDELimitOrderState) TokenIDToSell() string {
+	return lo.tokenIDToSell
+}
+
+func (lo *PDELimitOrderState) SetTokenIDToSell(tokenIDToSell string) {
+	lo.tokenIDToSell = tokenIDToSell
+}
+
+func (lo PDELimitOrderState) SellAmount() uint64 {
+	return lo.sellAmount
+}
+
+func (lo *PDELimitOrderState) SetSellAmount(sellAmount uint64) {
+	lo.sellAmount = sellAmount
+}
+
+func (lo PDELimitOrderState) MinAcceptableAmount() uint64 {
+	return lo.minAcceptableAmount
+}
+
+func (lo *PDELimitOrderState) SetMinAcceptableAmount(minAcceptableAmount uint64) {
+	lo.minAcceptableAmount = minAcceptableAmount
+}
+
+func (lo PDELimitOrderState) RemainingSellAmount() uint64 {
+	return lo.remainingSellAmount
+}
+
+func (lo *PDELimitOrderState) SetRemainingSellAmount(remainingSellAmount uint64) {
+	lo.remainingSellAmount = remainingSellAmount
+}
+
+func (lo PDELimitOrderState) ReceivedAmount() uint64 {
+	return lo.receivedAmount
+}
+
+func (lo *PDELimitOrderState) SetReceivedAmount(receivedAmount uint64) {
+	lo.receivedAmount = receivedAmount
+}
+
+func (lo PDELimitOrderState) TradingFee() uint64 {
+	return lo.tradingFee
+}
+
+func (lo *PDELimitOrderState) SetTradingFee(tradingFee uint64) {
+	lo.tradingFee = tradingFee
+}
+
+func (lo PDELimitOrderState) ExpiryBeaconHeight() uint64 {
+	return lo.expiryBeaconHeight
+}
+
+func (lo *PDELimitOrderState) SetExpiryBeaconHeight(expiryBeaconHeight uint64) {
+	lo.expiryBeaconHeight = expiryBeaconHeight
+}
+
+func (lo PDELimitOrderState) ShardID() byte {
+	return lo.shardID
+}
+
+func (lo *PDELimitOrderState) SetShardID(shardID byte) {
+	lo.shardID = shardID
+}
+
+func (lo PDELimitOrderState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		OrderID             common.Hash
+		TraderAddress       string
+		TokenIDToBuy        string
+		TokenIDToSell       string
+		SellAmount          uint64
+		MinAcceptableAmount uint64
+		RemainingSellAmount uint64
+		ReceivedAmount      uint64
+		TradingFee          uint64
+		ExpiryBeaconHeight  uint64
+		ShardID             byte
+	}{
+		OrderID:             lo.orderID,
+		TraderAddress:       lo.traderAddress,
+		TokenIDToBuy:        lo.tokenIDToBuy,
+		TokenIDToSell:       lo.tokenIDToSell,
+		SellAmount:          lo.sellAmount,
+		MinAcceptableAmount: lo.minAcceptableAmount,
+		RemainingSellAmount: lo.remainingSellAmount,
+		ReceivedAmount:      lo.receivedAmount,
+		TradingFee:          lo.tradingFee,
+		ExpiryBeaconHeight:  lo.expiryBeaconHeight,
+		ShardID:             lo.shardID,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (lo *PDELimitOrderState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		OrderID             common.Hash
+		TraderAddress       string
+		TokenIDToBuy        string
+		TokenIDToSell       string
+		SellAmount          uint64
+		MinAcceptableAmount uint64
+		RemainingSellAmount uint64
+		ReceivedAmount      uint64
+		TradingFee          uint64
+		ExpiryBeaconHeight  uint64
+		ShardID             byte
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	lo.orderID = temp.OrderID
+	lo.traderAddress = temp.TraderAddress
+	lo.tokenIDToBuy = temp.TokenIDToBuy
+	lo.tokenIDToSell = temp.TokenIDToSell
+	lo.sellAmount = temp.SellAmount
+	lo.minAcceptableAmount = temp.MinAcceptableAmount
+	lo.remainingSellAmount = temp.RemainingSellAmount
+	lo.receivedAmount = temp.ReceivedAmount
+	lo.tradingFee = temp.TradingFee
+	lo.expiryBeaconHeight = temp.ExpiryBeaconHeight
+	lo.shardID = temp.ShardID
+	return nil
+}
+
+func NewPDELimitOrderState() *PDELimitOrderState {
+	return &PDELimitOrderState{}
+}
+
+func NewPDELimitOrderStateWithValue(
+	orderID common.Hash,
+	traderAddress string,
+	tokenIDToBuy string,
+	tokenIDToSell string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	remainingSellAmount uint64,
+	receivedAmount uint64,
+	tradingFee uint64,
+	expiryBeaconHeight uint64,
+	shardID byte,
+) *PDELimitOrderState {
+	return &PDELimitOrderState{
+		orderID:             orderID,
+		traderAddress:       traderAddress,
+		tokenIDToBuy:        tokenIDToBuy,
+		tokenIDToSell:       tokenIDToSell,
+		sellAmount:          sellAmount,
+		minAcceptableAmount: minAcceptableAmount,
+		remainingSellAmount: remainingSellAmount,
+		receivedAmount:      receivedAmount,
+		tradingFee:          tradingFee,
+		expiryBeaconHeight:  expiryBeaconHeight,
+		shardID:             shardID,
+	}
+}
+
+type PDELimitOrderObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version            int
+	pdeLimitOrderHash  common.Hash
+	pdeLimitOrderState *PDELimitOrderState
+	objectType         int
+	deleted            bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPDELimitOrderObject(db *StateDB, hash common.Hash) *PDELimitOrderObject {
+	return &PDELimitOrderObject{
+		version:            defaultVersion,
+		db:                 db,
+		pdeLimitOrderHash:  hash,
+		pdeLimitOrderState: NewPDELimitOrderState(),
+		objectType:         PDELimitOrderObjectType,
+		deleted:            false,
+	}
+}
+
+func newPDELimitOrderObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PDELimitOrderObject, error) {
+	var newPDELimitOrderState = NewPDELimitOrderState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPDELimitOrderState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPDELimitOrderState, ok = data.(*PDELimitOrderState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPDELimitOrderStateType, reflect.TypeOf(data))
+		}
+	}
+	return &PDELimitOrderObject{
+		version:            defaultVersion,
+		pdeLimitOrderHash:  key,
+		pdeLimitOrderState: newPDELimitOrderState,
+		db:                 db,
+		objectType:         PDELimitOrderObjectType,
+		deleted:            false,
+	}, nil
+}
+
+func GeneratePDELimitOrderObjectKey(orderID string) common.Hash {
+	prefixHash := GetPDELimitOrderPrefix()
+	valueHash := common.HashH([]byte(orderID))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t PDELimitOrderObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PDELimitOrderObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PDELimitOrderObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PDELimitOrderObject) SetValue(data interface{}) error {
+	newPDELimitOrderState, ok := data.(*PDELimitOrderState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPDELimitOrderStateType, reflect.TypeOf(data))
+	}
+	t.pdeLimitOrderState = newPDELimitOrderState
+	return nil
+}
+
+func (t PDELimitOrderObject) GetValue() interface{} {
+	return t.pdeLimitOrderState
+}
+
+func (t PDELimitOrderObject) GetValueBytes() []byte {
+	pdeLimitOrderState, ok := t.GetValue().(*PDELimitOrderState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(pdeLimitOrderState)
+	if err != nil {
+		panic("failed to marshal pde limit order state")
+	}
+	return value
+}
+
+func (t PDELimitOrderObject) GetHash() common.Hash {
+	return t.pdeLimitOrderHash
+}
+
+func (t PDELimitOrderObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PDELimitOrderObject) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all limit order value into default value
+func (t *PDELimitOrderObject) Reset() bool {
+	t.pdeLimitOrderState = NewPDELimitOrderState()
+	return true
+}
+
+func (t PDELimitOrderObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PDELimitOrderObject) IsEmpty() bool {
+	temp := NewPDELimitOrderState()
+	return reflect.DeepEqual(temp, t.pdeLimitOrderState) || t.pdeLimitOrderState == nil
+}
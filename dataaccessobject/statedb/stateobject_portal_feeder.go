@@ -0,0 +1,276 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PortalFeederRate is the latest accepted rate of a feeder for one token
+type PortalFeederRate struct {
+	Rate         uint64
+	BeaconHeight uint64
+}
+
+// PortalFeederSubmission is one exchange rates request of a feeder,
+// rates are keyed by token id. Submissions are stored apart from the feeder state
+// so a new one does not rewrite the history of the feeder
+type PortalFeederSubmission struct {
+	TxReqID       common.Hash
+	BeaconHeight  uint64
+	AcceptedRates map[string]uint64
+	RejectedRates map[string]uint64
+}
+
+type PortalFeederState struct {
+	feederAddress      string
+	latestRates        map[string]PortalFeederRate
+	totalSubmissions   uint64
+	totalAcceptedRates uint64
+	totalRejectedRates uint64
+}
+
+func (f PortalFeederState) FeederAddress() string {
+	return f.feederAddress
+}
+
+func (f *PortalFeederState) SetFeederAddress(feederAddress string) {
+	f.feederAddress = feederAddress
+}
+
+func (f PortalFeederState) LatestRates() map[string]PortalFeederRate {
+	return f.latestRates
+}
+
+func (f *PortalFeederState) SetLatestRates(latestRates map[string]PortalFeederRate) {
+	f.latestRates = latestRates
+}
+
+func (f PortalFeederState) TotalSubmissions() uint64 {
+	return f.totalSubmissions
+}
+
+func (f *PortalFeederState) SetTotalSubmissions(totalSubmissions uint64) {
+	f.totalSubmissions = totalSubmissions
+}
+
+func (f PortalFeederState) TotalAcceptedRates() uint64 {
+	return f.totalAcceptedRates
+}
+
+func (f *PortalFeederState) SetTotalAcceptedRates(totalAcceptedRates uint64) {
+	f.totalAcceptedRates = totalAcceptedRates
+}
+
+func (f PortalFeederState) TotalRejectedRates() uint64 {
+	return f.totalRejectedRates
+}
+
+func (f *PortalFeederState) SetTotalRejectedRates(totalRejectedRates uint64) {
+	f.totalRejectedRates = totalRejectedRates
+}
+
+// AddSubmission counts a submission of the feeder and updates its latest rates by the accepted ones
+func (f *PortalFeederState) AddSubmission(submission PortalFeederSubmission) {
+	if f.latestRates == nil {
+		f.latestRates = make(map[string]PortalFeederRate)
+	}
+	for tokenID, rate := range submission.AcceptedRates {
+		f.latestRates[tokenID] = PortalFeederRate{Rate: rate, BeaconHeight: submission.BeaconHeight}
+	}
+	f.totalSubmissions++
+	f.totalAcceptedRates += uint64(len(submission.AcceptedRates))
+	f.totalRejectedRates += uint64(len(submission.RejectedRates))
+}
+
+func NewPortalFeederState() *PortalFeederState {
+	return &PortalFeederState{}
+}
+
+func NewPortalFeederStateWithValue(
+	feederAddress string,
+	latestRates map[string]PortalFeederRate,
+	totalSubmissions uint64,
+	totalAcceptedRates uint64,
+	totalRejectedRates uint64,
+) *PortalFeederState {
+	return &PortalFeederState{
+		feederAddress:      feederAddress,
+		latestRates:        latestRates,
+		totalSubmissions:   totalSubmissions,
+		totalAcceptedRates: totalAcceptedRates,
+		totalRejectedRates: totalRejectedRates,
+	}
+}
+
+func GeneratePortalFeederStateObjectKey(feederAddress string) common.Hash {
+	prefixHash := GetPortalFeederStatePrefix()
+	valueHash := common.HashH([]byte(feederAddress))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (f PortalFeederState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		FeederAddress      string
+		LatestRates        map[string]PortalFeederRate
+		TotalSubmissions   uint64
+		TotalAcceptedRates uint64
+		TotalRejectedRates uint64
+	}{
+		FeederAddress:      f.feederAddress,
+		LatestRates:        f.latestRates,
+		TotalSubmissions:   f.totalSubmissions,
+		TotalAcceptedRates: f.totalAcceptedRates,
+		TotalRejectedRates: f.totalRejectedRates,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (f *PortalFeederState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		FeederAddress      string
+		LatestRates        map[string]PortalFeederRate
+		TotalSubmissions   uint64
+		TotalAcceptedRates uint64
+		TotalRejectedRates uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	f.feederAddress = temp.FeederAddress
+	f.latestRates = temp.LatestRates
+	f.totalSubmissions = temp.TotalSubmissions
+	f.totalAcceptedRates = temp.TotalAcceptedRates
+	f.totalRejectedRates = temp.TotalRejectedRates
+	return nil
+}
+
+type PortalFeederStateObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version               int
+	portalFeederStateHash common.Hash
+	portalFeederState     *PortalFeederState
+	objectType            int
+	deleted               bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPortalFeederStateObjectWithValue(db *StateDB, portalFeederStateHash common.Hash, data interface{}) (*PortalFeederStateObject, error) {
+	var newPortalFeederState = NewPortalFeederState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPortalFeederState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPortalFeederState, ok = data.(*PortalFeederState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPortalFeederStateType, reflect.TypeOf(data))
+		}
+	}
+	return &PortalFeederStateObject{
+		db:                    db,
+		version:               defaultVersion,
+		portalFeederStateHash: portalFeederStateHash,
+		portalFeederState:     newPortalFeederState,
+		objectType:            PortalFeederStateObjectType,
+		deleted:               false,
+	}, nil
+}
+
+func newPortalFeederStateObject(db *StateDB, portalFeederStateHash common.Hash) *PortalFeederStateObject {
+	return &PortalFeederStateObject{
+		db:                    db,
+		version:               defaultVersion,
+		portalFeederStateHash: portalFeederStateHash,
+		portalFeederState:     NewPortalFeederState(),
+		objectType:            PortalFeederStateObjectType,
+		deleted:               false,
+	}
+}
+
+func (f PortalFeederStateObject) GetVersion() int {
+	return f.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (f *PortalFeederStateObject) SetError(err error) {
+	if f.dbErr == nil {
+		f.dbErr = err
+	}
+}
+
+func (f PortalFeederStateObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return f.trie
+}
+
+func (f *PortalFeederStateObject) SetValue(data interface{}) error {
+	portalFeederState, ok := data.(*PortalFeederState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPortalFeederStateType, reflect.TypeOf(data))
+	}
+	f.portalFeederState = portalFeederState
+	return nil
+}
+
+func (f PortalFeederStateObject) GetValue() interface{} {
+	return f.portalFeederState
+}
+
+func (f PortalFeederStateObject) GetValueBytes() []byte {
+	portalFeederState, ok := f.GetValue().(*PortalFeederState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(portalFeederState)
+	if err != nil {
+		panic("failed to marshal PortalFeederState")
+	}
+	return value
+}
+
+func (f PortalFeederStateObject) GetHash() common.Hash {
+	return f.portalFeederStateHash
+}
+
+func (f PortalFeederStateObject) GetType() int {
+	return f.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (f *PortalFeederStateObject) MarkDelete() {
+	f.deleted = true
+}
+
+// reset all shard committee value into default value
+func (f *PortalFeederStateObject) Reset() bool {
+	f.portalFeederState = NewPortalFeederState()
+	return true
+}
+
+func (f PortalFeederStateObject) IsDeleted() bool {
+	return f.deleted
+}
+
+// value is either default or nil
+func (f PortalFeederStateObject) IsEmpty() bool {
+	temp := NewPortalFeederState()
+	return reflect.DeepEqual(temp, f.portalFeederState) || f.portalFeederState == nil
+}
@@ -0,0 +1,112 @@
+package statedb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestPortalFeederState_AddSubmission(t *testing.T) {
+	feederState := NewPortalFeederState()
+	feederState.SetFeederAddress("feeder")
+	feederState.AddSubmission(PortalFeederSubmission{
+		TxReqID:       common.HashH([]byte("tx-1")),
+		BeaconHeight:  10,
+		AcceptedRates: map[string]uint64{"token-1": 100, "token-2": 200},
+	})
+	feederState.AddSubmission(PortalFeederSubmission{
+		TxReqID:       common.HashH([]byte("tx-2")),
+		BeaconHeight:  12,
+		AcceptedRates: map[string]uint64{"token-1": 110},
+		RejectedRates: map[string]uint64{"token-2": 900},
+	})
+
+	wantLatestRates := map[string]PortalFeederRate{
+		"token-1": {Rate: 110, BeaconHeight: 12},
+		// a rejected rate does not replace the latest accepted one
+		"token-2": {Rate: 200, BeaconHeight: 10},
+	}
+	if !reflect.DeepEqual(wantLatestRates, feederState.LatestRates()) {
+		t.Fatalf("want latest rates %+v but got %+v", wantLatestRates, feederState.LatestRates())
+	}
+	if feederState.TotalSubmissions() != 2 || feederState.TotalAcceptedRates() != 3 || feederState.TotalRejectedRates() != 1 {
+		t.Fatalf("wrong counters %+v", feederState)
+	}
+}
+
+func TestPortalFeederStateObject_Value(t *testing.T) {
+	feederState := NewPortalFeederStateWithValue("feeder", map[string]PortalFeederRate{"token-1": {Rate: 100, BeaconHeight: 10}}, 1, 1, 0)
+	key := GeneratePortalFeederStateObjectKey("feeder")
+	stateObject, err := newPortalFeederStateObjectWithValue(nil, key, feederState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBytes, err := newPortalFeederStateObjectWithValue(nil, key, stateObject.GetValueBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(feederState, fromBytes.GetValue()) {
+		t.Fatalf("want %+v but got %+v", feederState, fromBytes.GetValue())
+	}
+	if _, err := newPortalFeederStateObjectWithValue(nil, key, "feeder"); err == nil {
+		t.Fatal("want error of wrong value type")
+	}
+	if stateObject.IsEmpty() || !newPortalFeederStateObject(nil, key).IsEmpty() {
+		t.Fatal("wrong empty state")
+	}
+}
+
+func TestStateDB_StoreAndGetPortalFeeders(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, warperDBStatedbTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feederStates := map[string]*PortalFeederState{
+		"feeder-1": NewPortalFeederStateWithValue("feeder-1", map[string]PortalFeederRate{"token-1": {Rate: 100, BeaconHeight: 10}}, 1, 1, 0),
+		"feeder-2": NewPortalFeederStateWithValue("feeder-2", map[string]PortalFeederRate{"token-1": {Rate: 120, BeaconHeight: 11}}, 2, 1, 1),
+	}
+	if err := StorePortalFeederStates(sDB, feederStates); err != nil {
+		t.Fatal(err)
+	}
+	submission := PortalFeederSubmission{
+		TxReqID:       common.HashH([]byte("tx-1")),
+		BeaconHeight:  11,
+		AcceptedRates: map[string]uint64{"token-1": 120},
+		RejectedRates: map[string]uint64{"token-2": 900},
+	}
+	if err := StorePortalFeederSubmission(sDB, "feeder-2", 1, submission); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sDB.Database().TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+
+	tempStateDB, err := NewWithPrefixTrie(rootHash, warperDBStatedbTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotFeederStates, err := GetPortalFeederStates(tempStateDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(feederStates, gotFeederStates) {
+		t.Fatalf("want %+v but got %+v", feederStates, gotFeederStates)
+	}
+	gotFeederState, has, err := GetPortalFeederStateByAddress(tempStateDB, "feeder-1")
+	if err != nil || !has || !reflect.DeepEqual(feederStates["feeder-1"], gotFeederState) {
+		t.Fatalf("want %+v but got %+v, %v, %v", feederStates["feeder-1"], gotFeederState, has, err)
+	}
+	gotSubmission, err := GetPortalFeederSubmission(tempStateDB, "feeder-2", 1)
+	if err != nil || !reflect.DeepEqual(submission, *gotSubmission) {
+		t.Fatalf("want %+v but got %+v, %v", submission, gotSubmission, err)
+	}
+	// slots are per feeder
+	if _, err := GetPortalFeederSubmission(tempStateDB, "feeder-1", 1); err == nil {
+		t.Fatal("want not found error")
+	}
+}
@@ -43,13 +43,7 @@ func OpenMultipleDB(typ string, dbPath string) (map[int]Database, error) {
 		return nil, errors.Wrapf(errors.New("Driver is not registered"), typ)
 	}
 	for i := -1; i < common.MaxShardNumber; i++ {
-		newPath := ""
-		if i == common.BeaconChainDataBaseID {
-			newPath = path.Join(dbPath, common.BeaconChainDatabaseDirectory)
-		} else {
-			newPath = path.Join(dbPath, common.ShardChainDatabaseDirectory+strconv.Itoa(i))
-		}
-		db, err := d.Open(newPath)
+		db, err := d.Open(GetChainDBPath(dbPath, i))
 		if err != nil {
 			return nil, errors.WithStack(fmt.Errorf("Open database error %+v", err))
 		}
@@ -57,3 +51,11 @@ func OpenMultipleDB(typ string, dbPath string) (map[int]Database, error) {
 	}
 	return m, nil
 }
+
+// GetChainDBPath returns the path of the database of chain chainID among the databases opened by OpenMultipleDB
+func GetChainDBPath(dbPath string, chainID int) string {
+	if chainID == common.BeaconChainDataBaseID {
+		return path.Join(dbPath, common.BeaconChainDatabaseDirectory)
+	}
+	return path.Join(dbPath, common.ShardChainDatabaseDirectory+strconv.Itoa(chainID))
+}
@@ -39,18 +39,27 @@ func init() {
 	}
 }
 
+// openDriver opens the database at the path given as first argument,
+// an optional second bool argument opens it read-only
 func openDriver(args ...interface{}) (incdb.Database, error) {
-	if len(args) != 1 {
+	if len(args) != 1 && len(args) != 2 {
 		return nil, errors.New("invalid arguments")
 	}
 	dbPath, ok := args[0].(string)
 	if !ok {
 		return nil, errors.New("expected db path")
 	}
-	return open(dbPath)
+	readOnly := false
+	if len(args) == 2 {
+		readOnly, ok = args[1].(bool)
+		if !ok {
+			return nil, errors.New("expected read only flag")
+		}
+	}
+	return open(dbPath, readOnly)
 }
 
-func open(dbPath string) (incdb.Database, error) {
+func open(dbPath string, readOnly bool) (incdb.Database, error) {
 	handles := 256
 	cache := 8
 	lvdb, err := leveldb.OpenFile(dbPath, &opt.Options{
@@ -58,8 +67,11 @@ func open(dbPath string) (incdb.Database, error) {
 		BlockCacheCapacity:     cache / 2 * opt.MiB,
 		WriteBuffer:            cache / 4 * opt.MiB, // Two of these are used internally
 		Filter:                 filter.NewBloomFilter(10),
+		ReadOnly:               readOnly,
+		ErrorIfMissing:         readOnly,
 	})
-	if _, corrupted := err.(*lvdbErrors.ErrCorrupted); corrupted {
+	// a read only database is never repaired, corruption is reported to the caller
+	if _, corrupted := err.(*lvdbErrors.ErrCorrupted); corrupted && !readOnly {
 		lvdb, err = leveldb.RecoverFile(dbPath, nil)
 	}
 	if err != nil {
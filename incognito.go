@@ -14,7 +14,6 @@ import (
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 
-	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/databasemp"
@@ -23,6 +22,8 @@ import (
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -36,20 +37,14 @@ var (
 var winServiceMain func() (bool, error)
 
 func getBTCRelayingChain(btcRelayingChainID string, btcDataFolderName string) (*btcrelaying.BlockChain, error) {
-	relayingChainParams := map[string]*chaincfg.Params{
-		blockchain.TestnetBTCChainID:  btcrelaying.GetTestNet3Params(),
-		blockchain.Testnet2BTCChainID: btcrelaying.GetTestNet3ParamsForInc2(),
-		blockchain.MainnetBTCChainID:  btcrelaying.GetMainNetParams(),
-	}
-	relayingChainGenesisBlkHeight := map[string]int32{
-		blockchain.TestnetBTCChainID:  int32(1896910),
-		blockchain.Testnet2BTCChainID: int32(1863675),
-		blockchain.MainnetBTCChainID:  int32(634140),
+	relayingChainParams, genesisBlkHeight, err := blockchain.GetBTCRelayingChainParams(btcRelayingChainID)
+	if err != nil {
+		return nil, err
 	}
 	return btcrelaying.GetChainV2(
 		filepath.Join(cfg.DataDir, btcDataFolderName),
-		relayingChainParams[btcRelayingChainID],
-		relayingChainGenesisBlkHeight[btcRelayingChainID],
+		relayingChainParams,
+		genesisBlkHeight,
 	)
 }
 
@@ -66,6 +61,42 @@ func getBNBRelayingChainState(bnbRelayingChainID string) (*bnbrelaying.BNBChainS
 	return bnbChainState, nil
 }
 
+func getETHRelayingChain(ethRelayingChainID string) (*ethrelaying.HeaderChain, error) {
+	config, err := blockchain.GetETHRelayingChainConfig(ethRelayingChainID)
+	if err != nil {
+		return nil, err
+	}
+	db, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "ethrelaying"))
+	if err != nil {
+		return nil, err
+	}
+	return ethrelaying.NewHeaderChain(config, db, nil)
+}
+
+// getLTCRelayingChainConfig returns the config of the header chain of an LTC network
+func getLTCRelayingChainConfig(ltcRelayingChainID string) (*ltcrelaying.HeaderChainConfig, error) {
+	relayingChainConfigs := map[string]*ltcrelaying.HeaderChainConfig{
+		blockchain.TestnetLTCChainID: ltcrelaying.GetTestNet4Config(), // testnet-2 relays testnet4 too
+	}
+	config, ok := relayingChainConfigs[ltcRelayingChainID]
+	if !ok {
+		return nil, fmt.Errorf("LTC relaying chain %v is not supported", ltcRelayingChainID)
+	}
+	return config, nil
+}
+
+func getLTCRelayingChain(ltcRelayingChainID string) (*ltcrelaying.HeaderChain, error) {
+	config, err := getLTCRelayingChainConfig(ltcRelayingChainID)
+	if err != nil {
+		return nil, err
+	}
+	db, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "ltcrelaying"))
+	if err != nil {
+		return nil, err
+	}
+	return ltcrelaying.NewHeaderChain(config, db)
+}
+
 // mainMaster is the real main function for Incognito network.  It is necessary to work around
 // the fact that deferred functions do not run when os.Exit() is called.  The
 // optional serverChan parameter is mainly used by the service code to be
@@ -94,6 +125,12 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Show version at startup.
 	version := version()
 	Logger.log.Infof("Version %s", version)
+	paramsHash, err := activeNetParams.Hash()
+	if err != nil {
+		Logger.log.Error(err)
+		return err
+	}
+	Logger.log.Infof("Chain params of network %v, hash %v", activeNetParams.Name, paramsHash.String())
 	// Return now if an interrupt signal was triggered.
 	if interruptRequested(interrupt) {
 		return nil
@@ -165,6 +202,40 @@ func mainMaster(serverChan chan<- *Server) error {
 		panic(err)
 	}
 
+	// Create ethrelaying header chain
+	ethChain, err := getETHRelayingChain(activeNetParams.Params.ETHRelayingHeaderChainID)
+	if err != nil {
+		Logger.log.Error("could not get or create eth relaying chain")
+		Logger.log.Error(err)
+		panic(err)
+	}
+	defer func() {
+		Logger.log.Warn("Gracefully shutting down the eth database...")
+		db := ethChain.GetDB()
+		db.Close()
+	}()
+
+	// Create the relaying chains of portal tokens of external chains
+	var ltcChain *ltcrelaying.HeaderChain
+	ltcRelayingChainID, err := activeNetParams.Params.GetLTCRelayingChainID()
+	if err != nil {
+		Logger.log.Error(err)
+		return err
+	}
+	if ltcRelayingChainID != "" {
+		ltcChain, err = getLTCRelayingChain(ltcRelayingChainID)
+		if err != nil {
+			Logger.log.Error("could not get or create ltc relaying chain")
+			Logger.log.Error(err)
+			panic(err)
+		}
+		defer func() {
+			Logger.log.Warn("Gracefully shutting down the ltc database...")
+			db := ltcChain.GetDB()
+			db.Close()
+		}()
+	}
+
 	//update preload address
 	if cfg.PreloadAddress != "" {
 		activeNetParams.Params.PreloadAddress = cfg.PreloadAddress
@@ -174,7 +245,7 @@ func mainMaster(serverChan chan<- *Server) error {
 	server := Server{}
 	server.wallet = walletObj
 	activeNetParams.Params.IsBackup = cfg.ForceBackup
-	err = server.NewServer(cfg.Listener, db, dbmp, activeNetParams.Params, version, btcChain, bnbChainState, interrupt)
+	err = server.NewServer(cfg.Listener, db, dbmp, activeNetParams.Params, version, btcChain, bnbChainState, ethChain, ltcChain, interrupt)
 	if err != nil {
 		Logger.log.Errorf("Unable to start server on %+v", cfg.Listener)
 		Logger.log.Error(err)
@@ -8,12 +8,15 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject"
 	relaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcRelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethRelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+	ltcRelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
 
 	"github.com/incognitochain/incognito-chain/syncker"
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	main2 "github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/blockexporter"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
@@ -22,14 +25,18 @@ import (
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/netsync"
+	"github.com/incognitochain/incognito-chain/pdeindexer"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/peerv2"
 	"github.com/incognitochain/incognito-chain/peerv2/wrapper"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rewardindexer"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/trie"
+	"github.com/incognitochain/incognito-chain/validatorindexer"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/jrick/logrotate/rotator"
 )
@@ -65,6 +72,13 @@ var (
 	wrapperLogger          = backendLog.Logger("Wrapper log", false)
 	daov2Logger            = backendLog.Logger("DAO log", false)
 	btcRelayingLogger      = backendLog.Logger("BTC relaying log", false)
+	ethRelayingLogger      = backendLog.Logger("ETH relaying log", false)
+	ltcRelayingLogger      = backendLog.Logger("LTC relaying log", false)
+	pdeIndexerLogger       = backendLog.Logger("PDE indexer log", false)
+	blockExporterLogger    = backendLog.Logger("Block exporter log", false)
+	validatorIndexerLogger = backendLog.Logger("Validator indexer log", false)
+	rewardIndexerLogger    = backendLog.Logger("Reward indexer log", false)
+	tracingLogger          = backendLog.Logger("Tracing log", false)
 	synckerLogger          = backendLog.Logger("Syncker log ", false)
 )
 
@@ -108,6 +122,13 @@ func init() {
 	wrapper.Logger.Init(wrapperLogger)
 	dataaccessobject.Logger.Init(daov2Logger)
 	btcRelaying.Logger.Init(btcRelayingLogger)
+	ethRelaying.Logger.Init(ethRelayingLogger)
+	ltcRelaying.Logger.Init(ltcRelayingLogger)
+	pdeindexer.Logger.Init(pdeIndexerLogger)
+	blockexporter.Logger.Init(blockExporterLogger)
+	validatorindexer.Logger.Init(validatorIndexerLogger)
+	rewardindexer.Logger.Init(rewardIndexerLogger)
+	tracing.Logger.Init(tracingLogger)
 	syncker.Logger.Init(synckerLogger)
 }
 
@@ -137,6 +158,13 @@ var subsystemLoggers = map[string]common.Logger{
 	"PEERV2":            peerv2Logger,
 	"DAO":               daov2Logger,
 	"BTCRELAYING":       btcRelayingLogger,
+	"ETHRELAYING":       ethRelayingLogger,
+	"LTCRELAYING":       ltcRelayingLogger,
+	"PDEINDEXER":        pdeIndexerLogger,
+	"BLOCKEXPORTER":     blockExporterLogger,
+	"VALIDATORINDEXER":  validatorIndexerLogger,
+	"REWARDINDEXER":     rewardIndexerLogger,
+	"TRACING":           tracingLogger,
 	"SYNCKER":           synckerLogger,
 }
 
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -18,6 +19,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/transaction"
 )
 
@@ -285,7 +287,23 @@ func (tp *TxPool) MaybeAcceptTransactionForBlockProducing(tx metadata.Transactio
 	return tempTxDesc, err
 }
 
-func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(shardID byte, txs []metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) ([]*metadata.TxDesc, error) {
+func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(ctx context.Context, shardID byte, txs []metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) (txDescs []*metadata.TxDesc, err error) {
+	return tp.maybeAcceptBatchTransactionForBlockProducing(ctx, shardID, txs, shardView, false)
+}
+
+// MaybeAcceptVerifiedBatchTransactionForBlockProducing works as MaybeAcceptBatchTransactionForBlockProducing for txs
+// whose sanity data, signatures and proofs are already verified, only the checks against the chain and the pool are done
+func (tp *TxPool) MaybeAcceptVerifiedBatchTransactionForBlockProducing(ctx context.Context, shardID byte, txs []metadata.Transaction, beaconHeight int64, shardView *blockchain.ShardBestState) (txDescs []*metadata.TxDesc, err error) {
+	return tp.maybeAcceptBatchTransactionForBlockProducing(ctx, shardID, txs, shardView, true)
+}
+
+func (tp *TxPool) maybeAcceptBatchTransactionForBlockProducing(ctx context.Context, shardID byte, txs []metadata.Transaction, shardView *blockchain.ShardBestState, isVerified bool) (txDescs []*metadata.TxDesc, err error) {
+	_, span := tracing.StartSpan(ctx, "mempool.MaybeAcceptBatchTransaction", "txs", len(txs))
+	defer func() {
+		span.SetAttributes("accepted", len(txDescs))
+		span.SetError(err)
+		span.End()
+	}()
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	bHeight := shardView.BestBlock.Header.BeaconHeight
@@ -295,11 +313,13 @@ func (tp *TxPool) MaybeAcceptBatchTransactionForBlockProducing(shardID byte, txs
 		Logger.log.Error(err)
 		return nil, err
 	}
-	_, txDesc, err := tp.maybeAcceptBatchTransaction(shardView, beaconView, shardID, txs, int64(bHeight))
-	return txDesc, err
+	_, txDescs, err = tp.maybeAcceptBatchTransaction(shardView, beaconView, shardID, txs, int64(bHeight), isVerified)
+	return txDescs, err
 }
 
-func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, shardID byte, txs []metadata.Transaction, beaconHeight int64) ([]common.Hash, []*metadata.TxDesc, error) {
+// maybeAcceptBatchTransaction verifies the signatures and proofs of txs in a batch if they are not verified yet,
+// then validates them with the chain and adds them to the pool one by one
+func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, shardID byte, txs []metadata.Transaction, beaconHeight int64, isVerified bool) ([]common.Hash, []*metadata.TxDesc, error) {
 	txDescs := []*metadata.TxDesc{}
 	txHashes := []common.Hash{}
 	batch := transaction.NewBatchTransaction(txs)
@@ -309,16 +329,18 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 	boolParams["isBatch"] = true
 	boolParams["isNewZKP"] = tp.config.BlockChain.IsAfterNewZKPCheckPoint(uint64(beaconHeight))
 
-	ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), boolParams)
-	if err != nil {
-		return nil, nil, err
-	}
-	if !ok {
-		return nil, nil, fmt.Errorf("Verify Batch Transaction failed %+v", txs)
+	if !isVerified {
+		ok, err, _ := batch.Validate(shardView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), boolParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("Verify Batch Transaction failed %+v", txs)
+		}
 	}
 	for _, tx := range txs {
 		// validate tx
-		err := tp.validateTransaction(shardView, beaconView, tx, beaconHeight, true, false)
+		err := tp.validateTransaction(shardView, beaconView, tx, beaconHeight, true, false, isVerified)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -345,7 +367,7 @@ func (tp *TxPool) maybeAcceptBatchTransaction(shardView *blockchain.ShardBestSta
 */
 func (tp *TxPool) maybeAcceptTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, isStore bool, isNewTransaction bool, beaconHeight int64) (*common.Hash, *TxDesc, error) {
 	// validate tx
-	err := tp.validateTransaction(shardView, beaconView, tx, beaconHeight, false, isNewTransaction)
+	err := tp.validateTransaction(shardView, beaconView, tx, beaconHeight, false, isNewTransaction, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -481,17 +503,20 @@ In Param#2: isStore: store transaction to persistence storage only work for tran
 9. Staking Transaction: Check Duplicate stake public key in pool ONLY with staking transaction
 10. RequestStopAutoStaking
 */
-func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, beaconHeight int64, isBatch bool, isNewTransaction bool) error {
+func (tp *TxPool) validateTransaction(shardView *blockchain.ShardBestState, beaconView *blockchain.BeaconBestState, tx metadata.Transaction, beaconHeight int64, isBatch bool, isNewTransaction bool, isSanityChecked bool) error {
 	var err error
 	txHash := tx.Hash()
 	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 	// Condition 1: sanity data
-	validated := false
-	if !isNewTransaction {
-		// need to use beacon height from
-		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, uint64(beaconHeight))
-	} else {
-		validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, 0)
+	// skip it if the caller already checked it
+	validated := isSanityChecked
+	if !validated {
+		if !isNewTransaction {
+			// need to use beacon height from
+			validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, uint64(beaconHeight))
+		} else {
+			validated, err = tx.ValidateSanityData(tp.config.BlockChain, shardView, beaconView, 0)
+		}
 	}
 	if !validated {
 		// try parse to TransactionError
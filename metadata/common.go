@@ -74,6 +74,12 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PDECrossPoolTradeRequest{}
 	case PDECrossPoolTradeResponseMeta:
 		md = &PDECrossPoolTradeResponse{}
+	case PDELimitOrderRequestMeta:
+		md = &PDELimitOrderRequest{}
+	case PDELimitOrderResponseMeta:
+		md = &PDELimitOrderResponse{}
+	case PDECancelLimitOrderRequestMeta:
+		md = &PDECancelLimitOrderRequest{}
 	case PDEWithdrawalRequestMeta:
 		md = &PDEWithdrawalRequest{}
 	case PDEWithdrawalResponseMeta:
@@ -108,6 +114,10 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
 		md = &RelayingHeader{}
+	case RelayingETHHeaderMeta:
+		md = &RelayingHeader{}
+	case RelayingLTCHeaderMeta:
+		md = &RelayingHeader{}
 	case PortalCustodianWithdrawRequestMeta:
 		md = &PortalCustodianWithdrawRequest{}
 	case PortalCustodianWithdrawResponseMeta:
@@ -216,12 +226,12 @@ func ValidatePortalExternalAddress(chainName string, tokenID string, address str
 }
 
 // Validate portal remote addresses for portal tokens (BTC, BNB)
-func ValidatePortalRemoteAddresses(remoteAddresses map[string]string, chainRetriever ChainRetriever) (bool, error) {
+func ValidatePortalRemoteAddresses(remoteAddresses map[string]string, chainRetriever ChainRetriever, beaconHeight uint64) (bool, error) {
 	if len(remoteAddresses) == 0 {
 		return false, errors.New("remote addresses should be at least one address")
 	}
 	for tokenID, remoteAddr := range remoteAddresses {
-		if !IsPortalToken(tokenID) {
+		if !IsPortalToken(chainRetriever, beaconHeight, tokenID) {
 			return false, errors.New("TokenID in remote address is invalid")
 		}
 		if len(remoteAddr) == 0 {
@@ -48,6 +48,9 @@ const (
 	PDEFeeWithdrawalRequestMeta           = 207
 	PDEFeeWithdrawalResponseMeta          = 208
 	PDETradingFeesDistributionMeta        = 209
+	PDELimitOrderRequestMeta              = 212
+	PDELimitOrderResponseMeta             = 213
+	PDECancelLimitOrderRequestMeta        = 214
 
 	// portal
 	PortalCustodianDepositMeta                  = 100
@@ -105,6 +108,8 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta = 200
 	RelayingBTCHeaderMeta = 201
+	RelayingETHHeaderMeta = 210
+	RelayingLTCHeaderMeta = 211
 
 	PortalTopUpWaitingPortingRequestMeta  = 202
 	PortalTopUpWaitingPortingResponseMeta = 203
@@ -125,6 +130,7 @@ var minerCreatedMetaTypes = []int{
 	WithDrawRewardResponseMeta,
 	PDETradeResponseMeta,
 	PDECrossPoolTradeResponseMeta,
+	PDELimitOrderResponseMeta,
 	PDEWithdrawalResponseMeta,
 	PDEFeeWithdrawalResponseMeta,
 	PDEContributionResponseMeta,
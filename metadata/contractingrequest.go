@@ -103,7 +103,7 @@ func (cReq ContractingRequest) Hash() *common.Hash {
 	return &hash
 }
 
-func (cReq *ContractingRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (cReq *ContractingRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := map[string]interface{}{
 		"meta":          *cReq,
 		"RequestedTxID": tx.Hash(),
@@ -42,6 +42,8 @@ const (
 	CouldNotGetExchangeRateError
 	RejectInvalidFee
 	PDEFeeWithdrawalRequestFromMapError
+	PDELimitOrderRequestFromMapError
+	PDECancelLimitOrderRequestFromMapError
 
 	// portal
 	PortalRequestPTokenParamError
@@ -102,6 +104,9 @@ var ErrCodeMessage = map[int]struct {
 	CouldNotGetExchangeRateError:     {-6002, "Could not get the exchange rate error"},
 	RejectInvalidFee:                 {-6003, "Reject invalid fee"},
 
+	PDELimitOrderRequestFromMapError:       {-6004, "PDE limit order request Error"},
+	PDECancelLimitOrderRequestFromMapError: {-6005, "PDE cancel limit order request Error"},
+
 	// portal
 	PortalRequestPTokenParamError:                {-7001, "Portal request ptoken param error"},
 	PortalRedeemRequestParamError:                {-7002, "Portal redeem request param error"},
@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata/ethprovider"
+)
+
+var (
+	ethHeaderVerifier     *ethprovider.ETHHeaderVerifier
+	ethHeaderVerifierLock sync.Mutex
+)
+
+// newETHHeaderVerifierFromENV builds the verifier from GETH_PROVIDERS, a comma separated list of endpoints,
+// and GETH_QUORUM (default: a majority of the providers).
+// Without GETH_PROVIDERS the single endpoint from GETH_NAME/GETH_PORT/GETH_PROTOCOL is used.
+func newETHHeaderVerifierFromENV() (*ethprovider.ETHHeaderVerifier, error) {
+	providers := []ethprovider.ETHHeaderProvider{}
+	for _, endpoint := range strings.Split(common.GetENV("GETH_PROVIDERS", ""), ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		providers = append(providers, ethprovider.NewRPCETHHeaderProvider("", endpoint, ""))
+	}
+	if len(providers) == 0 {
+		providers = append(providers, ethprovider.NewRPCETHHeaderProvider(EthereumLightNodeProtocol, EthereumLightNodeHost, EthereumLightNodePort))
+	}
+	quorum := len(providers)/2 + 1
+	quorumStr := common.GetENV("GETH_QUORUM", "")
+	if quorumStr != "" {
+		var err error
+		quorum, err = strconv.Atoi(quorumStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ethprovider.NewETHHeaderVerifier(providers, quorum, ETHConfirmationBlocks)
+}
+
+// GetETHHeaderVerifier returns the verifier used to fetch ETH headers, it is built from env vars on the first call
+func GetETHHeaderVerifier() (*ethprovider.ETHHeaderVerifier, error) {
+	ethHeaderVerifierLock.Lock()
+	defer ethHeaderVerifierLock.Unlock()
+	if ethHeaderVerifier == nil {
+		verifier, err := newETHHeaderVerifierFromENV()
+		if err != nil {
+			return nil, err
+		}
+		ethHeaderVerifier = verifier
+	}
+	return ethHeaderVerifier, nil
+}
+
+// SetETHHeaderVerifier replaces the verifier, e.g. by one backed by mock providers
+func SetETHHeaderVerifier(verifier *ethprovider.ETHHeaderVerifier) {
+	ethHeaderVerifierLock.Lock()
+	defer ethHeaderVerifierLock.Unlock()
+	ethHeaderVerifier = verifier
+}
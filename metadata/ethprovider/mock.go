@@ -0,0 +1,100 @@
+package ethprovider
+
+import (
+	"math/big"
+	"sync"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MockETHHeaderProvider is an in-memory provider used to run the ETH bridge flow offline
+type MockETHHeaderProvider struct {
+	name       string
+	headers    map[rCommon.Hash]*types.Header
+	canonical  map[uint64]rCommon.Hash
+	latest     *big.Int
+	err        error
+	numOfCalls int
+	lock       sync.RWMutex
+}
+
+func NewMockETHHeaderProvider(name string) *MockETHHeaderProvider {
+	return &MockETHHeaderProvider{
+		name:      name,
+		headers:   make(map[rCommon.Hash]*types.Header),
+		canonical: make(map[uint64]rCommon.Hash),
+		latest:    big.NewInt(0),
+	}
+}
+
+// AddHeader stores header, a canonical header replaces the current one at the same height
+func (provider *MockETHHeaderProvider) AddHeader(header *types.Header, isCanonical bool) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.headers[header.Hash()] = header
+	if isCanonical {
+		provider.canonical[header.Number.Uint64()] = header.Hash()
+		if header.Number.Cmp(provider.latest) > 0 {
+			provider.latest = new(big.Int).Set(header.Number)
+		}
+	}
+}
+
+func (provider *MockETHHeaderProvider) SetLatestBlockNumber(number *big.Int) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.latest = new(big.Int).Set(number)
+}
+
+// SetError makes every following call fail with err, nil restores the provider
+func (provider *MockETHHeaderProvider) SetError(err error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.err = err
+}
+
+// NumOfCalls returns the number of calls served by the provider
+func (provider *MockETHHeaderProvider) NumOfCalls() int {
+	provider.lock.RLock()
+	defer provider.lock.RUnlock()
+	return provider.numOfCalls
+}
+
+func (provider *MockETHHeaderProvider) Name() string {
+	return provider.name
+}
+
+func (provider *MockETHHeaderProvider) GetHeaderByHash(blockHash rCommon.Hash) (*types.Header, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.numOfCalls++
+	if provider.err != nil {
+		return nil, provider.err
+	}
+	return provider.headers[blockHash], nil
+}
+
+func (provider *MockETHHeaderProvider) GetHeaderByNumber(number *big.Int) (*types.Header, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.numOfCalls++
+	if provider.err != nil {
+		return nil, provider.err
+	}
+	blockHash, ok := provider.canonical[number.Uint64()]
+	if !ok {
+		return nil, nil
+	}
+	return provider.headers[blockHash], nil
+}
+
+func (provider *MockETHHeaderProvider) GetLatestBlockNumber() (*big.Int, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.numOfCalls++
+	if provider.err != nil {
+		return nil, provider.err
+	}
+	return new(big.Int).Set(provider.latest), nil
+}
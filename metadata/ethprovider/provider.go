@@ -0,0 +1,92 @@
+package ethprovider
+
+import (
+	"fmt"
+	"math/big"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/pkg/errors"
+)
+
+// ETHHeaderProvider is a source of Ethereum block headers
+type ETHHeaderProvider interface {
+	Name() string
+	// GetHeaderByHash returns nil header without error if the provider does not know the block
+	GetHeaderByHash(blockHash rCommon.Hash) (*types.Header, error)
+	// GetHeaderByNumber returns the canonical header at the height
+	GetHeaderByNumber(number *big.Int) (*types.Header, error)
+	GetLatestBlockNumber() (*big.Int, error)
+}
+
+type getETHHeaderRes struct {
+	rpccaller.RPCBaseRes
+	Result *types.Header `json:"result"`
+}
+
+type getETHBlockNumRes struct {
+	rpccaller.RPCBaseRes
+	Result string `json:"result"`
+}
+
+// RPCETHHeaderProvider reads headers from a geth compatible JSON-RPC endpoint
+type RPCETHHeaderProvider struct {
+	protocol  string
+	host      string
+	port      string
+	rpcClient *rpccaller.RPCClient
+}
+
+func NewRPCETHHeaderProvider(protocol string, host string, port string) *RPCETHHeaderProvider {
+	return &RPCETHHeaderProvider{
+		protocol:  protocol,
+		host:      host,
+		port:      port,
+		rpcClient: rpccaller.NewRPCClient(),
+	}
+}
+
+func (provider *RPCETHHeaderProvider) Name() string {
+	return rpccaller.BuildRPCServerAddress(provider.protocol, provider.host, provider.port)
+}
+
+func (provider *RPCETHHeaderProvider) getHeader(method string, params []interface{}) (*types.Header, error) {
+	var res getETHHeaderRes
+	err := provider.rpcClient.RPCCall(provider.protocol, provider.host, provider.port, method, params, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.RPCError != nil {
+		return nil, errors.New(fmt.Sprintf("an error occured during calling %s: %s", method, res.RPCError.Message))
+	}
+	return res.Result, nil
+}
+
+func (provider *RPCETHHeaderProvider) GetHeaderByHash(blockHash rCommon.Hash) (*types.Header, error) {
+	return provider.getHeader("eth_getBlockByHash", []interface{}{blockHash, false})
+}
+
+func (provider *RPCETHHeaderProvider) GetHeaderByNumber(number *big.Int) (*types.Header, error) {
+	return provider.getHeader("eth_getBlockByNumber", []interface{}{fmt.Sprintf("0x%x", number), false})
+}
+
+func (provider *RPCETHHeaderProvider) GetLatestBlockNumber() (*big.Int, error) {
+	var res getETHBlockNumRes
+	err := provider.rpcClient.RPCCall(provider.protocol, provider.host, provider.port, "eth_blockNumber", []interface{}{}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.RPCError != nil {
+		return nil, errors.New(fmt.Sprintf("an error occured during calling eth_blockNumber: %s", res.RPCError.Message))
+	}
+	if len(res.Result) < 3 {
+		return nil, errors.New("Cannot convert blockNumber into integer")
+	}
+	blockNumber := new(big.Int)
+	_, ok := blockNumber.SetString(res.Result[2:], 16)
+	if !ok {
+		return nil, errors.New("Cannot convert blockNumber into integer")
+	}
+	return blockNumber, nil
+}
@@ -0,0 +1,247 @@
+package ethprovider
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHeaderCacheSize = 1000
+	baseProviderBackoff    = 10 * time.Second
+	maxProviderBackoff     = 5 * time.Minute
+)
+
+// ProviderHealth is the health of a provider tracked by the verifier
+type ProviderHealth struct {
+	Name            string
+	IsHealthy       bool
+	NumOfFailures   int // consecutive failures
+	LastError       string
+	LastSuccessTime time.Time
+	UnhealthyUntil  time.Time
+}
+
+// ETHHeaderVerifier asks several providers for a header and accepts it only if a quorum of them agree on it,
+// a provider which fails is skipped for an exponential backoff period
+type ETHHeaderVerifier struct {
+	providers          []ETHHeaderProvider
+	quorum             int
+	confirmationBlocks int64
+	health             map[string]*ProviderHealth
+	// headers confirmed by a quorum at the time they are verified, they can not be reorged anymore
+	confirmedHeaders *lru.Cache
+	lock             sync.RWMutex
+}
+
+func NewETHHeaderVerifier(providers []ETHHeaderProvider, quorum int, confirmationBlocks int64) (*ETHHeaderVerifier, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("there is no ETH header provider")
+	}
+	if quorum <= 0 || quorum > len(providers) {
+		return nil, errors.Errorf("quorum %v is invalid for %v providers", quorum, len(providers))
+	}
+	cache, err := lru.New(defaultHeaderCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	health := make(map[string]*ProviderHealth)
+	for _, provider := range providers {
+		if _, ok := health[provider.Name()]; ok {
+			return nil, errors.Errorf("duplicated ETH header provider %v", provider.Name())
+		}
+		health[provider.Name()] = &ProviderHealth{Name: provider.Name(), IsHealthy: true}
+	}
+	return &ETHHeaderVerifier{
+		providers:          providers,
+		quorum:             quorum,
+		confirmationBlocks: confirmationBlocks,
+		health:             health,
+		confirmedHeaders:   cache,
+	}, nil
+}
+
+func (verifier *ETHHeaderVerifier) GetQuorum() int {
+	return verifier.quorum
+}
+
+// GetProvidersHealth returns a snapshot of the health of all providers
+func (verifier *ETHHeaderVerifier) GetProvidersHealth() []ProviderHealth {
+	verifier.lock.RLock()
+	defer verifier.lock.RUnlock()
+	res := make([]ProviderHealth, 0, len(verifier.providers))
+	for _, provider := range verifier.providers {
+		res = append(res, *verifier.health[provider.Name()])
+	}
+	return res
+}
+
+func (verifier *ETHHeaderVerifier) markSuccess(provider ETHHeaderProvider) {
+	verifier.lock.Lock()
+	defer verifier.lock.Unlock()
+	health := verifier.health[provider.Name()]
+	health.IsHealthy = true
+	health.NumOfFailures = 0
+	health.LastSuccessTime = time.Now()
+}
+
+func (verifier *ETHHeaderVerifier) markFailure(provider ETHHeaderProvider, err error) {
+	verifier.lock.Lock()
+	defer verifier.lock.Unlock()
+	health := verifier.health[provider.Name()]
+	health.IsHealthy = false
+	health.NumOfFailures++
+	health.LastError = err.Error()
+	backoff := baseProviderBackoff
+	for i := 1; i < health.NumOfFailures && backoff < maxProviderBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxProviderBackoff {
+		backoff = maxProviderBackoff
+	}
+	health.UnhealthyUntil = time.Now().Add(backoff)
+}
+
+// pickProviders returns providers which are not backing off,
+// all providers are returned when there are not enough of them to reach the quorum
+func (verifier *ETHHeaderVerifier) pickProviders() []ETHHeaderProvider {
+	verifier.lock.RLock()
+	defer verifier.lock.RUnlock()
+	now := time.Now()
+	res := make([]ETHHeaderProvider, 0, len(verifier.providers))
+	for _, provider := range verifier.providers {
+		health := verifier.health[provider.Name()]
+		if health.IsHealthy || now.After(health.UnhealthyUntil) {
+			res = append(res, provider)
+		}
+	}
+	if len(res) < verifier.quorum {
+		return verifier.providers
+	}
+	return res
+}
+
+type headerVote struct {
+	header   *types.Header
+	latest   *big.Int
+	err      error
+	isFailed bool // the provider is faulty, not only disagreeing
+}
+
+func (verifier *ETHHeaderVerifier) queryHeader(provider ETHHeaderProvider, blockHash rCommon.Hash) headerVote {
+	header, err := provider.GetHeaderByHash(blockHash)
+	if err != nil {
+		return headerVote{err: err, isFailed: true}
+	}
+	if header == nil {
+		return headerVote{err: errors.Errorf("block %v is not found", blockHash.String())}
+	}
+	if header.Hash() != blockHash || header.Number == nil {
+		return headerVote{err: errors.Errorf("returned header does not match the block hash %v", blockHash.String()), isFailed: true}
+	}
+	headerByNum, err := provider.GetHeaderByNumber(header.Number)
+	if err != nil {
+		return headerVote{err: err, isFailed: true}
+	}
+	if headerByNum == nil || headerByNum.Hash() != blockHash {
+		return headerVote{err: errors.Errorf("block %v is being on fork branch", blockHash.String())}
+	}
+	latest, err := provider.GetLatestBlockNumber()
+	if err != nil {
+		return headerVote{err: err, isFailed: true}
+	}
+	return headerVote{header: header, latest: latest}
+}
+
+func (verifier *ETHHeaderVerifier) collectVotes(providers []ETHHeaderProvider, query func(ETHHeaderProvider) headerVote) []headerVote {
+	votes := make([]headerVote, len(providers))
+	wg := sync.WaitGroup{}
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider ETHHeaderProvider) {
+			defer wg.Done()
+			votes[i] = query(provider)
+			if votes[i].isFailed {
+				verifier.markFailure(provider, votes[i].err)
+			} else {
+				verifier.markSuccess(provider)
+			}
+		}(i, provider)
+	}
+	wg.Wait()
+	return votes
+}
+
+// GetHeader returns the header of blockHash if a quorum of providers agree it is on the canonical chain
+// and has been followed by confirmationBlocks blocks, it returns an error otherwise
+func (verifier *ETHHeaderVerifier) GetHeader(blockHash rCommon.Hash) (*types.Header, error) {
+	if header, ok := verifier.confirmedHeaders.Get(blockHash); ok {
+		return header.(*types.Header), nil
+	}
+
+	providers := verifier.pickProviders()
+	votes := verifier.collectVotes(providers, func(provider ETHHeaderProvider) headerVote {
+		return verifier.queryHeader(provider, blockHash)
+	})
+
+	var header *types.Header
+	numOfAgreements := 0
+	numOfConfirmations := 0
+	errs := []string{}
+	for i, vote := range votes {
+		if vote.err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", providers[i].Name(), vote.err))
+			continue
+		}
+		header = vote.header
+		numOfAgreements++
+		confirmedHeight := new(big.Int).Add(vote.header.Number, big.NewInt(verifier.confirmationBlocks))
+		if vote.latest.Cmp(confirmedHeight) >= 0 {
+			numOfConfirmations++
+		}
+	}
+	if numOfAgreements < verifier.quorum {
+		return nil, errors.Errorf("only %v of %v required providers agree on block %v: %v", numOfAgreements, verifier.quorum, blockHash.String(), errs)
+	}
+	if numOfConfirmations < verifier.quorum {
+		return nil, errors.Errorf("only %v of %v required providers see %v confirmations of block %v", numOfConfirmations, verifier.quorum, verifier.confirmationBlocks, blockHash.String())
+	}
+	verifier.confirmedHeaders.Add(blockHash, header)
+	return header, nil
+}
+
+// GetLatestBlockNumber returns the highest block number that at least a quorum of providers have reached
+func (verifier *ETHHeaderVerifier) GetLatestBlockNumber() (*big.Int, error) {
+	providers := verifier.pickProviders()
+	votes := verifier.collectVotes(providers, func(provider ETHHeaderProvider) headerVote {
+		latest, err := provider.GetLatestBlockNumber()
+		if err != nil {
+			return headerVote{err: err, isFailed: true}
+		}
+		return headerVote{latest: latest}
+	})
+
+	latestNumbers := []*big.Int{}
+	errs := []string{}
+	for i, vote := range votes {
+		if vote.err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", providers[i].Name(), vote.err))
+			continue
+		}
+		latestNumbers = append(latestNumbers, vote.latest)
+	}
+	if len(latestNumbers) < verifier.quorum {
+		return nil, errors.Errorf("only %v of %v required providers return the latest block number: %v", len(latestNumbers), verifier.quorum, errs)
+	}
+	sort.Slice(latestNumbers, func(i, j int) bool {
+		return latestNumbers[i].Cmp(latestNumbers[j]) > 0
+	})
+	return latestNumbers[verifier.quorum-1], nil
+}
@@ -0,0 +1,155 @@
+package ethprovider
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHeader(number int64, extra byte) *types.Header {
+	return &types.Header{
+		Number:     big.NewInt(number),
+		Difficulty: big.NewInt(1),
+		Extra:      []byte{extra},
+	}
+}
+
+func newTestProviders(n int, headers ...*types.Header) []*MockETHHeaderProvider {
+	providers := make([]*MockETHHeaderProvider, n)
+	for i := range providers {
+		providers[i] = NewMockETHHeaderProvider(string(rune('a' + i)))
+		for _, header := range headers {
+			providers[i].AddHeader(header, true)
+		}
+	}
+	return providers
+}
+
+func toProviders(mocks []*MockETHHeaderProvider) []ETHHeaderProvider {
+	res := make([]ETHHeaderProvider, len(mocks))
+	for i := range mocks {
+		res[i] = mocks[i]
+	}
+	return res
+}
+
+func TestNewETHHeaderVerifier(t *testing.T) {
+	_, err := NewETHHeaderVerifier(nil, 1, 15)
+	assert.NotNil(t, err)
+	mocks := newTestProviders(2)
+	_, err = NewETHHeaderVerifier(toProviders(mocks), 3, 15)
+	assert.NotNil(t, err)
+	_, err = NewETHHeaderVerifier(toProviders(append(mocks, mocks[0])), 2, 15)
+	assert.NotNil(t, err)
+	_, err = NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+}
+
+func TestETHHeaderVerifierQuorum(t *testing.T) {
+	header := newTestHeader(100, 0)
+	mocks := newTestProviders(3, header)
+	for _, mock := range mocks {
+		mock.SetLatestBlockNumber(big.NewInt(115))
+	}
+	verifier, err := NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+
+	// one provider sees the block on a fork branch, the quorum is still reached
+	forkHeader := newTestHeader(100, 1)
+	mocks[0].AddHeader(forkHeader, true)
+	res, err := verifier.GetHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), res.Hash())
+
+	// two providers see the block on a fork branch
+	mocks[1].AddHeader(forkHeader, true)
+	verifier, err = NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+	_, err = verifier.GetHeader(header.Hash())
+	assert.NotNil(t, err)
+
+	_, err = verifier.GetHeader(newTestHeader(101, 0).Hash())
+	assert.NotNil(t, err)
+}
+
+func TestETHHeaderVerifierConfirmations(t *testing.T) {
+	header := newTestHeader(100, 0)
+	mocks := newTestProviders(3, header)
+	verifier, err := NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+
+	// all providers agree on the block but it is not confirmed yet
+	_, err = verifier.GetHeader(header.Hash())
+	assert.NotNil(t, err)
+
+	// a single provider can not confirm the block
+	mocks[0].SetLatestBlockNumber(big.NewInt(1000))
+	_, err = verifier.GetHeader(header.Hash())
+	assert.NotNil(t, err)
+
+	mocks[1].SetLatestBlockNumber(big.NewInt(115))
+	res, err := verifier.GetHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), res.Hash())
+}
+
+func TestETHHeaderVerifierLatestBlockNumber(t *testing.T) {
+	mocks := newTestProviders(3)
+	mocks[0].SetLatestBlockNumber(big.NewInt(1000000))
+	mocks[1].SetLatestBlockNumber(big.NewInt(120))
+	mocks[2].SetLatestBlockNumber(big.NewInt(110))
+	verifier, err := NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+
+	// a single provider can not push the latest block number up
+	latest, err := verifier.GetLatestBlockNumber()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(120), latest.Int64())
+
+	mocks[0].SetError(errors.New("down"))
+	mocks[1].SetError(errors.New("down"))
+	_, err = verifier.GetLatestBlockNumber()
+	assert.NotNil(t, err)
+}
+
+func TestETHHeaderVerifierHealthAndCache(t *testing.T) {
+	header := newTestHeader(100, 0)
+	otherHeader := newTestHeader(101, 0)
+	mocks := newTestProviders(3, header, otherHeader)
+	verifier, err := NewETHHeaderVerifier(toProviders(mocks), 2, 15)
+	assert.Nil(t, err)
+
+	// the header is not confirmed yet so it is not cached
+	_, err = verifier.GetHeader(header.Hash())
+	assert.NotNil(t, err)
+	numOfCalls := mocks[1].NumOfCalls()
+	_, err = verifier.GetHeader(header.Hash())
+	assert.NotNil(t, err)
+	assert.True(t, mocks[1].NumOfCalls() > numOfCalls)
+
+	// a failing provider is skipped until its backoff expires
+	for _, mock := range mocks {
+		mock.SetLatestBlockNumber(big.NewInt(120))
+	}
+	mocks[0].SetError(errors.New("down"))
+	_, err = verifier.GetHeader(header.Hash())
+	assert.Nil(t, err)
+	health := verifier.GetProvidersHealth()
+	assert.False(t, health[0].IsHealthy)
+	assert.Equal(t, 1, health[0].NumOfFailures)
+	assert.True(t, health[1].IsHealthy)
+	numOfCalls = mocks[0].NumOfCalls()
+	_, err = verifier.GetHeader(otherHeader.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, numOfCalls, mocks[0].NumOfCalls())
+
+	// a confirmed header is cached
+	numOfCalls = mocks[1].NumOfCalls()
+	res, err := verifier.GetHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), res.Hash())
+	assert.Equal(t, numOfCalls, mocks[1].NumOfCalls())
+}
@@ -17,26 +17,55 @@ import (
 	"strings"
 )
 
-func VerifyProofAndParseReceipt(blockHash eCommon.Hash, txIndex uint, proofStrs []string) (*types.Receipt, error) {
+// GetConfirmedETHHeader returns the header of blockHash if it has ETHConfirmationBlocks confirmations,
+// the header is read from the relayed ETH header chain once ETH relaying is enabled at beaconHeight,
+// the beacon height of the block being produced or validated, from ETH header providers otherwise
+func GetConfirmedETHHeader(chainRetriever ChainRetriever, beaconHeight uint64, blockHash eCommon.Hash) (*types.Header, error) {
+	if chainRetriever.IsETHRelayingEnabled(beaconHeight) {
+		ethChain := chainRetriever.GetETHHeaderChain()
+		if ethChain == nil {
+			return nil, errors.New("ETH relaying is enabled but the relayed ETH header chain is not found")
+		}
+		ethHeader, confirmations, err := ethChain.GetCanonicalHeader(blockHash)
+		if err != nil {
+			Logger.log.Info("WARNING: Could not find out the relayed ETH block header with the hash: ", blockHash)
+			return nil, err
+		}
+		if confirmations < ETHConfirmationBlocks {
+			errMsg := fmt.Sprintf("WARNING: It needs %v confirmation blocks for the process, the requested block (%s) has %v confirmations in the relayed chain", ETHConfirmationBlocks, ethHeader.Number.String(), confirmations)
+			Logger.log.Info(errMsg)
+			return nil, errors.New(errMsg)
+		}
+		return ethHeader, nil
+	}
+
 	ethHeader, err := GetETHHeader(blockHash)
 	if err != nil {
-		return nil, NewMetadataTxError(VerifyProofAndParseReceiptError, err)
+		return nil, err
 	}
 	if ethHeader == nil {
 		Logger.log.Info("WARNING: Could not find out the ETH block header with the hash: ", blockHash)
-		return nil, NewMetadataTxError(VerifyProofAndParseReceiptError, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", blockHash.String()))
+		return nil, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", blockHash.String())
 	}
 
 	mostRecentBlkNum, err := GetMostRecentETHBlockHeight()
 	if err != nil {
 		Logger.log.Info("WARNING: Could not find the most recent block height on Ethereum")
-		return nil, NewMetadataTxError(VerifyProofAndParseReceiptError, err)
+		return nil, err
 	}
 
 	if mostRecentBlkNum.Cmp(big.NewInt(0).Add(ethHeader.Number, big.NewInt(ETHConfirmationBlocks))) == -1 {
 		errMsg := fmt.Sprintf("WARNING: It needs 15 confirmation blocks for the process, the requested block (%s) but the latest block (%s)", ethHeader.Number.String(), mostRecentBlkNum.String())
 		Logger.log.Info(errMsg)
-		return nil, NewMetadataTxError(VerifyProofAndParseReceiptError, errors.New(errMsg))
+		return nil, errors.New(errMsg)
+	}
+	return ethHeader, nil
+}
+
+func VerifyProofAndParseReceipt(chainRetriever ChainRetriever, beaconHeight uint64, blockHash eCommon.Hash, txIndex uint, proofStrs []string) (*types.Receipt, error) {
+	ethHeader, err := GetConfirmedETHHeader(chainRetriever, beaconHeight, blockHash)
+	if err != nil {
+		return nil, NewMetadataTxError(VerifyProofAndParseReceiptError, err)
 	}
 
 	keybuf := new(bytes.Buffer)
@@ -120,7 +120,7 @@ func NewIssuingETHRequestFromMap(
 }
 
 func (iReq IssuingETHRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(chainRetriever, shardViewRetriever.GetBeaconHeight())
 	if err != nil {
 		return false, NewMetadataTxError(IssuingEthRequestValidateTxWithBlockChainError, err)
 	}
@@ -173,8 +173,8 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 	return &hash
 }
 
-func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(chainRetriever, beaconHeight)
 	if err != nil {
 		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
 	}
@@ -205,27 +205,11 @@ func (iReq *IssuingETHRequest) CalculateSize() uint64 {
 	return calculateSize(iReq)
 }
 
-func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, error) {
-	ethHeader, err := GetETHHeader(iReq.BlockHash)
+func (iReq *IssuingETHRequest) verifyProofAndParseReceipt(chainRetriever ChainRetriever, beaconHeight uint64) (*types.Receipt, error) {
+	ethHeader, err := GetConfirmedETHHeader(chainRetriever, beaconHeight, iReq.BlockHash)
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
 	}
-	if ethHeader == nil {
-		Logger.log.Info("WARNING: Could not find out the ETH block header with the hash: ", iReq.BlockHash)
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", iReq.BlockHash.String()))
-	}
-
-	mostRecentBlkNum, err := GetMostRecentETHBlockHeight()
-	if err != nil {
-		Logger.log.Info("WARNING: Could not find the most recent block height on Ethereum")
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
-	}
-
-	if mostRecentBlkNum.Cmp(big.NewInt(0).Add(ethHeader.Number, big.NewInt(ETHConfirmationBlocks))) == -1 {
-		errMsg := fmt.Sprintf("WARNING: It needs 15 confirmation blocks for the process, the requested block (%s) but the latest block (%s)", ethHeader.Number.String(), mostRecentBlkNum.String())
-		Logger.log.Info(errMsg)
-		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.New(errMsg))
-	}
 
 	keybuf := new(bytes.Buffer)
 	keybuf.Reset()
@@ -280,93 +264,30 @@ func IsETHTxHashUsedInBlock(uniqETHTx []byte, uniqETHTxsUsed [][]byte) bool {
 	return false
 }
 
+// GetETHHeader returns the header of ethBlockHash if a quorum of ETH header providers agree it is on the canonical chain
+// and has ETHConfirmationBlocks confirmations
 func GetETHHeader(
 	ethBlockHash rCommon.Hash,
 ) (*types.Header, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	getETHHeaderByHashParams := []interface{}{ethBlockHash, false}
-	var getETHHeaderByHashRes GetETHHeaderByHashRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_getBlockByHash",
-		getETHHeaderByHashParams,
-		&getETHHeaderByHashRes,
-	)
+	verifier, err := GetETHHeaderVerifier()
 	if err != nil {
 		return nil, err
 	}
-	if getETHHeaderByHashRes.RPCError != nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash: %s", getETHHeaderByHashRes.RPCError.Message)
-		return nil, errors.New(fmt.Sprintf("An error occured during calling eth_getBlockByHash: %s", getETHHeaderByHashRes.RPCError.Message))
-	}
-
-	if getETHHeaderByHashRes.Result == nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash: result is nil")
-		return nil, errors.New(fmt.Sprintf("An error occured during calling eth_getBlockByHash: result is nil"))
-	}
-
-	ethHeaderByHash := getETHHeaderByHashRes.Result
-	headerNum := ethHeaderByHash.Number
-
-	getETHHeaderByNumberParams := []interface{}{fmt.Sprintf("0x%x", headerNum), false}
-	var getETHHeaderByNumberRes GetETHHeaderByNumberRes
-	err = rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_getBlockByNumber",
-		getETHHeaderByNumberParams,
-		&getETHHeaderByNumberRes,
-	)
+	ethHeader, err := verifier.GetHeader(ethBlockHash)
 	if err != nil {
+		Logger.log.Infof("WARNING: could not get ETH header %s: %v", ethBlockHash.String(), err)
 		return nil, err
 	}
-	if getETHHeaderByNumberRes.RPCError != nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByNumber: %s", getETHHeaderByNumberRes.RPCError.Message)
-		return nil, errors.New(fmt.Sprintf("An error occured during calling eth_getBlockByNumber: %s", getETHHeaderByNumberRes.RPCError.Message))
-	}
-
-	if getETHHeaderByNumberRes.Result == nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByNumber: result is nil")
-		return nil, errors.New(fmt.Sprintf("An error occured during calling eth_getBlockByNumber: result is nil"))
-	}
-
-
-	ethHeaderByNum := getETHHeaderByNumberRes.Result
-	if ethHeaderByNum.Hash().String() != ethHeaderByHash.Hash().String() {
-		return nil, errors.New(fmt.Sprintf("The requested eth BlockHash is being on fork branch, rejected!"))
-	}
-	return ethHeaderByHash, nil
+	return ethHeader, nil
 }
 
-// GetMostRecentETHBlockHeight get most recent block height on Ethereum
+// GetMostRecentETHBlockHeight get most recent block height on Ethereum that a quorum of providers have reached
 func GetMostRecentETHBlockHeight() (*big.Int, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	params := []interface{}{}
-	var getETHBlockNumRes GetETHBlockNumRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_blockNumber",
-		params,
-		&getETHBlockNumRes,
-	)
+	verifier, err := GetETHHeaderVerifier()
 	if err != nil {
 		return nil, err
 	}
-	if getETHBlockNumRes.RPCError != nil {
-		return nil, errors.New(fmt.Sprintf("an error occured during calling eth_blockNumber: %s", getETHBlockNumRes.RPCError.Message))
-	}
-
-	blockNumber := new(big.Int)
-	_, ok := blockNumber.SetString(getETHBlockNumRes.Result[2:], 16)
-	if !ok {
-		return nil, errors.New("Cannot convert blockNumber into integer")
-	}
-	return blockNumber, nil
+	return verifier.GetLatestBlockNumber()
 }
 
 func PickAndParseLogMapFromReceipt(constructedReceipt *types.Receipt, ethContractAddressStr string) (map[string]interface{}, error) {
@@ -6,13 +6,13 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/privacy"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
 )
 
 // Interface for all types of metadata in tx
@@ -23,7 +23,7 @@ type Metadata interface {
 	ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error)
 	ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error)
 	ValidateMetadataByItself() bool
-	BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error)
+	BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error)
 	CalculateSize() uint64
 	VerifyMinerCreatedTxBeforeGettingInBlock(txsInBlock []Transaction, txsUsed []int, insts [][]string, instUsed []int, shardID byte, tx Transaction, chainRetriever ChainRetriever, ac *AccumulatedValues, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever) (bool, error)
 	IsMinerCreatedMetaType() bool
@@ -58,6 +58,7 @@ type MempoolRetriever interface {
 type ChainRetriever interface {
 	GetETHRemoveBridgeSigEpoch() uint64
 	GetBCHeightBreakPointPortalV3() uint64
+	GetBCHeightBreakPointPDELimitOrders() uint64
 	GetStakingAmountShard() uint64
 	GetCentralizedWebsitePaymentAddress(uint64) string
 	GetBeaconHeightBreakPointBurnAddr() uint64
@@ -67,8 +68,15 @@ type ChainRetriever interface {
 	GetBNBChainID() string
 	GetBTCChainID() string
 	GetBTCHeaderChain() *btcrelaying.BlockChain
+	GetETHHeaderChain() *ethrelaying.HeaderChain
+	IsETHRelayingEnabled(beaconHeight uint64) bool
+	IsValidPortalRemoteAddress(tokenIDStr string, remoteAddress string) (bool, error)
+	IsPortalToken(beaconHeight uint64, tokenIDStr string) bool
+	GetMinAmountPortalToken(tokenIDStr string, beaconHeight uint64) (uint64, error)
 	GetPortalFeederAddress() string
+	GetPortalFeederAddresses(beaconHeight uint64) []string
 	GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar
+	GetSupportedCommitmentRingSizes(beaconHeight uint64) []int
 	GetSupportedCollateralTokenIDs(beaconHeight uint64) []string
 	GetPortalETHContractAddrStr() string
 }
@@ -259,21 +267,17 @@ func IsValidPortalRemoteAddress(
 	remoteAddress string,
 	tokenID string,
 ) bool {
-	if tokenID == common.PortalBNBIDStr {
-		return bnb.IsValidBNBAddress(remoteAddress, bcr.GetBNBChainID())
-	} else if tokenID == common.PortalBTCIDStr {
-		btcHeaderChain := bcr.GetBTCHeaderChain()
-		if btcHeaderChain == nil {
-			return false
-		}
-		return btcHeaderChain.IsBTCAddressValid(remoteAddress)
+	isValid, err := bcr.IsValidPortalRemoteAddress(tokenID, remoteAddress)
+	if err != nil {
+		Logger.log.Errorf("Can not validate remote address %v of portal token %v: %v", remoteAddress, tokenID, err)
+		return false
 	}
-	return false
+	return isValid
 }
 
-func IsPortalToken(tokenIDStr string) bool {
-	isExisted, _ := common.SliceExists(common.PortalSupportedIncTokenIDs, tokenIDStr)
-	return isExisted
+// IsPortalToken returns true if the portal token is supported by the chain params at beaconHeight
+func IsPortalToken(bcr ChainRetriever, beaconHeight uint64, tokenIDStr string) bool {
+	return bcr.IsPortalToken(beaconHeight, tokenIDStr)
 }
 
 func IsSupportedTokenCollateralV3(bcr ChainRetriever, beaconHeight uint64, externalTokenID string) bool {
@@ -282,5 +286,5 @@ func IsSupportedTokenCollateralV3(bcr ChainRetriever, beaconHeight uint64, exter
 }
 
 func IsPortalExchangeRateToken(tokenIDStr string, bcr ChainRetriever, beaconHeight uint64) bool {
-	return IsPortalToken(tokenIDStr) || tokenIDStr == common.PRVIDStr || IsSupportedTokenCollateralV3(bcr, beaconHeight, tokenIDStr)
+	return IsPortalToken(bcr, beaconHeight, tokenIDStr) || tokenIDStr == common.PRVIDStr || IsSupportedTokenCollateralV3(bcr, beaconHeight, tokenIDStr)
 }
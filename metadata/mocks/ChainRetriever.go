@@ -6,6 +6,8 @@ import (
 	common "github.com/incognitochain/incognito-chain/common"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+
 	metadata "github.com/incognitochain/incognito-chain/metadata"
 
 	mock "github.com/stretchr/testify/mock"
@@ -18,6 +20,20 @@ type ChainRetriever struct {
 	mock.Mock
 }
 
+// GetBCHeightBreakPointPDELimitOrders provides a mock function with given fields:
+func (_m *ChainRetriever) GetBCHeightBreakPointPDELimitOrders() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetBNBChainID provides a mock function with given fields:
 func (_m *ChainRetriever) GetBNBChainID() string {
 	ret := _m.Called()
@@ -62,6 +78,22 @@ func (_m *ChainRetriever) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return r0
 }
 
+// GetETHHeaderChain provides a mock function with given fields:
+func (_m *ChainRetriever) GetETHHeaderChain() *ethrelaying.HeaderChain {
+	ret := _m.Called()
+
+	var r0 *ethrelaying.HeaderChain
+	if rf, ok := ret.Get(0).(func() *ethrelaying.HeaderChain); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethrelaying.HeaderChain)
+		}
+	}
+
+	return r0
+}
+
 // GetBeaconHeightBreakPointBurnAddr provides a mock function with given fields:
 func (_m *ChainRetriever) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	ret := _m.Called()
@@ -120,6 +152,27 @@ func (_m *ChainRetriever) GetFixedRandomForShardIDCommitment(beaconHeight uint64
 	return r0
 }
 
+// GetMinAmountPortalToken provides a mock function with given fields: tokenIDStr, beaconHeight
+func (_m *ChainRetriever) GetMinAmountPortalToken(tokenIDStr string, beaconHeight uint64) (uint64, error) {
+	ret := _m.Called(tokenIDStr, beaconHeight)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, uint64) uint64); ok {
+		r0 = rf(tokenIDStr, beaconHeight)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64) error); ok {
+		r1 = rf(tokenIDStr, beaconHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPortalFeederAddress provides a mock function with given fields:
 func (_m *ChainRetriever) GetPortalFeederAddress() string {
 	ret := _m.Called()
@@ -134,6 +187,22 @@ func (_m *ChainRetriever) GetPortalFeederAddress() string {
 	return r0
 }
 
+// GetPortalFeederAddresses provides a mock function with given fields: beaconHeight
+func (_m *ChainRetriever) GetPortalFeederAddresses(beaconHeight uint64) []string {
+	ret := _m.Called(beaconHeight)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(uint64) []string); ok {
+		r0 = rf(beaconHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // GetStakingAmountShard provides a mock function with given fields:
 func (_m *ChainRetriever) GetStakingAmountShard() uint64 {
 	ret := _m.Called()
@@ -148,6 +217,22 @@ func (_m *ChainRetriever) GetStakingAmountShard() uint64 {
 	return r0
 }
 
+// GetSupportedCommitmentRingSizes provides a mock function with given fields: beaconHeight
+func (_m *ChainRetriever) GetSupportedCommitmentRingSizes(beaconHeight uint64) []int {
+	ret := _m.Called(beaconHeight)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(uint64) []int); ok {
+		r0 = rf(beaconHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	return r0
+}
+
 // GetTransactionByHash provides a mock function with given fields: _a0
 func (_m *ChainRetriever) GetTransactionByHash(_a0 common.Hash) (byte, common.Hash, uint64, int, metadata.Transaction, error) {
 	ret := _m.Called(_a0)
@@ -201,6 +286,55 @@ func (_m *ChainRetriever) GetTransactionByHash(_a0 common.Hash) (byte, common.Ha
 	return r0, r1, r2, r3, r4, r5
 }
 
+// IsETHRelayingEnabled provides a mock function with given fields: beaconHeight
+func (_m *ChainRetriever) IsETHRelayingEnabled(beaconHeight uint64) bool {
+	ret := _m.Called(beaconHeight)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
+		r0 = rf(beaconHeight)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsPortalToken provides a mock function with given fields: beaconHeight, tokenIDStr
+func (_m *ChainRetriever) IsPortalToken(beaconHeight uint64, tokenIDStr string) bool {
+	ret := _m.Called(beaconHeight, tokenIDStr)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(uint64, string) bool); ok {
+		r0 = rf(beaconHeight, tokenIDStr)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// IsValidPortalRemoteAddress provides a mock function with given fields: tokenIDStr, remoteAddress
+func (_m *ChainRetriever) IsValidPortalRemoteAddress(tokenIDStr string, remoteAddress string) (bool, error) {
+	ret := _m.Called(tokenIDStr, remoteAddress)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(tokenIDStr, remoteAddress)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tokenIDStr, remoteAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPrivacyTokenAndBridgeTokenAndPRVByShardID provides a mock function with given fields: _a0
 func (_m *ChainRetriever) ListPrivacyTokenAndBridgeTokenAndPRVByShardID(_a0 byte) ([]common.Hash, error) {
 	ret := _m.Called(_a0)
@@ -0,0 +1,110 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDECancelLimitOrderRequest - privacy dex request cancelling a resting limit order,
+// the remaining selling amount of the order is refunded to its trader
+type PDECancelLimitOrderRequest struct {
+	OrderID          string // hash of the limit order request tx
+	TraderAddressStr string
+	MetadataBase
+}
+
+type PDECancelLimitOrderRequestAction struct {
+	Meta    PDECancelLimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type PDECancelLimitOrderContent struct {
+	OrderID          common.Hash
+	TraderAddressStr string
+	ShardID          byte
+	TxReqID          common.Hash
+}
+
+func NewPDECancelLimitOrderRequest(
+	orderID string,
+	traderAddressStr string,
+	metaType int,
+) (*PDECancelLimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeCancelLimitOrderRequest := &PDECancelLimitOrderRequest{
+		OrderID:          orderID,
+		TraderAddressStr: traderAddressStr,
+	}
+	pdeCancelLimitOrderRequest.MetadataBase = metadataBase
+	return pdeCancelLimitOrderRequest, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the order and its trader are verified on beacon chain
+	return true, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < chainRetriever.GetBCHeightBreakPointPDELimitOrders() {
+		return false, false, NewMetadataTxError(PDECancelLimitOrderRequestFromMapError, errors.New("pde limit orders are not enabled yet"))
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDECancelLimitOrderRequestFromMapError, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+	_, err = common.Hash{}.NewHashFromStr(pc.OrderID)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDECancelLimitOrderRequestFromMapError, errors.New("OrderID incorrect"))
+	}
+	return true, true, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDECancelLimitOrderRequestMeta
+}
+
+func (pc PDECancelLimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.OrderID
+	record += pc.TraderAddressStr
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDECancelLimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
+	actionContent := PDECancelLimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDECancelLimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -0,0 +1,236 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderRequest - privacy dex limit order,
+// the order rests on the beacon chain and is filled against the pool pair of the tokens
+// while the pool price is not worse than MinAcceptableAmount / SellAmount, until ExpiryBeaconHeight
+type PDELimitOrderRequest struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64 // must be equal to vout value
+	MinAcceptableAmount uint64 // amount of token to buy for the whole SellAmount
+	TradingFee          uint64
+	TraderAddressStr    string
+	ExpiryBeaconHeight  uint64
+	MetadataBase
+}
+
+type PDELimitOrderRequestAction struct {
+	Meta    PDELimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type PDELimitOrderAcceptedContent struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	TradingFee          uint64
+	ExpiryBeaconHeight  uint64
+	ShardID             byte
+}
+
+type PDELimitOrderFilledContent struct {
+	OrderID                  common.Hash
+	TraderAddressStr         string
+	TokenIDToBuyStr          string
+	SellAmount               uint64
+	ReceiveAmount            uint64
+	Token1IDStr              string
+	Token2IDStr              string
+	Token1PoolValueOperation TokenPoolValueOperation
+	Token2PoolValueOperation TokenPoolValueOperation
+	AddingFee                uint64
+	ShardID                  byte
+}
+
+type PDERefundLimitOrder struct {
+	OrderID          common.Hash
+	TraderAddressStr string
+	TokenIDStr       string
+	Amount           uint64
+	OrderStatus      byte
+	ShardID          byte
+}
+
+func NewPDELimitOrderRequest(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	tradingFee uint64,
+	traderAddressStr string,
+	expiryBeaconHeight uint64,
+	metaType int,
+) (*PDELimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeLimitOrderRequest := &PDELimitOrderRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+		TraderAddressStr:    traderAddressStr,
+		ExpiryBeaconHeight:  expiryBeaconHeight,
+	}
+	pdeLimitOrderRequest.MetadataBase = metadataBase
+	return pdeLimitOrderRequest, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// NOTE: the pool pair and the expiry are verified on beacon chain
+	return true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	if beaconHeight < chainRetriever.GetBCHeightBreakPointPDELimitOrders() {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("pde limit orders are not enabled yet"))
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+
+	_, err = common.Hash{}.NewHashFromStr(pc.TokenIDToBuyStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TokenIDToBuyStr incorrect"))
+	}
+
+	if pc.TokenIDToSellStr == pc.TokenIDToBuyStr {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TokenIDToSellStr should be different from TokenIDToBuyStr"))
+	}
+
+	// limit orders are filled against one pool pair so one of the tokens must be PRV
+	if pc.TokenIDToSellStr != common.PRVCoinID.String() && pc.TokenIDToBuyStr != common.PRVCoinID.String() {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("Either TokenIDToSellStr or TokenIDToBuyStr should be PRV"))
+	}
+
+	if pc.SellAmount == 0 || pc.MinAcceptableAmount == 0 {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("SellAmount and MinAcceptableAmount should be larger than 0"))
+	}
+
+	if pc.ExpiryBeaconHeight <= beaconHeight {
+		return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("ExpiryBeaconHeight should be larger than the current beacon height"))
+	}
+
+	if tx.GetType() == common.TxNormalType {
+		if pc.TokenIDToSellStr != common.PRVCoinID.String() {
+			return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token")
+		}
+		if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+			return false, false, errors.New("Must send coin to burning address")
+		}
+		txValue := tx.CalculateTxValue()
+		if pc.SellAmount > txValue || pc.TradingFee > txValue {
+			return false, false, errors.New("Neither selling amount nor trading fee allows to be larger than the tx value")
+		}
+		if (pc.SellAmount + pc.TradingFee) != txValue {
+			return false, false, errors.New("Total of selling amount and trading fee should be equal to the tx value")
+		}
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		if pc.TokenIDToSellStr == common.PRVCoinID.String() {
+			return false, false, errors.New("With custom token privacy tx, the tokenIDStr should not be PRV, but custom token")
+		}
+		tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(PDELimitOrderRequestFromMapError, errors.New("TokenIDToSellStr incorrect"))
+		}
+		if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+			return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id")
+		}
+
+		if pc.TradingFee == 0 {
+			if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+				return false, false, errors.New("Must send custom coin to burning address")
+			}
+			pTokenAmt := tx.CalculateTxValue()
+			if pTokenAmt != pc.SellAmount {
+				return false, false, errors.New("Sell amount should be equal to the burned pToken amount")
+			}
+		} else {
+			if !tx.IsFullBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+				return false, false, errors.New("Must send coins to burning address")
+			}
+			prvAmt, pTokenAmt := tx.GetFullTxValues()
+			if prvAmt != pc.TradingFee {
+				return false, false, errors.New("Trading fee should be equal to the burned prv amount")
+			}
+			if pTokenAmt != pc.SellAmount {
+				return false, false, errors.New("Sell amount should be equal to the burned pToken amount")
+			}
+		}
+	}
+
+	return true, true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDELimitOrderRequestMeta
+}
+
+func (pc PDELimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	record += strconv.FormatUint(pc.TradingFee, 10)
+	record += strconv.FormatUint(pc.ExpiryBeaconHeight, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDELimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
+	actionContent := PDELimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDELimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
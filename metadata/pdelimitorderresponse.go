@@ -0,0 +1,152 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type PDELimitOrderResponse struct {
+	MetadataBase
+	TradeStatus   string
+	RequestedTxID common.Hash
+}
+
+func NewPDELimitOrderResponse(
+	tradeStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *PDELimitOrderResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &PDELimitOrderResponse{
+		TradeStatus:   tradeStatus,
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes PDELimitOrderResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes PDELimitOrderResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == PDELimitOrderResponseMeta
+}
+
+func (iRes PDELimitOrderResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.TradeStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *PDELimitOrderResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes PDELimitOrderResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	chainRetriever ChainRetriever,
+	ac *AccumulatedValues,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not PDELimitOrderRequestMeta instruction
+			continue
+		}
+		instMetaType := inst[0]
+		if instUsed[i] > 0 ||
+			instMetaType != strconv.Itoa(PDELimitOrderRequestMeta) {
+			continue
+		}
+		instTradeStatus := inst[2]
+		if instTradeStatus != iRes.TradeStatus ||
+			(instTradeStatus != common.PDELimitOrderFeeRefundChainStatus &&
+				instTradeStatus != common.PDELimitOrderSellingTokenRefundChainStatus &&
+				instTradeStatus != common.PDELimitOrderFilledChainStatus) {
+			continue
+		}
+
+		var shardIDFromInst byte
+		var orderIDFromInst common.Hash
+		var receiverAddrStrFromInst string
+		var receivingAmtFromInst uint64
+		var receivingTokenIDStr string
+		if instTradeStatus == common.PDELimitOrderFilledChainStatus {
+			var pdeLimitOrderFilledContent PDELimitOrderFilledContent
+			err := json.Unmarshal([]byte(inst[3]), &pdeLimitOrderFilledContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde limit order filled content: ", err)
+				continue
+			}
+			shardIDFromInst = pdeLimitOrderFilledContent.ShardID
+			orderIDFromInst = pdeLimitOrderFilledContent.OrderID
+			receiverAddrStrFromInst = pdeLimitOrderFilledContent.TraderAddressStr
+			receivingTokenIDStr = pdeLimitOrderFilledContent.TokenIDToBuyStr
+			receivingAmtFromInst = pdeLimitOrderFilledContent.ReceiveAmount
+		} else { // refund
+			var pdeRefundLimitOrder PDERefundLimitOrder
+			err := json.Unmarshal([]byte(inst[3]), &pdeRefundLimitOrder)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde refund limit order content: ", err)
+				continue
+			}
+			shardIDFromInst = pdeRefundLimitOrder.ShardID
+			orderIDFromInst = pdeRefundLimitOrder.OrderID
+			receiverAddrStrFromInst = pdeRefundLimitOrder.TraderAddressStr
+			receivingTokenIDStr = pdeRefundLimitOrder.TokenIDStr
+			receivingAmtFromInst = pdeRefundLimitOrder.Amount
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], orderIDFromInst[:]) ||
+			shardID != shardIDFromInst {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(receiverAddrStrFromInst)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			receivingTokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the limit order request tx for this response
+		return false, fmt.Errorf(fmt.Sprintf("no PDELimitOrderRequestMeta tx found for PDELimitOrderResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -104,7 +104,7 @@ func (pc PDEWithdrawalRequest) Hash() *common.Hash {
 	return &hash
 }
 
-func (pc *PDEWithdrawalRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (pc *PDEWithdrawalRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PDEWithdrawalRequestAction{
 		Meta:    *pc,
 		TxReqID: *tx.Hash(),
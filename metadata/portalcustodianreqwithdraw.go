@@ -113,7 +113,7 @@ func (withdrawReq PortalCustodianWithdrawRequest) Hash() *common.Hash {
 	return &hash
 }
 
-func (withdrawReq *PortalCustodianWithdrawRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (withdrawReq *PortalCustodianWithdrawRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalCustodianWithdrawRequestAction{
 		Meta:    *withdrawReq,
 		TxReqID: *tx.Hash(),
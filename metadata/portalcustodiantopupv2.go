@@ -109,7 +109,7 @@ func (custodianDeposit PortalLiquidationCustodianDepositV2) ValidateSanityData(c
 		return false, false, errors.New("both DepositedAmount and FreeCollateralAmount are zero")
 	}
 
-	if !IsPortalToken(custodianDeposit.PTokenId) {
+	if !IsPortalToken(chainRetriever, beaconHeight, custodianDeposit.PTokenId) {
 		return false, false, errors.New("TokenID in remote address is invalid")
 	}
 
@@ -131,7 +131,7 @@ func (custodianDeposit PortalLiquidationCustodianDepositV2) Hash() *common.Hash
 	return &hash
 }
 
-func (custodianDeposit *PortalLiquidationCustodianDepositV2) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (custodianDeposit *PortalLiquidationCustodianDepositV2) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalLiquidationCustodianDepositActionV2{
 		Meta:    *custodianDeposit,
 		TxReqID: *tx.Hash(),
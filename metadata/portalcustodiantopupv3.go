@@ -133,7 +133,7 @@ func (req PortalLiquidationCustodianDepositV3) ValidateSanityData(chainRetriever
 	}
 
 	// check PortalTokenID
-	if !IsPortalToken(req.PortalTokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, req.PortalTokenID) {
 		return false, false, errors.New("TokenID in remote address is invalid")
 	}
 
@@ -183,7 +183,7 @@ func (req PortalLiquidationCustodianDepositV3) Hash() *common.Hash {
 	return &hash
 }
 
-func (req *PortalLiquidationCustodianDepositV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (req *PortalLiquidationCustodianDepositV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalLiquidationCustodianDepositActionV3{
 		Meta:    *req,
 		TxReqID: *tx.Hash(),
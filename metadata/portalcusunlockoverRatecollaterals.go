@@ -86,7 +86,7 @@ func (portalUnlockCs PortalUnlockOverRateCollaterals) ValidateSanityData(chainRe
 	}
 
 	// check tokenId is portal token or not
-	if !IsPortalToken(portalUnlockCs.TokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, portalUnlockCs.TokenID) {
 		return false, false, NewMetadataTxError(PortalUnlockOverRateCollateralsError, errors.New("TokenID is not in portal tokens list"))
 	}
 
@@ -106,7 +106,7 @@ func (portalUnlockCs PortalUnlockOverRateCollaterals) Hash() *common.Hash {
 	return &hash
 }
 
-func (portalUnlockCs *PortalUnlockOverRateCollaterals) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (portalUnlockCs *PortalUnlockOverRateCollaterals) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalUnlockOverRateCollateralsAction{
 		Meta:    *portalUnlockCs,
 		TxReqID: *tx.Hash(),
@@ -58,6 +58,7 @@ type PortalExchangeRatesContent struct {
 	Rates         []*ExchangeRateInfo
 	TxReqID       common.Hash
 	LockTime      int64
+	RejectedRates []*ExchangeRateInfo `json:",omitempty"` // rates deviating too much from the final rates
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
@@ -73,9 +74,9 @@ func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
-	feederAddress := chainRetriever.GetPortalFeederAddress()
-	if portalExchangeRates.SenderAddress != feederAddress {
-		return false, false, fmt.Errorf("Sender must be feeder's address %v\n", feederAddress)
+	feederAddresses := chainRetriever.GetPortalFeederAddresses(beaconHeight)
+	if common.IndexOfStr(portalExchangeRates.SenderAddress, feederAddresses) == -1 {
+		return false, false, fmt.Errorf("Sender must be one of feeders' addresses %v\n", feederAddresses)
 	}
 
 	keyWallet, err := wallet.Base58CheckDeserialize(portalExchangeRates.SenderAddress)
@@ -126,7 +127,7 @@ func (portalExchangeRates PortalExchangeRates) Hash() *common.Hash {
 	return &hash
 }
 
-func (portalExchangeRates *PortalExchangeRates) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (portalExchangeRates *PortalExchangeRates) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalExchangeRatesAction{
 		Meta:     *portalExchangeRates,
 		TxReqID:  *tx.Hash(),
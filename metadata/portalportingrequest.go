@@ -139,7 +139,10 @@ func (portalUserRegister PortalUserRegister) ValidateSanityData(chainRetriever C
 	}
 
 	// validate amount register
-	minAmount := common.MinAmountPortalPToken[portalUserRegister.PTokenId]
+	minAmount, err := chainRetriever.GetMinAmountPortalToken(portalUserRegister.PTokenId, beaconHeight)
+	if err != nil {
+		return false, false, err
+	}
 	if portalUserRegister.RegisterAmount < minAmount {
 		return false, false, fmt.Errorf("register amount should be larger or equal to %v", minAmount)
 	}
@@ -178,7 +181,7 @@ func (portalUserRegister PortalUserRegister) Hash() *common.Hash {
 	return &hash
 }
 
-func (portalUserRegister *PortalUserRegister) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (portalUserRegister *PortalUserRegister) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	if portalUserRegister.Type == PortalRequestPortingMeta {
 		actionContent := PortalUserRegisterAction{
 			Meta:    *portalUserRegister,
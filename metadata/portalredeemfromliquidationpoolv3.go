@@ -104,7 +104,10 @@ func (redeemReq PortalRedeemFromLiquidationPoolV3) ValidateSanityData(chainRetri
 	}
 
 	// validate redeem amount
-	minAmount := common.MinAmountPortalPToken[redeemReq.TokenID]
+	minAmount, err := chainRetriever.GetMinAmountPortalToken(redeemReq.TokenID, beaconHeight)
+	if err != nil {
+		return false, false, err
+	}
 	if redeemReq.RedeemAmount < minAmount {
 		return false, false, fmt.Errorf("redeem amount should be larger or equal to %v", minAmount)
 	}
@@ -119,7 +122,7 @@ func (redeemReq PortalRedeemFromLiquidationPoolV3) ValidateSanityData(chainRetri
 		return false, false, NewMetadataTxError(PortalRedeemLiquidateExchangeRatesParamError, errors.New("TokenID in metadata is not matched to tokenID in tx"))
 	}
 	// check tokenId is portal token or not
-	if !IsPortalToken(redeemReq.TokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, redeemReq.TokenID) {
 		return false, false, NewMetadataTxError(PortalRedeemLiquidateExchangeRatesParamError, errors.New("TokenID is not in portal tokens list"))
 	}
 
@@ -148,7 +151,7 @@ func (redeemReq PortalRedeemFromLiquidationPoolV3) Hash() *common.Hash {
 	return &hash
 }
 
-func (redeemReq *PortalRedeemFromLiquidationPoolV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (redeemReq *PortalRedeemFromLiquidationPoolV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalRedeemFromLiquidationPoolActionV3{
 		Meta:    *redeemReq,
 		TxReqID: *tx.Hash(),
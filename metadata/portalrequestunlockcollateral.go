@@ -110,7 +110,7 @@ func (meta PortalRequestUnlockCollateral) ValidateSanityData(chainRetriever Chai
 	}
 
 	// validate tokenID
-	if !IsPortalToken(meta.TokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, meta.TokenID) {
 		return false, false, errors.New("TokenID is not a portal token")
 	}
 
@@ -133,7 +133,7 @@ func (meta PortalRequestUnlockCollateral) Hash() *common.Hash {
 	return &hash
 }
 
-func (meta *PortalRequestUnlockCollateral) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (meta *PortalRequestUnlockCollateral) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalRequestUnlockCollateralAction{
 		Meta:    *meta,
 		TxReqID: *tx.Hash(),
@@ -136,7 +136,7 @@ func (p PortalTopUpWaitingPortingRequest) ValidateSanityData(
 		return false, false, errors.New("both DepositedAmount and FreeCollateralAmount are zero")
 	}
 
-	if !IsPortalToken(p.PTokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, p.PTokenID) {
 		return false, false, errors.New("TokenID in remote address is invalid")
 	}
 
@@ -170,6 +170,7 @@ func (p *PortalTopUpWaitingPortingRequest) BuildReqActions(
 	beaconViewRetriever BeaconViewRetriever,
 	shardID byte,
 	shardHeight uint64,
+	beaconHeight uint64,
 ) ([][]string, error) {
 	actionContent := PortalTopUpWaitingPortingRequestAction{
 		Meta:    *p,
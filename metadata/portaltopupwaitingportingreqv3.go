@@ -140,7 +140,7 @@ func (req PortalTopUpWaitingPortingRequestV3) ValidateSanityData(chainRetriever
 	}
 
 	// check PortalTokenID
-	if !IsPortalToken(req.PortalTokenID) {
+	if !IsPortalToken(chainRetriever, beaconHeight, req.PortalTokenID) {
 		return false, false, errors.New("TokenID in remote address is invalid")
 	}
 
@@ -196,7 +196,7 @@ func (req PortalTopUpWaitingPortingRequestV3) Hash() *common.Hash {
 	return &hash
 }
 
-func (req *PortalTopUpWaitingPortingRequestV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (req *PortalTopUpWaitingPortingRequestV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := PortalTopUpWaitingPortingRequestActionV3{
 		Meta:    *req,
 		TxReqID: *tx.Hash(),
@@ -108,7 +108,7 @@ func (rh RelayingHeader) ValidateSanityData(chainRetriever ChainRetriever, shard
 }
 
 func (rh RelayingHeader) ValidateMetadataByItself() bool {
-	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta
+	return rh.Type == RelayingBNBHeaderMeta || rh.Type == RelayingBTCHeaderMeta || rh.Type == RelayingETHHeaderMeta || rh.Type == RelayingLTCHeaderMeta
 }
 
 func (rh RelayingHeader) Hash() *common.Hash {
@@ -122,7 +122,7 @@ func (rh RelayingHeader) Hash() *common.Hash {
 	return &hash
 }
 
-func (rh *RelayingHeader) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64) ([][]string, error) {
+func (rh *RelayingHeader) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, shardHeight uint64, beaconHeight uint64) ([][]string, error) {
 	actionContent := RelayingHeaderAction{
 		Meta:    *rh,
 		TxReqID: *tx.Hash(),
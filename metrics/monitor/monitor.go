@@ -25,6 +25,7 @@ import (
 var monitorFile *os.File
 var globalParam *logKV
 var blockchainObj *blockchain.BlockChain
+var monitorEndpoint string
 
 func getCPUSample() (idle, total uint64) {
 	contents, err := ioutil.ReadFile("/proc/stat")
@@ -146,6 +147,11 @@ func SetBlockChainObj(obj *blockchain.BlockChain) {
 	blockchainObj = obj
 }
 
+// SetMonitorEndpoint sets the url logs are posted to, no log is sent if it is empty
+func SetMonitorEndpoint(endpoint string) {
+	monitorEndpoint = endpoint
+}
+
 func NewLog(p ...interface{}) *logKV {
 	nl := (&logKV{param: make(map[string]interface{})}).Add(p...)
 	globalParam.RLock()
@@ -171,6 +177,9 @@ func (s *logKV) Add(p ...interface{}) *logKV {
 }
 
 func (s *logKV) Write() {
+	if monitorEndpoint == "" {
+		return
+	}
 	s.RLock()
 	defer s.RUnlock()
 	//fn, f, l := getMethodName(2)
@@ -185,13 +194,14 @@ func (s *logKV) Write() {
 	//io.Copy(monitorFile, bytes.NewReader(b))
 	//io.Copy(monitorFile, bytes.NewReader([]byte("\n")))
 
+	endpoint := monitorEndpoint
 	go func() {
-		req, err := http.NewRequest(http.MethodPost, "http://51.91.220.58:33333", bytes.NewBuffer(b))
-		req.Header.Set("Content-Type", "application/json")
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(b))
 		if err != nil {
 			metrics.IncLogger.Log.Debug("Create Request failed with err: ", err)
 			return
 		}
+		req.Header.Set("Content-Type", "application/json")
 		ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
 		defer cancel()
 		req = req.WithContext(ctx)
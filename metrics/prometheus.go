@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Names of the node metrics exported with labels
+const (
+	ChainHeight        = "chain_height"
+	BlockInsertTime    = "block_insert_seconds"
+	MempoolSize        = "mempool_size"
+	PeerCount          = "peer_count"
+	SyncLag            = "sync_lag_blocks"
+	BFTPhaseTime       = "bft_phase_seconds"
+	BFTVoteCount       = "bft_votes"
+	RPCRequestTime     = "rpc_request_seconds"
+	RPCRequestErrCount = "rpc_request_errors"
+)
+
+var prometheusQuantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// LabeledName returns the registry name of a metric with Prometheus labels,
+// e.g. LabeledName("chain_height", "chain", "shard", "shard", "0") returns
+// `chain_height{chain="shard",shard="0"}`. Other exporters use the name as is.
+func LabeledName(name string, labelPairs ...string) string {
+	if len(labelPairs) < 2 {
+		return name
+	}
+	labels := make([]string, 0, len(labelPairs)/2)
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		labels = append(labels, fmt.Sprintf("%s=\"%s\"", sanitizePrometheusName(labelPairs[i]), escapePrometheusLabelValue(labelPairs[i+1])))
+	}
+	return name + "{" + strings.Join(labels, ",") + "}"
+}
+
+type prometheusSample struct {
+	labels string
+	metric interface{}
+}
+
+// WritePrometheus writes all metrics of r in Prometheus text exposition format,
+// metric names are sanitized and prepended with prefix.
+// Counters and gauges are exported as is, meters as counters,
+// histograms and timers as summaries (timers in seconds).
+func WritePrometheus(w io.Writer, r Registry, prefix string) error {
+	families := map[string][]prometheusSample{}
+	r.Each(func(name string, i interface{}) {
+		baseName, labels := splitPrometheusName(name)
+		if prefix != "" {
+			baseName = prefix + "_" + baseName
+		}
+		baseName = sanitizePrometheusName(baseName)
+		families[baseName] = append(families[baseName], prometheusSample{labels: labels, metric: i})
+	})
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		samples := families[name]
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].labels < samples[j].labels
+		})
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, prometheusType(samples[0].metric))
+		for _, sample := range samples {
+			writePrometheusSample(bw, name, sample)
+		}
+	}
+	return bw.Flush()
+}
+
+// PrometheusHandler returns a http handler serving metrics of r in Prometheus text exposition format
+func PrometheusHandler(r Registry, prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		if err := WritePrometheus(w, r, prefix); err != nil {
+			IncLogger.Log.Errorf("Write prometheus metrics failed with err: %v", err)
+		}
+	})
+}
+
+func prometheusType(i interface{}) string {
+	switch i.(type) {
+	case Counter, Meter:
+		return "counter"
+	case Gauge, GaugeFloat64:
+		return "gauge"
+	case Histogram, Timer:
+		return "summary"
+	}
+	return "untyped"
+}
+
+func writePrometheusSample(w io.Writer, name string, sample prometheusSample) {
+	switch metric := sample.metric.(type) {
+	case Counter:
+		fmt.Fprintf(w, "%s%s %d\n", name, wrapPrometheusLabels(sample.labels), metric.Count())
+	case Gauge:
+		fmt.Fprintf(w, "%s%s %d\n", name, wrapPrometheusLabels(sample.labels), metric.Value())
+	case GaugeFloat64:
+		fmt.Fprintf(w, "%s%s %g\n", name, wrapPrometheusLabels(sample.labels), metric.Value())
+	case Meter:
+		fmt.Fprintf(w, "%s%s %d\n", name, wrapPrometheusLabels(sample.labels), metric.Snapshot().Count())
+	case Histogram:
+		h := metric.Snapshot()
+		ps := h.Percentiles(prometheusQuantiles)
+		for i, q := range prometheusQuantiles {
+			fmt.Fprintf(w, "%s%s %g\n", name, wrapPrometheusLabels(joinPrometheusLabels(sample.labels, fmt.Sprintf("quantile=\"%g\"", q))), ps[i])
+		}
+		fmt.Fprintf(w, "%s_sum%s %d\n", name, wrapPrometheusLabels(sample.labels), h.Sum())
+		fmt.Fprintf(w, "%s_count%s %d\n", name, wrapPrometheusLabels(sample.labels), h.Count())
+	case Timer:
+		t := metric.Snapshot()
+		ps := t.Percentiles(prometheusQuantiles)
+		for i, q := range prometheusQuantiles {
+			fmt.Fprintf(w, "%s%s %g\n", name, wrapPrometheusLabels(joinPrometheusLabels(sample.labels, fmt.Sprintf("quantile=\"%g\"", q))), ps[i]/1e9)
+		}
+		fmt.Fprintf(w, "%s_sum%s %g\n", name, wrapPrometheusLabels(sample.labels), float64(t.Sum())/1e9)
+		fmt.Fprintf(w, "%s_count%s %d\n", name, wrapPrometheusLabels(sample.labels), t.Count())
+	}
+}
+
+// splitPrometheusName splits a registry name made by LabeledName into the base name and the labels
+func splitPrometheusName(name string) (string, string) {
+	index := strings.Index(name, "{")
+	if index < 0 || !strings.HasSuffix(name, "}") {
+		return name, ""
+	}
+	return name[:index], name[index+1 : len(name)-1]
+}
+
+func joinPrometheusLabels(labels string, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func wrapPrometheusLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func sanitizePrometheusName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == ':' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func escapePrometheusLabelValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	return strings.Replace(value, `"`, `\"`, -1)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLabeledName(t *testing.T) {
+	if s := LabeledName(ChainHeight); s != "chain_height" {
+		t.Fatal(s)
+	}
+	if s := LabeledName(ChainHeight, "chain", "shard", "shard", "0"); s != `chain_height{chain="shard",shard="0"}` {
+		t.Fatal(s)
+	}
+	if s := LabeledName(RPCRequestTime, "rpc-method", "a\"b\\c\nd"); s != `rpc_request_seconds{rpc_method="a\"b\\c\nd"}` {
+		t.Fatal(s)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := NewRegistry()
+	NewRegisteredFunctionalGauge(LabeledName(ChainHeight, "chain", "shard", "shard", "1"), r, func() int64 { return 20 })
+	NewRegisteredFunctionalGauge(LabeledName(ChainHeight, "chain", "beacon"), r, func() int64 { return 10 })
+	NewRegisteredFunctionalGauge(LabeledName(ChainHeight, "chain", "shard", "shard", "0"), r, func() int64 { return 15 })
+	NewRegisteredCounter(LabeledName(RPCRequestErrCount, "method", "getbalance"), r).Inc(3)
+	NewRegisteredGaugeFloat64("mempool.fee-rate", r).Update(0.5)
+	h := NewRegisteredHistogram(BFTVoteCount, r, NewUniformSample(100))
+	for i := int64(1); i <= 4; i++ {
+		h.Update(i)
+	}
+	NewRegisteredTimer(LabeledName(RPCRequestTime, "method", "getbalance"), r).Update(2 * time.Second)
+
+	b := &bytes.Buffer{}
+	if err := WritePrometheus(b, r, "incognito"); err != nil {
+		t.Fatal(err)
+	}
+	want := `# TYPE incognito_bft_votes summary
+incognito_bft_votes{quantile="0.5"} 2.5
+incognito_bft_votes{quantile="0.75"} 3.75
+incognito_bft_votes{quantile="0.95"} 4
+incognito_bft_votes{quantile="0.99"} 4
+incognito_bft_votes{quantile="0.999"} 4
+incognito_bft_votes_sum 10
+incognito_bft_votes_count 4
+# TYPE incognito_chain_height gauge
+incognito_chain_height{chain="beacon"} 10
+incognito_chain_height{chain="shard",shard="0"} 15
+incognito_chain_height{chain="shard",shard="1"} 20
+# TYPE incognito_mempool_fee_rate gauge
+incognito_mempool_fee_rate 0.5
+# TYPE incognito_rpc_request_errors counter
+incognito_rpc_request_errors{method="getbalance"} 3
+# TYPE incognito_rpc_request_seconds summary
+incognito_rpc_request_seconds{method="getbalance",quantile="0.5"} 2
+incognito_rpc_request_seconds{method="getbalance",quantile="0.75"} 2
+incognito_rpc_request_seconds{method="getbalance",quantile="0.95"} 2
+incognito_rpc_request_seconds{method="getbalance",quantile="0.99"} 2
+incognito_rpc_request_seconds{method="getbalance",quantile="0.999"} 2
+incognito_rpc_request_seconds_sum{method="getbalance"} 2
+incognito_rpc_request_seconds_count{method="getbalance"} 1
+`
+	if s := b.String(); s != want {
+		t.Fatalf("want\n%s\nbut got\n%s", want, s)
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	r := NewRegistry()
+	NewRegisteredCounter(MempoolSize, r).Inc(1)
+	w := httptest.NewRecorder()
+	PrometheusHandler(r, "").ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := w.Header().Get("Content-Type"); ct != PrometheusContentType {
+		t.Fatal(ct)
+	}
+	if s := w.Body.String(); s != "# TYPE mempool_size counter\nmempool_size 1\n" {
+		t.Fatal(s)
+	}
+}
@@ -38,3 +38,38 @@ var testNet2Params = params{
 func netName(chainParams *params) string {
 	return chainParams.Name
 }
+
+// loadChainConfigParams builds the parameters of a network defined by a chain config file,
+// the rpc and websocket ports are the ports of its base network
+func loadChainConfigParams(path string) (*params, error) {
+	chainConfig, err := blockchain.LoadChainConfigFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	chainParams, err := chainConfig.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	// the header chain of the pLTC tokens is created at startup, a network it does not support is refused here
+	ltcRelayingChainID, err := chainParams.GetLTCRelayingChainID()
+	if err != nil {
+		return nil, err
+	}
+	if ltcRelayingChainID != "" {
+		if _, err := getLTCRelayingChainConfig(ltcRelayingChainID); err != nil {
+			return nil, err
+		}
+	}
+	base := testNetParams
+	switch chainConfig.Base {
+	case blockchain.ChainConfigBaseMainnet:
+		base = mainNetParams
+	case blockchain.ChainConfigBaseTestnet2:
+		base = testNet2Params
+	}
+	return &params{
+		Params:  chainParams,
+		rpcPort: base.rpcPort,
+		wsPort:  base.wsPort,
+	}, nil
+}
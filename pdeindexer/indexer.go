@@ -0,0 +1,458 @@
+package pdeindexer
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// BeaconChainReader is the access of the indexer to the beacon chain
+type BeaconChainReader interface {
+	blockfollower.BeaconChain
+	GetPDEStateByHeight(beaconHeight uint64) (*blockchain.CurrentPDEState, error)
+}
+
+type Config struct {
+	DB            incdb.Database
+	BlockChain    BeaconChainReader
+	PubSubManager *pubsub.PubSubManager
+}
+
+// Indexer stores the history of pool reserves, trades, trading fees and liquidity events of the PDE
+// in its own database as final beacon blocks are inserted
+type Indexer struct {
+	config   Config
+	follower *blockfollower.Follower
+
+	mtx                 sync.Mutex
+	latestIndexedHeight uint64
+	prevPDEState        *blockchain.CurrentPDEState
+}
+
+func NewIndexer(config Config) (*Indexer, error) {
+	if config.DB == nil || config.BlockChain == nil {
+		return nil, errors.New("database and blockchain of pde indexer must not be nil")
+	}
+	indexer := &Indexer{config: config}
+	indexer.follower = blockfollower.New(config.PubSubManager, []string{pubsub.NewBeaconBlockTopic}, indexer.follow)
+	var err error
+	indexer.latestIndexedHeight, err = blockfollower.GetStoredHeight(config.DB, latestIndexedHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	return indexer, nil
+}
+
+// Start indexes final beacon blocks in background
+func (indexer *Indexer) Start() error {
+	return indexer.follower.Start()
+}
+
+// Stop stops indexing and closes the database after the block being indexed is stored
+func (indexer *Indexer) Stop() {
+	indexer.follower.Stop()
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	err := indexer.config.DB.Close()
+	if err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (indexer *Indexer) follow() {
+	err := indexer.IndexFinalBlocks()
+	if err != nil {
+		Logger.log.Errorf("Index pde instructions of beacon blocks failed: %v", err)
+	}
+}
+
+// GetLatestIndexedHeight returns the height of the latest indexed beacon block
+func (indexer *Indexer) GetLatestIndexedHeight() uint64 {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	return indexer.latestIndexedHeight
+}
+
+// IndexFinalBlocks indexes beacon blocks from the latest indexed one to the final view
+func (indexer *Indexer) IndexFinalBlocks() error {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	bc := indexer.config.BlockChain
+	if indexer.latestIndexedHeight >= bc.GetFinalBeaconHeight() {
+		return nil
+	}
+	if indexer.prevPDEState == nil {
+		if indexer.latestIndexedHeight == 0 {
+			indexer.prevPDEState = newEmptyPDEState()
+		} else {
+			prevPDEState, err := bc.GetPDEStateByHeight(indexer.latestIndexedHeight)
+			if err != nil {
+				return err
+			}
+			indexer.prevPDEState = prevPDEState
+		}
+	}
+	return indexer.follower.FollowBeacon(bc, indexer.latestIndexedHeight+1, func(beaconBlock *blockchain.BeaconBlock) error {
+		pdeState, err := bc.GetPDEStateByHeight(beaconBlock.Header.Height)
+		if err != nil {
+			return err
+		}
+		return indexer.indexBeaconBlock(beaconBlock, pdeState)
+	})
+}
+
+func newEmptyPDEState() *blockchain.CurrentPDEState {
+	return &blockchain.CurrentPDEState{
+		WaitingPDEContributions:        map[string]*rawdbv2.PDEContribution{},
+		DeletedWaitingPDEContributions: map[string]*rawdbv2.PDEContribution{},
+		PDEPoolPairs:                   map[string]*rawdbv2.PDEPoolForPair{},
+		PDEShares:                      map[string]uint64{},
+		PDETradingFees:                 map[string]uint64{},
+		PDELimitOrders:                 map[string]*rawdbv2.PDELimitOrder{},
+		DeletedPDELimitOrders:          map[string]*rawdbv2.PDELimitOrder{},
+	}
+}
+
+// indexBeaconBlock stores the records of a beacon block, pdeState is the state after the block is processed
+func (indexer *Indexer) indexBeaconBlock(beaconBlock *blockchain.BeaconBlock, pdeState *blockchain.CurrentPDEState) error {
+	beaconHeight := beaconBlock.Header.Height
+	timeStamp := beaconBlock.Header.Timestamp
+	batch := indexer.config.DB.NewBatch()
+	putRecord := func(key []byte, record interface{}) error {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		return batch.Put(key, value)
+	}
+
+	trades, liquidityEvents := indexer.parsePDEInsts(beaconBlock.Body.Instructions, beaconHeight-1)
+	for idx, trade := range trades {
+		trade.BeaconHeight = beaconHeight
+		trade.BeaconTimeStamp = timeStamp
+		err := putRecord(buildTradeKey(trade.TokenIDToSellStr, trade.TokenIDToBuyStr, beaconHeight, idx), trade)
+		if err != nil {
+			return err
+		}
+	}
+	for idx, event := range liquidityEvents {
+		event.BeaconHeight = beaconHeight
+		event.BeaconTimeStamp = timeStamp
+		err := putRecord(buildLiquidityKey(event.Token1IDStr, event.Token2IDStr, beaconHeight, idx), event)
+		if err != nil {
+			return err
+		}
+	}
+
+	// reserves and trading fees are compared with the previous state
+	// so changes of all kinds of instructions are recorded
+	prevPools := mapPoolsByPair(indexer.prevPDEState.PDEPoolPairs)
+	for pairKey, pool := range mapPoolsByPair(pdeState.PDEPoolPairs) {
+		prevPool, found := prevPools[pairKey]
+		if found && prevPool.Token1IDStr == pool.Token1IDStr &&
+			prevPool.Token1PoolValue == pool.Token1PoolValue && prevPool.Token2PoolValue == pool.Token2PoolValue {
+			continue
+		}
+		err := putRecord(buildReserveKey(pool.Token1IDStr, pool.Token2IDStr, beaconHeight), &PoolReserve{
+			BeaconHeight:    beaconHeight,
+			BeaconTimeStamp: timeStamp,
+			Token1IDStr:     pool.Token1IDStr,
+			Token1PoolValue: pool.Token1PoolValue,
+			Token2IDStr:     pool.Token2IDStr,
+			Token2PoolValue: pool.Token2PoolValue,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	prevFees := mapTradingFeesByContributor(indexer.prevPDEState.PDETradingFees)
+	fees := mapTradingFeesByContributor(pdeState.PDETradingFees)
+	for feeKey := range prevFees {
+		if _, found := fees[feeKey]; !found {
+			fees[feeKey] = 0
+		}
+	}
+	for feeKey, total := range fees {
+		prevTotal := prevFees[feeKey]
+		if total == prevTotal {
+			continue
+		}
+		token1IDStr, token2IDStr, contributorAddressStr := parseTradingFeeKey(feeKey)
+		feeAccrual := &FeeAccrual{
+			BeaconHeight:          beaconHeight,
+			BeaconTimeStamp:       timeStamp,
+			Token1IDStr:           token1IDStr,
+			Token2IDStr:           token2IDStr,
+			ContributorAddressStr: contributorAddressStr,
+			Total:                 total,
+		}
+		if total > prevTotal {
+			feeAccrual.Accrued = total - prevTotal
+		} else {
+			feeAccrual.Withdrawn = prevTotal - total
+		}
+		err := putRecord(buildFeeKey(token1IDStr, token2IDStr, beaconHeight, contributorAddressStr), feeAccrual)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := batch.Put(latestIndexedHeightKey, []byte(strconv.FormatUint(beaconHeight, 10)))
+	if err != nil {
+		return err
+	}
+	err = batch.Write()
+	if err != nil {
+		return err
+	}
+	indexer.latestIndexedHeight = beaconHeight
+	indexer.prevPDEState = pdeState
+	return nil
+}
+
+// parsePDEInsts returns the trades and liquidity events of the pde instructions of a beacon block,
+// prevHeight is the height of the previous pde state which holds the waiting contributions
+func (indexer *Indexer) parsePDEInsts(insts [][]string, prevHeight uint64) ([]*Trade, []*LiquidityEvent) {
+	trades := []*Trade{}
+	liquidityEvents := []*LiquidityEvent{}
+
+	waitingContributionKeyPrefix := string(rawdbv2.BuildWaitingPDEContributionKey(prevHeight, ""))
+	waitingContributions := map[string]*rawdbv2.PDEContribution{}
+	for key, contribution := range indexer.prevPDEState.WaitingPDEContributions {
+		waitingContributions[strings.TrimPrefix(key, waitingContributionKeyPrefix)] = contribution
+	}
+	matchedNReturnedContributions := map[string][]*metadata.PDEMatchedNReturnedContribution{}
+	matchedNReturnedPairIDs := []string{}
+
+	for _, inst := range insts {
+		if len(inst) < 4 {
+			continue // Not error, just not PDE instruction
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.PDEContributionMeta), strconv.Itoa(metadata.PDEPRVRequiredContributionRequestMeta):
+			switch inst[2] {
+			case common.PDEContributionWaitingChainStatus:
+				var waitingContribution metadata.PDEWaitingContribution
+				if json.Unmarshal([]byte(inst[3]), &waitingContribution) != nil {
+					continue
+				}
+				waitingContributions[waitingContribution.PDEContributionPairID] = &rawdbv2.PDEContribution{
+					ContributorAddressStr: waitingContribution.ContributorAddressStr,
+					TokenIDStr:            waitingContribution.TokenIDStr,
+					Amount:                waitingContribution.ContributedAmount,
+					TxReqID:               waitingContribution.TxReqID,
+				}
+			case common.PDEContributionRefundChainStatus:
+				var refundContribution metadata.PDERefundContribution
+				if json.Unmarshal([]byte(inst[3]), &refundContribution) != nil {
+					continue
+				}
+				delete(waitingContributions, refundContribution.PDEContributionPairID)
+			case common.PDEContributionMatchedChainStatus:
+				var matchedContribution metadata.PDEMatchedContribution
+				if json.Unmarshal([]byte(inst[3]), &matchedContribution) != nil {
+					continue
+				}
+				waitingContribution, found := waitingContributions[matchedContribution.PDEContributionPairID]
+				if !found {
+					Logger.log.Warnf("Waiting contribution of matched contribution pair %v is not found", matchedContribution.PDEContributionPairID)
+					continue
+				}
+				delete(waitingContributions, matchedContribution.PDEContributionPairID)
+				token1IDStr, token2IDStr := sortTokenIDs(waitingContribution.TokenIDStr, matchedContribution.TokenIDStr)
+				liquidityEvents = append(liquidityEvents, &LiquidityEvent{
+					Type:                  LiquidityContribution,
+					Token1IDStr:           token1IDStr,
+					Token2IDStr:           token2IDStr,
+					ContributorAddressStr: waitingContribution.ContributorAddressStr,
+					TokenIDStr:            waitingContribution.TokenIDStr,
+					Amount:                waitingContribution.Amount,
+					TxReqID:               waitingContribution.TxReqID,
+				}, &LiquidityEvent{
+					Type:                  LiquidityContribution,
+					Token1IDStr:           token1IDStr,
+					Token2IDStr:           token2IDStr,
+					ContributorAddressStr: matchedContribution.ContributorAddressStr,
+					TokenIDStr:            matchedContribution.TokenIDStr,
+					Amount:                matchedContribution.ContributedAmount,
+					TxReqID:               matchedContribution.TxReqID,
+				})
+			case common.PDEContributionMatchedNReturnedChainStatus:
+				// there is an instruction for each side of the pair
+				var matchedNReturnedContribution metadata.PDEMatchedNReturnedContribution
+				if json.Unmarshal([]byte(inst[3]), &matchedNReturnedContribution) != nil {
+					continue
+				}
+				pairID := matchedNReturnedContribution.PDEContributionPairID
+				if _, found := matchedNReturnedContributions[pairID]; !found {
+					matchedNReturnedPairIDs = append(matchedNReturnedPairIDs, pairID)
+				}
+				matchedNReturnedContributions[pairID] = append(matchedNReturnedContributions[pairID], &matchedNReturnedContribution)
+			}
+
+		case strconv.Itoa(metadata.PDETradeRequestMeta):
+			if inst[2] != common.PDETradeAcceptedChainStatus {
+				continue
+			}
+			var tradeAcceptedContent metadata.PDETradeAcceptedContent
+			if json.Unmarshal([]byte(inst[3]), &tradeAcceptedContent) != nil {
+				continue
+			}
+			trades = append(trades, newTrade(
+				tradeAcceptedContent.TraderAddressStr,
+				tradeAcceptedContent.RequestedTxID,
+				tradeAcceptedContent.TokenIDToBuyStr,
+				tradeAcceptedContent.ReceiveAmount,
+				tradeAcceptedContent.Token1IDStr,
+				tradeAcceptedContent.Token2IDStr,
+				tradeAcceptedContent.Token1PoolValueOperation,
+				tradeAcceptedContent.Token2PoolValueOperation,
+			))
+
+		case strconv.Itoa(metadata.PDECrossPoolTradeRequestMeta):
+			if inst[2] != common.PDECrossPoolTradeAcceptedChainStatus {
+				continue
+			}
+			var tradeAcceptedContents []metadata.PDECrossPoolTradeAcceptedContent
+			if json.Unmarshal([]byte(inst[3]), &tradeAcceptedContents) != nil {
+				continue
+			}
+			for _, content := range tradeAcceptedContents {
+				trades = append(trades, newTrade(
+					content.TraderAddressStr,
+					content.RequestedTxID,
+					content.TokenIDToBuyStr,
+					content.ReceiveAmount,
+					content.Token1IDStr,
+					content.Token2IDStr,
+					content.Token1PoolValueOperation,
+					content.Token2PoolValueOperation,
+				))
+			}
+
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			if inst[2] != common.PDELimitOrderFilledChainStatus {
+				continue
+			}
+			var filledContent metadata.PDELimitOrderFilledContent
+			if json.Unmarshal([]byte(inst[3]), &filledContent) != nil {
+				continue
+			}
+			trades = append(trades, newTrade(
+				filledContent.TraderAddressStr,
+				filledContent.OrderID,
+				filledContent.TokenIDToBuyStr,
+				filledContent.ReceiveAmount,
+				filledContent.Token1IDStr,
+				filledContent.Token2IDStr,
+				filledContent.Token1PoolValueOperation,
+				filledContent.Token2PoolValueOperation,
+			))
+
+		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+			if inst[2] != common.PDEWithdrawalAcceptedChainStatus {
+				continue
+			}
+			var withdrawalAcceptedContent metadata.PDEWithdrawalAcceptedContent
+			if json.Unmarshal([]byte(inst[3]), &withdrawalAcceptedContent) != nil {
+				continue
+			}
+			token1IDStr, token2IDStr := sortTokenIDs(withdrawalAcceptedContent.PairToken1IDStr, withdrawalAcceptedContent.PairToken2IDStr)
+			liquidityEvents = append(liquidityEvents, &LiquidityEvent{
+				Type:                  LiquidityWithdrawal,
+				Token1IDStr:           token1IDStr,
+				Token2IDStr:           token2IDStr,
+				ContributorAddressStr: withdrawalAcceptedContent.WithdrawerAddressStr,
+				TokenIDStr:            withdrawalAcceptedContent.WithdrawalTokenIDStr,
+				Amount:                withdrawalAcceptedContent.DeductingPoolValue,
+				DeductingShares:       withdrawalAcceptedContent.DeductingShares,
+				TxReqID:               withdrawalAcceptedContent.TxReqID,
+			})
+		}
+	}
+
+	for _, pairID := range matchedNReturnedPairIDs {
+		contributions := matchedNReturnedContributions[pairID]
+		if len(contributions) != 2 {
+			Logger.log.Warnf("Matched and returned contribution pair %v has %v instructions", pairID, len(contributions))
+			continue
+		}
+		token1IDStr, token2IDStr := sortTokenIDs(contributions[0].TokenIDStr, contributions[1].TokenIDStr)
+		for _, contribution := range contributions {
+			liquidityEvents = append(liquidityEvents, &LiquidityEvent{
+				Type:                  LiquidityContribution,
+				Token1IDStr:           token1IDStr,
+				Token2IDStr:           token2IDStr,
+				ContributorAddressStr: contribution.ContributorAddressStr,
+				TokenIDStr:            contribution.TokenIDStr,
+				Amount:                contribution.ActualContributedAmount,
+				TxReqID:               contribution.TxReqID,
+			})
+		}
+	}
+	return trades, liquidityEvents
+}
+
+func newTrade(
+	traderAddressStr string,
+	requestedTxID common.Hash,
+	tokenIDToBuyStr string,
+	receiveAmount uint64,
+	token1IDStr string,
+	token2IDStr string,
+	token1PoolValueOperation metadata.TokenPoolValueOperation,
+	token2PoolValueOperation metadata.TokenPoolValueOperation,
+) *Trade {
+	trade := &Trade{
+		RequestedTxID:    requestedTxID,
+		TraderAddressStr: traderAddressStr,
+		TokenIDToSellStr: token2IDStr,
+		SellAmount:       token2PoolValueOperation.Value,
+		TokenIDToBuyStr:  tokenIDToBuyStr,
+		ReceiveAmount:    receiveAmount,
+	}
+	if token1PoolValueOperation.Operator == "+" {
+		trade.TokenIDToSellStr = token1IDStr
+		trade.SellAmount = token1PoolValueOperation.Value
+	}
+	return trade
+}
+
+// mapPoolsByPair maps pool pairs by their tokens instead of the keys containing the beacon height
+func mapPoolsByPair(poolPairs map[string]*rawdbv2.PDEPoolForPair) map[string]*rawdbv2.PDEPoolForPair {
+	result := map[string]*rawdbv2.PDEPoolForPair{}
+	for _, pool := range poolPairs {
+		token1IDStr, token2IDStr := sortTokenIDs(pool.Token1IDStr, pool.Token2IDStr)
+		result[token1IDStr+"-"+token2IDStr] = pool
+	}
+	return result
+}
+
+// mapTradingFeesByContributor removes the beacon height from the keys of trading fees,
+// the keys are in the form of prefix-height-token1-token2-contributor
+func mapTradingFeesByContributor(tradingFees map[string]uint64) map[string]uint64 {
+	result := map[string]uint64{}
+	for key, fee := range tradingFees {
+		parts := strings.Split(key, "-")
+		if len(parts) < 5 {
+			continue
+		}
+		result[strings.Join(parts[len(parts)-3:], "-")] = fee
+	}
+	return result
+}
+
+func parseTradingFeeKey(key string) (string, string, string) {
+	parts := strings.Split(key, "-")
+	return parts[0], parts[1], parts[2]
+}
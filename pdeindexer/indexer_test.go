@@ -0,0 +1,246 @@
+package pdeindexer
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower/followertest"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+const (
+	testTokenIDStr       = "0000000000000000000000000000000000000000000000000000000000000005"
+	testContributor1Addr = "contributor1"
+	testContributor2Addr = "contributor2"
+	testTraderAddr       = "trader"
+)
+
+type fakeBeaconChain struct {
+	*followertest.Chain
+	pdeStates map[uint64]*blockchain.CurrentPDEState
+}
+
+func (bc *fakeBeaconChain) GetPDEStateByHeight(beaconHeight uint64) (*blockchain.CurrentPDEState, error) {
+	pdeState, ok := bc.pdeStates[beaconHeight]
+	if !ok {
+		return nil, errors.New("pde state not found")
+	}
+	return pdeState, nil
+}
+
+// addBlock adds a block with insts and the pde state after it with a pool of PRV and the test token and fees of contributor1
+func (bc *fakeBeaconChain) addBlock(height uint64, insts [][]string, prvPoolValue uint64, tokenPoolValue uint64, fee uint64) {
+	prvIDStr := common.PRVCoinID.String()
+	pdeState := newEmptyPDEState()
+	if prvPoolValue > 0 {
+		token1IDStr, token2IDStr := sortTokenIDs(prvIDStr, testTokenIDStr)
+		pool := rawdbv2.NewPDEPoolForPair(token1IDStr, prvPoolValue, token2IDStr, tokenPoolValue)
+		if token1IDStr != prvIDStr {
+			pool = rawdbv2.NewPDEPoolForPair(token1IDStr, tokenPoolValue, token2IDStr, prvPoolValue)
+		}
+		pdeState.PDEPoolPairs[string(rawdbv2.BuildPDEPoolForPairKey(height, prvIDStr, testTokenIDStr))] = pool
+	}
+	if fee > 0 {
+		pdeState.PDETradingFees[string(rawdbv2.BuildPDETradingFeeKey(height, prvIDStr, testTokenIDStr, testContributor1Addr))] = fee
+	}
+	bc.pdeStates[height] = pdeState
+	bc.AddBeaconBlock(&blockchain.BeaconBlock{
+		Header: blockchain.BeaconHeader{Height: height, Timestamp: int64(height * 40)},
+		Body:   blockchain.BeaconBody{Instructions: insts},
+	})
+}
+
+func buildTestInst(metaType int, status string, content interface{}) []string {
+	contentBytes, _ := json.Marshal(content)
+	return []string{strconv.Itoa(metaType), "0", status, string(contentBytes)}
+}
+
+func buildTestTradeInst(sellPRV bool, sellAmount uint64, receiveAmount uint64) []string {
+	prvIDStr := common.PRVCoinID.String()
+	content := metadata.PDETradeAcceptedContent{
+		TraderAddressStr:         testTraderAddr,
+		TokenIDToBuyStr:          testTokenIDStr,
+		ReceiveAmount:            receiveAmount,
+		Token1IDStr:              prvIDStr,
+		Token2IDStr:              testTokenIDStr,
+		Token1PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "+", Value: sellAmount},
+		Token2PoolValueOperation: metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmount},
+	}
+	if !sellPRV {
+		content.TokenIDToBuyStr = prvIDStr
+		content.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmount}
+		content.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: sellAmount}
+	}
+	return buildTestInst(metadata.PDETradeRequestMeta, common.PDETradeAcceptedChainStatus, content)
+}
+
+func newTestIndexer(t *testing.T) (*Indexer, *fakeBeaconChain, incdb.Database, func()) {
+	db, cleanup := followertest.OpenDB(t)
+	bc := &fakeBeaconChain{
+		Chain:     followertest.NewChain(),
+		pdeStates: map[uint64]*blockchain.CurrentPDEState{},
+	}
+	indexer, err := NewIndexer(Config{DB: db, BlockChain: bc})
+	assert.Nil(t, err)
+	return indexer, bc, db, cleanup
+}
+
+func TestIndexFinalBlocks(t *testing.T) {
+	indexer, bc, db, cleanup := newTestIndexer(t)
+	defer cleanup()
+	prvIDStr := common.PRVCoinID.String()
+
+	// a contribution waits in block 1 and is matched in block 2
+	bc.addBlock(1, [][]string{
+		buildTestInst(metadata.PDEContributionMeta, common.PDEContributionWaitingChainStatus, metadata.PDEWaitingContribution{
+			PDEContributionPairID: "pair",
+			ContributorAddressStr: testContributor1Addr,
+			ContributedAmount:     1000,
+			TokenIDStr:            prvIDStr,
+		}),
+	}, 0, 0, 0)
+	bc.pdeStates[1].WaitingPDEContributions[string(rawdbv2.BuildWaitingPDEContributionKey(1, "pair"))] = &rawdbv2.PDEContribution{
+		ContributorAddressStr: testContributor1Addr,
+		TokenIDStr:            prvIDStr,
+		Amount:                1000,
+	}
+	bc.addBlock(2, [][]string{
+		buildTestInst(metadata.PDEContributionMeta, common.PDEContributionMatchedChainStatus, metadata.PDEMatchedContribution{
+			PDEContributionPairID: "pair",
+			ContributorAddressStr: testContributor1Addr,
+			ContributedAmount:     2000,
+			TokenIDStr:            testTokenIDStr,
+		}),
+	}, 1000, 2000, 0)
+	bc.addBlock(3, [][]string{}, 1000, 2000, 0)
+	bc.addBlock(4, [][]string{buildTestTradeInst(true, 1000, 1000)}, 2000, 1000, 10)
+	bc.addBlock(5, [][]string{buildTestTradeInst(false, 1000, 666)}, 1334, 2000, 20)
+	bc.addBlock(6, [][]string{
+		buildTestInst(metadata.PDEWithdrawalRequestMeta, common.PDEWithdrawalAcceptedChainStatus, metadata.PDEWithdrawalAcceptedContent{
+			WithdrawalTokenIDStr: prvIDStr,
+			WithdrawerAddressStr: testContributor1Addr,
+			DeductingPoolValue:   334,
+			DeductingShares:      100,
+			PairToken1IDStr:      testTokenIDStr,
+			PairToken2IDStr:      prvIDStr,
+		}),
+	}, 1000, 2000, 5)
+
+	err := indexer.IndexFinalBlocks()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), indexer.GetLatestIndexedHeight())
+
+	// only changes of reserves are recorded
+	reserves, err := indexer.GetReserves(prvIDStr, testTokenIDStr, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(reserves))
+	assert.Equal(t, []uint64{2, 4, 5, 6}, []uint64{reserves[0].BeaconHeight, reserves[1].BeaconHeight, reserves[2].BeaconHeight, reserves[3].BeaconHeight})
+
+	events, err := indexer.GetLiquidityEvents(testTokenIDStr, prvIDStr, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, LiquidityContribution, events[0].Type)
+	assert.Equal(t, uint64(1000), events[0].Amount)
+	assert.Equal(t, prvIDStr, events[0].TokenIDStr)
+	assert.Equal(t, uint64(2000), events[1].Amount)
+	assert.Equal(t, LiquidityWithdrawal, events[2].Type)
+	assert.Equal(t, uint64(100), events[2].DeductingShares)
+
+	volume, err := indexer.GetPairVolume(testTokenIDStr, prvIDStr, 4, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), volume.TradeCount)
+	if volume.Token1IDStr == prvIDStr {
+		assert.Equal(t, uint64(1666), volume.Token1Volume)
+		assert.Equal(t, uint64(2000), volume.Token2Volume)
+	} else {
+		assert.Equal(t, uint64(2000), volume.Token1Volume)
+		assert.Equal(t, uint64(1666), volume.Token2Volume)
+	}
+	volume, err = indexer.GetPairVolume(testTokenIDStr, prvIDStr, 5, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), volume.TradeCount)
+
+	fees, err := indexer.GetFeeHistory(prvIDStr, testTokenIDStr, testContributor1Addr, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(fees))
+	assert.Equal(t, uint64(10), fees[0].Accrued)
+	assert.Equal(t, uint64(10), fees[1].Accrued)
+	assert.Equal(t, uint64(15), fees[2].Withdrawn)
+	assert.Equal(t, uint64(5), fees[2].Total)
+	fees, err = indexer.GetFeeHistory(prvIDStr, testTokenIDStr, testContributor2Addr, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(fees))
+
+	// the indexer continues from the indexed height after it is restarted
+	bc.addBlock(7, [][]string{}, 1000, 1000, 5)
+	indexer, err = NewIndexer(Config{DB: db, BlockChain: bc})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), indexer.GetLatestIndexedHeight())
+	err = indexer.IndexFinalBlocks()
+	assert.Nil(t, err)
+	reserves, err = indexer.GetReserves(prvIDStr, testTokenIDStr, 7, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(reserves))
+	fees, err = indexer.GetFeeHistory(prvIDStr, testTokenIDStr, "", 7, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(fees))
+}
+
+func TestGetCandles(t *testing.T) {
+	indexer, bc, _, cleanup := newTestIndexer(t)
+	defer cleanup()
+	prvIDStr := common.PRVCoinID.String()
+
+	bc.addBlock(1, [][]string{}, 0, 0, 0)
+	bc.addBlock(2, [][]string{}, 1000, 2000, 0)
+	bc.addBlock(3, [][]string{buildTestTradeInst(true, 1000, 1000)}, 2000, 1000, 0)
+	bc.addBlock(4, [][]string{buildTestTradeInst(false, 1000, 1000)}, 1000, 2000, 0)
+	bc.addBlock(5, [][]string{}, 1000, 2000, 0)
+	bc.addBlock(6, [][]string{}, 1000, 2000, 0)
+	bc.addBlock(7, [][]string{}, 1000, 4000, 0)
+	err := indexer.IndexFinalBlocks()
+	assert.Nil(t, err)
+
+	// price of PRV in the token
+	candles, err := indexer.GetCandles(prvIDStr, testTokenIDStr, 1, 8, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(candles))
+	assert.Equal(t, uint64(1), candles[0].FromHeight)
+	assert.Equal(t, float64(2), candles[0].Open)
+	assert.Equal(t, float64(2), candles[0].Close)
+	assert.Equal(t, uint64(3), candles[1].FromHeight)
+	assert.Equal(t, float64(2), candles[1].Open)
+	assert.Equal(t, float64(2), candles[1].High)
+	assert.Equal(t, float64(0.5), candles[1].Low)
+	assert.Equal(t, float64(2), candles[1].Close)
+	assert.Equal(t, uint64(2), candles[1].TradeCount)
+	assert.Equal(t, uint64(2000), candles[1].Volume)
+	assert.Equal(t, uint64(2000), candles[1].QuoteVolume)
+	assert.Equal(t, float64(2), candles[2].Open)
+	assert.Equal(t, float64(2), candles[2].Close)
+	assert.Equal(t, uint64(0), candles[2].TradeCount)
+	assert.Equal(t, float64(4), candles[3].Close)
+	assert.Equal(t, uint64(8), candles[3].ToHeight)
+
+	// the price before the range opens the first candle
+	candles, err = indexer.GetCandles(testTokenIDStr, prvIDStr, 5, 6, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(candles))
+	assert.Equal(t, float64(0.5), candles[0].Open)
+	assert.Equal(t, float64(0.5), candles[1].Close)
+
+	_, err = indexer.GetCandles(prvIDStr, testTokenIDStr, 1, 2*MaxCandles, 1)
+	assert.NotNil(t, err)
+}
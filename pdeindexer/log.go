@@ -0,0 +1,14 @@
+package pdeindexer
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type PDEIndexerLogger struct {
+	log common.Logger
+}
+
+func (logger *PDEIndexerLogger) Init(inst common.Logger) {
+	logger.log = inst
+}
+
+// Global instant to use
+var Logger = PDEIndexerLogger{}
@@ -0,0 +1,278 @@
+package pdeindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// maximum number of candles returned by a query
+const MaxCandles = 1000
+
+// Candle is the OHLC prices of a token in a quote token and the volumes of trades in an interval of beacon heights,
+// a price is the amount of the quote token for a unit of the token by the pool reserves
+type Candle struct {
+	FromHeight  uint64
+	ToHeight    uint64
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      uint64 // amount of the token traded
+	QuoteVolume uint64 // amount of the quote token traded
+	TradeCount  uint64
+}
+
+// PairVolume is the total amount of each token of a pool pair traded in a range of beacon heights
+type PairVolume struct {
+	Token1IDStr  string
+	Token1Volume uint64
+	Token2IDStr  string
+	Token2Volume uint64
+	TradeCount   uint64
+}
+
+// iterateRecords calls fn with the records of a pool pair in [fromHeight, toHeight] in the order of their keys
+func (indexer *Indexer) iterateRecords(
+	prefix []byte,
+	token1IDStr string,
+	token2IDStr string,
+	fromHeight uint64,
+	toHeight uint64,
+	fn func(value []byte) error,
+) error {
+	pairPrefix := buildPairPrefix(prefix, token1IDStr, token2IDStr)
+	iter := indexer.config.DB.NewIteratorWithStart(buildRecordKey(prefix, token1IDStr, token2IDStr, fromHeight, nil))
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, pairPrefix) || getHeightFromRecordKey(key, pairPrefix) > toHeight {
+			break
+		}
+		err := fn(iter.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// getLatestReserveBefore returns the latest reserves of a pool pair before height, or nil if there is none
+func (indexer *Indexer) getLatestReserveBefore(token1IDStr string, token2IDStr string, height uint64) (*PoolReserve, error) {
+	if height == 0 {
+		return nil, nil
+	}
+	var latestReserve *PoolReserve
+	err := indexer.iterateRecords(reservePrefix, token1IDStr, token2IDStr, 0, height-1, func(value []byte) error {
+		latestReserve = &PoolReserve{}
+		return json.Unmarshal(value, latestReserve)
+	})
+	return latestReserve, err
+}
+
+// getPrice returns the amount of quoteTokenIDStr for a unit of tokenIDStr by the reserves,
+// false is returned if the pool is empty
+func getPrice(reserve *PoolReserve, tokenIDStr string) (float64, bool) {
+	tokenPoolValue := reserve.Token1PoolValue
+	quotePoolValue := reserve.Token2PoolValue
+	if reserve.Token2IDStr == tokenIDStr {
+		tokenPoolValue = reserve.Token2PoolValue
+		quotePoolValue = reserve.Token1PoolValue
+	}
+	if tokenPoolValue == 0 || quotePoolValue == 0 {
+		return 0, false
+	}
+	return float64(quotePoolValue) / float64(tokenPoolValue), true
+}
+
+// GetCandles returns candles of interval beacon heights of the price of tokenIDStr in quoteTokenIDStr from fromHeight to toHeight,
+// candles before the first reserves of the pool pair are omitted
+func (indexer *Indexer) GetCandles(tokenIDStr string, quoteTokenIDStr string, fromHeight uint64, toHeight uint64, interval uint64) ([]*Candle, error) {
+	if interval == 0 {
+		return nil, fmt.Errorf("interval must be greater than zero")
+	}
+	if fromHeight > toHeight {
+		return nil, fmt.Errorf("from height %v is greater than to height %v", fromHeight, toHeight)
+	}
+	if (toHeight-fromHeight)/interval >= MaxCandles {
+		return nil, fmt.Errorf("number of candles must not be greater than %v", MaxCandles)
+	}
+
+	candles := []*Candle{}
+	var current *Candle
+	lastPrice, hasPrice := float64(0), false
+	latestReserve, err := indexer.getLatestReserveBefore(tokenIDStr, quoteTokenIDStr, fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	if latestReserve != nil {
+		lastPrice, hasPrice = getPrice(latestReserve, tokenIDStr)
+	}
+	// candleAt returns the candle of height, candles between the current one and it are filled with the last price
+	candleAt := func(height uint64) *Candle {
+		for current == nil || current.ToHeight < height {
+			candleFrom := fromHeight
+			if current != nil {
+				candleFrom = current.ToHeight + 1
+			}
+			candleTo := candleFrom + interval - 1
+			if candleTo > toHeight || candleTo < candleFrom {
+				candleTo = toHeight
+			}
+			current = &Candle{FromHeight: candleFrom, ToHeight: candleTo}
+			if hasPrice {
+				current.Open, current.High, current.Low, current.Close = lastPrice, lastPrice, lastPrice, lastPrice
+				candles = append(candles, current)
+			}
+		}
+		return current
+	}
+	// addPrice adds a price to the candle of height, it opens the candle if there was no price before
+	addPrice := func(height uint64, price float64) {
+		candle := candleAt(height)
+		if !hasPrice {
+			candle.Open, candle.High, candle.Low = price, price, price
+			candles = append(candles, candle)
+		}
+		if price > candle.High {
+			candle.High = price
+		}
+		if price < candle.Low {
+			candle.Low = price
+		}
+		candle.Close = price
+		lastPrice, hasPrice = price, true
+	}
+
+	// reserves and trades are in order of beacon heights, they are merged by heights
+	reserves := []*PoolReserve{}
+	err = indexer.iterateRecords(reservePrefix, tokenIDStr, quoteTokenIDStr, fromHeight, toHeight, func(value []byte) error {
+		reserve := &PoolReserve{}
+		err := json.Unmarshal(value, reserve)
+		reserves = append(reserves, reserve)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	trades := []*Trade{}
+	err = indexer.iterateRecords(tradePrefix, tokenIDStr, quoteTokenIDStr, fromHeight, toHeight, func(value []byte) error {
+		trade := &Trade{}
+		err := json.Unmarshal(value, trade)
+		trades = append(trades, trade)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tradeIdx := 0
+	for _, reserve := range reserves {
+		for ; tradeIdx < len(trades) && trades[tradeIdx].BeaconHeight <= reserve.BeaconHeight; tradeIdx++ {
+			addTradeToCandle(candleAt(trades[tradeIdx].BeaconHeight), trades[tradeIdx], tokenIDStr)
+		}
+		price, ok := getPrice(reserve, tokenIDStr)
+		if !ok {
+			continue
+		}
+		addPrice(reserve.BeaconHeight, price)
+	}
+	for ; tradeIdx < len(trades); tradeIdx++ {
+		addTradeToCandle(candleAt(trades[tradeIdx].BeaconHeight), trades[tradeIdx], tokenIDStr)
+	}
+	if hasPrice {
+		candleAt(toHeight)
+	}
+	return candles, nil
+}
+
+func addTradeToCandle(candle *Candle, trade *Trade, tokenIDStr string) {
+	candle.TradeCount++
+	if trade.TokenIDToSellStr == tokenIDStr {
+		candle.Volume += trade.SellAmount
+		candle.QuoteVolume += trade.ReceiveAmount
+	} else {
+		candle.Volume += trade.ReceiveAmount
+		candle.QuoteVolume += trade.SellAmount
+	}
+}
+
+// GetPairVolume returns the volume of trades of a pool pair from fromHeight to toHeight
+func (indexer *Indexer) GetPairVolume(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64) (*PairVolume, error) {
+	token1IDStr, token2IDStr = sortTokenIDs(token1IDStr, token2IDStr)
+	volume := &PairVolume{
+		Token1IDStr: token1IDStr,
+		Token2IDStr: token2IDStr,
+	}
+	err := indexer.iterateRecords(tradePrefix, token1IDStr, token2IDStr, fromHeight, toHeight, func(value []byte) error {
+		trade := &Trade{}
+		err := json.Unmarshal(value, trade)
+		if err != nil {
+			return err
+		}
+		volume.TradeCount++
+		if trade.TokenIDToSellStr == token1IDStr {
+			volume.Token1Volume += trade.SellAmount
+			volume.Token2Volume += trade.ReceiveAmount
+		} else {
+			volume.Token1Volume += trade.ReceiveAmount
+			volume.Token2Volume += trade.SellAmount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// GetFeeHistory returns the changes of trading fees of a pool pair from fromHeight to toHeight,
+// they are filtered by contributorAddressStr if it is not empty
+func (indexer *Indexer) GetFeeHistory(token1IDStr string, token2IDStr string, contributorAddressStr string, fromHeight uint64, toHeight uint64) ([]*FeeAccrual, error) {
+	feeAccruals := []*FeeAccrual{}
+	err := indexer.iterateRecords(feePrefix, token1IDStr, token2IDStr, fromHeight, toHeight, func(value []byte) error {
+		feeAccrual := &FeeAccrual{}
+		err := json.Unmarshal(value, feeAccrual)
+		if err != nil {
+			return err
+		}
+		if contributorAddressStr == "" || feeAccrual.ContributorAddressStr == contributorAddressStr {
+			feeAccruals = append(feeAccruals, feeAccrual)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return feeAccruals, nil
+}
+
+// GetLiquidityEvents returns the contributions to and withdrawals from a pool pair from fromHeight to toHeight
+func (indexer *Indexer) GetLiquidityEvents(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64) ([]*LiquidityEvent, error) {
+	events := []*LiquidityEvent{}
+	err := indexer.iterateRecords(liquidityPrefix, token1IDStr, token2IDStr, fromHeight, toHeight, func(value []byte) error {
+		event := &LiquidityEvent{}
+		err := json.Unmarshal(value, event)
+		events = append(events, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetReserves returns the reserves of a pool pair changed from fromHeight to toHeight
+func (indexer *Indexer) GetReserves(token1IDStr string, token2IDStr string, fromHeight uint64, toHeight uint64) ([]*PoolReserve, error) {
+	reserves := []*PoolReserve{}
+	err := indexer.iterateRecords(reservePrefix, token1IDStr, token2IDStr, fromHeight, toHeight, func(value []byte) error {
+		reserve := &PoolReserve{}
+		err := json.Unmarshal(value, reserve)
+		reserves = append(reserves, reserve)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reserves, nil
+}
@@ -0,0 +1,121 @@
+package pdeindexer
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	LiquidityContribution = "contribution"
+	LiquidityWithdrawal   = "withdrawal"
+)
+
+var (
+	latestIndexedHeightKey = []byte("pdeindex-height")
+	reservePrefix          = []byte("pdeindex-reserve-")
+	tradePrefix            = []byte("pdeindex-trade-")
+	feePrefix              = []byte("pdeindex-fee-")
+	liquidityPrefix        = []byte("pdeindex-liquidity-")
+)
+
+// PoolReserve is the reserves of a pool pair after a beacon block changing them
+type PoolReserve struct {
+	BeaconHeight    uint64
+	BeaconTimeStamp int64
+	Token1IDStr     string
+	Token1PoolValue uint64
+	Token2IDStr     string
+	Token2PoolValue uint64
+}
+
+// Trade is a trade on a pool pair, a cross pool trade is indexed as one trade on each pool pair it goes through
+type Trade struct {
+	BeaconHeight     uint64
+	BeaconTimeStamp  int64
+	RequestedTxID    common.Hash
+	TraderAddressStr string
+	TokenIDToSellStr string
+	SellAmount       uint64
+	TokenIDToBuyStr  string
+	ReceiveAmount    uint64
+}
+
+// FeeAccrual is the change of trading fees of a contributor of a pool pair in a beacon block
+type FeeAccrual struct {
+	BeaconHeight          uint64
+	BeaconTimeStamp       int64
+	Token1IDStr           string
+	Token2IDStr           string
+	ContributorAddressStr string
+	Accrued               uint64
+	Withdrawn             uint64
+	Total                 uint64
+}
+
+// LiquidityEvent is a contribution to or a withdrawal from a pool pair
+type LiquidityEvent struct {
+	BeaconHeight          uint64
+	BeaconTimeStamp       int64
+	Type                  string
+	Token1IDStr           string
+	Token2IDStr           string
+	ContributorAddressStr string
+	TokenIDStr            string
+	Amount                uint64
+	DeductingShares       uint64
+	TxReqID               common.Hash
+}
+
+// sortTokenIDs returns the token IDs of a pool pair in the order of the pde state keys
+func sortTokenIDs(token1IDStr string, token2IDStr string) (string, string) {
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	return tokenIDStrs[0], tokenIDStrs[1]
+}
+
+func buildPairPrefix(prefix []byte, token1IDStr string, token2IDStr string) []byte {
+	token1IDStr, token2IDStr = sortTokenIDs(token1IDStr, token2IDStr)
+	key := append([]byte{}, prefix...)
+	return append(key, []byte(token1IDStr+"-"+token2IDStr+"-")...)
+}
+
+// buildRecordKey orders records of a pool pair by beacon height then by their index in the beacon block
+func buildRecordKey(prefix []byte, token1IDStr string, token2IDStr string, beaconHeight uint64, suffix []byte) []byte {
+	key := buildPairPrefix(prefix, token1IDStr, token2IDStr)
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, beaconHeight)
+	key = append(key, heightBytes...)
+	return append(key, suffix...)
+}
+
+func buildIndexSuffix(index int) []byte {
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, uint32(index))
+	return indexBytes
+}
+
+func buildReserveKey(token1IDStr string, token2IDStr string, beaconHeight uint64) []byte {
+	return buildRecordKey(reservePrefix, token1IDStr, token2IDStr, beaconHeight, nil)
+}
+
+func buildTradeKey(token1IDStr string, token2IDStr string, beaconHeight uint64, index int) []byte {
+	return buildRecordKey(tradePrefix, token1IDStr, token2IDStr, beaconHeight, buildIndexSuffix(index))
+}
+
+func buildFeeKey(token1IDStr string, token2IDStr string, beaconHeight uint64, contributorAddressStr string) []byte {
+	return buildRecordKey(feePrefix, token1IDStr, token2IDStr, beaconHeight, []byte(contributorAddressStr))
+}
+
+func buildLiquidityKey(token1IDStr string, token2IDStr string, beaconHeight uint64, index int) []byte {
+	return buildRecordKey(liquidityPrefix, token1IDStr, token2IDStr, beaconHeight, buildIndexSuffix(index))
+}
+
+// getHeightFromRecordKey returns the beacon height of a record key with pairPrefix
+func getHeightFromRecordKey(key []byte, pairPrefix []byte) uint64 {
+	if len(key) < len(pairPrefix)+8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(key[len(pairPrefix) : len(pairPrefix)+8])
+}
@@ -11,11 +11,13 @@ const (
 const (
 	Ed25519KeySize        = 32
 	AESKeySize            = 32
-	CommitmentRingSize    = 8
+	CommitmentRingSize    = 8 // ring size of transactions version 1
 	CommitmentRingSizeExp = 3
-	CStringBulletProof    = "bulletproof"
-	CStringBurnAddress    = "burningaddress"
-	FixedRandomnessString = "fixedrandomness"
+	// MaxCommitmentRingSizeExp is the exponent of the largest ring size (64) one out of many proofs support
+	MaxCommitmentRingSizeExp = 6
+	CStringBulletProof       = "bulletproof"
+	CStringBurnAddress       = "burningaddress"
+	FixedRandomnessString    = "fixedrandomness"
 )
 
 const (
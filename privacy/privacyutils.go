@@ -75,3 +75,14 @@ func ConvertScalarArrayToBigIntArray(scalarArr []*Scalar) []*big.Int {
 
 	return res
 }
+
+// GetCommitmentRingSizeExp returns n with ringSize = 2^n,
+// ok is false if ringSize is not a ring size one out of many proofs support
+func GetCommitmentRingSizeExp(ringSize int) (n int, ok bool) {
+	for n = CommitmentRingSizeExp; n <= MaxCommitmentRingSizeExp; n++ {
+		if ringSize == 1<<uint(n) {
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -187,3 +187,15 @@ func TestEncryptByXorOperator(t *testing.T) {
 	}
 	fmt.Printf("plaintext %v\n", plaintext)
 }
+
+func TestGetCommitmentRingSizeExp(t *testing.T) {
+	for ringSize, exp := range map[int]int{8: 3, 16: 4, 32: 5, 64: 6} {
+		n, ok := GetCommitmentRingSizeExp(ringSize)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, exp, n)
+	}
+	for _, ringSize := range []int{0, 1, 4, 12, 128} {
+		_, ok := GetCommitmentRingSizeExp(ringSize)
+		assert.Equal(t, false, ok)
+	}
+}
@@ -143,3 +143,32 @@ func (sig *SchnSignature) SetBytes(bytes []byte) error {
 
 	return nil
 }
+
+// VerifyBatchSchnorr verifies a list of signatures, signatures[i] must be signed on data[i] by publicKeys[i].
+// A Schnorr signature (e, z1, z2) commits to the hash of the recomputed point, so signatures can not be
+// aggregated into one equation; instead every recomputed point is calculated with one multi-exponentiation.
+// It returns the index of the first invalid signature, -1 if all signatures are valid.
+func VerifyBatchSchnorr(publicKeys []*SchnorrPublicKey, signatures []*SchnSignature, data [][]byte) (bool, int) {
+	if len(publicKeys) != len(signatures) || len(publicKeys) != len(data) {
+		return false, -1
+	}
+	for i, publicKey := range publicKeys {
+		signature := signatures[i]
+		if publicKey == nil || signature == nil {
+			return false, i
+		}
+		scalars := []*Scalar{signature.e, signature.z1}
+		points := []*Point{publicKey.publicKey, publicKey.g}
+		if signature.z2 != nil {
+			scalars = append(scalars, signature.z2)
+			points = append(points, publicKey.h)
+		}
+		rv := new(Point).MultiScalarMult(scalars, points)
+		msg := append(rv.ToBytesS(), data[i]...)
+		ev := HashToScalar(msg)
+		if subtle.ConstantTimeCompare(ev.ToBytesS(), signature.e.ToBytesS()) != 1 {
+			return false, i
+		}
+	}
+	return true, -1
+}
@@ -59,3 +59,51 @@ func TestSchnorrSignatureWithoutZ2(t *testing.T) {
 		assert.Equal(t, true, res)
 	}
 }
+
+func generateSchnorrSignatures(numSig int) ([]*SchnorrPublicKey, []*SchnSignature, [][]byte) {
+	publicKeys := make([]*SchnorrPublicKey, numSig)
+	signatures := make([]*SchnSignature, numSig)
+	data := make([][]byte, numSig)
+	for i := 0; i < numSig; i++ {
+		privKey := new(SchnorrPrivateKey)
+		if i%2 == 0 {
+			privKey.Set(RandomScalar(), RandomScalar())
+		} else {
+			privKey.Set(RandomScalar(), new(Scalar).FromUint64(0))
+		}
+		data[i] = RandomScalar().ToBytesS()
+		signatures[i], _ = privKey.Sign(data[i])
+		publicKeys[i] = privKey.GetPublicKey()
+	}
+	return publicKeys, signatures, data
+}
+
+func TestVerifyBatchSchnorr(t *testing.T) {
+	publicKeys, signatures, data := generateSchnorrSignatures(20)
+	res, index := VerifyBatchSchnorr(publicKeys, signatures, data)
+	assert.Equal(t, true, res)
+	assert.Equal(t, -1, index)
+
+	data[7] = RandomScalar().ToBytesS()
+	res, index = VerifyBatchSchnorr(publicKeys, signatures, data)
+	assert.Equal(t, false, res)
+	assert.Equal(t, 7, index)
+}
+
+func BenchmarkSchnorrVerify(b *testing.B) {
+	publicKeys, signatures, data := generateSchnorrSignatures(32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range publicKeys {
+			publicKeys[j].Verify(signatures[j], data[j])
+		}
+	}
+}
+
+func BenchmarkVerifyBatchSchnorr(b *testing.B) {
+	publicKeys, signatures, data := generateSchnorrSignatures(32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBatchSchnorr(publicKeys, signatures, data)
+	}
+}
@@ -30,10 +30,13 @@ type OneOutOfManyProof struct {
 }
 
 func (proof OneOutOfManyProof) ValidateSanity() bool {
-	if len(proof.cl) != privacy.CommitmentRingSizeExp || len(proof.ca) != privacy.CommitmentRingSizeExp ||
-		len(proof.cb) != privacy.CommitmentRingSizeExp || len(proof.cd) != privacy.CommitmentRingSizeExp ||
-		len(proof.f) != privacy.CommitmentRingSizeExp || len(proof.za) != privacy.CommitmentRingSizeExp ||
-		len(proof.zb) != privacy.CommitmentRingSizeExp {
+	// N = 2^n, n is the number of elements of every array in the proof
+	n := len(proof.cl)
+	if _, ok := privacy.GetCommitmentRingSizeExp(1 << uint(n)); !ok {
+		return false
+	}
+	if len(proof.ca) != n || len(proof.cb) != n || len(proof.cd) != n ||
+		len(proof.f) != n || len(proof.za) != n || len(proof.zb) != n {
 		return false
 	}
 
@@ -126,6 +129,42 @@ func (proof *OneOutOfManyProof) Set(
 	proof.zd = zd
 }
 
+// hasValidShape checks a proof received from the network can be verified without panicking:
+// every array has the same supported length n, the statement has 2^n commitments and no element is nil
+func hasValidShape(proof *OneOutOfManyProof) bool {
+	if proof == nil || proof.Statement == nil || proof.zd == nil {
+		return false
+	}
+	n := len(proof.cl)
+	if _, ok := privacy.GetCommitmentRingSizeExp(1 << uint(n)); !ok {
+		return false
+	}
+	if len(proof.ca) != n || len(proof.cb) != n || len(proof.cd) != n ||
+		len(proof.f) != n || len(proof.za) != n || len(proof.zb) != n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if proof.cl[i] == nil || proof.ca[i] == nil || proof.cb[i] == nil || proof.cd[i] == nil ||
+			proof.f[i] == nil || proof.za[i] == nil || proof.zb[i] == nil {
+			return false
+		}
+	}
+	if len(proof.Statement.Commitments) != 1<<uint(n) {
+		return false
+	}
+	for _, commitment := range proof.Statement.Commitments {
+		if commitment == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRingSize returns the number of commitments the proof is built for
+func (proof OneOutOfManyProof) GetRingSize() int {
+	return 1 << uint(len(proof.cl))
+}
+
 // Bytes converts one of many proof to bytes array
 func (proof OneOutOfManyProof) Bytes() []byte {
 	// if proof is nil, return an empty array
@@ -134,7 +173,7 @@ func (proof OneOutOfManyProof) Bytes() []byte {
 	}
 
 	// N = 2^n
-	n := privacy.CommitmentRingSizeExp
+	n := len(proof.cl)
 
 	var bytes []byte
 
@@ -186,7 +225,14 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 		return nil
 	}
 
-	n := privacy.CommitmentRingSizeExp
+	// the proof has 7 arrays of n elements and zd
+	if (len(bytes)-privacy.Ed25519KeySize)%(7*privacy.Ed25519KeySize) != 0 {
+		return errors.New("invalid length of one out of many proof bytes")
+	}
+	n := (len(bytes) - privacy.Ed25519KeySize) / (7 * privacy.Ed25519KeySize)
+	if _, ok := privacy.GetCommitmentRingSizeExp(1 << uint(n)); !ok {
+		return errors.New("invalid ring size of one out of many proof")
+	}
 
 	offset := 0
 	var err error
@@ -262,10 +308,10 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 	// Check the number of Commitment list's elements
 	N := len(wit.stmt.Commitments)
-	if N != privacy.CommitmentRingSize {
-		return nil, errors.New("the number of Commitment list's elements must be equal to CMRingSize")
+	n, ok := privacy.GetCommitmentRingSizeExp(N)
+	if !ok {
+		return nil, errors.New("the number of Commitment list's elements must be a supported ring size")
 	}
-	n := privacy.CommitmentRingSizeExp
 	// Check indexIsZero
 	if wit.indexIsZero > uint64(N) {
 		return nil, errors.New("Index is zero must be Index in list of commitments")
@@ -360,12 +406,18 @@ func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 
 // Verify verifies a proof output by Prove
 func (proof OneOutOfManyProof) Verify() (bool, error) {
+	if proof.Statement == nil {
+		return false, errors.New("Invalid one out of many proof")
+	}
 	N := len(proof.Statement.Commitments)
-	// the number of Commitment list's elements must be equal to CMRingSize
-	if N != privacy.CommitmentRingSize {
+	// the number of Commitment list's elements must be equal to the ring size of the proof
+	if N != proof.GetRingSize() {
 		return false, errors.New("Invalid length of commitments list in one out of many proof")
 	}
-	n := privacy.CommitmentRingSizeExp
+	if !hasValidShape(&proof) {
+		return false, errors.New("Invalid one out of many proof")
+	}
+	n := len(proof.cl)
 	//Calculate x
 	cmtsInBytes := make([][]byte, 0)
 	for _, cmts := range proof.Statement.Commitments{
@@ -540,3 +592,90 @@ func getCoefficientInt(iBinary []byte, k int, n int, a []*big.Int, l []byte) *bi
 	}
 	return res[k]
 }
+
+// VerifyBatch verifies a list of proofs output by Prove with one multi-exponentiation.
+// Every verification equation of every proof is weighted by a random scalar and all of them are summed,
+// so the sum is the identity point only if all equations hold (except with negligible probability).
+// When the batch is invalid, proofs are verified one by one to return the index of the first invalid proof.
+func VerifyBatch(proofs []*OneOutOfManyProof) (bool, error, int) {
+	zero := new(privacy.Scalar).FromUint64(0)
+	// scalars of the generators G[PedersenPrivateKeyIndex] and G[PedersenRandomnessIndex] are accumulated
+	// over all proofs instead of being added once per equation
+	gScalar := new(privacy.Scalar).FromUint64(0)
+	hScalar := new(privacy.Scalar).FromUint64(0)
+	scalars := make([]*privacy.Scalar, 0)
+	points := make([]*privacy.Point, 0)
+
+	for index, proof := range proofs {
+		if !hasValidShape(proof) {
+			return false, errors.New("Invalid one out of many proof"), index
+		}
+		N := len(proof.Statement.Commitments)
+		n := len(proof.cl)
+		cmtsInBytes := make([][]byte, 0)
+		for _, cmts := range proof.Statement.Commitments {
+			cmtsInBytes = append(cmtsInBytes, cmts.ToBytesS())
+		}
+		x := utils.GenerateChallenge(cmtsInBytes)
+		for j := 0; j < n; j++ {
+			x = utils.GenerateChallenge([][]byte{x.ToBytesS(), proof.cl[j].ToBytesS(), proof.ca[j].ToBytesS(), proof.cb[j].ToBytesS(), proof.cd[j].ToBytesS()})
+		}
+
+		for i := 0; i < n; i++ {
+			// r1 * (cl^x * ca - Com(f, za)) + r2 * (cl^(x-f) * cb - Com(0, zb))
+			r1 := privacy.RandomScalar()
+			r2 := privacy.RandomScalar()
+			xSubF := new(privacy.Scalar).Sub(x, proof.f[i])
+			clScalar := new(privacy.Scalar).Mul(r1, x)
+			clScalar.Add(clScalar, new(privacy.Scalar).Mul(r2, xSubF))
+			scalars = append(scalars, clScalar, r1, r2)
+			points = append(points, proof.cl[i], proof.ca[i], proof.cb[i])
+			gScalar.Sub(gScalar, new(privacy.Scalar).Mul(r1, proof.f[i]))
+			hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r1, proof.za[i]))
+			hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r2, proof.zb[i]))
+		}
+
+		// r3 * (prod(commitments[i]^exp_i) * prod(cd[k]^(-x^k)) - Com(0, zd))
+		r3 := privacy.RandomScalar()
+		for i := 0; i < N; i++ {
+			iBinary := privacy.ConvertIntToBinary(i, n)
+			exp := new(privacy.Scalar).FromUint64(1)
+			fji := new(privacy.Scalar).FromUint64(1)
+			for j := 0; j < n; j++ {
+				if iBinary[j] == 1 {
+					fji.Set(proof.f[j])
+				} else {
+					fji.Sub(x, proof.f[j])
+				}
+				exp.Mul(exp, fji)
+			}
+			scalars = append(scalars, exp.Mul(exp, r3))
+			points = append(points, proof.Statement.Commitments[i])
+		}
+		xk := new(privacy.Scalar).Set(r3)
+		for k := 0; k < n; k++ {
+			scalars = append(scalars, new(privacy.Scalar).Sub(zero, xk))
+			points = append(points, proof.cd[k])
+			xk.Mul(xk, x)
+		}
+		hScalar.Sub(hScalar, new(privacy.Scalar).Mul(r3, proof.zd))
+	}
+	if len(points) == 0 {
+		return true, nil, -1
+	}
+
+	scalars = append(scalars, gScalar, hScalar)
+	points = append(points, privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], privacy.PedCom.G[privacy.PedersenRandomnessIndex])
+	res := new(privacy.Point).MultiScalarMult(scalars, points)
+	if res.IsIdentity() {
+		return true, nil, -1
+	}
+
+	for index, proof := range proofs {
+		if valid, err := proof.Verify(); !valid {
+			privacy.Logger.Log.Errorf("verify batch one out of many proofs failed at proof %v", index)
+			return false, err, index
+		}
+	}
+	return false, errors.New("verify batch one out of many proofs failed"), -1
+}
@@ -89,3 +89,138 @@ func TestPKOneOfMany(t *testing.T) {
 
 	}
 }
+
+func generateOneOutOfManyProofs(t testing.TB, numProof int) []*OneOutOfManyProof {
+	return generateOneOutOfManyProofsWithRingSize(t, numProof, privacy.CommitmentRingSize)
+}
+
+func generateOneOutOfManyProofsWithRingSize(t testing.TB, numProof int, ringSize int) []*OneOutOfManyProof {
+	proofs := make([]*OneOutOfManyProof, numProof)
+	for k := 0; k < numProof; k++ {
+		indexIsZero := int(common.RandInt() % ringSize)
+		commitments := make([]*privacy.Point, ringSize)
+		randoms := make([]*privacy.Scalar, ringSize)
+		for i := 0; i < ringSize; i++ {
+			randoms[i] = privacy.RandomScalar()
+			commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), randoms[i], privacy.PedersenSndIndex)
+		}
+		commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(new(privacy.Scalar).FromUint64(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+
+		witness := new(OneOutOfManyWitness)
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs[k] = proof
+	}
+	return proofs
+}
+
+func TestVerifyBatch(t *testing.T) {
+	proofs := generateOneOutOfManyProofs(t, 10)
+	res, err, index := VerifyBatch(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, index)
+
+	// an invalid proof in the batch is found by the fallback
+	proofs[6].zd = privacy.RandomScalar()
+	res, err, index = VerifyBatch(proofs)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 6, index)
+
+	res, err, index = VerifyBatch([]*OneOutOfManyProof{})
+	assert.Equal(t, true, res)
+}
+
+func TestVerifyBatchMalformedProof(t *testing.T) {
+	malformations := []func(proof *OneOutOfManyProof){
+		func(proof *OneOutOfManyProof) { proof.Statement = nil },
+		func(proof *OneOutOfManyProof) { proof.ca = proof.ca[:len(proof.ca)-1] },
+		func(proof *OneOutOfManyProof) { proof.zb = nil },
+		func(proof *OneOutOfManyProof) { proof.cd[2] = nil },
+		func(proof *OneOutOfManyProof) { proof.f[0] = nil },
+		func(proof *OneOutOfManyProof) { proof.zd = nil },
+		func(proof *OneOutOfManyProof) { proof.Statement.Commitments[3] = nil },
+		func(proof *OneOutOfManyProof) { proof.cl = nil },
+	}
+	for i, malform := range malformations {
+		proofs := generateOneOutOfManyProofs(t, 3)
+		malform(proofs[1])
+		res, err, index := VerifyBatch(proofs)
+		assert.Equal(t, false, res, "malformation %v", i)
+		assert.NotEqual(t, nil, err, "malformation %v", i)
+		assert.Equal(t, 1, index, "malformation %v", i)
+		res, _ = proofs[1].Verify()
+		assert.Equal(t, false, res, "malformation %v", i)
+	}
+
+	res, _, index := VerifyBatch([]*OneOutOfManyProof{nil})
+	assert.Equal(t, false, res)
+	assert.Equal(t, 0, index)
+}
+
+func TestOneOfManyRingSize(t *testing.T) {
+	for _, ringSize := range []int{16, 32, 64} {
+		proof := generateOneOutOfManyProofsWithRingSize(t, 1, ringSize)[0]
+		assert.Equal(t, true, proof.ValidateSanity())
+		assert.Equal(t, ringSize, proof.GetRingSize())
+		res, err := proof.Verify()
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		proofBytes := proof.Bytes()
+		assert.Equal(t, utils.GetOneOfManyProofSize(ringSize), len(proofBytes))
+		proof2 := new(OneOutOfManyProof).Init()
+		err = proof2.SetBytes(proofBytes)
+		assert.Equal(t, nil, err)
+		proof2.Statement.Commitments = proof.Statement.Commitments
+		res, err = proof2.Verify()
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		// the statement must have as many commitments as the ring size of the proof
+		proof2.Statement.Commitments = proof.Statement.Commitments[:privacy.CommitmentRingSize]
+		res, _ = proof2.Verify()
+		assert.Equal(t, false, res)
+	}
+
+	// proofs of different ring sizes can be verified in the same batch
+	proofs := append(generateOneOutOfManyProofs(t, 2), generateOneOutOfManyProofsWithRingSize(t, 2, 32)...)
+	res, err, index := VerifyBatch(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, index)
+
+	// unsupported ring size
+	witness := new(OneOutOfManyWitness)
+	commitments := make([]*privacy.Point, 12)
+	for i := range commitments {
+		commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandomScalar(), privacy.RandomScalar(), privacy.PedersenSndIndex)
+	}
+	witness.Set(commitments, privacy.RandomScalar(), 0)
+	_, err = witness.Prove()
+	assert.NotEqual(t, nil, err)
+	err = new(OneOutOfManyProof).Init().SetBytes(make([]byte, 100))
+	assert.NotEqual(t, nil, err)
+}
+
+func BenchmarkVerify(b *testing.B) {
+	proofs := generateOneOutOfManyProofs(b, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, proof := range proofs {
+			proof.Verify()
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	proofs := generateOneOutOfManyProofs(b, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBatch(proofs)
+	}
+}
@@ -117,6 +117,15 @@ func (paymentProof *PaymentProof) SetSerialNumberProof(p []*serialnumberprivacy.
 	paymentProof.serialNumberProof = p
 }
 
+// GetRingSize returns the ring size of the one out of many proofs,
+// all of them must be built for the same ring size, that is checked when validating sanity of the tx
+func (paymentProof PaymentProof) GetRingSize() int {
+	if len(paymentProof.oneOfManyProof) == 0 || paymentProof.oneOfManyProof[0] == nil {
+		return privacy.CommitmentRingSize
+	}
+	return paymentProof.oneOfManyProof[0].GetRingSize()
+}
+
 func (paymentProof *PaymentProof) SetOneOfManyProof(p []*oneoutofmany.OneOutOfManyProof) {
 	paymentProof.oneOfManyProof = p
 }
@@ -185,7 +194,7 @@ func (proof *PaymentProof) Bytes() []byte {
 	bytes = append(bytes, byte(len(proof.oneOfManyProof)))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
 		oneOfManyProof := proof.oneOfManyProof[i].Bytes()
-		bytes = append(bytes, common.IntToBytes(utils.OneOfManyProofSize)...)
+		bytes = append(bytes, common.IntToBytes(len(oneOfManyProof))...)
 		bytes = append(bytes, oneOfManyProof...)
 	}
 
@@ -630,8 +639,9 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	}
 
 	// get commitments list
-	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*privacy.CommitmentRingSize)
-	for i := 0; i < len(proof.oneOfManyProof)*privacy.CommitmentRingSize; i++ {
+	ringSize := proof.GetRingSize()
+	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*ringSize)
+	for i := 0; i < len(proof.oneOfManyProof)*ringSize; i++ {
 		if offset+common.Uint64Size > len(proofbytes) {
 			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, errors.New("Out of range commitment indices"))
 		}
@@ -763,10 +773,14 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 	}
 
 	// verify for input coins
+	ringSize := proof.GetRingSize()
+	if len(proof.commitmentIndices) != len(proof.oneOfManyProof)*ringSize {
+		return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, errors.New("invalid length of commitment indices"))
+	}
 	cmInputSum := make([]*privacy.Point, len(proof.oneOfManyProof))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
 		privacy.Logger.Log.Debugf("[TEST] input coins %v\n ShardID %v fee %v", i, shardID, fee)
-		privacy.Logger.Log.Debugf("[TEST] commitments indices %v\n", proof.commitmentIndices[i*privacy.CommitmentRingSize:i*privacy.CommitmentRingSize+8])
+		privacy.Logger.Log.Debugf("[TEST] commitments indices %v\n", proof.commitmentIndices[i*ringSize:(i+1)*ringSize])
 		// Verify for the proof one-out-of-N commitments is a commitment to the coins being spent
 		// Calculate cm input sum
 		cmInputSum[i] = new(privacy.Point).Add(proof.commitmentInputSecretKey, proof.commitmentInputValue[i])
@@ -774,9 +788,9 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 		cmInputSum[i].Add(cmInputSum[i], proof.commitmentInputShardID)
 
 		// get commitments list from CommitmentIndices
-		commitments := make([]*privacy.Point, privacy.CommitmentRingSize)
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
-			index := proof.commitmentIndices[i*privacy.CommitmentRingSize+j]
+		commitments := make([]*privacy.Point, ringSize)
+		for j := 0; j < ringSize; j++ {
+			index := proof.commitmentIndices[i*ringSize+j]
 			commitmentBytes, err := statedb.GetCommitmentByIndex(stateDB, *tokenID, index, shardID)
 			privacy.Logger.Log.Debugf("[TEST] commitment at index %v: %v\n", index, commitmentBytes)
 			if err != nil {
@@ -802,6 +816,11 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 
 		proof.oneOfManyProof[i].Statement.Commitments = commitments
 
+		if isBatch && isNewZKP {
+			// one out of many and serial number proofs are verified in batch by the caller
+			continue
+		}
+
 		if isNewZKP{
 			valid, err := proof.oneOfManyProof[i].Verify()
 			if !valid {
@@ -105,6 +105,15 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 	numInputCoin := len(wit.inputCoins)
 	numOutputCoin := len(wit.outputCoins)
 
+	// every input coin is proved with a ring of ringSize commitments
+	ringSize := privacy.CommitmentRingSize
+	if numInputCoin > 0 {
+		ringSize = len(commitments) / numInputCoin
+	}
+	if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok || len(commitments) != numInputCoin*ringSize {
+		return privacy.NewPrivacyErr(privacy.ProveOneOutOfManyErr, errors.New("invalid number of commitments for one out of many proofs"))
+	}
+
 	randInputSK := privacy.RandomScalar()
 	// set rand sk for Schnorr signature
 	wit.randSecretKey = new(privacy.Scalar).Set(randInputSK)
@@ -175,22 +184,22 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 		randInputSumAll.Add(randInputSumAll, randInputSum[i])
 
 		// commitmentTemps is a list of commitments for protocol one-out-of-N
-		commitmentTemps[i] = make([]*privacy.Point, privacy.CommitmentRingSize)
+		commitmentTemps[i] = make([]*privacy.Point, ringSize)
 
 		randInputIsZero[i] = new(privacy.Scalar).FromUint64(0)
 		randInputIsZero[i].Sub(inputCoin.CoinDetails.GetRandomness(), randInputSum[i])
 
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
+		for j := 0; j < ringSize; j++ {
 			commitmentTemps[i][j] = new(privacy.Point).Sub(commitments[preIndex+j], cmInputSum[i])
 		}
 
 		if wit.oneOfManyWitness[i] == nil {
 			wit.oneOfManyWitness[i] = new(oneoutofmany.OneOutOfManyWitness)
 		}
-		indexIsZero := myCommitmentIndices[i] % privacy.CommitmentRingSize
+		indexIsZero := myCommitmentIndices[i] % uint64(ringSize)
 
 		wit.oneOfManyWitness[i].Set(commitmentTemps[i], randInputIsZero[i], indexIsZero)
-		preIndex = privacy.CommitmentRingSize * (i + 1)
+		preIndex = ringSize * (i + 1)
 		// ---------------------------------------------------
 
 		/***** Build witness for proving that serial number is derived from the committed derivator *****/
@@ -35,6 +35,14 @@ type SNPrivacyProof struct {
 	zRInput *privacy.Scalar // second challenge-dependent information to open the commitment to input
 }
 
+// hasValidShape checks a proof received from the network has no nil element so it can be verified without panicking
+func hasValidShape(proof *SNPrivacyProof) bool {
+	return proof != nil && proof.stmt != nil &&
+		proof.stmt.sn != nil && proof.stmt.comSK != nil && proof.stmt.comInput != nil &&
+		proof.tSK != nil && proof.tInput != nil && proof.tSN != nil &&
+		proof.zSK != nil && proof.zRSK != nil && proof.zInput != nil && proof.zRInput != nil
+}
+
 // ValidateSanity validates sanity of proof
 func (proof SNPrivacyProof) ValidateSanity() bool {
 	if !proof.stmt.sn.PointValid() {
@@ -384,3 +392,68 @@ func (proof SNPrivacyProof) Verify(mess []byte) (bool, error) {
 	return true, nil
 }
 
+
+// VerifyBatch verifies a list of proofs output by Prove(nil) with one multi-exponentiation.
+// The three verification equations of every proof are weighted by random scalars and summed,
+// the sum is the identity point only if all equations hold (except with negligible probability).
+// When the batch is invalid, proofs are verified one by one to return the index of the first invalid proof.
+func VerifyBatch(proofs []*SNPrivacyProof) (bool, error, int) {
+	zero := new(privacy.Scalar).FromUint64(0)
+	// scalars of the generators are accumulated over all proofs
+	gSKScalar := new(privacy.Scalar).FromUint64(0)
+	gSNDScalar := new(privacy.Scalar).FromUint64(0)
+	hScalar := new(privacy.Scalar).FromUint64(0)
+	scalars := make([]*privacy.Scalar, 0)
+	points := make([]*privacy.Point, 0)
+
+	for index, proof := range proofs {
+		if !hasValidShape(proof) {
+			return false, errors.New("invalid serial number privacy proof"), index
+		}
+		x := utils.GenerateChallenge([][]byte{
+			proof.stmt.sn.ToBytesS(),
+			proof.stmt.comSK.ToBytesS(),
+			proof.tSK.ToBytesS(),
+			proof.tInput.ToBytesS(),
+			proof.tSN.ToBytesS()})
+		r1 := privacy.RandomScalar()
+		r2 := privacy.RandomScalar()
+		r3 := privacy.RandomScalar()
+
+		// r1 * (gSND^zInput * h^zRInput - input^x * tInput)
+		gSNDScalar.Add(gSNDScalar, new(privacy.Scalar).Mul(r1, proof.zInput))
+		hScalar.Add(hScalar, new(privacy.Scalar).Mul(r1, proof.zRInput))
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, new(privacy.Scalar).Mul(r1, x)), new(privacy.Scalar).Sub(zero, r1))
+		points = append(points, proof.stmt.comInput, proof.tInput)
+
+		// r2 * (gSK^zSeed * h^zRSeed - vKey^x * tSeed)
+		gSKScalar.Add(gSKScalar, new(privacy.Scalar).Mul(r2, proof.zSK))
+		hScalar.Add(hScalar, new(privacy.Scalar).Mul(r2, proof.zRSK))
+		scalars = append(scalars, new(privacy.Scalar).Sub(zero, new(privacy.Scalar).Mul(r2, x)), new(privacy.Scalar).Sub(zero, r2))
+		points = append(points, proof.stmt.comSK, proof.tSK)
+
+		// r3 * (sn^(zSeed + zInput) - gSK^x * tOutput)
+		gSKScalar.Sub(gSKScalar, new(privacy.Scalar).Mul(r3, x))
+		snScalar := new(privacy.Scalar).Add(proof.zSK, proof.zInput)
+		scalars = append(scalars, snScalar.Mul(snScalar, r3), new(privacy.Scalar).Sub(zero, r3))
+		points = append(points, proof.stmt.sn, proof.tSN)
+	}
+	if len(points) == 0 {
+		return true, nil, -1
+	}
+
+	scalars = append(scalars, gSKScalar, gSNDScalar, hScalar)
+	points = append(points, privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], privacy.PedCom.G[privacy.PedersenSndIndex], privacy.PedCom.G[privacy.PedersenRandomnessIndex])
+	res := new(privacy.Point).MultiScalarMult(scalars, points)
+	if res.IsIdentity() {
+		return true, nil, -1
+	}
+
+	for index, proof := range proofs {
+		if valid, err := proof.Verify(nil); !valid {
+			privacy.Logger.Log.Errorf("verify batch serial number privacy proofs failed at proof %v", index)
+			return false, err, index
+		}
+	}
+	return false, errors.New("verify batch serial number privacy proofs failed"), -1
+}
@@ -5,11 +5,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/utils"
 	"github.com/stretchr/testify/assert"
 )
 
+var _ = func() (_ struct{}) {
+	privacy.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
 func TestPKSNPrivacy(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		sk := privacy.GeneratePrivateKey(privacy.RandBytes(31))
@@ -62,3 +68,61 @@ func TestPKSNPrivacy(t *testing.T) {
 		assert.Equal(t, nil, err)
 	}
 }
+
+func generateSNPrivacyProofs(t testing.TB, numProof int) []*SNPrivacyProof {
+	proofs := make([]*SNPrivacyProof, numProof)
+	for i := 0; i < numProof; i++ {
+		skScalar := new(privacy.Scalar).FromBytesS(privacy.GeneratePrivateKey(privacy.RandBytes(31)))
+		SND := privacy.RandomScalar()
+		rSK := privacy.RandomScalar()
+		rSND := privacy.RandomScalar()
+
+		serialNumber := new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], skScalar, SND)
+		comSK := privacy.PedCom.CommitAtIndex(skScalar, rSK, privacy.PedersenPrivateKeyIndex)
+		comSND := privacy.PedCom.CommitAtIndex(SND, rSND, privacy.PedersenSndIndex)
+
+		stmt := new(SerialNumberPrivacyStatement)
+		stmt.Set(serialNumber, comSK, comSND)
+		witness := new(SNPrivacyWitness)
+		witness.Set(stmt, skScalar, rSK, SND, rSND)
+		proof, err := witness.Prove(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs[i] = proof
+	}
+	return proofs
+}
+
+func TestVerifyBatch(t *testing.T) {
+	proofs := generateSNPrivacyProofs(t, 20)
+	res, err, index := VerifyBatch(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, index)
+
+	// an invalid proof in the batch is found by the fallback
+	proofs[13].zRSK = privacy.RandomScalar()
+	res, err, index = VerifyBatch(proofs)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 13, index)
+}
+
+func BenchmarkVerify(b *testing.B) {
+	proofs := generateSNPrivacyProofs(b, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, proof := range proofs {
+			proof.Verify(nil)
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	proofs := generateSNPrivacyProofs(b, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBatch(proofs)
+	}
+}
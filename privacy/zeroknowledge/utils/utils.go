@@ -26,7 +26,22 @@ func GenerateChallenge(values [][]byte) *privacy.Scalar {
 }
 
 // EstimateProofSize returns the estimated size of the proof in bytes
+// GetOneOfManyProofSize returns the size in bytes of an one out of many proof for ringSize commitments
+func GetOneOfManyProofSize(ringSize int) int {
+	n, ok := privacy.GetCommitmentRingSizeExp(ringSize)
+	if !ok {
+		return OneOfManyProofSize
+	}
+	// 7 arrays of n elements and zd
+	return (7*n + 1) * privacy.Ed25519KeySize
+}
+
 func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
+	return EstimateProofSizeWithRingSize(nInput, nOutput, hasPrivacy, privacy.CommitmentRingSize)
+}
+
+// EstimateProofSizeWithRingSize works as EstimateProofSize for proofs whose one out of many proofs are built for ringSize commitments
+func EstimateProofSizeWithRingSize(nInput int, nOutput int, hasPrivacy bool, ringSize int) uint64 {
 	if !hasPrivacy {
 		FlagSize := 14 + 2*nInput + nOutput
 		sizeSNNoPrivacyProof := nInput * SnNoPrivacyProofSize
@@ -39,7 +54,7 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 
 	FlagSize := 14 + 7*nInput + 4*nOutput
 
-	sizeOneOfManyProof := nInput * OneOfManyProofSize
+	sizeOneOfManyProof := nInput * GetOneOfManyProofSize(ringSize)
 	sizeSNPrivacyProof := nInput * SnPrivacyProofSize
 	sizeComOutputMultiRangeProof := int(aggregaterange.EstimateMultiRangeProofSize(nOutput))
 
@@ -55,7 +70,7 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 	sizeComInputSND := nInput * privacy.Ed25519KeySize
 	sizeComInputShardID := privacy.Ed25519KeySize
 
-	sizeCommitmentIndices := nInput * privacy.CommitmentRingSize * common.Uint64Size
+	sizeCommitmentIndices := nInput * ringSize * common.Uint64Size
 
 	sizeProof := sizeOneOfManyProof + sizeSNPrivacyProof +
 		sizeComOutputMultiRangeProof + sizeInputCoins + sizeOutputCoins +
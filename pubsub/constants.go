@@ -17,6 +17,7 @@ const (
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
 	TestTopic                       = "testtopic"
+	ValidatorTransitionTopic        = "validatortransitiontopic"
 )
 
 var Topics = []string{
@@ -33,4 +34,5 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	ValidatorTransitionTopic,
 }
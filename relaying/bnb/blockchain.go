@@ -2,12 +2,15 @@ package bnb
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	bnbdb "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/types"
 	"sort"
+	"strings"
+	"sync"
 )
 
 var dbPath = ""
@@ -19,15 +22,66 @@ func setDBPath(path string) {
 }
 
 type BNBChainState struct {
-	FinalBlocks         []*types.Block           // there are two blocks behind
-	LatestBlock         *types.Block             // there is one block behind (in candidate next blocks)
-	CandidateNextBlocks []*types.Block           // candidates for next latest block
-	OrphanBlocks        map[int64][]*types.Block // orphan blocks, waiting to be appended to candidates
+	FinalBlocks         []*types.Block             // there are two blocks behind
+	LatestBlock         *types.Block               // there is one block behind (in candidate next blocks)
+	CandidateNextBlocks []*types.Block             // candidates for next latest block
+	OrphanBlocks        map[int64][]*types.Block   // orphan blocks, waiting to be appended to candidates
+	ValidatorSets       map[string][]*BNBValidator // validator sets of new bnb epochs by validators hash (hex)
 
 	ChainDB bnbdb.DB
+
+	lock sync.RWMutex // guards the state, the beacon writes it while rpc handlers read it
+}
+
+// BNBChainSnapshot is a copy of the unfinalized part of bnb chain state which can be read without holding the lock
+type BNBChainSnapshot struct {
+	FinalizedBlockHeight  int64
+	LatestBlock           *types.Block
+	CandidateNextBlocks   []*types.Block
+	OrphanBlocks          map[int64][]*types.Block
+	PendingValidatorsHash []byte
+	ValidatorsHashes      []string // hashes (hex) of known validator sets, sorted
+}
+
+// Snapshot copies latest, candidate and orphan blocks and known validator sets of bnb chain state
+func (b *BNBChainState) Snapshot() *BNBChainSnapshot {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	snapshot := &BNBChainSnapshot{
+		FinalizedBlockHeight:  b.finalizedBlockHeight(),
+		LatestBlock:           b.LatestBlock,
+		CandidateNextBlocks:   append([]*types.Block{}, b.CandidateNextBlocks...),
+		OrphanBlocks:          make(map[int64][]*types.Block, len(b.OrphanBlocks)),
+		PendingValidatorsHash: b.pendingValidatorsHash(),
+		ValidatorsHashes:      make([]string, 0, len(b.ValidatorSets)),
+	}
+	for height, blocks := range b.OrphanBlocks {
+		snapshot.OrphanBlocks[height] = append([]*types.Block{}, blocks...)
+	}
+	for validatorsHash := range b.ValidatorSets {
+		snapshot.ValidatorsHashes = append(snapshot.ValidatorsHashes, validatorsHash)
+	}
+	sort.Strings(snapshot.ValidatorsHashes)
+	return snapshot
 }
 
 func (b *BNBChainState) GetBNBBlockByHeight(h int64) (*types.Block, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.getBNBBlockByHeight(h)
+}
+
+func (b *BNBChainState) getBNBBlockByHeight(h int64) (*types.Block, error) {
+	// final blocks which have not been stored yet
+	for _, block := range b.FinalBlocks {
+		if block.Height == h {
+			return block, nil
+		}
+	}
+	if b.ChainDB == nil {
+		return nil, errors.New("[GetBNBBlockByHeight] BNB chain db is nil")
+	}
 	blockStore := NewBlockStore(b.ChainDB)
 	block := blockStore.LoadBlock(h)
 	if block == nil {
@@ -37,7 +91,13 @@ func (b *BNBChainState) GetBNBBlockByHeight(h int64) (*types.Block, error) {
 }
 
 func (b *BNBChainState) GetDataHashBNBBlockByHeight(h int64) ([]byte, error) {
-	block, err := b.GetBNBBlockByHeight(h)
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.getDataHashBNBBlockByHeight(h)
+}
+
+func (b *BNBChainState) getDataHashBNBBlockByHeight(h int64) ([]byte, error) {
+	block, err := b.getBNBBlockByHeight(h)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +116,14 @@ func newOrphanBlockKey() []byte {
 	return []byte("orphanblock")
 }
 
+func newValidatorSetsKey() []byte {
+	return []byte("validatorsets")
+}
+
 func (b *BNBChainState) StoreBNBChainState() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	var err error
 	// store FinalBlocks into db (final blocks, there are two blocks behind)
 	blockStore := NewBlockStore(b.ChainDB)
@@ -96,6 +163,13 @@ func (b *BNBChainState) StoreBNBChainState() error {
 		Logger.log.Errorf("Error when store bnb latest block %v\n", err)
 		return NewBNBRelayingError(StoreBNBChainErr, err)
 	}
+
+	// store ValidatorSets
+	err = storeValidatorSets(b.ChainDB, b.ValidatorSets)
+	if err != nil {
+		Logger.log.Errorf("Error when store bnb validator sets %v\n", err)
+		return NewBNBRelayingError(StoreBNBChainErr, err)
+	}
 	return nil
 }
 
@@ -137,6 +211,19 @@ func storeOrphanBlocks(db bnbdb.DB, orphanBlocks map[int64][]*types.Block) error
 	return nil
 }
 
+func storeValidatorSets(db bnbdb.DB, validatorSets map[string][]*BNBValidator) error {
+	key := newValidatorSetsKey()
+	value, err := json.Marshal(validatorSets)
+
+	if err != nil {
+		Logger.log.Errorf("Error when marshaling validator sets %v\n", err)
+		return NewBNBRelayingError(StoreBNBChainErr, err)
+	}
+
+	db.Set(key, value)
+	return nil
+}
+
 func getLatestBlock(db bnbdb.DB) (*types.Block, error) {
 	key := newLatestBlockKey()
 	value := db.Get(key)
@@ -179,7 +266,24 @@ func getOrphanBlocks(db bnbdb.DB) (map[int64][]*types.Block, error) {
 	return blocks, nil
 }
 
+func getValidatorSets(db bnbdb.DB) (map[string][]*BNBValidator, error) {
+	key := newValidatorSetsKey()
+	value := db.Get(key)
+	validatorSets := map[string][]*BNBValidator{}
+	if len(value) > 0 {
+		err := json.Unmarshal(value, &validatorSets)
+		if err != nil {
+			Logger.log.Errorf("Error when unmarshaling validator sets %v\n", err)
+			return nil, NewBNBRelayingError(GetBNBChainErr, err)
+		}
+	}
+	return validatorSets, nil
+}
+
 func (b *BNBChainState) LoadBNBChainState(path string, chainID string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	setDBPath(path)
 	// if there is no block in db, add genesis block to final blocks
 	var err error
@@ -214,9 +318,131 @@ func (b *BNBChainState) LoadBNBChainState(path string, chainID string) error {
 		return NewBNBRelayingError(GetBNBChainErr, err)
 	}
 
+	// load ValidatorSets
+	b.ValidatorSets, err = getValidatorSets(b.ChainDB)
+	if err != nil {
+		Logger.log.Errorf("Error when get bnb validator sets %v\n", err)
+		return NewBNBRelayingError(GetBNBChainErr, err)
+	}
+
 	return nil
 }
 
+// FinalizedBlockHeight returns height of the latest final block, it is confirmed by two next blocks and can not be reorganized
+func (b *BNBChainState) FinalizedBlockHeight() int64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.finalizedBlockHeight()
+}
+
+func (b *BNBChainState) finalizedBlockHeight() int64 {
+	if len(b.FinalBlocks) > 0 {
+		return b.FinalBlocks[len(b.FinalBlocks)-1].Height
+	}
+	if b.ChainDB == nil {
+		return 0
+	}
+	return NewBlockStore(b.ChainDB).Height()
+}
+
+// PendingValidatorsHash returns hash of the validator set of the next bnb epoch if the latest block announces a change of validator set
+func (b *BNBChainState) PendingValidatorsHash() []byte {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.pendingValidatorsHash()
+}
+
+func (b *BNBChainState) pendingValidatorsHash() []byte {
+	if b.LatestBlock == nil || bytes.Equal(b.LatestBlock.ValidatorsHash, b.LatestBlock.NextValidatorsHash) {
+		return nil
+	}
+	return b.LatestBlock.NextValidatorsHash
+}
+
+// AddValidatorSet adds validator set of a new bnb epoch
+// the validator set is only accepted if its hash is committed as next validators hash by the latest block, one of candidate blocks or one of orphan blocks
+func (b *BNBChainState) AddValidatorSet(validators []*BNBValidator) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.addValidatorSet(validators)
+}
+
+func (b *BNBChainState) addValidatorSet(validators []*BNBValidator) error {
+	validatorSet, err := NewValidatorSet(validators)
+	if err != nil {
+		Logger.log.Errorf("[AddValidatorSet] Invalid validator set %v\n", err)
+		return NewBNBRelayingError(InvalidValidatorSetErr, err)
+	}
+	validatorsHash := validatorSet.Hash()
+
+	isCommitted := b.LatestBlock != nil && bytes.Equal(b.LatestBlock.NextValidatorsHash, validatorsHash)
+	for _, cb := range b.CandidateNextBlocks {
+		if bytes.Equal(cb.NextValidatorsHash, validatorsHash) {
+			isCommitted = true
+		}
+	}
+	for _, blocks := range b.OrphanBlocks {
+		for _, ob := range blocks {
+			if bytes.Equal(ob.NextValidatorsHash, validatorsHash) {
+				isCommitted = true
+			}
+		}
+	}
+	if !isCommitted {
+		Logger.log.Errorf("[AddValidatorSet] Validator set %X is not committed by relayed blocks\n", validatorsHash)
+		return NewBNBRelayingError(InvalidValidatorSetErr, fmt.Errorf("validator set %X is not committed by relayed blocks", validatorsHash))
+	}
+
+	if b.ValidatorSets == nil {
+		b.ValidatorSets = map[string][]*BNBValidator{}
+	}
+	b.ValidatorSets[strings.ToUpper(hex.EncodeToString(validatorsHash))] = validators
+	Logger.log.Infof("[AddValidatorSet] Add validator set %X of new bnb epoch\n", validatorsHash)
+	return nil
+}
+
+// verifySignedHeader verifies signed header by the validator set of its epoch
+// headers of the genesis epoch are verified by the fixed validator set
+func (b *BNBChainState) verifySignedHeader(sh *types.SignedHeader, chainID string) (bool, error) {
+	validators, ok := b.ValidatorSets[strings.ToUpper(hex.EncodeToString(sh.Header.ValidatorsHash))]
+	if ok {
+		return VerifySignedHeaderByValidators(sh, chainID, validators)
+	}
+	return VerifySignedHeader(sh, chainID)
+}
+
+// VerifyProof verifies tx proof against data hash of a relayed bnb block
+// the block must be finalized and have at least minConfirmations blocks on top of it
+func (b *BNBChainState) VerifyProof(proof *BNBProof, minConfirmations int64) (bool, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if proof == nil || proof.Proof == nil {
+		return false, NewBNBRelayingError(InvalidTxProofErr, errors.New("bnb proof is empty"))
+	}
+	if b.LatestBlock == nil {
+		return false, NewBNBRelayingError(GetBNBChainErr, errors.New("latest bnb block is nil"))
+	}
+	finalizedBlockHeight := b.finalizedBlockHeight()
+	if proof.BlockHeight > finalizedBlockHeight {
+		return false, NewBNBRelayingError(NotFinalizedBlockErr,
+			fmt.Errorf("block %v is not finalized, finalized block height %v", proof.BlockHeight, finalizedBlockHeight))
+	}
+	if b.LatestBlock.Height < proof.BlockHeight+minConfirmations {
+		return false, NewBNBRelayingError(NotFinalizedBlockErr,
+			fmt.Errorf("not enough %v confirmations for block %v, latest block height %v", minConfirmations, proof.BlockHeight, b.LatestBlock.Height))
+	}
+	dataHash, err := b.getDataHashBNBBlockByHeight(proof.BlockHeight)
+	if err != nil {
+		return false, NewBNBRelayingError(GetBNBDataHashErr, err)
+	}
+	isValid, err2 := proof.Verify(dataHash)
+	if err2 != nil {
+		return false, err2
+	}
+	return isValid, nil
+}
+
 func appendBlockToBlocksArray(b *types.Block, blocks []*types.Block) ([]*types.Block, error) {
 	if blocks == nil {
 		return []*types.Block{b}, nil
@@ -251,7 +477,45 @@ func (b *BNBChainState) addBlockToOrphanBlocks(block *types.Block) error {
 	return nil
 }
 
-func (b *BNBChainState) ProcessNewBlock(block *types.Block, chainID string) error {
+// ProcessNewBlock processes a relayed bnb block
+// the last block of a bnb epoch carries validator set of the next epoch (nextValidators), it is needed to verify blocks of the next epoch
+func (b *BNBChainState) ProcessNewBlock(block *types.Block, nextValidators []*BNBValidator, chainID string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	err := b.processNewBlock(block, chainID)
+	if err != nil {
+		return err
+	}
+	if len(nextValidators) == 0 {
+		if !bytes.Equal(block.ValidatorsHash, block.NextValidatorsHash) {
+			Logger.log.Warnf("[ProcessNewBlock] Block %v changes validator set to %X but does not carry it\n", block.Height, block.NextValidatorsHash)
+		}
+		return nil
+	}
+	nextValidatorSet, err := NewValidatorSet(nextValidators)
+	if err != nil {
+		Logger.log.Errorf("[ProcessNewBlock] Invalid next validators of block %v %v\n", block.Height, err)
+		return NewBNBRelayingError(InvalidValidatorSetErr, err)
+	}
+	if !bytes.Equal(block.NextValidatorsHash, nextValidatorSet.Hash()) {
+		Logger.log.Errorf("[ProcessNewBlock] Next validators of block %v are unmatched with its next validators hash\n", block.Height)
+		return NewBNBRelayingError(InvalidValidatorSetErr, errors.New("next validators are unmatched with next validators hash"))
+	}
+	err = b.addValidatorSet(nextValidators)
+	if err != nil {
+		return err
+	}
+	// orphan blocks of the new epoch can be verified now
+	err = b.checkOrphanBlocks(chainID)
+	if err != nil {
+		Logger.log.Errorf("[ProcessNewBlock] Error when check orphan blocks %v\n", err)
+		return NewBNBRelayingError(CheckOrphanBlockErr, err)
+	}
+	return nil
+}
+
+func (b *BNBChainState) processNewBlock(block *types.Block, chainID string) error {
 	// verify lastCommit
 	if block.LastCommit == nil {
 		Logger.log.Errorf("[ProcessNewBlock] last commit is nil")
@@ -307,8 +571,13 @@ func (b *BNBChainState) handleBlock(block *types.Block, chainID string) (bool, b
 	if block.Height == b.LatestBlock.Height+1 {
 		// check last blockID
 		if bytes.Equal(block.LastBlockID.Hash.Bytes(), b.LatestBlock.Hash()) {
+			// validator set of the block must be the one committed by its previous block
+			if !bytes.Equal(block.ValidatorsHash, b.LatestBlock.NextValidatorsHash) {
+				Logger.log.Errorf("[ProcessNewBlock] validators hash of block %v is unmatched with next validators hash of the previous block", block.Height)
+				return true, false, NewBNBRelayingError(InvalidValidatorSetErr, errors.New("validators hash is unmatched with next validators hash of the previous block"))
+			}
 			newSignedHeader := NewSignedHeader(&b.LatestBlock.Header, block.LastCommit)
-			isValid, err := VerifySignedHeader(newSignedHeader, chainID)
+			isValid, err := b.verifySignedHeader(newSignedHeader, chainID)
 			if isValid && err == nil {
 				b.CandidateNextBlocks, err = appendBlockToBlocksArray(block, b.CandidateNextBlocks)
 				if err != nil {
@@ -332,8 +601,12 @@ func (b *BNBChainState) handleBlock(block *types.Block, chainID string) (bool, b
 	if block.Height == b.LatestBlock.Height+2 {
 		for _, cb := range b.CandidateNextBlocks {
 			if block.Height == cb.Height+1 && bytes.Equal(block.LastBlockID.Hash.Bytes(), cb.Hash()) {
+				if !bytes.Equal(block.ValidatorsHash, cb.NextValidatorsHash) {
+					Logger.log.Errorf("[ProcessNewBlock] validators hash of block %v is unmatched with next validators hash of the previous block", block.Height)
+					return false, true, NewBNBRelayingError(InvalidValidatorSetErr, errors.New("validators hash is unmatched with next validators hash of the previous block"))
+				}
 				newSignedHeader := NewSignedHeader(&cb.Header, block.LastCommit)
-				isValid, err := VerifySignedHeader(newSignedHeader, chainID)
+				isValid, err := b.verifySignedHeader(newSignedHeader, chainID)
 				if isValid && err == nil {
 					b.FinalBlocks, err = appendBlockToBlocksArray(b.LatestBlock, b.FinalBlocks)
 					if err != nil {
@@ -343,6 +616,9 @@ func (b *BNBChainState) handleBlock(block *types.Block, chainID string) (bool, b
 					b.LatestBlock = cb
 					b.CandidateNextBlocks = []*types.Block{block}
 					Logger.log.Infof("[ProcessNewBlock] Case 2 new confirmation block for one of candidate blocks %v\n", block.Height)
+					if pendingValidatorsHash := b.pendingValidatorsHash(); pendingValidatorsHash != nil {
+						Logger.log.Infof("[ProcessNewBlock] Validator set changes to %X after block %v\n", pendingValidatorsHash, cb.Height)
+					}
 					return false, true, nil
 				} else {
 					Logger.log.Errorf("[ProcessNewBlock] invalid new signed header %v", err)
@@ -376,20 +652,44 @@ func (b *BNBChainState) checkOrphanBlocks(chainID string) error {
 	// check whether the block is the confirmation block of one of candidate blocks
 
 	// else, do nothing
-	for blkHeight, blks := range b.OrphanBlocks {
+	for _, blkHeight := range blkHeightKeys {
 		if blkHeight <= b.LatestBlock.Height {
 			delete(b.OrphanBlocks, blkHeight)
-		} else {
-			for i := 0; i < len(blks); i++ {
-				isCase1, isCase2, err := b.handleBlock(blks[i], chainID)
-				if isCase1 || (isCase2 && err == nil) {
-					b.OrphanBlocks[blkHeight] = removeBlock(b.OrphanBlocks[blkHeight], i)
-					if len(b.OrphanBlocks[blkHeight]) == 0 {
-						delete(b.OrphanBlocks, blkHeight)
-					}
-				}
+			continue
+		}
+		for i := 0; i < len(b.OrphanBlocks[blkHeight]); {
+			isCase1, isCase2, err := b.handleBlock(b.OrphanBlocks[blkHeight][i], chainID)
+			if isCase1 || (isCase2 && err == nil) {
+				b.OrphanBlocks[blkHeight] = removeBlock(b.OrphanBlocks[blkHeight], i)
+				continue
 			}
+			i++
+		}
+		if len(b.OrphanBlocks[blkHeight]) == 0 {
+			delete(b.OrphanBlocks, blkHeight)
 		}
 	}
+	b.pruneStaleBlocks()
 	return nil
 }
+
+// pruneStaleBlocks removes candidate blocks which are not next blocks of the latest block
+// and orphan blocks which are behind the latest block or too far ahead of it
+func (b *BNBChainState) pruneStaleBlocks() {
+	candidateBlocks := []*types.Block{}
+	for _, cb := range b.CandidateNextBlocks {
+		if cb.Height == b.LatestBlock.Height+1 && bytes.Equal(cb.LastBlockID.Hash.Bytes(), b.LatestBlock.Hash()) {
+			candidateBlocks = append(candidateBlocks, cb)
+		} else {
+			Logger.log.Infof("[pruneStaleBlocks] Remove stale candidate block %v - %X\n", cb.Height, cb.Hash())
+		}
+	}
+	b.CandidateNextBlocks = candidateBlocks
+
+	for blkHeight := range b.OrphanBlocks {
+		if blkHeight <= b.LatestBlock.Height || blkHeight > b.LatestBlock.Height+MaxOrphanBlockGap {
+			Logger.log.Infof("[pruneStaleBlocks] Remove stale orphan blocks at height %v\n", blkHeight)
+			delete(b.OrphanBlocks, blkHeight)
+		}
+	}
+}
@@ -2,12 +2,17 @@ package bnb
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/types"
+	"strings"
 	"testing"
+	"time"
 )
 
 func getBNBHeaderStrFromBinanceNetwork(blockHeight int64, url string) (string, error) {
@@ -53,7 +58,7 @@ func TestProcessNewBlock(t *testing.T) {
 		nextBlock, err := getBNBHeaderFromBinanceNetwork(nextBlockHeight, TestnetURLRemote)
 		assert.Nil(t, err)
 
-		err = state.ProcessNewBlock(nextBlock, TestnetBNBChainID)
+		err = state.ProcessNewBlock(nextBlock, nil, TestnetBNBChainID)
 		assert.Nil(t, err)
 		fmt.Printf("bnb chain state after processing block height: %v\n", nextBlockHeight)
 		fmt.Printf("state.FinalBlocks: %v\n", state.FinalBlocks)
@@ -69,7 +74,7 @@ func TestProcessNewBlock(t *testing.T) {
 		nextBlock, err := getBNBHeaderFromBinanceNetwork(oldBlockHeight, TestnetURLRemote)
 		assert.Nil(t, err)
 
-		err = state.ProcessNewBlock(nextBlock, TestnetBNBChainID)
+		err = state.ProcessNewBlock(nextBlock, nil, TestnetBNBChainID)
 		assert.Nil(t, err)
 		fmt.Printf("bnb chain state after processing block height: %v\n", oldBlockHeight)
 		fmt.Printf("state.FinalBlocks: %v\n", state.FinalBlocks)
@@ -81,3 +86,262 @@ func TestProcessNewBlock(t *testing.T) {
 		oldBlockHeight++
 	}
 }
+
+const testBNBChainID = "Binance-Chain-Test"
+
+type testBNBValidators struct {
+	privKeys   []ed25519.PrivKeyEd25519
+	validators []*BNBValidator
+	hash       []byte
+}
+
+func newTestBNBValidators(t *testing.T, secret string, n int) *testBNBValidators {
+	vals := &testBNBValidators{}
+	for i := 0; i < n; i++ {
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("%v-%v", secret, i)))
+		pubKey := privKey.PubKey().(ed25519.PubKeyEd25519)
+		vals.privKeys = append(vals.privKeys, privKey)
+		vals.validators = append(vals.validators, &BNBValidator{
+			Address:     strings.ToUpper(hex.EncodeToString(pubKey.Address())),
+			PubKey:      pubKey[:],
+			VotingPower: 10,
+		})
+	}
+	validatorSet, err := NewValidatorSet(vals.validators)
+	assert.Nil(t, err)
+	vals.hash = validatorSet.Hash()
+	return vals
+}
+
+// commit returns the commit of block signed by all validators
+func (vals *testBNBValidators) commit(t *testing.T, block *types.Block) *types.Commit {
+	blockID := types.BlockID{Hash: block.Hash()}
+	precommits := []*types.CommitSig{}
+	for i, privKey := range vals.privKeys {
+		vote := &types.Vote{
+			Type:             types.PrecommitType,
+			Height:           block.Height,
+			BlockID:          blockID,
+			Timestamp:        block.Time,
+			ValidatorAddress: privKey.PubKey().Address(),
+			ValidatorIndex:   i,
+		}
+		sig, err := privKey.Sign(vote.SignBytes(testBNBChainID))
+		assert.Nil(t, err)
+		vote.Signature = sig
+		precommits = append(precommits, vote.CommitSig())
+	}
+	return types.NewCommit(blockID, precommits)
+}
+
+// newTestBNBBlock returns the next block of lastBlock, the previous block is committed by lastBlockValidators
+func newTestBNBBlock(t *testing.T, lastBlock *types.Block, lastBlockValidators *testBNBValidators, validatorsHash []byte, nextValidatorsHash []byte) *types.Block {
+	lastCommit := lastBlockValidators.commit(t, lastBlock)
+	return &types.Block{
+		Header: types.Header{
+			ChainID:            testBNBChainID,
+			Height:             lastBlock.Height + 1,
+			Time:               lastBlock.Time.Add(time.Second),
+			LastBlockID:        types.BlockID{Hash: lastBlock.Hash()},
+			LastCommitHash:     lastCommit.Hash(),
+			ValidatorsHash:     validatorsHash,
+			NextValidatorsHash: nextValidatorsHash,
+		},
+		LastCommit: lastCommit,
+	}
+}
+
+// newTestBNBChainState returns chain state with the genesis block of the epoch of validators
+func newTestBNBChainState(validators *testBNBValidators) *BNBChainState {
+	genesisBlock := &types.Block{
+		Header: types.Header{
+			ChainID:            testBNBChainID,
+			Height:             100,
+			Time:               time.Unix(1600000000, 0).UTC(),
+			ValidatorsHash:     validators.hash,
+			NextValidatorsHash: validators.hash,
+		},
+		LastCommit: &types.Commit{},
+	}
+	return &BNBChainState{
+		LatestBlock:  genesisBlock,
+		OrphanBlocks: map[int64][]*types.Block{},
+		ValidatorSets: map[string][]*BNBValidator{
+			strings.ToUpper(hex.EncodeToString(validators.hash)): validators.validators,
+		},
+	}
+}
+
+func TestProcessNewBlock_ValidatorSetChange(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+	valsB := newTestBNBValidators(t, "epoch-b", 3)
+	valsC := newTestBNBValidators(t, "epoch-c", 3)
+
+	state := newTestBNBChainState(valsA)
+	genesisBlock := state.LatestBlock
+	// the last block of epoch A announces validator set B
+	block101 := newTestBNBBlock(t, genesisBlock, valsA, valsA.hash, valsB.hash)
+	block102 := newTestBNBBlock(t, block101, valsA, valsB.hash, valsB.hash)
+	block103 := newTestBNBBlock(t, block102, valsB, valsB.hash, valsB.hash)
+
+	// next validators must match next validators hash of the block
+	err := state.ProcessNewBlock(block101, valsC.validators, testBNBChainID)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(state.ValidatorSets))
+
+	state = newTestBNBChainState(valsA)
+	assert.Nil(t, state.ProcessNewBlock(block101, valsB.validators, testBNBChainID))
+	assert.Equal(t, 2, len(state.ValidatorSets))
+	assert.Nil(t, state.ProcessNewBlock(block102, nil, testBNBChainID))
+	assert.Equal(t, block101.Height, state.LatestBlock.Height)
+	assert.Equal(t, valsB.hash, []byte(state.PendingValidatorsHash()))
+
+	// block 102 is signed by validator set B
+	assert.Nil(t, state.ProcessNewBlock(block103, nil, testBNBChainID))
+	assert.Equal(t, block102.Height, state.LatestBlock.Height)
+	assert.Equal(t, []*types.Block{block103}, state.CandidateNextBlocks)
+	assert.Equal(t, block101.Height, state.FinalizedBlockHeight())
+	assert.Nil(t, state.PendingValidatorsHash())
+
+	// final blocks which have not been stored yet are served
+	block, err := state.GetBNBBlockByHeight(block101.Height)
+	assert.Nil(t, err)
+	assert.Equal(t, block101, block)
+	_, err = state.GetBNBBlockByHeight(block102.Height)
+	assert.NotNil(t, err)
+}
+
+func TestProcessNewBlock_MissingValidatorSet(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+	valsB := newTestBNBValidators(t, "epoch-b", 3)
+
+	state := newTestBNBChainState(valsA)
+	block101 := newTestBNBBlock(t, state.LatestBlock, valsA, valsA.hash, valsB.hash)
+	block102 := newTestBNBBlock(t, block101, valsA, valsB.hash, valsB.hash)
+	block103 := newTestBNBBlock(t, block102, valsB, valsB.hash, valsB.hash)
+	block104 := newTestBNBBlock(t, block103, valsB, valsB.hash, valsB.hash)
+
+	// block 101 does not carry validator set B, so block 102 can not be confirmed
+	assert.Nil(t, state.ProcessNewBlock(block101, nil, testBNBChainID))
+	assert.Nil(t, state.ProcessNewBlock(block102, nil, testBNBChainID))
+	assert.Nil(t, state.ProcessNewBlock(block103, nil, testBNBChainID))
+	assert.Equal(t, block101.Height, state.LatestBlock.Height)
+	assert.Equal(t, []*types.Block{block103}, state.OrphanBlocks[block103.Height])
+
+	// an uncommitted validator set is rejected
+	assert.NotNil(t, state.AddValidatorSet(newTestBNBValidators(t, "epoch-c", 3).validators))
+
+	// orphan blocks are confirmed once validator set B is added
+	assert.Nil(t, state.AddValidatorSet(valsB.validators))
+	assert.Nil(t, state.ProcessNewBlock(block104, nil, testBNBChainID))
+	assert.Equal(t, block103.Height, state.LatestBlock.Height)
+	assert.Equal(t, []*types.Block{block104}, state.CandidateNextBlocks)
+	assert.Equal(t, 0, len(state.OrphanBlocks))
+}
+
+func TestProcessNewBlock_UnmatchedValidatorsHash(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+	valsB := newTestBNBValidators(t, "epoch-b", 3)
+
+	state := newTestBNBChainState(valsA)
+	// block 101 is signed by A but claims validator set B without an announcement of the previous block
+	block101 := newTestBNBBlock(t, state.LatestBlock, valsA, valsB.hash, valsB.hash)
+	_ = state.ProcessNewBlock(block101, nil, testBNBChainID)
+	assert.Equal(t, 0, len(state.CandidateNextBlocks))
+	assert.Equal(t, 0, len(state.OrphanBlocks))
+}
+
+func TestProcessNewBlock_PruneStaleBlocks(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+
+	state := newTestBNBChainState(valsA)
+	genesisBlock := state.LatestBlock
+	block101 := newTestBNBBlock(t, genesisBlock, valsA, valsA.hash, valsA.hash)
+	block102 := newTestBNBBlock(t, block101, valsA, valsA.hash, valsA.hash)
+	// a fork of block 101, it is stale once block 101 becomes the latest block
+	forkBlock101 := newTestBNBBlock(t, genesisBlock, valsA, valsA.hash, valsA.hash)
+	forkBlock101.Time = forkBlock101.Time.Add(time.Second)
+
+	nearOrphanBlock := &types.Block{Header: types.Header{ChainID: testBNBChainID, Height: 110}, LastCommit: &types.Commit{}}
+	farOrphanBlock := &types.Block{Header: types.Header{ChainID: testBNBChainID, Height: 101 + MaxOrphanBlockGap + 1}, LastCommit: &types.Commit{}}
+	state.OrphanBlocks[nearOrphanBlock.Height] = []*types.Block{nearOrphanBlock}
+	state.OrphanBlocks[farOrphanBlock.Height] = []*types.Block{farOrphanBlock}
+
+	assert.Nil(t, state.ProcessNewBlock(block101, nil, testBNBChainID))
+	assert.Nil(t, state.ProcessNewBlock(forkBlock101, nil, testBNBChainID))
+	assert.Equal(t, 2, len(state.CandidateNextBlocks))
+	assert.Nil(t, state.ProcessNewBlock(block102, nil, testBNBChainID))
+
+	assert.Equal(t, block101.Height, state.LatestBlock.Height)
+	assert.Equal(t, []*types.Block{block102}, state.CandidateNextBlocks)
+	assert.Equal(t, map[int64][]*types.Block{nearOrphanBlock.Height: {nearOrphanBlock}}, state.OrphanBlocks)
+}
+
+func TestBNBChainState_Snapshot(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+	state := newTestBNBChainState(valsA)
+	blocks := []*types.Block{state.LatestBlock}
+	for i := 0; i < 20; i++ {
+		blocks = append(blocks, newTestBNBBlock(t, blocks[len(blocks)-1], valsA, valsA.hash, valsA.hash))
+	}
+
+	// blocks are processed while the state is read, orphan blocks are added and removed
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := len(blocks) - 1; i > 0; i-- {
+			_ = state.ProcessNewBlock(blocks[i], nil, testBNBChainID)
+		}
+	}()
+	for {
+		snapshot := state.Snapshot()
+		for height, orphanBlocks := range snapshot.OrphanBlocks {
+			assert.True(t, height > snapshot.LatestBlock.Height)
+			assert.NotEqual(t, 0, len(orphanBlocks))
+		}
+		select {
+		case <-done:
+			snapshot = state.Snapshot()
+			assert.Equal(t, blocks[len(blocks)-2].Height, snapshot.LatestBlock.Height)
+			assert.Equal(t, []string{strings.ToUpper(hex.EncodeToString(valsA.hash))}, snapshot.ValidatorsHashes)
+
+			// the snapshot is a copy
+			snapshot.CandidateNextBlocks[0] = nil
+			assert.NotNil(t, state.CandidateNextBlocks[0])
+			return
+		default:
+		}
+	}
+}
+
+func TestParseRelayingBlock(t *testing.T) {
+	valsA := newTestBNBValidators(t, "epoch-a", 4)
+	valsB := newTestBNBValidators(t, "epoch-b", 3)
+	state := newTestBNBChainState(valsA)
+	block101 := newTestBNBBlock(t, state.LatestBlock, valsA, valsA.hash, valsB.hash)
+
+	blockBytes, err := json.Marshal(block101)
+	assert.Nil(t, err)
+	block, nextValidators, err := ParseRelayingBlock(blockBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, block101.Hash(), block.Hash())
+	assert.Nil(t, nextValidators)
+
+	relayingBlockBytes, err := json.Marshal(struct {
+		*types.Block
+		NextValidators []*BNBValidator `json:"next_validators"`
+	}{block101, valsB.validators})
+	assert.Nil(t, err)
+	block, nextValidators, err = ParseRelayingBlock(relayingBlockBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, block101.Hash(), block.Hash())
+	assert.Equal(t, valsB.validators, nextValidators)
+
+	_, _, err = ParseRelayingBlock([]byte("block"))
+	assert.NotNil(t, err)
+}
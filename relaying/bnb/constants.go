@@ -6,6 +6,7 @@ const (
 	DenomBNB              = "BNB"
 	MinConfirmationsBlock = 3
 	MaxOrphanBlocks       = 1000
+	MaxOrphanBlockGap     = 100 // orphan blocks higher than latest block height + MaxOrphanBlockGap are pruned
 
 	// mainnet
 	MainnetBNBChainID         = "Binance-Chain-Tigris"
@@ -21,6 +21,8 @@ const (
 	FullOrphanBlockErr
 	AddBlockToOrphanBlockErr
 	CheckOrphanBlockErr
+	InvalidValidatorSetErr
+	NotFinalizedBlockErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -43,6 +45,8 @@ var ErrCodeMessage = map[int]struct {
 	FullOrphanBlockErr:       {-14011, "Full orphan blocks error"},
 	AddBlockToOrphanBlockErr: {-14012, "Add block to orphan blocks error"},
 	CheckOrphanBlockErr:      {-14013, "Check orphan blocks error"},
+	InvalidValidatorSetErr:   {-14014, "Invalid validator set error"},
+	NotFinalizedBlockErr:     {-14015, "Block is not finalized error"},
 }
 
 type BNBRelayingError struct {
@@ -24,7 +24,10 @@ func VerifySignature(sh *types.SignedHeader, chainID string) error {
 		validatorMap = validatorsMainnet
 		totalVotingPowerParam = MainnetTotalVotingPowers
 	}
+	return verifySignatureByValidators(sh, chainID, validatorMap, int64(totalVotingPowerParam))
+}
 
+func verifySignatureByValidators(sh *types.SignedHeader, chainID string, validatorMap map[string]*types.Validator, totalVotingPowerParam int64) error {
 	signedValidators := map[string]bool{}
 	sigs := sh.Commit.Precommits
 	totalVotingPower := int64(0)
@@ -36,15 +39,20 @@ func VerifySignature(sh *types.SignedHeader, chainID string) error {
 		vote := sh.Commit.GetVote(i)
 		if vote != nil {
 			validateAddressStr := strings.ToUpper(hex.EncodeToString(vote.ValidatorAddress))
+			validator, ok := validatorMap[validateAddressStr]
+			if !ok || validator == nil {
+				Logger.log.Errorf("Signature from unknown validator %v\n", validateAddressStr)
+				continue
+			}
 			// check duplicate vote
 			if !signedValidators[validateAddressStr] {
 				signedValidators[validateAddressStr] = true
-				err := vote.Verify(chainID, validatorMap[validateAddressStr].PubKey)
+				err := vote.Verify(chainID, validator.PubKey)
 				if err != nil {
 					Logger.log.Errorf("Invalid signature index %v - %v\n", i, err)
 					continue
 				}
-				totalVotingPower += validatorMap[validateAddressStr].VotingPower
+				totalVotingPower += validator.VotingPower
 			} else {
 				Logger.log.Errorf("Duplicate signature from the same validator %v\n", validateAddressStr)
 			}
@@ -52,7 +60,7 @@ func VerifySignature(sh *types.SignedHeader, chainID string) error {
 	}
 
 	// not greater than 2/3 voting power
-	if totalVotingPower <= int64(totalVotingPowerParam)*2/3 {
+	if totalVotingPower <= totalVotingPowerParam*2/3 {
 		return NewBNBRelayingError(InvalidSignatureSignedHeaderErr, errors.New("not greater than 2/3 voting power"))
 	}
 
@@ -72,3 +80,19 @@ func VerifySignedHeader(sh *types.SignedHeader, chainID string) (bool, error) {
 
 	return true, nil
 }
+
+// VerifySignedHeaderByValidators verifies signed header with the commit signed by a given validator set
+func VerifySignedHeaderByValidators(sh *types.SignedHeader, chainID string, validators []*BNBValidator) (bool, error) {
+	err := sh.ValidateBasic(chainID)
+	if err != nil {
+		return false, NewBNBRelayingError(InvalidBasicSignedHeaderErr, err)
+	}
+
+	validatorMap, totalVotingPower := getValidatorMap(validators)
+	err2 := verifySignatureByValidators(sh, chainID, validatorMap, totalVotingPower)
+	if err2 != nil {
+		return false, err2
+	}
+
+	return true, nil
+}
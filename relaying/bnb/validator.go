@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -204,3 +205,67 @@ func NewFixedValidators(chainID string) (map[string]*types.Validator, error) {
 
 var validatorsMainnet, _ = NewFixedValidators(MainnetBNBChainID)
 var validatorsTestnet, _ = NewFixedValidators(TestnetBNBChainID)
+
+// BNBValidator is a validator of a bnb validator set in a form that can be stored and sent over RPC
+type BNBValidator struct {
+	Address     string
+	PubKey      []byte
+	VotingPower int64
+}
+
+// NewValidatorSet builds tendermint validator set from list of bnb validators
+func NewValidatorSet(validators []*BNBValidator) (*types.ValidatorSet, error) {
+	if len(validators) == 0 {
+		return nil, errors.New("validator set is empty")
+	}
+	tmValidators := make([]*types.Validator, 0, len(validators))
+	for _, v := range validators {
+		if len(v.PubKey) != ed25519.PubKeyEd25519Size {
+			return nil, fmt.Errorf("invalid public key length of validator %v", v.Address)
+		}
+		if v.VotingPower <= 0 {
+			return nil, fmt.Errorf("invalid voting power of validator %v", v.Address)
+		}
+		var pubKey ed25519.PubKeyEd25519
+		copy(pubKey[:], v.PubKey)
+		if strings.ToUpper(hex.EncodeToString(pubKey.Address())) != strings.ToUpper(v.Address) {
+			return nil, fmt.Errorf("address %v does not match public key", v.Address)
+		}
+		tmValidators = append(tmValidators, types.NewValidator(pubKey, v.VotingPower))
+	}
+	return types.NewValidatorSet(tmValidators), nil
+}
+
+// getValidatorMap returns validators map by address and total voting power of the validator set
+func getValidatorMap(validators []*BNBValidator) (map[string]*types.Validator, int64) {
+	validatorMap := make(map[string]*types.Validator, len(validators))
+	totalVotingPower := int64(0)
+	for _, v := range validators {
+		var pubKey ed25519.PubKeyEd25519
+		copy(pubKey[:], v.PubKey)
+		validatorMap[strings.ToUpper(v.Address)] = &types.Validator{
+			PubKey:      pubKey,
+			VotingPower: v.VotingPower,
+		}
+		totalVotingPower += v.VotingPower
+	}
+	return validatorMap, totalVotingPower
+}
+
+// ParseRelayingBlock parses json of a relayed bnb block
+// the last block of a bnb epoch also carries validator set of the next epoch in field "next_validators"
+func ParseRelayingBlock(data []byte) (*types.Block, []*BNBValidator, error) {
+	block := new(types.Block)
+	err := json.Unmarshal(data, block)
+	if err != nil {
+		return nil, nil, err
+	}
+	var nextValidators struct {
+		NextValidators []*BNBValidator `json:"next_validators"`
+	}
+	err = json.Unmarshal(data, &nextValidators)
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, nextValidators.NextValidators, nil
+}
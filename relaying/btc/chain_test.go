@@ -2,6 +2,9 @@ package btcrelaying
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -296,7 +299,12 @@ func TestReorganizeChainV2(t *testing.T) {
 
 	fmt.Println(genBlk)
 
-	chain, err := GetChainV2("haveblock",
+	dbDir, err := ioutil.TempDir("", "btcrelaying")
+	if err != nil {
+		t.Fatalf("Failed to create db directory: %v", err)
+	}
+	defer os.RemoveAll(dbDir)
+	chain, err := GetChainV2(filepath.Join(dbDir, "haveblock"),
 		&chaincfg.MainNetParams, 0)
 	if err != nil {
 		t.Errorf("Failed to setup chain instance: %v", err)
@@ -610,7 +618,12 @@ func TestGetChainInfo(t *testing.T) {
 	}
 	fmt.Println(genBlk)
 
-	chain, err := GetChainV2("haveblock",
+	dbDir, err := ioutil.TempDir("", "btcrelaying")
+	if err != nil {
+		t.Fatalf("Failed to create db directory: %v", err)
+	}
+	defer os.RemoveAll(dbDir)
+	chain, err := GetChainV2(filepath.Join(dbDir, "haveblock"),
 		&chaincfg.MainNetParams, 0)
 	if err != nil {
 		t.Errorf("Failed to get chain instance: %v", err)
@@ -0,0 +1,83 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// ExportHeaders writes the serialized 80-byte headers of the main chain to w,
+// from the genesis block of the relaying chain up to the best block.
+// It returns the number of written headers.
+func (b *BlockChain) ExportHeaders(w io.Writer) (int, error) {
+	bestState := b.BestSnapshot()
+	if bestState == nil {
+		return 0, AssertError("ExportHeaders best state is nil")
+	}
+	count := 0
+	for height := b.genesisBlkHeight; height <= bestState.Height; height++ {
+		hash, err := b.BlockHashByHeight(height)
+		if err != nil {
+			return count, err
+		}
+		header, err := b.HeaderByHash(hash)
+		if err != nil {
+			return count, err
+		}
+		err = header.Serialize(w)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// ReadHeaders reads serialized 80-byte headers from r until EOF
+func ReadHeaders(r io.Reader) ([]*wire.BlockHeader, error) {
+	headers := []*wire.BlockHeader{}
+	headerBytes := make([]byte, wire.MaxBlockHeaderPayload)
+	for {
+		_, err := io.ReadFull(r, headerBytes)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("header %d is truncated", len(headers))
+		}
+		if err != nil {
+			return nil, err
+		}
+		header := &wire.BlockHeader{}
+		err = header.Deserialize(bytes.NewReader(headerBytes))
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// ImportHeaders inserts the headers into the chain with the same proof of work and difficulty
+// validation as relayed headers. Headers which are already known are skipped, so a file
+// exported from another node can be imported into a chain with the same genesis block.
+// It returns the number of imported headers.
+func (b *BlockChain) ImportHeaders(headers []*wire.BlockHeader) (int, error) {
+	imported := 0
+	for i, header := range headers {
+		hash := header.BlockHash()
+		if b.index.HaveBlock(&hash) {
+			continue
+		}
+		block := btcutil.NewBlock(&wire.MsgBlock{Header: *header})
+		_, _, err := b.ProcessBlockV2(block, BFNone)
+		if err != nil {
+			return imported, fmt.Errorf("failed to import header %d (%s): %v", i, hash.String(), err)
+		}
+		imported++
+	}
+	return imported, nil
+}
@@ -0,0 +1,84 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestExportImportHeaders(t *testing.T) {
+	blocks, err := loadBlocks("blk_0_to_4.dat.bz2")
+	if err != nil {
+		t.Fatalf("Error loading file: %v\n", err)
+	}
+	dbRoot, err := ioutil.TempDir("", "headerio")
+	if err != nil {
+		t.Fatalf("Failed to create db root: %v", err)
+	}
+	defer os.RemoveAll(dbRoot)
+	srcChain, err := GetChainV2(filepath.Join(dbRoot, "exportheaders"), &chaincfg.MainNetParams, 0)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	for i := 1; i < len(blocks); i++ {
+		blocks[i].MsgBlock().ClearTransactions()
+		_, _, err := srcChain.ProcessBlockV2(blocks[i], BFNone)
+		if err != nil {
+			t.Fatalf("ProcessBlock fail on block %v: %v\n", i, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	count, err := srcChain.ExportHeaders(&buf)
+	if err != nil {
+		t.Fatalf("ExportHeaders fail: %v", err)
+	}
+	if count != len(blocks) || buf.Len() != len(blocks)*wire.MaxBlockHeaderPayload {
+		t.Fatalf("ExportHeaders wrote %v headers (%v bytes), expected %v", count, buf.Len(), len(blocks))
+	}
+
+	headers, err := ReadHeaders(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadHeaders fail: %v", err)
+	}
+	_, err = ReadHeaders(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if err == nil {
+		t.Fatalf("ReadHeaders should fail on a truncated file")
+	}
+
+	dstChain, err := GetChainV2(filepath.Join(dbRoot, "importheaders"), &chaincfg.MainNetParams, 0)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+
+	// a header with an invalid proof of work is rejected
+	tamperedHeader := *headers[1]
+	tamperedHeader.Nonce++
+	_, err = dstChain.ImportHeaders([]*wire.BlockHeader{&tamperedHeader})
+	if err == nil {
+		t.Fatalf("ImportHeaders should reject a header with an invalid proof of work")
+	}
+
+	imported, err := dstChain.ImportHeaders(headers)
+	if err != nil {
+		t.Fatalf("ImportHeaders fail: %v", err)
+	}
+	// the genesis header is known already
+	if imported != len(headers)-1 {
+		t.Fatalf("ImportHeaders imported %v headers, expected %v", imported, len(headers)-1)
+	}
+	if dstChain.BestSnapshot().Hash != srcChain.BestSnapshot().Hash {
+		t.Fatalf("Best block of the imported chain %v is different from %v", dstChain.BestSnapshot().Hash, srcChain.BestSnapshot().Hash)
+	}
+
+	// importing the same headers again is a no-op
+	imported, err = dstChain.ImportHeaders(headers)
+	if err != nil || imported != 0 {
+		t.Fatalf("ImportHeaders again imported %v headers with error %v", imported, err)
+	}
+}
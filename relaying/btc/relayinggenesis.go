@@ -8,6 +8,13 @@ import (
 	"github.com/btcsuite/btcd/wire"
 )
 
+// heights of the hardcoded genesis blocks of the relaying chains
+const (
+	MainNetGenesisBlockHeight         = int32(634140)
+	TestNet3GenesisBlockHeight        = int32(1896910)
+	TestNet3GenesisBlockHeightForInc2 = int32(1863675)
+)
+
 func getHardcodedMainNetGenesisBlock() (*wire.MsgBlock, *chainhash.Hash) {
 	// Block 634140 from bitcoin mainnet
 	genesisHash, _ := chainhash.NewHashFromStr("00000000000000000008d18906abd79b6f21ffb02a805d5c85f976efc6d76d6c")
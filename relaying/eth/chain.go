@@ -0,0 +1,392 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/pkg/errors"
+)
+
+var (
+	headerKeyPrefix    = []byte("ethrelaying-header-")
+	canonicalKeyPrefix = []byte("ethrelaying-canonical-")
+	tipKey             = []byte("ethrelaying-tip")
+)
+
+func newHeaderKey(hash rCommon.Hash) []byte {
+	return append(append([]byte{}, headerKeyPrefix...), hash.Bytes()...)
+}
+
+func newCanonicalKey(number uint64) []byte {
+	key := append([]byte{}, canonicalKeyPrefix...)
+	numberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(numberBytes, number)
+	return append(key, numberBytes...)
+}
+
+// storedHeader is a relayed header with the total difficulty of the chain ending at it
+type storedHeader struct {
+	Header          *types.Header
+	TotalDifficulty *big.Int
+}
+
+// HeaderChain tracks relayed ETH headers from the root checkpoint,
+// the canonical chain is the one with the highest total difficulty
+type HeaderChain struct {
+	config       *HeaderChainConfig
+	db           incdb.Database
+	sealVerifier SealVerifier
+	rootNumber   uint64
+	tip          *storedHeader
+	lock         sync.RWMutex
+}
+
+// NewHeaderChain loads the relayed chain from db,
+// sealVerifier is only used if config.VerifyPoW is true, nil means ethash
+func NewHeaderChain(config *HeaderChainConfig, db incdb.Database, sealVerifier SealVerifier) (*HeaderChain, error) {
+	if len(config.Checkpoints) == 0 {
+		return nil, NewETHRelayingError(UnexpectedErr, errors.New("there is no checkpoint in config"))
+	}
+	if config.VerifyPoW && config.ChainConfig == nil {
+		return nil, NewETHRelayingError(UnexpectedErr, errors.New("chain config is required to verify proof of work"))
+	}
+	if config.VerifyPoW == (config.Clique != nil) {
+		return nil, NewETHRelayingError(UnexpectedErr, errors.New("headers must be verified by either proof of work or clique"))
+	}
+	if config.Clique != nil && config.Clique.Epoch == 0 {
+		return nil, NewETHRelayingError(UnexpectedErr, errors.New("clique epoch must be positive"))
+	}
+	if config.VerifyPoW && sealVerifier == nil {
+		sealVerifier = NewEthashSealVerifier()
+	}
+	checkpointNumbers := make([]uint64, 0, len(config.Checkpoints))
+	for number := range config.Checkpoints {
+		checkpointNumbers = append(checkpointNumbers, number)
+	}
+	sort.Slice(checkpointNumbers, func(i, j int) bool {
+		return checkpointNumbers[i] < checkpointNumbers[j]
+	})
+	hc := &HeaderChain{
+		config:       config,
+		db:           db,
+		sealVerifier: sealVerifier,
+		rootNumber:   checkpointNumbers[0],
+	}
+	if config.Clique != nil && !hc.isCliqueCheckpoint(hc.rootNumber) {
+		return nil, NewETHRelayingError(UnexpectedErr, fmt.Errorf("root %v is not a clique epoch checkpoint", hc.rootNumber))
+	}
+
+	hasTip, err := db.Has(tipKey)
+	if err != nil {
+		return nil, NewETHRelayingError(GetETHChainErr, err)
+	}
+	if hasTip {
+		tipHash, err := db.Get(tipKey)
+		if err != nil {
+			return nil, NewETHRelayingError(GetETHChainErr, err)
+		}
+		hc.tip, err = hc.getStoredHeader(rCommon.BytesToHash(tipHash))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hc, nil
+}
+
+func (hc *HeaderChain) getStoredHeader(hash rCommon.Hash) (*storedHeader, error) {
+	key := newHeaderKey(hash)
+	has, err := hc.db.Has(key)
+	if err != nil {
+		return nil, NewETHRelayingError(GetETHChainErr, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	value, err := hc.db.Get(key)
+	if err != nil {
+		return nil, NewETHRelayingError(GetETHChainErr, err)
+	}
+	res := new(storedHeader)
+	err = rlp.DecodeBytes(value, res)
+	if err != nil {
+		return nil, NewETHRelayingError(GetETHChainErr, err)
+	}
+	return res, nil
+}
+
+func (hc *HeaderChain) getCanonicalHash(number uint64) (rCommon.Hash, bool, error) {
+	key := newCanonicalKey(number)
+	has, err := hc.db.Has(key)
+	if err != nil {
+		return rCommon.Hash{}, false, NewETHRelayingError(GetETHChainErr, err)
+	}
+	if !has {
+		return rCommon.Hash{}, false, nil
+	}
+	value, err := hc.db.Get(key)
+	if err != nil {
+		return rCommon.Hash{}, false, NewETHRelayingError(GetETHChainErr, err)
+	}
+	return rCommon.BytesToHash(value), true, nil
+}
+
+func (hc *HeaderChain) isCanonical(header *types.Header) (bool, error) {
+	hash, ok, err := hc.getCanonicalHash(header.Number.Uint64())
+	if err != nil || !ok {
+		return false, err
+	}
+	return hash == header.Hash(), nil
+}
+
+// getFinalizedHeight returns the height under which the canonical chain can not be reorged
+func (hc *HeaderChain) getFinalizedHeight() uint64 {
+	if hc.tip == nil {
+		return hc.rootNumber
+	}
+	tipNumber := hc.tip.Header.Number.Uint64()
+	if tipNumber < hc.rootNumber+hc.config.FinalityDepth {
+		return hc.rootNumber
+	}
+	return tipNumber - hc.config.FinalityDepth
+}
+
+// findForkPoint returns the nearest canonical ancestor of the header
+func (hc *HeaderChain) findForkPoint(header *types.Header) (*types.Header, error) {
+	cur := header
+	for {
+		isCanonical, err := hc.isCanonical(cur)
+		if err != nil {
+			return nil, err
+		}
+		if isCanonical {
+			return cur, nil
+		}
+		if cur.Number.Uint64() <= hc.rootNumber {
+			return nil, NewETHRelayingError(UnexpectedErr, errors.New("can not find the fork point"))
+		}
+		parent, err := hc.getStoredHeader(cur.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, NewETHRelayingError(UnexpectedErr, fmt.Errorf("ancestor %v is not found", cur.ParentHash.String()))
+		}
+		cur = parent.Header
+	}
+}
+
+// ProcessHeader validates the header and appends it to the relayed chain,
+// it returns true if the header becomes the tip of the canonical chain
+func (hc *HeaderChain) ProcessHeader(header *types.Header) (bool, error) {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	if header.Number == nil || header.Difficulty == nil {
+		return false, NewETHRelayingError(InvalidHeaderErr, errors.New("number and difficulty of header must not be nil"))
+	}
+	hash := header.Hash()
+	number := header.Number.Uint64()
+	existed, err := hc.getStoredHeader(hash)
+	if err != nil {
+		return false, err
+	}
+	if existed != nil {
+		return false, NewETHRelayingError(ExistedHeaderErr, fmt.Errorf("header %v", hash.String()))
+	}
+	if checkpoint, ok := hc.config.Checkpoints[number]; ok && checkpoint != hash {
+		return false, NewETHRelayingError(CheckpointMismatchErr, fmt.Errorf("header %v at %v, checkpoint %v", hash.String(), number, checkpoint.String()))
+	}
+
+	// the first relayed header must be the root checkpoint
+	if hc.tip == nil {
+		if number != hc.rootNumber {
+			return false, NewETHRelayingError(OrphanHeaderErr, fmt.Errorf("the first relayed header must be at the checkpoint %v", hc.rootNumber))
+		}
+		return true, hc.storeHeader(&storedHeader{Header: header, TotalDifficulty: new(big.Int).Set(header.Difficulty)}, true)
+	}
+
+	parent, err := hc.getStoredHeader(header.ParentHash)
+	if err != nil {
+		return false, err
+	}
+	if parent == nil {
+		return false, NewETHRelayingError(OrphanHeaderErr, fmt.Errorf("parent %v of header %v", header.ParentHash.String(), hash.String()))
+	}
+	err = validateHeader(hc.config, header, parent.Header)
+	if err != nil {
+		return false, NewETHRelayingError(InvalidHeaderErr, err)
+	}
+	forkPoint, err := hc.findForkPoint(parent.Header)
+	if err != nil {
+		return false, err
+	}
+	if forkPoint.Number.Uint64() < hc.getFinalizedHeight() {
+		return false, NewETHRelayingError(BeyondFinalityErr, fmt.Errorf("fork point %v, finalized height %v", forkPoint.Number, hc.getFinalizedHeight()))
+	}
+	if hc.config.VerifyPoW {
+		err = hc.sealVerifier.VerifySeal(header)
+		if err != nil {
+			return false, NewETHRelayingError(InvalidSealErr, err)
+		}
+	}
+	if hc.config.Clique != nil {
+		err = hc.verifyCliqueHeader(header, parent.Header)
+		if err != nil {
+			return false, NewETHRelayingError(InvalidSealErr, err)
+		}
+	}
+
+	newHeader := &storedHeader{
+		Header:          header,
+		TotalDifficulty: new(big.Int).Add(parent.TotalDifficulty, header.Difficulty),
+	}
+	isMainChain := newHeader.TotalDifficulty.Cmp(hc.tip.TotalDifficulty) > 0
+	err = hc.storeHeader(newHeader, isMainChain)
+	if err != nil {
+		return false, err
+	}
+	return isMainChain, nil
+}
+
+// storeHeader stores the header, and if it is the new tip, rewrites the canonical index from the fork point
+func (hc *HeaderChain) storeHeader(newHeader *storedHeader, isNewTip bool) error {
+	value, err := rlp.EncodeToBytes(newHeader)
+	if err != nil {
+		return NewETHRelayingError(StoreETHChainErr, err)
+	}
+	batch := hc.db.NewBatch()
+	hash := newHeader.Header.Hash()
+	err = batch.Put(newHeaderKey(hash), value)
+	if err != nil {
+		return NewETHRelayingError(StoreETHChainErr, err)
+	}
+	// signers listed by a clique epoch checkpoint seal the headers of the next epoch
+	if hc.config.Clique != nil && hc.isCliqueCheckpoint(newHeader.Header.Number.Uint64()) {
+		signers, err := getCliqueCheckpointSigners(newHeader.Header)
+		if err != nil {
+			return NewETHRelayingError(InvalidHeaderErr, err)
+		}
+		signersBytes := make([]byte, 0, len(signers)*rCommon.AddressLength)
+		for _, signer := range signers {
+			signersBytes = append(signersBytes, signer.Bytes()...)
+		}
+		err = batch.Put(newSignersKey(hash), signersBytes)
+		if err != nil {
+			return NewETHRelayingError(StoreETHChainErr, err)
+		}
+	}
+
+	if isNewTip {
+		newNumber := newHeader.Header.Number.Uint64()
+		// a heavier chain can be shorter than the current one
+		if hc.tip != nil {
+			for number := hc.tip.Header.Number.Uint64(); number > newNumber; number-- {
+				err = batch.Delete(newCanonicalKey(number))
+				if err != nil {
+					return NewETHRelayingError(StoreETHChainErr, err)
+				}
+			}
+		}
+		cur := newHeader.Header
+		for {
+			isCanonical, err := hc.isCanonical(cur)
+			if err != nil {
+				return err
+			}
+			if isCanonical {
+				break
+			}
+			err = batch.Put(newCanonicalKey(cur.Number.Uint64()), cur.Hash().Bytes())
+			if err != nil {
+				return NewETHRelayingError(StoreETHChainErr, err)
+			}
+			if cur.Number.Uint64() <= hc.rootNumber {
+				break
+			}
+			parent, err := hc.getStoredHeader(cur.ParentHash)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return NewETHRelayingError(StoreETHChainErr, fmt.Errorf("ancestor %v is not found", cur.ParentHash.String()))
+			}
+			cur = parent.Header
+		}
+		err = batch.Put(tipKey, hash.Bytes())
+		if err != nil {
+			return NewETHRelayingError(StoreETHChainErr, err)
+		}
+	}
+
+	err = batch.Write()
+	if err != nil {
+		return NewETHRelayingError(StoreETHChainErr, err)
+	}
+	if isNewTip {
+		if hc.tip != nil && newHeader.Header.ParentHash != hc.tip.Header.Hash() {
+			Logger.log.Infof("[ETH Relaying] reorg from %v (%v) to %v (%v)", hc.tip.Header.Hash().String(), hc.tip.Header.Number, hash.String(), newHeader.Header.Number)
+		}
+		hc.tip = newHeader
+	}
+	return nil
+}
+
+func (hc *HeaderChain) GetDB() incdb.Database {
+	return hc.db
+}
+
+// GetLatestHeader returns the tip of the canonical chain, nil if no header is relayed
+func (hc *HeaderChain) GetLatestHeader() *types.Header {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	if hc.tip == nil {
+		return nil
+	}
+	return hc.tip.Header
+}
+
+// GetFinalizedHeight returns the height under which the canonical chain can not be reorged anymore
+func (hc *HeaderChain) GetFinalizedHeight() uint64 {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	return hc.getFinalizedHeight()
+}
+
+// GetHeader returns a relayed header, nil if it is not relayed
+func (hc *HeaderChain) GetHeader(hash rCommon.Hash) (*types.Header, error) {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	stored, err := hc.getStoredHeader(hash)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	return stored.Header, nil
+}
+
+// GetCanonicalHeader returns the header of hash with its number of confirmations if it is on the canonical chain
+func (hc *HeaderChain) GetCanonicalHeader(hash rCommon.Hash) (*types.Header, uint64, error) {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	stored, err := hc.getStoredHeader(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if stored == nil {
+		return nil, 0, NewETHRelayingError(GetETHChainErr, fmt.Errorf("header %v is not relayed", hash.String()))
+	}
+	isCanonical, err := hc.isCanonical(stored.Header)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isCanonical {
+		return nil, 0, NewETHRelayingError(GetETHChainErr, fmt.Errorf("header %v is being on fork branch", hash.String()))
+	}
+	return stored.Header, hc.tip.Header.Number.Uint64() - stored.Header.Number.Uint64(), nil
+}
@@ -0,0 +1,211 @@
+package eth
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+type fakeSealVerifier struct {
+	invalidNumber uint64
+}
+
+func (verifier *fakeSealVerifier) VerifySeal(header *types.Header) error {
+	if header.Number.Uint64() == verifier.invalidNumber {
+		return errors.New("invalid proof of work")
+	}
+	return nil
+}
+
+var testRoot = &types.Header{
+	Number:     big.NewInt(100),
+	Difficulty: big.NewInt(1000000),
+	GasLimit:   8000000,
+	Time:       1000,
+}
+
+func newTestConfig(verifyPoW bool) *HeaderChainConfig {
+	return &HeaderChainConfig{
+		ChainConfig:   params.MainnetChainConfig,
+		VerifyPoW:     verifyPoW,
+		Checkpoints:   map[uint64]rCommon.Hash{100: testRoot.Hash()},
+		FinalityDepth: 5,
+	}
+}
+
+// newChildHeader returns a valid child of parent, timeDelta changes the difficulty in proof of work mode
+func newChildHeader(config *HeaderChainConfig, parent *types.Header, timeDelta uint64) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + timeDelta,
+		Difficulty: big.NewInt(1000000),
+	}
+	if config.VerifyPoW {
+		header.Difficulty = ethash.CalcDifficulty(config.ChainConfig, header.Time, parent)
+	}
+	return header
+}
+
+func newTestHeaderChain(t *testing.T, config *HeaderChainConfig) (*HeaderChain, incdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "ethrelaying")
+	assert.Nil(t, err)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	hc, err := NewHeaderChain(config, db, &fakeSealVerifier{invalidNumber: 1000})
+	assert.Nil(t, err)
+	return hc, db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestHeaderChainProcessHeader(t *testing.T) {
+	config := newTestConfig(true)
+	hc, _, cleanup := newTestHeaderChain(t, config)
+	defer cleanup()
+
+	// the first header must be the root checkpoint
+	h101 := newChildHeader(config, testRoot, 13)
+	_, err := hc.ProcessHeader(h101)
+	assert.NotNil(t, err)
+	isMainChain, err := hc.ProcessHeader(testRoot)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+	_, err = hc.ProcessHeader(testRoot)
+	assert.Equal(t, ErrCodeMessage[ExistedHeaderErr].Code, err.(*ETHRelayingError).GetCode())
+
+	isMainChain, err = hc.ProcessHeader(h101)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+	assert.Equal(t, h101.Hash(), hc.GetLatestHeader().Hash())
+
+	// orphan
+	_, err = hc.ProcessHeader(newChildHeader(config, newChildHeader(config, h101, 13), 13))
+	assert.Equal(t, ErrCodeMessage[OrphanHeaderErr].Code, err.(*ETHRelayingError).GetCode())
+
+	// wrong difficulty
+	invalidHeader := newChildHeader(config, h101, 13)
+	invalidHeader.Difficulty = big.NewInt(1)
+	_, err = hc.ProcessHeader(invalidHeader)
+	assert.Equal(t, ErrCodeMessage[InvalidHeaderErr].Code, err.(*ETHRelayingError).GetCode())
+
+	// wrong timestamp
+	invalidHeader = newChildHeader(config, h101, 0)
+	_, err = hc.ProcessHeader(invalidHeader)
+	assert.Equal(t, ErrCodeMessage[InvalidHeaderErr].Code, err.(*ETHRelayingError).GetCode())
+
+	header, confirmations, err := hc.GetCanonicalHeader(testRoot.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, testRoot.Hash(), header.Hash())
+	assert.Equal(t, uint64(1), confirmations)
+}
+
+func TestHeaderChainReorg(t *testing.T) {
+	config := newTestConfig(true)
+	hc, db, cleanup := newTestHeaderChain(t, config)
+	defer cleanup()
+
+	_, err := hc.ProcessHeader(testRoot)
+	assert.Nil(t, err)
+	// main branch: slow blocks, lower difficulty
+	a1 := newChildHeader(config, testRoot, 30)
+	a2 := newChildHeader(config, a1, 30)
+	for _, header := range []*types.Header{a1, a2} {
+		isMainChain, err := hc.ProcessHeader(header)
+		assert.Nil(t, err)
+		assert.True(t, isMainChain)
+	}
+	// side branch: fast blocks, higher difficulty
+	b1 := newChildHeader(config, testRoot, 5)
+	isMainChain, err := hc.ProcessHeader(b1)
+	assert.Nil(t, err)
+	assert.False(t, isMainChain)
+	_, _, err = hc.GetCanonicalHeader(b1.Hash())
+	assert.NotNil(t, err)
+
+	b2 := newChildHeader(config, b1, 5)
+	isMainChain, err = hc.ProcessHeader(b2)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+	assert.Equal(t, b2.Hash(), hc.GetLatestHeader().Hash())
+	_, _, err = hc.GetCanonicalHeader(a1.Hash())
+	assert.NotNil(t, err)
+	_, confirmations, err := hc.GetCanonicalHeader(b1.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), confirmations)
+
+	// the relayed chain is reloaded from db
+	hc2, err := NewHeaderChain(config, db, &fakeSealVerifier{})
+	assert.Nil(t, err)
+	assert.Equal(t, b2.Hash(), hc2.GetLatestHeader().Hash())
+	_, _, err = hc2.GetCanonicalHeader(b1.Hash())
+	assert.Nil(t, err)
+}
+
+func TestHeaderChainFinalityAndCheckpoint(t *testing.T) {
+	config := newTestConfig(true)
+	hc, _, cleanup := newTestHeaderChain(t, config)
+	defer cleanup()
+
+	_, err := hc.ProcessHeader(testRoot)
+	assert.Nil(t, err)
+	headers := []*types.Header{testRoot}
+	for i := 0; i < 10; i++ {
+		header := newChildHeader(config, headers[len(headers)-1], 13)
+		_, err := hc.ProcessHeader(header)
+		assert.Nil(t, err)
+		headers = append(headers, header)
+	}
+	assert.Equal(t, uint64(105), hc.GetFinalizedHeight())
+
+	// fork before the finalized height
+	fork := newChildHeader(config, headers[3], 1)
+	_, err = hc.ProcessHeader(fork)
+	assert.Equal(t, ErrCodeMessage[BeyondFinalityErr].Code, err.(*ETHRelayingError).GetCode())
+
+	// fork after the finalized height is kept as a side branch
+	fork = newChildHeader(config, headers[8], 1)
+	isMainChain, err := hc.ProcessHeader(fork)
+	assert.Nil(t, err)
+	assert.False(t, isMainChain)
+
+	// checkpoint
+	next := newChildHeader(config, headers[len(headers)-1], 13)
+	config.Checkpoints[next.Number.Uint64()] = rCommon.HexToHash("0x01")
+	_, err = hc.ProcessHeader(next)
+	assert.Equal(t, ErrCodeMessage[CheckpointMismatchErr].Code, err.(*ETHRelayingError).GetCode())
+	config.Checkpoints[next.Number.Uint64()] = next.Hash()
+	_, err = hc.ProcessHeader(next)
+	assert.Nil(t, err)
+}
+
+func TestHeaderChainInvalidSeal(t *testing.T) {
+	config := newTestConfig(true)
+	root := &types.Header{Number: big.NewInt(999), Difficulty: big.NewInt(1000000), GasLimit: 8000000, Time: 1000}
+	config.Checkpoints = map[uint64]rCommon.Hash{999: root.Hash()}
+	hc, _, cleanup := newTestHeaderChain(t, config)
+	defer cleanup()
+
+	_, err := hc.ProcessHeader(root)
+	assert.Nil(t, err)
+	_, err = hc.ProcessHeader(newChildHeader(config, root, 13))
+	assert.Equal(t, ErrCodeMessage[InvalidSealErr].Code, err.(*ETHRelayingError).GetCode())
+}
@@ -0,0 +1,202 @@
+package eth
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+const (
+	cliqueExtraVanity = 32 // bytes of extra data reserved for the signer vanity
+	cliqueExtraSeal   = 65 // bytes of extra data reserved for the signature of the signer
+)
+
+var (
+	signersKeyPrefix = []byte("ethrelaying-signers-")
+
+	cliqueDiffInTurn  = rCommon.Big2
+	cliqueDiffNoTurn  = rCommon.Big1
+	cliqueNonceAuth   = types.BlockNonce{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	cliqueNonceDrop   = types.BlockNonce{}
+	cliqueEmptyUncles = types.CalcUncleHash(nil)
+)
+
+func newSignersKey(hash rCommon.Hash) []byte {
+	return append(append([]byte{}, signersKeyPrefix...), hash.Bytes()...)
+}
+
+// getCliqueCheckpointSigners returns the authorized signers listed in the extra data of an epoch checkpoint header
+func getCliqueCheckpointSigners(header *types.Header) ([]rCommon.Address, error) {
+	if len(header.Extra) < cliqueExtraVanity+cliqueExtraSeal {
+		return nil, errors.New("extra data of checkpoint is too short")
+	}
+	signersBytes := header.Extra[cliqueExtraVanity : len(header.Extra)-cliqueExtraSeal]
+	if len(signersBytes) == 0 || len(signersBytes)%rCommon.AddressLength != 0 {
+		return nil, fmt.Errorf("invalid signer list of %v bytes in checkpoint", len(signersBytes))
+	}
+	signers := make([]rCommon.Address, len(signersBytes)/rCommon.AddressLength)
+	for i := range signers {
+		copy(signers[i][:], signersBytes[i*rCommon.AddressLength:])
+	}
+	return signers, nil
+}
+
+// getCliqueSigner recovers the address which sealed header
+func getCliqueSigner(header *types.Header) (rCommon.Address, error) {
+	if len(header.Extra) < cliqueExtraSeal {
+		return rCommon.Address{}, errors.New("missing signature in extra data")
+	}
+	signature := header.Extra[len(header.Extra)-cliqueExtraSeal:]
+	pubKey, err := crypto.Ecrecover(clique.SealHash(header).Bytes(), signature)
+	if err != nil {
+		return rCommon.Address{}, err
+	}
+	var signer rCommon.Address
+	copy(signer[:], crypto.Keccak256(pubKey[1:])[12:])
+	return signer, nil
+}
+
+func (hc *HeaderChain) isCliqueCheckpoint(number uint64) bool {
+	return number%hc.config.Clique.Epoch == 0
+}
+
+// getCliqueSigners returns the signers authorized to seal the child of parent,
+// they are listed by the last epoch checkpoint on the branch of parent
+func (hc *HeaderChain) getCliqueSigners(parent *types.Header) ([]rCommon.Address, error) {
+	checkpointNumber := parent.Number.Uint64() - parent.Number.Uint64()%hc.config.Clique.Epoch
+	if checkpointNumber < hc.rootNumber {
+		return nil, fmt.Errorf("epoch checkpoint %v is before the root %v", checkpointNumber, hc.rootNumber)
+	}
+	cur := parent
+	for cur.Number.Uint64() > checkpointNumber {
+		isCanonical, err := hc.isCanonical(cur)
+		if err != nil {
+			return nil, err
+		}
+		if isCanonical {
+			break
+		}
+		stored, err := hc.getStoredHeader(cur.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+		if stored == nil {
+			return nil, fmt.Errorf("ancestor %v is not found", cur.ParentHash.String())
+		}
+		cur = stored.Header
+	}
+	checkpointHash := cur.Hash()
+	if cur.Number.Uint64() > checkpointNumber {
+		var ok bool
+		var err error
+		checkpointHash, ok, err = hc.getCanonicalHash(checkpointNumber)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("epoch checkpoint %v is not relayed", checkpointNumber)
+		}
+	}
+	value, err := hc.db.Get(newSignersKey(checkpointHash))
+	if err != nil {
+		return nil, fmt.Errorf("signers of epoch checkpoint %v are not found: %v", checkpointHash.String(), err)
+	}
+	signers := make([]rCommon.Address, len(value)/rCommon.AddressLength)
+	for i := range signers {
+		copy(signers[i][:], value[i*rCommon.AddressLength:])
+	}
+	return signers, nil
+}
+
+// verifyCliqueHeader checks the proof of authority of header: it must be sealed by a signer authorized
+// at the last epoch checkpoint, who did not seal one of the last len(signers)/2 blocks,
+// and its difficulty must tell whether the signer is in turn.
+// Signers voted in or out in the middle of an epoch are only taken into account from the next checkpoint.
+func (hc *HeaderChain) verifyCliqueHeader(header *types.Header, parent *types.Header) error {
+	number := header.Number.Uint64()
+	isCheckpoint := hc.isCliqueCheckpoint(number)
+	if isCheckpoint && header.Coinbase != (rCommon.Address{}) {
+		return errors.New("checkpoint must have zero beneficiary")
+	}
+	if header.Nonce != cliqueNonceAuth && header.Nonce != cliqueNonceDrop {
+		return errors.New("invalid vote nonce")
+	}
+	if isCheckpoint && header.Nonce != cliqueNonceDrop {
+		return errors.New("checkpoint must have zero nonce")
+	}
+	if len(header.Extra) < cliqueExtraVanity+cliqueExtraSeal {
+		return errors.New("extra data is too short")
+	}
+	if isCheckpoint {
+		if _, err := getCliqueCheckpointSigners(header); err != nil {
+			return err
+		}
+	} else if len(header.Extra) != cliqueExtraVanity+cliqueExtraSeal {
+		return errors.New("non checkpoint header must not list signers")
+	}
+	if header.MixDigest != (rCommon.Hash{}) {
+		return errors.New("mix digest must be zero")
+	}
+	if header.UncleHash != cliqueEmptyUncles {
+		return errors.New("header must not have uncles")
+	}
+	if header.Difficulty.Cmp(cliqueDiffInTurn) != 0 && header.Difficulty.Cmp(cliqueDiffNoTurn) != 0 {
+		return fmt.Errorf("invalid difficulty %v", header.Difficulty)
+	}
+	if header.Time < parent.Time+hc.config.Clique.Period {
+		return fmt.Errorf("timestamp %v is less than %v seconds after the one of parent %v", header.Time, hc.config.Clique.Period, parent.Time)
+	}
+
+	signers, err := hc.getCliqueSigners(parent)
+	if err != nil {
+		return err
+	}
+	signer, err := getCliqueSigner(header)
+	if err != nil {
+		return err
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i][:], signers[j][:]) < 0
+	})
+	offset := -1
+	for i := range signers {
+		if signers[i] == signer {
+			offset = i
+			break
+		}
+	}
+	if offset < 0 {
+		return fmt.Errorf("signer %v is not authorized", signer.String())
+	}
+	isInTurn := number%uint64(len(signers)) == uint64(offset)
+	if isInTurn && header.Difficulty.Cmp(cliqueDiffInTurn) != 0 || !isInTurn && header.Difficulty.Cmp(cliqueDiffNoTurn) != 0 {
+		return fmt.Errorf("difficulty %v does not match the turn of signer %v", header.Difficulty, signer.String())
+	}
+
+	// a signer may only seal one of len(signers)/2+1 consecutive blocks
+	cur := parent
+	for i := 0; i < len(signers)/2 && cur.Number.Uint64() > hc.rootNumber; i++ {
+		recent, err := getCliqueSigner(cur)
+		if err != nil {
+			return err
+		}
+		if recent == signer {
+			return fmt.Errorf("signer %v sealed block %v recently", signer.String(), cur.Number)
+		}
+		stored, err := hc.getStoredHeader(cur.ParentHash)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return fmt.Errorf("ancestor %v is not found", cur.ParentHash.String())
+		}
+		cur = stored.Header
+	}
+	return nil
+}
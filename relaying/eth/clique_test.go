@@ -0,0 +1,161 @@
+package eth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCliqueSigners returns keys sorted by address, the signer in turn at block n is n % len(keys)
+func newTestCliqueSigners(t *testing.T, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		assert.Nil(t, err)
+		keys[i] = key
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(), crypto.PubkeyToAddress(keys[j].PublicKey).Bytes()) < 0
+	})
+	return keys
+}
+
+// newCliqueHeader returns a child of parent sealed by key, signers are listed if the child is an epoch checkpoint
+func newCliqueHeader(t *testing.T, parent *types.Header, key *ecdsa.PrivateKey, difficulty int64, signers []*ecdsa.PrivateKey) *types.Header {
+	extra := make([]byte, cliqueExtraVanity)
+	for _, signer := range signers {
+		extra = append(extra, crypto.PubkeyToAddress(signer.PublicKey).Bytes()...)
+	}
+	extra = append(extra, make([]byte, cliqueExtraSeal)...)
+	header := &types.Header{
+		UncleHash:  types.CalcUncleHash(nil),
+		Number:     big.NewInt(0),
+		GasLimit:   8000000,
+		Difficulty: big.NewInt(difficulty),
+		Extra:      extra,
+	}
+	if parent != nil {
+		header.ParentHash = parent.Hash()
+		header.Number = new(big.Int).Add(parent.Number, big.NewInt(1))
+		header.Time = parent.Time + 15
+	}
+	if key != nil {
+		seal, err := crypto.Sign(clique.SealHash(header).Bytes(), key)
+		assert.Nil(t, err)
+		copy(header.Extra[len(header.Extra)-cliqueExtraSeal:], seal)
+	}
+	return header
+}
+
+func newTestCliqueConfig(root *types.Header) *HeaderChainConfig {
+	return &HeaderChainConfig{
+		ChainConfig:   params.GoerliChainConfig,
+		Clique:        &params.CliqueConfig{Period: 15, Epoch: 4},
+		Checkpoints:   map[uint64]rCommon.Hash{root.Number.Uint64(): root.Hash()},
+		FinalityDepth: 100,
+	}
+}
+
+func TestHeaderChainConfig(t *testing.T) {
+	root := newCliqueHeader(t, nil, nil, 1, newTestCliqueSigners(t, 1))
+	config := newTestCliqueConfig(root)
+	config.VerifyPoW = true
+	_, err := NewHeaderChain(config, nil, nil)
+	assert.NotNil(t, err)
+	config.VerifyPoW = false
+	config.Clique = nil
+	_, err = NewHeaderChain(config, nil, nil)
+	assert.NotNil(t, err)
+	// the signers of a clique chain are known from an epoch checkpoint
+	config = newTestCliqueConfig(root)
+	config.Checkpoints = map[uint64]rCommon.Hash{3: root.Hash()}
+	_, err = NewHeaderChain(config, nil, nil)
+	assert.NotNil(t, err)
+
+	for _, config := range []*HeaderChainConfig{GetMainnetConfig(), GetGoerliConfig()} {
+		_, _, cleanup := newTestHeaderChain(t, config)
+		cleanup()
+	}
+}
+
+// cliqueDifficulty returns the difficulty of the block number sealed by key, signers are sorted by address
+func cliqueDifficulty(signers []*ecdsa.PrivateKey, number int, key *ecdsa.PrivateKey) int64 {
+	if signers[number%len(signers)] == key {
+		return 2
+	}
+	return 1
+}
+
+func TestHeaderChainClique(t *testing.T) {
+	keys := newTestCliqueSigners(t, 3)
+	outsider := newTestCliqueSigners(t, 1)[0]
+	root := newCliqueHeader(t, nil, nil, 1, keys)
+	hc, _, cleanup := newTestHeaderChain(t, newTestCliqueConfig(root))
+	defer cleanup()
+	_, err := hc.ProcessHeader(root)
+	assert.Nil(t, err)
+
+	// the signer in turn seals with difficulty 2
+	h1 := newCliqueHeader(t, root, keys[1], 2, nil)
+	isMainChain, err := hc.ProcessHeader(h1)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+
+	invalidHeaders := map[string]*types.Header{
+		"unauthorized signer":       newCliqueHeader(t, h1, outsider, 1, nil),
+		"difficulty of turn":        newCliqueHeader(t, h1, keys[2], 1, nil),
+		"difficulty out of turn":    newCliqueHeader(t, h1, keys[0], 2, nil),
+		"recently sealed":           newCliqueHeader(t, h1, keys[1], 1, nil),
+		"signers out of checkpoint": newCliqueHeader(t, h1, keys[2], 2, keys),
+		"unsigned":                  newCliqueHeader(t, h1, nil, 2, nil),
+	}
+	for name, header := range invalidHeaders {
+		_, err = hc.ProcessHeader(header)
+		if assert.NotNil(t, err, name) {
+			assert.Contains(t, []int{ErrCodeMessage[InvalidSealErr].Code, ErrCodeMessage[InvalidHeaderErr].Code}, err.(*ETHRelayingError).GetCode(), name)
+		}
+	}
+	tooEarly := newCliqueHeader(t, h1, nil, 2, nil)
+	tooEarly.Time = h1.Time + 1
+	seal, _ := crypto.Sign(clique.SealHash(tooEarly).Bytes(), keys[2])
+	copy(tooEarly.Extra[len(tooEarly.Extra)-cliqueExtraSeal:], seal)
+	_, err = hc.ProcessHeader(tooEarly)
+	assert.NotNil(t, err)
+
+	// a signer out of turn can seal too
+	h2 := newCliqueHeader(t, h1, keys[0], 1, nil)
+	_, err = hc.ProcessHeader(h2)
+	assert.Nil(t, err)
+	h3 := newCliqueHeader(t, h2, keys[1], 1, nil)
+	_, err = hc.ProcessHeader(h3)
+	assert.Nil(t, err)
+
+	// the checkpoint at block 4 is sealed by the signers of the previous epoch, it replaces keys[0] by the outsider
+	newSigners := []*ecdsa.PrivateKey{keys[1], keys[2], outsider}
+	sort.Slice(newSigners, func(i, j int) bool {
+		return bytes.Compare(crypto.PubkeyToAddress(newSigners[i].PublicKey).Bytes(), crypto.PubkeyToAddress(newSigners[j].PublicKey).Bytes()) < 0
+	})
+	_, err = hc.ProcessHeader(newCliqueHeader(t, h3, outsider, 1, newSigners))
+	assert.NotNil(t, err)
+	_, err = hc.ProcessHeader(newCliqueHeader(t, h3, keys[0], 1, nil))
+	assert.NotNil(t, err)
+	h4 := newCliqueHeader(t, h3, keys[0], 1, newSigners)
+	_, err = hc.ProcessHeader(h4)
+	assert.Nil(t, err)
+
+	_, err = hc.ProcessHeader(newCliqueHeader(t, h4, keys[0], cliqueDifficulty(keys, 5, keys[0]), nil))
+	assert.NotNil(t, err)
+	h5 := newCliqueHeader(t, h4, outsider, cliqueDifficulty(newSigners, 5, outsider), nil)
+	isMainChain, err = hc.ProcessHeader(h5)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+}
@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedErr = iota
+	ExistedHeaderErr
+	OrphanHeaderErr
+	InvalidHeaderErr
+	InvalidSealErr
+	CheckpointMismatchErr
+	BeyondFinalityErr
+	StoreETHChainErr
+	GetETHChainErr
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedErr: {-15000, "Unexpected error"},
+
+	ExistedHeaderErr:      {-15001, "Header is existed in relayed chain error"},
+	OrphanHeaderErr:       {-15002, "Parent of header is not relayed error"},
+	InvalidHeaderErr:      {-15003, "Invalid header error"},
+	InvalidSealErr:        {-15004, "Invalid proof of work or authority of header error"},
+	CheckpointMismatchErr: {-15005, "Header mismatches the checkpoint error"},
+	BeyondFinalityErr:     {-15006, "Header forks the chain before the finalized height error"},
+	StoreETHChainErr:      {-15007, "Store eth chain to lvdb error"},
+	GetETHChainErr:        {-15008, "Get eth chain from lvdb error"},
+}
+
+type ETHRelayingError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e ETHRelayingError) Error() string {
+	return fmt.Sprintf("%+v: %+v %+v", e.Code, e.Message, e.err)
+}
+
+func (e ETHRelayingError) GetCode() int {
+	return e.Code
+}
+
+func NewETHRelayingError(key int, err error) *ETHRelayingError {
+	return &ETHRelayingError{
+		err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+	}
+}
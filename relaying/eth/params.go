@@ -0,0 +1,53 @@
+package eth
+
+import (
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// reorgs deeper than this number of blocks from the tip are rejected
+	DefaultFinalityDepth = 100
+)
+
+// HeaderChainConfig is the config of a relayed ETH header chain
+type HeaderChainConfig struct {
+	// ChainConfig is used to compute the expected difficulty of headers when VerifyPoW is true
+	ChainConfig *params.ChainConfig
+	// VerifyPoW is true for proof of work networks, headers are checked by their difficulty and seal
+	VerifyPoW bool
+	// Clique is set for proof of authority networks, headers must be sealed by the signers listed in epoch checkpoints,
+	// the root checkpoint must be an epoch checkpoint
+	Clique *params.CliqueConfig
+	// Checkpoints are trusted header hashes by block number, the lowest one is the root of the relayed chain
+	Checkpoints   map[uint64]rCommon.Hash
+	FinalityDepth uint64
+}
+
+// GetMainnetConfig returns the config of the main network, its root is the head of the last CHT section
+// trusted by the light client of go-ethereum
+func GetMainnetConfig() *HeaderChainConfig {
+	checkpoint := params.MainnetTrustedCheckpoint
+	return &HeaderChainConfig{
+		ChainConfig: params.MainnetChainConfig,
+		VerifyPoW:   true,
+		Checkpoints: map[uint64]rCommon.Hash{
+			(checkpoint.SectionIndex+1)*params.CHTFrequency - 1: checkpoint.SectionHead,
+		},
+		FinalityDepth: DefaultFinalityDepth,
+	}
+}
+
+// GetGoerliConfig returns the config of the Goerli testnet which runs proof of authority (clique),
+// its root is the genesis which lists the initial signers.
+// Kovan is not supported because its Aura headers are not hashed as standard headers
+func GetGoerliConfig() *HeaderChainConfig {
+	return &HeaderChainConfig{
+		ChainConfig: params.GoerliChainConfig,
+		Clique:      params.GoerliChainConfig.Clique,
+		Checkpoints: map[uint64]rCommon.Hash{
+			0: params.GoerliGenesisHash,
+		},
+		FinalityDepth: DefaultFinalityDepth,
+	}
+}
@@ -0,0 +1,68 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+// SealVerifier verifies the proof of work of a header
+type SealVerifier interface {
+	VerifySeal(header *types.Header) error
+}
+
+type ethashSealVerifier struct {
+	engine *ethash.Ethash
+}
+
+// NewEthashSealVerifier returns a verifier which checks the ethash proof of work with light caches
+func NewEthashSealVerifier() SealVerifier {
+	return &ethashSealVerifier{
+		engine: ethash.NewShared(),
+	}
+}
+
+func (verifier *ethashSealVerifier) VerifySeal(header *types.Header) error {
+	return verifier.engine.VerifySeal(nil, header)
+}
+
+// validateHeader checks header against its parent by the rules of ethereum header chains
+func validateHeader(config *HeaderChainConfig, header *types.Header, parent *types.Header) error {
+	if header.Number == nil || header.Difficulty == nil {
+		return errors.New("number and difficulty of header must not be nil")
+	}
+	if header.Number.Cmp(new(big.Int).Add(parent.Number, big.NewInt(1))) != 0 {
+		return fmt.Errorf("invalid block number %v, parent number is %v", header.Number, parent.Number)
+	}
+	if header.Time <= parent.Time {
+		return fmt.Errorf("timestamp %v is not greater than the one of parent %v", header.Time, parent.Time)
+	}
+	if uint64(len(header.Extra)) > params.MaximumExtraDataSize && config.VerifyPoW {
+		return fmt.Errorf("extra data too long: %v > %v", len(header.Extra), params.MaximumExtraDataSize)
+	}
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("invalid gasUsed: have %v, gasLimit %v", header.GasUsed, header.GasLimit)
+	}
+	diff := int64(parent.GasLimit) - int64(header.GasLimit)
+	if diff < 0 {
+		diff *= -1
+	}
+	limit := parent.GasLimit / params.GasLimitBoundDivisor
+	if uint64(diff) >= limit || header.GasLimit < params.MinGasLimit {
+		return fmt.Errorf("invalid gas limit: have %v, want %v += %v", header.GasLimit, parent.GasLimit, limit)
+	}
+	if header.Difficulty.Sign() <= 0 {
+		return errors.New("difficulty of header must be positive")
+	}
+	if config.VerifyPoW {
+		expected := ethash.CalcDifficulty(config.ChainConfig, header.Time, parent)
+		if expected.Cmp(header.Difficulty) != 0 {
+			return fmt.Errorf("invalid difficulty: have %v, want %v", header.Difficulty, expected)
+		}
+	}
+	return nil
+}
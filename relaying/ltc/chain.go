@@ -0,0 +1,436 @@
+package ltcrelaying
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/incdb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/pkg/errors"
+)
+
+const medianTimeBlocks = 11
+
+var (
+	headerKeyPrefix    = []byte("ltcrelaying-header-")
+	canonicalKeyPrefix = []byte("ltcrelaying-canonical-")
+	tipKey             = []byte("ltcrelaying-tip")
+)
+
+func newHeaderKey(hash chainhash.Hash) []byte {
+	return append(append([]byte{}, headerKeyPrefix...), hash[:]...)
+}
+
+func newCanonicalKey(height uint64) []byte {
+	key := append([]byte{}, canonicalKeyPrefix...)
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	return append(key, heightBytes...)
+}
+
+// storedHeader is a relayed header with its height and the total work of the chain ending at it
+type storedHeader struct {
+	Header    wire.BlockHeader
+	Height    uint64
+	TotalWork *big.Int
+}
+
+func (s *storedHeader) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	err := s.Header.Serialize(&buf)
+	if err != nil {
+		return nil, err
+	}
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, s.Height)
+	buf.Write(heightBytes)
+	buf.Write(s.TotalWork.Bytes())
+	return buf.Bytes(), nil
+}
+
+func (s *storedHeader) setBytes(value []byte) error {
+	if len(value) < wire.MaxBlockHeaderPayload+8 {
+		return errors.New("stored header is too short")
+	}
+	err := s.Header.Deserialize(bytes.NewReader(value[:wire.MaxBlockHeaderPayload]))
+	if err != nil {
+		return err
+	}
+	s.Height = binary.BigEndian.Uint64(value[wire.MaxBlockHeaderPayload : wire.MaxBlockHeaderPayload+8])
+	s.TotalWork = new(big.Int).SetBytes(value[wire.MaxBlockHeaderPayload+8:])
+	return nil
+}
+
+// HeaderChain tracks relayed LTC headers from the root header,
+// the canonical chain is the one with the most cumulative work
+type HeaderChain struct {
+	config *HeaderChainConfig
+	db     incdb.Database
+	tip    *storedHeader
+	lock   sync.RWMutex
+}
+
+// NewHeaderChain loads the relayed chain from db
+func NewHeaderChain(config *HeaderChainConfig, db incdb.Database) (*HeaderChain, error) {
+	if config.Params == nil || config.RootHeader == nil {
+		return nil, NewLTCRelayingError(UnexpectedErr, errors.New("params and root header are required"))
+	}
+	hc := &HeaderChain{
+		config: config,
+		db:     db,
+	}
+	hasTip, err := db.Has(tipKey)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	if hasTip {
+		tipHash, err := db.Get(tipKey)
+		if err != nil {
+			return nil, NewLTCRelayingError(GetLTCChainErr, err)
+		}
+		hash, err := chainhash.NewHash(tipHash)
+		if err != nil {
+			return nil, NewLTCRelayingError(GetLTCChainErr, err)
+		}
+		hc.tip, err = hc.getStoredHeader(*hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hc, nil
+}
+
+func (hc *HeaderChain) getStoredHeader(hash chainhash.Hash) (*storedHeader, error) {
+	key := newHeaderKey(hash)
+	has, err := hc.db.Has(key)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	value, err := hc.db.Get(key)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	res := new(storedHeader)
+	err = res.setBytes(value)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	return res, nil
+}
+
+func (hc *HeaderChain) getCanonicalHash(height uint64) (*chainhash.Hash, error) {
+	key := newCanonicalKey(height)
+	has, err := hc.db.Has(key)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	if !has {
+		return nil, nil
+	}
+	value, err := hc.db.Get(key)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	hash, err := chainhash.NewHash(value)
+	if err != nil {
+		return nil, NewLTCRelayingError(GetLTCChainErr, err)
+	}
+	return hash, nil
+}
+
+func (hc *HeaderChain) isCanonical(stored *storedHeader) (bool, error) {
+	hash, err := hc.getCanonicalHash(stored.Height)
+	if err != nil || hash == nil {
+		return false, err
+	}
+	return *hash == stored.Header.BlockHash(), nil
+}
+
+// getAncestor returns the ancestor of stored at height, nil if it is lower than the root
+func (hc *HeaderChain) getAncestor(stored *storedHeader, height uint64) (*storedHeader, error) {
+	if height < hc.config.RootHeight || height > stored.Height {
+		return nil, nil
+	}
+	cur := stored
+	for cur.Height > height {
+		isCanonical, err := hc.isCanonical(cur)
+		if err != nil {
+			return nil, err
+		}
+		if isCanonical {
+			hash, err := hc.getCanonicalHash(height)
+			if err != nil || hash == nil {
+				return nil, err
+			}
+			return hc.getStoredHeader(*hash)
+		}
+		parent, err := hc.getStoredHeader(cur.Header.PrevBlock)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, NewLTCRelayingError(UnexpectedErr, fmt.Errorf("ancestor %v is not found", cur.Header.PrevBlock.String()))
+		}
+		cur = parent
+	}
+	return cur, nil
+}
+
+// calcPastMedianTime returns the median timestamp of the last blocks ending at stored
+func (hc *HeaderChain) calcPastMedianTime(stored *storedHeader) (time.Time, error) {
+	timestamps := make([]int64, 0, medianTimeBlocks)
+	cur := stored
+	for i := 0; i < medianTimeBlocks && cur != nil; i++ {
+		timestamps = append(timestamps, cur.Header.Timestamp.Unix())
+		if cur.Height <= hc.config.RootHeight {
+			break
+		}
+		parent, err := hc.getStoredHeader(cur.Header.PrevBlock)
+		if err != nil {
+			return time.Time{}, err
+		}
+		cur = parent
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	return time.Unix(timestamps[len(timestamps)/2], 0), nil
+}
+
+// checkBits checks the difficulty bits of header by the litecoin retarget rules
+func (hc *HeaderChain) checkBits(header *wire.BlockHeader, parent *storedHeader) error {
+	params := hc.config.Params
+	height := parent.Height + 1
+	interval := blocksPerRetarget(params)
+	if height%interval != 0 {
+		expectedBits := parent.Header.Bits
+		if params.ReduceMinDifficulty {
+			if header.Timestamp.After(parent.Header.Timestamp.Add(params.MinDiffReductionTime)) {
+				expectedBits = params.PowLimitBits
+			} else {
+				// the bits of the last block which is not mined at the minimum difficulty
+				cur := parent
+				for cur.Height%interval != 0 && cur.Header.Bits == params.PowLimitBits && cur.Height > hc.config.RootHeight {
+					prev, err := hc.getStoredHeader(cur.Header.PrevBlock)
+					if err != nil {
+						return err
+					}
+					cur = prev
+				}
+				expectedBits = cur.Header.Bits
+			}
+		}
+		if header.Bits != expectedBits {
+			return fmt.Errorf("invalid difficulty bits %08x, expected %08x", header.Bits, expectedBits)
+		}
+		return nil
+	}
+
+	blocksToGoBack := interval
+	if height == interval {
+		blocksToGoBack = interval - 1
+	}
+	if parent.Height >= hc.config.RootHeight+blocksToGoBack {
+		first, err := hc.getAncestor(parent, parent.Height-blocksToGoBack)
+		if err != nil {
+			return err
+		}
+		expectedBits := calcRetargetBits(params, &parent.Header, first.Header.Timestamp)
+		if header.Bits != expectedBits {
+			return fmt.Errorf("invalid difficulty bits %08x at retarget height %v, expected %08x", header.Bits, height, expectedBits)
+		}
+		return nil
+	}
+
+	// the start of the interval is not relayed, only the adjustment bounds can be checked
+	parentTarget := btcrelaying.CompactToBig(parent.Header.Bits)
+	target := btcrelaying.CompactToBig(header.Bits)
+	factor := big.NewInt(params.RetargetAdjustmentFactor)
+	if target.Cmp(new(big.Int).Mul(parentTarget, factor)) > 0 || target.Cmp(new(big.Int).Div(parentTarget, factor)) < 0 {
+		return fmt.Errorf("difficulty bits %08x at retarget height %v are out of the adjustment bounds", header.Bits, height)
+	}
+	return nil
+}
+
+// ProcessHeader validates the header and appends it to the relayed chain,
+// it returns true if the header becomes the tip of the canonical chain
+func (hc *HeaderChain) ProcessHeader(header *wire.BlockHeader) (bool, error) {
+	hc.lock.Lock()
+	defer hc.lock.Unlock()
+
+	hash := header.BlockHash()
+	existed, err := hc.getStoredHeader(hash)
+	if err != nil {
+		return false, err
+	}
+	if existed != nil {
+		return false, NewLTCRelayingError(ExistedHeaderErr, fmt.Errorf("header %v", hash.String()))
+	}
+
+	// the first relayed header must be the root header
+	if hc.tip == nil {
+		if hash != hc.config.RootHeader.BlockHash() {
+			return false, NewLTCRelayingError(OrphanHeaderErr, fmt.Errorf("the first relayed header must be the root header %v", hc.config.RootHeader.BlockHash().String()))
+		}
+		newHeader := &storedHeader{
+			Header:    *header,
+			Height:    hc.config.RootHeight,
+			TotalWork: btcrelaying.CalcWork(header.Bits),
+		}
+		return true, hc.storeHeader(newHeader, true)
+	}
+
+	parent, err := hc.getStoredHeader(header.PrevBlock)
+	if err != nil {
+		return false, err
+	}
+	if parent == nil {
+		return false, NewLTCRelayingError(OrphanHeaderErr, fmt.Errorf("parent %v of header %v", header.PrevBlock.String(), hash.String()))
+	}
+	medianTime, err := hc.calcPastMedianTime(parent)
+	if err != nil {
+		return false, err
+	}
+	if !header.Timestamp.After(medianTime) {
+		return false, NewLTCRelayingError(InvalidHeaderErr, fmt.Errorf("timestamp %v is not after the median time %v", header.Timestamp, medianTime))
+	}
+	err = hc.checkBits(header, parent)
+	if err != nil {
+		return false, NewLTCRelayingError(InvalidHeaderErr, err)
+	}
+	err = checkProofOfWork(hc.config.Params, header)
+	if err != nil {
+		return false, NewLTCRelayingError(InvalidPoWErr, err)
+	}
+
+	newHeader := &storedHeader{
+		Header:    *header,
+		Height:    parent.Height + 1,
+		TotalWork: new(big.Int).Add(parent.TotalWork, btcrelaying.CalcWork(header.Bits)),
+	}
+	isMainChain := newHeader.TotalWork.Cmp(hc.tip.TotalWork) > 0
+	err = hc.storeHeader(newHeader, isMainChain)
+	if err != nil {
+		return false, err
+	}
+	return isMainChain, nil
+}
+
+// storeHeader stores the header, and if it is the new tip, rewrites the canonical index from the fork point
+func (hc *HeaderChain) storeHeader(newHeader *storedHeader, isNewTip bool) error {
+	value, err := newHeader.bytes()
+	if err != nil {
+		return NewLTCRelayingError(StoreLTCChainErr, err)
+	}
+	batch := hc.db.NewBatch()
+	hash := newHeader.Header.BlockHash()
+	err = batch.Put(newHeaderKey(hash), value)
+	if err != nil {
+		return NewLTCRelayingError(StoreLTCChainErr, err)
+	}
+
+	if isNewTip {
+		// a heavier chain can be shorter than the current one
+		if hc.tip != nil {
+			for height := hc.tip.Height; height > newHeader.Height; height-- {
+				err = batch.Delete(newCanonicalKey(height))
+				if err != nil {
+					return NewLTCRelayingError(StoreLTCChainErr, err)
+				}
+			}
+		}
+		cur := newHeader
+		for {
+			isCanonical, err := hc.isCanonical(cur)
+			if err != nil {
+				return err
+			}
+			if isCanonical {
+				break
+			}
+			curHash := cur.Header.BlockHash()
+			err = batch.Put(newCanonicalKey(cur.Height), curHash[:])
+			if err != nil {
+				return NewLTCRelayingError(StoreLTCChainErr, err)
+			}
+			if cur.Height <= hc.config.RootHeight {
+				break
+			}
+			parent, err := hc.getStoredHeader(cur.Header.PrevBlock)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return NewLTCRelayingError(StoreLTCChainErr, fmt.Errorf("ancestor %v is not found", cur.Header.PrevBlock.String()))
+			}
+			cur = parent
+		}
+		err = batch.Put(tipKey, hash[:])
+		if err != nil {
+			return NewLTCRelayingError(StoreLTCChainErr, err)
+		}
+	}
+
+	err = batch.Write()
+	if err != nil {
+		return NewLTCRelayingError(StoreLTCChainErr, err)
+	}
+	if isNewTip {
+		if hc.tip != nil && newHeader.Header.PrevBlock != hc.tip.Header.BlockHash() {
+			Logger.log.Infof("[LTC Relaying] reorg from %v (%v) to %v (%v)", hc.tip.Header.BlockHash().String(), hc.tip.Height, hash.String(), newHeader.Height)
+		}
+		hc.tip = newHeader
+	}
+	return nil
+}
+
+func (hc *HeaderChain) GetDB() incdb.Database {
+	return hc.db
+}
+
+func (hc *HeaderChain) GetChainParams() *chaincfg.Params {
+	return hc.config.Params
+}
+
+// GetLatestHeader returns the tip of the canonical chain with its height, nil if no header is relayed
+func (hc *HeaderChain) GetLatestHeader() (*wire.BlockHeader, uint64) {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	if hc.tip == nil {
+		return nil, 0
+	}
+	header := hc.tip.Header
+	return &header, hc.tip.Height
+}
+
+// GetCanonicalHeader returns the header of hash with its number of confirmations if it is on the canonical chain
+func (hc *HeaderChain) GetCanonicalHeader(hash chainhash.Hash) (*wire.BlockHeader, uint64, error) {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+	stored, err := hc.getStoredHeader(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if stored == nil {
+		return nil, 0, NewLTCRelayingError(GetLTCChainErr, fmt.Errorf("header %v is not relayed", hash.String()))
+	}
+	isCanonical, err := hc.isCanonical(stored)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isCanonical {
+		return nil, 0, NewLTCRelayingError(GetLTCChainErr, fmt.Errorf("header %v is being on fork branch", hash.String()))
+	}
+	return &stored.Header, hc.tip.Height - stored.Height, nil
+}
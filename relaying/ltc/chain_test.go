@@ -0,0 +1,234 @@
+package ltcrelaying
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+// newTestParams returns params with an easy proof of work and a retarget interval of 10 blocks
+func newTestParams() *chaincfg.Params {
+	params := MainNetParams
+	params.PowLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	params.PowLimitBits = btcrelaying.BigToCompact(params.PowLimit)
+	params.TargetTimespan = 10 * params.TargetTimePerBlock
+	return &params
+}
+
+// mine finds a nonce which satisfies (or does not satisfy if isValid is false) the proof of work of header
+func mine(params *chaincfg.Params, header *wire.BlockHeader, isValid bool) *wire.BlockHeader {
+	for {
+		err := checkProofOfWork(params, header)
+		if (err == nil) == isValid {
+			return header
+		}
+		header.Nonce++
+	}
+}
+
+func newChildHeader(params *chaincfg.Params, parent *wire.BlockHeader, bits uint32, timeDelta time.Duration) *wire.BlockHeader {
+	return mine(params, &wire.BlockHeader{
+		Version:   1,
+		PrevBlock: parent.BlockHash(),
+		Timestamp: parent.Timestamp.Add(timeDelta),
+		Bits:      bits,
+	}, true)
+}
+
+func newTestHeaderChain(t *testing.T) (*HeaderChain, incdb.Database, func()) {
+	params := newTestParams()
+	root := mine(params, &wire.BlockHeader{Version: 1, Timestamp: time.Unix(1600000000, 0), Bits: params.PowLimitBits}, true)
+	dir, err := ioutil.TempDir("", "ltcrelaying")
+	assert.Nil(t, err)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	hc, err := NewHeaderChain(&HeaderChainConfig{Params: params, RootHeader: root, RootHeight: 0}, db)
+	assert.Nil(t, err)
+	return hc, db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGenesisHeaders(t *testing.T) {
+	mainnetGenesis := getMainNetGenesisHeader()
+	assert.Equal(t, "12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2", mainnetGenesis.BlockHash().String())
+	assert.Nil(t, checkProofOfWork(&MainNetParams, mainnetGenesis))
+
+	testnetGenesis := getTestNet4GenesisHeader()
+	assert.Equal(t, "4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0", testnetGenesis.BlockHash().String())
+	assert.Nil(t, checkProofOfWork(&TestNet4Params, testnetGenesis))
+
+	testnetGenesis.Nonce++
+	assert.NotNil(t, checkProofOfWork(&TestNet4Params, testnetGenesis))
+}
+
+func TestHeaderChainProcessHeader(t *testing.T) {
+	hc, db, cleanup := newTestHeaderChain(t)
+	defer cleanup()
+	params := hc.config.Params
+	root := hc.config.RootHeader
+
+	// the first header must be the root header
+	h1 := newChildHeader(params, root, params.PowLimitBits, 150*time.Second)
+	_, err := hc.ProcessHeader(h1)
+	assert.Equal(t, ErrCodeMessage[OrphanHeaderErr].Code, err.(*LTCRelayingError).GetCode())
+	isMainChain, err := hc.ProcessHeader(root)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+	_, err = hc.ProcessHeader(root)
+	assert.Equal(t, ErrCodeMessage[ExistedHeaderErr].Code, err.(*LTCRelayingError).GetCode())
+
+	isMainChain, err = hc.ProcessHeader(h1)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+
+	// wrong bits
+	invalidHeader := newChildHeader(params, h1, params.PowLimitBits-1, 150*time.Second)
+	_, err = hc.ProcessHeader(invalidHeader)
+	assert.Equal(t, ErrCodeMessage[InvalidHeaderErr].Code, err.(*LTCRelayingError).GetCode())
+
+	// wrong proof of work
+	invalidHeader = mine(params, &wire.BlockHeader{Version: 1, PrevBlock: h1.BlockHash(), Timestamp: h1.Timestamp.Add(time.Minute), Bits: params.PowLimitBits}, false)
+	_, err = hc.ProcessHeader(invalidHeader)
+	assert.Equal(t, ErrCodeMessage[InvalidPoWErr].Code, err.(*LTCRelayingError).GetCode())
+
+	// timestamp before the median time
+	invalidHeader = newChildHeader(params, h1, params.PowLimitBits, -150*time.Second)
+	_, err = hc.ProcessHeader(invalidHeader)
+	assert.Equal(t, ErrCodeMessage[InvalidHeaderErr].Code, err.(*LTCRelayingError).GetCode())
+
+	// a longer side branch becomes the canonical chain
+	h2 := newChildHeader(params, h1, params.PowLimitBits, 150*time.Second)
+	_, err = hc.ProcessHeader(h2)
+	assert.Nil(t, err)
+	b1 := newChildHeader(params, root, params.PowLimitBits, 100*time.Second)
+	b2 := newChildHeader(params, b1, params.PowLimitBits, 100*time.Second)
+	b3 := newChildHeader(params, b2, params.PowLimitBits, 100*time.Second)
+	isMainChain, err = hc.ProcessHeader(b1)
+	assert.Nil(t, err)
+	assert.False(t, isMainChain)
+	isMainChain, err = hc.ProcessHeader(b2)
+	assert.Nil(t, err)
+	assert.False(t, isMainChain)
+	isMainChain, err = hc.ProcessHeader(b3)
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+	_, _, err = hc.GetCanonicalHeader(h1.BlockHash())
+	assert.NotNil(t, err)
+	_, confirmations, err := hc.GetCanonicalHeader(b1.BlockHash())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), confirmations)
+
+	// the relayed chain is reloaded from db
+	hc2, err := NewHeaderChain(hc.config, db)
+	assert.Nil(t, err)
+	latestHeader, height := hc2.GetLatestHeader()
+	assert.Equal(t, b3.BlockHash(), latestHeader.BlockHash())
+	assert.Equal(t, uint64(3), height)
+}
+
+func TestHeaderChainRetarget(t *testing.T) {
+	hc, _, cleanup := newTestHeaderChain(t)
+	defer cleanup()
+	params := hc.config.Params
+	interval := blocksPerRetarget(params)
+
+	_, err := hc.ProcessHeader(hc.config.RootHeader)
+	assert.Nil(t, err)
+	headers := []*wire.BlockHeader{hc.config.RootHeader}
+	for i := uint64(1); i < interval; i++ {
+		header := newChildHeader(params, headers[len(headers)-1], params.PowLimitBits, 75*time.Second)
+		_, err := hc.ProcessHeader(header)
+		assert.Nil(t, err)
+		headers = append(headers, header)
+	}
+
+	// blocks are twice as fast as expected, the target is halved
+	parent := headers[len(headers)-1]
+	expectedBits := calcRetargetBits(params, parent, headers[0].Timestamp)
+	assert.True(t, btcrelaying.CompactToBig(expectedBits).Cmp(params.PowLimit) < 0)
+	_, err = hc.ProcessHeader(newChildHeader(params, parent, params.PowLimitBits, 75*time.Second))
+	assert.Equal(t, ErrCodeMessage[InvalidHeaderErr].Code, err.(*LTCRelayingError).GetCode())
+	isMainChain, err := hc.ProcessHeader(newChildHeader(params, parent, expectedBits, 75*time.Second))
+	assert.Nil(t, err)
+	assert.True(t, isMainChain)
+}
+
+func TestVerifyTxWithMerkleProofs(t *testing.T) {
+	hc, _, cleanup := newTestHeaderChain(t)
+	defer cleanup()
+	params := hc.config.Params
+
+	tx1 := wire.NewMsgTx(wire.TxVersion)
+	tx1.AddTxOut(wire.NewTxOut(1000, []byte{0x6a}))
+	tx2 := wire.NewMsgTx(wire.TxVersion)
+	tx2.AddTxOut(wire.NewTxOut(2000, []byte{0x6a}))
+	hash1 := tx1.TxHash()
+	hash2 := tx2.TxHash()
+	merkleRoot := btcrelaying.HashMerkleBranches(&hash1, &hash2)
+
+	_, err := hc.ProcessHeader(hc.config.RootHeader)
+	assert.Nil(t, err)
+	header := mine(params, &wire.BlockHeader{
+		Version:    1,
+		PrevBlock:  hc.config.RootHeader.BlockHash(),
+		MerkleRoot: *merkleRoot,
+		Timestamp:  hc.config.RootHeader.Timestamp.Add(150 * time.Second),
+		Bits:       params.PowLimitBits,
+	}, true)
+	_, err = hc.ProcessHeader(header)
+	assert.Nil(t, err)
+	blockHash := header.BlockHash()
+
+	proof := &LTCProof{
+		MerkleProofs: []*btcrelaying.MerkleProof{{ProofHash: &hash1, IsLeft: true}},
+		LTCTx:        tx2,
+		BlockHash:    &blockHash,
+	}
+	isValid, err := hc.VerifyTxWithMerkleProofs(proof, 0)
+	assert.Nil(t, err)
+	assert.True(t, isValid)
+	_, err = hc.VerifyTxWithMerkleProofs(proof, 1)
+	assert.NotNil(t, err)
+
+	proof.MerkleProofs[0].IsLeft = false
+	isValid, err = hc.VerifyTxWithMerkleProofs(proof, 0)
+	assert.Nil(t, err)
+	assert.False(t, isValid)
+
+	unknownHash := chainhash.Hash{1}
+	proof.BlockHash = &unknownHash
+	_, err = hc.VerifyTxWithMerkleProofs(proof, 0)
+	assert.NotNil(t, err)
+}
+
+func TestIsLTCAddressValid(t *testing.T) {
+	hc, _, cleanup := newTestHeaderChain(t)
+	defer cleanup()
+	hc.config.Params = &MainNetParams
+	assert.True(t, hc.IsLTCAddressValid("LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1"))
+	segwitAddress, err := btcutil.NewAddressWitnessPubKeyHash(make([]byte, 20), &MainNetParams)
+	assert.Nil(t, err)
+	assert.True(t, hc.IsLTCAddressValid(segwitAddress.EncodeAddress()))
+	assert.False(t, hc.IsLTCAddressValid("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"))
+	hc.config.Params = &TestNet4Params
+	assert.False(t, hc.IsLTCAddressValid("LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1"))
+}
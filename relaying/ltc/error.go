@@ -0,0 +1,53 @@
+package ltcrelaying
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedErr = iota
+	ExistedHeaderErr
+	OrphanHeaderErr
+	InvalidHeaderErr
+	InvalidPoWErr
+	StoreLTCChainErr
+	GetLTCChainErr
+)
+
+var ErrCodeMessage = map[int]struct {
+	Code    int
+	Message string
+}{
+	UnexpectedErr: {-16000, "Unexpected error"},
+
+	ExistedHeaderErr: {-16001, "Header is existed in relayed chain error"},
+	OrphanHeaderErr:  {-16002, "Parent of header is not relayed error"},
+	InvalidHeaderErr: {-16003, "Invalid header error"},
+	InvalidPoWErr:    {-16004, "Invalid proof of work of header error"},
+	StoreLTCChainErr: {-16005, "Store ltc chain to lvdb error"},
+	GetLTCChainErr:   {-16006, "Get ltc chain from lvdb error"},
+}
+
+type LTCRelayingError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e LTCRelayingError) Error() string {
+	return fmt.Sprintf("%+v: %+v %+v", e.Code, e.Message, e.err)
+}
+
+func (e LTCRelayingError) GetCode() int {
+	return e.Code
+}
+
+func NewLTCRelayingError(key int, err error) *LTCRelayingError {
+	return &LTCRelayingError{
+		err:     errors.Wrap(err, ErrCodeMessage[key].Message),
+		Code:    ErrCodeMessage[key].Code,
+		Message: ErrCodeMessage[key].Message,
+	}
+}
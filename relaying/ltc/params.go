@@ -0,0 +1,111 @@
+package ltcrelaying
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// powLimit is the highest proof of work value a litecoin block can have, 2^236 - 1
+var powLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 236), big.NewInt(1))
+
+// HeaderChainConfig is the config of a relayed LTC header chain
+type HeaderChainConfig struct {
+	Params *chaincfg.Params
+	// RootHeader is the trusted header the relayed chain starts from
+	RootHeader *wire.BlockHeader
+	RootHeight uint64
+}
+
+func newHash(hashStr string) chainhash.Hash {
+	hash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		panic(err)
+	}
+	return *hash
+}
+
+// MainNetParams are the network parameters of the litecoin main network,
+// only the fields used by header validation and address encoding are set
+var MainNetParams = chaincfg.Params{
+	Name:                     "litecoin-mainnet",
+	Net:                      wire.BitcoinNet(0xdbb6c0fb),
+	PowLimit:                 powLimit,
+	PowLimitBits:             0x1e0fffff,
+	TargetTimespan:           time.Hour * 84, // 3.5 days
+	TargetTimePerBlock:       time.Second * 150,
+	RetargetAdjustmentFactor: 4,
+	ReduceMinDifficulty:      false,
+	Bech32HRPSegwit:          "ltc",
+	PubKeyHashAddrID:         0x30,
+	ScriptHashAddrID:         0x32,
+	PrivateKeyID:             0xb0,
+	HDPrivateKeyID:           [4]byte{0x04, 0x88, 0xad, 0xe4},
+	HDPublicKeyID:            [4]byte{0x04, 0x88, 0xb2, 0x1e},
+	HDCoinType:               2,
+}
+
+// TestNet4Params are the network parameters of the litecoin test network (version 4)
+var TestNet4Params = chaincfg.Params{
+	Name:                     "litecoin-testnet4",
+	Net:                      wire.BitcoinNet(0xf1c8d2fd),
+	PowLimit:                 powLimit,
+	PowLimitBits:             0x1e0fffff,
+	TargetTimespan:           time.Hour * 84, // 3.5 days
+	TargetTimePerBlock:       time.Second * 150,
+	RetargetAdjustmentFactor: 4,
+	ReduceMinDifficulty:      true,
+	MinDiffReductionTime:     time.Second * 300, // TargetTimePerBlock * 2
+	Bech32HRPSegwit:          "tltc",
+	PubKeyHashAddrID:         0x6f,
+	ScriptHashAddrID:         0x3a,
+	PrivateKeyID:             0xef,
+	HDPrivateKeyID:           [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:            [4]byte{0x04, 0x35, 0x87, 0xcf},
+	HDCoinType:               1,
+}
+
+func init() {
+	// btcutil only decodes segwit addresses of registered networks
+	for _, params := range []*chaincfg.Params{&MainNetParams, &TestNet4Params} {
+		err := chaincfg.Register(params)
+		if err != nil && err != chaincfg.ErrDuplicateNet {
+			panic(err)
+		}
+	}
+}
+
+func getMainNetGenesisHeader() *wire.BlockHeader {
+	return &wire.BlockHeader{
+		Version:    1,
+		PrevBlock:  chainhash.Hash{},
+		MerkleRoot: newHash("97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9"),
+		Timestamp:  time.Unix(1317972665, 0),
+		Bits:       0x1e0ffff0,
+		Nonce:      2084524493,
+	}
+}
+
+func getTestNet4GenesisHeader() *wire.BlockHeader {
+	return &wire.BlockHeader{
+		Version:    1,
+		PrevBlock:  chainhash.Hash{},
+		MerkleRoot: newHash("97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9"),
+		Timestamp:  time.Unix(1486949366, 0),
+		Bits:       0x1e0ffff0,
+		Nonce:      293345,
+	}
+}
+
+// GetTestNet4Config returns the config of the testnet4 header chain rooted at its genesis,
+// there is no config of the main network until a trusted recent header is chosen as its root
+func GetTestNet4Config() *HeaderChainConfig {
+	return &HeaderChainConfig{
+		Params:     &TestNet4Params,
+		RootHeader: getTestNet4GenesisHeader(),
+		RootHeight: 0,
+	}
+}
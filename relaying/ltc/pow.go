@@ -0,0 +1,75 @@
+package ltcrelaying
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PoWHash returns the scrypt hash of the header which is compared to the target,
+// the block hash is still the double sha256 of the header as in bitcoin
+func PoWHash(header *wire.BlockHeader) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	err := header.Serialize(&buf)
+	if err != nil {
+		return nil, err
+	}
+	headerBytes := buf.Bytes()
+	hashBytes, err := scrypt.Key(headerBytes, headerBytes, 1024, 1, 1, chainhash.HashSize)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHash(hashBytes)
+}
+
+func checkProofOfWork(params *chaincfg.Params, header *wire.BlockHeader) error {
+	target := btcrelaying.CompactToBig(header.Bits)
+	if target.Sign() <= 0 {
+		return fmt.Errorf("target difficulty of %064x is too low", target)
+	}
+	if target.Cmp(params.PowLimit) > 0 {
+		return fmt.Errorf("target difficulty of %064x is higher than max of %064x", target, params.PowLimit)
+	}
+	hash, err := PoWHash(header)
+	if err != nil {
+		return err
+	}
+	if btcrelaying.HashToBig(hash).Cmp(target) > 0 {
+		return fmt.Errorf("proof of work hash %064x is higher than expected max of %064x", btcrelaying.HashToBig(hash), target)
+	}
+	return nil
+}
+
+// calcRetargetBits returns the bits of the first block of a retarget interval,
+// firstTime is the timestamp of the block an interval before the parent, litecoin counts the whole interval
+// instead of one block less as bitcoin does
+func calcRetargetBits(params *chaincfg.Params, parent *wire.BlockHeader, firstTime time.Time) uint32 {
+	targetTimespan := int64(params.TargetTimespan / time.Second)
+	minTimespan := targetTimespan / params.RetargetAdjustmentFactor
+	maxTimespan := targetTimespan * params.RetargetAdjustmentFactor
+	actualTimespan := parent.Timestamp.Unix() - firstTime.Unix()
+	if actualTimespan < minTimespan {
+		actualTimespan = minTimespan
+	} else if actualTimespan > maxTimespan {
+		actualTimespan = maxTimespan
+	}
+
+	newTarget := btcrelaying.CompactToBig(parent.Bits)
+	newTarget.Mul(newTarget, big.NewInt(actualTimespan))
+	newTarget.Div(newTarget, big.NewInt(targetTimespan))
+	if newTarget.Cmp(params.PowLimit) > 0 {
+		newTarget.Set(params.PowLimit)
+	}
+	return btcrelaying.BigToCompact(newTarget)
+}
+
+func blocksPerRetarget(params *chaincfg.Params) uint64 {
+	return uint64(params.TargetTimespan / params.TargetTimePerBlock)
+}
@@ -0,0 +1,79 @@
+package ltcrelaying
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+// LTCProof proves that LTCTx is included in the block BlockHash, it has the same format as the BTC proof
+type LTCProof struct {
+	MerkleProofs []*btcrelaying.MerkleProof
+	LTCTx        *wire.MsgTx
+	BlockHash    *chainhash.Hash
+}
+
+func ParseLTCProofFromB64EncodeStr(b64EncodedStr string) (*LTCProof, error) {
+	jsonBytes, err := base64.StdEncoding.DecodeString(b64EncodedStr)
+	if err != nil {
+		return nil, err
+	}
+	var proof LTCProof
+	err = json.Unmarshal(jsonBytes, &proof)
+	if err != nil {
+		return nil, err
+	}
+	if proof.LTCTx == nil || proof.BlockHash == nil {
+		return nil, fmt.Errorf("tx and block hash of LTC proof must not be nil")
+	}
+	return &proof, nil
+}
+
+// VerifyTxWithMerkleProofs verifies that the tx of ltcProof is in a canonical block which has at least minConfirmations
+func (hc *HeaderChain) VerifyTxWithMerkleProofs(ltcProof *LTCProof, minConfirmations uint64) (bool, error) {
+	header, confirmations, err := hc.GetCanonicalHeader(*ltcProof.BlockHash)
+	if err != nil {
+		return false, err
+	}
+	if confirmations < minConfirmations {
+		return false, fmt.Errorf("need to wait for %v ltc block confirmations, current confirmations %v", minConfirmations, confirmations)
+	}
+	curHash := ltcProof.LTCTx.TxHash()
+	for _, mklProof := range ltcProof.MerkleProofs {
+		if mklProof.IsLeft {
+			curHash = *btcrelaying.HashMerkleBranches(mklProof.ProofHash, &curHash)
+		} else {
+			curHash = *btcrelaying.HashMerkleBranches(&curHash, mklProof.ProofHash)
+		}
+	}
+	return curHash == header.MerkleRoot, nil
+}
+
+// ExtractPaymentAddrStrFromPkScript extracts payment address string from pkscript
+func (hc *HeaderChain) ExtractPaymentAddrStrFromPkScript(pkScript []byte) (string, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, hc.config.Params)
+	if err != nil {
+		return "", err
+	}
+	if len(addrs) == 0 {
+		return "", nil
+	}
+	return addrs[0].EncodeAddress(), nil
+}
+
+// IsLTCAddressValid checks whether the passed ltc address string is valid or not
+func (hc *HeaderChain) IsLTCAddressValid(addrStr string) bool {
+	params := hc.config.Params
+	ltcAddress, err := btcutil.DecodeAddress(addrStr, params)
+	if err != nil {
+		Logger.log.Warnf("IsLTCAddressValid - Failed to decode ltc address with error: %v\n", err)
+		return false
+	}
+	return ltcAddress.IsForNet(params)
+}
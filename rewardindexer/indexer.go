@@ -0,0 +1,335 @@
+package rewardindexer
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// ChainReader is the access of the indexer to the beacon and shard chains
+type ChainReader interface {
+	blockfollower.BeaconChain
+	blockfollower.ShardChain
+	GetShardCommitteeRewardReceiversByEpoch(epoch uint64) (map[int][]incognitokey.CommitteePublicKey, map[string]privacy.PaymentAddress, error)
+}
+
+type Config struct {
+	DB            incdb.Database
+	BlockChain    ChainReader
+	ActiveShards  int
+	PubSubManager *pubsub.PubSubManager
+}
+
+// Indexer stores the rewards earned by every public key in every epoch, the split of the rewards of every epoch
+// and the withdrawals of rewards in its own database as final blocks are inserted
+type Indexer struct {
+	config   Config
+	follower *blockfollower.Follower
+
+	mtx                      sync.Mutex
+	latestIndexedHeight      uint64
+	latestIndexedShardHeight map[byte]uint64
+}
+
+func NewIndexer(config Config) (*Indexer, error) {
+	if config.DB == nil || config.BlockChain == nil {
+		return nil, errors.New("database and blockchain of reward indexer must not be nil")
+	}
+	indexer := &Indexer{
+		config:                   config,
+		latestIndexedShardHeight: make(map[byte]uint64),
+	}
+	indexer.follower = blockfollower.New(config.PubSubManager, []string{pubsub.NewBeaconBlockTopic}, indexer.follow)
+	var err error
+	indexer.latestIndexedHeight, err = blockfollower.GetStoredHeight(config.DB, latestIndexedHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	for shardID := 0; shardID < config.ActiveShards; shardID++ {
+		indexer.latestIndexedShardHeight[byte(shardID)], err = blockfollower.GetStoredHeight(config.DB, buildLatestIndexedShardHeightKey(byte(shardID)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return indexer, nil
+}
+
+// Start indexes final blocks in background
+func (indexer *Indexer) Start() error {
+	return indexer.follower.Start()
+}
+
+// Stop stops indexing and closes the database after the block being indexed is stored
+func (indexer *Indexer) Stop() {
+	indexer.follower.Stop()
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	err := indexer.config.DB.Close()
+	if err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (indexer *Indexer) follow() {
+	err := indexer.IndexFinalBlocks()
+	if err != nil {
+		Logger.log.Errorf("Index rewards of blocks failed: %v", err)
+	}
+}
+
+// GetLatestIndexedHeight returns the height of the latest indexed beacon block
+func (indexer *Indexer) GetLatestIndexedHeight() uint64 {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	return indexer.latestIndexedHeight
+}
+
+// IndexFinalBlocks indexes beacon blocks and blocks of every shard from the latest indexed ones to the final views
+func (indexer *Indexer) IndexFinalBlocks() error {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	bc := indexer.config.BlockChain
+	err := indexer.follower.FollowBeacon(bc, indexer.latestIndexedHeight+1, indexer.indexBeaconBlock)
+	if err != nil {
+		return err
+	}
+	for shardID := 0; shardID < indexer.config.ActiveShards; shardID++ {
+		err := indexer.follower.FollowShard(bc, byte(shardID), indexer.latestIndexedShardHeight[byte(shardID)]+1, indexer.indexShardBlock)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexBeaconBlock stores the earnings and the reward split of the reward instructions of a beacon block
+func (indexer *Indexer) indexBeaconBlock(beaconBlock *blockchain.BeaconBlock) error {
+	beaconHeight := beaconBlock.Header.Height
+	batch := indexer.config.DB.NewBatch()
+	putRecord := func(key []byte, record interface{}) error {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		return batch.Put(key, value)
+	}
+
+	earnings, splits, err := indexer.parseRewardInsts(beaconBlock)
+	if err != nil {
+		return err
+	}
+	for idx, earning := range earnings {
+		earning.BeaconHeight = beaconHeight
+		err := putRecord(buildEarningKey(earning.PublicKey, earning.Epoch, idx), earning)
+		if err != nil {
+			return err
+		}
+	}
+	for _, split := range splits {
+		split.BeaconHeight = beaconHeight
+		err := putRecord(buildRewardSplitKey(split.Epoch), split)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = batch.Put(latestIndexedHeightKey, []byte(strconv.FormatUint(beaconHeight, 10)))
+	if err != nil {
+		return err
+	}
+	err = batch.Write()
+	if err != nil {
+		return err
+	}
+	indexer.latestIndexedHeight = beaconHeight
+	return nil
+}
+
+// contents of the reward instructions with token IDs as strings,
+// common.Hash map keys of the metadata reward infos are not restored by json.Unmarshal
+type beaconRewardContent struct {
+	BeaconReward   map[string]uint64
+	PayToPublicKey string
+}
+
+type incDAORewardContent struct {
+	IncDAOReward map[string]uint64
+}
+
+type shardRewardContent struct {
+	ShardReward map[string]uint64
+	Epoch       uint64
+}
+
+// parseRewardInsts returns the earnings of the reward instructions of a beacon block and the reward splits by epoch,
+// a shard reward is split equally among the committee of the shard at the end of the epoch like the shards do
+func (indexer *Indexer) parseRewardInsts(beaconBlock *blockchain.BeaconBlock) ([]*Earning, map[uint64]*RewardSplit, error) {
+	earnings := []*Earning{}
+	beaconEarnings := []*Earning{}
+	splits := map[uint64]*RewardSplit{}
+	getSplit := func(epoch uint64) *RewardSplit {
+		split, ok := splits[epoch]
+		if !ok {
+			split = &RewardSplit{
+				Epoch:         epoch,
+				BeaconRewards: map[string]uint64{},
+				ShardRewards:  map[int]map[string]uint64{},
+				DAORewards:    map[string]uint64{},
+			}
+			splits[epoch] = split
+		}
+		return split
+	}
+	// rewards of beacons and the dao are built in the first beacon block of the next epoch
+	rewardEpoch := beaconBlock.Header.Epoch - 1
+	var committees map[int][]incognitokey.CommitteePublicKey
+	var rewardReceivers map[string]privacy.PaymentAddress
+	committeesEpoch := uint64(0)
+
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) < 4 {
+			continue // Not error, just not reward instruction
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.BeaconRewardRequestMeta):
+			var beaconRewardInfo beaconRewardContent
+			if json.Unmarshal([]byte(inst[3]), &beaconRewardInfo) != nil {
+				continue
+			}
+			earning := &Earning{
+				Epoch:     rewardEpoch,
+				PublicKey: beaconRewardInfo.PayToPublicKey,
+				Committee: BeaconCommittee,
+				ShardID:   -1,
+				Rewards:   map[string]uint64{},
+			}
+			split := getSplit(rewardEpoch)
+			for tokenID, amount := range beaconRewardInfo.BeaconReward {
+				earning.Rewards[tokenID] = amount
+				split.BeaconRewards[tokenID] += amount
+			}
+			earnings = append(earnings, earning)
+			beaconEarnings = append(beaconEarnings, earning)
+
+		case strconv.Itoa(metadata.IncDAORewardRequestMeta):
+			var incDAORewardInfo incDAORewardContent
+			if json.Unmarshal([]byte(inst[3]), &incDAORewardInfo) != nil {
+				continue
+			}
+			split := getSplit(rewardEpoch)
+			for tokenID, amount := range incDAORewardInfo.IncDAOReward {
+				split.DAORewards[tokenID] += amount
+			}
+
+		case strconv.Itoa(metadata.ShardBlockRewardRequestMeta):
+			shardID, err := strconv.Atoi(inst[1])
+			if err != nil {
+				continue
+			}
+			var shardRewardInfo shardRewardContent
+			if json.Unmarshal([]byte(inst[3]), &shardRewardInfo) != nil {
+				continue
+			}
+			if committees == nil || committeesEpoch != shardRewardInfo.Epoch {
+				committees, rewardReceivers, err = indexer.config.BlockChain.GetShardCommitteeRewardReceiversByEpoch(shardRewardInfo.Epoch)
+				if err != nil {
+					return nil, nil, err
+				}
+				committeesEpoch = shardRewardInfo.Epoch
+			}
+			split := getSplit(shardRewardInfo.Epoch)
+			split.ShardRewards[shardID] = shardRewardInfo.ShardReward
+			committee := committees[shardID]
+			for _, committeePublicKey := range committee {
+				keyStr, err := committeePublicKey.ToBase58()
+				if err != nil {
+					return nil, nil, err
+				}
+				rewardReceiver, ok := rewardReceivers[keyStr]
+				if !ok {
+					Logger.log.Warnf("Reward receiver of committee %v is not found", keyStr)
+					continue
+				}
+				earning := &Earning{
+					Epoch:              shardRewardInfo.Epoch,
+					PublicKey:          base58.Base58Check{}.Encode(rewardReceiver.Pk, common.Base58Version),
+					CommitteePublicKey: keyStr,
+					Committee:          ShardCommittee,
+					ShardID:            shardID,
+					CommitteeSize:      len(committee),
+					Rewards:            map[string]uint64{},
+				}
+				for tokenID, amount := range shardRewardInfo.ShardReward {
+					earning.Rewards[tokenID] = amount / uint64(len(committee))
+				}
+				earnings = append(earnings, earning)
+			}
+		}
+	}
+	// every member of the beacon committee has a reward instruction
+	for _, earning := range beaconEarnings {
+		earning.CommitteeSize = len(beaconEarnings)
+	}
+	return earnings, splits, nil
+}
+
+// indexShardBlock stores the withdrawals of the withdraw reward response transactions of a shard block
+func (indexer *Indexer) indexShardBlock(shardBlock *blockchain.ShardBlock) error {
+	shardID := shardBlock.Header.ShardID
+	shardHeight := shardBlock.Header.Height
+	batch := indexer.config.DB.NewBatch()
+	for _, tx := range shardBlock.Body.Transactions {
+		if tx.GetMetadataType() != metadata.WithDrawRewardResponseMeta {
+			continue
+		}
+		withdrawRewardResponse, ok := tx.GetMetadata().(*metadata.WithDrawRewardResponse)
+		if !ok {
+			continue
+		}
+		_, publicKey, amount, _ := tx.GetTransferData()
+		if len(publicKey) == 0 {
+			continue
+		}
+		withdrawal := &Withdrawal{
+			Epoch:       shardBlock.Header.Epoch,
+			ShardID:     int(shardID),
+			ShardHeight: shardHeight,
+			PublicKey:   base58.Base58Check{}.Encode(publicKey, common.Base58Version),
+			TxID:        tx.Hash().String(),
+			TokenID:     withdrawRewardResponse.TokenID.String(),
+			Amount:      amount,
+		}
+		if withdrawRewardResponse.TxRequest != nil {
+			withdrawal.TxRequestID = withdrawRewardResponse.TxRequest.String()
+		}
+		value, err := json.Marshal(withdrawal)
+		if err != nil {
+			return err
+		}
+		err = batch.Put(buildWithdrawalKey(withdrawal.PublicKey, withdrawal.Epoch, shardID, shardHeight, withdrawal.TxID), value)
+		if err != nil {
+			return err
+		}
+	}
+	err := batch.Put(buildLatestIndexedShardHeightKey(shardID), []byte(strconv.FormatUint(shardHeight, 10)))
+	if err != nil {
+		return err
+	}
+	err = batch.Write()
+	if err != nil {
+		return err
+	}
+	indexer.latestIndexedShardHeight[shardID] = shardHeight
+	return nil
+}
@@ -0,0 +1,231 @@
+package rewardindexer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower/followertest"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/mocks"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+type fakeChain struct {
+	*followertest.Chain
+	committees      map[int][]incognitokey.CommitteePublicKey
+	rewardReceivers map[string]privacy.PaymentAddress
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{Chain: followertest.NewChain()}
+}
+
+func (bc *fakeChain) GetShardCommitteeRewardReceiversByEpoch(epoch uint64) (map[int][]incognitokey.CommitteePublicKey, map[string]privacy.PaymentAddress, error) {
+	return bc.committees, bc.rewardReceivers, nil
+}
+
+func (bc *fakeChain) addBeaconBlock(height uint64, epoch uint64, insts ...[]string) {
+	bc.AddBeaconBlock(&blockchain.BeaconBlock{
+		Header: blockchain.BeaconHeader{Height: height, Epoch: epoch},
+		Body:   blockchain.BeaconBody{Instructions: insts},
+	})
+}
+
+func (bc *fakeChain) addShardBlock(height uint64, epoch uint64, txs ...metadata.Transaction) {
+	bc.AddShardBlock(&blockchain.ShardBlock{
+		Header: blockchain.ShardHeader{Height: height, Epoch: epoch},
+		Body:   blockchain.ShardBody{Transactions: txs},
+	})
+}
+
+func newTestIndexer(t *testing.T, bc *fakeChain) (*Indexer, func()) {
+	db, cleanup := followertest.OpenDB(t)
+	indexer, err := NewIndexer(Config{DB: db, BlockChain: bc, ActiveShards: 1})
+	assert.Nil(t, err)
+	return indexer, cleanup
+}
+
+func newTestPublicKey(lastByte byte) []byte {
+	publicKey := make([]byte, common.PublicKeySize)
+	publicKey[0] = lastByte + 1
+	publicKey[common.PublicKeySize-1] = lastByte
+	return publicKey
+}
+
+func newWithdrawRewardResponseTx(txID byte, publicKey []byte, amount uint64) metadata.Transaction {
+	txRequest := common.Hash{txID + 1}
+	tx := &mocks.Transaction{}
+	tx.On("GetMetadataType").Return(metadata.WithDrawRewardResponseMeta)
+	tx.On("GetMetadata").Return(&metadata.WithDrawRewardResponse{
+		MetadataBase: metadata.MetadataBase{Type: metadata.WithDrawRewardResponseMeta},
+		TxRequest:    &txRequest,
+		TokenID:      common.PRVCoinID,
+	})
+	tx.On("GetTransferData").Return(true, publicKey, amount, &common.PRVCoinID)
+	tx.On("Hash").Return(&common.Hash{txID})
+	return tx
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	committeeKeys := []incognitokey.CommitteePublicKey{}
+	rewardReceivers := map[string]privacy.PaymentAddress{}
+	receiverPublicKeys := [][]byte{}
+	for i := 0; i < 2; i++ {
+		seed := []byte("validator" + strconv.Itoa(i))
+		key, err := incognitokey.NewCommitteeKeyFromSeed(seed, seed)
+		assert.Nil(t, err)
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		committeeKeys = append(committeeKeys, key)
+		receiverPublicKeys = append(receiverPublicKeys, newTestPublicKey(byte(i)))
+		rewardReceivers[keyStr] = privacy.PaymentAddress{Pk: receiverPublicKeys[i]}
+	}
+	bc := newFakeChain()
+	bc.committees = map[int][]incognitokey.CommitteePublicKey{0: committeeKeys}
+	bc.rewardReceivers = rewardReceivers
+	indexer, cleanup := newTestIndexer(t, bc)
+	defer cleanup()
+
+	// the first validator is also the beacon, the rewards of epoch 1 are built in the first block of epoch 2
+	beaconInst, err := metadata.BuildInstForBeaconReward(map[common.Hash]uint64{common.PRVCoinID: 100}, receiverPublicKeys[0])
+	assert.Nil(t, err)
+	daoRewardInfo, err := json.Marshal(metadata.IncDAORewardInfo{IncDAOReward: map[common.Hash]uint64{common.PRVCoinID: 50}})
+	assert.Nil(t, err)
+	daoInst := []string{strconv.Itoa(metadata.IncDAORewardRequestMeta), "0", "devRewardInst", string(daoRewardInfo)}
+	shardInsts, err := metadata.BuildInstForShardReward(map[common.Hash]uint64{common.PRVCoinID: 1000}, 1, 0)
+	assert.Nil(t, err)
+	bc.addBeaconBlock(1, 1)
+	bc.addBeaconBlock(2, 2, beaconInst, daoInst, shardInsts[0])
+	bc.addShardBlock(1, 2)
+	bc.addShardBlock(2, 2, newWithdrawRewardResponseTx(1, receiverPublicKeys[0], 600))
+
+	assert.Nil(t, indexer.IndexFinalBlocks())
+	assert.Equal(t, uint64(2), indexer.GetLatestIndexedHeight())
+
+	publicKey := base58.Base58Check{}.Encode(receiverPublicKeys[0], common.Base58Version)
+	history, err := indexer.GetRewardHistory(publicKey, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, uint64(1), history[0].Epoch)
+	assert.Equal(t, map[string]uint64{common.PRVCoinID.String(): 600}, history[0].Rewards)
+	assert.Equal(t, 2, len(history[0].Earnings))
+	assert.Equal(t, BeaconCommittee, history[0].Earnings[0].Committee)
+	assert.Equal(t, 1, history[0].Earnings[0].CommitteeSize)
+	assert.Equal(t, ShardCommittee, history[0].Earnings[1].Committee)
+	assert.Equal(t, 2, history[0].Earnings[1].CommitteeSize)
+	assert.Equal(t, uint64(50), history[0].RewardSplit.DAORewards[common.PRVCoinID.String()])
+	assert.Equal(t, uint64(1000), history[0].RewardSplit.ShardRewards[0][common.PRVCoinID.String()])
+	assert.Equal(t, 0, len(history[0].Withdrawals))
+
+	assert.Equal(t, uint64(2), history[1].Epoch)
+	assert.Equal(t, 0, len(history[1].Earnings))
+	assert.Nil(t, history[1].RewardSplit)
+	assert.Equal(t, 1, len(history[1].Withdrawals))
+	assert.Equal(t, uint64(600), history[1].Withdrawals[0].Amount)
+	assert.Equal(t, uint64(2), history[1].Withdrawals[0].ShardHeight)
+	assert.Equal(t, (&common.Hash{2}).String(), history[1].Withdrawals[0].TxRequestID)
+
+	history, err = indexer.GetRewardHistory(publicKey, 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(history))
+
+	// the second validator only earns its share of the shard reward
+	history, err = indexer.GetRewardHistory(base58.Base58Check{}.Encode(receiverPublicKeys[1], common.Base58Version), 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, map[string]uint64{common.PRVCoinID.String(): 500}, history[0].Rewards)
+	committeePublicKey, _ := committeeKeys[1].ToBase58()
+	assert.Equal(t, committeePublicKey, history[0].Earnings[0].CommitteePublicKey)
+
+	_, err = indexer.GetRewardHistory(publicKey, 10, 0)
+	assert.NotNil(t, err)
+}
+
+func TestIndexFinalBlocks_Resume(t *testing.T) {
+	bc := newFakeChain()
+	dir, err := ioutil.TempDir("", "rewardindexer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	indexer, err := NewIndexer(Config{DB: db, BlockChain: bc, ActiveShards: 1})
+	assert.Nil(t, err)
+	bc.addBeaconBlock(1, 1)
+	bc.addShardBlock(1, 1)
+	assert.Nil(t, indexer.IndexFinalBlocks())
+	indexer.Stop()
+
+	db, err = incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	defer db.Close()
+	indexer, err = NewIndexer(Config{DB: db, BlockChain: bc, ActiveShards: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), indexer.GetLatestIndexedHeight())
+	assert.Equal(t, uint64(1), indexer.latestIndexedShardHeight[0])
+	// blocks indexed before are not read again
+	delete(bc.BeaconBlocks, 1)
+	delete(bc.ShardBlocks[0], 1)
+	assert.Nil(t, indexer.IndexFinalBlocks())
+}
+
+// failingDB fails every read
+type failingDB struct {
+	incdb.Database
+}
+
+func (db failingDB) Get(key []byte) ([]byte, error) {
+	return nil, errors.New("read failed")
+}
+
+// a database read error fails the indexer instead of indexing again from the first block
+func TestNewIndexer_ReadError(t *testing.T) {
+	db, cleanup := followertest.OpenDB(t)
+	defer cleanup()
+	_, err := NewIndexer(Config{DB: failingDB{db}, BlockChain: newFakeChain(), ActiveShards: 1})
+	assert.NotNil(t, err)
+}
+
+func TestWriteCSV(t *testing.T) {
+	history := []*EpochReward{{
+		Epoch:     1,
+		PublicKey: "pk",
+		Earnings: []*Earning{{
+			Epoch:         1,
+			BeaconHeight:  2,
+			Committee:     ShardCommittee,
+			ShardID:       0,
+			CommitteeSize: 2,
+			Rewards:       map[string]uint64{"b": 2, "a": 1},
+		}},
+		RewardSplit: &RewardSplit{Epoch: 1, BeaconHeight: 2, DAORewards: map[string]uint64{"a": 5}},
+		Withdrawals: []*Withdrawal{{Epoch: 1, ShardHeight: 3, TxID: "tx", TokenID: "a", Amount: 3}},
+	}}
+	buf := &bytes.Buffer{}
+	assert.Nil(t, WriteCSV(buf, history))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		strings.Join(CSVHeader, ","),
+		"1,pk,shard,0,,2,a,1,2,",
+		"1,pk,shard,0,,2,b,2,2,",
+		"1,pk,dao,,,,a,5,2,",
+		"1,pk,withdrawal,0,,,a,3,3,tx",
+	}, lines)
+}
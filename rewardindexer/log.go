@@ -0,0 +1,14 @@
+package rewardindexer
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type RewardIndexerLogger struct {
+	log common.Logger
+}
+
+func (logger *RewardIndexerLogger) Init(inst common.Logger) {
+	logger.log = inst
+}
+
+// Global instant to use
+var Logger = RewardIndexerLogger{}
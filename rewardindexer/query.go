@@ -0,0 +1,212 @@
+package rewardindexer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// maximum number of epochs returned by a query
+const MaxEpochs = 1000
+
+// EpochReward is the rewards a public key earns in an epoch with the committees it serves on,
+// the split of the rewards of the epoch and the rewards the public key withdraws in the epoch
+type EpochReward struct {
+	Epoch       uint64
+	PublicKey   string
+	Rewards     map[string]uint64 // total amount earned by token ID
+	Earnings    []*Earning
+	RewardSplit *RewardSplit
+	Withdrawals []*Withdrawal
+}
+
+// iterateRecords calls fn with the records of a public key in [fromEpoch, toEpoch] in the order of their keys
+func (indexer *Indexer) iterateRecords(prefix []byte, publicKey string, fromEpoch uint64, toEpoch uint64, fn func(value []byte) error) error {
+	publicKeyPrefix := buildPublicKeyPrefix(prefix, publicKey)
+	iter := indexer.config.DB.NewIteratorWithStart(append(append([]byte{}, publicKeyPrefix...), uint64ToBytes(fromEpoch)...))
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, publicKeyPrefix) || getEpochFromRecordKey(key, publicKeyPrefix) > toEpoch {
+			break
+		}
+		err := fn(iter.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// GetRewardHistory returns the rewards of publicKey from fromEpoch to toEpoch in the order of epochs,
+// publicKey is the base58 check encoded public key of a payment address, epochs without earnings or withdrawals are omitted
+func (indexer *Indexer) GetRewardHistory(publicKey string, fromEpoch uint64, toEpoch uint64) ([]*EpochReward, error) {
+	if fromEpoch > toEpoch {
+		return nil, fmt.Errorf("from epoch %v is greater than to epoch %v", fromEpoch, toEpoch)
+	}
+	if toEpoch-fromEpoch >= MaxEpochs {
+		return nil, fmt.Errorf("number of epochs must not be greater than %v", MaxEpochs)
+	}
+	epochRewards := map[uint64]*EpochReward{}
+	getEpochReward := func(epoch uint64) *EpochReward {
+		epochReward, ok := epochRewards[epoch]
+		if !ok {
+			epochReward = &EpochReward{
+				Epoch:       epoch,
+				PublicKey:   publicKey,
+				Rewards:     map[string]uint64{},
+				Earnings:    []*Earning{},
+				Withdrawals: []*Withdrawal{},
+			}
+			epochRewards[epoch] = epochReward
+		}
+		return epochReward
+	}
+	err := indexer.iterateRecords(earningPrefix, publicKey, fromEpoch, toEpoch, func(value []byte) error {
+		earning := &Earning{}
+		err := json.Unmarshal(value, earning)
+		if err != nil {
+			return err
+		}
+		epochReward := getEpochReward(earning.Epoch)
+		epochReward.Earnings = append(epochReward.Earnings, earning)
+		for tokenID, amount := range earning.Rewards {
+			epochReward.Rewards[tokenID] += amount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = indexer.iterateRecords(withdrawalPrefix, publicKey, fromEpoch, toEpoch, func(value []byte) error {
+		withdrawal := &Withdrawal{}
+		err := json.Unmarshal(value, withdrawal)
+		if err != nil {
+			return err
+		}
+		epochReward := getEpochReward(withdrawal.Epoch)
+		epochReward.Withdrawals = append(epochReward.Withdrawals, withdrawal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*EpochReward{}
+	for _, epochReward := range epochRewards {
+		split, err := indexer.getRewardSplit(epochReward.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		epochReward.RewardSplit = split
+		result = append(result, epochReward)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Epoch < result[j].Epoch
+	})
+	return result, nil
+}
+
+// getRewardSplit returns the reward split of an epoch, or nil if no reward is built for the epoch
+func (indexer *Indexer) getRewardSplit(epoch uint64) (*RewardSplit, error) {
+	has, err := indexer.config.DB.Has(buildRewardSplitKey(epoch))
+	if err != nil || !has {
+		return nil, err
+	}
+	value, err := indexer.config.DB.Get(buildRewardSplitKey(epoch))
+	if err != nil {
+		return nil, err
+	}
+	split := &RewardSplit{}
+	err = json.Unmarshal(value, split)
+	if err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+// CSVHeader is the header of the csv of a reward history, every row is an amount of a token,
+// the type of a row is the committee of an earning, "dao" for the dao share of the epoch or "withdrawal"
+var CSVHeader = []string{"epoch", "public_key", "type", "shard_id", "committee_public_key", "committee_size", "token_id", "amount", "height", "tx_id"}
+
+// WriteCSV writes the earnings, dao shares and withdrawals of a reward history as csv rows to w
+func WriteCSV(w io.Writer, history []*EpochReward) error {
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write(CSVHeader)
+	if err != nil {
+		return err
+	}
+	for _, epochReward := range history {
+		epoch := strconv.FormatUint(epochReward.Epoch, 10)
+		for _, earning := range epochReward.Earnings {
+			for _, tokenID := range sortedTokenIDs(earning.Rewards) {
+				err := csvWriter.Write([]string{
+					epoch,
+					epochReward.PublicKey,
+					earning.Committee,
+					strconv.Itoa(earning.ShardID),
+					earning.CommitteePublicKey,
+					strconv.Itoa(earning.CommitteeSize),
+					tokenID,
+					strconv.FormatUint(earning.Rewards[tokenID], 10),
+					strconv.FormatUint(earning.BeaconHeight, 10),
+					"",
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if epochReward.RewardSplit != nil {
+			for _, tokenID := range sortedTokenIDs(epochReward.RewardSplit.DAORewards) {
+				err := csvWriter.Write([]string{
+					epoch,
+					epochReward.PublicKey,
+					"dao",
+					"",
+					"",
+					"",
+					tokenID,
+					strconv.FormatUint(epochReward.RewardSplit.DAORewards[tokenID], 10),
+					strconv.FormatUint(epochReward.RewardSplit.BeaconHeight, 10),
+					"",
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		for _, withdrawal := range epochReward.Withdrawals {
+			err := csvWriter.Write([]string{
+				epoch,
+				epochReward.PublicKey,
+				"withdrawal",
+				strconv.Itoa(withdrawal.ShardID),
+				"",
+				"",
+				withdrawal.TokenID,
+				strconv.FormatUint(withdrawal.Amount, 10),
+				strconv.FormatUint(withdrawal.ShardHeight, 10),
+				withdrawal.TxID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func sortedTokenIDs(amounts map[string]uint64) []string {
+	tokenIDs := []string{}
+	for tokenID := range amounts {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs
+}
@@ -0,0 +1,98 @@
+package rewardindexer
+
+import (
+	"encoding/binary"
+	"strconv"
+)
+
+const (
+	BeaconCommittee = "beacon"
+	ShardCommittee  = "shard"
+)
+
+var (
+	latestIndexedHeightKey      = []byte("rewardindex-height")
+	latestIndexedShardHeightKey = []byte("rewardindex-shardheight-")
+	earningPrefix               = []byte("rewardindex-earning-")
+	withdrawalPrefix            = []byte("rewardindex-withdrawal-")
+	rewardSplitPrefix           = []byte("rewardindex-split-")
+)
+
+// Earning is the reward of a public key for serving on a committee in an epoch,
+// the public key is the base58 check encoded public key of the payment address receiving the reward
+type Earning struct {
+	Epoch              uint64
+	BeaconHeight       uint64 // height of the beacon block with the reward instruction
+	PublicKey          string
+	CommitteePublicKey string // empty for a beacon committee member, its reward instruction only has the public key
+	Committee          string
+	ShardID            int // shard of the committee, -1 for the beacon committee
+	CommitteeSize      int
+	Rewards            map[string]uint64 // amount by token ID
+}
+
+// RewardSplit is how the rewards of an epoch are split among the beacon committee, the shard committees and the DAO
+type RewardSplit struct {
+	Epoch         uint64
+	BeaconHeight  uint64
+	BeaconRewards map[string]uint64
+	ShardRewards  map[int]map[string]uint64
+	DAORewards    map[string]uint64
+}
+
+// Withdrawal is a reward paid to a public key by a withdraw reward response transaction
+type Withdrawal struct {
+	Epoch       uint64 // epoch of the shard block with the transaction
+	ShardID     int
+	ShardHeight uint64
+	PublicKey   string
+	TxID        string
+	TxRequestID string
+	TokenID     string
+	Amount      uint64
+}
+
+func buildLatestIndexedShardHeightKey(shardID byte) []byte {
+	key := append([]byte{}, latestIndexedShardHeightKey...)
+	return append(key, []byte(strconv.Itoa(int(shardID)))...)
+}
+
+func buildPublicKeyPrefix(prefix []byte, publicKey string) []byte {
+	key := append([]byte{}, prefix...)
+	return append(key, []byte(publicKey+"-")...)
+}
+
+func uint64ToBytes(value uint64) []byte {
+	valueBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBytes, value)
+	return valueBytes
+}
+
+// buildEarningKey orders earnings of a public key by epoch then by their index in the beacon block
+func buildEarningKey(publicKey string, epoch uint64, index int) []byte {
+	key := append(buildPublicKeyPrefix(earningPrefix, publicKey), uint64ToBytes(epoch)...)
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, uint32(index))
+	return append(key, indexBytes...)
+}
+
+// buildWithdrawalKey orders withdrawals of a public key by epoch, then by shard and height of the shard block
+func buildWithdrawalKey(publicKey string, epoch uint64, shardID byte, shardHeight uint64, txID string) []byte {
+	key := append(buildPublicKeyPrefix(withdrawalPrefix, publicKey), uint64ToBytes(epoch)...)
+	key = append(key, shardID)
+	key = append(key, uint64ToBytes(shardHeight)...)
+	return append(key, []byte(txID)...)
+}
+
+func buildRewardSplitKey(epoch uint64) []byte {
+	key := append([]byte{}, rewardSplitPrefix...)
+	return append(key, uint64ToBytes(epoch)...)
+}
+
+// getEpochFromRecordKey returns the epoch of a record key with publicKeyPrefix
+func getEpochFromRecordKey(key []byte, publicKeyPrefix []byte) uint64 {
+	if len(key) < len(publicKeyPrefix)+8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(key[len(publicKeyPrefix) : len(publicKeyPrefix)+8])
+}
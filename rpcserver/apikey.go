@@ -0,0 +1,306 @@
+package rpcserver
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	apiKeyHeader       = "X-Api-Key"
+	apiKeyBearerPrefix = "Bearer "
+	apiKeyRawLength    = 32
+)
+
+// Scopes group rpc methods so that a key does not have to enumerate every
+// method it may call.
+const (
+	APIKeyScopeAll    = "*"
+	APIKeyScopeRead   = "read"
+	APIKeyScopeSendTx = "sendtx"
+	APIKeyScopeWallet = "wallet"
+	APIKeyScopeAdmin  = "admin"
+)
+
+// adminMethods can change the state of the node itself
+var adminMethods = map[string]bool{
+	removeTxInMempool: true,
+	enableMining:      true,
+	setBackup:         true,
+	startProfiling:    true,
+	stopProfiling:     true,
+	revertbeaconchain: true,
+	revertshardchain:  true,
+	listAPIKeys:       true,
+	addAPIKey:         true,
+	removeAPIKey:      true,
+	reloadAPIKeys:     true,
+}
+
+// sendTxMethodPrefixes are the prefixes of methods which broadcast a transaction
+var sendTxMethodPrefixes = []string{"createandsend", "send", CreateRawWithDrawTransaction}
+
+// methodScope returns the scope a rpc method belongs to, or an empty scope
+// if the method is unknown
+func methodScope(method string) string {
+	_, isHttpMethod := HttpHandler[method]
+	_, isAdminMethod := AdminHttpHandler[method]
+	_, isLimitedMethod := LimitedHttpHandler[method]
+	_, isWsMethod := WsHandler[method]
+	if !isHttpMethod && !isAdminMethod && !isLimitedMethod && !isWsMethod {
+		return ""
+	}
+	if adminMethods[method] {
+		return APIKeyScopeAdmin
+	}
+	if _, ok := LimitedHttpHandler[method]; ok {
+		return APIKeyScopeWallet
+	}
+	for _, prefix := range sendTxMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return APIKeyScopeSendTx
+		}
+	}
+	return APIKeyScopeRead
+}
+
+// APIKey is one credential of the api key file. Only the hash of the raw key
+// is kept, the raw key is shown once when it is created.
+type APIKey struct {
+	Name             string
+	KeyHash          string
+	Scopes           []string
+	Methods          []string // extra methods allowed beside the scopes
+	DeniedMethods    []string // methods denied even if a scope allows them
+	RequestPerMinute int      // 0: unlimited
+	RequestPerDay    int      // 0: unlimited
+	Disabled         bool
+	CreatedAt        int64
+}
+
+// IsMethodAllowed checks the method against the allow-list of the key,
+// unknown methods are never allowed
+func (apiKey APIKey) IsMethodAllowed(method string) bool {
+	if apiKey.Disabled {
+		return false
+	}
+	for _, denied := range apiKey.DeniedMethods {
+		if denied == method {
+			return false
+		}
+	}
+	scope := methodScope(method)
+	if scope == "" {
+		return false
+	}
+	for _, allowed := range apiKey.Methods {
+		if allowed == method {
+			return true
+		}
+	}
+	for _, s := range apiKey.Scopes {
+		if s == APIKeyScopeAll || s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (apiKey APIKey) validate() error {
+	if apiKey.Name == "" {
+		return errors.New("api key name is empty")
+	}
+	if _, err := hex.DecodeString(apiKey.KeyHash); err != nil || len(apiKey.KeyHash) != common.HashSize*2 {
+		return errors.Errorf("api key %s has invalid key hash", apiKey.Name)
+	}
+	for _, scope := range apiKey.Scopes {
+		switch scope {
+		case APIKeyScopeAll, APIKeyScopeRead, APIKeyScopeSendTx, APIKeyScopeWallet, APIKeyScopeAdmin:
+		default:
+			return errors.Errorf("api key %s has unknown scope %s", apiKey.Name, scope)
+		}
+	}
+	if apiKey.RequestPerMinute < 0 || apiKey.RequestPerDay < 0 {
+		return errors.Errorf("api key %s has negative rate limit", apiKey.Name)
+	}
+	return nil
+}
+
+type apiKeyFile struct {
+	Keys []APIKey
+}
+
+// APIKeyStore holds the api keys loaded from the api key file.
+//
+// This struct is safe for concurrent access.
+type APIKeyStore struct {
+	filePath string
+	lock     sync.RWMutex
+	keys     map[string]*APIKey // key by name
+}
+
+func NewAPIKeyStore(filePath string) *APIKeyStore {
+	return &APIKeyStore{
+		filePath: filePath,
+		keys:     make(map[string]*APIKey),
+	}
+}
+
+// HashAPIKey returns the hex encoded hash stored in the api key file for a raw key
+func HashAPIKey(rawKey string) string {
+	return hex.EncodeToString(common.HashB([]byte(rawKey)))
+}
+
+// Load reads the api key file, replacing all keys in memory.
+// A missing file is treated as an empty key set.
+func (store *APIKeyStore) Load() error {
+	data, err := ioutil.ReadFile(store.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "can not read api key file %s", store.filePath)
+	}
+	keys := make(map[string]*APIKey)
+	if len(data) > 0 {
+		content := apiKeyFile{}
+		if err := json.Unmarshal(data, &content); err != nil {
+			return errors.Wrapf(err, "can not parse api key file %s", store.filePath)
+		}
+		for i := range content.Keys {
+			apiKey := content.Keys[i]
+			if err := apiKey.validate(); err != nil {
+				return err
+			}
+			if _, ok := keys[apiKey.Name]; ok {
+				return errors.Errorf("api key %s is duplicated", apiKey.Name)
+			}
+			keys[apiKey.Name] = &apiKey
+		}
+	}
+	store.lock.Lock()
+	store.keys = keys
+	store.lock.Unlock()
+	return nil
+}
+
+// save writes the keys to the api key file, caller must hold the lock
+func (store *APIKeyStore) save() error {
+	content := apiKeyFile{Keys: make([]APIKey, 0, len(store.keys))}
+	for _, apiKey := range store.keys {
+		content.Keys = append(content.Keys, *apiKey)
+	}
+	sort.Slice(content.Keys, func(i, j int) bool {
+		return content.Keys[i].Name < content.Keys[j].Name
+	})
+	data, err := json.MarshalIndent(content, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmpPath := store.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, store.filePath)
+}
+
+// Authenticate looks up the key matching the raw key
+func (store *APIKeyStore) Authenticate(rawKey string) (*APIKey, bool) {
+	if rawKey == "" {
+		return nil, false
+	}
+	hash := common.HashB([]byte(rawKey))
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+	var found *APIKey
+	for _, apiKey := range store.keys {
+		keyHash, err := hex.DecodeString(apiKey.KeyHash)
+		if err != nil {
+			continue
+		}
+		if subtle.ConstantTimeCompare(hash, keyHash) == 1 {
+			found = apiKey
+		}
+	}
+	if found == nil || found.Disabled {
+		return nil, false
+	}
+	apiKey := *found
+	return &apiKey, true
+}
+
+// Add creates a new key and persists it, the raw key is returned to be
+// handed to the key owner
+func (store *APIKeyStore) Add(apiKey APIKey) (string, error) {
+	rawKeyBytes := make([]byte, apiKeyRawLength)
+	if _, err := rand.Read(rawKeyBytes); err != nil {
+		return "", err
+	}
+	rawKey := hex.EncodeToString(rawKeyBytes)
+	apiKey.KeyHash = HashAPIKey(rawKey)
+	apiKey.CreatedAt = time.Now().Unix()
+	if err := apiKey.validate(); err != nil {
+		return "", err
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if _, ok := store.keys[apiKey.Name]; ok {
+		return "", errors.Errorf("api key %s already exists", apiKey.Name)
+	}
+	store.keys[apiKey.Name] = &apiKey
+	if err := store.save(); err != nil {
+		delete(store.keys, apiKey.Name)
+		return "", err
+	}
+	return rawKey, nil
+}
+
+// Remove deletes a key by name and persists the change
+func (store *APIKeyStore) Remove(name string) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	apiKey, ok := store.keys[name]
+	if !ok {
+		return errors.Errorf("api key %s not found", name)
+	}
+	delete(store.keys, name)
+	if err := store.save(); err != nil {
+		store.keys[name] = apiKey
+		return err
+	}
+	return nil
+}
+
+// List returns a copy of all keys sorted by name
+func (store *APIKeyStore) List() []APIKey {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+	res := make([]APIKey, 0, len(store.keys))
+	for _, apiKey := range store.keys {
+		res = append(res, *apiKey)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// getRawAPIKey extracts the raw api key from the request headers.
+// Both "X-Api-Key: <key>" and "Authorization: Bearer <key>" are accepted.
+func getRawAPIKey(header map[string][]string) string {
+	if values := header[apiKeyHeader]; len(values) > 0 {
+		return values[0]
+	}
+	if values := header["Authorization"]; len(values) > 0 && strings.HasPrefix(values[0], apiKeyBearerPrefix) {
+		return strings.TrimPrefix(values[0], apiKeyBearerPrefix)
+	}
+	return ""
+}
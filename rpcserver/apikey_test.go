@@ -0,0 +1,225 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/incognitochain/incognito-chain/memcache"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+func TestAPIKeyIsMethodAllowed(t *testing.T) {
+	readOnly := APIKey{Name: "read", Scopes: []string{APIKeyScopeRead}}
+	if !readOnly.IsMethodAllowed(getBlockChainInfo) {
+		t.Error("read key should call getblockchaininfo")
+	}
+	if readOnly.IsMethodAllowed(createAndSendTransaction) || readOnly.IsMethodAllowed(sendRawTransaction) {
+		t.Error("read key should not send transaction")
+	}
+	if readOnly.IsMethodAllowed(removeTxInMempool) || readOnly.IsMethodAllowed(enableMining) {
+		t.Error("read key should not call admin method")
+	}
+	if readOnly.IsMethodAllowed(dumpPrivkey) {
+		t.Error("read key should not call wallet method")
+	}
+
+	sendTx := APIKey{Name: "sendtx", Scopes: []string{APIKeyScopeRead, APIKeyScopeSendTx}, DeniedMethods: []string{createAndSendStakingTransaction}}
+	if !sendTx.IsMethodAllowed(createAndSendTransaction) {
+		t.Error("sendtx key should send transaction")
+	}
+	if sendTx.IsMethodAllowed(createAndSendStakingTransaction) {
+		t.Error("denied method should not be allowed")
+	}
+
+	extra := APIKey{Name: "extra", Scopes: []string{APIKeyScopeRead}, Methods: []string{enableMining}}
+	if !extra.IsMethodAllowed(enableMining) {
+		t.Error("method in allow-list should be allowed")
+	}
+
+	all := APIKey{Name: "all", Scopes: []string{APIKeyScopeAll}, Methods: []string{"nosuchmethod"}}
+	if !all.IsMethodAllowed(getBlockChainInfo) || !all.IsMethodAllowed(enableMining) {
+		t.Error("key of all scopes should call known methods")
+	}
+	if all.IsMethodAllowed("nosuchmethod") {
+		t.Error("unknown method should not be allowed")
+	}
+	all.Disabled = true
+	if all.IsMethodAllowed(getBlockChainInfo) {
+		t.Error("disabled key should not call any method")
+	}
+}
+
+func TestHttpServerInitAPIKeyFileError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "apikeys.json")
+	if err := ioutil.WriteFile(filePath, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server := &HttpServer{}
+	if err := server.Init(&RpcServerConfig{RPCAPIKeyFile: filePath}); err == nil {
+		t.Error("invalid api key file should fail init")
+	}
+}
+
+func TestAPIKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "apikeys.json")
+
+	store := NewAPIKeyStore(filePath)
+	if err := store.Load(); err != nil {
+		t.Fatal("missing file should be loaded as empty key set", err)
+	}
+	rawKey, err := store.Add(APIKey{Name: "team-a", Scopes: []string{APIKeyScopeRead}, RequestPerMinute: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Add(APIKey{Name: "team-a", Scopes: []string{APIKeyScopeRead}}); err == nil {
+		t.Error("duplicated key name should be rejected")
+	}
+	if _, err := store.Add(APIKey{Name: "team-b", Scopes: []string{"root"}}); err == nil {
+		t.Error("unknown scope should be rejected")
+	}
+
+	apiKey, ok := store.Authenticate(rawKey)
+	if !ok || apiKey.Name != "team-a" || apiKey.RequestPerMinute != 10 {
+		t.Fatal("can not authenticate added key")
+	}
+	if _, ok := store.Authenticate(rawKey + "0"); ok {
+		t.Error("wrong key should not be authenticated")
+	}
+
+	// keys are persisted and can be reloaded
+	reloaded := NewAPIKeyStore(filePath)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.Authenticate(rawKey); !ok {
+		t.Error("reloaded store should authenticate key")
+	}
+
+	if err := store.Remove("team-a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.List()) != 0 {
+		t.Error("removed key should not be reloaded")
+	}
+}
+
+func TestGetRawAPIKey(t *testing.T) {
+	if key := getRawAPIKey(map[string][]string{"X-Api-Key": {"abc"}}); key != "abc" {
+		t.Error("can not get key from api key header")
+	}
+	if key := getRawAPIKey(map[string][]string{"Authorization": {"Bearer abc"}}); key != "abc" {
+		t.Error("can not get key from authorization header")
+	}
+	if key := getRawAPIKey(map[string][]string{"Authorization": {"Basic abc"}}); key != "" {
+		t.Error("basic authorization should not be an api key")
+	}
+}
+
+func TestAPIKeyManagementIsAdminOnly(t *testing.T) {
+	for _, method := range []string{listAPIKeys, addAPIKey, removeAPIKey, reloadAPIKeys} {
+		if _, ok := LimitedHttpHandler[method]; ok {
+			t.Errorf("%s should not be available to the limited user", method)
+		}
+		if _, ok := AdminHttpHandler[method]; !ok {
+			t.Errorf("%s should be available to the admin user", method)
+		}
+		if scope := methodScope(method); scope != APIKeyScopeAdmin {
+			t.Errorf("%s should be in admin scope, got %s", method, scope)
+		}
+	}
+}
+
+func TestWsServerAPIKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewAPIKeyStore(filepath.Join(dir, "apikeys.json"))
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	sendTxKey, err := store.Add(APIKey{Name: "sendtx", Scopes: []string{APIKeyScopeSendTx}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readKey, err := store.Add(APIKey{Name: "read", Scopes: []string{APIKeyScopeRead}, RequestPerMinute: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wsServer := &WsServer{}
+	wsServer.Init(&RpcServerConfig{RPCMaxWSClients: 10, MemCache: memcache.New()})
+	wsServer.apiKeyStore = store
+	server := httptest.NewServer(http.HandlerFunc(wsServer.handleWsRequest))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dial := func(rawKey string) (*websocket.Conn, *http.Response, error) {
+		return websocket.DefaultDialer.Dial(url, http.Header{"X-Api-Key": {rawKey}})
+	}
+	// subscribe sends a test subscription and returns the error code of the first reply
+	subscribe := func(ws *websocket.Conn, id int) int {
+		request := map[string]interface{}{
+			"Request":     map[string]interface{}{"Jsonrpc": "1.0", "Method": testSubcrice, "Params": []interface{}{id}, "Id": id},
+			"Subcription": "test",
+			"Type":        0,
+		}
+		if err := ws.WriteJSON(request); err != nil {
+			t.Fatal(err)
+		}
+		var response JsonResponse
+		if err := ws.ReadJSON(&response); err != nil {
+			t.Fatal(err)
+		}
+		if response.Error == nil {
+			return 0
+		}
+		return response.Error.Code
+	}
+	permissionErrCode := rpcservice.ErrCodeMessage[rpcservice.RPCInvalidMethodPermissionError].Code
+
+	if _, resp, err := dial(readKey + "0"); err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Error("wrong api key should not connect")
+	}
+
+	ws, _, err := dial(sendTxKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := subscribe(ws, 1); code != permissionErrCode {
+		t.Error("api key without read scope should not subscribe")
+	}
+	ws.Close()
+
+	ws, _, err = dial(readKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if code := subscribe(ws, 1); code != 0 {
+		t.Error("read key should subscribe, got error code", code)
+	}
+	if code := subscribe(ws, 2); code != permissionErrCode {
+		t.Error("api key over rate limit should not subscribe")
+	}
+}
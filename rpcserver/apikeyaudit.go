@@ -0,0 +1,65 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// APIAuditRecord is one line of the rpc audit log
+type APIAuditRecord struct {
+	Time          int64
+	KeyName       string
+	RemoteAddress string
+	Method        string
+	Allowed       bool
+	ErrorCode     int
+	DurationMs    int64
+}
+
+// apiAuditLog appends audit records as json lines to a file.
+// When no file is configured, records are written to the rpc logger.
+type apiAuditLog struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+func newAPIAuditLog(filePath string) (*apiAuditLog, error) {
+	auditLog := &apiAuditLog{}
+	if filePath == "" {
+		return auditLog, nil
+	}
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	auditLog.file = file
+	return auditLog, nil
+}
+
+func (auditLog *apiAuditLog) write(record APIAuditRecord) {
+	if record.Time == 0 {
+		record.Time = time.Now().Unix()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	if auditLog.file == nil {
+		Logger.log.Infof("RPC audit %s", string(data))
+		return
+	}
+	auditLog.lock.Lock()
+	defer auditLog.lock.Unlock()
+	if _, err := auditLog.file.Write(append(data, '\n')); err != nil {
+		Logger.log.Errorf("Can not write rpc audit log %+v", err)
+	}
+}
+
+func (auditLog *apiAuditLog) close() {
+	if auditLog.file != nil {
+		auditLog.file.Close()
+	}
+}
@@ -17,6 +17,7 @@ type CreateRawTxParam struct {
 	EstimateFeeCoinPerKb int64
 	HasPrivacyCoin       bool
 	Info                 []byte
+	RingSize             int // default is 0 -> use privacy.CommitmentRingSize
 }
 
 func GetKeySetFromPrivateKeyParams(privateKeyWalletStr string) (*incognitokey.KeySet, byte, error) {
@@ -120,6 +121,12 @@ func NewCreateRawTxParam(params interface{}) (*CreateRawTxParam, error) {
 		}
 	}
 
+	// param #7: ring size (optional)
+	ringSize, err := getRingSizeParam(arrayParams, 6)
+	if err != nil {
+		return nil, err
+	}
+
 	return &CreateRawTxParam{
 		SenderKeySet:         senderKeySet,
 		ShardIDSender:        shardIDSender,
@@ -127,6 +134,7 @@ func NewCreateRawTxParam(params interface{}) (*CreateRawTxParam, error) {
 		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
 		HasPrivacyCoin:       hasPrivacyCoin,
 		Info:                 info,
+		RingSize:             ringSize,
 	}, nil
 }
 
@@ -209,6 +217,12 @@ func NewCreateRawTxParamV2(params interface{}) (*CreateRawTxParam, error) {
 
 	}
 
+	// param #7: ring size (optional)
+	ringSize, err := getRingSizeParam(arrayParams, 6)
+	if err != nil {
+		return nil, err
+	}
+
 	return &CreateRawTxParam{
 		SenderKeySet:         senderKeySet,
 		ShardIDSender:        shardIDSender,
@@ -216,5 +230,19 @@ func NewCreateRawTxParamV2(params interface{}) (*CreateRawTxParam, error) {
 		EstimateFeeCoinPerKb: int64(estimateFeeCoinPerKb),
 		HasPrivacyCoin:       hasPrivacyCoin,
 		Info:                 info,
+		RingSize:             ringSize,
 	}, nil
 }
+
+// getRingSizeParam reads the optional ring size of a privacy tx at index of arrayParams,
+// 0 if it is missing, which means privacy.CommitmentRingSize
+func getRingSizeParam(arrayParams []interface{}, index int) (int, error) {
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return 0, nil
+	}
+	ringSizeParam, ok := arrayParams[index].(float64)
+	if !ok || ringSizeParam < 0 {
+		return 0, errors.New("ring size is invalid")
+	}
+	return int(ringSizeParam), nil
+}
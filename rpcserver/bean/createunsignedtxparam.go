@@ -0,0 +1,149 @@
+package bean
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type CreateUnsignedTxParam struct {
+	SenderPaymentAddress privacy.PaymentAddress
+	ShardIDSender        byte
+	InputCoins           []*privacy.InputCoin
+	PaymentInfos         []*privacy.PaymentInfo
+	Fee                  uint64
+	HasPrivacyCoin       bool
+	Info                 []byte
+	RingSize             int
+	Metadata             map[string]interface{}
+}
+
+func NewCreateUnsignedTxParam(params interface{}) (*CreateUnsignedTxParam, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, errors.New("not enough param")
+	}
+
+	// param #1: payment address of sender
+	senderAddressParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, errors.New("sender payment address is invalid")
+	}
+	senderKeyWallet, err := wallet.Base58CheckDeserialize(senderAddressParam)
+	if err != nil {
+		return nil, err
+	}
+	if len(senderKeyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, errors.New("sender payment address is invalid")
+	}
+	senderPk := senderKeyWallet.KeySet.PaymentAddress.Pk
+	shardIDSender := common.GetShardIDFromLastByte(senderPk[len(senderPk)-1])
+
+	// param #2: input coins to spend, with the format of output coins of listoutputcoins
+	inputCoinsParam, ok := arrayParams[1].([]interface{})
+	if !ok || len(inputCoinsParam) == 0 {
+		return nil, errors.New("input coins param is invalid")
+	}
+	inputCoins := make([]*privacy.InputCoin, 0)
+	for _, inputCoinParam := range inputCoinsParam {
+		coinObjBytes, err := json.Marshal(inputCoinParam)
+		if err != nil {
+			return nil, err
+		}
+		coinObj := privacy.CoinObject{}
+		err = json.Unmarshal(coinObjBytes, &coinObj)
+		if err != nil {
+			return nil, errors.New("input coin param is invalid")
+		}
+		inputCoin := new(privacy.InputCoin).Init()
+		err = inputCoin.ParseCoinObjectToInputCoin(coinObj)
+		if err != nil {
+			return nil, fmt.Errorf("input coin param is invalid %v", err)
+		}
+		inputCoins = append(inputCoins, inputCoin)
+	}
+
+	// param #3: list receivers
+	receivers, ok := arrayParams[2].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("receivers param is invalid")
+	}
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, amount := range receivers {
+		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyWalletReceiver.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("payment info %+v is invalid", paymentAddressStr)
+		}
+		amountParam, ok := amount.(float64)
+		if !ok {
+			return nil, errors.New("amount payment address is invalid")
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			Amount:         uint64(amountParam),
+			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+		})
+	}
+
+	// param #4: fee in nano PRV
+	feeParam, ok := arrayParams[3].(float64)
+	if !ok || feeParam < 0 {
+		return nil, errors.New("fee is invalid")
+	}
+
+	// param #5: hasPrivacyCoin flag: 1 or -1
+	// default: -1 (has no privacy) (if missing this param)
+	hasPrivacyCoinParam := float64(-1)
+	if len(arrayParams) > 4 {
+		hasPrivacyCoinParam, ok = arrayParams[4].(float64)
+		if !ok {
+			return nil, errors.New("has privacy for tx is invalid")
+		}
+	}
+
+	// param #6: info (optional)
+	info := []byte{}
+	if len(arrayParams) > 5 && arrayParams[5] != nil {
+		infoStr, ok := arrayParams[5].(string)
+		if !ok {
+			return nil, errors.New("info is invalid")
+		}
+		info = []byte(infoStr)
+	}
+
+	// param #7: ring size (optional), default is privacy.CommitmentRingSize
+	ringSize, err := getRingSizeParam(arrayParams, 6)
+	if err != nil {
+		return nil, err
+	}
+
+	// param #8: metadata (optional), with the json format of the metadata of the transaction
+	var meta map[string]interface{}
+	if len(arrayParams) > 7 && arrayParams[7] != nil {
+		meta, ok = arrayParams[7].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("metadata is invalid")
+		}
+		if _, ok := meta["Type"].(float64); !ok {
+			return nil, errors.New("metadata type is invalid")
+		}
+	}
+
+	return &CreateUnsignedTxParam{
+		SenderPaymentAddress: senderKeyWallet.KeySet.PaymentAddress,
+		ShardIDSender:        shardIDSender,
+		InputCoins:           inputCoins,
+		PaymentInfos:         paymentInfos,
+		Fee:                  uint64(feeParam),
+		HasPrivacyCoin:       int(hasPrivacyCoinParam) > 0,
+		Info:                 info,
+		RingSize:             ringSize,
+		Metadata:             meta,
+	}, nil
+}
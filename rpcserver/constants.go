@@ -55,6 +55,7 @@ const (
 	listOutputCoins                              = "listoutputcoins"
 	createRawTransaction                         = "createtransaction"
 	sendRawTransaction                           = "sendtransaction"
+	createUnsignedTransaction                    = "createunsignedtransaction" // the bundle is signed offline by the signtxbundle command of cmd, then sent by sendtransaction
 	createAndSendTransaction                     = "createandsendtransaction"
 	createAndSendTransactionV2                   = "createandsendtransactionv2"
 	createAndSendCustomTokenTransaction          = "createandsendcustomtokentransaction"
@@ -126,6 +127,10 @@ const (
 	dumpPrivkey                = "dumpprivkey"
 	importAccount              = "importaccount"
 	removeAccount              = "removeaccount"
+	importWatchOnlyAccount     = "importwatchonlyaccount"
+	createHDAccount            = "createhdaccount"
+	recoverHDAccounts          = "recoverhdaccounts"
+	setAccountLabel            = "setaccountlabel"
 	listUnspentOutputCoins     = "listunspentoutputcoins"
 	getBalance                 = "getbalance"
 	getBalanceByPrivatekey     = "getbalancebyprivatekey"
@@ -173,6 +178,7 @@ const (
 	getRewardAmount              = "getrewardamount"
 	getRewardAmountByPublicKey   = "getrewardamountbypublickey"
 	listRewardAmount             = "listrewardamount"
+	getRewardHistory             = "getrewardhistory"
 
 	revertbeaconchain = "revertbeaconchain"
 	revertshardchain  = "revertshardchain"
@@ -211,6 +217,16 @@ const (
 	getPDEFeeWithdrawalStatus                  = "getpdefeewithdrawalstatus"
 	convertPDEPrices                           = "convertpdeprices"
 	extractPDEInstsFromBeaconBlock             = "extractpdeinstsfrombeaconblock"
+	getPDETradeQuote                           = "getpdetradequote"
+	getPDECandles                              = "getpdecandles"
+	getPDEPairVolume                           = "getpdepairvolume"
+	getPDELPFeeHistory                         = "getpdelpfeehistory"
+	getPDELiquidityHistory                     = "getpdeliquidityhistory"
+	createAndSendTxWithPRVLimitOrderReq        = "createandsendtxwithprvlimitorderreq"
+	createAndSendTxWithPTokenLimitOrderReq     = "createandsendtxwithptokenlimitorderreq"
+	createAndSendTxWithCancelLimitOrderReq     = "createandsendtxwithcancellimitorderreq"
+	getPDELimitOrderStatus                     = "getpdelimitorderstatus"
+	getPDECancelLimitOrderStatus               = "getpdecancellimitorderstatus"
 
 	// get burning address
 	getBurningAddress = "getburningaddress"
@@ -223,6 +239,7 @@ const (
 	createAndSendRegisterPortingPublicTokens      = "createandsendregisterportingpublictokens"
 	createAndSendPortalExchangeRates              = "createandsendportalexchangerates"
 	getPortalFinalExchangeRates                   = "getportalfinalexchangerates"
+	getPortalFeederPerformance                    = "getportalfeederperformance"
 	getPortalPortingRequestByKey                  = "getportalportingrequestbykey"
 	getPortalPortingRequestByPortingId            = "getportalportingrequestbyportingid"
 	convertExchangeRates                          = "convertexchangerates"
@@ -263,6 +280,7 @@ const (
 	getPortalWithdrawCollateralProof              = "getportalwithdrawcollateralproof"
 	createAndSendUnlockOverRateCollaterals        = "createandsendtxwithunlockoverratecollaterals"
 	getPortalUnlockOverRateCollateralsStatus      = "getportalunlockoverratecollateralsbytxidstatus"
+	getCustodianRiskReport                        = "getcustodianriskreport"
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
@@ -272,6 +290,12 @@ const (
 	getBTCRelayingBestState              = "getbtcrelayingbeststate"
 	getBTCBlockByHash                    = "getbtcblockbyhash"
 	getLatestBNBHeaderBlockHeight        = "getlatestbnbheaderblockheight"
+	createAndSendTxWithRelayingETHHeader = "createandsendtxwithrelayingethheader"
+	getETHRelayingBestState              = "getethrelayingbeststate"
+	createAndSendTxWithRelayingLTCHeader = "createandsendtxwithrelayingltcheader"
+	getLTCRelayingBestState              = "getltcrelayingbeststate"
+	getRelayingBNBCandidateForks         = "getrelayingbnbcandidateforks"
+	verifyRelayingBNBProof               = "verifyrelayingbnbproof"
 
 	// incognito mode for sc
 	getBurnProofForDepositToSC                  = "getburnprooffordeposittosc"
@@ -290,7 +314,14 @@ const (
 	getTotalStaker = "gettotalstaker"
 
 	//validator state
-	getValKeyState = "getvalkeystate"
+	getValKeyState     = "getvalkeystate"
+	getValidatorStatus = "getvalidatorstatus"
+
+	// api key management
+	listAPIKeys   = "listapikeys"
+	addAPIKey     = "addapikey"
+	removeAPIKey  = "removeapikey"
+	reloadAPIKeys = "reloadapikeys"
 )
 
 const (
@@ -312,4 +343,6 @@ const (
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeCustodianRisk                       = "subcribecustodianrisk"
+	subcribeValidatorStatus                     = "subcribevalidatorstatus"
 )
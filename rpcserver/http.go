@@ -1,6 +1,7 @@
 package rpcserver
 
 import (
+	"context"
 	"crypto/subtle"
 	"encoding/base64"
 	"errors"
@@ -17,8 +18,10 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/memcache"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
@@ -33,6 +36,8 @@ type HttpServer struct {
 	statusLines      map[int]string
 	authSHA          []byte
 	limitAuthSHA     []byte
+	apiKeyStore      *APIKeyStore
+	auditLog         *apiAuditLog
 	// channel
 	cRequestProcessShutdown chan struct{}
 
@@ -47,7 +52,7 @@ type HttpServer struct {
 	synkerService     *rpcservice.SynkerService
 }
 
-func (httpServer *HttpServer) Init(config *RpcServerConfig) {
+func (httpServer *HttpServer) Init(config *RpcServerConfig) error {
 	httpServer.config = *config
 	httpServer.statusLines = make(map[int]string)
 	if config.RPCUser != "" && config.RPCPass != "" {
@@ -60,6 +65,17 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 		httpServer.limitAuthSHA = common.HashB([]byte(auth))
 	}
+	if config.RPCAPIKeyFile != "" {
+		httpServer.apiKeyStore = NewAPIKeyStore(config.RPCAPIKeyFile)
+		if err := httpServer.apiKeyStore.Load(); err != nil {
+			return fmt.Errorf("can not load rpc api keys: %v", err)
+		}
+		auditLog, err := newAPIAuditLog(config.RPCAuditLogFile)
+		if err != nil {
+			return fmt.Errorf("can not open rpc audit log: %v", err)
+		}
+		httpServer.auditLog = auditLog
+	}
 
 	// init service
 	httpServer.blockService = &rpcservice.BlockService{
@@ -93,6 +109,7 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 	httpServer.portal = &rpcservice.PortalService{
 		BlockChain: httpServer.config.BlockChain,
 	}
+	return nil
 }
 
 // Start is used by rpcserver.go to start the rpc listener.
@@ -140,6 +157,9 @@ func (httpServer *HttpServer) Stop() {
 	for _, listen := range httpServer.config.HttpListenters {
 		listen.Close()
 	}
+	if httpServer.auditLog != nil {
+		httpServer.auditLog.close()
+	}
 	Logger.log.Warn("RPC server shutdown complete")
 	atomic.StoreInt32(&httpServer.started, 0)
 	atomic.StoreInt32(&httpServer.shutdown, 1)
@@ -170,12 +190,24 @@ func (httpServer *HttpServer) handleRequest(w http.ResponseWriter, r *http.Reque
 		httpServer.DecrementClients()
 		//fmt.Println("RPCCON:", before, httpServer.numClients)
 	}()
-	// Check authentication for rpc user
-	ok, isLimitUser, err := httpServer.checkAuth(r, true)
-	if err != nil || !ok {
-		Logger.log.Error(err)
-		AuthFail(w)
-		return
+	// Check authentication for api key, then for rpc user
+	isLimitUser := false
+	if rawKey := getRawAPIKey(r.Header); rawKey != "" && httpServer.apiKeyStore != nil {
+		apiKey, ok := httpServer.apiKeyStore.Authenticate(rawKey)
+		if !ok {
+			Logger.log.Warnf("RPC api key authentication failure from %s", r.RemoteAddr)
+			AuthFail(w)
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, apiKey))
+	} else {
+		ok, isLimit, err := httpServer.checkAuth(r, true)
+		if err != nil || !ok {
+			Logger.log.Error(err)
+			AuthFail(w)
+			return
+		}
+		isLimitUser = isLimit
 	}
 
 	go func() {
@@ -210,6 +242,21 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	apiKey := apiKeyFromRequest(r)
+	startTime := time.Now()
+	if apiKey != nil && checkAPIKeyRateLimit(httpServer.config.MemCache, apiKey) {
+		errMsg := "Reach rate limit of api key " + apiKey.Name
+		Logger.log.Error(errMsg)
+		httpServer.auditLog.write(APIAuditRecord{
+			KeyName:       apiKey.Name,
+			RemoteAddress: getIP(r),
+			ErrorCode:     http.StatusTooManyRequests,
+		})
+		errCode := http.StatusTooManyRequests
+		http.Error(w, strconv.Itoa(errCode)+" "+errMsg, errCode)
+		return
+	}
+
 	if httpServer.config.RPCLimitRequestPerDay > 0 {
 		// check limit request per day
 		if httpServer.checkLimitRequestPerDay(r) {
@@ -286,11 +333,20 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		}()
 
 		// Check if the user is limited and set error if method unauthorized
-		if !isLimitedUser {
+		if apiKey != nil {
+			// api keys are checked against their own allow-list
+			if !apiKey.IsMethodAllowed(request.Method) {
+				jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New("api key "+apiKey.Name+" is not allowed to call "+request.Method))
+			}
+			isLimitedUser = true
+		} else if !isLimitedUser {
 			if function, ok := LimitedHttpHandler[request.Method]; ok {
 				_ = function
 				jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New(""))
 			}
+		} else if _, ok := AdminHttpHandler[request.Method]; ok {
+			// the limited user can not manage the node
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New(""))
 		}
 		if jsonErr == nil {
 			if request.Method == "downloadbackup" {
@@ -301,6 +357,10 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 			// Attempt to parse the JSON-RPC request into a known concrete
 			// command.
 			command := HttpHandler[request.Method]
+			if command == nil {
+				// permission of admin commands was checked above
+				command = AdminHttpHandler[request.Method]
+			}
 			if command == nil {
 				if isLimitedUser {
 					command = LimitedHttpHandler[request.Method]
@@ -310,7 +370,9 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 				}
 			}
 			if command != nil {
+				startTimeCommand := time.Now()
 				result, jsonErr = command(httpServer, request.Params, closeChan)
+				updateRPCMethodMetrics(request.Method, startTimeCommand, jsonErr)
 			} else {
 				jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
 			}
@@ -336,6 +398,20 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		httpServer.addBlackListClientRequestErrorPerHour(r, request.Method)
 	}
 
+	if apiKey != nil {
+		record := APIAuditRecord{
+			KeyName:       apiKey.Name,
+			RemoteAddress: getIP(r),
+			Method:        request.Method,
+			Allowed:       apiKey.IsMethodAllowed(request.Method),
+			DurationMs:    time.Since(startTime).Milliseconds(),
+		}
+		if jsonErr != nil && jsonErr.(*rpcservice.RPCError) != nil {
+			record.ErrorCode = jsonErr.(*rpcservice.RPCError).Code
+		}
+		httpServer.auditLog.write(record)
+	}
+
 	// Marshal the response.
 	msg, err := createMarshalledResponse(request, result, jsonErr)
 	if err != nil {
@@ -486,6 +562,47 @@ func (httpServer *HttpServer) checkLimitRequestPerDay(r *http.Request) bool {
 	return reachLimit
 }
 
+// checkAPIKeyRateLimit returns true if the api key has reached its request
+// per minute or request per day limit, the counters are kept in memCache so
+// http and websocket requests of a key share the same limit
+func checkAPIKeyRateLimit(memCache *memcache.MemoryCache, apiKey *APIKey) bool {
+	reachLimit := false
+	if apiKey.RequestPerMinute > 0 {
+		key := []byte("rpc-apikey-minute-" + apiKey.Name)
+		reachLimit = increaseRequestCount(memCache, key, 60*1000) > apiKey.RequestPerMinute
+	}
+	if apiKey.RequestPerDay > 0 {
+		key := []byte("rpc-apikey-day-" + apiKey.Name)
+		reachLimit = increaseRequestCount(memCache, key, 24*60*60*1000) > apiKey.RequestPerDay || reachLimit
+	}
+	return reachLimit
+}
+
+// increaseRequestCount increases the request counter stored in memcache under key
+// and returns the new value, the counter is reset after expired milliseconds
+func increaseRequestCount(memCache *memcache.MemoryCache, key []byte, expired time.Duration) int {
+	requestCountInByte, _ := memCache.Get(key)
+	if requestCountInByte != nil {
+		requestCount := common.BytesToInt(requestCountInByte) + 1
+		memCache.Put(key, common.IntToBytes(requestCount))
+		return requestCount
+	}
+	err := memCache.PutExpired(key, common.IntToBytes(1), expired)
+	if err != nil {
+		Logger.log.Errorf("Can not update request count for %s err:%+v", string(key), err)
+	}
+	return 1
+}
+
+type apiKeyContextKey struct{}
+
+// apiKeyFromRequest returns the api key authenticated for the request, nil
+// if the request was authenticated by rpc user
+func apiKeyFromRequest(r *http.Request) *APIKey {
+	apiKey, _ := r.Context().Value(apiKeyContextKey{}).(*APIKey)
+	return apiKey
+}
+
 // checkAuth checks the HTTP Basic authentication supplied by a wallet
 // or RPC client in the HTTP request r.  If the supplied authentication
 // does not match the username and password expected, a non-nil error is
@@ -642,3 +759,11 @@ func (httpServer *HttpServer) GetShardChainDatabase(shardID byte) incdb.Database
 func (httpServer *HttpServer) GetBlockchain() *blockchain.BlockChain {
 	return httpServer.config.BlockChain
 }
+
+// updateRPCMethodMetrics records the latency of a known rpc method and counts its errors
+func updateRPCMethodMetrics(method string, startTime time.Time, jsonErr error) {
+	metrics.GetOrRegisterTimer(metrics.LabeledName(metrics.RPCRequestTime, "method", method), nil).UpdateSince(startTime)
+	if rpcErr, ok := jsonErr.(*rpcservice.RPCError); ok && rpcErr != nil {
+		metrics.GetOrRegisterCounter(metrics.LabeledName(metrics.RPCRequestErrCount, "method", method), nil).Inc(1)
+	}
+}
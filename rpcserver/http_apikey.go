@@ -0,0 +1,90 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+/*
+handleListAPIKeys - RPC returns all api keys without their key hash
+*/
+func (httpServer *HttpServer) handleListAPIKeys(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.apiKeyStore == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.APIKeyNotEnabledError, nil)
+	}
+	result := []jsonresult.APIKeyResult{}
+	for _, apiKey := range httpServer.apiKeyStore.List() {
+		result = append(result, jsonresult.NewAPIKeyResult(apiKey.Name, apiKey.Scopes, apiKey.Methods, apiKey.DeniedMethods, apiKey.RequestPerMinute, apiKey.RequestPerDay, apiKey.Disabled, apiKey.CreatedAt))
+	}
+	return result, nil
+}
+
+/*
+handleAddAPIKey - RPC creates a new api key and returns the raw key, which is not stored on the node
+Param #1: {"Name": "...", "Scopes": ["read", "sendtx"], "Methods": [], "DeniedMethods": [], "RequestPerMinute": 0, "RequestPerDay": 0}
+*/
+func (httpServer *HttpServer) handleAddAPIKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.apiKeyStore == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.APIKeyNotEnabledError, nil)
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("api key param is invalid"))
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	apiKey := APIKey{}
+	if err := json.Unmarshal(dataBytes, &apiKey); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	rawKey, err := httpServer.apiKeyStore.Add(apiKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UpdateAPIKeyError, err)
+	}
+	return map[string]string{"Name": apiKey.Name, "Key": rawKey}, nil
+}
+
+/*
+handleRemoveAPIKey - RPC removes an api key by name
+Param #1: name of the key
+*/
+func (httpServer *HttpServer) handleRemoveAPIKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.apiKeyStore == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.APIKeyNotEnabledError, nil)
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	name, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("api key name is invalid"))
+	}
+	if err := httpServer.apiKeyStore.Remove(name); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UpdateAPIKeyError, err)
+	}
+	return true, nil
+}
+
+/*
+handleReloadAPIKeys - RPC reloads all api keys from the api key file
+*/
+func (httpServer *HttpServer) handleReloadAPIKeys(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.apiKeyStore == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.APIKeyNotEnabledError, nil)
+	}
+	if err := httpServer.apiKeyStore.Load(); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UpdateAPIKeyError, err)
+	}
+	return len(httpServer.apiKeyStore.List()), nil
+}
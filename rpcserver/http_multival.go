@@ -2,7 +2,9 @@ package rpcserver
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
@@ -13,3 +15,27 @@ func (httpServer *HttpServer) handleGetValKeyState(params interface{}, closeChan
 	states := httpServer.config.ConsensusEngine.GetAllValidatorKeyState()
 	return states, nil
 }
+
+// handleGetValidatorStatus returns the phase of a validator and its transitions from the validator index,
+// param: the committee public key or the base58 bls mining key of the validator
+func (httpServer *HttpServer) handleGetValidatorStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.ValidatorIndexer == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetValidatorStatusError, errors.New("Validator index is not enabled on this node, run it with --validatorindex"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+	}
+	publicKey, ok := arrayParams[0].(string)
+	if !ok || publicKey == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
+	}
+	status, err := httpServer.config.ValidatorIndexer.GetValidatorStatus(publicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetValidatorStatusError, err)
+	}
+	if status == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetValidatorStatusError, fmt.Errorf("Public key %v is not staked until beacon height %v", publicKey, httpServer.config.ValidatorIndexer.GetLatestIndexedHeight()))
+	}
+	return status, nil
+}
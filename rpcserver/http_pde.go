@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"strconv"
@@ -536,6 +537,7 @@ func (httpServer *HttpServer) handleGetPDEState(params interface{}, closeChan <-
 		PDEShares:               pdeState.PDEShares,
 		WaitingPDEContributions: pdeState.WaitingPDEContributions,
 		PDETradingFees:          pdeState.PDETradingFees,
+		PDELimitOrders:          pdeState.PDELimitOrders,
 	}
 	return result, nil
 }
@@ -1584,3 +1586,95 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithPDEFeeWithdrawalReq(param
 	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
 	return result, nil
 }
+
+func (httpServer *HttpServer) handleGetPDETradeQuote(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToSellStr is invalid"))
+	}
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToBuyStr is invalid"))
+	}
+	sellAmount, ok := data["SellAmount"].(float64)
+	if !ok || sellAmount <= 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("SellAmount is invalid"))
+	}
+	// beacon height, trading fee and slippage (in percent) are optional
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if beaconHeightParam, ok := data["BeaconHeight"]; ok {
+		beaconHeightFloat, ok := beaconHeightParam.(float64)
+		if !ok || beaconHeightFloat < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BeaconHeight is invalid"))
+		}
+		beaconHeight = uint64(beaconHeightFloat)
+	}
+	tradingFee := float64(0)
+	if tradingFeeParam, ok := data["TradingFee"]; ok {
+		tradingFee, ok = tradingFeeParam.(float64)
+		if !ok || tradingFee < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TradingFee is invalid"))
+		}
+	}
+	slippage := float64(0)
+	if slippageParam, ok := data["Slippage"]; ok {
+		slippage, ok = slippageParam.(float64)
+		if !ok || slippage < 0 || slippage > 100 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Slippage must be a percentage from 0 to 100"))
+		}
+	}
+
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, fmt.Errorf("Can't found ConsensusStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(beaconFeatureStateDB, beaconHeight)
+	if err != nil || pdeState == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEStateError, err)
+	}
+
+	simulation, err := blockchain.SimulatePDECrossPoolTrade(pdeState, beaconHeight, tokenIDToSellStr, tokenIDToBuyStr, uint64(sellAmount), uint64(tradingFee))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDETradeQuoteError, err)
+	}
+	minAcceptableAmount, err := simulation.GetMinAcceptableAmount(uint64(math.Round(slippage * 100)))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	result := jsonresult.PDETradeQuote{
+		BeaconHeight:        beaconHeight,
+		TokenIDToSellStr:    simulation.TokenIDToSellStr,
+		TokenIDToBuyStr:     simulation.TokenIDToBuyStr,
+		SellAmount:          simulation.SellAmount,
+		ReceiveAmount:       simulation.ReceiveAmount,
+		SpotReceiveAmount:   simulation.SpotReceiveAmount,
+		TradingFee:          simulation.TradingFee,
+		PriceImpact:         simulation.PriceImpact,
+		Slippage:            slippage,
+		MinAcceptableAmount: minAcceptableAmount,
+		Pools:               []*jsonresult.PDETradeQuoteHop{},
+	}
+	for _, hop := range simulation.Hops {
+		result.Pools = append(result.Pools, &jsonresult.PDETradeQuoteHop{
+			PoolPairKey:      hop.PoolPairKey,
+			TokenIDToSellStr: hop.TokenIDToSellStr,
+			TokenIDToBuyStr:  hop.TokenIDToBuyStr,
+			SellAmount:       hop.SellAmount,
+			ReceiveAmount:    hop.ReceiveAmount,
+			AddingFee:        hop.AddingFee,
+		})
+	}
+	return result, nil
+}
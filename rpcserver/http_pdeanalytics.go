@@ -0,0 +1,133 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// parsePDEAnalyticsParams returns the payload, the from and to beacon heights of a pde analytics request,
+// the heights default to the whole indexed range
+func (httpServer *HttpServer) parsePDEAnalyticsParams(params interface{}) (map[string]interface{}, uint64, uint64, *rpcservice.RPCError) {
+	if httpServer.config.PDEIndexer == nil {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.GetPDEAnalyticsError, errors.New("PDE index is not enabled on this node, run it with --pdeindex"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) == 0 {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	fromHeight := uint64(0)
+	toHeight := httpServer.config.PDEIndexer.GetLatestIndexedHeight()
+	if fromHeightParam, ok := data["FromBeaconHeight"]; ok {
+		fromHeightFloat, ok := fromHeightParam.(float64)
+		if !ok || fromHeightFloat < 0 {
+			return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromBeaconHeight is invalid"))
+		}
+		fromHeight = uint64(fromHeightFloat)
+	}
+	if toHeightParam, ok := data["ToBeaconHeight"]; ok {
+		toHeightFloat, ok := toHeightParam.(float64)
+		if !ok || toHeightFloat < 0 {
+			return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToBeaconHeight is invalid"))
+		}
+		toHeight = uint64(toHeightFloat)
+	}
+	if fromHeight > toHeight {
+		return nil, 0, 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("FromBeaconHeight %v is greater than ToBeaconHeight %v", fromHeight, toHeight))
+	}
+	return data, fromHeight, toHeight, nil
+}
+
+func parsePDEPairParams(data map[string]interface{}, token1Key string, token2Key string) (string, string, *rpcservice.RPCError) {
+	token1IDStr, ok := data[token1Key].(string)
+	if !ok || token1IDStr == "" {
+		return "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%v is invalid", token1Key))
+	}
+	token2IDStr, ok := data[token2Key].(string)
+	if !ok || token2IDStr == "" || token2IDStr == token1IDStr {
+		return "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%v is invalid", token2Key))
+	}
+	return token1IDStr, token2IDStr, nil
+}
+
+func (httpServer *HttpServer) handleGetPDECandles(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := httpServer.parsePDEAnalyticsParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tokenIDStr, quoteTokenIDStr, rpcErr := parsePDEPairParams(data, "TokenIDStr", "QuoteTokenIDStr")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	interval, ok := data["Interval"].(float64)
+	if !ok || interval < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Interval is invalid"))
+	}
+	candles, err := httpServer.config.PDEIndexer.GetCandles(tokenIDStr, quoteTokenIDStr, fromHeight, toHeight, uint64(interval))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEAnalyticsError, err)
+	}
+	return candles, nil
+}
+
+func (httpServer *HttpServer) handleGetPDEPairVolume(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := httpServer.parsePDEAnalyticsParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEPairParams(data, "Token1IDStr", "Token2IDStr")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	volume, err := httpServer.config.PDEIndexer.GetPairVolume(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEAnalyticsError, err)
+	}
+	return volume, nil
+}
+
+func (httpServer *HttpServer) handleGetPDELPFeeHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := httpServer.parsePDEAnalyticsParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEPairParams(data, "Token1IDStr", "Token2IDStr")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	// fees of all contributors of the pair are returned if the contributor is not specified
+	contributorAddressStr := ""
+	if contributorParam, ok := data["ContributorAddressStr"]; ok {
+		contributorAddressStr, ok = contributorParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ContributorAddressStr is invalid"))
+		}
+	}
+	feeHistory, err := httpServer.config.PDEIndexer.GetFeeHistory(token1IDStr, token2IDStr, contributorAddressStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEAnalyticsError, err)
+	}
+	return feeHistory, nil
+}
+
+func (httpServer *HttpServer) handleGetPDELiquidityHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, fromHeight, toHeight, rpcErr := httpServer.parsePDEAnalyticsParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token1IDStr, token2IDStr, rpcErr := parsePDEPairParams(data, "Token1IDStr", "Token2IDStr")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	events, err := httpServer.config.PDEIndexer.GetLiquidityEvents(token1IDStr, token2IDStr, fromHeight, toHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEAnalyticsError, err)
+	}
+	return events, nil
+}
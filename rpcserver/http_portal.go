@@ -103,7 +103,7 @@ func (httpServer *HttpServer) handleGetPortingRequestFees(params interface{}, cl
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
 	}
-	if !metadata.IsPortalToken(tokenID) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, tokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID should be a portal token"))
 	}
 
@@ -168,7 +168,7 @@ func (httpServer *HttpServer) handleCreateRawTxWithPortingRequest(params interfa
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId is invalid"))
 	}
 
-	if !metadata.IsPortalToken(pTokenId) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -887,6 +887,52 @@ func (httpServer *HttpServer) handleGetPortalFinalExchangeRates(params interface
 	return result, nil
 }
 
+// handleGetPortalFeederPerformance returns the latest rates and the submission history of exchange rate feeders,
+// params: optional FeederAddress and BeaconHeight, the default beacon height is the best one
+func (httpServer *HttpServer) handleGetPortalFeederPerformance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	data := map[string]interface{}{}
+	if len(arrayParams) > 0 {
+		var ok bool
+		data, ok = arrayParams[0].(map[string]interface{})
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+		}
+	}
+	feederAddress := ""
+	if _, ok := data["FeederAddress"]; ok {
+		feederAddress, ok = data["FeederAddress"].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FeederAddress is invalid"))
+		}
+	}
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if _, ok := data["BeaconHeight"]; ok {
+		height, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		beaconHeight = height
+	}
+
+	featureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalFeederPerformanceError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(featureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.config.BlockChain.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalFeederPerformanceError, err)
+	}
+
+	authorizedFeeders := httpServer.config.BlockChain.GetPortalFeederAddresses(beaconHeight)
+	portalParam := httpServer.config.BlockChain.GetPortalParams(beaconHeight)
+	result, err := httpServer.portal.GetPortalFeederPerformance(stateDB, beaconHeight, feederAddress, authorizedFeeders, portalParam)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalFeederPerformanceError, err)
+	}
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleConvertExchangeRates(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 
@@ -44,7 +44,7 @@ func (httpServer *HttpServer) handleCreateRawTxWithCustodianDeposit(params inter
 	remoteAddresses := make(map[string]string, 0)
 	tokenIDKeys := make([]string, 0)
 	for pTokenID, remoteAddress := range remoteAddressesMap {
-		if !metadata.IsPortalToken(pTokenID) {
+		if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenID) {
 			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 		}
 		_, ok := remoteAddress.(string)
@@ -150,7 +150,7 @@ func (httpServer *HttpServer) handleCreateRawTxWithCustodianDepositV3(params int
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata param is invalid"))
 	}
 
-	meta, err := metadata.NewPortalCustodianDepositV3FromMap(data)
+	meta, err := metadata.NewPortalCustodianDepositV3FromMap(data, httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -604,3 +604,45 @@ func (httpServer *HttpServer) handleCheckPortalExternalHashSubmitted(params inte
 	}
 	return issued, nil
 }
+
+/*
+====== Custodian liquidation risk
+*/
+func (httpServer *HttpServer) handleGetCustodianRiskReport(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	custodianAddress, ok := data["CustodianAddress"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("CustodianAddress is invalid"))
+	}
+	beaconHeight := httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight
+	if _, ok := data["BeaconHeight"]; ok {
+		height, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		beaconHeight = height
+	}
+
+	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskReportError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
+	}
+	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.GetBeaconChainDatabase()))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskReportError, err)
+	}
+
+	portalParam := httpServer.config.BlockChain.GetPortalParams(beaconHeight)
+	result, err := httpServer.portal.GetCustodianRiskReport(beaconFeatureStateDB, beaconHeight, custodianAddress, portalParam)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetCustodianRiskReportError, err)
+	}
+	return result, nil
+}
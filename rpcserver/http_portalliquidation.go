@@ -45,7 +45,7 @@ func (httpServer *HttpServer) handleGetLiquidationExchangeRatesPool(params inter
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is invalid"))
 	}
 
-	if !metadata.IsPortalToken(pTokenID) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata TokenID is not support"))
 	}
 
@@ -224,7 +224,7 @@ func (httpServer *HttpServer) createCustodianTopup(params interface{}, closeChan
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
 
-	if !metadata.IsPortalToken(pTokenId) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -320,7 +320,7 @@ func (httpServer *HttpServer) createTopUpWaitingPorting(params interface{}, clos
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
 
-	if !metadata.IsPortalToken(pTokenId) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -457,7 +457,7 @@ func (httpServer *HttpServer) createCustodianTopupV3(params interface{}, closeCh
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
 
-	if !metadata.IsPortalToken(pTokenId) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -587,7 +587,7 @@ func (httpServer *HttpServer) createTopUpWaitingPortingV3(params interface{}, cl
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PTokenId param is invalid"))
 	}
-	if !metadata.IsPortalToken(pTokenId) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, pTokenId) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata public token is not supported currently"))
 	}
 
@@ -763,7 +763,7 @@ func (httpServer *HttpServer) handleGetTopupAmountForCustodianState(params inter
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PortalTokenID is invalid"))
 	}
-	if !metadata.IsPortalToken(portalTokenID) {
+	if !metadata.IsPortalToken(httpServer.config.BlockChain, httpServer.config.BlockChain.GetBeaconBestState().BeaconHeight, portalTokenID) {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata PortalTokenID is not support"))
 	}
 
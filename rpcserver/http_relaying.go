@@ -3,6 +3,7 @@ package rpcserver
 import (
 	"encoding/json"
 	"errors"
+	"github.com/binance-chain/go-sdk/types/msg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
@@ -30,6 +31,22 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingBNBHeader(params inte
 	)
 }
 
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingETHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingETHHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingLTCHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
 func (httpServer *HttpServer) handleCreateRawTxWithRelayingHeader(
 	metaType int,
 	params interface{},
@@ -133,7 +150,10 @@ func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderError, err)
 	}
-	bnbRelayingHeader := relayingState.BNBHeaderChain
+	if relayingState.BNBHeaderChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderError, errors.New("BNB relaying chain state should not be null"))
+	}
+	bnbRelayingHeader := relayingState.BNBHeaderChain.Snapshot()
 
 	type RelayingBNBHeader struct {
 		LatestBlock     *types.Block             `json:"LatestBlock"`
@@ -169,6 +189,100 @@ func (httpServer *HttpServer) handleGetRelayingBNBHeaderByBlockHeight(params int
 	return block, nil
 }
 
+func (httpServer *HttpServer) handleGetRelayingBNBCandidateForks(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	bnbChainState := httpServer.config.BlockChain.GetBNBChainState()
+	if bnbChainState == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBCandidateForksError, errors.New("BNB relaying chain state should not be null"))
+	}
+	snapshot := bnbChainState.Snapshot()
+	if snapshot.LatestBlock == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBCandidateForksError, errors.New("BNB relaying chain state should not be null"))
+	}
+
+	result := jsonresult.NewRelayingBNBCandidateForksResult(
+		snapshot.FinalizedBlockHeight,
+		snapshot.LatestBlock,
+		snapshot.CandidateNextBlocks,
+		snapshot.OrphanBlocks,
+		snapshot.PendingValidatorsHash,
+		snapshot.ValidatorsHashes,
+	)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleVerifyRelayingBNBProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param array must be at least one"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	proofStr, ok := data["BNBProof"].(string)
+	if !ok || proofStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BNBProof param is invalid"))
+	}
+	proof, err := bnbrelaying.ParseBNBProofFromB64EncodeStr(proofStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	bc := httpServer.config.BlockChain
+	bnbChainState := bc.GetBNBChainState()
+	if bnbChainState == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.VerifyRelayingBNBProofError, errors.New("BNB relaying chain state should not be null"))
+	}
+	snapshot := bnbChainState.Snapshot()
+	if snapshot.LatestBlock == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.VerifyRelayingBNBProofError, errors.New("BNB relaying chain state should not be null"))
+	}
+	portalToken, ok := bc.GetConfig().ChainParams.PortalTokens[common.PortalBNBIDStr]
+	if !ok || portalToken == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.VerifyRelayingBNBProofError, errors.New("BNB portal token is not supported"))
+	}
+
+	result := jsonresult.VerifyRelayingBNBProofResult{
+		BlockHeight:          proof.BlockHeight,
+		LatestBlockHeight:    snapshot.LatestBlock.Height,
+		FinalizedBlockHeight: snapshot.FinalizedBlockHeight,
+		MinConfirmations:     portalToken.GetMinConfirmations(),
+		Outputs:              []*jsonresult.RelayingBNBTxOutput{},
+	}
+	isValid, err2 := bnbChainState.VerifyProof(proof, int64(portalToken.GetMinConfirmations()))
+	if err2 != nil {
+		result.Error = err2.Error()
+		return result, nil
+	}
+	result.IsValid = isValid
+
+	txBNB, err := bnbrelaying.ParseTxFromData(proof.Proof.Data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.VerifyRelayingBNBProofError, err)
+	}
+	result.Memo = txBNB.Memo
+	for _, m := range txBNB.Msgs {
+		sendMsg, ok := m.(msg.SendMsg)
+		if !ok {
+			continue
+		}
+		for _, out := range sendMsg.Outputs {
+			addr, _ := bnbrelaying.GetAccAddressString(&out.Address, bc.GetConfig().ChainParams.BNBRelayingHeaderChainID)
+			amount := int64(0)
+			for _, coin := range out.Coins {
+				if coin.Denom == bnbrelaying.DenomBNB {
+					amount += coin.Amount
+				}
+			}
+			result.Outputs = append(result.Outputs, &jsonresult.RelayingBNBTxOutput{
+				Address: addr,
+				Amount:  amount,
+			})
+		}
+	}
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetBTCRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
 	btcChain := bc.GetConfig().BTCChain
@@ -216,3 +330,74 @@ func (httpServer *HttpServer) handleGetBTCBlockByHash(params interface{}, closeC
 	}
 	return btcBlock.MsgBlock(), nil
 }
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingETHHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingETHHeader(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleGetETHRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	ethChain := httpServer.config.BlockChain.GetETHHeaderChain()
+	if ethChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetETHRelayingBestState, errors.New("ETH relaying chain should not be null"))
+	}
+	type ETHRelayingBestState struct {
+		LatestBlockHeight    uint64 `json:"LatestBlockHeight"`
+		LatestBlockHash      string `json:"LatestBlockHash"`
+		FinalizedBlockHeight uint64 `json:"FinalizedBlockHeight"`
+	}
+	result := ETHRelayingBestState{
+		FinalizedBlockHeight: ethChain.GetFinalizedHeight(),
+	}
+	if latestHeader := ethChain.GetLatestHeader(); latestHeader != nil {
+		result.LatestBlockHeight = latestHeader.Number.Uint64()
+		result.LatestBlockHash = latestHeader.Hash().String()
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingLTCHeader(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleGetLTCRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	ltcChain := httpServer.config.BlockChain.GetConfig().LTCChain
+	if ltcChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCRelayingBestState, errors.New("LTC relaying chain should not be null"))
+	}
+	type LTCRelayingBestState struct {
+		LatestBlockHeight uint64 `json:"LatestBlockHeight"`
+		LatestBlockHash   string `json:"LatestBlockHash"`
+	}
+	result := LTCRelayingBestState{}
+	if latestHeader, height := ltcChain.GetLatestHeader(); latestHeader != nil {
+		result.LatestBlockHeight = height
+		result.LatestBlockHash = latestHeader.BlockHash().String()
+	}
+	return result, nil
+}
@@ -237,6 +237,7 @@ func extractInstsFromShardBlock(
 		bc,
 		shardBlock.Header.ShardID,
 		shardBlock.Header.Height,
+		shardBlock.Header.BeaconHeight,
 		//	&shardBlock.Header.ProducerAddress,
 		//	shardBlock.Header.Height,
 		//	beaconBlocks,
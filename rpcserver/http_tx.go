@@ -34,8 +34,33 @@ func (httpServer *HttpServer) handleCreateRawTransaction(params interface{}, clo
 	return result, nil
 }
 
+// handleCreateUnsignedTransaction handles createunsignedtransaction commands.
+// Parameter #1—payment address of the sender
+// Parameter #2—input coins to spend, as returned by listoutputcoins
+// Parameter #3—receivers with amounts
+// Parameter #4—fee in nano PRV
+// Parameter #5—privacy flag (1 or -1)
+// Parameter #6—info (optional)
+// Parameter #7—ring size (optional)
+// Parameter #8—metadata (optional)
+// Result—a base58 check encoded bundle to sign offline with the signtxbundle command of cmd,
+// the node does not sign bundles so the private key stays on the signing machine,
+// the Base58CheckData of the signed tx is broadcast by sendtransaction
+func (httpServer *HttpServer) handleCreateUnsignedTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	createUnsignedTxParam, errNewParam := bean.NewCreateUnsignedTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	bundle, err := httpServer.txService.CreateUnsignedTransactionBundle(createUnsignedTxParam)
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
 // handleSendTransaction implements the sendtransaction command.
-// Parameter #1—a serialized transaction to broadcast
+// Parameter #1—a serialized transaction to broadcast, as the Base58CheckData of createtransaction or of the signtxbundle command of cmd
 // Parameter #2–whether to allow high fees
 // Result—a TXID or error Message
 func (httpServer *HttpServer) handleSendRawTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
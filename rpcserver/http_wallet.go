@@ -156,6 +156,136 @@ func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan
 	return httpServer.walletService.RemoveAccount(privateKey, passPhrase)
 }
 
+/*
+handleImportWatchOnlyAccount - import an account by payment address and readonly key, its balance can be read but it can not spend
+- Param #1: payment address string
+- Param #2: readonly key string
+- Param #3: account name
+- Param #4: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleImportWatchOnlyAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 4 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("paymentAddress is invalid"))
+	}
+
+	readonlyKey, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("readonlyKey is invalid"))
+	}
+
+	accountName, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	passPhrase, ok := arrayParams[3].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	result, err := httpServer.walletService.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	return result, nil
+}
+
+/*
+handleCreateHDAccount - create an account with the next key of path m/44'/587'/account'/shardID
+- Param #1: account name, a default name is used if empty
+- Param #2: account index
+- Param #3: shard ID
+*/
+func (httpServer *HttpServer) handleCreateHDAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
+	}
+
+	accountName, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	accountIndex, ok := arrayParams[1].(float64)
+	if !ok || accountIndex < 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("account index is invalid"))
+	}
+
+	shardID, ok := arrayParams[2].(float64)
+	if !ok || shardID < 0 || int(shardID) >= common.MaxShardNumber {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
+	}
+
+	return httpServer.walletService.CreateHDAccount(accountName, uint32(accountIndex), byte(shardID))
+}
+
+/*
+handleRecoverHDAccounts - add to the wallet the accounts derived from its mnemonic which received coins,
+every shard branch of an account is scanned until gap limit consecutive keys did not receive any coin
+- Param #1: gap limit, optional, default is 20
+*/
+func (httpServer *HttpServer) handleRecoverHDAccounts(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	gapLimit := 0
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) > 0 {
+		gapLimitParam, ok := arrayParams[0].(float64)
+		if !ok || gapLimitParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("gap limit is invalid"))
+		}
+		gapLimit = int(gapLimitParam)
+	}
+
+	return httpServer.walletService.RecoverHDAccounts(gapLimit)
+}
+
+/*
+handleSetAccountLabel - set the label of an account and merge metadata into its metadata, a metadata key with an empty value is removed
+- Param #1: account name
+- Param #2: label
+- Param #3: metadata, optional, an object of string values
+*/
+func (httpServer *HttpServer) handleSetAccountLabel(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+
+	accountName, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	label, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("label is invalid"))
+	}
+
+	metadata := make(map[string]string)
+	if len(arrayParams) > 2 {
+		metadataParam, ok := arrayParams[2].(map[string]interface{})
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+		}
+		for k, v := range metadataParam {
+			value, ok := v.(string)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("metadata %v is not a string", k))
+			}
+			metadata[k] = value
+		}
+	}
+
+	return httpServer.walletService.SetAccountLabel(accountName, label, metadata)
+}
+
 // handleGetBalanceByPrivatekey -  return balance of private key
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// all component
@@ -1,9 +1,13 @@
 package rpcserver
 
 import (
+	"bytes"
 	"fmt"
+
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rewardindexer"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/pkg/errors"
@@ -103,3 +107,78 @@ func (httpServer *HttpServer) handleListRewardAmount(params interface{}, closeCh
 	}
 	return result, nil
 }
+
+// handleGetRewardHistory - Get the rewards earned in every epoch by a payment address or a public key from the reward index,
+// with the committees served on, the DAO split of the epoch and the withdrawals,
+// payload: PaymentAddress or PublicKey, optional FromEpoch and ToEpoch, optional Format json (default) or csv
+func (httpServer *HttpServer) handleGetRewardHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.RewardIndexer == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRewardHistoryError, errors.New("Reward index is not enabled on this node, run it with --rewardindex"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	// rewards are kept by the base58 check encoded public key of the payment address like getrewardamountbypublickey
+	publicKey := ""
+	if paymentAddressParam, ok := data["PaymentAddress"]; ok {
+		paymentAddress, ok := paymentAddressParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+		}
+		publicKey = base58.Base58Check{}.Encode(keyWallet.KeySet.PaymentAddress.Pk, common.Base58Version)
+	} else {
+		publicKey, ok = data["PublicKey"].(string)
+		if !ok || publicKey == "" {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress or PublicKey is required"))
+		}
+	}
+	// the epochs default to the latest epochs a query can return
+	toEpoch := httpServer.config.BlockChain.GetBeaconBestState().Epoch
+	if toEpochParam, ok := data["ToEpoch"]; ok {
+		toEpochFloat, ok := toEpochParam.(float64)
+		if !ok || toEpochFloat < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("ToEpoch is invalid"))
+		}
+		toEpoch = uint64(toEpochFloat)
+	}
+	fromEpoch := uint64(0)
+	if toEpoch >= rewardindexer.MaxEpochs {
+		fromEpoch = toEpoch - rewardindexer.MaxEpochs + 1
+	}
+	if fromEpochParam, ok := data["FromEpoch"]; ok {
+		fromEpochFloat, ok := fromEpochParam.(float64)
+		if !ok || fromEpochFloat < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("FromEpoch is invalid"))
+		}
+		fromEpoch = uint64(fromEpochFloat)
+	}
+	format := "json"
+	if formatParam, ok := data["Format"]; ok {
+		format, ok = formatParam.(string)
+		if !ok || (format != "json" && format != "csv") {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Format must be json or csv"))
+		}
+	}
+	history, err := httpServer.config.RewardIndexer.GetRewardHistory(publicKey, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetRewardHistoryError, err)
+	}
+	if format == "csv" {
+		buf := &bytes.Buffer{}
+		err := rewardindexer.WriteCSV(buf, history)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.GetRewardHistoryError, err)
+		}
+		return buf.String(), nil
+	}
+	return history, nil
+}
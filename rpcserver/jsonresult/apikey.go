@@ -0,0 +1,25 @@
+package jsonresult
+
+type APIKeyResult struct {
+	Name             string   `json:"Name"`
+	Scopes           []string `json:"Scopes"`
+	Methods          []string `json:"Methods"`
+	DeniedMethods    []string `json:"DeniedMethods"`
+	RequestPerMinute int      `json:"RequestPerMinute"`
+	RequestPerDay    int      `json:"RequestPerDay"`
+	Disabled         bool     `json:"Disabled"`
+	CreatedAt        int64    `json:"CreatedAt"`
+}
+
+func NewAPIKeyResult(name string, scopes, methods, deniedMethods []string, requestPerMinute, requestPerDay int, disabled bool, createdAt int64) APIKeyResult {
+	return APIKeyResult{
+		Name:             name,
+		Scopes:           scopes,
+		Methods:          methods,
+		DeniedMethods:    deniedMethods,
+		RequestPerMinute: requestPerMinute,
+		RequestPerDay:    requestPerDay,
+		Disabled:         disabled,
+		CreatedAt:        createdAt,
+	}
+}
@@ -0,0 +1,28 @@
+package jsonresult
+
+type CustodianTokenRisk struct {
+	PortalTokenID              string `json:"PortalTokenID"`
+	LockedCollateralInUSDT     uint64 `json:"LockedCollateralInUSDT"`
+	HoldingPubTokenAmount      uint64 `json:"HoldingPubTokenAmount"`
+	HoldingPubTokenInUSDT      uint64 `json:"HoldingPubTokenInUSDT"`
+	PubTokenRate               uint64 `json:"PubTokenRate"`
+	Ratio                      uint64 `json:"Ratio"`
+	LiquidationRatio           uint64 `json:"LiquidationRatio"`
+	LiquidationPubTokenRate    uint64 `json:"LiquidationPubTokenRate"`
+	PriceIncreaseToLiquidation uint64 `json:"PriceIncreaseToLiquidation"` // basis points
+	IsLiquidatable             bool   `json:"IsLiquidatable"`
+}
+
+type CustodianRiskReport struct {
+	CustodianAddress string                `json:"CustodianAddress"`
+	BeaconHeight     uint64                `json:"BeaconHeight"`
+	Tokens           []*CustodianTokenRisk `json:"Tokens"`
+}
+
+type CustodianRiskAlert struct {
+	CustodianAddress string              `json:"CustodianAddress"`
+	BeaconHeight     uint64              `json:"BeaconHeight"`
+	Threshold        uint64              `json:"Threshold"`
+	IsBelow          bool                `json:"IsBelow"`
+	Token            *CustodianTokenRisk `json:"Token"`
+}
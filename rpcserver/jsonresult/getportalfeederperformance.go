@@ -0,0 +1,32 @@
+package jsonresult
+
+type PortalFeederTokenRate struct {
+	TokenID      string `json:"TokenID"`
+	Rate         uint64 `json:"Rate"`
+	BeaconHeight uint64 `json:"BeaconHeight"`
+	FinalRate    uint64 `json:"FinalRate"`
+	IsInWindow   bool   `json:"IsInWindow"` // the rate is used for the final rate
+}
+
+type PortalFeederSubmission struct {
+	TxReqID       string            `json:"TxReqID"`
+	BeaconHeight  uint64            `json:"BeaconHeight"`
+	AcceptedRates map[string]uint64 `json:"AcceptedRates"`
+	RejectedRates map[string]uint64 `json:"RejectedRates"`
+}
+
+type PortalFeederPerformance struct {
+	FeederAddress       string                    `json:"FeederAddress"`
+	IsAuthorized        bool                      `json:"IsAuthorized"`
+	TotalSubmissions    uint64                    `json:"TotalSubmissions"`
+	TotalAcceptedRates  uint64                    `json:"TotalAcceptedRates"`
+	TotalRejectedRates  uint64                    `json:"TotalRejectedRates"`
+	RejectedRatePercent float64                   `json:"RejectedRatePercent"`
+	LatestRates         []*PortalFeederTokenRate  `json:"LatestRates"`
+	Submissions         []*PortalFeederSubmission `json:"Submissions"`
+}
+
+type PortalFeederPerformanceResult struct {
+	BeaconHeight uint64                     `json:"BeaconHeight"`
+	Feeders      []*PortalFeederPerformance `json:"Feeders"`
+}
@@ -1,6 +1,18 @@
 package jsonresult
 
 type ListAccounts struct {
-	WalletName string            `json:"WalletName"`
-	Accounts   map[string]uint64 `json:"Accounts"`
+	WalletName string                   `json:"WalletName"`
+	Accounts   map[string]uint64        `json:"Accounts"`
+	Details    map[string]AccountDetail `json:"Details"`
+}
+
+type AccountDetail struct {
+	PaymentAddress string            `json:"PaymentAddress"`
+	ReadonlyKey    string            `json:"ReadonlyKey"`
+	ShardID        byte              `json:"ShardID"`
+	Path           string            `json:"Path,omitempty"`
+	IsImported     bool              `json:"IsImported"`
+	IsWatchOnly    bool              `json:"IsWatchOnly"`
+	Label          string            `json:"Label,omitempty"`
+	Metadata       map[string]string `json:"Metadata,omitempty"`
 }
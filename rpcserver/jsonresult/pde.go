@@ -7,5 +7,29 @@ type CurrentPDEState struct {
 	PDEPoolPairs            map[string]*rawdbv2.PDEPoolForPair  `json:"PDEPoolPairs"`
 	PDEShares               map[string]uint64                   `json:"PDEShares"`
 	PDETradingFees          map[string]uint64                   `json:"PDETradingFees"`
+	PDELimitOrders          map[string]*rawdbv2.PDELimitOrder   `json:"PDELimitOrders"`
 	BeaconTimeStamp         int64                               `json:"BeaconTimeStamp"`
 }
+
+type PDETradeQuoteHop struct {
+	PoolPairKey      string `json:"PoolPairKey"`
+	TokenIDToSellStr string `json:"TokenIDToSellStr"`
+	TokenIDToBuyStr  string `json:"TokenIDToBuyStr"`
+	SellAmount       uint64 `json:"SellAmount"`
+	ReceiveAmount    uint64 `json:"ReceiveAmount"`
+	AddingFee        uint64 `json:"AddingFee"`
+}
+
+type PDETradeQuote struct {
+	BeaconHeight        uint64              `json:"BeaconHeight"`
+	TokenIDToSellStr    string              `json:"TokenIDToSellStr"`
+	TokenIDToBuyStr     string              `json:"TokenIDToBuyStr"`
+	SellAmount          uint64              `json:"SellAmount"`
+	ReceiveAmount       uint64              `json:"ReceiveAmount"`
+	SpotReceiveAmount   uint64              `json:"SpotReceiveAmount"`
+	TradingFee          uint64              `json:"TradingFee"`
+	PriceImpact         float64             `json:"PriceImpact"`
+	Slippage            float64             `json:"Slippage"`
+	MinAcceptableAmount uint64              `json:"MinAcceptableAmount"`
+	Pools               []*PDETradeQuoteHop `json:"Pools"`
+}
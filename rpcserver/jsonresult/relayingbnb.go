@@ -0,0 +1,81 @@
+package jsonresult
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+type RelayingBNBBlockInfo struct {
+	Height             int64  `json:"Height"`
+	Hash               string `json:"Hash"`
+	LastBlockHash      string `json:"LastBlockHash"`
+	ValidatorsHash     string `json:"ValidatorsHash"`
+	NextValidatorsHash string `json:"NextValidatorsHash"`
+}
+
+func NewRelayingBNBBlockInfo(block *types.Block) *RelayingBNBBlockInfo {
+	if block == nil {
+		return nil
+	}
+	return &RelayingBNBBlockInfo{
+		Height:             block.Height,
+		Hash:               block.Hash().String(),
+		LastBlockHash:      block.LastBlockID.Hash.String(),
+		ValidatorsHash:     block.ValidatorsHash.String(),
+		NextValidatorsHash: block.NextValidatorsHash.String(),
+	}
+}
+
+type RelayingBNBCandidateForksResult struct {
+	FinalizedBlockHeight  int64                             `json:"FinalizedBlockHeight"`
+	LatestBlock           *RelayingBNBBlockInfo             `json:"LatestBlock"`
+	CandidateBlocks       []*RelayingBNBBlockInfo           `json:"CandidateBlocks"`
+	OrphanBlocks          map[int64][]*RelayingBNBBlockInfo `json:"OrphanBlocks"`
+	PendingValidatorsHash string                            `json:"PendingValidatorsHash"` // validator set of the next bnb epoch
+	KnownValidatorSets    []string                          `json:"KnownValidatorSets"`
+}
+
+func NewRelayingBNBCandidateForksResult(
+	finalizedBlockHeight int64,
+	latestBlock *types.Block,
+	candidateBlocks []*types.Block,
+	orphanBlocks map[int64][]*types.Block,
+	pendingValidatorsHash []byte,
+	knownValidatorSets []string,
+) *RelayingBNBCandidateForksResult {
+	result := &RelayingBNBCandidateForksResult{
+		FinalizedBlockHeight:  finalizedBlockHeight,
+		LatestBlock:           NewRelayingBNBBlockInfo(latestBlock),
+		CandidateBlocks:       []*RelayingBNBBlockInfo{},
+		OrphanBlocks:          map[int64][]*RelayingBNBBlockInfo{},
+		PendingValidatorsHash: strings.ToUpper(hex.EncodeToString(pendingValidatorsHash)),
+		KnownValidatorSets:    knownValidatorSets,
+	}
+	for _, block := range candidateBlocks {
+		result.CandidateBlocks = append(result.CandidateBlocks, NewRelayingBNBBlockInfo(block))
+	}
+	for height, blocks := range orphanBlocks {
+		for _, block := range blocks {
+			result.OrphanBlocks[height] = append(result.OrphanBlocks[height], NewRelayingBNBBlockInfo(block))
+		}
+	}
+	return result
+}
+
+type RelayingBNBTxOutput struct {
+	Address string `json:"Address"`
+	Amount  int64  `json:"Amount"` // amount of BNB in nano
+}
+
+type VerifyRelayingBNBProofResult struct {
+	IsValid              bool                   `json:"IsValid"`
+	Error                string                 `json:"Error"`
+	BlockHeight          int64                  `json:"BlockHeight"`
+	LatestBlockHeight    int64                  `json:"LatestBlockHeight"`
+	FinalizedBlockHeight int64                  `json:"FinalizedBlockHeight"`
+	MinConfirmations     uint64                 `json:"MinConfirmations"`
+	Memo                 string                 `json:"Memo"`
+	Outputs              []*RelayingBNBTxOutput `json:"Outputs"`
+}
@@ -67,6 +67,7 @@ var HttpHandler = map[string]httpHandler{
 	listOutputCoins:                           (*HttpServer).handleListOutputCoins,
 	createRawTransaction:                      (*HttpServer).handleCreateRawTransaction,
 	sendRawTransaction:                        (*HttpServer).handleSendRawTransaction,
+	createUnsignedTransaction:                 (*HttpServer).handleCreateUnsignedTransaction,
 	createAndSendTransaction:                  (*HttpServer).handleCreateAndSendTx,
 	createAndSendTransactionV2:                (*HttpServer).handleCreateAndSendTxV2,
 	getTransactionByHash:                      (*HttpServer).handleGetTransactionByHash,
@@ -160,6 +161,7 @@ var HttpHandler = map[string]httpHandler{
 	getRewardAmount:              (*HttpServer).handleGetRewardAmount,
 	getRewardAmountByPublicKey:   (*HttpServer).handleGetRewardAmountByPublicKey,
 	listRewardAmount:             (*HttpServer).handleListRewardAmount,
+	getRewardHistory:             (*HttpServer).handleGetRewardHistory,
 
 	// mining info
 	getMiningInfo:               (*HttpServer).handleGetMiningInfo,
@@ -193,6 +195,16 @@ var HttpHandler = map[string]httpHandler{
 	getPDEFeeWithdrawalStatus:                  (*HttpServer).handleGetPDEFeeWithdrawalStatus,
 	convertPDEPrices:                           (*HttpServer).handleConvertPDEPrices,
 	extractPDEInstsFromBeaconBlock:             (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
+	getPDETradeQuote:                           (*HttpServer).handleGetPDETradeQuote,
+	getPDECandles:                              (*HttpServer).handleGetPDECandles,
+	getPDEPairVolume:                           (*HttpServer).handleGetPDEPairVolume,
+	getPDELPFeeHistory:                         (*HttpServer).handleGetPDELPFeeHistory,
+	getPDELiquidityHistory:                     (*HttpServer).handleGetPDELiquidityHistory,
+	createAndSendTxWithPRVLimitOrderReq:        (*HttpServer).handleCreateAndSendTxWithPRVLimitOrderReq,
+	createAndSendTxWithPTokenLimitOrderReq:     (*HttpServer).handleCreateAndSendTxWithPTokenLimitOrderReq,
+	createAndSendTxWithCancelLimitOrderReq:     (*HttpServer).handleCreateAndSendTxWithCancelLimitOrderReq,
+	getPDELimitOrderStatus:                     (*HttpServer).handleGetPDELimitOrderStatus,
+	getPDECancelLimitOrderStatus:               (*HttpServer).handleGetPDECancelLimitOrderStatus,
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
@@ -204,6 +216,7 @@ var HttpHandler = map[string]httpHandler{
 	createAndSendTxWithReqPToken:                  (*HttpServer).handleCreateAndSendTxWithReqPToken,
 	createAndSendPortalExchangeRates:              (*HttpServer).handleCreateAndSendTxWithPortalExchangeRate,
 	getPortalFinalExchangeRates:                   (*HttpServer).handleGetPortalFinalExchangeRates,
+	getPortalFeederPerformance:                    (*HttpServer).handleGetPortalFeederPerformance,
 	getPortalPortingRequestByKey:                  (*HttpServer).handleGetPortingRequestStatusByTxID,
 	getPortalPortingRequestByPortingId:            (*HttpServer).handleGetPortingRequestStatusByPortingId,
 	convertExchangeRates:                          (*HttpServer).handleConvertExchangeRates,
@@ -244,6 +257,7 @@ var HttpHandler = map[string]httpHandler{
 	getPortalWithdrawCollateralProof:              (*HttpServer).handleGetPortalWithdrawCollateralProof,
 	createAndSendUnlockOverRateCollaterals:        (*HttpServer).handleCreateAndSendTxWithPortalCusUnlockOverRateCollaterals,
 	getPortalUnlockOverRateCollateralsStatus:      (*HttpServer).handleGetPortalReqUnlockOverRateCollateralStatus,
+	getCustodianRiskReport:                        (*HttpServer).handleGetCustodianRiskReport,
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
@@ -253,6 +267,12 @@ var HttpHandler = map[string]httpHandler{
 	getBTCRelayingBestState:              (*HttpServer).handleGetBTCRelayingBestState,
 	getBTCBlockByHash:                    (*HttpServer).handleGetBTCBlockByHash,
 	getLatestBNBHeaderBlockHeight:        (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
+	createAndSendTxWithRelayingETHHeader: (*HttpServer).handleCreateAndSendTxWithRelayingETHHeader,
+	getETHRelayingBestState:              (*HttpServer).handleGetETHRelayingBestState,
+	createAndSendTxWithRelayingLTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingLTCHeader,
+	getLTCRelayingBestState:              (*HttpServer).handleGetLTCRelayingBestState,
+	getRelayingBNBCandidateForks:         (*HttpServer).handleGetRelayingBNBCandidateForks,
+	verifyRelayingBNBProof:               (*HttpServer).handleVerifyRelayingBNBProof,
 
 	// incognnito mode for sc
 	getBurnProofForDepositToSC:                  (*HttpServer).handleGetBurnProofForDepositToSC,
@@ -274,7 +294,8 @@ var HttpHandler = map[string]httpHandler{
 	getTotalStaker: (*HttpServer).handleGetTotalStaker,
 
 	//validators state
-	getValKeyState: (*HttpServer).handleGetValKeyState,
+	getValKeyState:     (*HttpServer).handleGetValKeyState,
+	getValidatorStatus: (*HttpServer).handleGetValidatorStatus,
 }
 
 // Commands that are available to a limited user
@@ -287,6 +308,10 @@ var LimitedHttpHandler = map[string]httpHandler{
 	dumpPrivkey:                      (*HttpServer).handleDumpPrivkey,
 	importAccount:                    (*HttpServer).handleImportAccount,
 	removeAccount:                    (*HttpServer).handleRemoveAccount,
+	importWatchOnlyAccount:           (*HttpServer).handleImportWatchOnlyAccount,
+	createHDAccount:                  (*HttpServer).handleCreateHDAccount,
+	recoverHDAccounts:                (*HttpServer).handleRecoverHDAccounts,
+	setAccountLabel:                  (*HttpServer).handleSetAccountLabel,
 	listUnspentOutputCoins:           (*HttpServer).handleListUnspentOutputCoins,
 	getBalance:                       (*HttpServer).handleGetBalance,
 	getBalanceByPrivatekey:           (*HttpServer).handleGetBalanceByPrivatekey,
@@ -297,6 +322,15 @@ var LimitedHttpHandler = map[string]httpHandler{
 	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
 }
 
+// Commands that are available to the admin user and api keys of the admin scope only
+var AdminHttpHandler = map[string]httpHandler{
+	// api key management
+	listAPIKeys:   (*HttpServer).handleListAPIKeys,
+	addAPIKey:     (*HttpServer).handleAddAPIKey,
+	removeAPIKey:  (*HttpServer).handleRemoveAPIKey,
+	reloadAPIKeys: (*HttpServer).handleReloadAPIKeys,
+}
+
 var WsHandler = map[string]wsHandler{
 	testSubcrice:                                (*WsServer).handleTestSubcribe,
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
@@ -315,4 +349,6 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeCustodianRisk:                       (*WsServer).handleSubcribeCustodianRisk,
+	subcribeValidatorStatus:                     (*WsServer).handleSubcribeValidatorStatus,
 }
@@ -1,6 +1,7 @@
 package rpcserver
 
 import (
+	"fmt"
 	"net"
 	"net/http"
 	"sync"
@@ -15,9 +16,12 @@ import (
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/netsync"
+	"github.com/incognitochain/incognito-chain/pdeindexer"
 	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rewardindexer"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/validatorindexer"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 	peer2 "github.com/libp2p/go-libp2p-peer"
@@ -106,23 +110,46 @@ type RpcServerConfig struct {
 	RPCLimitUser string
 	RPCLimitPass string
 	DisableAuth  bool
+	// API keys with scoped permissions, managed through a reloadable file
+	RPCAPIKeyFile   string
+	RPCAuditLogFile string
 	// The fee estimator keeps track of how long transactions are left in
 	// the mempool before they are mined into blocks.
 	FeeEstimator map[byte]*mempool.FeeEstimator
 	// IsMiningNode    bool   // flag mining node. True: mining, False: not mining
 	MiningKeys    string // encode of mining key
 	PubSubManager *pubsub.PubSubManager
+	// PDEIndexer is nil if the pde index is disabled
+	PDEIndexer *pdeindexer.Indexer
+	// ValidatorIndexer is nil if the validator index is disabled
+	ValidatorIndexer *validatorindexer.Indexer
+	// RewardIndexer is nil if the reward index is disabled
+	RewardIndexer *rewardindexer.Indexer
 }
 
-func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
+func (rpcServer *RpcServer) Init(config *RpcServerConfig) error {
 	if len(config.HttpListenters) > 0 {
 		rpcServer.HttpServer = &HttpServer{}
-		rpcServer.HttpServer.Init(config)
+		err := rpcServer.HttpServer.Init(config)
+		if err != nil {
+			return err
+		}
 	}
 	if len(config.WsListenters) > 0 {
 		rpcServer.WsServer = &WsServer{}
 		rpcServer.WsServer.Init(config)
+		if config.RPCAPIKeyFile != "" {
+			if rpcServer.HttpServer != nil {
+				rpcServer.WsServer.apiKeyStore = rpcServer.HttpServer.apiKeyStore
+			} else {
+				rpcServer.WsServer.apiKeyStore = NewAPIKeyStore(config.RPCAPIKeyFile)
+				if err := rpcServer.WsServer.apiKeyStore.Load(); err != nil {
+					return fmt.Errorf("can not load rpc api keys: %v", err)
+				}
+			}
+		}
 	}
+	return nil
 }
 func (rpcServer *RpcServer) Start() {
 	if rpcServer.WsServer != nil {
@@ -50,6 +50,8 @@ const (
 	NoSwapConfirmInst
 	GetKeySetFromPrivateKeyError
 	GetPDEStateError
+	GetPDETradeQuoteError
+	GetPDEAnalyticsError
 	ListCommitteeRewardError
 	GetRewardAmountError
 	ListOutputCoinsByKeyError
@@ -101,6 +103,8 @@ const (
 	GetCustodianTopupWaitingPortingStatusError
 	GetAmountTopUpWaitingPortingError
 	GetCustodianDepositV3Error
+	GetCustodianRiskReportError
+	GetPortalFeederPerformanceError
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError
@@ -108,6 +112,10 @@ const (
 	GetBTCBlockByHash
 	GetRelayingBNBHeaderError
 	GetLatestBNBHeaderBlockHeightError
+	GetETHRelayingBestState
+	GetLTCRelayingBestState
+	GetRelayingBNBCandidateForksError
+	VerifyRelayingBNBProofError
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError
@@ -128,6 +136,12 @@ const (
 	RestoreCandidateShardWaitingForNextRandom
 
 	GetTotalStakerError
+	GetValidatorStatusError
+	GetRewardHistoryError
+
+	// api key
+	APIKeyNotEnabledError
+	UpdateAPIKeyError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -208,7 +222,9 @@ var ErrCodeMessage = map[int]struct {
 	NoSwapConfirmInst: {-7000, "No swap confirm instruction found in block"},
 
 	// pde
-	GetPDEStateError: {-8000, "Get pde state error"},
+	GetPDEStateError:      {-8000, "Get pde state error"},
+	GetPDETradeQuoteError: {-8001, "Get pde trade quote error"},
+	GetPDEAnalyticsError:  {-8002, "Get pde analytics error"},
 
 	//portal
 	GetFinalExchangeRatesError:                         {-9000, "Get get final exchange rates error"},
@@ -231,6 +247,8 @@ var ErrCodeMessage = map[int]struct {
 	GetAmountTopUpWaitingPortingError:                  {-9017, "Get amount top up for waiting porting error"},
 	GetReqRedeemFromLiquidationPoolStatusError:         {-9018, "Get redeem request from liquidation pool status error"},
 	GetCustodianDepositV3Error:                         {-9019, "Get custodian deposit v3 status error"},
+	GetCustodianRiskReportError:                        {-9020, "Get custodian risk report error"},
+	GetPortalFeederPerformanceError:                    {-9021, "Get portal feeder performance error"},
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError: {-10001, "Get relaying bnb header by block height error"},
@@ -238,6 +256,10 @@ var ErrCodeMessage = map[int]struct {
 	GetBTCRelayingBestState:                {-10003, "Get BTC relaying best state error"},
 	GetLatestBNBHeaderBlockHeightError:     {-10004, "Get latest bnb header block height error"},
 	GetBTCBlockByHash:                      {-10005, "Get BTC block by hash error"},
+	GetETHRelayingBestState:                {-10006, "Get ETH relaying best state error"},
+	GetLTCRelayingBestState:                {-10007, "Get LTC relaying best state error"},
+	GetRelayingBNBCandidateForksError:      {-10008, "Get relaying bnb candidate forks error"},
+	VerifyRelayingBNBProofError:            {-10009, "Verify relaying bnb proof error"},
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError: {-11001, "Get feature reward by feature name error"},
@@ -256,6 +278,12 @@ var ErrCodeMessage = map[int]struct {
 	RestoreCandidateShardWaitingForNextRandom:     {-12008, "Restore candidate shard waiting for next random"},
 	GetAllBeaconViews:                             {-12009, "Get all beacon views"},
 	GetTotalStakerError:                           {-12010, "Get total staker return error"},
+	GetValidatorStatusError:                       {-12011, "Get validator status error"},
+	GetRewardHistoryError:                         {-12012, "Get reward history error"},
+
+	// api key -13xxx
+	APIKeyNotEnabledError: {-13000, "Api key authentication is not enabled"},
+	UpdateAPIKeyError:     {-13001, "Update api key error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
@@ -2,6 +2,8 @@ package rpcservice
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
@@ -187,3 +189,142 @@ func (s *PortalService) GetWithdrawCollateralConfirm(txID common.Hash) (uint64,
 	stateDB := s.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
 	return statedb.GetWithdrawCollateralConfirmProof(stateDB, txID)
 }
+
+func (s *PortalService) GetCustodianRiskReport(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	custodianAddress string,
+	portalParam blockchain.PortalParams) (jsonresult.CustodianRiskReport, error) {
+	currentPortalState, err := blockchain.InitCurrentPortalStateFromDB(stateDB)
+	if err != nil {
+		return jsonresult.CustodianRiskReport{}, err
+	}
+	return s.BuildCustodianRiskReport(currentPortalState, beaconHeight, custodianAddress, portalParam)
+}
+
+// BuildCustodianRiskReport builds the risk report of a custodian from a portal state loaded by the caller,
+// the state is only read so it can be shared by many reports
+func (s *PortalService) BuildCustodianRiskReport(
+	currentPortalState *blockchain.CurrentPortalState,
+	beaconHeight uint64,
+	custodianAddress string,
+	portalParam blockchain.PortalParams) (jsonresult.CustodianRiskReport, error) {
+	custodianKey := statedb.GenerateCustodianStateObjectKey(custodianAddress).String()
+	custodian, ok := currentPortalState.CustodianPoolState[custodianKey]
+	if !ok || custodian == nil {
+		return jsonresult.CustodianRiskReport{}, fmt.Errorf("Custodian %v not found", custodianAddress)
+	}
+
+	isPortalV3 := beaconHeight >= s.BlockChain.GetBCHeightBreakPointPortalV3()
+	tokenRisks, err := blockchain.CalCustodianRisk(currentPortalState, custodian, portalParam, isPortalV3)
+	if err != nil {
+		return jsonresult.CustodianRiskReport{}, err
+	}
+	result := jsonresult.CustodianRiskReport{
+		CustodianAddress: custodianAddress,
+		BeaconHeight:     beaconHeight,
+		Tokens:           make([]*jsonresult.CustodianTokenRisk, 0, len(tokenRisks)),
+	}
+	for _, risk := range tokenRisks {
+		result.Tokens = append(result.Tokens, &jsonresult.CustodianTokenRisk{
+			PortalTokenID:              risk.PortalTokenID,
+			LockedCollateralInUSDT:     risk.LockedCollateralInUSDT,
+			HoldingPubTokenAmount:      risk.HoldingPubTokenAmount,
+			HoldingPubTokenInUSDT:      risk.HoldingPubTokenInUSDT,
+			PubTokenRate:               risk.PubTokenRate,
+			Ratio:                      risk.Ratio,
+			LiquidationRatio:           risk.LiquidationRatio,
+			LiquidationPubTokenRate:    risk.LiquidationPubTokenRate,
+			PriceIncreaseToLiquidation: risk.PriceIncreaseToLiquidation,
+			IsLiquidatable:             risk.IsLiquidatable,
+		})
+	}
+	return result, nil
+}
+
+// GetPortalFeederPerformance returns the latest rates and the submission history of the feeders,
+// the authorized feeders are listed even if they have not submitted any rates yet
+func (s *PortalService) GetPortalFeederPerformance(
+	stateDB *statedb.StateDB,
+	beaconHeight uint64,
+	feederAddress string,
+	authorizedFeeders []string,
+	portalParam blockchain.PortalParams) (jsonresult.PortalFeederPerformanceResult, error) {
+	feederStates, err := statedb.GetPortalFeederStates(stateDB)
+	if err != nil {
+		return jsonresult.PortalFeederPerformanceResult{}, err
+	}
+	finalExchangeRates, err := statedb.GetFinalExchangeRatesState(stateDB)
+	if err != nil {
+		return jsonresult.PortalFeederPerformanceResult{}, err
+	}
+	for _, address := range authorizedFeeders {
+		if _, ok := feederStates[address]; !ok {
+			feederStates[address] = statedb.NewPortalFeederStateWithValue(address, nil, 0, 0, 0)
+		}
+	}
+	if feederAddress != "" {
+		feederState, ok := feederStates[feederAddress]
+		if !ok {
+			return jsonresult.PortalFeederPerformanceResult{}, fmt.Errorf("Feeder %v not found", feederAddress)
+		}
+		feederStates = map[string]*statedb.PortalFeederState{feederAddress: feederState}
+	}
+
+	addresses := make([]string, 0, len(feederStates))
+	for address := range feederStates {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	result := jsonresult.PortalFeederPerformanceResult{
+		BeaconHeight: beaconHeight,
+		Feeders:      make([]*jsonresult.PortalFeederPerformance, 0, len(addresses)),
+	}
+	for _, address := range addresses {
+		feederState := feederStates[address]
+		performance := &jsonresult.PortalFeederPerformance{
+			FeederAddress:      address,
+			IsAuthorized:       common.IndexOfStr(address, authorizedFeeders) != -1,
+			TotalSubmissions:   feederState.TotalSubmissions(),
+			TotalAcceptedRates: feederState.TotalAcceptedRates(),
+			TotalRejectedRates: feederState.TotalRejectedRates(),
+			LatestRates:        make([]*jsonresult.PortalFeederTokenRate, 0),
+			Submissions:        make([]*jsonresult.PortalFeederSubmission, 0),
+		}
+		totalRates := performance.TotalAcceptedRates + performance.TotalRejectedRates
+		if totalRates > 0 {
+			performance.RejectedRatePercent = float64(performance.TotalRejectedRates) * 100 / float64(totalRates)
+		}
+
+		tokenIDs := make([]string, 0, len(feederState.LatestRates()))
+		for tokenID := range feederState.LatestRates() {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+		sort.Strings(tokenIDs)
+		for _, tokenID := range tokenIDs {
+			latestRate := feederState.LatestRates()[tokenID]
+			performance.LatestRates = append(performance.LatestRates, &jsonresult.PortalFeederTokenRate{
+				TokenID:      tokenID,
+				Rate:         latestRate.Rate,
+				BeaconHeight: latestRate.BeaconHeight,
+				FinalRate:    finalExchangeRates.Rates()[tokenID].Amount,
+				IsInWindow:   performance.IsAuthorized && latestRate.BeaconHeight+portalParam.ExchangeRatesWindow >= beaconHeight,
+			})
+		}
+		submissions, err := blockchain.GetPortalFeederSubmissions(stateDB, feederState)
+		if err != nil {
+			return jsonresult.PortalFeederPerformanceResult{}, err
+		}
+		for _, submission := range submissions {
+			performance.Submissions = append(performance.Submissions, &jsonresult.PortalFeederSubmission{
+				TxReqID:       submission.TxReqID.String(),
+				BeaconHeight:  submission.BeaconHeight,
+				AcceptedRates: submission.AcceptedRates,
+				RejectedRates: submission.RejectedRates,
+			})
+		}
+		result.Feeders = append(result.Feeders, performance)
+	}
+	return result, nil
+}
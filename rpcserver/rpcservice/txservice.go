@@ -204,6 +204,22 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	isGetFeePToken bool,
 	unitFeePToken int64,
+) ([]*privacy.InputCoin, uint64, *RPCError) {
+	return txService.chooseOutsCoinByKeysetWithRingSize(paymentInfos, unitFeeNativeToken, numBlock, keySet, shardIDSender, hasPrivacy,
+		metadataParam, privacyCustomTokenParams, isGetFeePToken, unitFeePToken, 0)
+}
+
+// chooseOutsCoinByKeysetWithRingSize works as chooseOutsCoinByKeyset for a tx whose input coins are hidden among ringSize commitments,
+// the fee covers the larger proof of a larger ring size
+func (txService TxService) chooseOutsCoinByKeysetWithRingSize(
+	paymentInfos []*privacy.PaymentInfo,
+	unitFeeNativeToken int64, numBlock uint64, keySet *incognitokey.KeySet, shardIDSender byte,
+	hasPrivacy bool,
+	metadataParam metadata.Metadata,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	isGetFeePToken bool,
+	unitFeePToken int64,
+	ringSize int,
 ) ([]*privacy.InputCoin, uint64, *RPCError) {
 	// estimate fee according to 8 recent block
 	if numBlock == 0 {
@@ -233,10 +249,10 @@ func (txService TxService) chooseOutsCoinByKeyset(
 
 	// check real fee(nano PRV) per tx
 	beaconHeight := txService.BlockChain.GetBeaconBestState().BestBlock.GetHeight()
-	realFee, _, _, err := txService.EstimateFee(unitFeeNativeToken, false, candidateOutputCoins,
+	realFee, _, _, err := txService.estimateFeeWithRingSize(unitFeeNativeToken, false, candidateOutputCoins,
 		paymentInfos, shardIDSender, numBlock, hasPrivacy,
 		metadataParam,
-		privacyCustomTokenParams, int64(beaconHeight))
+		privacyCustomTokenParams, int64(beaconHeight), ringSize)
 	if err != nil {
 		return nil, 0, NewRPCError(RejectInvalidTxFeeError, err)
 	}
@@ -325,6 +341,20 @@ func (txService TxService) EstimateFee(
 	metadata metadata.Metadata,
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	beaconHeight int64) (uint64, uint64, uint64, error) {
+	return txService.estimateFeeWithRingSize(defaultFee, isGetPTokenFee, candidateOutputCoins, paymentInfos, shardID, numBlock, hasPrivacy,
+		metadata, privacyCustomTokenParams, beaconHeight, 0)
+}
+
+func (txService TxService) estimateFeeWithRingSize(
+	defaultFee int64,
+	isGetPTokenFee bool,
+	candidateOutputCoins []*privacy.OutputCoin,
+	paymentInfos []*privacy.PaymentInfo, shardID byte,
+	numBlock uint64, hasPrivacy bool,
+	metadata metadata.Metadata,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	beaconHeight int64,
+	ringSize int) (uint64, uint64, uint64, error) {
 	if numBlock == 0 {
 		numBlock = 1000
 	}
@@ -351,7 +381,9 @@ func (txService TxService) EstimateFee(
 	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFeeForNativeToken()
 	}
-	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(len(candidateOutputCoins), len(paymentInfos), hasPrivacy, metadata, privacyCustomTokenParams, limitFee))
+	estimateTxSizeParam := transaction.NewEstimateTxSizeParam(len(candidateOutputCoins), len(paymentInfos), hasPrivacy, metadata, privacyCustomTokenParams, limitFee)
+	estimateTxSizeParam.SetRingSize(ringSize)
+	estimateTxSizeInKb = transaction.EstimateTxSize(estimateTxSizeParam)
 	realFee = uint64(estimateFeeCoinPerKb) * uint64(estimateTxSizeInKb)
 	return realFee, estimateFeeCoinPerKb, estimateTxSizeInKb, nil
 }
@@ -406,27 +438,28 @@ func (txService TxService) EstimateFeeWithEstimator(defaultFee int64, shardID by
 
 func (txService TxService) BuildRawTransaction(params *bean.CreateRawTxParam, meta metadata.Metadata) (*transaction.Tx, *RPCError) {
 	// get output coins to spend and real fee
-	inputCoins, realFee, err1 := txService.chooseOutsCoinByKeyset(
+	inputCoins, realFee, err1 := txService.chooseOutsCoinByKeysetWithRingSize(
 		params.PaymentInfos, params.EstimateFeeCoinPerKb, 0,
 		params.SenderKeySet, params.ShardIDSender, params.HasPrivacyCoin,
-		meta, nil, false, int64(0))
+		meta, nil, false, int64(0), params.RingSize)
 	if err1 != nil {
 		return nil, err1
 	}
 	// init tx
 	tx := transaction.Tx{}
-	err := tx.Init(
-		transaction.NewTxPrivacyInitParams(
-			&params.SenderKeySet.PrivateKey,
-			params.PaymentInfos,
-			inputCoins,
-			realFee,
-			params.HasPrivacyCoin,
-			txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
-			nil, // use for prv coin -> nil is valid
-			meta,
-			params.Info,
-		))
+	initParams := transaction.NewTxPrivacyInitParams(
+		&params.SenderKeySet.PrivateKey,
+		params.PaymentInfos,
+		inputCoins,
+		realFee,
+		params.HasPrivacyCoin,
+		txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB(),
+		nil, // use for prv coin -> nil is valid
+		meta,
+		params.Info,
+	)
+	initParams.SetRingSize(params.RingSize)
+	err := tx.Init(initParams)
 	if err != nil {
 		return nil, NewRPCError(CreateTxDataError, err)
 	}
@@ -449,6 +482,53 @@ func (txService TxService) CreateRawTransaction(params *bean.CreateRawTxParam, m
 	return tx.Hash(), txBytes, txShardID, nil
 }
 
+// CreateUnsignedTransactionBundle picks decoys and output snds for the given input coins and returns
+// the base58 check encoded bundle, which is signed offline by the signtxbundle command of cmd.
+// The input coins must carry the serial numbers derived offline by the deriveserialnumbers command,
+// they are checked against the spent serial numbers of the sender shard
+func (txService TxService) CreateUnsignedTransactionBundle(params *bean.CreateUnsignedTxParam) (string, *RPCError) {
+	transactionStateDB := txService.BlockChain.GetBestStateShard(params.ShardIDSender).GetCopiedTransactionStateDB()
+	for i, inputCoin := range params.InputCoins {
+		if inputCoin.CoinDetails.GetSerialNumber() == nil {
+			return "", NewRPCError(RPCInvalidParamsError, fmt.Errorf("serial number of input coin %d is missing, derive it offline with the deriveserialnumbers command", i))
+		}
+		spent, err := statedb.HasSerialNumber(transactionStateDB, common.PRVCoinID, inputCoin.CoinDetails.GetSerialNumber().ToBytesS(), params.ShardIDSender)
+		if err != nil {
+			return "", NewRPCError(CreateTxDataError, err)
+		}
+		if spent {
+			return "", NewRPCError(CreateTxDataError, fmt.Errorf("input coin %d has been spent", i))
+		}
+	}
+	var meta metadata.Metadata
+	if params.Metadata != nil {
+		var err error
+		meta, err = metadata.ParseMetadata(params.Metadata)
+		if err != nil {
+			return "", NewRPCError(RPCInvalidParamsError, err)
+		}
+	}
+	bundle, err := transaction.NewUnsignedTxBundle(
+		params.SenderPaymentAddress,
+		params.InputCoins,
+		params.PaymentInfos,
+		params.Fee,
+		params.HasPrivacyCoin,
+		params.RingSize,
+		transactionStateDB,
+		meta,
+		params.Info,
+	)
+	if err != nil {
+		return "", NewRPCError(CreateTxDataError, err)
+	}
+	bundleStr, err := transaction.EncodeUnsignedTxBundle(bundle)
+	if err != nil {
+		return "", NewRPCError(CreateTxDataError, err)
+	}
+	return bundleStr, nil
+}
+
 func (txService TxService) SendRawTransaction(txB58Check string) (wire.Message, *common.Hash, byte, *RPCError) {
 	// Decode base58check data of tx
 	rawTxBytes, _, err := base58.Base58Check{}.Decode(txB58Check)
@@ -3,10 +3,13 @@ package rpcservice
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/rand"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
@@ -19,6 +22,7 @@ type WalletService struct {
 func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPCError) {
 	result := jsonresult.ListAccounts{
 		Accounts:   make(map[string]uint64),
+		Details:    make(map[string]jsonresult.AccountDetail),
 		WalletName: walletService.Wallet.Name,
 	}
 	accounts := walletService.Wallet.ListAccounts()
@@ -39,6 +43,16 @@ func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPC
 			amount += out.CoinDetails.GetValue()
 		}
 		result.Accounts[accountName] = amount
+		result.Details[accountName] = jsonresult.AccountDetail{
+			PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+			ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+			ShardID:        shardIDSender,
+			Path:           account.Path,
+			IsImported:     account.IsImported,
+			IsWatchOnly:    account.IsWatchOnly,
+			Label:          account.Label,
+			Metadata:       account.Metadata,
+		}
 	}
 
 	return result, nil
@@ -98,6 +112,91 @@ func (walletService *WalletService) ImportAccount(privateKey string, accountName
 	return result, nil
 }
 
+func (walletService *WalletService) ImportWatchOnlyAccount(paymentAddress string, readonlyKey string, accountName string, passPhrase string) (wallet.KeySerializedData, error) {
+	account, err := walletService.Wallet.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return wallet.KeySerializedData{}, err
+	}
+	result := wallet.KeySerializedData{
+		PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+		Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+		ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+	}
+	return result, nil
+}
+
+func (walletService *WalletService) CreateHDAccount(accountName string, accountIndex uint32, shardID byte) (jsonresult.AccountDetail, *RPCError) {
+	activeShards := walletService.BlockChain.GetBeaconBestState().ActiveShards
+	if int(shardID) >= activeShards {
+		return jsonresult.AccountDetail{}, NewRPCError(RPCInvalidParamsError, fmt.Errorf("shard %v is not active", shardID))
+	}
+	account, err := walletService.Wallet.CreateHDAccount(accountName, accountIndex, shardID)
+	if err != nil {
+		return jsonresult.AccountDetail{}, NewRPCError(UnexpectedError, err)
+	}
+	return jsonresult.AccountDetail{
+		PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+		ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+		ShardID:        shardID,
+		Path:           account.Path,
+	}, nil
+}
+
+// RecoverHDAccounts adds to the wallet the accounts of its mnemonic which received coins of PRV or any privacy token
+func (walletService *WalletService) RecoverHDAccounts(gapLimit int) ([]jsonresult.AccountDetail, *RPCError) {
+	tokenStates, err := walletService.BlockChain.ListAllPrivacyCustomTokenAndPRV()
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	tokenIDs := []common.Hash{common.PRVCoinID}
+	for tokenID := range tokenStates {
+		if tokenID != common.PRVCoinID {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	activeShards := walletService.BlockChain.GetBeaconBestState().ActiveShards
+	isUsed := func(keySet *incognitokey.KeySet, shardID byte) (bool, error) {
+		if int(shardID) >= activeShards {
+			return false, nil
+		}
+		transactionStateDB := walletService.BlockChain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
+		for _, tokenID := range tokenIDs {
+			outCoins, err := statedb.GetOutcoinsByPubkey(transactionStateDB, tokenID, keySet.PaymentAddress.Pk, shardID)
+			if err != nil {
+				return false, err
+			}
+			if len(outCoins) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	accounts, err := walletService.Wallet.RecoverHDAccounts(gapLimit, isUsed)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	result := []jsonresult.AccountDetail{}
+	for _, account := range accounts {
+		lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
+		result = append(result, jsonresult.AccountDetail{
+			PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+			ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+			ShardID:        common.GetShardIDFromLastByte(lastByte),
+			Path:           account.Path,
+		})
+	}
+	return result, nil
+}
+
+func (walletService *WalletService) SetAccountLabel(accountName string, label string, metadata map[string]string) (bool, *RPCError) {
+	err := walletService.Wallet.SetAccountLabel(accountName, label, metadata)
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	return true, nil
+}
+
 func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase string) (bool, *RPCError) {
 	err := walletService.Wallet.RemoveAccount(privateKey, passPhrase)
 	if err != nil {
@@ -161,6 +260,9 @@ func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress str
 	return balance, nil
 }
 
+// GetBalance returns the PRV balance of accountName or of all accounts for "*".
+// Watch-only accounts are left out: without their private key spent coins can not be detected,
+// their amounts are read with GetReceivedByAccount
 func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCError) {
 	prvCoinID := &common.Hash{}
 	err1 := prvCoinID.SetBytes(common.PRVCoinID[:])
@@ -172,6 +274,9 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	if accountName == "*" {
 		// get balance for all accounts in wallet
 		for _, account := range walletService.Wallet.MasterAccount.Child {
+			if account.IsWatchOnly {
+				continue
+			}
 			lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 			shardIDSender := common.GetShardIDFromLastByte(lastByte)
 			outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardIDSender, prvCoinID)
@@ -185,6 +290,9 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	} else {
 		for _, account := range walletService.Wallet.MasterAccount.Child {
 			if account.Name == accountName {
+				if account.IsWatchOnly {
+					return uint64(0), NewRPCError(RPCInvalidParamsError, fmt.Errorf("account %v is watch-only, its balance is unknown, use getreceivedbyaccount", accountName))
+				}
 				// get balance for accountName in wallet
 				lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 				shardIDSender := common.GetShardIDFromLastByte(lastByte)
@@ -203,6 +311,8 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	return balance, nil
 }
 
+// GetReceivedByAccount returns the PRV amount of the output coins of accountName.
+// For a watch-only account it is the total received, spent coins included
 func (walletService WalletService) GetReceivedByAccount(accountName string) (uint64, *RPCError) {
 	balance := uint64(0)
 	for _, account := range walletService.Wallet.MasterAccount.Child {
@@ -2,6 +2,7 @@ package rpcserver
 
 import (
 	"errors"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 	"net"
 	"net/http"
@@ -22,10 +23,19 @@ type WsServer struct {
 	statusLock   sync.RWMutex
 	authSHA      []byte
 	limitAuthSHA []byte
+	// apiKeyStore is nil if api keys are disabled, it is shared with the http server
+	// so keys managed by rpc apply to websocket clients too
+	apiKeyStore *APIKeyStore
 	// channel
 	cRequestProcessShutdown chan struct{}
 
 	blockService *rpcservice.BlockService
+	portal       *rpcservice.PortalService
+
+	// portal state of the best beacon block, loaded once per block for all portal subscriptions
+	portalStateLock      sync.Mutex
+	portalStateBlockHash common.Hash
+	portalState          *blockchain.CurrentPortalState
 }
 type RpcSubResult struct {
 	Result interface{}
@@ -38,6 +48,7 @@ type SubcriptionManager struct {
 	subMtx         sync.RWMutex
 	subRequestList map[string]map[common.Hash]chan struct{} // String: Subcription Method, Hash: hash from Subcription Params
 	ws             *websocket.Conn
+	apiKey         *APIKey // nil if the client connected without an api key
 }
 
 var upgrader = websocket.Upgrader{
@@ -54,6 +65,9 @@ func (wsServer *WsServer) Init(config *RpcServerConfig) {
 		DB:         wsServer.config.Database,
 		MemCache:   wsServer.config.MemCache,
 	}
+	wsServer.portal = &rpcservice.PortalService{
+		BlockChain: wsServer.config.BlockChain,
+	}
 }
 
 func NewSubscriptionManager(ws *websocket.Conn) *SubcriptionManager {
@@ -109,11 +123,21 @@ func (wsServer *WsServer) Stop() {
 /*
 Handle all ws request to rpcserver
 */
-// @NOTICE: no auth for this version yet
+// @NOTICE: only api keys are authenticated, clients without a key are not authenticated in this version yet
 func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request) {
 	if wsServer.limitWsConnections(w, r.RemoteAddr) {
 		return
 	}
+	var apiKey *APIKey
+	if rawKey := getRawAPIKey(r.Header); rawKey != "" && wsServer.apiKeyStore != nil {
+		var ok bool
+		apiKey, ok = wsServer.apiKeyStore.Authenticate(rawKey)
+		if !ok {
+			Logger.log.Warnf("RPC websocket api key authentication failure from %s", r.RemoteAddr)
+			AuthFail(w)
+			return
+		}
+	}
 	// Keep track of the number of connected clients.
 	wsServer.IncrementWsClients()
 	defer wsServer.DecrementWsClients()
@@ -125,7 +149,7 @@ func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		return
 	}
-	wsServer.ProcessRpcWsRequest(ws)
+	wsServer.ProcessRpcWsRequest(ws, apiKey)
 }
 
 func (wsServer *WsServer) limitWsConnections(w http.ResponseWriter, remoteAddr string) bool {
@@ -148,13 +172,16 @@ func (wsServer *WsServer) DecrementWsClients() {
 	atomic.AddInt32(&wsServer.numWsClients, -1)
 }
 
-func (wsServer *WsServer) ProcessRpcWsRequest(ws *websocket.Conn) {
+// ProcessRpcWsRequest serves the subscriptions of one websocket connection,
+// apiKey is nil if the client connected without an api key
+func (wsServer *WsServer) ProcessRpcWsRequest(ws *websocket.Conn, apiKey *APIKey) {
 	if atomic.LoadInt32(&wsServer.shutdown) != 0 {
 		return
 	}
 	defer ws.Close()
 	// one sub manager will manage connection and subcription with one client (one websocket connection)
 	subManager := NewSubscriptionManager(ws)
+	subManager.apiKey = apiKey
 	for {
 		msgType, msg, err := ws.ReadMessage()
 		if err != nil {
@@ -199,8 +226,17 @@ func (wsServer *WsServer) subscribe(subManager *SubcriptionManager, subRequest *
 	command := WsHandler[request.Method]
 	if command == nil {
 		jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method"+request.Method+"Not found"))
+	} else if apiKey := subManager.apiKey; apiKey != nil {
+		// api keys are checked against their own allow-list and rate limit, as for http requests
+		if !apiKey.IsMethodAllowed(request.Method) {
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New("api key "+apiKey.Name+" is not allowed to call "+request.Method))
+		} else if checkAPIKeyRateLimit(wsServer.config.MemCache, apiKey) {
+			jsonErr = rpcservice.NewRPCError(rpcservice.RPCInvalidMethodPermissionError, errors.New("Reach rate limit of api key "+apiKey.Name))
+		}
+	}
+	if jsonErr != nil {
 		Logger.log.Errorf("RPC from client %+v error %+v", subManager.ws.RemoteAddr(), jsonErr)
-		//Notify user, method not found
+		//Notify user, method not found or not allowed
 		res, err := createMarshalledSubResponse(subRequest, nil, jsonErr)
 		if err != nil {
 			Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
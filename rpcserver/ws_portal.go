@@ -0,0 +1,149 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleSubcribeCustodianRisk alerts when the ratio of a custodian for a portal token crosses one of the thresholds (in percent),
+// params: custodian address and an optional list of thresholds, the default thresholds are TP130 and TP120 of the portal params
+func (wsServer *WsServer) handleSubcribeCustodianRisk(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 && len(arrayParams) != 2 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 or 2 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	custodianAddress, ok := arrayParams[0].(string)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Custodian Address"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	thresholds := []uint64{}
+	if len(arrayParams) == 2 {
+		thresholdParams := common.InterfaceSlice(arrayParams[1])
+		if thresholdParams == nil {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Thresholds"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		for _, thresholdParam := range thresholdParams {
+			threshold, ok := thresholdParam.(float64)
+			if !ok || threshold <= 0 {
+				err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Thresholds"))
+				cResult <- RpcSubResult{Error: err}
+				return
+			}
+			thresholds = append(thresholds, uint64(threshold))
+		}
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Custodian Risk", custodianAddress)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewBeaconBlockTopic, subId)
+		close(cResult)
+	}()
+	// ratios of the last report by portal token id
+	lastRatios := map[string]uint64{}
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				_, ok := msg.Value.(*blockchain.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				beaconBestState := wsServer.config.BlockChain.GetBeaconBestState()
+				portalParams := wsServer.config.BlockChain.GetPortalParams(beaconBestState.BeaconHeight)
+				alertThresholds := thresholds
+				if len(alertThresholds) == 0 {
+					alertThresholds = []uint64{portalParams.TP130, portalParams.TP120}
+				}
+				portalState, err := wsServer.getBestPortalState(beaconBestState)
+				if err != nil {
+					Logger.log.Errorf("Get portal state of beacon height %v error %v", beaconBestState.BeaconHeight, err)
+					continue
+				}
+				report, err := wsServer.portal.BuildCustodianRiskReport(portalState, beaconBestState.BeaconHeight, custodianAddress, portalParams)
+				if err != nil {
+					Logger.log.Errorf("Get custodian risk report of %v error %v", custodianAddress, err)
+					continue
+				}
+				for _, alert := range buildCustodianRiskAlerts(report, alertThresholds, lastRatios) {
+					cResult <- RpcSubResult{Result: alert, Error: nil}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Custodian Risk " + custodianAddress}}
+				return
+			}
+		}
+	}
+}
+
+// getBestPortalState returns the portal state of the best beacon block,
+// it is loaded once per beacon block then shared by the subscriptions which only read it
+func (wsServer *WsServer) getBestPortalState(beaconBestState *blockchain.BeaconBestState) (*blockchain.CurrentPortalState, error) {
+	wsServer.portalStateLock.Lock()
+	defer wsServer.portalStateLock.Unlock()
+	if wsServer.portalState != nil && wsServer.portalStateBlockHash == beaconBestState.BestBlockHash {
+		return wsServer.portalState, nil
+	}
+	portalState, err := blockchain.InitCurrentPortalStateFromDB(beaconBestState.GetBeaconFeatureStateDB())
+	if err != nil {
+		return nil, err
+	}
+	wsServer.portalState = portalState
+	wsServer.portalStateBlockHash = beaconBestState.BestBlockHash
+	return portalState, nil
+}
+
+// buildCustodianRiskAlerts compares the ratios of the report with the last ones, then updates the last ratios,
+// tokens without holding public tokens are not at risk
+func buildCustodianRiskAlerts(report jsonresult.CustodianRiskReport, thresholds []uint64, lastRatios map[string]uint64) []jsonresult.CustodianRiskAlert {
+	sortedThresholds := make([]uint64, len(thresholds))
+	copy(sortedThresholds, thresholds)
+	sort.Slice(sortedThresholds, func(i, j int) bool {
+		return sortedThresholds[i] > sortedThresholds[j]
+	})
+	alerts := []jsonresult.CustodianRiskAlert{}
+	for _, token := range report.Tokens {
+		atRisk := token.HoldingPubTokenAmount > 0
+		lastRatio, found := lastRatios[token.PortalTokenID]
+		for _, threshold := range sortedThresholds {
+			isBelow := atRisk && token.Ratio <= threshold
+			wasBelow := found && lastRatio <= threshold
+			if isBelow == wasBelow {
+				continue
+			}
+			alerts = append(alerts, jsonresult.CustodianRiskAlert{
+				CustodianAddress: report.CustodianAddress,
+				BeaconHeight:     report.BeaconHeight,
+				Threshold:        threshold,
+				IsBelow:          isBelow,
+				Token:            token,
+			})
+		}
+		if atRisk {
+			lastRatios[token.PortalTokenID] = token.Ratio
+		} else {
+			delete(lastRatios, token.PortalTokenID)
+		}
+	}
+	return alerts
+}
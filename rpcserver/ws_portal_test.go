@@ -0,0 +1,54 @@
+package rpcserver
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCustodianRiskAlerts(t *testing.T) {
+	thresholds := []uint64{120, 130}
+	lastRatios := map[string]uint64{}
+	newReport := func(beaconHeight uint64, holdingPubTokenAmount uint64, ratio uint64) jsonresult.CustodianRiskReport {
+		return jsonresult.CustodianRiskReport{
+			CustodianAddress: "custodian",
+			BeaconHeight:     beaconHeight,
+			Tokens: []*jsonresult.CustodianTokenRisk{
+				{PortalTokenID: common.PortalBNBIDStr, HoldingPubTokenAmount: holdingPubTokenAmount, Ratio: ratio},
+			},
+		}
+	}
+	type alert struct {
+		threshold uint64
+		isBelow   bool
+	}
+	tests := []struct {
+		name   string
+		report jsonresult.CustodianRiskReport
+		want   []alert
+	}{
+		{"above all thresholds", newReport(1, 10, 140), []alert{}},
+		{"crossing down the higher threshold", newReport(2, 10, 125), []alert{{130, true}}},
+		{"staying between the thresholds", newReport(3, 10, 128), []alert{}},
+		{"crossing down the lower threshold", newReport(4, 10, 110), []alert{{120, true}}},
+		{"crossing up both thresholds", newReport(5, 10, 150), []alert{{130, false}, {120, false}}},
+		{"crossing down both thresholds", newReport(6, 10, 100), []alert{{130, true}, {120, true}}},
+		{"no holding public tokens", newReport(7, 0, 0), []alert{{130, false}, {120, false}}},
+		{"holding public tokens again", newReport(8, 10, 140), []alert{}},
+	}
+	for _, tt := range tests {
+		alerts := buildCustodianRiskAlerts(tt.report, thresholds, lastRatios)
+		got := []alert{}
+		for _, a := range alerts {
+			assert.Equal(t, tt.report.BeaconHeight, a.BeaconHeight, tt.name)
+			assert.Equal(t, "custodian", a.CustodianAddress, tt.name)
+			got = append(got, alert{a.Threshold, a.IsBelow})
+		}
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+	assert.Equal(t, map[string]uint64{common.PortalBNBIDStr: 140}, lastRatios)
+	// the thresholds of the caller are not reordered
+	assert.Equal(t, []uint64{120, 130}, thresholds)
+}
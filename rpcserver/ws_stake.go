@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/validatorindexer"
 )
 
 func (wsServer *WsServer) handleSubcribeShardCandidateByPublickey(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
@@ -398,3 +399,73 @@ func (wsServer *WsServer) handleSubcribeBeaconCommitteeByPublickey(params interf
 		}
 	}
 }
+
+// handleSubcribeValidatorStatus sends every transition of a validator indexed from final beacon blocks,
+// params: the committee public key or the base58 bls mining key of the validator, transitions of all validators are sent without params
+func (wsServer *WsServer) handleSubcribeValidatorStatus(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	if wsServer.config.ValidatorIndexer == nil {
+		err := rpcservice.NewRPCError(rpcservice.GetValidatorStatusError, errors.New("Validator index is not enabled on this node, run it with --validatorindex"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) > 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 0 or 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	publicKey := ""
+	if len(arrayParams) == 1 {
+		var ok bool
+		publicKey, ok = arrayParams[0].(string)
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Public Key"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.ValidatorTransitionTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Validator Status", publicKey)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.ValidatorTransitionTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				transition, ok := msg.Value.(*validatorindexer.Transition)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *validatorindexer.Transition, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if publicKey != "" && !isTransitionOfKey(transition, publicKey) {
+					continue
+				}
+				cResult <- RpcSubResult{Result: transition, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Validator Status " + publicKey}}
+				return
+			}
+		}
+	}
+}
+
+// isTransitionOfKey returns whether publicKey is the committee public key or the bls mining key of the validator of a transition
+func isTransitionOfKey(transition *validatorindexer.Transition, publicKey string) bool {
+	if transition.CommitteePublicKey == publicKey {
+		return true
+	}
+	key := incognitokey.CommitteePublicKey{}
+	if key.FromBase58(transition.CommitteePublicKey) != nil {
+		return false
+	}
+	return key.GetMiningKeyBase58(common.BlsConsensus) == publicKey
+}
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -18,9 +19,11 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/tracing"
 
 	"github.com/incognitochain/incognito-chain/peerv2"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/blockexporter"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
@@ -40,11 +44,16 @@ import (
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/netsync"
+	"github.com/incognitochain/incognito-chain/pdeindexer"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+	ethrelaying "github.com/incognitochain/incognito-chain/relaying/eth"
+	ltcrelaying "github.com/incognitochain/incognito-chain/relaying/ltc"
+	"github.com/incognitochain/incognito-chain/rewardindexer"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/validatorindexer"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 	libp2p "github.com/libp2p/go-libp2p-peer"
@@ -83,6 +92,15 @@ type Server struct {
 	// the mempool before they are mined into blocks.
 	feeEstimator map[byte]*mempool.FeeEstimator
 	highway      *peerv2.ConnManager
+	pdeIndexer   *pdeindexer.Indexer
+	// validatorIndexer indexes the phases of validators, it is nil if the validator index is disabled
+	validatorIndexer *validatorindexer.Indexer
+	// rewardIndexer indexes rewards and withdrawals of public keys, it is nil if the reward index is disabled
+	rewardIndexer *rewardindexer.Indexer
+	// blockExporter exports final blocks to files, it is nil if exportdir is not set
+	blockExporter *blockexporter.Exporter
+	// metricsServer serves Prometheus metrics, it is nil if metricslisten is not set
+	metricsServer *http.Server
 
 	cQuit     chan struct{}
 	cNewPeers chan *peer.Peer
@@ -201,6 +219,8 @@ func (serverObj *Server) NewServer(
 	protocolVer string,
 	btcChain *btcrelaying.BlockChain,
 	bnbChainState *bnbrelaying.BNBChainState,
+	ethChain *ethrelaying.HeaderChain,
+	ltcChain *ltcrelaying.HeaderChain,
 	interrupt <-chan struct{},
 ) error {
 	// Init data for Server
@@ -314,6 +334,8 @@ func (serverObj *Server) NewServer(
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
 		BNBChainState: bnbChainState,
+		ETHChain:      ethChain,
+		LTCChain:      ltcChain,
 		ChainParams:   serverObj.chainParams,
 		DataBase:      serverObj.dataBase,
 		MemCache:      serverObj.memCache,
@@ -331,6 +353,8 @@ func (serverObj *Server) NewServer(
 		ConsensusEngine: serverObj.consensusEngine,
 		Highway:         serverObj.highway,
 		GenesisParams:   blockchain.GenesisParam,
+
+		TxValidationWorkers: cfg.TxValidationWorkers,
 	})
 	if err != nil {
 		return err
@@ -340,8 +364,95 @@ func (serverObj *Server) NewServer(
 		return err
 	}
 
+	// Create the index of PDE history for the analytics RPCs
+	if cfg.PDEIndex {
+		pdeIndexDB, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "pdeindex"))
+		if err != nil {
+			return err
+		}
+		serverObj.pdeIndexer, err = pdeindexer.NewIndexer(pdeindexer.Config{
+			DB:            pdeIndexDB,
+			BlockChain:    serverObj.blockChain,
+			PubSubManager: pubsubManager,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the index of validator phases for the validator status RPC
+	if cfg.ValidatorIndex {
+		validatorIndexDB, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "validatorindex"))
+		if err != nil {
+			return err
+		}
+		serverObj.validatorIndexer, err = validatorindexer.NewIndexer(validatorindexer.Config{
+			DB:                        validatorIndexDB,
+			BlockChain:                serverObj.blockChain,
+			PubSubManager:             pubsubManager,
+			GenesisShardCommitteeSize: activeNetParams.MinShardCommitteeSize,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the index of rewards for the reward history RPC
+	if cfg.RewardIndex {
+		rewardIndexDB, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "rewardindex"))
+		if err != nil {
+			return err
+		}
+		serverObj.rewardIndexer, err = rewardindexer.NewIndexer(rewardindexer.Config{
+			DB:            rewardIndexDB,
+			BlockChain:    serverObj.blockChain,
+			ActiveShards:  activeNetParams.ActiveShards,
+			PubSubManager: pubsubManager,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Export final blocks for analytics
+	if cfg.ExportDir != "" {
+		serverObj.blockExporter, err = blockexporter.NewExporter(blockexporter.Config{
+			Dir:           cfg.ExportDir,
+			Format:        cfg.ExportFormat,
+			ChainIDs:      append([]int{common.BeaconChainDataBaseID}, serverObj.blockChain.GetShardIDs()...),
+			BlockChain:    serverObj.blockChain,
+			PubSubManager: pubsubManager,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	//set bc obj for monitor
 	monitor.SetBlockChainObj(serverObj.blockChain)
+	monitor.SetMonitorEndpoint(cfg.MonitorEndpoint)
+
+	if cfg.MetricsListen != "" {
+		serverObj.registerMetrics()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.PrometheusHandler(metrics.DefaultRegistry, "incognito"))
+		serverObj.metricsServer = &http.Server{Addr: cfg.MetricsListen, Handler: mux}
+	}
+
+	exporters := tracing.MultiExporter{}
+	if cfg.TraceFile != "" {
+		fileExporter, err := tracing.NewFileExporter(cfg.TraceFile)
+		if err != nil {
+			return err
+		}
+		exporters = append(exporters, fileExporter)
+	}
+	if cfg.TraceEndpoint != "" {
+		exporters = append(exporters, tracing.NewOTLPExporter(cfg.TraceEndpoint, "incognito"))
+	}
+	if len(exporters) > 0 {
+		tracing.SetExporter(exporters)
+	}
 
 	// or if it cannot be loaded, create a new one.
 	if cfg.FastStartup {
@@ -526,19 +637,27 @@ func (serverObj *Server) NewServer(
 			RPCLimitUser:                cfg.RPCLimitUser,
 			RPCLimitPass:                cfg.RPCLimitPass,
 			DisableAuth:                 cfg.RPCDisableAuth,
+			RPCAPIKeyFile:               cfg.RPCAPIKeyFile,
+			RPCAuditLogFile:             cfg.RPCAuditLogFile,
 			// NodeMode:                    cfg.NodeMode,
-			FeeEstimator:    serverObj.feeEstimator,
-			ProtocolVersion: serverObj.protocolVersion,
-			Database:        serverObj.dataBase,
-			MiningKeys:      cfg.MiningKeys,
-			NetSync:         serverObj.netSync,
-			PubSubManager:   pubsubManager,
-			ConsensusEngine: serverObj.consensusEngine,
-			MemCache:        serverObj.memCache,
-			Syncker:         serverObj.syncker,
+			FeeEstimator:     serverObj.feeEstimator,
+			ProtocolVersion:  serverObj.protocolVersion,
+			Database:         serverObj.dataBase,
+			MiningKeys:       cfg.MiningKeys,
+			NetSync:          serverObj.netSync,
+			PubSubManager:    pubsubManager,
+			ConsensusEngine:  serverObj.consensusEngine,
+			MemCache:         serverObj.memCache,
+			Syncker:          serverObj.syncker,
+			PDEIndexer:       serverObj.pdeIndexer,
+			ValidatorIndexer: serverObj.validatorIndexer,
+			RewardIndexer:    serverObj.rewardIndexer,
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
-		serverObj.rpcServer.Init(&rpcConfig)
+		err = serverObj.rpcServer.Init(&rpcConfig)
+		if err != nil {
+			return err
+		}
 
 		// init rpc client instance and stick to Blockchain object
 		// in order to communicate to external services (ex. eth light node)
@@ -567,6 +686,61 @@ func (serverObj *Server) NewServer(
 	return nil
 }
 
+// registerMetrics registers gauges of chain heights, mempool size, peer counts and sync lag,
+// they are evaluated each time metrics are collected
+func (serverObj *Server) registerMetrics() {
+	// best views are nil until the chains are loaded at startup
+	metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.ChainHeight, "chain", "beacon"), nil, func() int64 {
+		if serverObj.blockChain.BeaconChain.GetBestView() == nil {
+			return 0
+		}
+		return int64(serverObj.blockChain.GetBeaconBestState().BeaconHeight)
+	})
+	metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.SyncLag, "chain", "beacon"), nil, func() int64 {
+		return int64(serverObj.syncker.GetSyncLag(-1))
+	})
+	for i := 0; i < serverObj.chainParams.ActiveShards; i++ {
+		shardID := byte(i)
+		shardLabel := strconv.Itoa(i)
+		metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.ChainHeight, "chain", "shard", "shard", shardLabel), nil, func() int64 {
+			shardBestState := serverObj.blockChain.GetBestStateShard(shardID)
+			if shardBestState == nil {
+				return 0
+			}
+			return int64(shardBestState.ShardHeight)
+		})
+		metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.SyncLag, "chain", "shard", "shard", shardLabel), nil, func() int64 {
+			return int64(serverObj.syncker.GetSyncLag(int(shardID)))
+		})
+	}
+	metrics.NewRegisteredFunctionalGauge(metrics.MempoolSize, nil, func() int64 {
+		if serverObj.memPool == nil {
+			return 0
+		}
+		return int64(serverObj.memPool.Count())
+	})
+	metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.PeerCount, "network", "highway"), nil, func() int64 {
+		if serverObj.highway == nil || serverObj.highway.LocalHost == nil {
+			return 0
+		}
+		return int64(len(serverObj.highway.LocalHost.Host.Network().Peers()))
+	})
+	metrics.NewRegisteredFunctionalGauge(metrics.LabeledName(metrics.PeerCount, "network", "p2p"), nil, func() int64 {
+		if serverObj.connManager == nil || serverObj.connManager.GetListeningPeer() == nil {
+			return 0
+		}
+		return int64(len(serverObj.connManager.GetListeningPeer().GetPeerConns()))
+	})
+}
+
+// serveMetrics serves Prometheus metrics until the metrics server is closed
+func (serverObj *Server) serveMetrics() {
+	Logger.log.Infof("Metrics server listening on %s", serverObj.metricsServer.Addr)
+	if err := serverObj.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		Logger.log.Errorf("Metrics server stopped with err: %v", err)
+	}
+}
+
 /*
 // InboundPeerConnected is invoked by the connection manager when a new
 // inbound connection is established.
@@ -626,6 +800,31 @@ func (serverObj *Server) Stop() error {
 		}
 	}
 
+	if serverObj.pdeIndexer != nil {
+		serverObj.pdeIndexer.Stop()
+	}
+
+	if serverObj.validatorIndexer != nil {
+		serverObj.validatorIndexer.Stop()
+	}
+
+	if serverObj.rewardIndexer != nil {
+		serverObj.rewardIndexer.Stop()
+	}
+
+	if serverObj.blockExporter != nil {
+		serverObj.blockExporter.Stop()
+	}
+
+	if serverObj.metricsServer != nil {
+		if err := serverObj.metricsServer.Close(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+
+	// flush spans not exported yet
+	tracing.SetExporter(nil)
+
 	err := serverObj.consensusEngine.Stop()
 	if err != nil {
 		Logger.log.Error(err)
@@ -726,6 +925,10 @@ func (serverObj Server) Start() {
 		serverObj.blockChain.SetIsBlockGenStarted(true)
 	}
 
+	if serverObj.metricsServer != nil {
+		go serverObj.serveMetrics()
+	}
+
 	//go serverObj.blockChain.Synker.Start()
 	go serverObj.syncker.Start()
 	go serverObj.blockgen.Start(serverObj.cQuit)
@@ -741,6 +944,34 @@ func (serverObj Server) Start() {
 	}
 	go serverObj.pusubManager.Start()
 
+	if serverObj.pdeIndexer != nil {
+		err := serverObj.pdeIndexer.Start()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
+
+	if serverObj.validatorIndexer != nil {
+		err := serverObj.validatorIndexer.Start()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
+
+	if serverObj.rewardIndexer != nil {
+		err := serverObj.rewardIndexer.Start()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
+
+	if serverObj.blockExporter != nil {
+		err := serverObj.blockExporter.Start()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
+
 	err := serverObj.consensusEngine.Start()
 	if err != nil {
 		Logger.log.Error(err)
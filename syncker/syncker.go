@@ -16,6 +16,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/tracing"
 	"github.com/incognitochain/incognito-chain/wire"
 )
 
@@ -218,9 +219,18 @@ func (synckerManager *SynckerManager) ReceivePeerState(peerState *wire.MessagePe
 }
 
 //Get Crossshard Block for creating shardblock block
-func (synckerManager *SynckerManager) GetCrossShardBlocksForShardProducer(toShard byte, limit map[byte][]uint64) map[byte][]interface{} {
+func (synckerManager *SynckerManager) GetCrossShardBlocksForShardProducer(ctx context.Context, toShard byte, limit map[byte][]uint64) (res map[byte][]interface{}) {
+	_, span := tracing.StartSpan(ctx, "syncker.GetCrossShardBlocksForShardProducer", "toShard", int(toShard))
+	defer func() {
+		blockCount := 0
+		for _, blocks := range res {
+			blockCount += len(blocks)
+		}
+		span.SetAttributes("blocks", blockCount)
+		span.End()
+	}()
 	//get last confirm crossshard -> process request until retrieve info
-	res := make(map[byte][]interface{})
+	res = make(map[byte][]interface{})
 
 	lastRequestCrossShard := synckerManager.config.Blockchain.ShardChain[int(toShard)].GetCrossShardState()
 	bc := synckerManager.config.Blockchain
@@ -296,17 +306,20 @@ func (synckerManager *SynckerManager) GetCrossShardBlocksForShardProducer(toShar
 }
 
 //Get Crossshard Block for validating shardblock block
-func (synckerManager *SynckerManager) GetCrossShardBlocksForShardValidator(toShard byte, list map[byte][]uint64) (map[byte][]interface{}, error) {
-	crossShardPoolLists := synckerManager.GetCrossShardBlocksForShardProducer(toShard, list)
+func (synckerManager *SynckerManager) GetCrossShardBlocksForShardValidator(ctx context.Context, toShard byte, list map[byte][]uint64) (map[byte][]interface{}, error) {
+	ctx, span := tracing.StartSpan(ctx, "syncker.GetCrossShardBlocksForShardValidator", "toShard", int(toShard))
+	defer span.End()
+	crossShardPoolLists := synckerManager.GetCrossShardBlocksForShardProducer(ctx, toShard, list)
 
 	missingBlocks := compareListsByHeight(crossShardPoolLists, list)
 	// synckerManager.config.Server.
 	if len(missingBlocks) > 0 {
-		ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-		synckerManager.StreamMissingCrossShardBlock(ctx, toShard, missingBlocks)
+		span.SetAttributes("missingFromShards", len(missingBlocks))
+		streamCtx, _ := context.WithTimeout(ctx, 5*time.Second)
+		synckerManager.StreamMissingCrossShardBlock(streamCtx, toShard, missingBlocks)
 		//Logger.Info("debug finish stream missing crossX block")
 
-		crossShardPoolLists = synckerManager.GetCrossShardBlocksForShardProducer(toShard, list)
+		crossShardPoolLists = synckerManager.GetCrossShardBlocksForShardProducer(ctx, toShard, list)
 		//Logger.Info("get crosshshard block for shard producer", crossShardPoolLists)
 		missingBlocks = compareListsByHeight(crossShardPoolLists, list)
 
@@ -511,6 +524,37 @@ func (synckerManager *SynckerManager) IsChainReady(chainID int) bool {
 	return false
 }
 
+// GetSyncLag returns the number of blocks the chain is behind the highest block reported by peers (chainID -1 is beacon)
+func (synckerManager *SynckerManager) GetSyncLag(chainID int) uint64 {
+	var bestHeight uint64
+	var peerHeights []uint64
+	if chainID == -1 {
+		if synckerManager.BeaconSyncProcess == nil {
+			return 0
+		}
+		bestHeight = synckerManager.BeaconSyncProcess.chain.GetBestViewHeight()
+		for _, ps := range synckerManager.BeaconSyncProcess.getBeaconPeerStates() {
+			peerHeights = append(peerHeights, ps.BestViewHeight)
+		}
+	} else {
+		shardSyncProcess, ok := synckerManager.ShardSyncProcess[chainID]
+		if !ok || shardSyncProcess == nil {
+			return 0
+		}
+		bestHeight = shardSyncProcess.Chain.GetBestViewHeight()
+		for _, ps := range shardSyncProcess.getShardPeerStates() {
+			peerHeights = append(peerHeights, ps.BestViewHeight)
+		}
+	}
+	var lag uint64
+	for _, peerHeight := range peerHeights {
+		if peerHeight > bestHeight && peerHeight-bestHeight > lag {
+			lag = peerHeight - bestHeight
+		}
+	}
+	return lag
+}
+
 type TmpBlock struct {
 	Height  uint64
 	BlkHash *common.Hash
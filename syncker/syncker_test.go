@@ -0,0 +1,72 @@
+package syncker
+
+import (
+	"testing"
+)
+
+type fakeSyncChain struct {
+	ShardChainInterface
+	bestViewHeight uint64
+}
+
+func (chain *fakeSyncChain) GetBestViewHeight() uint64 {
+	return chain.bestViewHeight
+}
+
+// runActions runs actions sent to a sync process, as its sync loop does
+func runActions(actionCh chan func()) {
+	go func() {
+		for action := range actionCh {
+			action()
+		}
+	}()
+}
+
+func TestSynckerManager_GetSyncLag(t *testing.T) {
+	manager := NewSynckerManager()
+	if lag := manager.GetSyncLag(-1); lag != 0 {
+		t.Fatalf("want no lag before the beacon sync process starts but got %v", lag)
+	}
+	if lag := manager.GetSyncLag(0); lag != 0 {
+		t.Fatalf("want no lag of an unknown shard but got %v", lag)
+	}
+
+	beaconActionCh := make(chan func())
+	defer close(beaconActionCh)
+	runActions(beaconActionCh)
+	manager.BeaconSyncProcess = &BeaconSyncProcess{
+		chain:    &fakeSyncChain{bestViewHeight: 100},
+		actionCh: beaconActionCh,
+		beaconPeerStates: map[string]BeaconPeerState{
+			"peer-1": {BestViewHeight: 90},
+			"peer-2": {BestViewHeight: 112},
+			"peer-3": {BestViewHeight: 105},
+		},
+	}
+	shardActionCh := make(chan func())
+	defer close(shardActionCh)
+	runActions(shardActionCh)
+	manager.ShardSyncProcess[1] = &ShardSyncProcess{
+		Chain:    &fakeSyncChain{bestViewHeight: 50},
+		actionCh: shardActionCh,
+		shardPeerState: map[string]ShardPeerState{
+			"peer-1": {BestViewHeight: 40},
+			"peer-2": {BestViewHeight: 50},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		chainID int
+		want    uint64
+	}{
+		{"beacon is behind the highest peer", -1, 12},
+		{"shard is not behind any peer", 1, 0},
+		{"unknown shard", 2, 0},
+	}
+	for _, tt := range tests {
+		if lag := manager.GetSyncLag(tt.chainID); lag != tt.want {
+			t.Errorf("%v: want lag %v but got %v", tt.name, tt.want, lag)
+		}
+	}
+}
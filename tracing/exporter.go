@@ -0,0 +1,338 @@
+package tracing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// OTLPBatchSize is the max number of spans sent to the collector in one request
+	OTLPBatchSize = 512
+	// OTLPFlushInterval is the max time a span waits before being sent to the collector
+	OTLPFlushInterval = 5 * time.Second
+	// OTLPQueueSize is the number of spans queued for the collector, new spans are dropped when the queue is full
+	OTLPQueueSize = 4096
+)
+
+// SpanData is a finished span as exported
+type SpanData struct {
+	Name         string                 `json:"name"`
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	StartTime    time.Time              `json:"startTime"`
+	EndTime      time.Time              `json:"endTime"`
+	Duration     float64                `json:"duration"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// Exporter receives finished spans, ExportSpan must not block the caller for long
+type Exporter interface {
+	ExportSpan(span *SpanData)
+	Close() error
+}
+
+var (
+	exporterLock sync.RWMutex
+	exporter     Exporter
+)
+
+// SetExporter sets the exporter of finished spans and closes the previous one, tracing is disabled if it is nil
+func SetExporter(e Exporter) {
+	exporterLock.Lock()
+	previous := exporter
+	exporter = e
+	exporterLock.Unlock()
+	if previous != nil {
+		if err := previous.Close(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+}
+
+// Enabled returns whether spans are recorded
+func Enabled() bool {
+	return getExporter() != nil
+}
+
+func getExporter() Exporter {
+	exporterLock.RLock()
+	defer exporterLock.RUnlock()
+	return exporter
+}
+
+// FileExporter writes spans to a file, one json object per line
+type FileExporter struct {
+	lock   sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewFileExporter opens fileName in append mode, it is created if it does not exist
+func NewFileExporter(fileName string) (*FileExporter, error) {
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+func (e *FileExporter) ExportSpan(span *SpanData) {
+	spanBytes, err := json.Marshal(span)
+	if err != nil {
+		Logger.log.Errorf("Marshal span %v failed with err: %v", span.Name, err)
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.writer.Write(spanBytes)
+	e.writer.WriteByte('\n')
+	if err := e.writer.Flush(); err != nil {
+		Logger.log.Errorf("Write span %v failed with err: %v", span.Name, err)
+	}
+}
+
+func (e *FileExporter) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err := e.writer.Flush(); err != nil {
+		return err
+	}
+	return e.file.Close()
+}
+
+// OTLPExporter sends spans in batches to an OpenTelemetry collector with OTLP/HTTP json
+type OTLPExporter struct {
+	endpoint    string
+	serviceName string
+	client      *http.Client
+	spanCh      chan *SpanData
+	closeCh     chan struct{}
+	doneCh      chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewOTLPExporter returns an exporter posting to endpoint, the traces url of the collector
+// (e.g. http://localhost:4318/v1/traces), spans are tagged with the service.name serviceName
+func NewOTLPExporter(endpoint string, serviceName string) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		spanCh:      make(chan *SpanData, OTLPQueueSize),
+		closeCh:     make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+func (e *OTLPExporter) ExportSpan(span *SpanData) {
+	select {
+	case e.spanCh <- span:
+	default:
+		Logger.log.Debugf("OTLP exporter queue is full, drop span %v", span.Name)
+	}
+}
+
+// Close sends the queued spans and stops the exporter
+func (e *OTLPExporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closeCh)
+	})
+	<-e.doneCh
+	return nil
+}
+
+func (e *OTLPExporter) run() {
+	defer close(e.doneCh)
+	ticker := time.NewTicker(OTLPFlushInterval)
+	defer ticker.Stop()
+	batch := make([]*SpanData, 0, OTLPBatchSize)
+	for {
+		select {
+		case span := <-e.spanCh:
+			batch = append(batch, span)
+			if len(batch) >= OTLPBatchSize {
+				e.send(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.send(batch)
+				batch = batch[:0]
+			}
+		case <-e.closeCh:
+			for {
+				select {
+				case span := <-e.spanCh:
+					batch = append(batch, span)
+				default:
+					if len(batch) > 0 {
+						e.send(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *OTLPExporter) send(batch []*SpanData) {
+	body, err := json.Marshal(newOTLPTraces(e.serviceName, batch))
+	if err != nil {
+		Logger.log.Errorf("Marshal %v spans failed with err: %v", len(batch), err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		Logger.log.Errorf("Create OTLP request failed with err: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		Logger.log.Errorf("Send %v spans to %v failed with err: %v", len(batch), e.endpoint, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		Logger.log.Errorf("Send %v spans to %v failed with status %v", len(batch), e.endpoint, resp.Status)
+	}
+}
+
+// MultiExporter exports spans to several exporters
+type MultiExporter []Exporter
+
+func (e MultiExporter) ExportSpan(span *SpanData) {
+	for _, exporter := range e {
+		exporter.ExportSpan(span)
+	}
+}
+
+func (e MultiExporter) Close() error {
+	var lastErr error
+	for _, exporter := range e {
+		if err := exporter.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// OTLP/HTTP json payload, see opentelemetry-proto trace/v1/trace.proto
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+func newOTLPTraces(serviceName string, batch []*SpanData) *otlpTraces {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, span := range batch {
+		otlpSpan := otlpSpan{
+			TraceID:           span.TraceID,
+			SpanID:            span.SpanID,
+			ParentSpanID:      span.ParentSpanID,
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Status:            otlpStatus{Code: otlpStatusCodeOk},
+		}
+		for k, v := range span.Attributes {
+			otlpSpan.Attributes = append(otlpSpan.Attributes, newOTLPKeyValue(k, v))
+		}
+		if span.Error != "" {
+			otlpSpan.Status = otlpStatus{Code: otlpStatusCodeError, Message: span.Error}
+		}
+		spans = append(spans, otlpSpan)
+	}
+	return &otlpTraces{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: []otlpKeyValue{newOTLPKeyValue("service.name", serviceName)}},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/incognitochain/incognito-chain/tracing"},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func newOTLPKeyValue(key string, value interface{}) otlpKeyValue {
+	var otlpValue map[string]interface{}
+	switch v := value.(type) {
+	case string:
+		otlpValue = map[string]interface{}{"stringValue": v}
+	case bool:
+		otlpValue = map[string]interface{}{"boolValue": v}
+	case int:
+		otlpValue = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int64:
+		otlpValue = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case uint64:
+		otlpValue = map[string]interface{}{"intValue": strconv.FormatUint(v, 10)}
+	case byte:
+		otlpValue = map[string]interface{}{"intValue": strconv.Itoa(int(v))}
+	case float64:
+		otlpValue = map[string]interface{}{"doubleValue": v}
+	default:
+		valueBytes, _ := json.Marshal(v)
+		otlpValue = map[string]interface{}{"stringValue": string(valueBytes)}
+	}
+	return otlpKeyValue{Key: key, Value: otlpValue}
+}
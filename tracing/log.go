@@ -0,0 +1,14 @@
+package tracing
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type TracingLogger struct {
+	log common.Logger
+}
+
+func (logger *TracingLogger) Init(inst common.Logger) {
+	logger.log = inst
+}
+
+// Global instant to use
+var Logger = TracingLogger{}
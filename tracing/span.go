@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceID identifies all spans of one operation, e.g. producing and committing a block on every node
+type TraceID [16]byte
+
+// SpanID identifies one span of a trace
+type SpanID [8]byte
+
+func (traceID TraceID) String() string {
+	return hex.EncodeToString(traceID[:])
+}
+
+func (traceID TraceID) IsValid() bool {
+	return traceID != TraceID{}
+}
+
+func (spanID SpanID) String() string {
+	return hex.EncodeToString(spanID[:])
+}
+
+func (spanID SpanID) IsValid() bool {
+	return spanID != SpanID{}
+}
+
+// SpanContext is the part of a span propagated to child spans, in process with a context.Context
+// and between nodes with its string form
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// String encodes the span context in the W3C traceparent format, it returns an empty string if sc is not valid
+func (sc SpanContext) String() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// ParseSpanContext decodes a span context in the W3C traceparent format
+func ParseSpanContext(s string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return sc, fmt.Errorf("invalid traceparent %v", s)
+	}
+	traceIDBytes, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceIDBytes) != len(sc.TraceID) {
+		return sc, fmt.Errorf("invalid trace id %v", parts[1])
+	}
+	spanIDBytes, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanIDBytes) != len(sc.SpanID) {
+		return sc, fmt.Errorf("invalid span id %v", parts[2])
+	}
+	copy(sc.TraceID[:], traceIDBytes)
+	copy(sc.SpanID[:], spanIDBytes)
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid traceparent %v", s)
+	}
+	return sc, nil
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx whose new spans are children of sc,
+// it is used to continue a trace received from another node
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the current span of ctx
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// Span is a timed operation of a trace. Spans are only recorded when an exporter is set,
+// otherwise StartSpan returns a nil span whose methods do nothing
+type Span struct {
+	name         string
+	spanContext  SpanContext
+	parentSpanID SpanID
+	startTime    time.Time
+
+	lock       sync.Mutex
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+// StartSpan starts a span as a child of the current span of ctx, or as the root of a new trace.
+// attributes are key/value pairs, e.g. StartSpan(ctx, "shard.InsertBlock", "shardID", 0, "height", 10).
+// The returned context carries the new span and must be passed to the operations it covers
+func StartSpan(ctx context.Context, name string, attributes ...interface{}) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if getExporter() == nil {
+		return ctx, nil
+	}
+	parent := SpanContextFromContext(ctx)
+	span := &Span{
+		name:       name,
+		startTime:  time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+	if parent.IsValid() {
+		span.spanContext.TraceID = parent.TraceID
+		span.parentSpanID = parent.SpanID
+	} else {
+		rand.Read(span.spanContext.TraceID[:])
+	}
+	rand.Read(span.spanContext.SpanID[:])
+	span.SetAttributes(attributes...)
+	return context.WithValue(ctx, spanContextKey{}, span.spanContext), span
+}
+
+// SpanContext returns the span context to propagate to other nodes
+func (span *Span) SpanContext() SpanContext {
+	if span == nil {
+		return SpanContext{}
+	}
+	return span.spanContext
+}
+
+// SetAttributes adds key/value pairs to the span, keys must be strings
+func (span *Span) SetAttributes(attributes ...interface{}) {
+	if span == nil || len(attributes)%2 != 0 {
+		return
+	}
+	span.lock.Lock()
+	defer span.lock.Unlock()
+	for i := 0; i < len(attributes); i += 2 {
+		key, ok := attributes[i].(string)
+		if !ok {
+			continue
+		}
+		span.attributes[key] = attributes[i+1]
+	}
+}
+
+// SetError marks the span as failed with err, a nil err is ignored
+func (span *Span) SetError(err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.lock.Lock()
+	defer span.lock.Unlock()
+	span.err = err
+}
+
+// End finishes the span and hands it to the exporter, only the first call has an effect
+func (span *Span) End() {
+	if span == nil {
+		return
+	}
+	endTime := time.Now()
+	span.lock.Lock()
+	if span.ended {
+		span.lock.Unlock()
+		return
+	}
+	span.ended = true
+	data := &SpanData{
+		Name:       span.name,
+		TraceID:    span.spanContext.TraceID.String(),
+		SpanID:     span.spanContext.SpanID.String(),
+		StartTime:  span.startTime,
+		EndTime:    endTime,
+		Duration:   endTime.Sub(span.startTime).Seconds(),
+		Attributes: make(map[string]interface{}, len(span.attributes)),
+	}
+	if span.parentSpanID.IsValid() {
+		data.ParentSpanID = span.parentSpanID.String()
+	}
+	for k, v := range span.attributes {
+		data.Attributes[k] = v
+	}
+	if span.err != nil {
+		data.Error = span.err.Error()
+	}
+	span.lock.Unlock()
+
+	if exporter := getExporter(); exporter != nil {
+		exporter.ExportSpan(data)
+	}
+}
@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testExporter struct {
+	spans []*SpanData
+}
+
+func (e *testExporter) ExportSpan(span *SpanData) {
+	e.spans = append(e.spans, span)
+}
+
+func (e *testExporter) Close() error {
+	return nil
+}
+
+func TestParseSpanContext(t *testing.T) {
+	sc, err := ParseSpanContext("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Fatalf("wrong span context %+v", sc)
+	}
+	if sc.String() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("wrong traceparent %v", sc.String())
+	}
+	for _, s := range []string{
+		"",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01",
+	} {
+		if _, err := ParseSpanContext(s); err == nil {
+			t.Errorf("expect error for %v", s)
+		}
+	}
+}
+
+func TestStartSpanWithoutExporter(t *testing.T) {
+	SetExporter(nil)
+	ctx, span := StartSpan(context.Background(), "test")
+	if span != nil {
+		t.Fatal("expect nil span")
+	}
+	if SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("expect no span context")
+	}
+	span.SetAttributes("key", 1)
+	span.SetError(errors.New("error"))
+	span.End()
+	if span.SpanContext().String() != "" {
+		t.Fatal("expect empty traceparent")
+	}
+}
+
+func TestStartSpanChild(t *testing.T) {
+	exporter := &testExporter{}
+	SetExporter(exporter)
+	defer SetExporter(nil)
+
+	remote, _ := ParseSpanContext("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, parent := StartSpan(ContextWithSpanContext(context.Background(), remote), "parent", "height", uint64(10))
+	_, child := StartSpan(ctx, "child")
+	child.SetError(errors.New("invalid block"))
+	child.End()
+	parent.End()
+	parent.End()
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expect 2 spans, got %v", len(exporter.spans))
+	}
+	childData, parentData := exporter.spans[0], exporter.spans[1]
+	if parentData.TraceID != remote.TraceID.String() || parentData.ParentSpanID != remote.SpanID.String() {
+		t.Fatalf("parent is not a child of the remote span %+v", parentData)
+	}
+	if childData.TraceID != parentData.TraceID || childData.ParentSpanID != parentData.SpanID {
+		t.Fatalf("child is not a child of parent %+v", childData)
+	}
+	if childData.Error != "invalid block" {
+		t.Fatalf("wrong error %v", childData.Error)
+	}
+	if parentData.Attributes["height"] != uint64(10) {
+		t.Fatalf("wrong attributes %v", parentData.Attributes)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "trace.json")
+	exporter, err := NewFileExporter(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetExporter(exporter)
+	_, span := StartSpan(context.Background(), "shard.InsertBlock", "shardID", 1)
+	span.End()
+	SetExporter(nil)
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	lines := 0
+	for scanner.Scan() {
+		data := &SpanData{}
+		if err := json.Unmarshal(scanner.Bytes(), data); err != nil {
+			t.Fatal(err)
+		}
+		if data.Name != "shard.InsertBlock" || data.TraceID != span.SpanContext().TraceID.String() {
+			t.Fatalf("wrong span %+v", data)
+		}
+		lines++
+	}
+	if lines != 1 {
+		t.Fatalf("expect 1 span, got %v", lines)
+	}
+}
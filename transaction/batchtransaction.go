@@ -3,17 +3,36 @@ package transaction
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/oneoutofmany"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/serialnumberprivacy"
 )
 
 type batchTransaction struct {
 	txs []metadata.Transaction
 }
 
+// batchProofs collects the proofs and signatures which are skipped by ValidateTransaction in batch mode,
+// with the index of the transaction each of them belongs to
+type batchProofs struct {
+	bulletProofs        []*aggregaterange.AggregatedRangeProof
+	bulletProofTxIndex  []int
+	oneOfManyProofs     []*oneoutofmany.OneOutOfManyProof
+	oneOfManyTxIndex    []int
+	serialNumberProofs  []*serialnumberprivacy.SNPrivacyProof
+	serialNumberTxIndex []int
+	sigPubKeys          []*privacy.SchnorrPublicKey
+	sigs                []*privacy.SchnSignature
+	sigData             [][]byte
+	sigTxIndex          []int
+}
+
 func NewBatchTransaction(txs []metadata.Transaction) *batchTransaction {
 	return &batchTransaction{txs: txs}
 }
@@ -23,17 +42,42 @@ func (b *batchTransaction) AddTxs(txs []metadata.Transaction) {
 }
 
 func (b *batchTransaction) Validate(transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, boolParams map[string]bool) (bool, error, int) {
-	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB, boolParams)
+	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB, boolParams, 1)
+}
+
+// ValidateParallel works as Validate but splits the proofs and signatures of the batch into numWorkers chunks
+// of consecutive transactions, each chunk is verified by its own goroutine
+func (b *batchTransaction) ValidateParallel(transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, boolParams map[string]bool, numWorkers int) (bool, error, int) {
+	return b.validateBatchTxsByItself(b.txs, transactionStateDB, bridgeStateDB, boolParams, numWorkers)
 }
 
-func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transaction, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, boolParams map[string]bool) (bool, error, int) {
+func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transaction, transactionStateDB *statedb.StateDB, bridgeStateDB *statedb.StateDB, boolParams map[string]bool, numWorkers int) (bool, error, int) {
+	if len(txList) == 0 {
+		return true, nil, -1
+	}
 	prvCoinID := &common.Hash{}
 	err := prvCoinID.SetBytes(common.PRVCoinID[:])
 	if err != nil {
 		return false, err, -1
 	}
-	bulletProofList := make([]*aggregaterange.AggregatedRangeProof, 0)
+	isNewZKP, ok := boolParams["isNewZKP"]
+	if !ok {
+		isNewZKP = true
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	if numWorkers > len(txList) {
+		numWorkers = len(txList)
+	}
+	chunkSize := (len(txList) + numWorkers - 1) / numWorkers
+	chunks := make([]*batchProofs, numWorkers)
+	for i := range chunks {
+		chunks[i] = &batchProofs{}
+	}
+	// transactions are validated against the state db one by one, statedb is not safe for concurrent use
 	for i, tx := range txList {
+		proofs := chunks[i/chunkSize]
 		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 		hasPrivacy := tx.IsPrivacy()
 
@@ -52,38 +96,133 @@ func (b *batchTransaction) validateBatchTxsByItself(txList []metadata.Transactio
 			}
 		}
 
-		if hasPrivacy {
-			if bulletProof := tx.GetProof().GetAggregatedRangeProof(); bulletProof != nil {
-				bulletProofList = append(bulletProofList, bulletProof)
+		switch txType := tx.(type) {
+		case *Tx:
+			err = proofs.addTx(txType, i, isNewZKP)
+		case *TxCustomTokenPrivacy:
+			err = proofs.addTx(&txType.Tx, i, isNewZKP)
+			if err == nil && txType.TxPrivacyTokenData.Type != CustomTokenInit {
+				err = proofs.addTx(&txType.TxPrivacyTokenData.TxNormal, i, isNewZKP)
 			}
+		default:
+			err = NewTransactionErr(UnexpectedError, fmt.Errorf("can not validate tx type %T in batch", tx))
+		}
+		if err != nil {
+			return false, NewTransactionErr(VerifyTxSigFailError, err), i
 		}
 	}
-	//TODO: add go routine
-	isNewZKP, ok := boolParams["isNewZKP"]
-	if !ok {
-		isNewZKP = true
+
+	return verifyBatchProofs(chunks, isNewZKP)
+}
+
+// verifyBatchProofs verifies every chunk in its own goroutine, returns the failure of the first invalid chunk
+func verifyBatchProofs(chunks []*batchProofs, isNewZKP bool) (bool, error, int) {
+	type result struct {
+		ok    bool
+		err   error
+		index int
+	}
+	results := make([]result, len(chunks))
+	var wg sync.WaitGroup
+	for i, proofs := range chunks {
+		wg.Add(1)
+		go func(i int, proofs *batchProofs) {
+			defer wg.Done()
+			ok, err, index := proofs.verify(isNewZKP)
+			results[i] = result{ok: ok, err: err, index: index}
+		}(i, proofs)
+	}
+	wg.Wait()
+	for _, res := range results {
+		if !res.ok {
+			return false, res.err, res.index
+		}
+	}
+	return true, nil, -1
+}
+
+// verify checks all collected signatures and proofs, returns the index of the transaction of the first invalid one
+func (proofs *batchProofs) verify(isNewZKP bool) (bool, error, int) {
+	if ok, index := privacy.VerifyBatchSchnorr(proofs.sigPubKeys, proofs.sigs, proofs.sigData); !ok {
+		txIndex := getBatchTxIndex(proofs.sigTxIndex, index)
+		Logger.log.Errorf("FAILED VERIFICATION BATCH SIGNATURE %d", txIndex)
+		return false, NewTransactionErr(VerifyTxSigFailError, fmt.Errorf("FAILED VERIFICATION BATCH SIGNATURE %d", txIndex)), txIndex
 	}
 
 	if isNewZKP {
-		ok, err, index := aggregaterange.VerifyBatch(bulletProofList)
+		ok, err, index := oneoutofmany.VerifyBatch(proofs.oneOfManyProofs)
+		if !ok {
+			txIndex := getBatchTxIndex(proofs.oneOfManyTxIndex, index)
+			Logger.log.Errorf("FAILED VERIFICATION BATCH ONE OUT OF MANY PROOF %d", txIndex)
+			return false, NewTransactionErr(VerifyOneOutOfManyProofFailedErr, fmt.Errorf("FAILED VERIFICATION BATCH ONE OUT OF MANY PROOF %d %v", txIndex, err)), txIndex
+		}
+		ok, err, index = serialnumberprivacy.VerifyBatch(proofs.serialNumberProofs)
+		if !ok {
+			txIndex := getBatchTxIndex(proofs.serialNumberTxIndex, index)
+			Logger.log.Errorf("FAILED VERIFICATION BATCH SERIAL NUMBER PROOF %d", txIndex)
+			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH SERIAL NUMBER PROOF %d %v", txIndex, err)), txIndex
+		}
+
+		ok, err, index = aggregaterange.VerifyBatch(proofs.bulletProofs)
 		if err != nil {
 			return false, NewTransactionErr(TxProofVerifyFailError, err), -1
 		}
 		if !ok {
-			Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 2 %d", index)
-			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", index)), index
+			txIndex := getBatchTxIndex(proofs.bulletProofTxIndex, index)
+			Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 2 %d", txIndex)
+			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", txIndex)), txIndex
 		}
 		return true, nil, -1
 	} else {
-		ok, err, index := aggregaterange.VerifyBatchOld(bulletProofList)
+		ok, err, index := aggregaterange.VerifyBatchOld(proofs.bulletProofs)
 		if err != nil {
 			return false, NewTransactionErr(TxProofVerifyFailError, err), -1
 		}
 		if !ok {
-			Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 %d", index)
-			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", index)), index
+			txIndex := getBatchTxIndex(proofs.bulletProofTxIndex, index)
+			Logger.log.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF VER 1 %d", txIndex)
+			return false, NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("FAILED VERIFICATION BATCH PAYMENT PROOF %d", txIndex)), txIndex
 		}
 		return true, nil, -1
 	}
 }
 
+// addTx collects the signature and the privacy proofs of tx, the same ones ValidateTransaction skips in batch mode
+func (proofs *batchProofs) addTx(tx *Tx, txIndex int, isNewZKP bool) error {
+	if tx.IsSalaryTx() {
+		return nil
+	}
+	verifyKey, signature, err := tx.getSigVerifyKey()
+	if err != nil {
+		return err
+	}
+	proofs.sigPubKeys = append(proofs.sigPubKeys, verifyKey)
+	proofs.sigs = append(proofs.sigs, signature)
+	proofs.sigData = append(proofs.sigData, tx.Hash()[:])
+	proofs.sigTxIndex = append(proofs.sigTxIndex, txIndex)
+
+	if tx.GetType() == common.TxReturnStakingType || !tx.IsPrivacy() {
+		return nil
+	}
+	proofs.bulletProofs = append(proofs.bulletProofs, tx.Proof.GetAggregatedRangeProof())
+	proofs.bulletProofTxIndex = append(proofs.bulletProofTxIndex, txIndex)
+	if isNewZKP {
+		for _, proof := range tx.Proof.GetOneOfManyProof() {
+			proofs.oneOfManyProofs = append(proofs.oneOfManyProofs, proof)
+			proofs.oneOfManyTxIndex = append(proofs.oneOfManyTxIndex, txIndex)
+		}
+		for _, proof := range tx.Proof.GetSerialNumberProof() {
+			proofs.serialNumberProofs = append(proofs.serialNumberProofs, proof)
+			proofs.serialNumberTxIndex = append(proofs.serialNumberTxIndex, txIndex)
+		}
+	}
+	return nil
+}
+
+// getBatchTxIndex maps the index of a failed proof in a batch to the index of its transaction
+func getBatchTxIndex(txIndex []int, proofIndex int) int {
+	if proofIndex < 0 || proofIndex >= len(txIndex) {
+		return -1
+	}
+	return txIndex[proofIndex]
+}
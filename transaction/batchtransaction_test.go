@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBatchProofs returns a chunk with one valid signature for every index of txIndex
+func newTestBatchProofs(t *testing.T, txIndex []int) *batchProofs {
+	proofs := &batchProofs{}
+	for _, index := range txIndex {
+		privateKey := new(privacy.SchnorrPrivateKey)
+		privateKey.Set(privacy.RandomScalar(), privacy.RandomScalar())
+		data := privacy.RandomScalar().ToBytesS()
+		signature, err := privateKey.Sign(data)
+		assert.Nil(t, err)
+		proofs.sigPubKeys = append(proofs.sigPubKeys, privateKey.GetPublicKey())
+		proofs.sigs = append(proofs.sigs, signature)
+		proofs.sigData = append(proofs.sigData, data)
+		proofs.sigTxIndex = append(proofs.sigTxIndex, index)
+	}
+	return proofs
+}
+
+func TestVerifyBatchProofs(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	chunks := []*batchProofs{
+		newTestBatchProofs(t, []int{0, 1}),
+		newTestBatchProofs(t, []int{2, 3}),
+		newTestBatchProofs(t, []int{4}),
+	}
+	ok, err, index := verifyBatchProofs(chunks, false)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, index)
+
+	// a bad signature is reported with the index of its transaction in the block, not in the chunk
+	chunks[2].sigData[0] = privacy.RandomScalar().ToBytesS()
+	ok, err, index = verifyBatchProofs(chunks, false)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, index)
+
+	// the first failed chunk in block order wins whatever chunk finishes first
+	chunks[1].sigData[1] = privacy.RandomScalar().ToBytesS()
+	ok, _, index = verifyBatchProofs(chunks, false)
+	assert.False(t, ok)
+	assert.Equal(t, 3, index)
+}
+
+func TestValidateBatchEmpty(t *testing.T) {
+	ok, err, index := NewBatchTransaction(nil).ValidateParallel(nil, nil, map[string]bool{}, 4)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, index)
+}
+
+func TestGetBatchTxIndex(t *testing.T) {
+	txIndex := []int{0, 0, 2, 5}
+	assert.Equal(t, 2, getBatchTxIndex(txIndex, 2))
+	assert.Equal(t, 5, getBatchTxIndex(txIndex, 3))
+	assert.Equal(t, -1, getBatchTxIndex(txIndex, 4))
+	assert.Equal(t, -1, getBatchTxIndex(txIndex, -1))
+}
@@ -91,14 +91,20 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 		return
 	}
 	if lenCommitment.Uint64() == 1 && len(param.usableInputCoins) == 1 {
-		commitmentIndexs = []uint64{0, 0, 0, 0, 0, 0, 0}
 		temp := param.usableInputCoins[0].CoinDetails.GetCoinCommitment().ToBytesS()
-		commitments = [][]byte{temp, temp, temp, temp, temp, temp, temp}
+		for i := 0; i < cpRandNum; i++ {
+			commitmentIndexs = append(commitmentIndexs, 0)
+			commitments = append(commitments, temp)
+		}
 	} else {
 		for i := 0; i < cpRandNum; i++ {
 			for {
 				lenCommitment, _ = statedb.GetCommitmentLength(param.stateDB, *param.tokenID, param.shardID)
-				index, _ := common.RandBigIntMaxRange(lenCommitment)
+				index, err := randomDecoyIndex(lenCommitment)
+				if err != nil {
+					Logger.log.Error(err)
+					continue
+				}
 				ok, err := statedb.HasCommitmentIndex(param.stateDB, *param.tokenID, index.Uint64(), param.shardID)
 				if ok && err == nil {
 					temp, _ := statedb.GetCommitmentByIndex(param.stateDB, *param.tokenID, index.Uint64(), param.shardID)
@@ -129,6 +135,36 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 	return commitmentIndexs, myCommitmentIndexs, commitments
 }
 
+// randomDecoyIndex picks the index of a decoy commitment among lenCommitment commitments.
+// Spent coins are usually recent ones, so when decoys are picked uniformly the real coin of a ring is
+// often the most recent one. Half of the decoys are picked with a log-uniform distribution over the age
+// of commitments, which favours recent commitments, and the other half uniformly, so old coins still
+// have decoys of the same age.
+func randomDecoyIndex(lenCommitment *big.Int) (*big.Int, error) {
+	if lenCommitment.Uint64() <= 1 {
+		return common.RandBigIntMaxRange(lenCommitment)
+	}
+	choice, err := common.RandBigIntMaxRange(big.NewInt(2))
+	if err != nil {
+		return nil, err
+	}
+	if choice.Uint64() == 0 {
+		return common.RandBigIntMaxRange(lenCommitment)
+	}
+	// u is uniform in [0, 1), age = lenCommitment^u - 1 is in [0, lenCommitment - 1]
+	precision := new(big.Int).Lsh(big.NewInt(1), 53)
+	r, err := common.RandBigIntMaxRange(precision)
+	if err != nil {
+		return nil, err
+	}
+	u := float64(r.Uint64()) / float64(precision.Uint64())
+	age := uint64(math.Pow(float64(lenCommitment.Uint64()), u)) - 1
+	if age >= lenCommitment.Uint64() {
+		age = lenCommitment.Uint64() - 1
+	}
+	return new(big.Int).SetUint64(lenCommitment.Uint64() - 1 - age), nil
+}
+
 // CheckSNDerivatorExistence return true if snd exists in snDerivators list
 func CheckSNDerivatorExistence(tokenID *common.Hash, snd *privacy.Scalar, stateDB *statedb.StateDB) (bool, error) {
 	ok, err := statedb.HasSNDerivator(stateDB, *tokenID, snd.ToBytesS())
@@ -145,6 +181,7 @@ type EstimateTxSizeParam struct {
 	metadata                 metadata.Metadata
 	privacyCustomTokenParams *CustomTokenPrivacyParamTx
 	limitFee                 uint64
+	ringSize                 int // default is 0 -> use privacy.CommitmentRingSize
 }
 
 func NewEstimateTxSizeParam(numInputCoins, numPayments int,
@@ -162,6 +199,11 @@ func NewEstimateTxSizeParam(numInputCoins, numPayments int,
 	return estimateTxSizeParam
 }
 
+// SetRingSize sets the ring size of the input coins of the estimated tx, see TxPrivacyInitParams.SetRingSize
+func (param *EstimateTxSizeParam) SetRingSize(ringSize int) {
+	param.ringSize = ringSize
+}
+
 // EstimateTxSize returns the estimated size of the tx in kilobyte
 func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 
@@ -178,12 +220,16 @@ func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 		sizeSig = uint64(common.SigPrivacySize)
 	}
 
+	ringSize := estimateTxSizeParam.ringSize
+	if ringSize == 0 {
+		ringSize = privacy.CommitmentRingSize
+	}
 	sizeProof := uint64(0)
 	if estimateTxSizeParam.numInputCoins != 0 || estimateTxSizeParam.numPayments != 0 {
-		sizeProof = utils.EstimateProofSize(estimateTxSizeParam.numInputCoins, estimateTxSizeParam.numPayments, estimateTxSizeParam.hasPrivacy)
+		sizeProof = utils.EstimateProofSizeWithRingSize(estimateTxSizeParam.numInputCoins, estimateTxSizeParam.numPayments, estimateTxSizeParam.hasPrivacy, ringSize)
 	} else {
 		if estimateTxSizeParam.limitFee > 0 {
-			sizeProof = utils.EstimateProofSize(1, 1, estimateTxSizeParam.hasPrivacy)
+			sizeProof = utils.EstimateProofSizeWithRingSize(1, 1, estimateTxSizeParam.hasPrivacy, ringSize)
 		}
 	}
 
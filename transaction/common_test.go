@@ -3,14 +3,15 @@ package transaction
 import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
-	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
+	"math/big"
 	"os"
 	"testing"
 )
@@ -74,12 +75,13 @@ func TestRandomCommitmentsProcess(t *testing.T) {
 	key, _ := wallet.Base58CheckDeserialize("112t8rnXCqbbNYBquntyd6EvDT4WiDDQw84ZSRDKmazkqrzi6w8rWyCVt7QEZgAiYAV4vhJiX7V9MCfuj4hGLoDN7wdU1LoWGEFpLs59X7K3")
 	_ = key.KeySet.InitFromPrivateKey(&key.KeySet.PrivateKey)
 	paymentAddress := key.KeySet.PaymentAddress
+	db := newTestStateDB()
 	tx1 := &Tx{}
 	err := tx1.InitTxSalary(10, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
 	if err != nil {
 		t.Error(err)
 	}
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx1.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	statedb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx1.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 
 	in1 := ConvertOutputCoinToInputCoin(tx1.Proof.GetOutputCoins())
 
@@ -93,10 +95,10 @@ func TestRandomCommitmentsProcess(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx2.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	statedb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx2.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 	tx3 := &Tx{}
 	err = tx3.InitTxSalary(5, &paymentAddress, &key.KeySet.PrivateKey, db, nil)
-	rawdb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx3.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
+	statedb.StoreCommitments(db, common.Hash{}, paymentAddress.Pk, [][]byte{tx3.Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()}, 0)
 	in2 := ConvertOutputCoinToInputCoin(tx2.Proof.GetOutputCoins())
 	in := append(in1, in2...)
 
@@ -105,30 +107,40 @@ func TestRandomCommitmentsProcess(t *testing.T) {
 	assert.Equal(t, 16, len(cmm))
 	assert.Equal(t, 2, len(myIndexs))
 
-	rawdb.CleanCommitments(db)
-	cmmIndexs1, myCommIndex1, cmm1 := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, db, 0, &common.Hash{}))
+	cmmIndexs1, myCommIndex1, cmm1 := RandomCommitmentsProcess(NewRandomCommitmentsProcessParam(in, 0, newTestStateDB(), 0, &common.Hash{}))
 	assert.Equal(t, 0, len(cmmIndexs1))
 	assert.Equal(t, 0, len(myCommIndex1))
 	assert.Equal(t, 0, len(cmm1))
 }
 
-var db incdb.Database
+var diskDB incdb.Database
+var db *statedb.StateDB
 var _ = func() (_ struct{}) {
 	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
 	if err != nil {
 		log.Fatalf("failed to create temp dir: %+v", err)
 	}
 	log.Println(dbPath)
-	db, err = incdb.Open("leveldb", dbPath)
+	diskDB, err = incdb.Open("leveldb", dbPath)
 	if err != nil {
 		log.Fatalf("could not open db path: %s, %+v", dbPath, err)
 	}
+	db = newTestStateDB()
 	incdb.Logger.Init(common.NewBackend(nil).Logger("db", true))
 	Logger.Init(common.NewBackend(nil).Logger("tx", true))
 	privacy.Logger.Init(common.NewBackend(nil).Logger("privacy", true))
 	return
 }()
 
+// newTestStateDB returns an empty state db on the test disk db
+func newTestStateDB() *statedb.StateDB {
+	stateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	if err != nil {
+		log.Fatalf("could not create state db: %+v", err)
+	}
+	return stateDB
+}
+
 func TestBuildCoinbaseTxByCoinID(t *testing.T) {
 	key, err := wallet.Base58CheckDeserialize("112t8rnXCqbbNYBquntyd6EvDT4WiDDQw84ZSRDKmazkqrzi6w8rWyCVt7QEZgAiYAV4vhJiX7V9MCfuj4hGLoDN7wdU1LoWGEFpLs59X7K3")
 	assert.Equal(t, nil, err)
@@ -136,15 +148,42 @@ func TestBuildCoinbaseTxByCoinID(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 
-	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	//assert.Equal(t, uint64(10), tx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetValue())
 	assert.Equal(t, common.PRVCoinID.String(), tx.GetTokenID().String())
 
-	txCustomTokenPrivacy, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{2}, CustomTokenPrivacyType, "Custom Token", 0))
+	txCustomTokenPrivacy, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{2}, CustomTokenPrivacyType, "Custom Token", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	//assert.Equal(t, uint64(10), txCustomTokenPrivacy.(*TxCustomTokenPrivacy).TxPrivacyTokenData.TxNormal.Proof.GetOutputCoins()[0].CoinDetails.GetValue())
 	assert.Equal(t, common.Hash{2}.String(), txCustomTokenPrivacy.GetTokenID().String())
 }
+
+func TestRandomDecoyIndex(t *testing.T) {
+	for _, lenCommitment := range []int64{1, 2, 7} {
+		for i := 0; i < 100; i++ {
+			index, err := randomDecoyIndex(big.NewInt(lenCommitment))
+			assert.Nil(t, err)
+			assert.True(t, index.Int64() >= 0 && index.Int64() < lenCommitment, "index %v of %v commitments", index, lenCommitment)
+		}
+	}
+
+	// recent commitments are picked more often than with a uniform distribution
+	lenCommitment := int64(1000000)
+	recent := 0
+	numSamples := 2000
+	for i := 0; i < numSamples; i++ {
+		index, err := randomDecoyIndex(big.NewInt(lenCommitment))
+		assert.Nil(t, err)
+		assert.True(t, index.Int64() >= 0 && index.Int64() < lenCommitment)
+		if index.Int64() >= lenCommitment-lenCommitment/100 {
+			recent++
+		}
+	}
+	// uniform decoys give 1% of the samples in the most recent 1% of commitments, the log-uniform half gives about 33%
+	assert.True(t, recent > numSamples/10, "%v of %v decoys are recent", recent, numSamples)
+	// old commitments still get decoys
+	assert.True(t, recent < numSamples/2, "%v of %v decoys are recent", recent, numSamples)
+}
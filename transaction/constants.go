@@ -1,8 +1,11 @@
 package transaction
 
 const (
-	// txVersion is the current latest supported transaction version.
-	txVersion                        = 1
+	// txVersion is the version of transactions using the default ring size privacy.CommitmentRingSize.
+	txVersion = 1
+	// txVersionRingSize is the latest supported transaction version, transactions of this version use
+	// a larger ring size which is supported by the chain params from BCHeightBreakPointRingSize.
+	txVersionRingSize                = 2
 	ValidateTimeForOneoutOfManyProof = 1574985600 // GMT: Friday, November 29, 2019 12:00:00 AM
 )
 
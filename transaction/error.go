@@ -65,6 +65,7 @@ const (
 	RejectTxType
 	RejectTxInfoSize
 	RejectTxMedataWithBlockChain
+	RejectTxRingSize
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -114,6 +115,7 @@ var ErrCodeMessage = map[int]struct {
 	RejectTxMedataWithBlockChain:                  {-1039, "Reject invalid metadata with blockchain"},
 	BatchTxProofVerifyFailError:                   {-1040, "Can not verify proof of batch txs %s"},
 	VerifyOneOutOfManyProofFailedErr:              {-1041, "Verify one out of many proof failed"},
+	RejectTxRingSize:                              {-1042, "Ring size of tx is not supported"},
 
 	// for PRV
 	InvalidSanityDataPRVError:  {-2000, "Invalid sanity data for PRV"},
@@ -0,0 +1,296 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// unsignedTxBundleVersion is the latest version of the unsigned tx bundle format
+const unsignedTxBundleVersion = 1
+
+// UnsignedTxBundle contains everything needed to create a PRV transaction except the private key of the sender:
+// the chosen input coins, the decoy commitments with their indices, the outputs, the fee and the metadata.
+// It is built by a node, which can access the chain, and signed by SignUnsignedTxBundle on a machine keeping the key.
+type UnsignedTxBundle struct {
+	Version              int
+	SenderPaymentAddress string
+	InputCoins           []string // base58 check encoded input coins
+	PaymentInfos         []UnsignedTxBundlePayment
+	Fee                  uint64
+	HasPrivacy           bool
+	Info                 []byte
+	Metadata             *json.RawMessage
+	CommitmentIndices    []uint64
+	Commitments          []string // base58 check encoded commitments at CommitmentIndices
+	MyCommitmentIndices  []uint64
+	SNDOutputs           []string // base58 check encoded snd of output coins, the change output included
+}
+
+// UnsignedTxBundlePayment is a payment of an unsigned tx bundle, the change output for the sender is not listed
+type UnsignedTxBundlePayment struct {
+	PaymentAddress string
+	Amount         uint64
+	Message        []byte
+}
+
+// DeriveSerialNumbers sets the serial numbers of inputCoins, which can only be derived with the private key of their owner,
+// it does not access the chain so it runs on the machine keeping the key, before the bundle of the coins is built
+func DeriveSerialNumbers(inputCoins []*privacy.InputCoin, senderSK *privacy.PrivateKey) {
+	skScalar := new(privacy.Scalar).FromBytesS(*senderSK)
+	for _, inputCoin := range inputCoins {
+		serialNumber := new(privacy.Point).Derive(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], skScalar, inputCoin.CoinDetails.GetSNDerivator())
+		inputCoin.CoinDetails.SetSerialNumber(serialNumber)
+	}
+}
+
+// NewUnsignedTxBundle chooses decoy commitments for inputCoins among commitments in stateDB
+// and output snds, then returns the bundle which can be signed offline.
+// inputCoins must carry their serial numbers, see DeriveSerialNumbers, so the caller can check they are not spent.
+func NewUnsignedTxBundle(
+	senderPaymentAddress privacy.PaymentAddress,
+	inputCoins []*privacy.InputCoin,
+	paymentInfos []*privacy.PaymentInfo,
+	fee uint64,
+	hasPrivacy bool,
+	ringSize int,
+	stateDB *statedb.StateDB,
+	metaData metadata.Metadata,
+	info []byte,
+) (*UnsignedTxBundle, error) {
+	if len(inputCoins) > 255 {
+		return nil, NewTransactionErr(InputCoinIsVeryLargeError, nil, fmt.Sprint(len(inputCoins)))
+	}
+	if len(paymentInfos) > 254 {
+		return nil, NewTransactionErr(PaymentInfoIsVeryLargeError, nil, fmt.Sprint(len(paymentInfos)))
+	}
+	if len(info) > MaxSizeInfo {
+		return nil, NewTransactionErr(ExceedSizeInfoTxError, nil)
+	}
+	if len(senderPaymentAddress.Pk) == 0 {
+		return nil, NewTransactionErr(UnexpectedError, errors.New("sender payment address is invalid"))
+	}
+	if ringSize == 0 {
+		ringSize = privacy.CommitmentRingSize
+	}
+	if _, err := getTxVersionByRingSize(ringSize); err != nil {
+		return nil, err
+	}
+
+	senderKey := wallet.KeyWallet{}
+	senderKey.KeySet.PaymentAddress = senderPaymentAddress
+	bundle := &UnsignedTxBundle{
+		Version:              unsignedTxBundleVersion,
+		SenderPaymentAddress: senderKey.Base58CheckSerialize(wallet.PaymentAddressType),
+		Fee:                  fee,
+		HasPrivacy:           hasPrivacy,
+		Info:                 info,
+	}
+
+	sumInputValue := uint64(0)
+	for i, inputCoin := range inputCoins {
+		if inputCoin.CoinDetails.GetSerialNumber() == nil {
+			return nil, NewTransactionErr(WrongInputError, fmt.Errorf("serial number of input coin %d is missing", i))
+		}
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+		bundle.InputCoins = append(bundle.InputCoins, base58.Base58Check{}.Encode(inputCoin.Bytes(), common.ZeroByte))
+	}
+	sumOutputValue := uint64(0)
+	for _, paymentInfo := range paymentInfos {
+		sumOutputValue += paymentInfo.Amount
+		receiverKey := wallet.KeyWallet{}
+		receiverKey.KeySet.PaymentAddress = paymentInfo.PaymentAddress
+		bundle.PaymentInfos = append(bundle.PaymentInfos, UnsignedTxBundlePayment{
+			PaymentAddress: receiverKey.Base58CheckSerialize(wallet.PaymentAddressType),
+			Amount:         paymentInfo.Amount,
+			Message:        paymentInfo.Message,
+		})
+	}
+	if sumInputValue < sumOutputValue+fee {
+		return nil, NewTransactionErr(WrongInputError, fmt.Errorf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, fee))
+	}
+
+	if metaData != nil {
+		metaDataBytes, err := json.Marshal(metaData)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+		rawMetadata := json.RawMessage(metaDataBytes)
+		bundle.Metadata = &rawMetadata
+	}
+
+	tokenID := &common.Hash{}
+	err := tokenID.SetBytes(common.PRVCoinID[:])
+	if err != nil {
+		return nil, NewTransactionErr(TokenIDInvalidError, err, tokenID.String())
+	}
+	if hasPrivacy && len(inputCoins) > 0 {
+		shardID := common.GetShardIDFromLastByte(senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1])
+		randomParams := NewRandomCommitmentsProcessParam(inputCoins, ringSize, stateDB, shardID, tokenID)
+		commitmentIndices, myCommitmentIndices, commitments := RandomCommitmentsProcess(randomParams)
+		if len(commitmentIndices) != len(inputCoins)*ringSize || len(commitments) != len(commitmentIndices) {
+			return nil, NewTransactionErr(RandomCommitmentError, nil)
+		}
+		if len(myCommitmentIndices) != len(inputCoins) {
+			return nil, NewTransactionErr(RandomCommitmentError, errors.New("number of list my commitment indices must be equal to number of input coins"))
+		}
+		bundle.CommitmentIndices = commitmentIndices
+		bundle.MyCommitmentIndices = myCommitmentIndices
+		for _, commitment := range commitments {
+			bundle.Commitments = append(bundle.Commitments, base58.Base58Check{}.Encode(commitment, common.ZeroByte))
+		}
+	}
+
+	// the signer adds an output for the change, it needs a snd too
+	numOutputs := len(paymentInfos)
+	if sumInputValue > sumOutputValue+fee {
+		numOutputs++
+	}
+	sndOutputs := make([]*privacy.Scalar, 0, numOutputs)
+	for len(sndOutputs) < numOutputs {
+		sndOut := privacy.RandomScalar()
+		existed, err := CheckSNDerivatorExistence(tokenID, sndOut, stateDB)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+		// CheckDuplicateScalarArray sorts its argument, it must not share the array of sndOutputs
+		if existed || privacy.CheckDuplicateScalarArray(append(append([]*privacy.Scalar{}, sndOutputs...), sndOut)) {
+			continue
+		}
+		sndOutputs = append(sndOutputs, sndOut)
+	}
+	for _, sndOut := range sndOutputs {
+		bundle.SNDOutputs = append(bundle.SNDOutputs, base58.Base58Check{}.Encode(sndOut.ToBytesS(), common.ZeroByte))
+	}
+	return bundle, nil
+}
+
+// EncodeUnsignedTxBundle serializes bundle to a base58 check string
+func EncodeUnsignedTxBundle(bundle *UnsignedTxBundle) (string, error) {
+	bundleBytes, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+	return base58.Base58Check{}.Encode(bundleBytes, common.ZeroByte), nil
+}
+
+// DecodeUnsignedTxBundle parses a bundle serialized by EncodeUnsignedTxBundle
+func DecodeUnsignedTxBundle(data string) (*UnsignedTxBundle, error) {
+	bundleBytes, _, err := base58.Base58Check{}.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &UnsignedTxBundle{}
+	err = json.Unmarshal(bundleBytes, bundle)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.Version <= 0 || bundle.Version > unsignedTxBundleVersion {
+		return nil, fmt.Errorf("unsigned tx bundle version %d is not supported", bundle.Version)
+	}
+	return bundle, nil
+}
+
+// SignUnsignedTxBundle builds the proof and the signature of the transaction described by bundle with senderSK,
+// it does not access the chain so it can run on an air-gapped machine
+func SignUnsignedTxBundle(bundle *UnsignedTxBundle, senderSK *privacy.PrivateKey, serverTime int64) (*Tx, error) {
+	senderKey := wallet.KeyWallet{}
+	err := senderKey.KeySet.InitFromPrivateKey(senderSK)
+	if err != nil {
+		return nil, NewTransactionErr(PrivateKeySenderInvalidError, err)
+	}
+	if senderKey.Base58CheckSerialize(wallet.PaymentAddressType) != bundle.SenderPaymentAddress {
+		return nil, NewTransactionErr(PrivateKeySenderInvalidError, errors.New("private key does not belong to the sender of the bundle"))
+	}
+
+	inputCoins := make([]*privacy.InputCoin, len(bundle.InputCoins))
+	bundleSerialNumbers := make([]*privacy.Point, len(bundle.InputCoins))
+	for i, inputCoinStr := range bundle.InputCoins {
+		inputCoinBytes, _, err := base58.Base58Check{}.Decode(inputCoinStr)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+		inputCoins[i] = new(privacy.InputCoin).Init()
+		err = inputCoins[i].SetBytes(inputCoinBytes)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+		bundleSerialNumbers[i] = inputCoins[i].CoinDetails.GetSerialNumber()
+	}
+	// the node checked the serial numbers of the bundle are not spent, they must be the ones the key derives
+	DeriveSerialNumbers(inputCoins, senderSK)
+	for i, inputCoin := range inputCoins {
+		if bundleSerialNumbers[i] == nil || !privacy.IsPointEqual(bundleSerialNumbers[i], inputCoin.CoinDetails.GetSerialNumber()) {
+			return nil, NewTransactionErr(WrongInputError, fmt.Errorf("serial number of input coin %d does not match the private key", i))
+		}
+	}
+
+	paymentInfos := make([]*privacy.PaymentInfo, len(bundle.PaymentInfos))
+	for i, payment := range bundle.PaymentInfos {
+		receiverKey, err := wallet.Base58CheckDeserialize(payment.PaymentAddress)
+		if err != nil {
+			return nil, NewTransactionErr(DecompressPaymentAddressError, err, payment.PaymentAddress)
+		}
+		paymentInfos[i] = &privacy.PaymentInfo{
+			PaymentAddress: receiverKey.KeySet.PaymentAddress,
+			Amount:         payment.Amount,
+			Message:        payment.Message,
+		}
+	}
+
+	commitmentBytes := make([][]byte, len(bundle.Commitments))
+	for i, commitment := range bundle.Commitments {
+		commitmentBytes[i], _, err = base58.Base58Check{}.Decode(commitment)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+	}
+	sndOutputs := make([]*privacy.Scalar, len(bundle.SNDOutputs))
+	for i, sndOut := range bundle.SNDOutputs {
+		sndBytes, _, err := base58.Base58Check{}.Decode(sndOut)
+		if err != nil {
+			return nil, NewTransactionErr(UnexpectedError, err)
+		}
+		sndOutputs[i] = new(privacy.Scalar).FromBytesS(sndBytes)
+	}
+	// the change output takes the last snd
+	sumInputValue := uint64(0)
+	for _, inputCoin := range inputCoins {
+		sumInputValue += inputCoin.CoinDetails.GetValue()
+	}
+	sumOutputValue := bundle.Fee
+	for _, paymentInfo := range paymentInfos {
+		sumOutputValue += paymentInfo.Amount
+	}
+	numOutputs := len(paymentInfos)
+	if sumInputValue > sumOutputValue {
+		numOutputs++
+	}
+	if len(sndOutputs) != numOutputs {
+		return nil, NewTransactionErr(UnexpectedError, fmt.Errorf("bundle has %d output snds for %d outputs", len(sndOutputs), numOutputs))
+	}
+
+	var metaData metadata.Metadata
+	if bundle.Metadata != nil {
+		metaData, err = metadata.ParseMetadata(bundle.Metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	params := NewTxPrivacyInitParamsForASM(senderSK, paymentInfos, inputCoins, bundle.Fee, bundle.HasPrivacy, nil, metaData, bundle.Info,
+		bundle.CommitmentIndices, commitmentBytes, bundle.MyCommitmentIndices, sndOutputs)
+	tx := &Tx{}
+	err = tx.InitForASM(params, serverTime)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
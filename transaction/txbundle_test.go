@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTxBundleStateDB returns a state db with a minted coin of the sender, whose commitment is stored,
+// and the coin as an input coin without serial number, as listoutputcoins returns it
+func newTestTxBundleStateDB(t *testing.T, senderKey *wallet.KeyWallet, amount uint64) (*statedb.StateDB, []*privacy.InputCoin) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_txbundle_")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dbPath) })
+	diskDB, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	stateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskDB))
+	assert.Nil(t, err)
+
+	paymentAddress := senderKey.KeySet.PaymentAddress
+	shardID := common.GetShardIDFromLastByte(paymentAddress.Pk[len(paymentAddress.Pk)-1])
+	coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, amount, &senderKey.KeySet.PrivateKey, stateDB, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
+	assert.Nil(t, err)
+	outputCoins := coinBaseTx.(*Tx).Proof.GetOutputCoins()
+	err = statedb.StoreCommitments(stateDB, common.PRVCoinID, paymentAddress.Pk, [][]byte{outputCoins[0].CoinDetails.GetCoinCommitment().ToBytesS()}, shardID)
+	assert.Nil(t, err)
+
+	inputCoins := ConvertOutputCoinToInputCoin(outputCoins)
+	for _, inputCoin := range inputCoins {
+		inputCoin.CoinDetails.SetSerialNumber(nil)
+	}
+	return stateDB, inputCoins
+}
+
+func newTestTxBundleKeys(t *testing.T) (*wallet.KeyWallet, *wallet.KeyWallet) {
+	masterKey, err := wallet.NewMasterKey(privacy.RandomScalar().ToBytesS())
+	assert.Nil(t, err)
+	senderKey, err := masterKey.NewChildKey(1)
+	assert.Nil(t, err)
+	receiverKey, err := masterKey.NewChildKey(2)
+	assert.Nil(t, err)
+	return senderKey, receiverKey
+}
+
+func TestUnsignedTxBundleRoundTrip(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	privacy.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	tests := []struct {
+		name       string
+		hasPrivacy bool
+		withMeta   bool
+	}{
+		{"no privacy", false, false},
+		{"privacy", true, false},
+		{"metadata", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			senderKey, receiverKey := newTestTxBundleKeys(t)
+			stateDB, inputCoins := newTestTxBundleStateDB(t, senderKey, 1000)
+			paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiverKey.KeySet.PaymentAddress, Amount: 600}}
+			var meta metadata.Metadata
+			if tt.withMeta {
+				var err error
+				meta, err = metadata.NewStopAutoStakingMetadata(metadata.StopAutoStakingMeta, "committee-key")
+				assert.Nil(t, err)
+			}
+
+			// coins of listoutputcoins have no serial number, they can not be checked against the spent ones
+			_, err := NewUnsignedTxBundle(senderKey.KeySet.PaymentAddress, inputCoins, paymentInfos, 10, tt.hasPrivacy, 0, stateDB, meta, []byte("info"))
+			assert.NotNil(t, err)
+
+			DeriveSerialNumbers(inputCoins, &senderKey.KeySet.PrivateKey)
+			bundle, err := NewUnsignedTxBundle(senderKey.KeySet.PaymentAddress, inputCoins, paymentInfos, 10, tt.hasPrivacy, 0, stateDB, meta, []byte("info"))
+			assert.Nil(t, err)
+			// the change output needs a snd too
+			assert.Equal(t, 2, len(bundle.SNDOutputs))
+			if tt.hasPrivacy {
+				assert.Equal(t, privacy.CommitmentRingSize, len(bundle.CommitmentIndices))
+			}
+
+			bundleStr, err := EncodeUnsignedTxBundle(bundle)
+			assert.Nil(t, err)
+			decodedBundle, err := DecodeUnsignedTxBundle(bundleStr)
+			assert.Nil(t, err)
+			assert.Equal(t, bundle, decodedBundle)
+
+			tx, err := SignUnsignedTxBundle(decodedBundle, &senderKey.KeySet.PrivateKey, time.Now().Unix())
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(10), tx.Fee)
+			assert.Equal(t, []byte("info"), tx.Info)
+			assert.True(t, privacy.IsPointEqual(inputCoins[0].CoinDetails.GetSerialNumber(), tx.Proof.GetInputCoins()[0].CoinDetails.GetSerialNumber()))
+			if tt.withMeta {
+				assert.NotNil(t, tx.Metadata)
+				assert.Equal(t, metadata.StopAutoStakingMeta, tx.Metadata.GetType())
+			} else {
+				assert.Nil(t, tx.Metadata)
+			}
+
+			// the node validates the transaction parsed from sendtransaction
+			txBytes, err := json.Marshal(tx)
+			assert.Nil(t, err)
+			sentTx := &Tx{}
+			err = json.Unmarshal(txBytes, sentTx)
+			assert.Nil(t, err)
+			shardID := common.GetShardIDFromLastByte(sentTx.GetSenderAddrLastByte())
+			valid, err := sentTx.ValidateTransaction(map[string]bool{"hasPrivacy": tt.hasPrivacy, "isNewTransaction": true}, stateDB, nil, shardID, &common.PRVCoinID)
+			assert.Nil(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
+
+func TestSignUnsignedTxBundle_Invalid(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	senderKey, receiverKey := newTestTxBundleKeys(t)
+	stateDB, inputCoins := newTestTxBundleStateDB(t, senderKey, 1000)
+	DeriveSerialNumbers(inputCoins, &senderKey.KeySet.PrivateKey)
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiverKey.KeySet.PaymentAddress, Amount: 990}}
+	bundle, err := NewUnsignedTxBundle(senderKey.KeySet.PaymentAddress, inputCoins, paymentInfos, 10, true, 0, stateDB, nil, nil)
+	assert.Nil(t, err)
+	// no change output
+	assert.Equal(t, 1, len(bundle.SNDOutputs))
+
+	// the key of another account can not sign
+	_, err = SignUnsignedTxBundle(bundle, &receiverKey.KeySet.PrivateKey, time.Now().Unix())
+	assert.NotNil(t, err)
+
+	// the node checked the serial numbers of the bundle, a bundle with other serial numbers is refused
+	DeriveSerialNumbers(inputCoins, &receiverKey.KeySet.PrivateKey)
+	forgedBundle, err := NewUnsignedTxBundle(senderKey.KeySet.PaymentAddress, inputCoins, paymentInfos, 10, true, 0, stateDB, nil, nil)
+	assert.Nil(t, err)
+	_, err = SignUnsignedTxBundle(forgedBundle, &senderKey.KeySet.PrivateKey, time.Now().Unix())
+	assert.NotNil(t, err)
+
+	// more outputs than the bundle has snds for
+	DeriveSerialNumbers(inputCoins, &senderKey.KeySet.PrivateKey)
+	bundle.PaymentInfos[0].Amount = 900
+	_, err = SignUnsignedTxBundle(bundle, &senderKey.KeySet.PrivateKey, time.Now().Unix())
+	assert.NotNil(t, err)
+
+	_, err = DecodeUnsignedTxBundle("invalid")
+	assert.NotNil(t, err)
+	bundle.Version = unsignedTxBundleVersion + 1
+	bundleStr, err := EncodeUnsignedTxBundle(bundle)
+	assert.Nil(t, err)
+	_, err = DecodeUnsignedTxBundle(bundleStr)
+	assert.NotNil(t, err)
+}
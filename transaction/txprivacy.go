@@ -78,6 +78,20 @@ type TxPrivacyInitParams struct {
 	tokenID     *common.Hash // default is nil -> use for prv coin
 	metaData    metadata.Metadata
 	info        []byte // 512 bytes
+	ringSize    int    // default is 0 -> use privacy.CommitmentRingSize
+}
+
+// SetRingSize sets the number of commitments every input coin is hidden among,
+// a ring size other than privacy.CommitmentRingSize makes a transaction version 2
+func (param *TxPrivacyInitParams) SetRingSize(ringSize int) {
+	param.ringSize = ringSize
+}
+
+func (param TxPrivacyInitParams) getRingSize() int {
+	if param.ringSize == 0 {
+		return privacy.CommitmentRingSize
+	}
+	return param.ringSize
 }
 
 func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
@@ -109,8 +123,15 @@ func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
 // database is used like an interface which use to query info from transactionStateDB in building tx
 func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	Logger.log.Debugf("CREATING TX........\n")
-	tx.Version = txVersion
 	var err error
+	ringSize := params.getRingSize()
+	if !params.hasPrivacy && ringSize != privacy.CommitmentRingSize {
+		return NewTransactionErr(RejectTxRingSize, fmt.Errorf("ring size %d is only for privacy tx", ringSize))
+	}
+	tx.Version, err = getTxVersionByRingSize(ringSize)
+	if err != nil {
+		return err
+	}
 	if len(params.inputCoins) > 255 {
 		return NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(params.inputCoins)))
 	}
@@ -120,6 +141,7 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	limitFee := uint64(0)
 	estimateTxSizeParam := NewEstimateTxSizeParam(len(params.inputCoins), len(params.paymentInfo),
 		params.hasPrivacy, nil, nil, limitFee)
+	estimateTxSizeParam.ringSize = ringSize
 	if txSize := EstimateTxSize(estimateTxSizeParam); txSize > common.MaxTxSize {
 		return NewTransactionErr(ExceedSizeTx, nil, strconv.Itoa(int(txSize)))
 	}
@@ -190,11 +212,11 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 		if len(params.inputCoins) == 0 {
 			return NewTransactionErr(RandomCommitmentError, fmt.Errorf("input is empty"))
 		}
-		randomParams := NewRandomCommitmentsProcessParam(params.inputCoins, privacy.CommitmentRingSize, params.stateDB, shardID, params.tokenID)
+		randomParams := NewRandomCommitmentsProcessParam(params.inputCoins, ringSize, params.stateDB, shardID, params.tokenID)
 		commitmentIndexs, myCommitmentIndexs, _ = RandomCommitmentsProcess(randomParams)
 
 		// Check number of list of random commitments, list of random commitment indices
-		if len(commitmentIndexs) != len(params.inputCoins)*privacy.CommitmentRingSize {
+		if len(commitmentIndexs) != len(params.inputCoins)*ringSize {
 			return NewTransactionErr(RandomCommitmentError, nil)
 		}
 
@@ -423,22 +445,36 @@ func (tx *Tx) signTx() error {
 
 // verifySigTx - verify signature on tx
 func (tx *Tx) verifySigTx() (bool, error) {
+	/****** verify Schnorr signature *****/
+	verifyKey, signature, err := tx.getSigVerifyKey()
+	if err != nil {
+		return false, err
+	}
+
+	// verify signature
+	/*Logger.log.Debugf(" VERIFY SIGNATURE ----------- HASH: %v\n", tx.Hash()[:])
+	if tx.Proof != nil {
+		Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX Proof bytes before verifing the signature: %v\n", tx.Proof.Bytes())
+	}
+	Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX meta: %v\n", tx.Metadata)*/
+	res := verifyKey.Verify(signature, tx.Hash()[:])
+
+	return res, nil
+}
+
+// getSigVerifyKey - parse the public key and the Schnorr signature on tx
+func (tx *Tx) getSigVerifyKey() (*privacy.SchnorrPublicKey, *privacy.SchnSignature, error) {
 	// check input transaction
 	if tx.Sig == nil || tx.SigPubKey == nil {
-		return false, NewTransactionErr(UnexpectedError, errors.New("input transaction must be an signed one"))
+		return nil, nil, NewTransactionErr(UnexpectedError, errors.New("input transaction must be an signed one"))
 	}
 
-	var err error
-	res := false
-
-	/****** verify Schnorr signature *****/
 	// prepare Public key for verification
 	verifyKey := new(privacy.SchnorrPublicKey)
 	sigPublicKey, err := new(privacy.Point).FromBytesS(tx.SigPubKey)
-
 	if err != nil {
 		Logger.log.Error(err)
-		return false, NewTransactionErr(DecompressSigPubKeyError, err)
+		return nil, nil, NewTransactionErr(DecompressSigPubKeyError, err)
 	}
 	verifyKey.Set(sigPublicKey)
 
@@ -447,18 +483,9 @@ func (tx *Tx) verifySigTx() (bool, error) {
 	err = signature.SetBytes(tx.Sig)
 	if err != nil {
 		Logger.log.Error(err)
-		return false, NewTransactionErr(InitTxSignatureFromBytesError, err)
-	}
-
-	// verify signature
-	/*Logger.log.Debugf(" VERIFY SIGNATURE ----------- HASH: %v\n", tx.Hash()[:])
-	if tx.Proof != nil {
-		Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX Proof bytes before verifing the signature: %v\n", tx.Proof.Bytes())
+		return nil, nil, NewTransactionErr(InitTxSignatureFromBytesError, err)
 	}
-	Logger.log.Debugf(" VERIFY SIGNATURE ----------- TX meta: %v\n", tx.Metadata)*/
-	res = verifyKey.Verify(signature, tx.Hash()[:])
-
-	return res, nil
+	return verifyKey, signature, nil
 }
 
 // ValidateTransaction returns true if transaction is valid:
@@ -477,14 +504,21 @@ func (tx *Tx) ValidateTransaction(boolParams map[string]bool, transactionStateDB
 	var valid bool
 	var err error
 
-	valid, err = tx.verifySigTx()
-	if !valid {
-		if err != nil {
-			Logger.log.Errorf("Error verifying signature with tx hash %s: %+v \n", tx.Hash().String(), err)
-			return false, NewTransactionErr(VerifyTxSigFailError, err)
+	isBatch, ok := boolParams["isBatch"]
+	if !ok {
+		isBatch = false
+	}
+	// in batch mode, signatures are verified together by batchTransaction
+	if !isBatch {
+		valid, err = tx.verifySigTx()
+		if !valid {
+			if err != nil {
+				Logger.log.Errorf("Error verifying signature with tx hash %s: %+v \n", tx.Hash().String(), err)
+				return false, NewTransactionErr(VerifyTxSigFailError, err)
+			}
+			Logger.log.Errorf("FAILED VERIFICATION SIGNATURE with tx hash %s", tx.Hash().String())
+			return false, NewTransactionErr(VerifyTxSigFailError, fmt.Errorf("FAILED VERIFICATION SIGNATURE with tx hash %s", tx.Hash().String()))
 		}
-		Logger.log.Errorf("FAILED VERIFICATION SIGNATURE with tx hash %s", tx.Hash().String())
-		return false, NewTransactionErr(VerifyTxSigFailError, fmt.Errorf("FAILED VERIFICATION SIGNATURE with tx hash %s", tx.Hash().String()))
 	}
 
 	if tx.GetType() == common.TxReturnStakingType {
@@ -904,8 +938,11 @@ func (tx Tx) ValidateTxWithBlockChain(chainRetriever metadata.ChainRetriever, sh
 
 func (tx Tx) validateNormalTxSanityData(bcr metadata.ChainRetriever, beaconHeight uint64) (bool, error) {
 	//check version
-	if tx.Version > txVersion {
-		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version is %d. Wrong version tx. Only support for version >= %d", tx.Version, txVersion))
+	if tx.Version > txVersionRingSize {
+		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version is %d. Wrong version tx. Only support for version <= %d", tx.Version, txVersionRingSize))
+	}
+	if tx.Version == txVersionRingSize && !tx.IsPrivacy() {
+		return false, NewTransactionErr(RejectTxVersion, fmt.Errorf("tx version %d is only for privacy tx", tx.Version))
 	}
 	// check LockTime before now
 	if int64(tx.LockTime) > time.Now().Unix() {
@@ -947,6 +984,36 @@ func (tx Tx) validateNormalTxSanityData(bcr metadata.ChainRetriever, beaconHeigh
 	return true, nil
 }
 
+// getTxVersionByRingSize returns the version of a tx whose input coins are proved with ringSize commitments
+func getTxVersionByRingSize(ringSize int) (int8, error) {
+	if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok {
+		return 0, NewTransactionErr(RejectTxRingSize, fmt.Errorf("ring size %d is not supported", ringSize))
+	}
+	if ringSize == privacy.CommitmentRingSize {
+		return txVersion, nil
+	}
+	return txVersionRingSize, nil
+}
+
+// validateRingSize checks ringSize of tx matches its version and is supported by chain params at beaconHeight
+func (txN Tx) validateRingSize(bcr metadata.ChainRetriever, beaconHeight uint64, ringSize int) error {
+	if txN.Version < txVersionRingSize {
+		if ringSize != privacy.CommitmentRingSize {
+			return NewTransactionErr(RejectTxRingSize, fmt.Errorf("ring size of tx version %d must be %d, got %d", txN.Version, privacy.CommitmentRingSize, ringSize))
+		}
+		return nil
+	}
+	if ringSize <= privacy.CommitmentRingSize {
+		return NewTransactionErr(RejectTxRingSize, fmt.Errorf("ring size of tx version %d must be larger than %d, got %d", txN.Version, privacy.CommitmentRingSize, ringSize))
+	}
+	for _, supportedRingSize := range bcr.GetSupportedCommitmentRingSizes(beaconHeight) {
+		if ringSize == supportedRingSize {
+			return nil
+		}
+	}
+	return NewTransactionErr(RejectTxRingSize, fmt.Errorf("ring size %d is not supported at beacon height %d", ringSize, beaconHeight))
+}
+
 func (txN Tx) validateSanityDataOfProof(bcr metadata.ChainRetriever, beaconHeight uint64) (bool, error) {
 	if txN.Proof != nil {
 		if len(txN.Proof.GetInputCoins()) > 255 {
@@ -1107,10 +1174,19 @@ func (txN Tx) validateSanityDataOfProof(bcr metadata.ChainRetriever, beaconHeigh
 					return false, errors.New("validate sanity ComOutputValue of proof failed")
 				}
 			}
-			if len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*privacy.CommitmentRingSize {
+			ringSize := txN.Proof.GetRingSize()
+			for _, oneOfManyProof := range txN.Proof.GetOneOfManyProof() {
+				if oneOfManyProof.GetRingSize() != ringSize {
+					return false, errors.New("all one out of many proofs must have the same ring size")
+				}
+			}
+			if len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*ringSize {
 				return false, errors.New("validate sanity CommitmentIndices of proof failed")
 
 			}
+			if err := txN.validateRingSize(bcr, beaconHeight, ringSize); err != nil {
+				return false, err
+			}
 		}
 
 		if !isPrivacy {
@@ -1207,6 +1283,10 @@ func (tx Tx) ValidateSanityData(chainRetriever metadata.ChainRetriever, shardVie
 		}
 	}
 	Logger.log.Debugf("\n\n\n END sanity data of metadata%+v\n\n\n")
+	// a new tx is not in a block yet, its ring size is checked at the beacon height of the shard view it is validated on
+	if beaconHeight == 0 && shardViewRetriever != nil {
+		beaconHeight = shardViewRetriever.GetBeaconHeight()
+	}
 	return tx.validateNormalTxSanityData(chainRetriever, beaconHeight)
 }
 
@@ -1620,8 +1700,17 @@ func (tx *Tx) InitForASM(params *TxPrivacyInitParamsForASM, serverTime int64) er
 	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
 
 	if params.txParam.hasPrivacy {
+		// the ring size is chosen by the caller which picked the random commitments
+		ringSize := privacy.CommitmentRingSize
+		if len(params.txParam.inputCoins) > 0 {
+			ringSize = len(params.commitmentIndices) / len(params.txParam.inputCoins)
+		}
+		tx.Version, err = getTxVersionByRingSize(ringSize)
+		if err != nil {
+			return err
+		}
 		// Check number of list of random commitments, list of random commitment indices
-		if len(params.commitmentIndices) != len(params.txParam.inputCoins)*privacy.CommitmentRingSize {
+		if len(params.commitmentIndices) != len(params.txParam.inputCoins)*ringSize {
 			return NewTransactionErr(RandomCommitmentError, nil)
 		}
 
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 	"time"
 )
@@ -20,7 +22,7 @@ func TestUnmarshalJSON(t *testing.T) {
 	assert.Equal(t, nil, err)
 	paymentAddress := key.KeySet.PaymentAddress
 	responseMeta, err := metadata.NewWithDrawRewardResponse(&metadata.WithDrawRewardRequest{}, &common.Hash{})
-	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, responseMeta, common.Hash{}, NormalCoinType, "PRV", 0))
+	tx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, responseMeta, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, nil, tx)
 	assert.Equal(t, uint64(10), tx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetValue())
@@ -61,14 +63,15 @@ func TestInitTx(t *testing.T) {
 
 		// coin base tx to mint PRV
 		mintedAmount := 1000
-		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 
-		isValidSanity, err := coinBaseTx.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err := coinBaseTx.ValidateSanityData(testChainRetriever, nil, nil, 0)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValidSanity)
 
 		// store output coin's coin commitments in coin base tx
-		db.StoreCommitments(
+		statedb.StoreCommitments(
+			db,
 			common.PRVCoinID,
 			senderPaymentAddress.Pk,
 			[][]byte{coinBaseTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()},
@@ -116,7 +119,7 @@ func TestInitTx(t *testing.T) {
 		fmt.Printf("actualSize: %v\n", actualSize)
 
 		senderPubKeyLastByte := tx1.GetSenderAddrLastByte()
-		assert.Equal(t, senderKey.KeySet.PaymentAddress.Pk[len(senderKey.KeySet.PaymentAddress.Pk)-1], senderPubKeyLastByte)
+		assert.Equal(t, shardID, senderPubKeyLastByte)
 
 		actualFee := tx1.GetTxFee()
 		assert.Equal(t, uint64(fee), actualFee)
@@ -147,11 +150,11 @@ func TestInitTx(t *testing.T) {
 		assert.Equal(t, 1, len(listInputSerialNumber))
 		assert.Equal(t, common.HashH(coinBaseOutput[0].CoinDetails.GetSerialNumber().ToBytesS()), listInputSerialNumber[0])
 
-		isValidSanity, err = tx1.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err = tx1.ValidateSanityData(testChainRetriever, nil, nil, 0)
 		assert.Equal(t, true, isValidSanity)
 		assert.Equal(t, nil, err)
 
-		isValid, err := tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err := tx1.ValidateTransaction(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, shardID, nil)
 
 		fmt.Printf("Error: %v\n", err)
 		assert.Equal(t, true, isValid)
@@ -174,13 +177,13 @@ func TestInitTx(t *testing.T) {
 		//err = tx1.ValidateTxWithCurrentMempool(nil)
 		//	assert.Equal(t, nil, err)
 
-		err = tx1.ValidateDoubleSpendWithBlockchain(nil, shardID, db, nil)
+		err = tx1.ValidateDoubleSpendWithBlockchain(shardID, db, nil)
 		assert.Equal(t, nil, err)
 
-		err = tx1.ValidateTxWithBlockChain(nil, shardID, nil, nil, db)
+		err = tx1.ValidateTxWithBlockChain(nil, nil, nil, shardID, db)
 		assert.Equal(t, nil, err)
 
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, testChainRetriever, shardID, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 
@@ -272,14 +275,15 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 
 		// create coin base tx to mint PRV
 		mintedAmount := 1000
-		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+		coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&senderPaymentAddress, uint64(mintedAmount), &senderKey.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0, nil))
 
-		isValidSanity, err := coinBaseTx.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err := coinBaseTx.ValidateSanityData(testChainRetriever, nil, nil, 0)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValidSanity)
 
 		// store output coin's coin commitments in coin base tx
-		db.StoreCommitments(
+		statedb.StoreCommitments(
+			db,
 			common.PRVCoinID,
 			senderPaymentAddress.Pk,
 			[][]byte{coinBaseTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().ToBytesS()},
@@ -310,28 +314,28 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 		)
 		assert.Equal(t, nil, err)
 
-		isValidSanity, err = tx1.ValidateSanityData(nil, nil, nil)
+		isValidSanity, err = tx1.ValidateSanityData(testChainRetriever, nil, nil, 0)
 		assert.Equal(t, true, isValidSanity)
 		assert.Equal(t, nil, err)
 		fmt.Println("Hello")
-		isValid, err := tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err := tx1.ValidateTransaction(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, shardID, nil)
 		assert.Equal(t, true, isValid)
 		assert.Equal(t, nil, err)
 		fmt.Println("Hello")
-		err = tx1.ValidateDoubleSpendWithBlockchain(nil, shardID, db, nil)
+		err = tx1.ValidateDoubleSpendWithBlockchain(shardID, db, nil)
 		assert.Equal(t, nil, err)
 
-		err = tx1.ValidateTxWithBlockChain(nil, shardID, nil, nil, db)
+		err = tx1.ValidateTxWithBlockChain(nil, nil, nil, shardID, db)
 		assert.Equal(t, nil, err)
 
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, testChainRetriever, shardID, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 
 		// modify Sig
 		tx1.Sig[len(tx1.Sig)-1] = tx1.Sig[len(tx1.Sig)-1] ^ tx1.Sig[0]
 		tx1.Sig[len(tx1.Sig)-2] = tx1.Sig[len(tx1.Sig)-2] ^ tx1.Sig[1]
-		isValid, err = tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err = tx1.ValidateTransaction(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, shardID, nil)
 		assert.Equal(t, false, isValid)
 		assert.NotEqual(t, nil, err)
 		tx1.Sig[len(tx1.Sig)-1] = tx1.Sig[len(tx1.Sig)-1] ^ tx1.Sig[0]
@@ -341,7 +345,7 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 		tx1.SigPubKey[len(tx1.SigPubKey)-1] = tx1.SigPubKey[len(tx1.SigPubKey)-1] ^ tx1.SigPubKey[0]
 		tx1.SigPubKey[len(tx1.SigPubKey)-2] = tx1.SigPubKey[len(tx1.SigPubKey)-2] ^ tx1.SigPubKey[1]
 
-		isValid, err = tx1.ValidateTransaction(hasPrivacy, db, shardID, nil, false)
+		isValid, err = tx1.ValidateTransaction(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, shardID, nil)
 		assert.Equal(t, false, isValid)
 		assert.NotEqual(t, nil, err)
 
@@ -366,7 +370,7 @@ func TestInitTxWithMultiScenario(t *testing.T) {
 		tx1.Proof.SetBytes(originProof)
 
 		// back to correct case
-		isValid, err = tx1.ValidateTxByItself(hasPrivacy, db, nil, shardID, nil, nil)
+		isValid, err = tx1.ValidateTxByItself(map[string]bool{"hasPrivacy": hasPrivacy}, db, nil, testChainRetriever, shardID, nil, nil)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, true, isValid)
 	}
@@ -443,3 +447,61 @@ func ParseCoinObjectToStruct(coinObjects []CoinObject) ([]*privacy.InputCoin, ui
 
 	return coins, sumValue
 }
+
+// ringSizeChainRetriever supports ringSizes from breakPoint
+type ringSizeChainRetriever struct {
+	metadata.ChainRetriever
+	breakPoint uint64
+	ringSizes  []int
+}
+
+func (bcr ringSizeChainRetriever) GetSupportedCommitmentRingSizes(beaconHeight uint64) []int {
+	if beaconHeight >= bcr.breakPoint {
+		return bcr.ringSizes
+	}
+	return []int{privacy.CommitmentRingSize}
+}
+
+func (bcr ringSizeChainRetriever) GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar {
+	return nil
+}
+
+// testChainRetriever supports only the ring size of version 1
+var testChainRetriever = ringSizeChainRetriever{breakPoint: math.MaxUint64}
+
+func TestGetTxVersionByRingSize(t *testing.T) {
+	tests := []struct {
+		ringSize int
+		version  int8
+		isValid  bool
+	}{
+		{privacy.CommitmentRingSize, txVersion, true},
+		{16, txVersionRingSize, true},
+		{64, txVersionRingSize, true},
+		{4, 0, false},
+		{24, 0, false},
+		{1 << (privacy.MaxCommitmentRingSizeExp + 1), 0, false},
+	}
+	for _, tt := range tests {
+		version, err := getTxVersionByRingSize(tt.ringSize)
+		assert.Equal(t, tt.isValid, err == nil, "ring size %v", tt.ringSize)
+		assert.Equal(t, tt.version, version, "ring size %v", tt.ringSize)
+	}
+}
+
+func TestValidateRingSize(t *testing.T) {
+	bcr := ringSizeChainRetriever{breakPoint: 200, ringSizes: []int{16, 32}}
+
+	txV1 := Tx{Version: txVersion}
+	assert.Nil(t, txV1.validateRingSize(bcr, 200, privacy.CommitmentRingSize))
+	assert.NotNil(t, txV1.validateRingSize(bcr, 200, 16))
+
+	txV2 := Tx{Version: txVersionRingSize}
+	// before the break point only the ring size of version 1 is supported
+	assert.NotNil(t, txV2.validateRingSize(bcr, 100, 16))
+	assert.Nil(t, txV2.validateRingSize(bcr, 200, 16))
+	assert.Nil(t, txV2.validateRingSize(bcr, 200, 32))
+	assert.NotNil(t, txV2.validateRingSize(bcr, 200, 64))
+	// version 2 must not use the ring size of version 1
+	assert.NotNil(t, txV2.validateRingSize(bcr, 200, privacy.CommitmentRingSize))
+}
@@ -0,0 +1,392 @@
+package validatorindexer
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+type Config struct {
+	DB            incdb.Database
+	BlockChain    blockfollower.BeaconChain
+	PubSubManager *pubsub.PubSubManager
+	// GenesisShardCommitteeSize is the number of committee members of each shard staked in the genesis block
+	GenesisShardCommitteeSize int
+}
+
+// Indexer stores the phase of every staked committee public key and its transitions
+// in its own database as final beacon blocks are inserted, transitions are published to pubsub.ValidatorTransitionTopic
+type Indexer struct {
+	config   Config
+	follower *blockfollower.Follower
+
+	mtx                 sync.Mutex
+	latestIndexedHeight uint64
+}
+
+func NewIndexer(config Config) (*Indexer, error) {
+	if config.DB == nil || config.BlockChain == nil {
+		return nil, errors.New("database and blockchain of validator indexer must not be nil")
+	}
+	indexer := &Indexer{config: config}
+	indexer.follower = blockfollower.New(config.PubSubManager, []string{pubsub.NewBeaconBlockTopic}, indexer.follow)
+	var err error
+	indexer.latestIndexedHeight, err = blockfollower.GetStoredHeight(config.DB, latestIndexedHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	return indexer, nil
+}
+
+// Start indexes final beacon blocks in background
+func (indexer *Indexer) Start() error {
+	return indexer.follower.Start()
+}
+
+// Stop stops indexing and closes the database after the block being indexed is stored
+func (indexer *Indexer) Stop() {
+	indexer.follower.Stop()
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	err := indexer.config.DB.Close()
+	if err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (indexer *Indexer) follow() {
+	err := indexer.IndexFinalBlocks()
+	if err != nil {
+		Logger.log.Errorf("Index staking instructions of beacon blocks failed: %v", err)
+	}
+}
+
+// GetLatestIndexedHeight returns the height of the latest indexed beacon block
+func (indexer *Indexer) GetLatestIndexedHeight() uint64 {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	return indexer.latestIndexedHeight
+}
+
+// IndexFinalBlocks indexes beacon blocks from the latest indexed one to the final view
+func (indexer *Indexer) IndexFinalBlocks() error {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	return indexer.follower.FollowBeacon(indexer.config.BlockChain, indexer.latestIndexedHeight+1, indexer.indexBeaconBlock)
+}
+
+// blockIndex is the changes of statuses by the instructions of a beacon block
+type blockIndex struct {
+	indexer         *Indexer
+	beaconHeight    uint64
+	beaconTimeStamp int64
+	statuses        map[string]*ValidatorStatus
+	newKeys         []string // keys in the order their statuses are loaded or created
+	transitions     []*Transition
+}
+
+// getStatus returns the status of a key changed in the block or stored in the database,
+// a new status of role is created if the key is not indexed, or nil is returned if role is empty
+func (index *blockIndex) getStatus(committeePublicKey string, role string) (*ValidatorStatus, error) {
+	if status, ok := index.statuses[committeePublicKey]; ok {
+		return status, nil
+	}
+	status, err := index.indexer.getStoredStatus(committeePublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		if role == "" {
+			return nil, nil
+		}
+		status = &ValidatorStatus{
+			CommitteePublicKey: committeePublicKey,
+			Role:               role,
+			ShardID:            -1,
+		}
+	}
+	index.statuses[committeePublicKey] = status
+	index.newKeys = append(index.newKeys, committeePublicKey)
+	return status, nil
+}
+
+func (index *blockIndex) addTransition(status *ValidatorStatus, phase string, shardID int, punishedEpochs uint8) {
+	if phase != StopAutoStakingTransition {
+		status.Phase = phase
+		status.ShardID = shardID
+	}
+	status.BeaconHeight = index.beaconHeight
+	index.transitions = append(index.transitions, &Transition{
+		CommitteePublicKey: status.CommitteePublicKey,
+		Phase:              phase,
+		ShardID:            shardID,
+		BeaconHeight:       index.beaconHeight,
+		BeaconTimeStamp:    index.beaconTimeStamp,
+		PunishedEpochs:     punishedEpochs,
+	})
+}
+
+// indexBeaconBlock stores the statuses and transitions changed by the staking instructions of a beacon block
+func (indexer *Indexer) indexBeaconBlock(beaconBlock *blockchain.BeaconBlock) error {
+	index := &blockIndex{
+		indexer:         indexer,
+		beaconHeight:    beaconBlock.Header.Height,
+		beaconTimeStamp: beaconBlock.Header.Timestamp,
+		statuses:        map[string]*ValidatorStatus{},
+	}
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) == 0 {
+			continue
+		}
+		var err error
+		switch inst[0] {
+		case blockchain.StakeAction:
+			err = index.processStakeInstruction(inst, indexer.config.GenesisShardCommitteeSize)
+		case blockchain.AssignAction:
+			err = index.processAssignInstruction(inst)
+		case blockchain.SwapAction:
+			err = index.processSwapInstruction(inst)
+		case blockchain.StopAutoStake:
+			err = index.processStopAutoStakeInstruction(inst)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	batch := indexer.config.DB.NewBatch()
+	putRecord := func(key []byte, record interface{}) error {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		return batch.Put(key, value)
+	}
+	for _, committeePublicKey := range index.newKeys {
+		status := index.statuses[committeePublicKey]
+		if status.BeaconHeight != index.beaconHeight {
+			continue
+		}
+		err := putRecord(buildStatusKey(committeePublicKey), status)
+		if err != nil {
+			return err
+		}
+		key := incognitokey.CommitteePublicKey{}
+		if key.FromBase58(committeePublicKey) != nil {
+			continue
+		}
+		miningPublicKey := key.GetMiningKeyBase58(common.BlsConsensus)
+		if miningPublicKey == "" {
+			continue
+		}
+		err = batch.Put(buildMiningKeyKey(miningPublicKey), []byte(committeePublicKey))
+		if err != nil {
+			return err
+		}
+	}
+	for idx, transition := range index.transitions {
+		err := putRecord(buildTransitionKey(transition.CommitteePublicKey, index.beaconHeight, idx), transition)
+		if err != nil {
+			return err
+		}
+	}
+	err := batch.Put(latestIndexedHeightKey, []byte(strconv.FormatUint(index.beaconHeight, 10)))
+	if err != nil {
+		return err
+	}
+	err = batch.Write()
+	if err != nil {
+		return err
+	}
+	indexer.latestIndexedHeight = index.beaconHeight
+	if indexer.config.PubSubManager != nil {
+		for _, transition := range index.transitions {
+			go indexer.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ValidatorTransitionTopic, transition))
+		}
+	}
+	return nil
+}
+
+// processStakeInstruction makes the staked keys candidates, keys staked in the genesis block are committee members
+//
+//	["stake", "pubkey1,pubkey2,..." "shard" "txStake1,txStake2,..." "rewardReceiver1,rewardReceiver2,..." "autostaking1,autostaking2,..."]
+//	["stake", "pubkey1,pubkey2,..." "beacon" "txStake1,txStake2,..." "rewardReceiver1,rewardReceiver2,..." "autostaking1,autostaking2,..."]
+func (index *blockIndex) processStakeInstruction(inst []string, genesisShardCommitteeSize int) error {
+	if len(inst) < 6 || inst[1] == "" {
+		return nil
+	}
+	role := common.ShardRole
+	if inst[2] == "beacon" {
+		role = common.BeaconRole
+	}
+	publicKeys := strings.Split(inst[1], ",")
+	stakingTxIDs := strings.Split(inst[3], ",")
+	autoStakings := strings.Split(inst[5], ",")
+	for i, publicKey := range publicKeys {
+		status, err := index.getStatus(publicKey, role)
+		if err != nil {
+			return err
+		}
+		status.Role = role
+		status.AutoStaking = i < len(autoStakings) && autoStakings[i] == "true"
+		status.StakingTxID = ""
+		if len(stakingTxIDs) == len(publicKeys) {
+			status.StakingTxID = stakingTxIDs[i]
+		}
+		if index.beaconHeight > 1 {
+			index.addTransition(status, CandidatePhase, -1, 0)
+			continue
+		}
+		// the genesis committees are staked directly, the shard committees are split by their size
+		shardID := -1
+		if role == common.ShardRole && genesisShardCommitteeSize > 0 {
+			shardID = i / genesisShardCommitteeSize
+		}
+		index.addTransition(status, CommitteePhase, shardID, 0)
+	}
+	return nil
+}
+
+// processAssignInstruction makes the assigned candidates substitutes of a shard
+//
+//	["assign", "pubkey1,pubkey2,..." "shard" "shardID"]
+func (index *blockIndex) processAssignInstruction(inst []string) error {
+	if len(inst) < 4 || inst[2] != "shard" || inst[1] == "" {
+		return nil
+	}
+	shardID, err := strconv.Atoi(inst[3])
+	if err != nil {
+		return err
+	}
+	for _, publicKey := range strings.Split(inst[1], ",") {
+		status, err := index.getStatus(publicKey, common.ShardRole)
+		if err != nil {
+			return err
+		}
+		index.addTransition(status, SubstitutePhase, shardID, 0)
+	}
+	return nil
+}
+
+// processSwapInstruction makes the keys swapped in committee members and the keys swapped out candidates again
+// if they are auto staking, the staking of other swapped out keys is returned by the shard of their staking tx
+//
+//	["swap" "inPubkey1,inPubkey2,..." "outPubkey1,outPubkey2,..." "shard" "shardID" "badProducersWithPunishment"]
+//	["swap" "inPubkey1,inPubkey2,..." "outPubkey1,outPubkey2,..." "beacon" "badProducersWithPunishment"]
+//
+// swap instructions of 7 elements replace committees by key lists of the genesis params without staking
+func (index *blockIndex) processSwapInstruction(inst []string) error {
+	if len(inst) < 4 {
+		return nil
+	}
+	isKeyListV2 := len(inst) == 7
+	role := common.BeaconRole
+	shardID := -1
+	punishmentIndex := 4
+	if inst[3] == "shard" {
+		if len(inst) < 5 {
+			return nil
+		}
+		role = common.ShardRole
+		temp, err := strconv.Atoi(inst[4])
+		if err != nil {
+			return err
+		}
+		shardID = temp
+		punishmentIndex = 5
+	}
+	badProducersWithPunishment := map[string]uint8{}
+	if !isKeyListV2 && len(inst) > punishmentIndex && inst[punishmentIndex] != "" {
+		err := json.Unmarshal([]byte(inst[punishmentIndex]), &badProducersWithPunishment)
+		if err != nil {
+			Logger.log.Warnf("Cannot parse bad producers of swap instruction at beacon height %v: %v", index.beaconHeight, err)
+		}
+	}
+	for _, publicKey := range strings.Split(inst[1], ",") {
+		if publicKey == "" {
+			continue
+		}
+		status, err := index.getStatus(publicKey, role)
+		if err != nil {
+			return err
+		}
+		if isKeyListV2 {
+			status.AutoStaking = false
+			status.StakingTxID = ""
+		}
+		index.addTransition(status, CommitteePhase, shardID, 0)
+	}
+	noStakingTxID := common.HashH([]byte{0}).String()
+	for _, publicKey := range strings.Split(inst[2], ",") {
+		if publicKey == "" {
+			continue
+		}
+		status, err := index.getStatus(publicKey, role)
+		if err != nil {
+			return err
+		}
+		if punishedEpochs, ok := badProducersWithPunishment[publicKey]; ok {
+			index.addTransition(status, SlashedPhase, shardID, punishedEpochs)
+		} else {
+			index.addTransition(status, SwappedOutPhase, shardID, 0)
+		}
+		if isKeyListV2 {
+			status.AutoStaking = false
+			continue
+		}
+		if status.AutoStaking {
+			index.addTransition(status, CandidatePhase, -1, 0)
+		} else if status.StakingTxID != "" && status.StakingTxID != noStakingTxID {
+			index.addTransition(status, StakingReturnedPhase, -1, 0)
+		}
+	}
+	return nil
+}
+
+// processStopAutoStakeInstruction turns off auto staking of the keys, they are not candidates again after being swapped out
+//
+//	["stopautostake" "pubkey1,pubkey2,..."]
+func (index *blockIndex) processStopAutoStakeInstruction(inst []string) error {
+	if len(inst) < 2 || inst[1] == "" {
+		return nil
+	}
+	for _, publicKey := range strings.Split(inst[1], ",") {
+		status, err := index.getStatus(publicKey, "")
+		if err != nil {
+			return err
+		}
+		if status == nil {
+			continue
+		}
+		status.AutoStaking = false
+		index.addTransition(status, StopAutoStakingTransition, status.ShardID, 0)
+	}
+	return nil
+}
+
+// getStoredStatus returns the stored status of a committee public key, or nil if it is not indexed
+func (indexer *Indexer) getStoredStatus(committeePublicKey string) (*ValidatorStatus, error) {
+	has, err := indexer.config.DB.Has(buildStatusKey(committeePublicKey))
+	if err != nil || !has {
+		return nil, err
+	}
+	value, err := indexer.config.DB.Get(buildStatusKey(committeePublicKey))
+	if err != nil {
+		return nil, err
+	}
+	status := &ValidatorStatus{}
+	err = json.Unmarshal(value, status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
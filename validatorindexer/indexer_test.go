@@ -0,0 +1,164 @@
+package validatorindexer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockfollower/followertest"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+// addBlock adds a final beacon block with insts
+func addBlock(bc *followertest.Chain, height uint64, insts ...[]string) {
+	bc.AddBeaconBlock(&blockchain.BeaconBlock{
+		Header: blockchain.BeaconHeader{Height: height, Timestamp: int64(height * 40)},
+		Body:   blockchain.BeaconBody{Instructions: insts},
+	})
+}
+
+func newTestIndexer(t *testing.T) (*Indexer, *followertest.Chain, func()) {
+	db, cleanup := followertest.OpenDB(t)
+	bc := followertest.NewChain()
+	indexer, err := NewIndexer(Config{DB: db, BlockChain: bc, GenesisShardCommitteeSize: 2})
+	assert.Nil(t, err)
+	return indexer, bc, cleanup
+}
+
+func newTestKey(t *testing.T, seed string) (string, string) {
+	key, err := incognitokey.NewCommitteeKeyFromSeed([]byte(seed), []byte(seed))
+	assert.Nil(t, err)
+	committeePublicKey, err := key.ToBase58()
+	assert.Nil(t, err)
+	return committeePublicKey, key.GetMiningKeyBase58(common.BlsConsensus)
+}
+
+func getPhases(status *ValidatorStatus) []string {
+	phases := []string{}
+	for _, transition := range status.Transitions {
+		phases = append(phases, transition.Phase)
+	}
+	return phases
+}
+
+func TestIndexFinalBlocks(t *testing.T) {
+	indexer, bc, cleanup := newTestIndexer(t)
+	defer cleanup()
+	genesis1, _ := newTestKey(t, "genesis1")
+	genesis2, _ := newTestKey(t, "genesis2")
+	genesis3, _ := newTestKey(t, "genesis3")
+	autoStaker, autoStakerMiningKey := newTestKey(t, "autostaker")
+	staker, _ := newTestKey(t, "staker")
+
+	addBlock(bc, 1, []string{blockchain.StakeAction, strings.Join([]string{genesis1, genesis2, genesis3}, ","), "shard", "", "r1,r2,r3", "false,false,false"})
+	addBlock(bc, 2,
+		[]string{blockchain.StakeAction, autoStaker + "," + staker, "shard", "tx1,tx2", "r4,r5", "true,false"},
+		[]string{blockchain.RandomAction, "1", "", "", ""},
+	)
+	addBlock(bc, 3, []string{blockchain.AssignAction, autoStaker + "," + staker, "shard", "1"})
+	addBlock(bc, 4, []string{blockchain.SwapAction, autoStaker + "," + staker, genesis3, "shard", "1", "{}"})
+	addBlock(bc, 5, []string{blockchain.StopAutoStake, staker})
+	addBlock(bc, 6, []string{blockchain.SwapAction, genesis3, autoStaker + "," + staker, "shard", "1", `{"` + staker + `":2}`})
+
+	assert.Nil(t, indexer.IndexFinalBlocks())
+	assert.Equal(t, uint64(6), indexer.GetLatestIndexedHeight())
+
+	status, err := indexer.GetValidatorStatus(genesis3)
+	assert.Nil(t, err)
+	assert.Equal(t, common.ShardRole, status.Role)
+	assert.Equal(t, CommitteePhase, status.Phase)
+	assert.Equal(t, 1, status.ShardID)
+	assert.Equal(t, []string{CommitteePhase, SwappedOutPhase, CommitteePhase}, getPhases(status))
+
+	status, err = indexer.GetValidatorStatus(genesis1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, status.ShardID)
+
+	// the auto staking validator is a candidate again and can be found by its mining key
+	status, err = indexer.GetValidatorStatus(autoStakerMiningKey)
+	assert.Nil(t, err)
+	assert.Equal(t, autoStaker, status.CommitteePublicKey)
+	assert.Equal(t, CandidatePhase, status.Phase)
+	assert.Equal(t, -1, status.ShardID)
+	assert.True(t, status.AutoStaking)
+	assert.Equal(t, []string{CandidatePhase, SubstitutePhase, CommitteePhase, SwappedOutPhase, CandidatePhase}, getPhases(status))
+	assert.Equal(t, uint64(6), status.BeaconHeight)
+
+	// the validator which stopped auto staking is slashed and its staking is returned
+	status, err = indexer.GetValidatorStatus(staker)
+	assert.Nil(t, err)
+	assert.Equal(t, StakingReturnedPhase, status.Phase)
+	assert.False(t, status.AutoStaking)
+	assert.Equal(t, "tx2", status.StakingTxID)
+	assert.Equal(t, []string{CandidatePhase, SubstitutePhase, CommitteePhase, StopAutoStakingTransition, SlashedPhase, StakingReturnedPhase}, getPhases(status))
+	assert.Equal(t, 1, status.Transitions[3].ShardID)
+	assert.Equal(t, uint8(2), status.Transitions[4].PunishedEpochs)
+	assert.Equal(t, uint64(5), status.Transitions[3].BeaconHeight)
+	assert.Equal(t, int64(5*40), status.Transitions[3].BeaconTimeStamp)
+
+	unknown, _ := newTestKey(t, "unknown")
+	status, err = indexer.GetValidatorStatus(unknown)
+	assert.Nil(t, err)
+	assert.Nil(t, status)
+}
+
+func TestIndexFinalBlocksResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validatorindexer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	staker, _ := newTestKey(t, "staker")
+	bc := followertest.NewChain()
+	addBlock(bc, 1)
+	addBlock(bc, 2, []string{blockchain.StakeAction, staker, "shard", "tx1", "r1", "false"})
+
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	indexer, err := NewIndexer(Config{DB: db, BlockChain: bc})
+	assert.Nil(t, err)
+	assert.Nil(t, indexer.IndexFinalBlocks())
+	db.Close()
+
+	// the status stored before the restart is updated by new blocks
+	addBlock(bc, 3, []string{blockchain.AssignAction, staker, "shard", "0"})
+	db, err = incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	defer db.Close()
+	indexer, err = NewIndexer(Config{DB: db, BlockChain: bc})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), indexer.GetLatestIndexedHeight())
+	assert.Nil(t, indexer.IndexFinalBlocks())
+	status, err := indexer.GetValidatorStatus(staker)
+	assert.Nil(t, err)
+	assert.Equal(t, SubstitutePhase, status.Phase)
+	assert.Equal(t, 0, status.ShardID)
+	assert.Equal(t, []string{CandidatePhase, SubstitutePhase}, getPhases(status))
+}
+
+func TestProcessSwapInstructionKeyListV2(t *testing.T) {
+	indexer, _, cleanup := newTestIndexer(t)
+	defer cleanup()
+	in, _ := newTestKey(t, "in")
+	out, _ := newTestKey(t, "out")
+	index := &blockIndex{indexer: indexer, beaconHeight: 10, statuses: map[string]*ValidatorStatus{
+		out: {CommitteePublicKey: out, Role: common.BeaconRole, Phase: CommitteePhase, ShardID: -1, AutoStaking: true, StakingTxID: "tx"},
+	}}
+	assert.Nil(t, index.processSwapInstruction([]string{blockchain.SwapAction, in, out, "beacon", "", "", "r1"}))
+	// keys replaced by the key lists are neither candidates again nor returned
+	assert.Equal(t, SwappedOutPhase, index.statuses[out].Phase)
+	assert.False(t, index.statuses[out].AutoStaking)
+	assert.Equal(t, CommitteePhase, index.statuses[in].Phase)
+	assert.Equal(t, common.BeaconRole, index.statuses[in].Role)
+	transitions, _ := json.Marshal(index.transitions)
+	assert.NotContains(t, string(transitions), CandidatePhase)
+}
@@ -0,0 +1,14 @@
+package validatorindexer
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type ValidatorIndexerLogger struct {
+	log common.Logger
+}
+
+func (logger *ValidatorIndexerLogger) Init(inst common.Logger) {
+	logger.log = inst
+}
+
+// Global instant to use
+var Logger = ValidatorIndexerLogger{}
@@ -0,0 +1,43 @@
+package validatorindexer
+
+import (
+	"encoding/json"
+)
+
+// GetValidatorStatus returns the status of a validator with all its transitions in the order they happen,
+// publicKey is the committee public key or the base58 bls mining key of the validator,
+// nil is returned if the key has never been staked
+func (indexer *Indexer) GetValidatorStatus(publicKey string) (*ValidatorStatus, error) {
+	indexer.mtx.Lock()
+	defer indexer.mtx.Unlock()
+	status, err := indexer.getStoredStatus(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		has, err := indexer.config.DB.Has(buildMiningKeyKey(publicKey))
+		if err != nil || !has {
+			return nil, err
+		}
+		committeePublicKey, err := indexer.config.DB.Get(buildMiningKeyKey(publicKey))
+		if err != nil {
+			return nil, err
+		}
+		status, err = indexer.getStoredStatus(string(committeePublicKey))
+		if err != nil || status == nil {
+			return nil, err
+		}
+	}
+	status.Transitions = []*Transition{}
+	iter := indexer.config.DB.NewIteratorWithPrefix(buildTransitionPrefix(status.CommitteePublicKey))
+	defer iter.Release()
+	for iter.Next() {
+		transition := &Transition{}
+		err := json.Unmarshal(iter.Value(), transition)
+		if err != nil {
+			return nil, err
+		}
+		status.Transitions = append(status.Transitions, transition)
+	}
+	return status, iter.Error()
+}
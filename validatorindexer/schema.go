@@ -0,0 +1,72 @@
+package validatorindexer
+
+import (
+	"encoding/binary"
+)
+
+// phases of a validator, a validator goes from candidate to substitute to committee and is swapped out,
+// then it is a candidate again if it is auto staking or its staking is returned
+const (
+	CandidatePhase       = "candidate"
+	SubstitutePhase      = "substitute"
+	CommitteePhase       = "committee"
+	SwappedOutPhase      = "swappedout"
+	SlashedPhase         = "slashed"
+	StakingReturnedPhase = "stakingreturned"
+	// StopAutoStakingTransition does not change the phase of a validator, it turns off its auto staking
+	StopAutoStakingTransition = "stopautostaking"
+)
+
+var (
+	latestIndexedHeightKey = []byte("valindex-height")
+	statusPrefix           = []byte("valindex-status-")
+	transitionPrefix       = []byte("valindex-transition-")
+	miningKeyPrefix        = []byte("valindex-miningkey-")
+)
+
+// ValidatorStatus is the current phase of a staked committee public key
+type ValidatorStatus struct {
+	CommitteePublicKey string
+	Role               string // the chain the key is staked for, common.BeaconRole or common.ShardRole
+	Phase              string
+	ShardID            int // shard of a substitute or a committee member of a shard, -1 otherwise
+	AutoStaking        bool
+	StakingTxID        string
+	BeaconHeight       uint64        // height of the beacon block of the latest transition
+	Transitions        []*Transition `json:",omitempty"`
+}
+
+// Transition is a change of the phase of a validator by an instruction of a beacon block
+type Transition struct {
+	CommitteePublicKey string
+	Phase              string // phase after the transition or StopAutoStakingTransition
+	ShardID            int
+	BeaconHeight       uint64
+	BeaconTimeStamp    int64
+	PunishedEpochs     uint8 `json:",omitempty"` // number of epochs a slashed validator is punished for
+}
+
+func buildStatusKey(committeePublicKey string) []byte {
+	key := append([]byte{}, statusPrefix...)
+	return append(key, []byte(committeePublicKey)...)
+}
+
+func buildTransitionPrefix(committeePublicKey string) []byte {
+	key := append([]byte{}, transitionPrefix...)
+	return append(key, []byte(committeePublicKey+"-")...)
+}
+
+// buildTransitionKey orders the transitions of a validator by beacon height then by their index in the beacon block
+func buildTransitionKey(committeePublicKey string, beaconHeight uint64, index int) []byte {
+	key := buildTransitionPrefix(committeePublicKey)
+	suffix := make([]byte, 12)
+	binary.BigEndian.PutUint64(suffix, beaconHeight)
+	binary.BigEndian.PutUint32(suffix[8:], uint32(index))
+	return append(key, suffix...)
+}
+
+// buildMiningKeyKey maps the base58 bls mining key of a validator to its committee public key
+func buildMiningKeyKey(miningPublicKey string) []byte {
+	key := append([]byte{}, miningKeyPrefix...)
+	return append(key, []byte(miningPublicKey)...)
+}
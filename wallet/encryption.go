@@ -9,6 +9,9 @@ import (
 	"strings"
 )
 
+// Functions of this file implement the legacy wallet file format, salt and ciphertext in hex separated by '-'
+// They are only used to read wallet files not migrated yet to the keystore format (see keystore.go)
+
 // deriveKey receives passPhrase and salt
 // if salt is empty array, we will random 8-byte array for salt
 // and return new 32-byte key using pbkdf2 method and salt
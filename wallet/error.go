@@ -26,6 +26,10 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	InvalidKeystoreErr
+	UnsupportedKeystoreVersionErr
+	InvalidShardIDErr
+	UnmatchedReadonlyKeyErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -53,6 +57,11 @@ var ErrCodeMessage = map[int]struct {
 	NewMnemonicError:       {-1015, "Can not create mnemonic"},
 	MnemonicInvalidError:   {-1016, "Mnemonic is invalid"},
 	InvalidSeserializedKey: {-1016, "Serialized key is invalid"},
+	InvalidKeystoreErr:     {-1017, "Keystore is invalid"},
+
+	UnsupportedKeystoreVersionErr: {-1018, "Keystore version is not supported"},
+	InvalidShardIDErr:             {-1019, "Shard ID is invalid"},
+	UnmatchedReadonlyKeyErr:       {-1020, "Readonly key does not match payment address"},
 }
 
 type WalletError struct {
@@ -0,0 +1,235 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// KeyUsedFunc returns whether a key set of shardID has been used on chain, it is used to recover accounts
+type KeyUsedFunc func(keySet *incognitokey.KeySet, shardID byte) (bool, error)
+
+// CreateHDAccount creates an account with the next key of the shard branch of account accountIndex,
+// i.e. path m/44'/587'/accountIndex'/shardID/index where index is the next index whose key belongs to shardID
+// It returns that new account and returns errors if accountName is existed
+func (wallet *Wallet) CreateHDAccount(accountName string, accountIndex uint32, shardID byte) (*AccountWallet, error) {
+	if int(shardID) >= common.MaxShardNumber {
+		return nil, NewWalletError(InvalidShardIDErr, nil)
+	}
+	if accountIndex >= HardenedKeyStart {
+		return nil, NewWalletError(NewChildKeyError, fmt.Errorf("account index %v is out of range", accountIndex))
+	}
+	if accountName != "" {
+		for _, acc := range wallet.MasterAccount.Child {
+			if acc.Name == accountName {
+				return nil, NewWalletError(ExistedAccountNameErr, nil)
+			}
+		}
+	}
+
+	// next index of the branch is after the last one used by an account of the wallet
+	newIndex := uint32(0)
+	for _, acc := range wallet.MasterAccount.Child {
+		path, err := ParseDerivationPath(acc.Path)
+		if acc.Path == "" || err != nil {
+			continue
+		}
+		account, accShardID, index, ok := path.accountLevels()
+		if ok && account == accountIndex && accShardID == shardID && index >= newIndex {
+			newIndex = index + 1
+		}
+	}
+
+	branchPath := NewAccountPath(accountIndex, shardID, 0)
+	branchKey, err := wallet.MasterAccount.Key.DeriveKey(branchPath[:len(branchPath)-1])
+	if err != nil {
+		return nil, err
+	}
+	childKey, index, err := nextShardKey(branchKey, shardID, newIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountName == "" {
+		accountName = fmt.Sprintf("AccountWallet %d", len(wallet.MasterAccount.Child))
+	}
+	account := AccountWallet{
+		Key:   *childKey,
+		Child: make([]AccountWallet, 0),
+		Name:  accountName,
+		Path:  NewAccountPath(accountIndex, shardID, index).String(),
+	}
+	wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
+	err = wallet.Save(wallet.PassPhrase)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return &account, nil
+}
+
+// nextShardKey returns the first child key of branchKey from index fromIndex which belongs to shardID
+func nextShardKey(branchKey *KeyWallet, shardID byte, fromIndex uint32) (*KeyWallet, uint32, error) {
+	for index := fromIndex; index < HardenedKeyStart; index++ {
+		childKey, err := branchKey.NewChildKey(index)
+		if err != nil {
+			return nil, 0, err
+		}
+		lastByte := childKey.KeySet.PaymentAddress.Pk[len(childKey.KeySet.PaymentAddress.Pk)-1]
+		if common.GetShardIDFromLastByte(lastByte) == shardID {
+			return childKey, index, nil
+		}
+	}
+	return nil, 0, NewWalletError(NewChildKeyError, fmt.Errorf("no key of shard %v left in branch", shardID))
+}
+
+// RecoverHDAccounts scans account paths and adds to the wallet every key isUsed reports as used,
+// a shard branch is scanned until gapLimit consecutive keys are unused and accounts are scanned
+// until an account has no used key, as in BIP44
+// It returns the accounts added to the wallet
+func (wallet *Wallet) RecoverHDAccounts(gapLimit int, isUsed KeyUsedFunc) ([]*AccountWallet, error) {
+	if gapLimit <= 0 {
+		gapLimit = DefaultGapLimit
+	}
+	existedPaths := make(map[string]bool)
+	for _, acc := range wallet.MasterAccount.Child {
+		if acc.Path != "" {
+			existedPaths[acc.Path] = true
+		}
+	}
+
+	result := []*AccountWallet{}
+	for accountIndex := uint32(0); accountIndex < HardenedKeyStart; accountIndex++ {
+		accountUsed := false
+		for shardID := byte(0); int(shardID) < common.MaxShardNumber; shardID++ {
+			branchPath := NewAccountPath(accountIndex, shardID, 0)
+			branchKey, err := wallet.MasterAccount.Key.DeriveKey(branchPath[:len(branchPath)-1])
+			if err != nil {
+				return nil, err
+			}
+			index := uint32(0)
+			for unused := 0; unused < gapLimit; index++ {
+				childKey, childIndex, err := nextShardKey(branchKey, shardID, index)
+				if err != nil {
+					return nil, err
+				}
+				index = childIndex
+				used, err := isUsed(&childKey.KeySet, shardID)
+				if err != nil {
+					return nil, err
+				}
+				if !used {
+					unused++
+					continue
+				}
+				unused = 0
+				accountUsed = true
+				path := NewAccountPath(accountIndex, shardID, childIndex).String()
+				if existedPaths[path] {
+					continue
+				}
+				account := AccountWallet{
+					Key:   *childKey,
+					Child: make([]AccountWallet, 0),
+					Name:  fmt.Sprintf("AccountWallet %d", len(wallet.MasterAccount.Child)),
+					Path:  path,
+				}
+				wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
+				existedPaths[path] = true
+				result = append(result, &account)
+				Logger.log.Infof("Recovered account %v with path %v", account.Name, path)
+			}
+		}
+		if !accountUsed {
+			break
+		}
+	}
+
+	if len(result) > 0 {
+		err := wallet.Save(wallet.PassPhrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ImportWatchOnlyAccount adds an account known by its payment address and readonly key, its balance can be read
+// but it can not spend
+// It returns AccountWallet which is imported and errors (if any)
+func (wallet *Wallet) ImportWatchOnlyAccount(paymentAddressStr string, readonlyKeyStr string, accountName string, passPhrase string) (*AccountWallet, error) {
+	if passPhrase != wallet.PassPhrase {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+
+	paymentAddressKey, err := Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	readonlyKey, err := Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(paymentAddressKey.KeySet.PaymentAddress.Pk) == 0 || len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	if !bytes.Equal(paymentAddressKey.KeySet.PaymentAddress.Pk, readonlyKey.KeySet.ReadonlyKey.Pk) {
+		return nil, NewWalletError(UnmatchedReadonlyKeyErr, nil)
+	}
+
+	for _, account := range wallet.MasterAccount.Child {
+		if bytes.Equal(account.Key.KeySet.PaymentAddress.Pk, paymentAddressKey.KeySet.PaymentAddress.Pk) {
+			return nil, NewWalletError(ExistedAccountErr, nil)
+		}
+		if account.Name == accountName {
+			return nil, NewWalletError(ExistedAccountNameErr, nil)
+		}
+	}
+
+	account := AccountWallet{
+		Key: KeyWallet{
+			KeySet: incognitokey.KeySet{
+				PaymentAddress: paymentAddressKey.KeySet.PaymentAddress,
+				ReadonlyKey:    readonlyKey.KeySet.ReadonlyKey,
+			},
+		},
+		Child:       make([]AccountWallet, 0),
+		IsImported:  true,
+		IsWatchOnly: true,
+		Name:        accountName,
+	}
+	wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
+	err = wallet.Save(wallet.PassPhrase)
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// SetAccountLabel sets the label of account accountName and merges metadata into its metadata,
+// a key of metadata with an empty value is removed
+func (wallet *Wallet) SetAccountLabel(accountName string, label string, metadata map[string]string) error {
+	for i := range wallet.MasterAccount.Child {
+		account := &wallet.MasterAccount.Child[i]
+		if account.Name != accountName {
+			continue
+		}
+		account.Label = label
+		for k, v := range metadata {
+			if account.Metadata == nil {
+				account.Metadata = make(map[string]string)
+			}
+			if v == "" {
+				delete(account.Metadata, k)
+			} else {
+				account.Metadata[k] = v
+			}
+		}
+		if len(account.Metadata) == 0 {
+			account.Metadata = nil
+		}
+		return wallet.Save(wallet.PassPhrase)
+	}
+	return NewWalletError(NotFoundAccountErr, nil)
+}
@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHDWallet(t *testing.T) (*Wallet, func()) {
+	dataDir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := new(Wallet)
+	w.SetConfig(&WalletConfig{
+		DataDir:  dataDir,
+		DataFile: "wallet",
+		DataPath: filepath.Join(dataDir, "wallet"),
+		LightKDF: true,
+	})
+	err = w.Init("123", 0, "Wallet")
+	assert.Equal(t, nil, err)
+	return w, func() { os.RemoveAll(dataDir) }
+}
+
+/*
+	Unit test for DerivationPath
+*/
+
+func TestDerivationPath(t *testing.T) {
+	path := NewAccountPath(2, 3, 7)
+	assert.Equal(t, "m/44'/587'/2'/3/7", path.String())
+
+	parsedPath, err := ParseDerivationPath("m/44'/587'/2'/3/7")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, path, parsedPath)
+
+	account, shardID, index, ok := parsedPath.accountLevels()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint32(2), account)
+	assert.Equal(t, byte(3), shardID)
+	assert.Equal(t, uint32(7), index)
+
+	for _, invalidPath := range []string{"", "44'/587'", "m/a", "m/2147483648", "m/-1"} {
+		_, err := ParseDerivationPath(invalidPath)
+		assert.NotEqual(t, nil, err, invalidPath)
+	}
+	_, _, _, ok = DerivationPath{HDPurpose, HDCoinType, 0, 0, 0}.accountLevels()
+	assert.Equal(t, false, ok)
+}
+
+/*
+	Unit test for CreateHDAccount function
+*/
+
+func TestWalletCreateHDAccount(t *testing.T) {
+	w, cleanup := newTestHDWallet(t)
+	defer cleanup()
+
+	for _, shardID := range []byte{0, 5, 5} {
+		account, err := w.CreateHDAccount("", 1, shardID)
+		assert.Equal(t, nil, err)
+		pk := account.Key.KeySet.PaymentAddress.Pk
+		assert.Equal(t, shardID, common.GetShardIDFromLastByte(pk[len(pk)-1]))
+
+		path, err := ParseDerivationPath(account.Path)
+		assert.Equal(t, nil, err)
+		derivedKey, _ := w.MasterAccount.Key.DeriveKey(path)
+		assert.Equal(t, pk, derivedKey.KeySet.PaymentAddress.Pk)
+	}
+	first, _ := ParseDerivationPath(w.MasterAccount.Child[2].Path)
+	second, _ := ParseDerivationPath(w.MasterAccount.Child[3].Path)
+	assert.Equal(t, true, second[4] > first[4])
+
+	_, err := w.CreateHDAccount("", 0, byte(common.MaxShardNumber))
+	assert.Equal(t, ErrCodeMessage[InvalidShardIDErr].code, err.(*WalletError).GetCode())
+
+	_, err = w.CreateHDAccount(w.MasterAccount.Child[1].Name, 0, 0)
+	assert.Equal(t, NewWalletError(ExistedAccountNameErr, nil), err)
+}
+
+/*
+	Unit test for RecoverHDAccounts function
+*/
+
+func TestWalletRecoverHDAccounts(t *testing.T) {
+	w, cleanup := newTestHDWallet(t)
+	defer cleanup()
+
+	used := [][]byte{}
+	paths := map[string]bool{}
+	for _, item := range []struct {
+		account uint32
+		shardID byte
+	}{{0, 1}, {0, 1}, {0, 6}, {1, 0}} {
+		account, err := w.CreateHDAccount("", item.account, item.shardID)
+		assert.Equal(t, nil, err)
+		used = append(used, account.Key.KeySet.PaymentAddress.Pk)
+		paths[account.Path] = true
+	}
+	isUsed := func(keySet *incognitokey.KeySet, shardID byte) (bool, error) {
+		for _, pk := range used {
+			if bytes.Equal(pk, keySet.PaymentAddress.Pk) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	w2, cleanup2 := newTestHDWallet(t)
+	defer cleanup2()
+	err := w2.InitFromMnemonic(w.Mnemonic, w.PassPhrase, "Wallet")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, w.Seed, w2.Seed)
+
+	recovered, err := w2.RecoverHDAccounts(3, isUsed)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(used), len(recovered))
+	for _, account := range recovered {
+		assert.Equal(t, true, paths[account.Path], account.Path)
+	}
+
+	// recovering again adds nothing
+	recovered, err = w2.RecoverHDAccounts(3, isUsed)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(recovered))
+	assert.Equal(t, len(used), len(w2.MasterAccount.Child))
+}
+
+func TestWalletInitFromInvalidMnemonic(t *testing.T) {
+	w := new(Wallet)
+	err := w.InitFromMnemonic("abandon abandon", "", "Wallet")
+	assert.Equal(t, ErrCodeMessage[MnemonicInvalidError].code, err.(*WalletError).GetCode())
+}
+
+/*
+	Unit test for ImportWatchOnlyAccount and SetAccountLabel functions
+*/
+
+func TestWalletImportWatchOnlyAccount(t *testing.T) {
+	w, cleanup := newTestHDWallet(t)
+	defer cleanup()
+
+	other, cleanupOther := newTestHDWallet(t)
+	defer cleanupOther()
+	otherAccount := other.MasterAccount.Child[0]
+	paymentAddress := otherAccount.Key.Base58CheckSerialize(PaymentAddressType)
+	readonlyKey := otherAccount.Key.Base58CheckSerialize(ReadonlyKeyType)
+
+	_, err := w.ImportWatchOnlyAccount(paymentAddress, readonlyKey, "Watch", "1234")
+	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
+
+	_, err = w.ImportWatchOnlyAccount(paymentAddress, w.MasterAccount.Child[0].Key.Base58CheckSerialize(ReadonlyKeyType), "Watch", "123")
+	assert.Equal(t, NewWalletError(UnmatchedReadonlyKeyErr, nil), err)
+
+	account, err := w.ImportWatchOnlyAccount(paymentAddress, readonlyKey, "Watch", "123")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, account.IsWatchOnly)
+	assert.Equal(t, 0, len(account.Key.KeySet.PrivateKey))
+
+	_, err = w.ImportWatchOnlyAccount(paymentAddress, readonlyKey, "Watch 2", "123")
+	assert.Equal(t, NewWalletError(ExistedAccountErr, nil), err)
+
+	keys := w.GetAddressByAccName("Watch", nil)
+	assert.Equal(t, paymentAddress, keys.PaymentAddress)
+	assert.Equal(t, readonlyKey, keys.ReadonlyKey)
+	assert.Equal(t, "", keys.PrivateKey)
+	assert.Equal(t, "", keys.ValidatorKey)
+	assert.Equal(t, KeySerializedData{}, w.DumpPrivateKey(paymentAddress))
+
+	err = w.SetAccountLabel("Watch", "cold storage", map[string]string{"owner": "treasury"})
+	assert.Equal(t, nil, err)
+	err = w.SetAccountLabel("Unknown", "label", nil)
+	assert.Equal(t, NewWalletError(NotFoundAccountErr, nil), err)
+
+	w2 := new(Wallet)
+	w2.SetConfig(w.GetConfig())
+	err = w2.LoadWallet("123")
+	assert.Equal(t, nil, err)
+	loaded := w2.ListAccounts()["Watch"]
+	assert.Equal(t, true, loaded.IsWatchOnly)
+	assert.Equal(t, "cold storage", loaded.Label)
+	assert.Equal(t, map[string]string{"owner": "treasury"}, loaded.Metadata)
+
+	err = w2.SetAccountLabel("Watch", "", map[string]string{"owner": ""})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", w2.ListAccounts()["Watch"].Label)
+	assert.Equal(t, 0, len(w2.ListAccounts()["Watch"].Metadata))
+}
@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HardenedKeyStart is the offset of indexes written with ' in paths. The marker is kept for BIP44-style
+	// notation only: NewChildKey derives every child from the parent chain code, so a "hardened" child is
+	// not protected like a BIP32 hardened key, it is just a child with a different index
+	HardenedKeyStart = uint32(0x80000000)
+
+	// HDPurpose is the purpose level of account paths, following BIP44
+	HDPurpose = uint32(44)
+	// HDCoinType is the coin type level of account paths of Incognito keys
+	HDCoinType = uint32(587)
+
+	// DefaultGapLimit is the number of consecutive unused keys after which recovery stops scanning a shard branch
+	DefaultGapLimit = 20
+)
+
+// DerivationPath is a list of child indexes from the master key,
+// account paths are m / 44' / 587' / account' / shard / index, see HardenedKeyStart for the meaning of '
+type DerivationPath []uint32
+
+// NewAccountPath returns the path of the key at index of the shard branch of account
+func NewAccountPath(account uint32, shardID byte, index uint32) DerivationPath {
+	return DerivationPath{
+		HDPurpose + HardenedKeyStart,
+		HDCoinType + HardenedKeyStart,
+		account + HardenedKeyStart,
+		uint32(shardID),
+		index,
+	}
+}
+
+// ParseDerivationPath parses a path like m/44'/587'/0'/1/5
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	elems := strings.Split(strings.TrimSpace(path), "/")
+	if len(elems) == 0 || elems[0] != "m" {
+		return nil, fmt.Errorf("derivation path %v must start with m", path)
+	}
+	result := DerivationPath{}
+	for _, elem := range elems[1:] {
+		hardened := strings.HasSuffix(elem, "'")
+		index, err := strconv.ParseUint(strings.TrimSuffix(elem, "'"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %v of derivation path %v", elem, path)
+		}
+		if index >= uint64(HardenedKeyStart) {
+			return nil, fmt.Errorf("index %v of derivation path %v is out of range", elem, path)
+		}
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		result = append(result, uint32(index))
+	}
+	return result, nil
+}
+
+func (path DerivationPath) String() string {
+	result := "m"
+	for _, index := range path {
+		if index >= HardenedKeyStart {
+			result += fmt.Sprintf("/%d'", index-HardenedKeyStart)
+		} else {
+			result += fmt.Sprintf("/%d", index)
+		}
+	}
+	return result
+}
+
+// accountLevels returns the account, shard and index levels of an account path
+func (path DerivationPath) accountLevels() (account uint32, shardID byte, index uint32, ok bool) {
+	if len(path) != 5 || path[0] != HDPurpose+HardenedKeyStart || path[1] != HDCoinType+HardenedKeyStart ||
+		path[2] < HardenedKeyStart || path[3] > math.MaxUint8 || path[4] >= HardenedKeyStart {
+		return 0, 0, 0, false
+	}
+	return path[2] - HardenedKeyStart, byte(path[3]), path[4], true
+}
+
+// DeriveKey derives the key at path from key, key is usually the master key
+func (key *KeyWallet) DeriveKey(path DerivationPath) (*KeyWallet, error) {
+	result := key
+	for _, index := range path {
+		childKey, err := result.NewChildKey(index)
+		if err != nil {
+			return nil, err
+		}
+		result = childKey
+	}
+	return result, nil
+}
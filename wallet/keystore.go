@@ -0,0 +1,220 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KeystoreVersion is the version of the keystore format written by Save
+	KeystoreVersion = 1
+
+	keystoreCipher = "aes-256-gcm"
+	keystoreKDF    = "scrypt"
+
+	// StandardScryptN and StandardScryptP make the key derivation use 256MB of memory and about 1s of CPU
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	// LightScryptN and LightScryptP make the key derivation use 4MB of memory and a few ms of CPU,
+	// they are meant for tests and low memory devices
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 64 // bytes, the first half is the encryption key, the second half is the mac key
+	scryptSalt  = 32 // bytes
+
+	// maxScryptN bounds the memory used to load a keystore with tampered parameters
+	maxScryptN = 1 << 22
+)
+
+// keystore is the versioned json format of an encrypted wallet file
+type keystore struct {
+	Version int            `json:"version"`
+	Crypto  keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher     string       `json:"cipher"`
+	CipherText string       `json:"ciphertext"`
+	Nonce      string       `json:"nonce"`
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfparams"`
+	MAC        string       `json:"mac"`
+}
+
+type scryptParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// keystoreMetadata is everything of a keystore but the ciphertext and the mac,
+// it is authenticated by the mac and used as additional data of the cipher
+type keystoreMetadata struct {
+	Version   int          `json:"version"`
+	Cipher    string       `json:"cipher"`
+	Nonce     string       `json:"nonce"`
+	KDF       string       `json:"kdf"`
+	KDFParams scryptParams `json:"kdfparams"`
+}
+
+func (ks *keystore) metadata() ([]byte, error) {
+	return json.Marshal(keystoreMetadata{
+		Version:   ks.Version,
+		Cipher:    ks.Crypto.Cipher,
+		Nonce:     ks.Crypto.Nonce,
+		KDF:       ks.Crypto.KDF,
+		KDFParams: ks.Crypto.KDFParams,
+	})
+}
+
+// isLegacyKeystore returns whether data is a wallet file written before keystore versioning,
+// i.e. hex salt and ciphertext separated by '-'
+func isLegacyKeystore(data []byte) bool {
+	return !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// encryptKeystore encrypts plaintext with a key derived from passPhrase by scrypt with parameters scryptN and scryptP,
+// and returns the keystore in json
+func encryptKeystore(passPhrase string, plaintext []byte, scryptN int, scryptP int) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, NewWalletError(InvalidPlaintextErr, nil)
+	}
+	salt := make([]byte, scryptSalt)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, NewWalletError(UnexpectedErr, err)
+	}
+	params := scryptParams{
+		N:     scryptN,
+		R:     scryptR,
+		P:     scryptP,
+		DKLen: scryptDKLen,
+		Salt:  hex.EncodeToString(salt),
+	}
+	derivedKey, err := scrypt.Key([]byte(passPhrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, NewWalletError(UnexpectedErr, err)
+	}
+	encryptKey, macKey := derivedKey[:common.AESKeySize], derivedKey[common.AESKeySize:]
+
+	aead, err := newKeystoreAEAD(encryptKey)
+	if err != nil {
+		return nil, NewWalletError(AESEncryptErr, err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, NewWalletError(UnexpectedErr, err)
+	}
+
+	ks := &keystore{
+		Version: KeystoreVersion,
+		Crypto: keystoreCrypto{
+			Cipher:    keystoreCipher,
+			Nonce:     hex.EncodeToString(nonce),
+			KDF:       keystoreKDF,
+			KDFParams: params,
+		},
+	}
+	metadata, err := ks.metadata()
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	ks.Crypto.CipherText = hex.EncodeToString(aead.Seal(nil, nonce, plaintext, metadata))
+	ks.Crypto.MAC = hex.EncodeToString(keystoreMAC(macKey, metadata))
+
+	data, err := json.Marshal(ks)
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	return data, nil
+}
+
+// decryptKeystore checks the mac of a keystore in json and returns its plaintext,
+// it returns WrongPassphraseErr if the mac does not match
+func decryptKeystore(passPhrase string, data []byte) ([]byte, error) {
+	ks := &keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	if ks.Version != KeystoreVersion {
+		return nil, NewWalletError(UnsupportedKeystoreVersionErr, fmt.Errorf("keystore version %v", ks.Version))
+	}
+	if ks.Crypto.Cipher != keystoreCipher || ks.Crypto.KDF != keystoreKDF {
+		return nil, NewWalletError(InvalidKeystoreErr, fmt.Errorf("unsupported cipher %v or kdf %v", ks.Crypto.Cipher, ks.Crypto.KDF))
+	}
+	params := ks.Crypto.KDFParams
+	if params.N <= 1 || params.N > maxScryptN || params.R != scryptR || params.P <= 0 || params.DKLen != scryptDKLen {
+		return nil, NewWalletError(InvalidKeystoreErr, fmt.Errorf("invalid kdf params %+v", params))
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	mac, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passPhrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	encryptKey, macKey := derivedKey[:common.AESKeySize], derivedKey[common.AESKeySize:]
+
+	metadata, err := ks.metadata()
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	if !hmac.Equal(mac, keystoreMAC(macKey, metadata)) {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+
+	aead, err := newKeystoreAEAD(encryptKey)
+	if err != nil {
+		return nil, NewWalletError(AESDecryptErr, err)
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, NewWalletError(InvalidKeystoreErr, errors.New("invalid nonce size"))
+	}
+	plaintext, err := aead.Open(nil, nonce, cipherText, metadata)
+	if err != nil {
+		return nil, NewWalletError(AESDecryptErr, err)
+	}
+	return plaintext, nil
+}
+
+func newKeystoreAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func keystoreMAC(macKey []byte, metadata []byte) []byte {
+	h := hmac.New(sha256.New, macKey)
+	h.Write(metadata)
+	return h.Sum(nil)
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for encryptKeystore and decryptKeystore functions
+*/
+
+func TestKeystoreEncryptDecrypt(t *testing.T) {
+	passPhrase := "123"
+	plaintext := []byte{1, 2, 3, 4}
+
+	data, err := encryptKeystore(passPhrase, plaintext, LightScryptN, LightScryptP)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, isLegacyKeystore(data))
+
+	ks := &keystore{}
+	err = json.Unmarshal(data, ks)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, KeystoreVersion, ks.Version)
+	assert.Equal(t, LightScryptN, ks.Crypto.KDFParams.N)
+	assert.Equal(t, LightScryptP, ks.Crypto.KDFParams.P)
+
+	plaintext2, err := decryptKeystore(passPhrase, data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, plaintext, plaintext2)
+}
+
+func TestKeystoreEncryptWithEmptyPlaintext(t *testing.T) {
+	_, err := encryptKeystore("123", []byte{}, LightScryptN, LightScryptP)
+	assert.Equal(t, ErrCodeMessage[InvalidPlaintextErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreDecryptWithWrongPassPhrase(t *testing.T) {
+	data, _ := encryptKeystore("123", []byte{1, 2, 3, 4}, LightScryptN, LightScryptP)
+
+	_, err := decryptKeystore("1234", data)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+}
+
+// flipHex changes the first digit of a hex string
+func flipHex(s string) string {
+	if s[0] == 'f' {
+		return "0" + s[1:]
+	}
+	return "f" + s[1:]
+}
+
+func TestKeystoreDecryptWithTamperedMetadata(t *testing.T) {
+	data, _ := encryptKeystore("123", []byte{1, 2, 3, 4}, LightScryptN, LightScryptP)
+
+	tamper := func(f func(ks *keystore)) []byte {
+		ks := &keystore{}
+		json.Unmarshal(data, ks)
+		f(ks)
+		tampered, _ := json.Marshal(ks)
+		return tampered
+	}
+
+	_, err := decryptKeystore("123", tamper(func(ks *keystore) { ks.Crypto.KDFParams.N = LightScryptN / 2 }))
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	_, err = decryptKeystore("123", tamper(func(ks *keystore) { ks.Crypto.KDFParams.N = maxScryptN * 2 }))
+	assert.Equal(t, ErrCodeMessage[InvalidKeystoreErr].code, err.(*WalletError).GetCode())
+
+	_, err = decryptKeystore("123", tamper(func(ks *keystore) { ks.Crypto.Nonce = flipHex(ks.Crypto.Nonce) }))
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	_, err = decryptKeystore("123", tamper(func(ks *keystore) { ks.Crypto.CipherText = flipHex(ks.Crypto.CipherText) }))
+	assert.Equal(t, ErrCodeMessage[AESDecryptErr].code, err.(*WalletError).GetCode())
+
+	_, err = decryptKeystore("123", tamper(func(ks *keystore) { ks.Version = KeystoreVersion + 1 }))
+	assert.Equal(t, ErrCodeMessage[UnsupportedKeystoreVersionErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreIsLegacy(t *testing.T) {
+	legacy, _ := encryptByPassPhrase("123", []byte{1, 2, 3, 4})
+
+	assert.Equal(t, true, isLegacyKeystore([]byte(legacy)))
+}
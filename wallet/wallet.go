@@ -8,13 +8,33 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"io/ioutil"
+	"os"
 )
 
 type AccountWallet struct {
-	Name       string
-	Key        KeyWallet
-	Child      []AccountWallet
-	IsImported bool
+	Name        string
+	Key         KeyWallet
+	Child       []AccountWallet
+	IsImported  bool
+	IsWatchOnly bool              // only the payment address and the readonly key are known
+	Path        string            `json:",omitempty"` // derivation path from the master key, empty for imported and legacy accounts
+	Label       string            `json:",omitempty"`
+	Metadata    map[string]string `json:",omitempty"`
+}
+
+// keySerializedData returns the serialized keys of account, the private key and the validator key are empty
+// for a watch-only account
+func (account AccountWallet) keySerializedData() KeySerializedData {
+	key := KeySerializedData{
+		PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
+		Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+		ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
+	}
+	if !account.IsWatchOnly {
+		key.PrivateKey = account.Key.Base58CheckSerialize(PriKeyType)
+		key.ValidatorKey = base58.Base58Check{}.Encode(common.HashB(common.HashB(account.Key.KeySet.PrivateKey)), common.ZeroByte)
+	}
+	return key
 }
 
 type Wallet struct {
@@ -33,6 +53,7 @@ type WalletConfig struct {
 	DataPath       string
 	IncrementalFee uint64
 	ShardID        *byte //default is nil -> create account for any shard
+	LightKDF       bool  // derive the keystore key with light scrypt params, only for tests and low memory devices
 }
 
 // GetConfig returns configuration of wallet
@@ -89,6 +110,37 @@ func (wallet *Wallet) Init(passPhrase string, numOfAccount uint32, name string)
 	return nil
 }
 
+// InitFromMnemonic restores a wallet from mnemonic and pass phrase, the wallet has no account
+// Accounts derived with account paths are found back with RecoverHDAccounts
+// If name is empty string or mnemonic is invalid, it returns error
+func (wallet *Wallet) InitFromMnemonic(mnemonic string, passPhrase string, name string) error {
+	if name == "" {
+		return NewWalletError(EmptyWalletNameErr, nil)
+	}
+
+	mnemonicGen := MnemonicGenerator{}
+	entropy, err := mnemonicGen.mnemonicToByteArray(mnemonic, true)
+	if err != nil {
+		return NewWalletError(MnemonicInvalidError, err)
+	}
+	wallet.Name = name
+	wallet.Entropy = entropy
+	wallet.Mnemonic = mnemonic
+	wallet.Seed = mnemonicGen.NewSeed(mnemonic, passPhrase)
+	wallet.PassPhrase = passPhrase
+
+	masterKey, err := NewMasterKey(wallet.Seed)
+	if err != nil {
+		return err
+	}
+	wallet.MasterAccount = AccountWallet{
+		Key:   *masterKey,
+		Child: make([]AccountWallet, 0),
+		Name:  "master",
+	}
+	return nil
+}
+
 // CreateNewAccount create new account with accountName
 // it returns that new account and returns errors if accountName is existed
 // If shardID is nil, new account will belong to any shards
@@ -169,7 +221,7 @@ func (wallet *Wallet) CreateNewAccount(accountName string, shardID *byte) (*Acco
 // ExportAccount returns a private key string of account at childIndex in wallet
 // It is base58 check serialized
 func (wallet *Wallet) ExportAccount(childIndex uint32) string {
-	if int(childIndex) >= len(wallet.MasterAccount.Child) {
+	if int(childIndex) >= len(wallet.MasterAccount.Child) || wallet.MasterAccount.Child[childIndex].IsWatchOnly {
 		return ""
 	}
 	return wallet.MasterAccount.Child[childIndex].Key.Base58CheckSerialize(PriKeyType)
@@ -235,7 +287,7 @@ func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, pa
 	return &account, nil
 }
 
-// Save saves encrypted wallet (using AES encryption scheme) in config data file of wallet
+// Save saves encrypted wallet (using versioned keystore format, see keystore.go) in config data file of wallet
 // It returns error if any
 func (wallet *Wallet) Save(password string) error {
 	if password == "" {
@@ -245,7 +297,10 @@ func (wallet *Wallet) Save(password string) error {
 	if password != wallet.PassPhrase {
 		return NewWalletError(WrongPassphraseErr, nil)
 	}
+	return wallet.save(password)
+}
 
+func (wallet *Wallet) save(password string) error {
 	// parse to byte[]
 	data, err := json.Marshal(*wallet)
 	if err != nil {
@@ -254,22 +309,82 @@ func (wallet *Wallet) Save(password string) error {
 	}
 
 	// encrypt data
-	cipherText, err := encryptByPassPhrase(password, data)
+	scryptN, scryptP := StandardScryptN, StandardScryptP
+	if wallet.config.LightKDF {
+		scryptN, scryptP = LightScryptN, LightScryptP
+	}
+	keystoreData, err := encryptKeystore(password, data, scryptN, scryptP)
 	if err != nil {
 		Logger.log.Error(err)
-		return NewWalletError(UnexpectedErr, err)
+		return err
 	}
 	// and
 	// save file
-	cipherTexInBytes := []byte(cipherText)
-	err = ioutil.WriteFile(wallet.config.DataPath, cipherTexInBytes, 0644)
+	err = writeFileAtomic(wallet.config.DataPath, keystoreData, 0600)
+	if err != nil {
+		return NewWalletError(WriteFileErr, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file, syncs it to disk and renames it to fileName,
+// so a crash or a full disk while writing never leaves fileName partially written
+func writeFileAtomic(fileName string, data []byte, perm os.FileMode) error {
+	tmpFileName := fileName + ".tmp"
+	f, err := os.OpenFile(tmpFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFileName, fileName)
+	}
+	if err != nil {
+		os.Remove(tmpFileName)
+		return err
+	}
+	return nil
+}
+
+// migrateLegacyFile re-encrypts the legacy wallet file in the current keystore format,
+// the legacy file is kept as a .bak file until the new file is read back and decrypted
+func (wallet *Wallet) migrateLegacyFile(password string, legacyData []byte) error {
+	backupPath := wallet.config.DataPath + ".bak"
+	err := writeFileAtomic(backupPath, legacyData, 0600)
 	if err != nil {
 		return NewWalletError(WriteFileErr, err)
 	}
+	err = wallet.save(password)
+	if err != nil {
+		return err
+	}
+	keystoreData, err := ioutil.ReadFile(wallet.config.DataPath)
+	if err != nil {
+		return NewWalletError(ReadFileErr, err)
+	}
+	_, err = decryptKeystore(password, keystoreData)
+	if err != nil {
+		// put the legacy file back, it is still kept as .bak if that fails
+		if err2 := os.Rename(backupPath, wallet.config.DataPath); err2 != nil {
+			Logger.log.Errorf("Restore wallet file %v from %v failed with err: %v", wallet.config.DataPath, backupPath, err2)
+		}
+		return err
+	}
+	err = os.Remove(backupPath)
+	if err != nil {
+		Logger.log.Errorf("Remove legacy wallet file %v failed with err: %v", backupPath, err)
+	}
 	return nil
 }
 
 // LoadWallet loads encrypted wallet from file and then decrypts it to wallet struct
+// A wallet file in the legacy format (pbkdf2, no version) is re-encrypted in the current keystore format
 // It returns error if any
 func (wallet *Wallet) LoadWallet(password string) error {
 	// read file and decrypt
@@ -277,9 +392,18 @@ func (wallet *Wallet) LoadWallet(password string) error {
 	if err != nil {
 		return NewWalletError(ReadFileErr, err)
 	}
-	bufBytes, err := decryptByPassPhrase(password, string(bytesData))
-	if err != nil {
-		return NewWalletError(AESDecryptErr, err)
+	legacy := isLegacyKeystore(bytesData)
+	var bufBytes []byte
+	if legacy {
+		bufBytes, err = decryptByPassPhrase(password, string(bytesData))
+		if err != nil {
+			return NewWalletError(AESDecryptErr, err)
+		}
+	} else {
+		bufBytes, err = decryptKeystore(password, bytesData)
+		if err != nil {
+			return err
+		}
 	}
 
 	// read to struct
@@ -287,6 +411,29 @@ func (wallet *Wallet) LoadWallet(password string) error {
 	if err != nil {
 		return NewWalletError(JsonUnmarshalErr, err)
 	}
+
+	if legacy {
+		if err := wallet.migrateLegacyFile(password, bytesData); err != nil {
+			Logger.log.Errorf("Migrate wallet file %v to keystore version %v failed with err: %v", wallet.config.DataPath, KeystoreVersion, err)
+		} else {
+			Logger.log.Infof("Migrated wallet file %v to keystore version %v", wallet.config.DataPath, KeystoreVersion)
+		}
+	}
+	return nil
+}
+
+// ChangePassPhrase re-encrypts the wallet file with newPassPhrase
+// It returns WrongPassphraseErr if oldPassPhrase is not the pass phrase of the wallet
+// The seed of the wallet is not changed so accounts stay the same
+func (wallet *Wallet) ChangePassPhrase(oldPassPhrase string, newPassPhrase string) error {
+	if oldPassPhrase != wallet.PassPhrase {
+		return NewWalletError(WrongPassphraseErr, nil)
+	}
+	wallet.PassPhrase = newPassPhrase
+	if err := wallet.save(newPassPhrase); err != nil {
+		wallet.PassPhrase = oldPassPhrase
+		return err
+	}
 	return nil
 }
 
@@ -297,7 +444,7 @@ func (wallet *Wallet) LoadWallet(password string) error {
 func (wallet *Wallet) DumpPrivateKey(paymentAddrSerialized string) KeySerializedData {
 	for _, account := range wallet.MasterAccount.Child {
 		address := account.Key.Base58CheckSerialize(PaymentAddressType)
-		if address == paymentAddrSerialized {
+		if address == paymentAddrSerialized && !account.IsWatchOnly {
 			key := KeySerializedData{
 				PrivateKey: account.Key.Base58CheckSerialize(PriKeyType),
 			}
@@ -314,25 +461,11 @@ func (wallet *Wallet) DumpPrivateKey(paymentAddrSerialized string) KeySerialized
 func (wallet *Wallet) GetAddressByAccName(accountName string, shardID *byte) KeySerializedData {
 	for _, account := range wallet.MasterAccount.Child {
 		if account.Name == accountName {
-			key := KeySerializedData{
-				PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
-				Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
-				ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
-				PrivateKey:     account.Key.Base58CheckSerialize(PriKeyType),
-				ValidatorKey:   base58.Base58Check{}.Encode(common.HashB(common.HashB(account.Key.KeySet.PrivateKey)), common.ZeroByte),
-			}
-			return key
+			return account.keySerializedData()
 		}
 	}
 	newAccount, _ := wallet.CreateNewAccount(accountName, shardID)
-	key := KeySerializedData{
-		PaymentAddress: newAccount.Key.Base58CheckSerialize(PaymentAddressType),
-		Pubkey:         hex.EncodeToString(newAccount.Key.KeySet.PaymentAddress.Pk),
-		ReadonlyKey:    newAccount.Key.Base58CheckSerialize(ReadonlyKeyType),
-		PrivateKey:     newAccount.Key.Base58CheckSerialize(PriKeyType),
-		ValidatorKey:   base58.Base58Check{}.Encode(common.HashB(common.HashB(newAccount.Key.KeySet.PrivateKey)), common.ZeroByte),
-	}
-	return key
+	return newAccount.keySerializedData()
 }
 
 // GetAddressesByAccName receives accountName
@@ -341,12 +474,8 @@ func (wallet *Wallet) GetAddressesByAccName(accountName string) []KeySerializedD
 	result := make([]KeySerializedData, 0)
 	for _, account := range wallet.MasterAccount.Child {
 		if account.Name == accountName {
-			item := KeySerializedData{
-				PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
-				Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
-				ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
-				ValidatorKey:   base58.Base58Check{}.Encode(common.HashB(common.HashB(account.Key.KeySet.PrivateKey)), common.ZeroByte),
-			}
+			item := account.keySerializedData()
+			item.PrivateKey = ""
 			result = append(result, item)
 		}
 	}
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
@@ -29,6 +30,7 @@ var _ = func() (_ struct{}) {
 		DataFile:       dataFile,
 		DataPath:       filepath.Join(dataDir, dataFile),
 		IncrementalFee: 0, // 0 mili PRV
+		LightKDF:       true,
 	}
 
 	wallet.SetConfig(walletConf)
@@ -440,6 +442,24 @@ func TestWalletSaveWithWrongConfig(t *testing.T) {
 	assert.Equal(t, ErrCodeMessage[WriteFileErr].code, err.(*WalletError).GetCode())
 }
 
+func TestWalletSaveKeepsFileOnWriteFailure(t *testing.T) {
+	passPhrase := "123"
+	wallet.Init(passPhrase, 0, "Wallet")
+	assert.Equal(t, nil, wallet.Save(passPhrase))
+	fileData, _ := ioutil.ReadFile(wallet.config.DataPath)
+
+	// the temporary file can not be created
+	tmpPath := wallet.config.DataPath + ".tmp"
+	assert.Equal(t, nil, os.Mkdir(tmpPath, 0700))
+	defer os.Remove(tmpPath)
+	wallet.Init(passPhrase, 2, "Wallet")
+	err := wallet.Save(passPhrase)
+
+	assert.Equal(t, ErrCodeMessage[WriteFileErr].code, err.(*WalletError).GetCode())
+	fileData2, _ := ioutil.ReadFile(wallet.config.DataPath)
+	assert.Equal(t, fileData, fileData2)
+}
+
 /*
 	Unit test for LoadWallet function
 */
@@ -460,6 +480,36 @@ func TestWalletLoadWallet(t *testing.T) {
 	assert.Equal(t, wallet, wallet2)
 }
 
+func TestWalletLoadWalletMigrateLegacy(t *testing.T) {
+	passPhrase := "123"
+	numAcc := 2
+	name := "Wallet"
+	wallet.Init(passPhrase, uint32(numAcc), name)
+	data, _ := json.Marshal(wallet)
+	legacy, _ := encryptByPassPhrase(passPhrase, data)
+	ioutil.WriteFile(wallet.config.DataPath, []byte(legacy), 0600)
+
+	wallet2 := new(Wallet)
+	wallet2.SetConfig(wallet.config)
+	err := wallet2.LoadWallet(passPhrase)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, wallet, wallet2)
+
+	fileData, _ := ioutil.ReadFile(wallet.config.DataPath)
+	assert.Equal(t, false, isLegacyKeystore(fileData))
+	// the legacy file is removed once the new file decrypts
+	_, err = os.Stat(wallet.config.DataPath + ".bak")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	wallet3 := new(Wallet)
+	wallet3.SetConfig(wallet.config)
+	err = wallet3.LoadWallet(passPhrase)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, wallet, wallet3)
+}
+
 func TestWalletLoadWalletWithUnmatchedPassPhrase(t *testing.T) {
 	passPhrase := "123"
 	passPhrase2 := "1234"
@@ -472,7 +522,7 @@ func TestWalletLoadWalletWithUnmatchedPassPhrase(t *testing.T) {
 	wallet2.SetConfig(wallet.config)
 	err := wallet2.LoadWallet(passPhrase2)
 
-	assert.Equal(t, ErrCodeMessage[JsonUnmarshalErr].code, err.(*WalletError).GetCode())
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
 }
 
 func TestWalletLoadWalletWithEmptyPassPhrase(t *testing.T) {
@@ -487,7 +537,7 @@ func TestWalletLoadWalletWithEmptyPassPhrase(t *testing.T) {
 	wallet2.SetConfig(wallet.config)
 	err := wallet2.LoadWallet(passPhrase2)
 
-	assert.Equal(t, ErrCodeMessage[JsonUnmarshalErr].code, err.(*WalletError).GetCode())
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
 }
 
 func TestWalletLoadWalletWithWrongConfig(t *testing.T) {
@@ -773,4 +823,42 @@ func TestWalletContainPubKeyWithNotExistedPubKey(t *testing.T) {
 	randPubKey := privacy.RandBytes(common.PublicKeySize)
 	res := wallet.ContainPublicKey(randPubKey)
 	assert.Equal(t, false, res)
-}
\ No newline at end of file
+}
+
+/*
+	Unit test for ChangePassPhrase function
+*/
+
+func TestWalletChangePassPhrase(t *testing.T) {
+	passPhrase := "123"
+	passPhrase2 := "1234"
+	numAcc := 2
+	name := "Wallet"
+	wallet.Init(passPhrase, uint32(numAcc), name)
+	wallet.Save(passPhrase)
+	seed := wallet.Seed
+
+	err := wallet.ChangePassPhrase(passPhrase, passPhrase2)
+	assert.Equal(t, nil, err)
+
+	wallet2 := new(Wallet)
+	wallet2.SetConfig(wallet.config)
+	err = wallet2.LoadWallet(passPhrase)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+
+	err = wallet2.LoadWallet(passPhrase2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, seed, wallet2.Seed)
+	assert.Equal(t, passPhrase2, wallet2.PassPhrase)
+}
+
+func TestWalletChangePassPhraseWithUnmatchedPassPhrase(t *testing.T) {
+	passPhrase := "123"
+	numAcc := 2
+	name := "Wallet"
+	wallet.Init(passPhrase, uint32(numAcc), name)
+
+	err := wallet.ChangePassPhrase("1234", "12345")
+	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
+	assert.Equal(t, passPhrase, wallet.PassPhrase)
+}